@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// loadActiveSession returns the active session and the day it is stored under. Like the
+// UI, an active session left over from the previous day counts as current.
+func loadActiveSession(store storage.Store, now time.Time) (*models.DailySessions, *models.Session, error) {
+	today := store.DayOf(now)
+	dailySessions, err := store.LoadDailySessions(today)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load daily sessions: %w", err)
+	}
+	dailySessions.Date = today
+	if active := findActiveSession(dailySessions); active != nil {
+		return dailySessions, active, nil
+	}
+
+	// Ignore errors as previous day may not exist
+	yesterday := today.AddDate(0, 0, -1)
+	if previousSessions, err := store.LoadDailySessions(yesterday); err == nil {
+		previousSessions.Date = yesterday
+		if active := findActiveSession(previousSessions); active != nil {
+			return previousSessions, active, nil
+		}
+	}
+	return dailySessions, nil, nil
+}
+
+// saveSessionEvent records the event in the journal, saves the day, runs the event's hook
+// script and notifies webhook subscribers, as the UI does for the same action
+func saveSessionEvent(store storage.Store, day *models.DailySessions, eventType models.JournalEventType, webhookEvent string, session *models.Session, entry *models.TimeEntry) error {
+	journalErr := store.AppendJournal(models.NewJournalEvent(eventType, day.Date, session, entry))
+	if err := store.SaveDailySessions(day); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	if journalErr != nil {
+		return fmt.Errorf("saved, but failed to write journal: %w", journalErr)
+	}
+
+	payload := integrations.NewWebhookPayload(webhookEvent, session, entry)
+	if err := integrations.NewHookRunner(store.GetDataDir()).Run(payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Hook failed: %v\n", err)
+	}
+	if cfg := store.GetConfig(); cfg != nil && len(cfg.Webhooks) > 0 {
+		notifier := integrations.NewWebhookNotifier(cfg)
+		if notifier.HasSubscribers(webhookEvent) {
+			if err := notifier.Notify(payload); err != nil {
+				fmt.Fprintf(os.Stderr, "Webhook failed: %v\n", err)
+			}
+		}
+	}
+	return nil
+}
+
+// currentSubSession returns the sub-session new entries of the session go to, nil for
+// sessions from before sub-sessions
+func currentSubSession(session *models.Session) *models.SubSession {
+	if len(session.SubSessions) == 0 {
+		return nil
+	}
+	return session.SubSessions[len(session.SubSessions)-1]
+}
+
+// addInterruptionEntry appends an interruption or return entry to the current
+// sub-session and, for backward compatibility, to the session
+func addInterruptionEntry(session *models.Session, entry *models.TimeEntry) {
+	if subSession := currentSubSession(session); subSession != nil {
+		subSession.Interruptions = append(subSession.Interruptions, entry)
+	}
+	session.Interruptions = append(session.Interruptions, entry)
+}
+
+// endSession ends the active session
+func endSession(store storage.Store, now time.Time) (*models.Session, error) {
+	day, active, err := loadActiveSession(store, now)
+	if err != nil {
+		return nil, err
+	}
+	if active == nil {
+		return nil, fmt.Errorf("no active session to end")
+	}
+	if len(active.Interruptions)%2 != 0 {
+		return nil, fmt.Errorf("cannot end the session while interrupted, return from the interruption first")
+	}
+
+	entry := active.EndAt(now)
+	if err := saveSessionEvent(store, day, models.JournalEnd, integrations.EventSessionEnd, active, entry); err != nil {
+		return nil, err
+	}
+	return active, nil
+}
+
+// resolveInterruptionTag checks the tag is built in or a configured custom tag, defaulting
+// to "other"
+func resolveInterruptionTag(store storage.Store, tag string) (models.InterruptionTag, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return models.TagOther, nil
+	}
+	if models.IsBuiltinTag(models.InterruptionTag(tag)) {
+		return models.InterruptionTag(tag), nil
+	}
+
+	known := []string{}
+	for _, builtin := range models.GetInterruptionTags() {
+		known = append(known, string(builtin))
+	}
+	if cfg := store.GetConfig(); cfg != nil {
+		for _, custom := range cfg.ActiveInterruptionTags() {
+			if custom == tag {
+				return models.InterruptionTag(tag), nil
+			}
+			known = append(known, custom)
+		}
+	}
+	return "", fmt.Errorf("unknown interruption tag %q, use one of: %s", tag, strings.Join(known, ", "))
+}
+
+// interruptSession interrupts the active session. With a duration the interruption is
+// already over: it started that long ago and work resumed now.
+func interruptSession(store storage.Store, now time.Time, tag models.InterruptionTag, description string, duration time.Duration) (*models.Session, error) {
+	day, active, err := loadActiveSession(store, now)
+	if err != nil {
+		return nil, err
+	}
+	if active == nil {
+		return nil, fmt.Errorf("no active session to interrupt")
+	}
+	if len(active.Interruptions)%2 != 0 {
+		return nil, fmt.Errorf("already interrupted, return from the current interruption first")
+	}
+
+	start := now.Add(-duration)
+	if duration > 0 {
+		// A past interruption may not reach back before the latest entry of the session
+		latest := active.Start.StartTime
+		if subSession := currentSubSession(active); subSession != nil && subSession.Start != nil {
+			latest = subSession.Start.StartTime
+		}
+		if len(active.Interruptions) > 0 {
+			latest = active.Interruptions[len(active.Interruptions)-1].StartTime
+		}
+		if start.Before(latest) {
+			return nil, fmt.Errorf("an interruption of %s overlaps earlier activity of the session at %s", formatDuration(duration), latest.Format("15:04"))
+		}
+	}
+
+	entry := models.NewInterruptionEntry(description, tag)
+	entry.StartTime = start
+	addInterruptionEntry(active, entry)
+	if err := saveSessionEvent(store, day, models.JournalInterrupt, integrations.EventInterrupt, active, entry); err != nil {
+		return nil, err
+	}
+	if description != "" {
+		// Ignore errors as the tag model only improves suggestions
+		store.LearnTag(description, tag)
+	}
+	if duration > 0 {
+		return returnFromInterruption(store, now)
+	}
+	return active, nil
+}
+
+// returnFromInterruption marks the return to work from the ongoing interruption
+func returnFromInterruption(store storage.Store, now time.Time) (*models.Session, error) {
+	day, active, err := loadActiveSession(store, now)
+	if err != nil {
+		return nil, err
+	}
+	if active == nil || len(active.Interruptions)%2 == 0 {
+		return nil, fmt.Errorf("not currently interrupted")
+	}
+
+	entry := models.NewTimeEntry(models.EntryTypeReturn, "")
+	entry.StartTime = now
+	addInterruptionEntry(active, entry)
+	if err := saveSessionEvent(store, day, models.JournalReturn, integrations.EventReturn, active, entry); err != nil {
+		return nil, err
+	}
+	return active, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// runAttach attaches files as evidence to a session, by default the active or most
+// recent session of today
+func runAttach(store *storage.Storage, args []string) {
+	attachFlags := flag.NewFlagSet("attach", flag.ExitOnError)
+	dateText := attachFlags.String("date", "", "Day of the session (YYYY-MM-DD), defaults to today")
+	sessionID := attachFlags.String("session", "", "ID of the session, defaults to the active or latest session of the day")
+	note := attachFlags.String("note", "", "What the files prove")
+	attachFlags.Parse(args)
+
+	if attachFlags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: interruption-tracker attach [--date=YYYY-MM-DD] [--session=ID] [--note=text] FILE...")
+		os.Exit(2)
+	}
+
+	now := time.Now()
+	day := store.DayOf(now)
+	if *dateText != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *dateText, time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid date %q, use YYYY-MM-DD\n", *dateText)
+			os.Exit(2)
+		}
+		day = parsed
+	}
+
+	session, err := findAttachSession(store, day, *sessionID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error attaching files: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, path := range attachFlags.Args() {
+		attachment, err := store.AttachFile(day, session.ID, path, *note, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error attaching %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Attached %s to %q\n", attachment.Name(), session.Start.Description)
+	}
+}
+
+// findAttachSession returns the session with the given ID on day, or without an ID the
+// active session, falling back to the one started last
+func findAttachSession(store *storage.Storage, day time.Time, sessionID string) (*models.Session, error) {
+	dailySessions, err := store.LoadDailySessions(day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily sessions: %w", err)
+	}
+
+	var latest *models.Session
+	for _, session := range dailySessions.Sessions {
+		if sessionID != "" {
+			if session.ID == sessionID {
+				return session, nil
+			}
+			continue
+		}
+		if session.End == nil {
+			return session, nil
+		}
+		if latest == nil || session.Start.StartTime.After(latest.Start.StartTime) {
+			latest = session
+		}
+	}
+
+	if latest == nil {
+		if sessionID != "" {
+			return nil, fmt.Errorf("session %s not found on %s", sessionID, day.Format("2006-01-02"))
+		}
+		return nil, fmt.Errorf("no sessions on %s", day.Format("2006-01-02"))
+	}
+	return latest, nil
+}
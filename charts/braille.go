@@ -0,0 +1,87 @@
+package charts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// brailleDots maps a dot position (column, row) inside a braille cell to its bit
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// BrailleRenderer plots values as a line using braille characters, two values per
+// character horizontally and four dots vertically
+type BrailleRenderer struct {
+	Height int // Plot height in characters, defaults to 4
+}
+
+// Render writes the plot followed by the first and last labels
+func (r *BrailleRenderer) Render(w io.Writer, data *Data) error {
+	if err := data.validate(); err != nil {
+		return err
+	}
+	if len(data.Values) == 0 {
+		_, err := fmt.Fprintln(w, "No data")
+		return err
+	}
+
+	height := r.Height
+	if height <= 0 {
+		height = 4
+	}
+	dotRows := height * 4
+	width := (len(data.Values) + 1) / 2
+
+	cells := make([][]rune, height)
+	for row := range cells {
+		cells[row] = make([]rune, width)
+	}
+
+	// Plot every value as a dot, filling the column between neighbours so the line is continuous
+	maxValue := data.maxValue()
+	level := func(value float64) int {
+		if maxValue <= 0 {
+			return 0
+		}
+		return int(value / maxValue * float64(dotRows-1))
+	}
+	for i, value := range data.Values {
+		from, to := level(value), level(value)
+		if i > 0 {
+			previous := level(data.Values[i-1])
+			if previous < from {
+				from = previous + 1
+			} else if previous > to {
+				to = previous - 1
+			}
+		}
+		for dot := from; dot <= to; dot++ {
+			row := dotRows - 1 - dot
+			cells[row/4][i/2] |= brailleDots[i%2][row%4]
+		}
+	}
+
+	var sb strings.Builder
+	for row, line := range cells {
+		// Label the top and bottom rows with the scale
+		switch row {
+		case 0:
+			fmt.Fprintf(&sb, "%6.1f ┤", maxValue)
+		case height - 1:
+			fmt.Fprintf(&sb, "%6.1f ┤", 0.0)
+		default:
+			sb.WriteString("       │")
+		}
+		for _, cell := range line {
+			sb.WriteRune(0x2800 + cell)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "        %s … %s\n", data.Labels[0], data.Labels[len(data.Labels)-1])
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,418 @@
+// Package charts turns aggregated statistics into chart data and renders it with
+// interchangeable backends (terminal text, braille plots, inline images and SVG).
+package charts
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// DailyChartDays is how many of the most recent days the daily chart shows
+const DailyChartDays = 10
+
+// Chart types
+const (
+	ChartTypeBar     = "bar"
+	ChartTypeLine    = "line"
+	ChartTypeHeatmap = "heatmap"
+)
+
+// Backend names accepted by NewRenderer
+const (
+	BackendText    = "text"
+	BackendBraille = "braille"
+	BackendKitty   = "kitty"
+	BackendSVG     = "svg"
+)
+
+// Data contains data for rendering different types of charts
+type Data struct {
+	Title       string
+	Description string
+	ChartType   string
+	Labels      []string
+	Values      []float64
+	ColorFunc   func(value float64) string // Color tag (e.g. "[green]") for a value, optional
+
+	// Heatmap charts use Labels as columns and hold one row of Grid values per RowLabels entry
+	RowLabels []string
+	Grid      [][]float64
+}
+
+// Renderer draws chart data in a backend specific format
+type Renderer interface {
+	Render(w io.Writer, data *Data) error
+}
+
+// NewRenderer returns the renderer for a backend name, for output outside the TUI
+func NewRenderer(backend string) (Renderer, error) {
+	switch backend {
+	case BackendText, "":
+		return &TextRenderer{Plain: true}, nil
+	case BackendBraille:
+		return &BrailleRenderer{}, nil
+	case BackendKitty:
+		return &KittyRenderer{}, nil
+	case BackendSVG:
+		return &SVGRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported chart backend: %s", backend)
+	}
+}
+
+// validate checks that every label has a value
+func (d *Data) validate() error {
+	if d.ChartType == ChartTypeHeatmap {
+		return fmt.Errorf("heatmap data can only be drawn by the heatmap renderer")
+	}
+	if len(d.Labels) != len(d.Values) {
+		return fmt.Errorf("data labels and values must have the same length")
+	}
+	return nil
+}
+
+// validateGrid checks that a heatmap has a row of values for every row label and a
+// value for every column label
+func (d *Data) validateGrid() error {
+	if len(d.RowLabels) != len(d.Grid) {
+		return fmt.Errorf("heatmap row labels and rows must have the same length")
+	}
+	for _, row := range d.Grid {
+		if len(row) != len(d.Labels) {
+			return fmt.Errorf("heatmap rows must have a value for every column label")
+		}
+	}
+	return nil
+}
+
+// maxGridValue returns the largest heatmap value, or 0 for empty data
+func (d *Data) maxGridValue() float64 {
+	var max float64
+	for _, row := range d.Grid {
+		for _, value := range row {
+			if value > max {
+				max = value
+			}
+		}
+	}
+	return max
+}
+
+// maxValue returns the largest value, or 0 for empty data
+func (d *Data) maxValue() float64 {
+	var max float64
+	for _, value := range d.Values {
+		if value > max {
+			max = value
+		}
+	}
+	return max
+}
+
+// colorTag returns the color tag for a value, blue when the chart has no color function
+func (d *Data) colorTag(value float64) string {
+	if d.ColorFunc == nil {
+		return "[blue]"
+	}
+	return d.ColorFunc(value)
+}
+
+// tagColors maps the color tags used by charts to RGB hex colors for image backends
+var tagColors = map[string]string{
+	"red":    "#d62728",
+	"orange": "#ff7f0e",
+	"yellow": "#e5c100",
+	"lime":   "#7fd13b",
+	"green":  "#2ca02c",
+	"blue":   "#1f77b4",
+}
+
+// hexColor returns the RGB hex color for a value
+func (d *Data) hexColor(value float64) string {
+	name := strings.Trim(d.colorTag(value), "[]")
+	if color, ok := tagColors[name]; ok {
+		return color
+	}
+	return tagColors["blue"]
+}
+
+// InterruptionsByType builds a bar chart of interruption counts per tag
+func InterruptionsByType(stats *models.DetailedStats) *Data {
+	tags := make([]string, 0, len(stats.InterruptionsByTag))
+	for tag := range stats.InterruptionsByTag {
+		tags = append(tags, string(tag))
+	}
+	sort.Strings(tags)
+
+	values := make([]float64, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, float64(stats.InterruptionsByTag[models.InterruptionTag(tag)]))
+	}
+
+	return &Data{
+		Title:       "Interruptions by Type",
+		Description: "Number of interruptions by category",
+		ChartType:   ChartTypeBar,
+		Labels:      tags,
+		Values:      values,
+	}
+}
+
+// ProductivityByHour builds a bar chart of focused hours per hour of day
+func ProductivityByHour(stats *models.DetailedStats) *Data {
+	hours := make([]int, 0, len(stats.HourlyProductivity))
+	for hour := range stats.HourlyProductivity {
+		hours = append(hours, hour)
+	}
+	sort.Ints(hours)
+
+	var labels []string
+	var values []float64
+	for _, hour := range hours {
+		labels = append(labels, fmt.Sprintf("%d:00", hour))
+		values = append(values, float64(stats.HourlyProductivity[hour])/float64(time.Hour))
+	}
+
+	return &Data{
+		Title:       "Productivity by Hour",
+		Description: "Hours of focused work by time of day",
+		ChartType:   ChartTypeBar,
+		Labels:      labels,
+		Values:      values,
+	}
+}
+
+// InterruptionProbabilityByHour builds a bar chart of the chance, in percent, of being
+// interrupted during an hour of work by hour of day, for hours with enough work to tell
+func InterruptionProbabilityByHour(stats *models.DetailedStats) *Data {
+	rates := stats.HourlyInterruptionRate()
+	hours := make([]int, 0, len(rates))
+	for hour := range rates {
+		hours = append(hours, hour)
+	}
+	sort.Ints(hours)
+
+	var labels []string
+	var values []float64
+	for _, hour := range hours {
+		labels = append(labels, fmt.Sprintf("%d:00", hour))
+		values = append(values, models.InterruptionProbability(rates[hour])*100)
+	}
+
+	return &Data{
+		Title:       "Interruption Probability by Hour",
+		Description: "Percent chance of an interruption within an hour of work by time of day",
+		ChartType:   ChartTypeBar,
+		Labels:      labels,
+		Values:      values,
+	}
+}
+
+// ProductivityByWeekday builds a bar chart of average focused hours per tracked day of
+// each weekday, Monday first
+func ProductivityByWeekday(stats *models.DetailedStats) *Data {
+	// Count the days with tracked work for each weekday to average over them
+	days := make(map[time.Weekday]int)
+	for dateStr, duration := range stats.DailyWorkDurations {
+		if t, err := time.Parse("2006-01-02", dateStr); err == nil && duration > 0 {
+			days[t.Weekday()]++
+		}
+	}
+
+	var labels []string
+	var values []float64
+	for i := 1; i <= 7; i++ {
+		weekday := time.Weekday(i % 7)
+		duration, ok := stats.WeekdayProductivity[weekday]
+		if !ok {
+			continue
+		}
+		count := days[weekday]
+		if count == 0 {
+			count = 1
+		}
+		labels = append(labels, weekday.String()[:3])
+		values = append(values, float64(duration)/float64(time.Hour)/float64(count))
+	}
+
+	return &Data{
+		Title:       "Productivity by Weekday",
+		Description: "Average hours of focused work per tracked day of the week",
+		ChartType:   ChartTypeBar,
+		Labels:      labels,
+		Values:      values,
+	}
+}
+
+// DailyProductivity builds a bar chart of focused hours for the last days with work
+func DailyProductivity(stats *models.DetailedStats, days int) *Data {
+	dates := make([]string, 0, len(stats.DailyWorkDurations))
+	for dateStr := range stats.DailyWorkDurations {
+		dates = append(dates, dateStr)
+	}
+	sort.Strings(dates)
+
+	if days > 0 && len(dates) > days {
+		dates = dates[len(dates)-days:]
+	}
+
+	var labels []string
+	var values []float64
+	for _, dateStr := range dates {
+		// Format date as day-month only
+		if t, err := time.Parse("2006-01-02", dateStr); err == nil {
+			labels = append(labels, t.Format("02-Jan"))
+		} else {
+			labels = append(labels, dateStr)
+		}
+		values = append(values, float64(stats.DailyWorkDurations[dateStr])/float64(time.Hour))
+	}
+
+	return &Data{
+		Title:       "Daily Productivity",
+		Description: "Hours of focused work by day",
+		ChartType:   ChartTypeBar,
+		Labels:      labels,
+		Values:      values,
+	}
+}
+
+// LongestFocusTrend builds a line chart of the longest block of work without an
+// interruption, in minutes, for the last days with work
+func LongestFocusTrend(stats *models.DetailedStats, days int) *Data {
+	dates := make([]string, 0, len(stats.DailyLongestFocus))
+	for dateStr, block := range stats.DailyLongestFocus {
+		if block > 0 {
+			dates = append(dates, dateStr)
+		}
+	}
+	sort.Strings(dates)
+
+	if days > 0 && len(dates) > days {
+		dates = dates[len(dates)-days:]
+	}
+
+	var labels []string
+	var values []float64
+	for _, dateStr := range dates {
+		if t, err := time.Parse("2006-01-02", dateStr); err == nil {
+			labels = append(labels, t.Format("02-Jan"))
+		} else {
+			labels = append(labels, dateStr)
+		}
+		values = append(values, stats.DailyLongestFocus[dateStr].Minutes())
+	}
+
+	return &Data{
+		Title:       "Longest Focus Block",
+		Description: "Minutes of the longest uninterrupted stretch of work by day",
+		ChartType:   ChartTypeLine,
+		Labels:      labels,
+		Values:      values,
+	}
+}
+
+// DeflectionRate builds a line chart of the share of interruptions deferred instead of
+// accepted, in percent, for the last days with interruption attempts
+func DeflectionRate(stats *models.DetailedStats, days int) *Data {
+	dates := make([]string, 0, len(stats.DailyDeflections))
+	for dateStr := range stats.DailyDeflections {
+		dates = append(dates, dateStr)
+	}
+	sort.Strings(dates)
+
+	if days > 0 && len(dates) > days {
+		dates = dates[len(dates)-days:]
+	}
+
+	var labels []string
+	var values []float64
+	for _, dateStr := range dates {
+		if t, err := time.Parse("2006-01-02", dateStr); err == nil {
+			labels = append(labels, t.Format("02-Jan"))
+		} else {
+			labels = append(labels, dateStr)
+		}
+		values = append(values, stats.DailyDeflections[dateStr].Rate()*100)
+	}
+
+	return &Data{
+		Title:       "Deflection Rate",
+		Description: "Percent of interruptions deferred instead of accepted, by day",
+		ChartType:   ChartTypeLine,
+		Labels:      labels,
+		Values:      values,
+	}
+}
+
+// ProductivityHeatmap builds an hours × days heatmap of focused hours for the last days
+// with work, covering the hours between the earliest and latest hour worked
+func ProductivityHeatmap(stats *models.DetailedStats, days int) *Data {
+	data := &Data{
+		Title:       "Productivity Heatmap",
+		Description: "Hours of focused work by day and time of day",
+		ChartType:   ChartTypeHeatmap,
+	}
+
+	dates := make([]string, 0, len(stats.DailyHourlyProductivity))
+	for dateStr := range stats.DailyHourlyProductivity {
+		dates = append(dates, dateStr)
+	}
+	sort.Strings(dates)
+	if days > 0 && len(dates) > days {
+		dates = dates[len(dates)-days:]
+	}
+
+	firstHour, lastHour := 24, -1
+	for _, dateStr := range dates {
+		for hour := range stats.DailyHourlyProductivity[dateStr] {
+			if hour < firstHour {
+				firstHour = hour
+			}
+			if hour > lastHour {
+				lastHour = hour
+			}
+		}
+	}
+
+	for hour := firstHour; hour <= lastHour; hour++ {
+		data.Labels = append(data.Labels, fmt.Sprintf("%02d", hour))
+	}
+	for _, dateStr := range dates {
+		// Format date as weekday and day-month
+		if t, err := time.Parse("2006-01-02", dateStr); err == nil {
+			data.RowLabels = append(data.RowLabels, t.Format("Mon 02-Jan"))
+		} else {
+			data.RowLabels = append(data.RowLabels, dateStr)
+		}
+
+		row := make([]float64, 0, len(data.Labels))
+		for hour := firstHour; hour <= lastHour; hour++ {
+			row = append(row, float64(stats.DailyHourlyProductivity[dateStr][hour])/float64(time.Hour))
+		}
+		data.Grid = append(data.Grid, row)
+	}
+
+	return data
+}
+
+// StandardNames lists the charts built by Standard in display order
+var StandardNames = []string{"hourly", "interruption-probability", "weekday", "interruptions", "deflection", "daily", "longest-focus"}
+
+// Standard builds the charts shown on the visualization pages, keyed by StandardNames
+func Standard(stats *models.DetailedStats) map[string]*Data {
+	return map[string]*Data{
+		"hourly":                   ProductivityByHour(stats),
+		"interruption-probability": InterruptionProbabilityByHour(stats),
+		"weekday":                  ProductivityByWeekday(stats),
+		"interruptions":            InterruptionsByType(stats),
+		"deflection":               DeflectionRate(stats, DailyChartDays),
+		"daily":                    DailyProductivity(stats, DailyChartDays),
+		"longest-focus":            LongestFocusTrend(stats, DailyChartDays),
+	}
+}
@@ -0,0 +1,147 @@
+package charts
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testStats returns detailed stats with a few hours, tags and days of work
+func testStats() *models.DetailedStats {
+	return &models.DetailedStats{
+		HourlyProductivity:  map[int]time.Duration{14: time.Hour, 9: 2 * time.Hour},
+		HourlyWorkTime:      map[int]time.Duration{14: 30 * time.Minute, 9: 2 * time.Hour, 16: 10 * time.Minute},
+		HourlyInterruptions: map[int]int{14: 1, 9: 1, 16: 3},
+		DailyLongestFocus: map[string]time.Duration{
+			"2025-03-04": 45 * time.Minute,
+			"2025-03-03": 90 * time.Minute,
+			"2025-03-05": 0,
+		},
+		WeekdayProductivity: map[time.Weekday]time.Duration{
+			time.Tuesday: 90 * time.Minute,
+			time.Monday:  3 * time.Hour,
+			time.Sunday:  time.Hour,
+		},
+		InterruptionsByTag: map[models.InterruptionTag]int{models.TagMeeting: 1, models.TagCall: 3},
+		DailyDeflections: map[string]models.DeflectionCount{
+			"2025-03-04": {Accepted: 1, Deferred: 3},
+			"2025-03-03": {Accepted: 2},
+		},
+		DailyWorkDurations: map[string]time.Duration{
+			"2025-03-03": 3 * time.Hour,
+			"2025-03-04": 90 * time.Minute,
+			"2025-03-05": 0,
+		},
+	}
+}
+
+// TestBuilders tests that chart data is aggregated in a stable order
+func TestBuilders(t *testing.T) {
+	stats := testStats()
+
+	hourly := ProductivityByHour(stats)
+	assert.Equal(t, []string{"9:00", "14:00"}, hourly.Labels)
+	assert.Equal(t, []float64{2, 1}, hourly.Values)
+
+	// Hours with too little work to tell are left out
+	probability := InterruptionProbabilityByHour(stats)
+	assert.Equal(t, []string{"9:00", "14:00"}, probability.Labels)
+	assert.InDeltaSlice(t, []float64{39.35, 86.47}, probability.Values, 0.01)
+
+	interruptions := InterruptionsByType(stats)
+	assert.Equal(t, []string{"call", "meeting"}, interruptions.Labels)
+	assert.Equal(t, []float64{3, 1}, interruptions.Values)
+
+	weekday := ProductivityByWeekday(stats)
+	assert.Equal(t, []string{"Mon", "Tue", "Sun"}, weekday.Labels)
+	assert.Equal(t, []float64{3, 1.5, 1}, weekday.Values)
+
+	deflection := DeflectionRate(stats, 10)
+	assert.Equal(t, []string{"03-Mar", "04-Mar"}, deflection.Labels)
+	assert.Equal(t, []float64{0, 75}, deflection.Values)
+
+	daily := DailyProductivity(stats, 2)
+	assert.Equal(t, []string{"04-Mar", "05-Mar"}, daily.Labels)
+	assert.Equal(t, []float64{1.5, 0}, daily.Values)
+
+	// Days without work are left out
+	longest := LongestFocusTrend(stats, 10)
+	assert.Equal(t, []string{"03-Mar", "04-Mar"}, longest.Labels)
+	assert.Equal(t, []float64{90, 45}, longest.Values)
+
+	assert.Len(t, Standard(stats), len(StandardNames))
+}
+
+// TestRenderers tests every backend renders the same data
+func TestRenderers(t *testing.T) {
+	data := InterruptionsByType(testStats())
+	data.ColorFunc = func(value float64) string {
+		if value > 2 {
+			return "[red]"
+		}
+		return "[green]"
+	}
+
+	var text bytes.Buffer
+	assert.NoError(t, (&TextRenderer{Plain: true}).Render(&text, data))
+	assert.Equal(t, "call               3.0 "+strings.Repeat("█", 40)+"\n"+
+		"meeting            1.0 "+strings.Repeat("█", 13)+"\n", text.String())
+
+	var tagged bytes.Buffer
+	assert.NoError(t, (&TextRenderer{}).Render(&tagged, data))
+	assert.Contains(t, tagged.String(), "[red]")
+
+	var braille bytes.Buffer
+	assert.NoError(t, (&BrailleRenderer{Height: 2}).Render(&braille, data))
+	lines := strings.Split(strings.TrimRight(braille.String(), "\n"), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "   3.0 ┤⢱", lines[0])
+	assert.Contains(t, lines[2], "call … meeting")
+
+	var svg bytes.Buffer
+	assert.NoError(t, (&SVGRenderer{}).Render(&svg, data))
+	assert.NoError(t, xml.Unmarshal(svg.Bytes(), new(struct{})))
+	assert.Contains(t, svg.String(), `fill="#d62728"`)
+	assert.Contains(t, svg.String(), `fill="#2ca02c"`)
+
+	var kitty bytes.Buffer
+	assert.NoError(t, (&KittyRenderer{Width: 40, Height: 20}).Render(&kitty, data))
+	assert.Contains(t, kitty.String(), "\x1b_Ga=T,f=100,m=0;")
+
+	_, err := NewRenderer("sixel")
+	assert.Error(t, err)
+	assert.Error(t, (&TextRenderer{}).Render(&text, &Data{Labels: []string{"a"}}))
+}
+
+// TestHeatmap tests the hours × days heatmap builder and renderer
+func TestHeatmap(t *testing.T) {
+	stats := &models.DetailedStats{
+		DailyHourlyProductivity: map[string]map[int]time.Duration{
+			"2025-03-04": {9: 30 * time.Minute, 11: 2 * time.Hour},
+			"2025-03-03": {10: time.Hour},
+		},
+	}
+
+	data := ProductivityHeatmap(stats, 10)
+	assert.Equal(t, []string{"09", "10", "11"}, data.Labels)
+	assert.Equal(t, []string{"Mon 03-Mar", "Tue 04-Mar"}, data.RowLabels)
+	assert.Equal(t, [][]float64{{0, 1, 0}, {0.5, 0, 2}}, data.Grid)
+
+	var plain bytes.Buffer
+	assert.NoError(t, (&HeatmapRenderer{Plain: true}).Render(&plain, data))
+	assert.Equal(t, "           09 10 11 \n"+
+		"Mon 03-Mar ·· ▒▒ ·· \n"+
+		"Tue 04-Mar ░░ ·· ██ \n", plain.String())
+
+	var tagged bytes.Buffer
+	assert.NoError(t, (&HeatmapRenderer{}).Render(&tagged, data))
+	assert.Contains(t, tagged.String(), "[:#00ff00]  [:-]")
+
+	assert.Error(t, (&TextRenderer{}).Render(&tagged, data))
+	assert.Len(t, ProductivityHeatmap(stats, 1).Grid, 1)
+}
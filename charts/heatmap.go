@@ -0,0 +1,72 @@
+package charts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// heatmapShades are the plain text cells from no work to the most focused hours
+var heatmapShades = []string{"··", "░░", "▒▒", "▓▓", "██"}
+
+// heatmapColors are the cell background colors from no work to the most focused hours,
+// matching the calendar heatmap
+var heatmapColors = []string{"#2f4f4f", "#006400", "#008000", "#32cd32", "#00ff00"}
+
+// HeatmapRenderer draws heatmap data as a grid of cells shaded by intensity, one row
+// per row label and one two-character cell per column label
+type HeatmapRenderer struct {
+	Plain bool // Use shade characters instead of color tags, for output outside the TUI
+}
+
+// Render writes the column header followed by one line per grid row
+func (r *HeatmapRenderer) Render(w io.Writer, data *Data) error {
+	if err := data.validateGrid(); err != nil {
+		return err
+	}
+	if len(data.Grid) == 0 || len(data.Labels) == 0 {
+		_, err := fmt.Fprintln(w, "No data")
+		return err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-11s", "")
+	for _, label := range data.Labels {
+		fmt.Fprintf(&sb, "%-3s", label)
+	}
+	sb.WriteString("\n")
+
+	maxValue := data.maxGridValue()
+	for i, row := range data.Grid {
+		if r.Plain {
+			fmt.Fprintf(&sb, "%-11s", data.RowLabels[i])
+		} else {
+			fmt.Fprintf(&sb, "[yellow]%-11s[white]", data.RowLabels[i])
+		}
+		for _, value := range row {
+			level := heatmapLevel(value, maxValue)
+			if r.Plain {
+				sb.WriteString(heatmapShades[level] + " ")
+			} else {
+				fmt.Fprintf(&sb, "[:%s]  [:-] ", heatmapColors[level])
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// heatmapLevel maps a value to an intensity level relative to the largest value, keeping
+// level 0 for cells without any work
+func heatmapLevel(value, maxValue float64) int {
+	if value <= 0 || maxValue <= 0 {
+		return 0
+	}
+	level := 1 + int(value/maxValue*float64(len(heatmapShades)-2))
+	if level >= len(heatmapShades) {
+		level = len(heatmapShades) - 1
+	}
+	return level
+}
@@ -0,0 +1,100 @@
+package charts
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+// kittyChunkSize is the largest payload the kitty graphics protocol accepts per escape sequence
+const kittyChunkSize = 4096
+
+// KittyRenderer draws a bar chart as a PNG and displays it inline using the kitty
+// graphics protocol (supported by kitty, WezTerm and Ghostty)
+type KittyRenderer struct {
+	Width  int // Image width in pixels, defaults to 600
+	Height int // Image height in pixels, defaults to 200
+}
+
+// Render writes the title and the image escape sequences
+func (r *KittyRenderer) Render(w io.Writer, data *Data) error {
+	img, err := r.drawBars(data)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return fmt.Errorf("failed to encode chart image: %w", err)
+	}
+	payload := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	if _, err := fmt.Fprintf(w, "%s\n", data.Title); err != nil {
+		return err
+	}
+	for offset := 0; offset < len(payload); offset += kittyChunkSize {
+		end := offset + kittyChunkSize
+		more := 1
+		if end >= len(payload) {
+			end = len(payload)
+			more = 0
+		}
+
+		control := "m=" + strconv.Itoa(more)
+		if offset == 0 {
+			control = "a=T,f=100," + control
+		}
+		if _, err := fmt.Fprintf(w, "\x1b_G%s;%s\x1b\\", control, payload[offset:end]); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+// drawBars renders vertical bars on a transparent background
+func (r *KittyRenderer) drawBars(data *Data) (*image.RGBA, error) {
+	if err := data.validate(); err != nil {
+		return nil, err
+	}
+
+	width, height := r.Width, r.Height
+	if width <= 0 {
+		width = 600
+	}
+	if height <= 0 {
+		height = 200
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	count := len(data.Values)
+	maxValue := data.maxValue()
+	if count == 0 || maxValue <= 0 {
+		return img, nil
+	}
+
+	slot := width / count
+	gap := slot / 5
+	for i, value := range data.Values {
+		barHeight := int(value / maxValue * float64(height))
+		rect := image.Rect(i*slot+gap, height-barHeight, (i+1)*slot-gap, height)
+		draw.Draw(img, rect, &image.Uniform{C: parseHexColor(data.hexColor(value))}, image.Point{}, draw.Src)
+	}
+
+	return img, nil
+}
+
+// parseHexColor converts "#rrggbb" to an opaque color
+func parseHexColor(hex string) color.RGBA {
+	value, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return color.RGBA{A: 0xff}
+	}
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}
+}
@@ -0,0 +1,57 @@
+package charts
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// SVG layout in user units
+const (
+	svgWidth      = 640
+	svgRowHeight  = 24
+	svgLabelWidth = 120
+	svgValueWidth = 60
+	svgHeader     = 56
+)
+
+// SVGRenderer draws a horizontal bar chart as a standalone SVG document, for the web
+// dashboard and reports
+type SVGRenderer struct{}
+
+// Render writes the SVG document
+func (r *SVGRenderer) Render(w io.Writer, data *Data) error {
+	if err := data.validate(); err != nil {
+		return err
+	}
+
+	height := svgHeader + len(data.Values)*svgRowHeight + 8
+	barSpace := float64(svgWidth - svgLabelWidth - svgValueWidth - 16)
+	maxValue := data.maxValue()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		svgWidth, height, svgWidth, height)
+	fmt.Fprintf(&sb, `  <text x="8" y="20" font-size="16" font-weight="bold">%s</text>`+"\n", html.EscapeString(data.Title))
+	fmt.Fprintf(&sb, `  <text x="8" y="40" fill="#666">%s</text>`+"\n", html.EscapeString(data.Description))
+
+	for i, label := range data.Labels {
+		value := data.Values[i]
+		y := svgHeader + i*svgRowHeight
+
+		barWidth := 0.0
+		if maxValue > 0 {
+			barWidth = value / maxValue * barSpace
+		}
+
+		fmt.Fprintf(&sb, `  <text x="8" y="%d">%s</text>`+"\n", y+16, html.EscapeString(label))
+		fmt.Fprintf(&sb, `  <text x="%d" y="%d" text-anchor="end">%.1f</text>`+"\n", svgLabelWidth+svgValueWidth, y+16, value)
+		fmt.Fprintf(&sb, `  <rect x="%d" y="%d" width="%.1f" height="%d" fill="%s"/>`+"\n",
+			svgLabelWidth+svgValueWidth+8, y+4, barWidth, svgRowHeight-8, data.hexColor(value))
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
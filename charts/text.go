@@ -0,0 +1,48 @@
+package charts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// textBarWidth is the width of the longest bar in characters
+const textBarWidth = 40
+
+// TextRenderer draws horizontal bars with block characters and tview color tags
+type TextRenderer struct {
+	Plain bool // Omit color tags, for output outside the TUI
+}
+
+// Render writes one labelled bar per value
+func (r *TextRenderer) Render(w io.Writer, data *Data) error {
+	if err := data.validate(); err != nil {
+		return err
+	}
+
+	maxValue := data.maxValue()
+	for i, label := range data.Labels {
+		value := data.Values[i]
+
+		barWidth := 0
+		if maxValue > 0 {
+			barWidth = int((value / maxValue) * textBarWidth)
+		}
+		if barWidth < 1 && value > 0 {
+			barWidth = 1 // Always show at least one character for non-zero values
+		}
+
+		bar := strings.Repeat("█", barWidth)
+		var err error
+		if r.Plain {
+			_, err = fmt.Fprintf(w, "%-15s %6.1f %s\n", label, value, bar)
+		} else {
+			_, err = fmt.Fprintf(w, "[yellow]%-15s[white] %6.1f %s%s[white]\n", label, value, data.colorTag(value), bar)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,25 +16,227 @@ type Config struct {
 	// Storage settings
 	DataDirectory  string `json:"data_directory" yaml:"data_directory"`
 	BackupEnabled  bool   `json:"backup_enabled" yaml:"backup_enabled"`
-	BackupInterval int    `json:"backup_interval" yaml:"backup_interval"` // Days between backups
+	BackupInterval int    `json:"backup_interval" yaml:"backup_interval"`                   // Days between backups
+	JournalEnabled bool   `json:"journal_enabled" yaml:"journal_enabled"`                   // Append every event to journal.jsonl
+	RetentionDays  int    `json:"retention_days,omitempty" yaml:"retention_days,omitempty"` // Days of data to keep, older days are purged at startup; 0 keeps everything
+
+	// Remote backup target used by -backup-remote
+	BackupRemoteType      string `json:"backup_remote_type,omitempty" yaml:"backup_remote_type,omitempty"`           // "s3" or "webdav"
+	BackupRemoteURL       string `json:"backup_remote_url,omitempty" yaml:"backup_remote_url,omitempty"`             // S3 endpoint or WebDAV collection URL
+	BackupRemoteBucket    string `json:"backup_remote_bucket,omitempty" yaml:"backup_remote_bucket,omitempty"`       // S3 only
+	BackupRemoteRegion    string `json:"backup_remote_region,omitempty" yaml:"backup_remote_region,omitempty"`       // S3 only, defaults to us-east-1
+	BackupRemotePrefix    string `json:"backup_remote_prefix,omitempty" yaml:"backup_remote_prefix,omitempty"`       // Key prefix or sub-folder
+	BackupRemoteUser      string `json:"backup_remote_user,omitempty" yaml:"backup_remote_user,omitempty"`           // S3 access key ID or WebDAV username
+	BackupRemoteSecret    string `json:"backup_remote_secret,omitempty" yaml:"backup_remote_secret,omitempty"`       // S3 secret key or WebDAV password
+	BackupRemoteRetention int    `json:"backup_remote_retention,omitempty" yaml:"backup_remote_retention,omitempty"` // Newest archives to keep, 0 keeps all
 
 	// Session settings
-	RecoveryTime         time.Duration `json:"recovery_time" yaml:"recovery_time"`                   // In minutes
-	DefaultSessionLength time.Duration `json:"default_session_length" yaml:"default_session_length"` // In minutes
+	RecoveryTime         Duration       `json:"recovery_time" yaml:"recovery_time"`                                 // e.g. "10m"
+	DefaultSessionLength Duration       `json:"default_session_length" yaml:"default_session_length"`               // e.g. "25m"
+	DailyFocusGoal       int            `json:"daily_focus_goal,omitempty" yaml:"daily_focus_goal,omitempty"`       // Minutes of focus per day that extend a streak, defaults to 240
+	WeekdayFocusGoals    map[string]int `json:"weekday_focus_goals,omitempty" yaml:"weekday_focus_goals,omitempty"` // Minutes per weekday ("friday": 120) overriding daily_focus_goal, 0 for no goal
+	RecoveryNotify       bool           `json:"recovery_notify" yaml:"recovery_notify"`                             // Desktop notification when recovery after a return ends
+	DayStartHour         int            `json:"day_start_hour,omitempty" yaml:"day_start_hour,omitempty"`           // Hour (0-23) a new tracking day starts at, e.g. 4 for night owls
+	AutoEndAfter         Duration       `json:"auto_end_after,omitempty" yaml:"auto_end_after,omitempty"`           // End sessions running longer than this, e.g. "10h"
+	AutoEndAt            string         `json:"auto_end_at,omitempty" yaml:"auto_end_at,omitempty"`                 // "HH:MM" end-of-day time sessions still running are ended at
+	AutoEndPrompt        bool           `json:"auto_end_prompt,omitempty" yaml:"auto_end_prompt,omitempty"`         // Ask before auto-ending instead of ending right away
+	RolloverMode         string         `json:"rollover_mode,omitempty" yaml:"rollover_mode,omitempty"`             // "move" (default) or "split" sessions running into a new day
 
 	// UI settings
 	EnableMouse       bool   `json:"enable_mouse" yaml:"enable_mouse"`
-	ColorTheme        string `json:"color_theme" yaml:"color_theme"` // "light", "dark", "system"
+	ColorTheme        string `json:"color_theme" yaml:"color_theme"`                             // "light", "dark", "system" or "monochrome"
+	Locale            string `json:"locale,omitempty" yaml:"locale,omitempty"`                   // Language of the interface and reports: "en" (default) or "pl"
+	ClockFormat       string `json:"clock_format,omitempty" yaml:"clock_format,omitempty"`       // Times of day on the "24h" (default) or "12h" clock
+	DurationFormat    string `json:"duration_format,omitempty" yaml:"duration_format,omitempty"` // "clock" (01:23:00), "short" (1h 23m) or "decimal" (1.4h) everywhere; tables use clock and the rest short when empty
+	AccessibleMode    bool   `json:"accessible_mode,omitempty" yaml:"accessible_mode,omitempty"` // Text instead of color-only information, timelines as lists of spans
 	ShowNotifications bool   `json:"show_notifications" yaml:"show_notifications"`
+	ChartBackend      string `json:"chart_backend,omitempty" yaml:"chart_backend,omitempty"` // "text" (default) or "braille"
+	DisableDailyRecap bool   `json:"disable_daily_recap" yaml:"disable_daily_recap"`         // Skip the recap of the last working day on the first launch of a day
+
+	// Sessions table layout, the number keys change the sort while running
+	SessionColumns       []string `json:"session_columns,omitempty" yaml:"session_columns,omitempty"`               // Columns in order: start, end, duration, interruptions, pattern, description; all when empty
+	SessionSort          string   `json:"session_sort,omitempty" yaml:"session_sort,omitempty"`                     // Column to sort by, defaults to "start"
+	SessionSortAscending bool     `json:"session_sort_ascending,omitempty" yaml:"session_sort_ascending,omitempty"` // Oldest, shortest or A-Z first instead of the reverse
+
+	// Key bindings, "page.action" to comma separated keys, e.g. "main.start": "x"; (?) lists them all
+	KeyBindings map[string]string `json:"key_bindings,omitempty" yaml:"key_bindings,omitempty"`
+
+	// Reminders to start tracking during working hours
+	ReminderEnabled     bool   `json:"reminder_enabled" yaml:"reminder_enabled"`                               // Remind when no session is active during working hours
+	ReminderWorkStart   string `json:"reminder_work_start,omitempty" yaml:"reminder_work_start,omitempty"`     // "HH:MM", defaults to 09:00
+	ReminderWorkEnd     string `json:"reminder_work_end,omitempty" yaml:"reminder_work_end,omitempty"`         // "HH:MM", defaults to 17:00
+	ReminderWeekends    bool   `json:"reminder_weekends" yaml:"reminder_weekends"`                             // Also remind on Saturday and Sunday
+	ReminderIdleMinutes int    `json:"reminder_idle_minutes,omitempty" yaml:"reminder_idle_minutes,omitempty"` // Minutes without a session before reminding, defaults to 15
+	ReminderDesktop     bool   `json:"reminder_desktop" yaml:"reminder_desktop"`                               // Also send a desktop notification
 
 	// Custom interruption categories
-	CustomInterruptionTags []string `json:"custom_interruption_tags" yaml:"custom_interruption_tags"`
+	CustomInterruptionTags   []string `json:"custom_interruption_tags" yaml:"custom_interruption_tags"`
+	ArchivedInterruptionTags []string `json:"archived_interruption_tags,omitempty" yaml:"archived_interruption_tags,omitempty"` // Hidden from the selection dialog
+	SmartDefaultTag          bool     `json:"smart_default_tag" yaml:"smart_default_tag"`                                       // Pre-select the most likely tag for the current time
 
 	// Security
 	EnableEncryption bool   `json:"enable_encryption" yaml:"enable_encryption"`
 	EncryptionKey    string `json:"encryption_key,omitempty" yaml:"encryption_key,omitempty"` // Only used if manually set
 	PasswordProtect  bool   `json:"password_protect" yaml:"password_protect"`
 	PasswordHash     string `json:"password_hash,omitempty" yaml:"password_hash,omitempty"`
+
+	// Toggl Track integration
+	TogglAPIToken    string         `json:"toggl_api_token,omitempty" yaml:"toggl_api_token,omitempty"`
+	TogglWorkspaceID int            `json:"toggl_workspace_id,omitempty" yaml:"toggl_workspace_id,omitempty"`
+	TogglProjectID   int            `json:"toggl_project_id,omitempty" yaml:"toggl_project_id,omitempty"`   // Default project for synced sessions
+	TogglProjectMap  map[string]int `json:"toggl_project_map,omitempty" yaml:"toggl_project_map,omitempty"` // Description keyword to project ID
+	TogglSyncOnEnd   bool           `json:"toggl_sync_on_end" yaml:"toggl_sync_on_end"`                     // Push sessions as soon as they end
+
+	// Issue tracker linking
+	JiraBaseURL  string   `json:"jira_base_url,omitempty" yaml:"jira_base_url,omitempty"`   // e.g. https://company.atlassian.net
+	JiraEmail    string   `json:"jira_email,omitempty" yaml:"jira_email,omitempty"`         // Account used with the API token
+	JiraAPIToken string   `json:"jira_api_token,omitempty" yaml:"jira_api_token,omitempty"` // Jira API token
+	JiraProjects []string `json:"jira_projects,omitempty" yaml:"jira_projects,omitempty"`   // Project keys whose issue titles are looked up, e.g. PROJ
+	GitHubAPIURL string   `json:"github_api_url,omitempty" yaml:"github_api_url,omitempty"` // Defaults to https://api.github.com
+	GitHubToken  string   `json:"github_token,omitempty" yaml:"github_token,omitempty"`     // Enables title lookups, including private repositories
+
+	// Report sent by email with -email-report
+	SMTPHost     string   `json:"smtp_host,omitempty" yaml:"smtp_host,omitempty"`         // e.g. smtp.example.com
+	SMTPPort     int      `json:"smtp_port,omitempty" yaml:"smtp_port,omitempty"`         // Defaults to 587 with STARTTLS; 465 uses TLS from the start
+	SMTPUsername string   `json:"smtp_username,omitempty" yaml:"smtp_username,omitempty"` // No authentication when empty
+	SMTPPassword string   `json:"smtp_password,omitempty" yaml:"smtp_password,omitempty"`
+	EmailFrom    string   `json:"email_from,omitempty" yaml:"email_from,omitempty"`     // Sender address, defaults to smtp_username
+	EmailTo      []string `json:"email_to,omitempty" yaml:"email_to,omitempty"`         // Recipients of the report
+	EmailFormat  string   `json:"email_format,omitempty" yaml:"email_format,omitempty"` // "html" (default) or "markdown"
+
+	// Slack focus integration
+	SlackFocusEnabled  bool   `json:"slack_focus_enabled" yaml:"slack_focus_enabled"`                       // Set status and snooze during sessions
+	SlackToken         string `json:"slack_token,omitempty" yaml:"slack_token,omitempty"`                   // User token with users.profile:write and dnd:write
+	SlackStatusText    string `json:"slack_status_text,omitempty" yaml:"slack_status_text,omitempty"`       // Defaults to "Focusing"
+	SlackStatusEmoji   string `json:"slack_status_emoji,omitempty" yaml:"slack_status_emoji,omitempty"`     // Defaults to ":no_bell:"
+	SlackSnoozeMinutes int    `json:"slack_snooze_minutes,omitempty" yaml:"slack_snooze_minutes,omitempty"` // Defaults to 60
+
+	// Interruption detection from window focus
+	FocusWatcherEnabled bool              `json:"focus_watcher_enabled" yaml:"focus_watcher_enabled"`               // Watch the active window during sessions
+	FocusWatcherMode    string            `json:"focus_watcher_mode,omitempty" yaml:"focus_watcher_mode,omitempty"` // "suggest" (default) or "record"
+	FocusWatcherApps    map[string]string `json:"focus_watcher_apps,omitempty" yaml:"focus_watcher_apps,omitempty"` // App name pattern to interruption tag
+
+	// Prompt to start a session when coming back to the workstation
+	ActivityWatcherEnabled bool `json:"activity_watcher_enabled" yaml:"activity_watcher_enabled"`               // Watch for the screen unlocking or the first input after being away
+	ActivityAwayMinutes    int  `json:"activity_away_minutes,omitempty" yaml:"activity_away_minutes,omitempty"` // Minutes locked or without input that count as away, defaults to 30
+	ActivityDesktop        bool `json:"activity_desktop" yaml:"activity_desktop"`                               // Also send a desktop notification
+
+	// Git sync across devices
+	GitSyncEnabled bool   `json:"git_sync_enabled" yaml:"git_sync_enabled"`                   // Pull on startup, push on shutdown
+	GitSyncRemote  string `json:"git_sync_remote,omitempty" yaml:"git_sync_remote,omitempty"` // Remote repository URL
+	GitSyncBranch  string `json:"git_sync_branch,omitempty" yaml:"git_sync_branch,omitempty"` // Defaults to "main"
+
+	// Webhooks fired on tracker events
+	Webhooks []WebhookConfig `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+
+	// Project assignment from the workspace a session starts in, first matching rule wins
+	ProjectRules []ProjectRule `json:"project_rules,omitempty" yaml:"project_rules,omitempty"`
+
+	// How session descriptions are normalized when grouping them into tasks
+	TaskNormalization TaskNormalization `json:"task_normalization,omitempty" yaml:"task_normalization,omitempty"`
+
+	// Favorite tasks pinned to the top of the picker when starting a session
+	TaskPresets []TaskPreset `json:"task_presets,omitempty" yaml:"task_presets,omitempty"`
+
+	// How interruptions are counted by the statistics views, reports and exports
+	StatsPolicy StatsPolicy `json:"stats_policy,omitempty" yaml:"stats_policy,omitempty"`
+
+	// Estimated cost of interruptions in the statistics and reports, none without a rate
+	HourlyRate float64 `json:"hourly_rate,omitempty" yaml:"hourly_rate,omitempty"` // Cost of an hour of work, e.g. 85
+	Currency   string  `json:"currency,omitempty" yaml:"currency,omitempty"`       // Shown after amounts, e.g. "EUR"
+
+	// Alerts when interruptions or sessions run longer than planned, none by default
+	Alerts Alerts `json:"alerts,omitempty" yaml:"alerts,omitempty"`
+
+	// Tasks run at set times while the TUI or -daemon runs
+	Schedule []ScheduledJob `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+
+	// Expected working hours, compared with the work in the statistics and shaded in timelines
+	WorkSchedule WorkSchedule `json:"work_schedule,omitempty" yaml:"work_schedule,omitempty"`
+
+	// Global hotkeys by action (toggle, interrupt, interrupt:TAG or return), e.g. "ctrl+alt+i"
+	Hotkeys map[string]string `json:"hotkeys,omitempty" yaml:"hotkeys,omitempty"`
+}
+
+// WebhookConfig describes a webhook and the events it is fired for
+type WebhookConfig struct {
+	URL    string   `json:"url" yaml:"url"`
+	Events []string `json:"events,omitempty" yaml:"events,omitempty"` // Empty means all events
+}
+
+// ProjectRule assigns a project when all of its non-empty patterns match the workspace
+type ProjectRule struct {
+	Project   string `json:"project" yaml:"project"`
+	Cwd       string `json:"cwd,omitempty" yaml:"cwd,omitempty"`               // Directory, matching it and everything below, "~" for home
+	GitRemote string `json:"git_remote,omitempty" yaml:"git_remote,omitempty"` // Part of the origin remote URL, case-insensitive
+	Window    string `json:"window,omitempty" yaml:"window,omitempty"`         // Part of the active window title, case-insensitive
+}
+
+// TaskPreset is a favorite task offered first when starting a session
+type TaskPreset struct {
+	Description string   `json:"description" yaml:"description"`
+	Estimate    Duration `json:"estimate,omitempty" yaml:"estimate,omitempty"` // e.g. "30m"
+	Project     string   `json:"project,omitempty" yaml:"project,omitempty"`   // Overrides the workspace project
+}
+
+// TaskNormalization configures how descriptions are compared when grouping sessions into
+// tasks. Case is folded and punctuation and numbers are dropped unless configured otherwise.
+type TaskNormalization struct {
+	CaseSensitive  bool     `json:"case_sensitive,omitempty" yaml:"case_sensitive,omitempty"`     // Keep "Login" and "login" apart
+	StripIssueKeys bool     `json:"strip_issue_keys,omitempty" yaml:"strip_issue_keys,omitempty"` // Ignore Jira keys and GitHub references
+	StopWords      []string `json:"stop_words,omitempty" yaml:"stop_words,omitempty"`             // Words ignored when comparing, e.g. "the", "a"
+}
+
+// StatsPolicy configures how interruptions are counted in the statistics. By default only
+// completed interruptions count, without the recovery after them.
+type StatsPolicy struct {
+	IncludeRecovery bool `json:"include_recovery,omitempty" yaml:"include_recovery,omitempty"` // Count recovery_time after each interruption as interruption time
+	CapBySession    bool `json:"cap_by_session,omitempty" yaml:"cap_by_session,omitempty"`     // Never count more interruption time than a work period lasted
+	CountActive     bool `json:"count_active,omitempty" yaml:"count_active,omitempty"`         // Count an ongoing interruption up to now
+
+	// Formula of the work efficiency: "focus" (default), "working_hours" or "score"
+	Efficiency string `json:"efficiency,omitempty" yaml:"efficiency,omitempty"`
+
+	// Exceptions for interruptions with these tags, e.g. meetings chosen to attend
+	Tags map[string]TagPolicy `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// TagPolicy changes how interruptions with a tag count in the statistics. By default they
+// count like any other interruption.
+type TagPolicy struct {
+	NotInterruption bool `json:"not_interruption,omitempty" yaml:"not_interruption,omitempty"` // Time away is a planned break, neither work nor interruption
+	NoRecovery      bool `json:"no_recovery,omitempty" yaml:"no_recovery,omitempty"`           // No recovery_time after it
+	NoScorePenalty  bool `json:"no_score_penalty,omitempty" yaml:"no_score_penalty,omitempty"` // Left out of the productivity score
+}
+
+// Alerts configures alerts for interruptions and sessions running long. Zero thresholds
+// disable the alerts.
+type Alerts struct {
+	InterruptionAfter Duration `json:"interruption_after,omitempty" yaml:"interruption_after,omitempty"` // Alert when an interruption runs longer, e.g. "5m"
+	SessionAfter      Duration `json:"session_after,omitempty" yaml:"session_after,omitempty"`           // Planned length of sessions without an estimate, e.g. "50m"
+	Methods           []string `json:"methods,omitempty" yaml:"methods,omitempty"`                       // "bell", "flash" and "notification"; bell and flash when empty
+
+	// Thresholds for interruptions with these tags instead of interruption_after, "0s" for none
+	Tags map[string]Duration `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// ScheduledJob is a task run at the times of a cron expression
+type ScheduledJob struct {
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`         // Identifies the job, defaults to the task
+	Task     string `json:"task" yaml:"task"`                             // "backup", "compact", "report", "email_report" or "sync"
+	Cron     string `json:"cron" yaml:"cron"`                             // "minute hour day month weekday", e.g. "0 8 * * mon", or @hourly, @daily, @weekly, @monthly
+	Range    string `json:"range,omitempty" yaml:"range,omitempty"`       // Range of report and email_report, defaults to week
+	Path     string `json:"path,omitempty" yaml:"path,omitempty"`         // File written by report, {date} is replaced with the date
+	Template string `json:"template,omitempty" yaml:"template,omitempty"` // Template of report and email_report from the templates directory
+	Target   string `json:"target,omitempty" yaml:"target,omitempty"`     // What sync syncs: "git" (default) or "toggl"
+}
+
+// WorkSchedule configures the expected working hours. Without any of its settings there is
+// no schedule.
+type WorkSchedule struct {
+	Days  []string `json:"days,omitempty" yaml:"days,omitempty"`   // Day names and ranges, e.g. ["mon-thu", "sat"]; Monday to Friday when empty
+	Start string   `json:"start,omitempty" yaml:"start,omitempty"` // "HH:MM", defaults to 09:00
+	End   string   `json:"end,omitempty" yaml:"end,omitempty"`     // "HH:MM", defaults to 17:00
 }
 
 // DefaultConfig returns the default configuration
@@ -49,8 +251,8 @@ func DefaultConfig() *Config {
 		BackupEnabled:  true,
 		BackupInterval: 7, // Weekly backups
 
-		RecoveryTime:         10 * time.Minute,
-		DefaultSessionLength: 25 * time.Minute, // Pomodoro-style default
+		RecoveryTime:         Duration(10 * time.Minute),
+		DefaultSessionLength: Duration(25 * time.Minute), // Pomodoro-style default
 
 		EnableMouse:       true,
 		ColorTheme:        "system",
@@ -63,6 +265,63 @@ func DefaultConfig() *Config {
 	}
 }
 
+// IsTagArchived reports whether a custom interruption tag has been archived
+func (c *Config) IsTagArchived(tag string) bool {
+	for _, archived := range c.ArchivedInterruptionTags {
+		if archived == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveInterruptionTags returns the custom interruption tags that are not archived
+func (c *Config) ActiveInterruptionTags() []string {
+	active := []string{}
+	for _, tag := range c.CustomInterruptionTags {
+		if !c.IsTagArchived(tag) {
+			active = append(active, tag)
+		}
+	}
+	return active
+}
+
+// SetTagArchived archives or restores a custom interruption tag
+func (c *Config) SetTagArchived(tag string, archived bool) {
+	remaining := []string{}
+	for _, existing := range c.ArchivedInterruptionTags {
+		if existing != tag {
+			remaining = append(remaining, existing)
+		}
+	}
+	if archived {
+		remaining = append(remaining, tag)
+	}
+	c.ArchivedInterruptionTags = remaining
+}
+
+// ExpandHome replaces a leading "~" with the home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// ConfigExists reports whether a configuration file has been written yet
+func ConfigExists() bool {
+	configPath, err := ConfigPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(configPath)
+	return err == nil
+}
+
 // ConfigFileType represents the type of configuration file
 type ConfigFileType int
 
@@ -154,9 +413,9 @@ func LoadConfigFromPath(configPath string) (*Config, error) {
 		config.DataDirectory = filepath.Join(homeDir, ".interruption-tracker")
 	}
 
-	// Convert recovery time from stored minutes to duration
+	// Fall back to the default recovery time when missing
 	if config.RecoveryTime == 0 {
-		config.RecoveryTime = 10 * time.Minute
+		config.RecoveryTime = Duration(10 * time.Minute)
 	}
 
 	return &config, nil
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration is a time.Duration written as a human-friendly string ("10m", "1h30m") in
+// configuration files. Plain numbers from older files are read as nanoseconds.
+type Duration time.Duration
+
+// ParseDuration parses a duration string such as "10m" or "1h30m"
+func ParseDuration(s string) (Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	return Duration(d), nil
+}
+
+// Std returns the duration as a time.Duration
+func (d Duration) Std() time.Duration {
+	return time.Duration(d)
+}
+
+// String formats the duration without trailing zero units, e.g. "10m" instead of "10m0s"
+func (d Duration) String() string {
+	s := time.Duration(d).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON reads a duration string or a number of nanoseconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	}
+
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid duration %s: expected a string like \"10m\" or nanoseconds", data)
+	}
+	*d = Duration(n)
+	return nil
+}
+
+// MarshalYAML writes the duration as a string
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML reads a duration string or a number of nanoseconds
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	if value.Tag == "!!int" {
+		var n int64
+		if err := value.Decode(&n); err != nil {
+			return fmt.Errorf("invalid duration %q: %w", value.Value, err)
+		}
+		*d = Duration(n)
+		return nil
+	}
+
+	parsed, err := ParseDuration(value.Value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDurationString(t *testing.T) {
+	assert.Equal(t, "10m", Duration(10*time.Minute).String())
+	assert.Equal(t, "1h30m", Duration(90*time.Minute).String())
+	assert.Equal(t, "2h", Duration(2*time.Hour).String())
+	assert.Equal(t, "10s", Duration(10*time.Second).String())
+	assert.Equal(t, "0s", Duration(0).String())
+}
+
+func TestDurationJSON(t *testing.T) {
+	var cfg Config
+	assert.NoError(t, json.Unmarshal([]byte(`{"recovery_time": "1h30m", "default_session_length": 1500000000000}`), &cfg))
+	assert.Equal(t, 90*time.Minute, cfg.RecoveryTime.Std())
+	assert.Equal(t, 25*time.Minute, cfg.DefaultSessionLength.Std())
+
+	data, err := json.Marshal(Duration(10 * time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, `"10m"`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"recovery_time": "ten minutes"}`), &cfg))
+}
+
+func TestDurationYAML(t *testing.T) {
+	var cfg Config
+	assert.NoError(t, yaml.Unmarshal([]byte("recovery_time: 10m\ndefault_session_length: 1500000000000\n"), &cfg))
+	assert.Equal(t, 10*time.Minute, cfg.RecoveryTime.Std())
+	assert.Equal(t, 25*time.Minute, cfg.DefaultSessionLength.Std())
+
+	data, err := yaml.Marshal(DefaultConfig())
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "recovery_time: 10m\n")
+	assert.Contains(t, string(data), "default_session_length: 25m\n")
+
+	assert.Error(t, yaml.Unmarshal([]byte("recovery_time: soon\n"), &cfg))
+}
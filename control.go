@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/control"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// handleControl runs a command of the control protocol, which the MCP tools share
+func handleControl(store storage.Store, req control.Request, now time.Time) control.Response {
+	message, err := runControlCommand(store, req, now)
+	if err != nil {
+		return control.Response{Error: err.Error()}
+	}
+	resp := control.Response{OK: true, Message: message}
+
+	if req.Command == control.CommandStats {
+		stats, err := controlStats(store, req.Range)
+		if err != nil {
+			return control.Response{Error: err.Error()}
+		}
+		resp.Stats = stats
+		return resp
+	}
+
+	status, err := buildStatus(store, now)
+	if err != nil {
+		return control.Response{Error: err.Error()}
+	}
+	resp.Status = status
+	return resp
+}
+
+// runControlCommand runs a command and describes the outcome in a sentence
+func runControlCommand(store storage.Store, req control.Request, now time.Time) (string, error) {
+	switch req.Command {
+	case control.CommandStatus:
+		return describeStatus(store, now)
+	case control.CommandStart:
+		if strings.TrimSpace(req.Description) == "" {
+			return "", fmt.Errorf("description is required")
+		}
+		estimate, err := models.ParseEstimate(req.Estimate)
+		if err != nil {
+			return "", err
+		}
+		session, err := startSession(store, now, req.Description, req.Project, estimate)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Started %q at %s", session.Start.Description, now.Format("15:04")), nil
+	case control.CommandEnd:
+		session, err := endSession(store, now)
+		if err != nil {
+			return "", err
+		}
+		work, _, interruptions := session.GetStats()
+		return fmt.Sprintf("Ended %q after %s of focused work and %d interruption(s)", session.Start.Description, formatDuration(work), interruptions), nil
+	case control.CommandInterrupt:
+		tag, err := resolveInterruptionTag(store, req.Tag)
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(req.Tag) == "" {
+			// Suggest a tag from the description when none was given
+			model, _ := store.LoadTagModel() // Default keywords still work without a model
+			if suggested, ok := model.Suggest(req.Description); ok {
+				tag = suggested
+			}
+		}
+		if req.Minutes < 0 {
+			return "", fmt.Errorf("minutes cannot be negative")
+		}
+		duration := time.Duration(req.Minutes * float64(time.Minute)).Round(time.Second)
+		if _, err := interruptSession(store, now, tag, req.Description, duration); err != nil {
+			return "", err
+		}
+		if duration > 0 {
+			return fmt.Sprintf("Logged a %s interruption of %s, back to work now", tag, formatDuration(duration)), nil
+		}
+		return fmt.Sprintf("Interrupted by %s since %s; return when back", tag, now.Format("15:04")), nil
+	case control.CommandReturn:
+		session, err := returnFromInterruption(store, now)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Back to work on %q", session.Start.Description), nil
+	case control.CommandStats:
+		return describeStats(store, controlRange(req.Range))
+	}
+	return "", fmt.Errorf("unknown command %q, use start, end, interrupt, return, status or stats", req.Command)
+}
+
+// controlRange returns the range of a stats command, a week by default
+func controlRange(rangeType string) string {
+	if rangeType == "" {
+		return "week"
+	}
+	return rangeType
+}
+
+// controlStats returns the statistics of a range for the control protocol
+func controlStats(store storage.Store, rangeType string) (*control.Stats, error) {
+	stats, err := store.GetDetailedStats(controlRange(rangeType))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &control.Stats{
+		Start:              stats.StartDate.Format("2006-01-02"),
+		End:                stats.EndDate.Format("2006-01-02"),
+		WorkSeconds:        int(stats.TotalWorkDuration.Seconds()),
+		Sessions:           stats.TotalSessions,
+		Interruptions:      stats.TotalInterruptions,
+		Score:              stats.CalculateProductivityScore(),
+		InterruptionsByTag: make(map[string]int, len(stats.InterruptionsByTag)),
+	}
+	var interrupted time.Duration
+	for tag, count := range stats.InterruptionsByTag {
+		result.InterruptionsByTag[string(tag)] = count
+		interrupted += stats.InterruptionDurationByTag[tag]
+	}
+	result.InterruptedSeconds = int(interrupted.Seconds())
+	return result, nil
+}
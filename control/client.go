@@ -0,0 +1,110 @@
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// Timeouts of clients, so a stuck tracker does not hang an editor
+const (
+	dialTimeout    = time.Second     // Connecting to the socket
+	requestTimeout = 5 * time.Second // Sending a request and reading its response
+)
+
+// Client sends commands to the tracker over the control socket
+type Client struct {
+	conn    net.Conn
+	decoder *json.Decoder
+	mu      sync.Mutex // One request at a time
+}
+
+// Dial connects to the control socket at path, control.sock in the data directory while
+// the daemon runs
+func Dial(path string) (*Client, error) {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the tracker: %w", err)
+	}
+	return &Client{conn: conn, decoder: json.NewDecoder(bufio.NewReader(conn))}, nil
+}
+
+// Close disconnects from the tracker
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Do sends a request and returns the response, with its error when it failed
+func (c *Client) Do(req Request) (*Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	if err := c.conn.SetDeadline(time.Now().Add(requestTimeout)); err != nil {
+		return nil, fmt.Errorf("failed to set request deadline: %w", err)
+	}
+	if _, err := c.conn.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var resp Response
+	if err := c.decoder.Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if !resp.OK {
+		return &resp, errors.New(resp.Error)
+	}
+	return &resp, nil
+}
+
+// Start starts a session, with an optional project
+func (c *Client) Start(description, project string) (*Status, error) {
+	return c.status(Request{Command: CommandStart, Description: description, Project: project})
+}
+
+// End ends the active session
+func (c *Client) End() (*Status, error) {
+	return c.status(Request{Command: CommandEnd})
+}
+
+// Interrupt interrupts the active session until Return, with the tag suggested from the
+// description when empty
+func (c *Client) Interrupt(tag, description string) (*Status, error) {
+	return c.status(Request{Command: CommandInterrupt, Tag: tag, Description: description})
+}
+
+// Return returns from the ongoing interruption
+func (c *Client) Return() (*Status, error) {
+	return c.status(Request{Command: CommandReturn})
+}
+
+// Status returns the state of the tracker
+func (c *Client) Status() (*Status, error) {
+	return c.status(Request{Command: CommandStatus})
+}
+
+// Stats returns the statistics of a range: day, week, month, quarter, year, all or
+// YYYY-MM-DD..YYYY-MM-DD
+func (c *Client) Stats(rangeType string) (*Stats, error) {
+	resp, err := c.Do(Request{Command: CommandStats, Range: rangeType})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Stats, nil
+}
+
+// status sends a request answered with the status
+func (c *Client) status(req Request) (*Status, error) {
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Status, nil
+}
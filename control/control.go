@@ -0,0 +1,123 @@
+// Package control is the protocol editors, window managers and scripts use to control the
+// tracker through the control socket of the daemon: one JSON request per line, answered
+// by one JSON response per line.
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Commands of requests
+const (
+	CommandStart     = "start"     // Start a session
+	CommandEnd       = "end"       // End the active session
+	CommandInterrupt = "interrupt" // Interrupt the active session
+	CommandReturn    = "return"    // Return from the ongoing interruption
+	CommandStatus    = "status"    // Report the state of the tracker
+	CommandStats     = "stats"     // Report the statistics of a range
+)
+
+// States of the tracker
+const (
+	StateWorking     = "working"
+	StateInterrupted = "interrupted"
+	StateIdle        = "idle"
+)
+
+// Request is a command sent to the tracker
+type Request struct {
+	Command     string  `json:"command"`
+	Description string  `json:"description,omitempty"` // Task started, or what the interruption is about
+	Project     string  `json:"project,omitempty"`     // Project of the session started
+	Estimate    string  `json:"estimate,omitempty"`    // Expected work time of the session started, e.g. 45m
+	Tag         string  `json:"tag,omitempty"`         // Tag of the interruption, suggested from the description when empty
+	Minutes     float64 `json:"minutes,omitempty"`     // Length of an interruption that is already over
+	Range       string  `json:"range,omitempty"`       // Range of the statistics, week by default
+}
+
+// Response answers a request. Every command but stats reports the status after it ran.
+type Response struct {
+	OK      bool    `json:"ok"`
+	Error   string  `json:"error,omitempty"`
+	Message string  `json:"message,omitempty"` // What was done, in a sentence
+	Status  *Status `json:"status,omitempty"`
+	Stats   *Stats  `json:"stats,omitempty"`
+}
+
+// Status is the state of the active session and today's work
+type Status struct {
+	State          string `json:"state"`                 // working, interrupted or idle
+	Description    string `json:"description,omitempty"` // Description of the active session
+	Project        string `json:"project,omitempty"`     // Project of the active session
+	Elapsed        string `json:"elapsed"`               // Focused work time in the active session
+	ElapsedSeconds int    `json:"elapsed_seconds"`
+	Tag            string `json:"tag,omitempty"`         // Tag of the ongoing interruption
+	Interrupted    string `json:"interrupted,omitempty"` // Duration of the ongoing interruption
+	Interruptions  int    `json:"interruptions"`         // Interruptions in the active session
+	Today          string `json:"today"`                 // Focused work time across all of today's sessions
+	TodaySeconds   int    `json:"today_seconds"`
+}
+
+// Stats are the statistics of a range of days
+type Stats struct {
+	Start              string         `json:"start"` // First day, YYYY-MM-DD
+	End                string         `json:"end"`   // Last day, YYYY-MM-DD
+	WorkSeconds        int            `json:"work_seconds"`
+	Sessions           int            `json:"sessions"`
+	Interruptions      int            `json:"interruptions"`
+	InterruptedSeconds int            `json:"interrupted_seconds"`
+	Score              float64        `json:"score"`                // Productivity score out of 100
+	InterruptionsByTag map[string]int `json:"interruptions_by_tag"` // Interruptions of each tag
+}
+
+// Handler answers a request
+type Handler func(req Request) Response
+
+// Serve answers the requests of the clients connecting to the listener with handler until
+// the listener is closed. Requests are handled one at a time.
+func Serve(listener net.Listener, handler Handler) error {
+	var mu sync.Mutex
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go serveConn(conn, func(req Request) Response {
+			mu.Lock()
+			defer mu.Unlock()
+			return handler(req)
+		})
+	}
+}
+
+// serveConn answers the requests of a client until it disconnects
+func serveConn(conn net.Conn, handler Handler) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var req Request
+		var resp Response
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp = Response{Error: fmt.Sprintf("invalid request: %v", err)}
+		} else {
+			resp = handler(req)
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return
+		}
+	}
+}
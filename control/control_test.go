@@ -0,0 +1,90 @@
+package control
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serve answers requests on a socket in a temporary directory with handler
+func serve(t *testing.T, handler Handler) string {
+	path := filepath.Join(t.TempDir(), "control.sock")
+	listener, err := net.Listen("unix", path)
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go Serve(listener, handler)
+	return path
+}
+
+// TestClient tests the requests sent by the client and the responses it returns
+func TestClient(t *testing.T) {
+	var requests []Request
+	path := serve(t, func(req Request) Response {
+		requests = append(requests, req)
+		switch req.Command {
+		case CommandStats:
+			return Response{OK: true, Stats: &Stats{Start: "2025-03-03", Sessions: 4}}
+		case CommandEnd:
+			return Response{Error: "no active session"}
+		}
+		return Response{OK: true, Status: &Status{State: StateWorking, Description: req.Description}}
+	})
+
+	client, err := Dial(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer client.Close()
+
+	status, err := client.Start("Write docs", "site")
+	assert.NoError(t, err)
+	assert.Equal(t, &Status{State: StateWorking, Description: "Write docs"}, status)
+
+	_, err = client.Interrupt("", "Phone call")
+	assert.NoError(t, err)
+
+	_, err = client.End()
+	assert.EqualError(t, err, "no active session")
+
+	stats, err := client.Stats("week")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, stats.Sessions)
+
+	assert.Equal(t, []Request{
+		{Command: CommandStart, Description: "Write docs", Project: "site"},
+		{Command: CommandInterrupt, Description: "Phone call"},
+		{Command: CommandEnd},
+		{Command: CommandStats, Range: "week"},
+	}, requests)
+}
+
+// TestServeInvalidRequest tests that a malformed line is answered with an error and the
+// connection keeps working
+func TestServeInvalidRequest(t *testing.T) {
+	path := serve(t, func(req Request) Response {
+		return Response{OK: true, Message: req.Command}
+	})
+
+	conn, err := net.Dial("unix", path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	_, err = conn.Write([]byte("{not json\n\n{\"command\":\"status\"}\n"))
+	assert.NoError(t, err)
+
+	scanner := bufio.NewScanner(conn)
+	var lines []string
+	for len(lines) < 2 && scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if !assert.Len(t, lines, 2) {
+		return
+	}
+	assert.Contains(t, lines[0], `"ok":false`)
+	assert.Contains(t, lines[0], "invalid request")
+	assert.Equal(t, `{"ok":true,"message":"status"}`, lines[1])
+}
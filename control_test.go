@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/control"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHandleControl tests control commands and the status and statistics they report
+func TestHandleControl(t *testing.T) {
+	store, err := storage.NewStorageWithConfig(&config.Config{}, t.TempDir())
+	assert.NoError(t, err)
+	now := store.DayOf(time.Now()).Add(9 * time.Hour)
+
+	resp := handleControl(store, control.Request{Command: control.CommandStart}, now)
+	assert.Equal(t, control.Response{Error: "description is required"}, resp)
+
+	resp = handleControl(store, control.Request{Command: control.CommandStart, Description: "Fix the build", Project: "ci"}, now)
+	assert.True(t, resp.OK)
+	assert.Equal(t, `Started "Fix the build" at 09:00`, resp.Message)
+	assert.Equal(t, StateWorking, resp.Status.State)
+	assert.Equal(t, "ci", resp.Status.Project)
+
+	// Without a tag, the tag is suggested from the description
+	now = now.Add(30 * time.Minute)
+	resp = handleControl(store, control.Request{Command: control.CommandInterrupt, Description: "Phone call", Minutes: 10}, now)
+	assert.True(t, resp.OK)
+	assert.Equal(t, "Logged a call interruption of 10m 0s, back to work now", resp.Message)
+	assert.Equal(t, StateWorking, resp.Status.State)
+	assert.Equal(t, 1, resp.Status.Interruptions)
+
+	now = now.Add(30 * time.Minute)
+	resp = handleControl(store, control.Request{Command: control.CommandEnd}, now)
+	assert.True(t, resp.OK)
+	assert.Equal(t, StateIdle, resp.Status.State)
+
+	resp = handleControl(store, control.Request{Command: control.CommandStats, Range: "day"}, now)
+	assert.True(t, resp.OK)
+	assert.Nil(t, resp.Status)
+	if assert.NotNil(t, resp.Stats) {
+		assert.Equal(t, 1, resp.Stats.Sessions)
+		assert.Equal(t, 1, resp.Stats.Interruptions)
+		assert.Equal(t, map[string]int{"call": 1}, resp.Stats.InterruptionsByTag)
+		assert.Equal(t, 600, resp.Stats.InterruptedSeconds)
+	}
+
+	resp = handleControl(store, control.Request{Command: "pause"}, now)
+	assert.False(t, resp.OK)
+	assert.Contains(t, resp.Error, `unknown command "pause"`)
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/control"
+	"github.com/lukaszraczylo/interruption-tracker/daemon"
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/scheduler"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/lukaszraczylo/interruption-tracker/ui"
+)
+
+// schedulerInterval is how often due jobs are looked for
+const schedulerInterval = time.Minute
+
+// meetingInterval is how often declared meetings are checked for starting or ending
+const meetingInterval = 15 * time.Second
+
+// newScheduler returns the scheduler of the configured jobs, nil when there are none. With
+// do set, each task runs inside it, such as the daemon serializing them with client calls.
+func newScheduler(store *storage.Storage, do func(fn func(storage.Store))) (*scheduler.Scheduler, error) {
+	jobs, err := scheduler.Jobs(store.GetConfig())
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	tasks := scheduledTasks(store)
+	if do != nil {
+		tasks = serializedTasks(tasks, do)
+	}
+	return scheduler.New(jobs, tasks, store.SchedulerStatePath()), nil
+}
+
+// serializedTasks wraps each task to run inside do
+func serializedTasks(tasks map[string]scheduler.Task, do func(fn func(storage.Store))) map[string]scheduler.Task {
+	serialized := make(map[string]scheduler.Task, len(tasks))
+	for name, task := range tasks {
+		task := task
+		serialized[name] = func(job config.ScheduledJob, now time.Time) (err error) {
+			do(func(storage.Store) { err = task(job, now) })
+			return err
+		}
+	}
+	return serialized
+}
+
+// scheduledTasks returns the tasks run by scheduled jobs
+func scheduledTasks(store *storage.Storage) map[string]scheduler.Task {
+	return map[string]scheduler.Task{
+		scheduler.TaskBackup: func(job config.ScheduledJob, now time.Time) error {
+			if _, err := store.BackupChanged(); err != nil {
+				return err
+			}
+			if store.GetConfig().BackupRemoteURL == "" {
+				return nil
+			}
+			target, err := storage.NewRemoteBackupTarget(store.GetConfig())
+			if err != nil {
+				return err
+			}
+			_, err = store.UploadBackupArchive(target)
+			return err
+		},
+		scheduler.TaskCompact: func(job config.ScheduledJob, now time.Time) error {
+			_, err := store.CompactBackups(now)
+			return err
+		},
+		scheduler.TaskReport: func(job config.ScheduledJob, now time.Time) error {
+			return writeReportFile(store, scheduledRange(job), scheduledReportPath(store, job, now), job.Template)
+		},
+		scheduler.TaskEmailReport: func(job config.ScheduledJob, now time.Time) error {
+			mailer, err := integrations.NewMailer(store.GetConfig())
+			if err != nil {
+				return err
+			}
+			return emailReport(store, mailer, scheduledRange(job), job.Template, now)
+		},
+		scheduler.TaskSync: func(job config.ScheduledJob, now time.Time) error {
+			if job.Target == "toggl" {
+				client, err := integrations.NewTogglClient(store.GetConfig(), store.GetDataDir())
+				if err != nil {
+					return err
+				}
+				sessions, err := loadAllSessions(store, nil)
+				if err != nil {
+					return err
+				}
+				_, err = client.SyncSessions(sessions)
+				return err
+			}
+
+			gitSync, err := storage.NewGitSync(store)
+			if err != nil {
+				return err
+			}
+			return gitSync.Push()
+		},
+	}
+}
+
+// scheduledRange returns the range of a scheduled report, a week by default
+func scheduledRange(job config.ScheduledJob) string {
+	if job.Range == "" {
+		return "week"
+	}
+	return job.Range
+}
+
+// scheduledReportPath returns the file a scheduled report is written to, with {date}
+// replaced and relative paths in the data directory
+func scheduledReportPath(store *storage.Storage, job config.ScheduledJob, now time.Time) string {
+	path := strings.ReplaceAll(job.Path, "{date}", now.Format("2006-01-02"))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(store.GetDataDir(), path)
+	}
+	return path
+}
+
+// logScheduled writes the outcome of a scheduled run
+func logScheduled(w io.Writer, result scheduler.Result, now time.Time) {
+	job := result.Job
+	if job == "" {
+		job = "scheduler"
+	}
+	if result.Err != nil {
+		fmt.Fprintf(w, "%s %s failed: %v\n", now.Format("2006-01-02 15:04"), job, result.Err)
+		return
+	}
+	fmt.Fprintf(w, "%s %s done\n", now.Format("2006-01-02 15:04"), job)
+}
+
+// runDaemon serves the data directory on the daemon socket, accepts commands on the
+// control socket and runs the scheduled jobs, logging every run, until a signal arrives
+func runDaemon(store *storage.Storage, w io.Writer, signals <-chan os.Signal) error {
+	listener, err := daemon.Listen(store.DaemonSocketPath())
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	controlListener, err := daemon.Listen(store.ControlSocketPath())
+	if err != nil {
+		return err
+	}
+	defer controlListener.Close()
+
+	// Scheduled jobs change the data too, run them between client calls
+	server := daemon.NewServer(store)
+	jobScheduler, err := newScheduler(store, server.Do)
+	if err != nil {
+		return err
+	}
+
+	served := make(chan error, 2)
+	go func() { served <- server.Serve(listener) }()
+	go func() {
+		served <- control.Serve(controlListener, func(req control.Request) (resp control.Response) {
+			server.Do(func(store storage.Store) { resp = handleControl(store, req, time.Now()) })
+			return resp
+		})
+	}()
+	fmt.Fprintf(w, "Serving %s, commands on %s\n", store.DaemonSocketPath(), store.ControlSocketPath())
+
+	if jobScheduler != nil {
+		for _, job := range jobScheduler.Jobs() {
+			fmt.Fprintf(w, "Scheduled %s (%s)\n", job.Name, job.Config.Task)
+		}
+		stop := jobScheduler.Start(schedulerInterval, func(result scheduler.Result) {
+			logScheduled(w, result, time.Now())
+		})
+		defer stop()
+	}
+
+	// Record declared meetings, also while no TUI is attached
+	stopMeetings := make(chan struct{})
+	defer close(stopMeetings)
+	go watchMeetings(server, w, stopMeetings)
+
+	if cfg := store.GetConfig(); cfg.ActivityWatcherEnabled {
+		var notify func(title, message string) error
+		if cfg.ActivityDesktop {
+			notify = integrations.Notify
+		}
+		stop := make(chan struct{})
+		go integrations.NewActivityWatcher(cfg).Run(stop, func(event integrations.ActivityEvent) {
+			server.Do(func(store storage.Store) { promptActivity(store, event, w, notify) })
+		}, func(err error) {
+			fmt.Fprintf(w, "%s activity watcher stopped: %v\n", time.Now().Format("2006-01-02 15:04"), err)
+		})
+		defer close(stop)
+	}
+
+	select {
+	case <-signals:
+		return nil
+	case err := <-served:
+		return err
+	}
+}
+
+// watchMeetings records declared meetings as they start and end, logging each, until stop
+// is closed
+func watchMeetings(server *daemon.Server, w io.Writer, stop <-chan struct{}) {
+	ticker := time.NewTicker(meetingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			server.Do(func(store storage.Store) {
+				message, err := recordMeetings(store, now)
+				switch {
+				case err != nil:
+					fmt.Fprintf(w, "%s failed to record meetings: %v\n", now.Format("2006-01-02 15:04"), err)
+				case message != "":
+					fmt.Fprintf(w, "%s %s\n", now.Format("2006-01-02 15:04"), message)
+				}
+			})
+		}
+	}
+}
+
+// promptActivity logs coming back to the workstation without a session, and prompts to
+// start one with a desktop notification when notify is set
+func promptActivity(store storage.Store, event integrations.ActivityEvent, w io.Writer, notify func(title, message string) error) {
+	status, err := buildStatus(store, event.At)
+	if err != nil {
+		fmt.Fprintf(w, "%s failed to check for a session: %v\n", event.At.Format("2006-01-02 15:04"), err)
+		return
+	}
+	if status.State != StateIdle {
+		return
+	}
+
+	fmt.Fprintf(w, "%s back after %s (%s), no session\n", event.At.Format("2006-01-02 15:04"), formatDuration(event.Away), event.Reason)
+	if notify == nil {
+		return
+	}
+	message := fmt.Sprintf("Away for %s, start a session to track your time", formatDuration(event.Away))
+	if err := notify("Interruption Tracker", message); err != nil {
+		fmt.Fprintf(w, "%s failed to send notification: %v\n", event.At.Format("2006-01-02 15:04"), err)
+	}
+}
+
+// daemonSignals returns the signals stopping the daemon. Hangups are ignored, so the
+// daemon outlives the terminal it was started from.
+func daemonSignals() <-chan os.Signal {
+	signal.Ignore(syscall.SIGHUP)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	return signals
+}
+
+// connectDaemon connects to the daemon serving the data directory, nil when none is running
+func connectDaemon(store *storage.Storage) *daemon.Client {
+	path := store.DaemonSocketPath()
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	client, err := daemon.Dial(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: The daemon is not answering, using the data directory directly: %v\n", err)
+		return nil
+	}
+	return client
+}
+
+// commandStore returns the daemon when one is running, so commands change sessions
+// through it, and the storage otherwise
+func commandStore(store *storage.Storage) storage.Store {
+	if client := connectDaemon(store); client != nil {
+		return client
+	}
+	return store
+}
+
+// runAttached runs the TUI as a client of the daemon, which owns the data and runs the
+// scheduled jobs. A session left running keeps running in the daemon when the TUI exits.
+func runAttached(client *daemon.Client) error {
+	defer client.Close()
+
+	timerUI, err := ui.NewTimerUI(client)
+	if err != nil {
+		return fmt.Errorf("failed to initialize UI: %w", err)
+	}
+	timerUI.SetAttached(true)
+	return timerUI.Run()
+}
@@ -0,0 +1,287 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// Client is a Store served by the daemon. The configuration and data directory are read
+// once when connecting; methods without an error result return their zero values when
+// the daemon cannot be reached.
+type Client struct {
+	rpc     *rpc.Client
+	config  *config.Config
+	dataDir string
+}
+
+// Client is the daemon backend of Store
+var _ storage.Store = (*Client)(nil)
+
+// Dial connects to the daemon listening on the unix socket at path
+func Dial(path string) (*Client, error) {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the daemon: %w", err)
+	}
+
+	client := &Client{rpc: jsonrpc.NewClient(conn)}
+	if err := client.call("GetConfig", nil, &client.config); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to read the daemon configuration: %w", err)
+	}
+	if err := client.call("GetDataDir", nil, &client.dataDir); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to read the daemon data directory: %w", err)
+	}
+	return client, nil
+}
+
+// Close disconnects from the daemon
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
+
+// call calls a method of the store served by the daemon and decodes its results, other
+// than the error, into results
+func (c *Client) call(method string, args []any, results ...any) error {
+	req := Request{Method: method}
+	for _, arg := range args {
+		data, err := json.Marshal(arg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal argument of %s: %w", method, err)
+		}
+		req.Args = append(req.Args, data)
+	}
+
+	var resp Response
+	if err := c.rpc.Call(serviceName+".Call", req, &resp); err != nil {
+		return err
+	}
+	if len(resp.Results) != len(results) {
+		return fmt.Errorf("%s returned %d results, expected %d", method, len(resp.Results), len(results))
+	}
+	for i, data := range resp.Results {
+		if err := json.Unmarshal(data, results[i]); err != nil {
+			return fmt.Errorf("failed to parse result of %s: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// LoadDailySessions loads the sessions of a day
+func (c *Client) LoadDailySessions(date time.Time) (*models.DailySessions, error) {
+	var sessions *models.DailySessions
+	err := c.call("LoadDailySessions", []any{date}, &sessions)
+	return sessions, err
+}
+
+// SaveDailySessions saves the sessions of a day
+func (c *Client) SaveDailySessions(sessions *models.DailySessions) error {
+	return c.call("SaveDailySessions", []any{sessions})
+}
+
+// MoveSessions moves sessions between days
+func (c *Client) MoveSessions(from, to time.Time, ids []string) error {
+	return c.call("MoveSessions", []any{from, to, ids})
+}
+
+// ForEachDay calls fn with the sessions of each tracked day in date order, fetching all
+// days first. Stops at the first error fn returns.
+func (c *Client) ForEachDay(fn func(day *models.DailySessions) error) error {
+	var days []*models.DailySessions
+	if err := c.rpc.Call(serviceName+".Days", struct{}{}, &days); err != nil {
+		return err
+	}
+	for _, day := range days {
+		if err := fn(day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AppendJournal appends an event to the journal
+func (c *Client) AppendJournal(event *models.JournalEvent) error {
+	return c.call("AppendJournal", []any{event})
+}
+
+// DayOf returns local midnight of the tracking day t belongs to
+func (c *Client) DayOf(t time.Time) time.Time {
+	return models.DayOf(t, c.config.DayStartHour)
+}
+
+// GetStats returns the work time, interruption time and number of interruptions of a range
+func (c *Client) GetStats(rangeType string) (time.Duration, time.Duration, int, error) {
+	var work, interruptions time.Duration
+	var count int
+	err := c.call("GetStats", []any{rangeType}, &work, &interruptions, &count)
+	return work, interruptions, count, err
+}
+
+// GetDetailedStats returns the statistics of a range
+func (c *Client) GetDetailedStats(rangeType string) (*models.DetailedStats, error) {
+	var stats *models.DetailedStats
+	err := c.call("GetDetailedStats", []any{rangeType}, &stats)
+	return stats, err
+}
+
+// GetDetailedStatsBetween returns the statistics of the days between two dates, empty when
+// the daemon cannot be reached
+func (c *Client) GetDetailedStatsBetween(startDate, endDate time.Time) *models.DetailedStats {
+	var stats *models.DetailedStats
+	if err := c.call("GetDetailedStatsBetween", []any{startDate, endDate}, &stats); err != nil || stats == nil {
+		return &models.DetailedStats{StartDate: startDate, EndDate: endDate, Policy: c.StatsPolicy()}
+	}
+	return stats
+}
+
+// GetDateRange returns the first and last day of a range
+func (c *Client) GetDateRange(rangeType string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	err := c.call("GetDateRange", []any{rangeType}, &start, &end)
+	return start, end, err
+}
+
+// StatsPolicy returns how the statistics count interruptions
+func (c *Client) StatsPolicy() models.StatsPolicy {
+	policy := models.StatsPolicy{Recovery: models.AssumedRecoveryTime}
+	c.call("StatsPolicy", nil, &policy)
+	return policy
+}
+
+// InterruptionCosts returns the cost of the interruptions in the statistics, false without
+// an hourly rate
+func (c *Client) InterruptionCosts(stats *models.DetailedStats) ([]models.InterruptionCost, models.InterruptionCost, bool) {
+	var costs []models.InterruptionCost
+	var total models.InterruptionCost
+	var ok bool
+	if err := c.call("InterruptionCosts", []any{stats}, &costs, &total, &ok); err != nil {
+		return nil, models.InterruptionCost{}, false
+	}
+	return costs, total, ok
+}
+
+// GetRefocusStats returns how long refocusing took after interruptions in a range
+func (c *Client) GetRefocusStats(rangeType string) ([]models.RefocusStats, error) {
+	var stats []models.RefocusStats
+	err := c.call("GetRefocusStats", []any{rangeType}, &stats)
+	return stats, err
+}
+
+// GetIssueStats returns the work on each issue in a range
+func (c *Client) GetIssueStats(rangeType string) ([]models.IssueStats, error) {
+	var stats []models.IssueStats
+	err := c.call("GetIssueStats", []any{rangeType}, &stats)
+	return stats, err
+}
+
+// GetContinuedTasks returns the tasks continued across sessions in a range
+func (c *Client) GetContinuedTasks(rangeType string) ([]models.ContinuedTask, error) {
+	var tasks []models.ContinuedTask
+	err := c.call("GetContinuedTasks", []any{rangeType}, &tasks)
+	return tasks, err
+}
+
+// GetQuarterReview returns the review of the quarter of a day
+func (c *Client) GetQuarterReview(day time.Time) (*models.QuarterReview, error) {
+	var review *models.QuarterReview
+	err := c.call("GetQuarterReview", []any{day}, &review)
+	return review, err
+}
+
+// GetRecap returns the recap of the last tracked day before a time, false when there is
+// none or the daemon cannot be reached
+func (c *Client) GetRecap(before time.Time) (*models.DailyRecap, bool) {
+	var recap *models.DailyRecap
+	var ok bool
+	if err := c.call("GetRecap", []any{before}, &recap, &ok); err != nil {
+		return nil, false
+	}
+	return recap, ok
+}
+
+// FocusGoals returns the focus goals and their progress
+func (c *Client) FocusGoals() (models.FocusGoals, error) {
+	var goals models.FocusGoals
+	err := c.call("FocusGoals", nil, &goals)
+	return goals, err
+}
+
+// UpdateRecords updates the personal records and returns the achievements unlocked
+func (c *Client) UpdateRecords(now time.Time) (*models.Records, []models.Achievement, error) {
+	var records *models.Records
+	var achievements []models.Achievement
+	err := c.call("UpdateRecords", []any{now}, &records, &achievements)
+	return records, achievements, err
+}
+
+// GetConfig returns the configuration of the daemon
+func (c *Client) GetConfig() *config.Config {
+	return c.config
+}
+
+// GetDataDir returns the data directory of the daemon
+func (c *Client) GetDataDir() string {
+	return c.dataDir
+}
+
+// MarkLaunch records a launch of the TUI and reports whether it is the first of the day
+func (c *Client) MarkLaunch(now time.Time) (bool, error) {
+	var first bool
+	err := c.call("MarkLaunch", []any{now}, &first)
+	return first, err
+}
+
+// ParseQuickEntry parses a quick entry typed for a day
+func (c *Client) ParseQuickEntry(text string, day time.Time) (*models.QuickEntry, error) {
+	var entry *models.QuickEntry
+	err := c.call("ParseQuickEntry", []any{text, day}, &entry)
+	return entry, err
+}
+
+// GetTaskSuggestions returns favorite and recent tasks to start, none when the daemon
+// cannot be reached
+func (c *Client) GetTaskSuggestions(now time.Time) (favorites, recent []models.TaskSuggestion) {
+	if err := c.call("GetTaskSuggestions", []any{now}, &favorites, &recent); err != nil {
+		return nil, nil
+	}
+	return favorites, recent
+}
+
+// GetTagUsage returns how often each interruption tag is used
+func (c *Client) GetTagUsage() ([]models.TagUsage, error) {
+	var usage []models.TagUsage
+	err := c.call("GetTagUsage", nil, &usage)
+	return usage, err
+}
+
+// GetLikelyTag returns the tag most likely for an interruption starting at a time, false
+// when there is none or the daemon cannot be reached
+func (c *Client) GetLikelyTag(at time.Time) (models.InterruptionTag, bool) {
+	var tag models.InterruptionTag
+	var ok bool
+	if err := c.call("GetLikelyTag", []any{at}, &tag, &ok); err != nil {
+		return "", false
+	}
+	return tag, ok
+}
+
+// LoadTagModel loads the model suggesting tags from descriptions
+func (c *Client) LoadTagModel() (*models.TagModel, error) {
+	var model *models.TagModel
+	err := c.call("LoadTagModel", nil, &model)
+	return model, err
+}
+
+// LearnTag teaches the tag model the tag of a description
+func (c *Client) LearnTag(description string, tag models.InterruptionTag) error {
+	return c.call("LearnTag", []any{description, tag})
+}
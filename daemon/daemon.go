@@ -0,0 +1,159 @@
+// Package daemon serves the storage of the data directory over a unix socket. The daemon
+// owns the data, and the TUI and commands connect to it as clients, so sessions keep
+// running in one place when the terminal that started them is closed.
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// serviceName is the name the store is served under
+const serviceName = "Store"
+
+// dialTimeout is how long connecting to the socket may take
+const dialTimeout = time.Second
+
+// Request is a call of a method of the Store interface with its arguments in JSON
+type Request struct {
+	Method string
+	Args   []json.RawMessage
+}
+
+// Response holds the results of a call in JSON, without the error
+type Response struct {
+	Results []json.RawMessage
+}
+
+var (
+	storeType = reflect.TypeOf((*storage.Store)(nil)).Elem()
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Server serves a store to clients, one call at a time so the storage has a single writer
+type Server struct {
+	store storage.Store
+	mu    sync.Mutex
+}
+
+// NewServer creates a server of the store
+func NewServer(store storage.Store) *Server {
+	return &Server{store: store}
+}
+
+// Do calls fn with the store while no client call runs, for other ways into the store
+// such as the control socket
+func (s *Server) Do(fn func(store storage.Store)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.store)
+}
+
+// service is the RPC service calling the store of the server
+type service struct {
+	server *Server
+}
+
+// Call calls a method of the Store interface. ForEachDay takes a function and is served
+// by Days instead.
+func (s *service) Call(req Request, resp *Response) error {
+	if _, ok := storeType.MethodByName(req.Method); !ok || req.Method == "ForEachDay" {
+		return fmt.Errorf("unknown method %q", req.Method)
+	}
+	method := reflect.ValueOf(s.server.store).MethodByName(req.Method)
+	methodType := method.Type()
+	if len(req.Args) != methodType.NumIn() {
+		return fmt.Errorf("%s takes %d arguments, got %d", req.Method, methodType.NumIn(), len(req.Args))
+	}
+
+	args := make([]reflect.Value, len(req.Args))
+	for i, data := range req.Args {
+		arg := reflect.New(methodType.In(i))
+		if err := json.Unmarshal(data, arg.Interface()); err != nil {
+			return fmt.Errorf("failed to parse argument %d of %s: %w", i+1, req.Method, err)
+		}
+		args[i] = arg.Elem()
+	}
+
+	var results []reflect.Value
+	s.server.Do(func(storage.Store) { results = method.Call(args) })
+
+	for _, result := range results {
+		if result.Type() == errorType {
+			if !result.IsNil() {
+				return result.Interface().(error)
+			}
+			continue
+		}
+		data, err := json.Marshal(result.Interface())
+		if err != nil {
+			return fmt.Errorf("failed to marshal result of %s: %w", req.Method, err)
+		}
+		resp.Results = append(resp.Results, data)
+	}
+	return nil
+}
+
+// Days returns the sessions of every tracked day in date order, for ForEachDay
+func (s *service) Days(_ struct{}, days *[]*models.DailySessions) error {
+	var err error
+	s.server.Do(func(store storage.Store) {
+		err = store.ForEachDay(func(day *models.DailySessions) error {
+			*days = append(*days, day)
+			return nil
+		})
+	})
+	return err
+}
+
+// Listen listens on the unix socket at path, replacing a socket left behind by a daemon
+// that did not shut down cleanly. Fails when another daemon is listening on it.
+func Listen(path string) (net.Listener, error) {
+	if conn, err := net.DialTimeout("unix", path, dialTimeout); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("a daemon is already running on %s", path)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// Serve serves the store to the clients connecting to the listener until it is closed
+func (s *Server) Serve(listener net.Listener) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(serviceName, &service{server: s}); err != nil {
+		return fmt.Errorf("failed to register store: %w", err)
+	}
+
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
@@ -0,0 +1,106 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// startDaemon serves a storage in a temporary data directory and connects a client to it
+func startDaemon(t *testing.T) (*storage.Storage, *Client, string) {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.DayStartHour = 4
+	store, err := storage.NewStorageWithConfig(cfg, dir)
+	assert.NoError(t, err)
+
+	path := store.DaemonSocketPath()
+	listener, err := Listen(path)
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go NewServer(store).Serve(listener)
+
+	client, err := Dial(path)
+	assert.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return store, client, path
+}
+
+// TestClient tests sessions saved through the daemon and read back from either side
+func TestClient(t *testing.T) {
+	store, client, _ := startDaemon(t)
+	assert.Equal(t, store.GetDataDir(), client.GetDataDir())
+	assert.Equal(t, 4, client.GetConfig().DayStartHour)
+
+	now := time.Date(2025, 3, 10, 2, 30, 0, 0, time.Local)
+	day := client.DayOf(now)
+	assert.Equal(t, store.DayOf(now), day)
+
+	entry := models.NewTimeEntry(models.EntryTypeStart, "Write the report")
+	entry.StartTime = now
+	session := models.NewSession(entry)
+	sessions, err := client.LoadDailySessions(day)
+	assert.NoError(t, err)
+	sessions.Date = day
+	sessions.Sessions = append(sessions.Sessions, session)
+	assert.NoError(t, client.SaveDailySessions(sessions))
+
+	// The daemon wrote the session, so the running timer is kept without the client
+	stored, err := store.LoadDailySessions(day)
+	assert.NoError(t, err)
+	if !assert.Len(t, stored.Sessions, 1) {
+		return
+	}
+	assert.Equal(t, session.ID, stored.Sessions[0].ID)
+	assert.Nil(t, stored.Sessions[0].End)
+
+	var descriptions []string
+	assert.NoError(t, client.ForEachDay(func(day *models.DailySessions) error {
+		for _, session := range day.Sessions {
+			descriptions = append(descriptions, session.Start.Description)
+		}
+		return nil
+	}))
+	assert.Equal(t, []string{"Write the report"}, descriptions)
+
+	start, end, err := client.GetDateRange("2025-03-01..2025-03-31")
+	assert.NoError(t, err)
+	wantStart, wantEnd, _ := store.GetDateRange("2025-03-01..2025-03-31")
+	assert.True(t, wantStart.Equal(start))
+	assert.True(t, wantEnd.Equal(end))
+	assert.Equal(t, store.StatsPolicy(), client.StatsPolicy())
+
+	// Errors of the store reach the client
+	_, _, err = client.GetDateRange("fortnight")
+	assert.Error(t, err)
+}
+
+// TestCall tests calls of methods outside the Store interface
+func TestCall(t *testing.T) {
+	_, client, _ := startDaemon(t)
+	assert.ErrorContains(t, client.call("ForEachDay", nil), "unknown method")
+	assert.ErrorContains(t, client.call("DaemonSocketPath", nil), "unknown method")
+	assert.ErrorContains(t, client.call("GetDateRange", nil), "takes 1 arguments")
+}
+
+// TestListen tests that a running daemon keeps its socket and a stale socket is replaced
+func TestListen(t *testing.T) {
+	_, _, path := startDaemon(t)
+	_, err := Listen(path)
+	assert.ErrorContains(t, err, "already running")
+
+	stale := filepath.Join(t.TempDir(), "daemon.sock")
+	assert.NoError(t, os.WriteFile(stale, nil, 0600))
+	listener, err := Listen(stale)
+	assert.NoError(t, err)
+	listener.Close()
+
+	_, err = Dial(stale)
+	assert.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/control"
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/scheduler"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestScheduledTasks tests the tasks of scheduled jobs against a store
+func TestScheduledTasks(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewStorageWithConfig(&config.Config{BackupEnabled: true}, dir)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	day := store.DayOf(now)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{
+		models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour), Description: "Release"}),
+	}}))
+	tasks := scheduledTasks(store)
+
+	// Backups without a remote target back up the changed days
+	assert.NoError(t, tasks[scheduler.TaskBackup](config.ScheduledJob{Task: scheduler.TaskBackup}, now))
+	backups, err := os.ReadDir(filepath.Join(dir, "backups"))
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+	assert.NoError(t, tasks[scheduler.TaskCompact](config.ScheduledJob{Task: scheduler.TaskCompact}, now))
+
+	// Reports are written in the data directory with the date in the name
+	job := config.ScheduledJob{Task: scheduler.TaskReport, Path: "reports-{date}.md", Range: "day"}
+	assert.NoError(t, tasks[scheduler.TaskReport](job, now))
+	report, err := os.ReadFile(filepath.Join(dir, "reports-"+now.Format("2006-01-02")+".md"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(report), "| Release |")
+
+	assert.Error(t, tasks[scheduler.TaskEmailReport](config.ScheduledJob{Task: scheduler.TaskEmailReport}, now))
+	assert.Error(t, tasks[scheduler.TaskSync](config.ScheduledJob{Task: scheduler.TaskSync, Target: "toggl"}, now))
+
+	var log bytes.Buffer
+	at := time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)
+	logScheduled(&log, scheduler.Result{Job: "report"}, at)
+	logScheduled(&log, scheduler.Result{Job: "sync", Err: errors.New("offline")}, at)
+	assert.Equal(t, "2025-03-03 08:00 report done\n2025-03-03 08:00 sync failed: offline\n", log.String())
+}
+
+// TestSerializedTasks tests scheduled tasks of the daemon run inside the function
+// serializing them with client calls
+func TestSerializedTasks(t *testing.T) {
+	inside := false
+	do := func(fn func(storage.Store)) {
+		inside = true
+		defer func() { inside = false }()
+		fn(nil)
+	}
+	runs := 0
+	tasks := serializedTasks(map[string]scheduler.Task{
+		scheduler.TaskBackup: func(job config.ScheduledJob, now time.Time) error {
+			runs++
+			assert.True(t, inside)
+			return errors.New("disk full")
+		},
+	}, do)
+
+	assert.EqualError(t, tasks[scheduler.TaskBackup](config.ScheduledJob{Task: scheduler.TaskBackup}, time.Now()), "disk full")
+	assert.Equal(t, 1, runs)
+	assert.False(t, inside)
+}
+
+// TestRunDaemon tests commands going through a running daemon and the daemon refusing
+// invalid jobs
+func TestRunDaemon(t *testing.T) {
+	store, err := storage.NewStorageWithConfig(&config.Config{}, t.TempDir())
+	assert.NoError(t, err)
+	assert.Nil(t, connectDaemon(store))
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	var log bytes.Buffer
+	go func() { done <- runDaemon(store, &log, signals) }()
+
+	var client storage.Store
+	for i := 0; i < 100 && client == nil; i++ {
+		if connected := connectDaemon(store); connected != nil {
+			client = connected
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !assert.NotNil(t, client) {
+		return
+	}
+	assert.IsType(t, client, commandStore(store))
+
+	now := time.Now()
+	_, err = startSession(client, now, "Review the design", "", 0)
+	assert.NoError(t, err)
+	status, err := buildStatus(store, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "Review the design", status.Description)
+
+	// Commands on the control socket change the same sessions
+	commands, err := control.Dial(store.ControlSocketPath())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer commands.Close()
+	status, err = commands.Interrupt("meeting", "Standup")
+	assert.NoError(t, err)
+	assert.Equal(t, StateInterrupted, status.State)
+	_, err = commands.Start("Another task", "")
+	assert.ErrorContains(t, err, "already active")
+	_, err = commands.Return()
+	assert.NoError(t, err)
+	status, err = commands.End()
+	if assert.NoError(t, err) {
+		assert.Equal(t, StateIdle, status.State)
+	}
+
+	signals <- os.Interrupt
+	assert.NoError(t, <-done)
+	assert.Equal(t, "Serving "+store.DaemonSocketPath()+", commands on "+store.ControlSocketPath()+"\n", log.String())
+	assert.NoFileExists(t, store.DaemonSocketPath())
+	assert.NoFileExists(t, store.ControlSocketPath())
+
+	store.GetConfig().Schedule = []config.ScheduledJob{{Task: "defrag", Cron: "@daily"}}
+	assert.Error(t, runDaemon(store, &bytes.Buffer{}, signals))
+	assert.NoFileExists(t, store.DaemonSocketPath())
+}
+
+// TestPromptActivity tests prompting to start a session when back at the workstation
+func TestPromptActivity(t *testing.T) {
+	store, err := storage.NewStorageWithConfig(&config.Config{}, t.TempDir())
+	assert.NoError(t, err)
+
+	var notified []string
+	notify := func(title, message string) error {
+		notified = append(notified, message)
+		return nil
+	}
+	now := time.Now()
+	event := integrations.ActivityEvent{Reason: integrations.ActivityUnlock, Away: 95 * time.Minute, At: now}
+
+	var log bytes.Buffer
+	promptActivity(store, event, &log, notify)
+	assert.Equal(t, []string{"Away for 1h 35m, start a session to track your time"}, notified)
+	assert.Contains(t, log.String(), "back after 1h 35m (unlock), no session")
+
+	// Notifications only when enabled
+	log.Reset()
+	promptActivity(store, event, &log, nil)
+	assert.Len(t, notified, 1)
+	assert.Contains(t, log.String(), "no session")
+
+	// Nothing to prompt for while tracking
+	log.Reset()
+	_, err = startSession(store, now, "Review the design", "", 0)
+	assert.NoError(t, err)
+	promptActivity(store, event, &log, notify)
+	assert.Len(t, notified, 1)
+	assert.Empty(t, log.String())
+
+	notify = func(title, message string) error { return errors.New("no notify-send") }
+	_, err = endSession(store, now)
+	if assert.NoError(t, err) {
+		promptActivity(store, event, &log, notify)
+		assert.Contains(t, log.String(), "failed to send notification: no notify-send")
+	}
+}
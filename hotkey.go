@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/control"
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// hotkeyTitle is the title of the notifications shown after a hotkey
+const hotkeyTitle = "Interruption Tracker"
+
+// runHotkey runs the action of a global hotkey and reports the outcome in a desktop
+// notification, as no terminal is in sight when a hotkey is pressed
+func runHotkey(store *storage.Storage, args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: interruption-tracker hotkey toggle|interrupt|interrupt:TAG|return")
+		os.Exit(2)
+	}
+
+	message, err := runHotkeyAction(commandStore(store), args[0], time.Now())
+	if err != nil {
+		integrations.Notify(hotkeyTitle, err.Error())
+		fmt.Fprintf(os.Stderr, "Error running hotkey: %v\n", err)
+		os.Exit(1)
+	}
+	integrations.Notify(hotkeyTitle, message)
+	fmt.Println(message)
+}
+
+// runHotkeyAction interrupts or returns from the active session for a hotkey action and
+// describes what was done
+func runHotkeyAction(store storage.Store, text string, now time.Time) (string, error) {
+	action, tag, err := integrations.ParseHotkeyAction(text)
+	if err != nil {
+		return "", err
+	}
+
+	if action == integrations.HotkeyToggle {
+		status, err := buildStatus(store, now)
+		if err != nil {
+			return "", err
+		}
+		switch status.State {
+		case StateIdle:
+			return "", fmt.Errorf("no active session to interrupt")
+		case StateInterrupted:
+			action = integrations.HotkeyReturn
+		default:
+			action = integrations.HotkeyInterrupt
+		}
+	}
+
+	req := control.Request{Command: control.CommandReturn}
+	if action == integrations.HotkeyInterrupt {
+		req = control.Request{Command: control.CommandInterrupt, Tag: tag}
+	}
+	resp := handleControl(store, req, now)
+	if !resp.OK {
+		return "", errors.New(resp.Error)
+	}
+	return resp.Message, nil
+}
+
+// runHotkeys prints the configured hotkeys as bindings for the hotkey tool of the platform
+func runHotkeys(store *storage.Storage, args []string) {
+	hotkeyFlags := flag.NewFlagSet("hotkeys", flag.ExitOnError)
+	format := hotkeyFlags.String("format", integrations.DefaultHotkeyFormat(), "Hotkey tool to write bindings for (sxhkd, gnome, skhd, autohotkey)")
+	hotkeyFlags.Parse(args)
+
+	if err := writeHotkeys(os.Stdout, store, *format, hotkeyCommand()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing hotkeys: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeHotkeys writes the bindings of the configured hotkeys for a hotkey tool, running
+// command with the hotkey subcommand
+func writeHotkeys(w io.Writer, store *storage.Storage, format string, command []string) error {
+	bindings, err := integrations.HotkeyBindings(store.GetConfig())
+	if err != nil {
+		return err
+	}
+	text, err := integrations.HotkeyConfig(format, bindings, command)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, text)
+	return err
+}
+
+// hotkeyCommand returns the command hotkeys run: this executable with the -config and
+// -data flags it was given, made absolute, and the hotkey subcommand
+func hotkeyCommand() []string {
+	executable, err := os.Executable()
+	if err != nil {
+		executable = "interruption-tracker"
+	}
+	command := []string{executable}
+	if *configFlag != "" {
+		command = append(command, "-config="+absPath(*configFlag))
+	}
+	if *dataFlag != "" {
+		command = append(command, "-data="+absPath(*dataFlag))
+	}
+	return append(command, "hotkey")
+}
+
+// absPath returns the absolute form of path, or path when it cannot be resolved
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunHotkeyAction tests toggling between work and interruptions with a hotkey
+func TestRunHotkeyAction(t *testing.T) {
+	store, err := storage.NewStorageWithConfig(&config.Config{}, t.TempDir())
+	assert.NoError(t, err)
+	now := store.DayOf(time.Now()).Add(9 * time.Hour)
+
+	_, err = runHotkeyAction(store, "toggle", now)
+	assert.EqualError(t, err, "no active session to interrupt")
+
+	_, err = startSession(store, now, "Plan the sprint", "", 0)
+	assert.NoError(t, err)
+
+	now = now.Add(20 * time.Minute)
+	message, err := runHotkeyAction(store, "toggle", now)
+	assert.NoError(t, err)
+	assert.Contains(t, message, "Interrupted by")
+	status, _ := buildStatus(store, now)
+	assert.Equal(t, StateInterrupted, status.State)
+
+	now = now.Add(5 * time.Minute)
+	message, err = runHotkeyAction(store, "toggle", now)
+	assert.NoError(t, err)
+	assert.Equal(t, `Back to work on "Plan the sprint"`, message)
+
+	message, err = runHotkeyAction(store, "interrupt:meeting", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "Interrupted by meeting since 09:25; return when back", message)
+	_, err = runHotkeyAction(store, "return", now.Add(time.Minute))
+	assert.NoError(t, err)
+
+	_, err = runHotkeyAction(store, "pause", now)
+	assert.Error(t, err)
+}
+
+// TestWriteHotkeys tests the bindings written for the configured hotkeys
+func TestWriteHotkeys(t *testing.T) {
+	store, err := storage.NewStorageWithConfig(&config.Config{Hotkeys: map[string]string{"toggle": "ctrl+shift+space"}}, t.TempDir())
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.NoError(t, writeHotkeys(&out, store, "sxhkd", []string{"/usr/bin/interruption-tracker", "hotkey"}))
+	assert.Equal(t, "# Add to ~/.config/sxhkd/sxhkdrc\nctrl + shift + space\n\t/usr/bin/interruption-tracker hotkey toggle\n", out.String())
+
+	assert.Error(t, writeHotkeys(&out, store, "unknown", nil))
+}
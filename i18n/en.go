@@ -0,0 +1,512 @@
+package i18n
+
+// english is the English catalog, the fallback for messages missing from other catalogs
+var english = Catalog{
+	// Durations
+	"duration.decimal":         "%.1fh",
+	"duration.hours_minutes":   "%dh %dm",
+	"duration.minutes_seconds": "%dm %ds",
+	"duration.minutes":         "%dm",
+	"duration.seconds":         "%ds",
+
+	// Buttons
+	"button.back":   "Back",
+	"button.cancel": "Cancel",
+	"button.add":    "Add",
+	"button.log":    "Log",
+	"button.move":   "Move",
+	"button.no":     "No",
+	"button.ok":     "OK",
+	"button.save":   "Save",
+	"button.show":   "Show",
+	"button.start":  "Start",
+	"button.submit": "Submit",
+	"button.update": "Update",
+	"button.yes":    "Yes",
+
+	// Table columns
+	"column.avg_time":      "Avg Time",
+	"column.count":         "Count",
+	"column.description":   "Description",
+	"column.duration":      "Duration",
+	"column.end":           "End",
+	"column.end_time":      "End Time",
+	"column.interrupt":     "Interrupt",
+	"column.interruptions": "Interruptions",
+	"column.pattern":       "Pattern",
+	"column.recovery":      "Recovery",
+	"column.start":         "Start",
+	"column.start_time":    "Start Time",
+	"column.sub_session":   "Sub-Session",
+	"column.total":         "Total",
+	"column.type":          "Type",
+	"column.assumed":       "Assumed",
+	"column.average":       "Average",
+	"column.days":          "Days",
+	"column.issue":         "Issue",
+	"column.measured":      "Measured avg",
+	"column.rate":          "Interruptions/h",
+	"column.refocused":     "Refocused",
+	"column.returns":       "Returns",
+	"column.sessions":      "Sessions",
+	"column.task":          "Task",
+	"column.title":         "Title",
+	"sort.ascending":       "ascending",
+	"sort.descending":      "descending",
+
+	// Header and key help
+	"header.goal_today":   "Today %s / %s",
+	"header.safe_mode":    "SAFE MODE",
+	"header.state":        "State: %s",
+	"help.press":          "Press %s",
+	"help.recovery_over":  "Recovery is over, back to focused work. %s",
+	"help.replay":         "Replaying, press (space) pause, (+/-) speed, (q)uit",
+	"reminder.away":       "Away for %s, press (s) to start tracking",
+	"reminder.no_session": "No session for %s, press (s) to start tracking",
+	"replay.badge":        "REPLAY",
+	"replay.finished":     "finished",
+	"replay.paused":       "paused",
+
+	// Key bindings
+	"keys.title":          "Key bindings",
+	"keys.close":          "Press (Esc) or (%s) to close",
+	"keys.page.main":      "Sessions",
+	"keys.page.stats":     "Statistics",
+	"keys.page.charts":    "Charts",
+	"keys.page.calendar":  "Calendar",
+	"keys.page.day":       "Calendar day",
+	"keys.page.records":   "Records",
+	"keys.page.quarter":   "Quarter review",
+	"keys.page.continued": "Linked tasks",
+	"keys.page.tags":      "Tags",
+	"keys.page.settings":  "Settings",
+	"keys.start":          "start session",
+	"keys.end":            "end session",
+	"keys.interrupt":      "interrupt",
+	"keys.back_to_work":   "back to work",
+	"keys.defer":          "defer interruption",
+	"keys.focus":          "focus mode",
+	"keys.details":        "session details",
+	"keys.rename":         "rename session",
+	"keys.resume":         "undo end",
+	"keys.delete":         "delete session",
+	"keys.move":           "move session to another day",
+	"keys.add":            "add past session",
+	"keys.meetings":       "plan today's meetings",
+	"keys.mark":           "mark session",
+	"keys.bulk":           "bulk actions on marked sessions",
+	"keys.sort":           "sort by column",
+	"keys.tags":           "tags",
+	"keys.settings":       "settings",
+	"keys.stats":          "statistics",
+	"keys.help":           "key bindings",
+	"keys.quit":           "quit",
+	"keys.day":            "day",
+	"keys.week":           "week",
+	"keys.month":          "month",
+	"keys.quarter":        "quarter",
+	"keys.year":           "year",
+	"keys.all":            "all time",
+	"keys.range":          "custom range",
+	"keys.productivity":   "productivity chart",
+	"keys.interruptions":  "interruptions chart",
+	"keys.trends":         "trends chart",
+	"keys.calendar":       "calendar",
+	"keys.records":        "records",
+	"keys.okr":            "OKR quarter review",
+	"keys.linked":         "linked tasks",
+	"keys.back":           "back",
+	"keys.previous_chart": "previous chart",
+	"keys.next_chart":     "next chart",
+	"keys.back_to_stats":  "back to statistics",
+	"keys.open_day":       "open day",
+	"keys.back_calendar":  "back to calendar",
+	"keys.prev_quarter":   "previous quarter",
+	"keys.next_quarter":   "next quarter",
+	"keys.archive_tag":    "archive/restore selected tag",
+	"keys.prune_tags":     "archive dead tags",
+	"keys.back_unsaved":   "back without saving",
+
+	// Alerts
+	"alert.interruption": "Interrupted for over %s (%s), time to get back to work",
+	"alert.session":      "Session %q has run past its planned %s",
+
+	// Session state and linear timelines
+	"state.idle":            "No session",
+	"state.working":         "Working",
+	"state.interrupted":     "Interrupted",
+	"state.recovery":        "Recovering",
+	"timeline.working":      "Working",
+	"timeline.interrupted":  "Interrupted",
+	"timeline.recovery":     "Recovery",
+	"timeline.continues":    "Continues past midnight",
+	"timeline.back_to_work": "Back to work",
+	"timeline.no_activity":  "No activity",
+	"timeline.scheduled":    "Scheduled hours: %s-%s",
+	"timeline.day_off":      "Not a scheduled working day",
+	"timeline.now":          "now",
+	"timeline.title":        "Daily Activity Timeline (24-Hour View)",
+	"legend.back_to_work":   "Back to Work",
+	"legend.continues":      "Continues Past Midnight",
+	"legend.interrupted":    "Interrupted",
+	"legend.no_activity":    "No Activity",
+	"legend.off_hours":      "Off Hours",
+	"legend.paused":         "Paused",
+	"legend.recovery":       "Recovery",
+	"legend.working":        "Working",
+	"timer.interrupted":     "Interrupted for %s",
+	"timer.interrupted_tag": "Interrupted for %s (%s)",
+	"timer.recovery":        "Recovery, %s left",
+
+	// Status bar
+	"status.activity_watcher_stopped":    "Activity watcher stopped: %v",
+	"status.already_interrupted":         "Already interrupted. Press 'b' to return",
+	"status.auto_end_failed":             "Error auto-ending session: %v",
+	"status.auto_ended":                  "Session auto-ended at %s",
+	"status.continue_failed":             "Error continuing session: %v",
+	"status.continued_failed":            "Error loading continued tasks: %v",
+	"status.deferral_failed":             "Error recording deferral: %v",
+	"status.deferred":                    "Interruption deferred (%.0f%% deflected today)",
+	"status.delete_failed":               "Error deleting session: %v",
+	"status.description_updated":         "Description updated",
+	"status.end_failed":                  "Error ending session: %v",
+	"status.end_while_interrupted":       "Cannot end session while interrupted. Return from interruption first",
+	"status.error":                       "Error: %v",
+	"status.focus_mode_failed":           "Error saving focus mode: %v",
+	"status.focus_mode_off":              "Focus mode off",
+	"status.focus_mode_on":               "Focus mode on, interruptions are flagged as despite DND",
+	"status.focus_watcher_stopped":       "Focus watcher stopped: %v",
+	"status.interrupted_despite_focus":   "Session interrupted despite focus mode",
+	"status.interruption_failed":         "Error recording interruption: %v",
+	"status.interruptions_retagged":      "%d interruption(s) re-tagged as %s",
+	"status.key_bindings_ignored":        "Key bindings ignored: %v",
+	"status.invalid_date":                "Invalid date, use YYYY-MM-DD",
+	"status.load_day_failed":             "Error loading %s: %v",
+	"status.logged":                      "Logged %s",
+	"status.marks_cleared":               "Marks cleared",
+	"status.meeting_over":                "%s is over, back to work",
+	"status.meeting_started":             "In %s until %s, recorded as a meeting interruption",
+	"status.meetings_declared":           "%d meeting(s) declared for today",
+	"status.merge_needs_two":             "Mark at least two sessions to merge",
+	"status.move_failed":                 "Error moving session: %v",
+	"status.move_needs_end":              "End the session before moving it to another day",
+	"status.no_active_session":           "No active session",
+	"status.no_active_sub_session":       "No active sub-session",
+	"status.no_column":                   "No column %d, the table has %d",
+	"status.no_config":                   "No configuration loaded",
+	"status.no_session_for_focus":        "No active session to turn focus mode on for",
+	"status.no_session_selected":         "No session selected",
+	"status.no_session_to_defer":         "No active session to defer an interruption in",
+	"status.no_session_to_edit":          "No active session to edit",
+	"status.no_session_to_end":           "No active session to end",
+	"status.no_session_to_interrupt":     "No active session to interrupt",
+	"status.no_sub_session_to_interrupt": "No active sub-session to interrupt",
+	"status.none_marked":                 "No sessions marked. Press Space to mark sessions",
+	"status.not_interrupted":             "Not currently interrupted",
+	"status.notification_failed":         "Failed to send notification: %v",
+	"status.quit_failed":                 "Error closing session, not quitting: %v",
+	"status.records_failed":              "Error updating records: %v",
+	"status.recovery_end_failed":         "Error saving end of recovery: %v",
+	"status.reminder_failed":             "Failed to send reminder: %v",
+	"status.reminders_disabled":          "Reminders disabled: %v",
+	"status.scheduled_failed":            "Scheduled %s failed: %v",
+	"status.scheduled_done":              "Ran scheduled %s",
+	"status.rename_failed":               "Error updating description: %v",
+	"status.resume_failed":               "Error resuming session: %v",
+	"status.resume_not_ended":            "Session is not ended, no need to resume",
+	"status.resume_while_active":         "Cannot resume while a session is already active",
+	"status.return_failed":               "Error recording return: %v",
+	"status.returned":                    "Returned from interruption",
+	"status.save_failed":                 "Error saving session: %v",
+	"status.session_deleted":             "Session deleted",
+	"status.session_ended":               "Session ended",
+	"status.session_interrupted":         "Session interrupted",
+	"status.session_resumed":             "Session resumed with a new time period",
+	"status.session_started":             "Session started",
+	"status.sessions_deleted":            "%d session(s) deleted",
+	"status.sessions_marked":             "%d session(s) marked. Press (m) for bulk actions, Space to mark more",
+	"status.sessions_merged":             "%d sessions merged",
+	"status.sessions_moved":              "%d session(s) moved to %s",
+	"status.settings_saved":              "Settings saved",
+	"status.slack_disabled":              "Slack focus disabled: %v",
+	"status.slack_failed":                "Slack update failed: %v",
+	"status.sorted_by":                   "Sorted by %s, %s",
+	"status.start_while_active":          "Cannot start a new session while one is active",
+	"status.tag_usage_failed":            "Error loading tag usage: %v",
+	"status.toggl_disabled":              "Toggl sync disabled: %v",
+	"status.toggl_failed":                "Toggl sync failed: %v",
+	"status.webhook_failed":              "Webhook failed: %v",
+
+	// Dialogs
+	"dialog.description":              "Description:",
+	"dialog.edit_description":         "Edit Activity Description",
+	"dialog.estimate":                 "Estimate:    ",
+	"dialog.estimate_placeholder":     "optional, e.g. 45m or 1h30m",
+	"dialog.interruption_description": "Enter Interruption Description",
+	"dialog.invalid_estimate":         "Invalid estimate, use e.g. 45m or 1h30m",
+	"dialog.select_deferred_tag":      "Select deferred interruption type",
+	"dialog.select_tag":               "Select interruption type",
+	"dialog.start_session":            "Start Session",
+	"dialog.suggested_tag":            "%s (suggested: %s):",
+	"dialog.tag":                      "Tag:",
+	"dialog.task_placeholder":         "type to search tasks or enter a new one",
+	"dialog.tasks_title":              "Tasks: 1-9 or Down to pick",
+	"tag.call":                        "Call",
+	"tag.meeting":                     "Meeting",
+	"tag.other":                       "Other (custom)",
+	"tag.spouse":                      "Spouse",
+
+	// Session details
+	"details.active":             "Active",
+	"details.attachment":         "Attachment: %s",
+	"details.back_to_work":       "Back to work: %s",
+	"details.description":        "Description: %s",
+	"details.duration":           "Duration: %s",
+	"details.end":                "End: %s",
+	"details.focus_mode":         "Focus mode: %s, %d interruption(s) despite DND",
+	"details.interruption":       "Interruption #%d:",
+	"details.interruptions_of":   "Interruptions for Sub-Session #%d:",
+	"details.issue":              "Issue: %s",
+	"details.no_description":     "(No description)",
+	"details.no_interruptions":   "No interruptions recorded for this sub-session.",
+	"details.ongoing":            "(ongoing)",
+	"details.project":            "Project: %s",
+	"details.resolving":          "(resolving...)",
+	"details.select_sub_session": "Select a sub-session to view interruption details",
+	"details.session":            "Session: %s",
+	"details.start":              "Start: %s",
+	"details.title":              "Session Details",
+	"details.total_duration":     "Total Duration: %s",
+	"details.type":               "Type: %s",
+	"details.unavailable":        "(unavailable)",
+	"details.unknown":            "Unknown",
+
+	// Statistics page
+	"stats.completed_tasks":        "Completed Tasks",
+	"stats.interruption_breakdown": "Interruption Breakdown",
+	"stats.recurring_tasks":        "Recurring Tasks",
+	"stats.title":                  "Statistics",
+	"stats.for":                    "Statistics for %s:",
+
+	// Daily recap
+	"recap.above_average":    "%.0f%% more focus than your 7-day average of %s.",
+	"recap.below_average":    "%.0f%% less focus than your 7-day average of %s.",
+	"recap.button":           "Let's go",
+	"recap.focus":            "Focus: %s in %d session(s)",
+	"recap.interruptions":    "Interruptions: %d (%s)",
+	"recap.no_comparison":    "No earlier days this week to compare with.",
+	"recap.no_interruptions": "Interruptions: none",
+	"recap.on_average":       "Right on your 7-day average of %s.",
+	"recap.score":            "Productivity score: %.0f/100",
+	"recap.weekday":          "%s's recap (%s)",
+	"recap.yesterday":        "Yesterday's recap (%s)",
+
+	// Auto-end
+	"autoend.end_at":       "End at %s",
+	"autoend.keep_running": "Keep running",
+	"autoend.notice":       "%q was still running and has been ended at %s.",
+	"autoend.prompt":       "%q has been running since %s. End it at %s?",
+
+	// Bulk actions
+	"bulk.clear_marks":    "Clear marks",
+	"bulk.confirm_delete": "Delete these sessions?",
+	"bulk.confirm_merge":  "Merge these sessions into one?",
+	"bulk.confirm_move":   "Move these sessions to %s?",
+	"bulk.confirm_retag":  "Re-tag the interruptions of these sessions as %q?",
+	"bulk.delete":         "Delete",
+	"bulk.marked":         "%d session(s) marked",
+	"bulk.merge":          "Merge",
+	"bulk.move":           "Move to day",
+	"bulk.move_title":     "Move Sessions",
+	"bulk.move_to_day":    "Move to day:",
+	"bulk.no_description": "(no description)",
+	"bulk.now":            "now",
+	"bulk.retag":          "Re-tag",
+	"bulk.retag_prompt":   "Re-tag all interruptions of the marked sessions as:",
+	"bulk.session_line":   "%s-%s  %s  %s, %d interruption(s)",
+	"bulk.total":          "%d session(s), %s of work",
+
+	// Sync conflicts
+	"conflict.and":         "and",
+	"conflict.copy":        "A sync tool left a conflicting copy of %s (%s).\n\nThis machine: %s\nCopy: %s",
+	"conflict.delete_copy": "Delete copy",
+	"conflict.duplicate":   "Session %s is stored under %s, most likely after syncing two machines.\nKeep it under one day so it is not counted twice.",
+	"conflict.keep_in":     "Keep in %s",
+	"conflict.keep_ours":   "Keep this machine's",
+	"conflict.later":       "Later",
+	"conflict.latest":      "(latest)",
+	"conflict.merge_both":  "Merge both",
+	"conflict.no_sessions": "no sessions",
+	"conflict.summary":     "%d session(s), %s of work, last change %s",
+	"conflict.title":       "Sync conflict %d of %d",
+	"conflict.unreadable":  "A sync tool left %s, which cannot be read: %v",
+	"conflict.use_copy":    "Use copy",
+
+	// Settings
+	"settings.color_theme":       "Color theme",
+	"settings.accessible":        "Accessible mode (text instead of colors)",
+	"settings.columns":           "Session columns (comma separated)",
+	"settings.currency":          "Currency",
+	"settings.custom_tags":       "Custom tags (comma separated)",
+	"settings.help":              "Tab moves between fields, %s",
+	"settings.hourly_rate":       "Hourly rate for interruption cost",
+	"settings.invalid_rate":      "hourly rate must be a positive number",
+	"settings.invalid_recovery":  "recovery time must be at least 1 minute",
+	"settings.language":          "Language",
+	"settings.twelve_hour_clock": "12-hour clock",
+	"settings.read_only":         "The configuration is read-only in safe mode",
+	"settings.read_only_back":    "The configuration is read-only in safe mode, %s",
+	"settings.recovery_notify":   "Notify when recovery ends",
+	"settings.recovery_time":     "Recovery time (minutes)",
+	"settings.reminder":          "Remind to start tracking",
+	"settings.reminder_desktop":  "Desktop notification for reminders",
+	"settings.save_failed":       "Error saving configuration: %v",
+	"settings.sort":              "Sort sessions by",
+	"settings.sort_ascending":    "Sort ascending",
+	"settings.title":             "Settings",
+	"settings.unknown_locale":    "unknown language %q",
+	"settings.unknown_sort":      "unknown sort column %q",
+
+	// Reports
+	"report.active":       "active",
+	"report.amount":       "Cost",
+	"report.attachments":  "Attachments",
+	"report.cost":         "Cost of interruptions",
+	"report.focused_work": "Focused work",
+	"report.generated":    "Generated",
+	"report.lost_time":    "Lost time",
+	"report.no_sessions":  "No sessions in this range.",
+	"report.project":      "Project",
+	"report.title":        "Work report",
+	"report.work":         "Work",
+
+	// Date ranges
+	"range.all":              "All Time",
+	"range.custom":           "%s to %s",
+	"range.day":              "Today",
+	"range.end":              "End:",
+	"range.end_before_start": "End must not be before start",
+	"range.last_week":        "Last Week",
+	"range.month":            "This Month",
+	"range.quarter":          "This Quarter",
+	"range.start":            "Start:",
+	"range.title":            "Date Range",
+	"range.week":             "This Week",
+	"range.year":             "This Year",
+
+	// Calendar
+	"calendar.day_focus": "%s: %s focused",
+	"calendar.day_title": "%s - %d session(s), %s focused",
+	"calendar.less":      "Less",
+	"calendar.more":      "More",
+	"calendar.title":     "Focus Calendar (last %d weeks)",
+
+	// Continued tasks
+	"continued.active":        "(active)",
+	"continued.combined":      "Combined: %s of work over %d day(s), %d interruption(s) (%s)",
+	"continued.interruptions": "%d interruption(s)",
+	"continued.none":          "No tasks were continued across days in this range.\n\nSessions are continued in a linked session when rollover_mode is \"split\".",
+	"continued.title":         "Tasks Continued Across Days - %s",
+
+	// Records
+	"records.achievements":    "Achievements",
+	"records.current_streak":  "Current streak",
+	"records.daily_goal":      "(daily goal: %s of focus)",
+	"records.days":            "%d day(s)",
+	"records.fewest":          "Fewest interruptions",
+	"records.fewest_unset":    "reach your goal to set this record",
+	"records.fewest_value":    "%d on a goal day",
+	"records.longest_block":   "Longest focus block",
+	"records.longest_streak":  "Longest streak",
+	"records.most_focus":      "Most focused day",
+	"records.new":             "NEW!",
+	"records.no_achievements": "None yet - keep focusing!",
+	"records.personal_bests":  "Personal bests",
+	"records.streaks":         "Streaks",
+	"records.title":           "Records & Achievements",
+
+	// Quarter review
+	"quarter.goal_attainment": "Goal attainment",
+	"quarter.goal_days":       "%d/%d goal days",
+	"quarter.goal_days_of":    "%d of %d tracked day(s) (%.0f%%)",
+	"quarter.load_failed":     "Error loading quarter: %v",
+	"quarter.no_projects":     "No focused work this quarter",
+	"quarter.no_tracked_days": "no tracked days",
+	"quarter.summary":         "Summary",
+	"quarter.title":           "Quarter Review - %s",
+	"quarter.top_projects":    "Top projects",
+	"quarter.total_focus":     "Total focus",
+	"quarter.weekly_focus":    "Weekly focus",
+
+	// Tag management
+	"tags.active":           "active",
+	"tags.archived":         "archived",
+	"tags.builtin":          "built-in",
+	"tags.builtin_archive":  "Built-in tags cannot be archived",
+	"tags.column.kind":      "Kind",
+	"tags.column.last_used": "Last Used",
+	"tags.column.status":    "Status",
+	"tags.column.tag":       "Tag",
+	"tags.column.uses":      "Uses",
+	"tags.custom":           "custom",
+	"tags.dead":             "dead",
+	"tags.never":            "never",
+	"tags.pruned":           "Archived %d dead tag(s)",
+	"tags.tag_archived":     "Tag %s archived",
+	"tags.tag_restored":     "Tag %s restored",
+	"tags.title":            "Interruption Tags",
+
+	// Quick entry
+	"quick.label":       "Log:",
+	"quick.placeholder": "e.g. worked on report 9-11 with 2 interruptions",
+	"quick.title":       "Quick Entry",
+
+	// Setup wizard
+	"wizard.backup_interval": "Backup interval (days)",
+	"wizard.backups":         "Daily file backups",
+	"wizard.data_directory":  "Data directory",
+	"wizard.encryption":      "Encrypt data files",
+	"wizard.intro":           "Let's set things up. Every setting can be changed later in the configuration file.\nTab moves between fields, Esc skips the setup.",
+	"wizard.passphrase":      "Encryption passphrase",
+	"wizard.skip":            "Skip (use defaults)",
+	"wizard.title":           "Welcome to Interruption Tracker - Setup",
+
+	// Charts
+	"charts.advice_high":         "• Maintain current work patterns\n• Consider optimizing work hours\n• Share techniques with team",
+	"charts.advice_low":          "• Reduce interruptions\n• Consider time blocking\n• Create a do-not-disturb system",
+	"charts.advice_medium":       "• Group similar tasks\n• Schedule focused work periods\n• Manage interruption sources",
+	"charts.analysis":            "Productivity Analysis",
+	"charts.declining":           "↓ Declining",
+	"charts.improving":           "↑ Improving",
+	"charts.interruptions_title": "Interruption Analysis (%s)",
+	"charts.no_trends":           "Not enough historical data available to display trends.\nTrack more days to see productivity patterns over time.",
+	"charts.productivity_title":  "Productivity Visualizations (%s)",
+	"charts.recommendations":     "Recommendations:",
+	"charts.score":               "Productivity Score (0-100):",
+	"charts.score_basis":         "Score based on:\n• Focused work time\n• Interruption frequency\n• Recovery time impact",
+	"charts.stable":              "→ Stable",
+	"charts.trends_title":        "Productivity Trends (%s)",
+
+	// Console statistics
+	"console.breakdown":       "Interruption breakdown:",
+	"console.continued":       "Tasks continued across days:",
+	"console.cost":            "Cost of interruptions (%s per hour, recovery included):",
+	"console.despite_dnd":     "Interruptions despite DND: %d (%.1f per hour)",
+	"console.estimates":       "Estimation accuracy: %.2fx of estimate over %d session(s), %d within %.0f%%",
+	"console.focus_mode":      "Focus mode (do not disturb): %s",
+	"console.issues":          "Sessions by issue:",
+	"console.longest_block":   "Longest focus block: %s (%s)",
+	"console.outside_dnd":     "Interruptions outside DND: %d (%.1f per hour)",
+	"console.productive_hour": "Most productive hour: %d:00 (%s of focused work)",
+	"console.recurring":       "Recurring tasks:",
+	"console.refocus":         "Time to refocus (until next %d-minute uninterrupted block):",
+	"console.score":           "Productivity score: %.1f / 100",
+	"console.title":           "Statistics for %s (%s to %s)",
+	"console.total":           "total",
+
+	// Meetings
+	"meetings.add":         "Add:",
+	"meetings.none":        "No meetings declared for today",
+	"meetings.placeholder": "e.g. 9:30-10 Standup; 14:00-15:00 Planning",
+	"meetings.title":       "Meetings",
+}
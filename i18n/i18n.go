@@ -0,0 +1,309 @@
+// Package i18n holds the message catalogs of the user interface and formats dates and
+// durations for a locale
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Locale is a language the interface can be shown in
+type Locale string
+
+const (
+	// English is the default locale, used for unknown locales and missing messages
+	English Locale = "en"
+	// Polish is the Polish translation
+	Polish Locale = "pl"
+)
+
+// localeNames are the names of the locales in their own language
+var localeNames = map[Locale]string{
+	English: "English",
+	Polish:  "Polski",
+}
+
+// Name returns the name of the locale in its own language
+func (l Locale) Name() string {
+	if name, ok := localeNames[l]; ok {
+		return name
+	}
+	return string(l)
+}
+
+// Clock is how times of day are shown
+type Clock string
+
+const (
+	// Clock24 shows times on the 24-hour clock, e.g. "14:05", the default
+	Clock24 Clock = "24h"
+	// Clock12 shows times on the 12-hour clock, e.g. "2:05 PM"
+	Clock12 Clock = "12h"
+)
+
+// ParseClock returns the clock of a name such as "12h" or "24", Clock24 for an empty name.
+// Returns false for unknown names.
+func ParseClock(name string) (Clock, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "24", "24h":
+		return Clock24, true
+	case "12", "12h":
+		return Clock12, true
+	}
+	return Clock24, false
+}
+
+// DurationFormat is how durations are shown
+type DurationFormat string
+
+const (
+	// DurationDefault shows durations as "01:23:00" in tables and "1h 23m" elsewhere
+	DurationDefault DurationFormat = ""
+	// DurationClock shows durations as "01:23:00" everywhere
+	DurationClock DurationFormat = "clock"
+	// DurationShort shows durations as "1h 23m" everywhere
+	DurationShort DurationFormat = "short"
+	// DurationDecimal shows durations as decimal hours, e.g. "1.4h", for billing
+	DurationDecimal DurationFormat = "decimal"
+)
+
+// ParseDurationFormat returns the duration format of a name, DurationDefault for an empty
+// name. Returns false for unknown names.
+func ParseDurationFormat(name string) (DurationFormat, bool) {
+	switch format := DurationFormat(strings.ToLower(strings.TrimSpace(name))); format {
+	case DurationDefault, DurationClock, DurationShort, DurationDecimal:
+		return format, true
+	}
+	return DurationDefault, false
+}
+
+// twelveHourLayouts turns the 24-hour clock elements of time.Format layouts into 12-hour ones
+var twelveHourLayouts = strings.NewReplacer("15:04:05", "3:04:05 PM", "15:04", "3:04 PM")
+
+// Catalog maps message keys to messages, which may hold fmt verbs
+type Catalog map[string]string
+
+// calendarNames are the names of weekdays and months of a language, Sunday and January first
+type calendarNames struct {
+	weekdays       [7]string
+	shortWeekdays  [7]string
+	months         [12]string
+	genitiveMonths [12]string // Months following a day number, as in "10 marca"
+	shortMonths    [12]string
+}
+
+// language is the translation of a locale
+type language struct {
+	messages Catalog
+	names    *calendarNames // Nil for the English names of the time package
+}
+
+// languages are the supported locales
+var languages = map[Locale]language{
+	English: {messages: english},
+	Polish:  {messages: polish, names: &polishNames},
+}
+
+// Locales returns the supported locales in alphabetical order
+func Locales() []Locale {
+	locales := make([]Locale, 0, len(languages))
+	for locale := range languages {
+		locales = append(locales, locale)
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i] < locales[j] })
+	return locales
+}
+
+// ParseLocale returns the supported locale of a locale name such as "pl", "pl-PL" or
+// "pl_PL.UTF-8". Returns false for unsupported locales.
+func ParseLocale(name string) (Locale, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(name, "-_."); i >= 0 {
+		name = name[:i]
+	}
+	if _, ok := languages[Locale(name)]; !ok {
+		return English, false
+	}
+	return Locale(name), true
+}
+
+// Printer translates messages and formats dates and durations for a locale. A nil Printer
+// prints English.
+type Printer struct {
+	locale    Locale
+	lang      language
+	clock     Clock
+	durations DurationFormat
+}
+
+// New returns a printer for the locale, English for an empty or unsupported locale
+func New(locale string) *Printer {
+	parsed, _ := ParseLocale(locale)
+	return &Printer{locale: parsed, lang: languages[parsed], clock: Clock24}
+}
+
+// WithClock returns a copy of the printer showing times on the clock, or the 24-hour
+// clock for an unknown one
+func (p *Printer) WithClock(clock string) *Printer {
+	printer := New("")
+	if p != nil {
+		copied := *p
+		printer = &copied
+	}
+	printer.clock, _ = ParseClock(clock)
+	return printer
+}
+
+// WithDurationFormat returns a copy of the printer showing durations in the format, or the
+// default format for an unknown one
+func (p *Printer) WithDurationFormat(format string) *Printer {
+	printer := New("")
+	if p != nil {
+		copied := *p
+		printer = &copied
+	}
+	printer.durations, _ = ParseDurationFormat(format)
+	return printer
+}
+
+// Locale returns the locale of the printer
+func (p *Printer) Locale() Locale {
+	if p == nil {
+		return English
+	}
+	return p.locale
+}
+
+// T returns the message of a key formatted with args like fmt.Sprintf. Messages missing
+// from the catalog of the locale are printed in English, unknown keys as the key itself.
+func (p *Printer) T(key string, args ...any) string {
+	message, ok := "", false
+	if p != nil {
+		message, ok = p.lang.messages[key]
+	}
+	if !ok {
+		if message, ok = english[key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// nameTokens are the layout elements of time.Format spelling out weekdays and months,
+// longer elements first
+var nameTokens = []string{"Monday", "Mon", "January", "Jan"}
+
+// Time formats the time of day with seconds, e.g. "14:05:09" or "2:05:09 PM"
+func (p *Printer) Time(t time.Time) string {
+	return p.Date(t, "15:04:05")
+}
+
+// ShortTime formats the time of day without seconds, e.g. "14:05" or "2:05 PM"
+func (p *Printer) ShortTime(t time.Time) string {
+	return p.Date(t, "15:04")
+}
+
+// Date formats t with a time.Format layout, spelling weekdays and months in the language
+// of the locale. Times of day in the layout follow the clock of the printer.
+func (p *Printer) Date(t time.Time, layout string) string {
+	if p != nil && p.clock == Clock12 {
+		layout = twelveHourLayouts.Replace(layout)
+	}
+	if p == nil || p.lang.names == nil {
+		return t.Format(layout)
+	}
+	names := p.lang.names
+
+	var sb strings.Builder
+	consumed := ""
+	for layout != "" {
+		index, token := -1, ""
+		for i := 0; i < len(layout) && index < 0; i++ {
+			for _, candidate := range nameTokens {
+				if strings.HasPrefix(layout[i:], candidate) {
+					index, token = i, candidate
+					break
+				}
+			}
+		}
+		if index < 0 {
+			sb.WriteString(t.Format(layout))
+			break
+		}
+
+		sb.WriteString(t.Format(layout[:index]))
+		consumed += layout[:index]
+		switch token {
+		case "Monday":
+			sb.WriteString(names.weekdays[t.Weekday()])
+		case "Mon":
+			sb.WriteString(names.shortWeekdays[t.Weekday()])
+		case "January":
+			// Months following the day of the month are declined, as in "10 marca 2025"
+			if strings.Contains(consumed, "02") || strings.Contains(consumed, "_2") {
+				sb.WriteString(names.genitiveMonths[t.Month()-1])
+			} else {
+				sb.WriteString(names.months[t.Month()-1])
+			}
+		case "Jan":
+			sb.WriteString(names.shortMonths[t.Month()-1])
+		}
+		consumed += token
+		layout = layout[index+len(token):]
+	}
+	return sb.String()
+}
+
+// Duration formats a duration in hours and minutes, or minutes and seconds under an hour,
+// e.g. "1h 5m" in English, unless the printer has a duration format
+func (p *Printer) Duration(d time.Duration) string {
+	switch p.durationFormat() {
+	case DurationClock:
+		return formatClockDuration(d)
+	case DurationDecimal:
+		return p.T("duration.decimal", d.Hours())
+	}
+
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	switch {
+	case hours > 0:
+		return p.T("duration.hours_minutes", hours, minutes)
+	case minutes > 0:
+		return p.T("duration.minutes_seconds", minutes, seconds)
+	default:
+		return p.T("duration.seconds", seconds)
+	}
+}
+
+// ClockDuration formats a duration for table cells as "HH:MM:SS", unless the printer has
+// a duration format
+func (p *Printer) ClockDuration(d time.Duration) string {
+	if p.durationFormat() == DurationDefault {
+		return formatClockDuration(d)
+	}
+	return p.Duration(d)
+}
+
+// durationFormat returns the duration format of the printer
+func (p *Printer) durationFormat() DurationFormat {
+	if p == nil {
+		return DurationDefault
+	}
+	return p.durations
+}
+
+// formatClockDuration formats a duration as "HH:MM:SS"
+func formatClockDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCatalogsMatch tests every catalog translates the English messages with the same
+// fmt verbs, so no message falls back to English or prints a bad verb
+func TestCatalogsMatch(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	for _, locale := range Locales() {
+		messages := languages[locale].messages
+		assert.Len(t, messages, len(english), "locale %s", locale)
+		for key, message := range english {
+			translated, ok := messages[key]
+			if assert.True(t, ok, "locale %s misses %s", locale, key) {
+				assert.Equal(t, verbs.FindAllString(message, -1), verbs.FindAllString(translated, -1), "locale %s, key %s", locale, key)
+			}
+		}
+	}
+}
+
+// TestParseLocale tests locale names with regions and encodings, and unsupported locales
+func TestParseLocale(t *testing.T) {
+	for name, want := range map[string]Locale{"pl": Polish, "pl-PL": Polish, "pl_PL.UTF-8": Polish, " EN ": English} {
+		locale, ok := ParseLocale(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, locale, name)
+	}
+
+	locale, ok := ParseLocale("de")
+	assert.False(t, ok)
+	assert.Equal(t, English, locale)
+	assert.Equal(t, English, New("").Locale())
+	assert.Equal(t, "Polski", Polish.Name())
+}
+
+// TestTranslate tests messages are formatted, and missing keys fall back to the key itself
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "Sync conflict 1 of 2", New("en").T("conflict.title", 1, 2))
+	assert.Equal(t, "Konflikt synchronizacji 1 z 2", New("pl").T("conflict.title", 1, 2))
+	assert.Equal(t, "no.such.key", New("pl").T("no.such.key"))
+
+	var printer *Printer
+	assert.Equal(t, "Settings", printer.T("settings.title"))
+}
+
+// TestDate tests weekdays and months are spelled in the language of the locale, declined
+// after the day of the month in Polish
+func TestDate(t *testing.T) {
+	date := time.Date(2025, 3, 10, 9, 5, 0, 0, time.UTC)
+
+	assert.Equal(t, "Monday, 10 March 2025", New("en").Date(date, "Monday, 02 January 2006"))
+	assert.Equal(t, "poniedziałek, 10 marca 2025", New("pl").Date(date, "Monday, 02 January 2006"))
+	assert.Equal(t, "marzec 2025", New("pl").Date(date, "January 2006"))
+	assert.Equal(t, "pon. 10 mar 09:05", New("pl").Date(date, "Mon 02 Jan 15:04"))
+}
+
+// TestClock tests times of day on the 12-hour clock, alone and inside date layouts
+func TestClock(t *testing.T) {
+	afternoon := time.Date(2025, 3, 10, 14, 5, 9, 0, time.UTC)
+	printer := New("en")
+
+	assert.Equal(t, "14:05:09", printer.Time(afternoon))
+	assert.Equal(t, "14:05", printer.ShortTime(afternoon))
+
+	printer = printer.WithClock("12h")
+	assert.Equal(t, "2:05:09 PM", printer.Time(afternoon))
+	assert.Equal(t, "9:30 AM", printer.ShortTime(afternoon.Add(-4*time.Hour-35*time.Minute)))
+	assert.Equal(t, "pon. 2:05 PM", New("pl").WithClock("12h").Date(afternoon, "Mon 15:04"))
+	assert.Equal(t, "14:05", New("en").ShortTime(afternoon), "WithClock copies the printer")
+
+	_, ok := ParseClock("13h")
+	assert.False(t, ok)
+	assert.Equal(t, "14:05", printer.WithClock("13h").ShortTime(afternoon))
+}
+
+// TestDuration tests durations in hours and minutes, minutes and seconds, and seconds
+func TestDuration(t *testing.T) {
+	en, pl := New("en"), New("pl")
+
+	assert.Equal(t, "1h 5m", en.Duration(65*time.Minute))
+	assert.Equal(t, "1 godz. 5 min", pl.Duration(65*time.Minute))
+	assert.Equal(t, "2m 30s", en.Duration(150*time.Second))
+	assert.Equal(t, "2 min 30 s", pl.Duration(150*time.Second))
+	assert.Equal(t, "45 s", pl.Duration(45*time.Second))
+}
+
+// TestDurationFormats tests durations shown as clock, short and decimal hours, and the
+// default of clock durations in tables only
+func TestDurationFormats(t *testing.T) {
+	d := 83 * time.Minute
+	printer := New("en")
+
+	assert.Equal(t, "1h 23m", printer.Duration(d))
+	assert.Equal(t, "01:23:00", printer.ClockDuration(d))
+
+	assert.Equal(t, "01:23:00", printer.WithDurationFormat("clock").Duration(d))
+	assert.Equal(t, "1h 23m", printer.WithDurationFormat("short").ClockDuration(d))
+	assert.Equal(t, "1.4h", printer.WithDurationFormat("decimal").Duration(d))
+	assert.Equal(t, "1.4h", printer.WithDurationFormat("decimal").ClockDuration(d))
+	assert.Equal(t, "1.4 godz.", New("pl").WithDurationFormat("decimal").Duration(d))
+
+	_, ok := ParseDurationFormat("minutes")
+	assert.False(t, ok)
+	assert.Equal(t, "01:23:00", printer.WithDurationFormat("minutes").ClockDuration(d))
+}
@@ -0,0 +1,521 @@
+package i18n
+
+// polishNames are the Polish names of weekdays and months
+var polishNames = calendarNames{
+	weekdays:       [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+	shortWeekdays:  [7]string{"niedz.", "pon.", "wt.", "śr.", "czw.", "pt.", "sob."},
+	months:         [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+	genitiveMonths: [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+	shortMonths:    [12]string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"},
+}
+
+// polish is the Polish catalog
+var polish = Catalog{
+	// Durations
+	"duration.decimal":         "%.1f godz.",
+	"duration.hours_minutes":   "%d godz. %d min",
+	"duration.minutes_seconds": "%d min %d s",
+	"duration.minutes":         "%d min",
+	"duration.seconds":         "%d s",
+
+	// Buttons
+	"button.back":   "Wstecz",
+	"button.cancel": "Anuluj",
+	"button.add":    "Dodaj",
+	"button.log":    "Zapisz wpis",
+	"button.move":   "Przenieś",
+	"button.no":     "Nie",
+	"button.ok":     "OK",
+	"button.save":   "Zapisz",
+	"button.show":   "Pokaż",
+	"button.start":  "Rozpocznij",
+	"button.submit": "Zatwierdź",
+	"button.update": "Zmień",
+	"button.yes":    "Tak",
+
+	// Table columns
+	"column.avg_time":      "Śr. czas",
+	"column.count":         "Liczba",
+	"column.description":   "Opis",
+	"column.duration":      "Czas",
+	"column.end":           "Koniec",
+	"column.end_time":      "Koniec",
+	"column.interrupt":     "Przerwa",
+	"column.interruptions": "Przerwy",
+	"column.pattern":       "Wzorzec",
+	"column.recovery":      "Powrót",
+	"column.start":         "Początek",
+	"column.start_time":    "Początek",
+	"column.sub_session":   "Podsesja",
+	"column.total":         "Razem",
+	"column.type":          "Rodzaj",
+	"column.assumed":       "Przyjęty",
+	"column.average":       "Średnio",
+	"column.days":          "Dni",
+	"column.issue":         "Zgłoszenie",
+	"column.measured":      "Zmierzony śr.",
+	"column.rate":          "Przerwy/godz.",
+	"column.refocused":     "Skupienie",
+	"column.returns":       "Powroty",
+	"column.sessions":      "Sesje",
+	"column.task":          "Zadanie",
+	"column.title":         "Tytuł",
+	"sort.ascending":       "rosnąco",
+	"sort.descending":      "malejąco",
+
+	// Header and key help
+	"header.goal_today":   "Dziś %s / %s",
+	"header.safe_mode":    "TRYB BEZPIECZNY",
+	"header.state":        "Stan: %s",
+	"help.press":          "Naciśnij %s",
+	"help.recovery_over":  "Koniec powrotu do skupienia, czas na pracę. %s",
+	"help.replay":         "Odtwarzanie, naciśnij (spacja) pauza, (+/-) szybkość, (q) wyjście",
+	"reminder.away":       "Nieobecność przez %s, naciśnij (s), aby zacząć mierzyć czas",
+	"reminder.no_session": "Brak sesji od %s, naciśnij (s), aby zacząć mierzyć czas",
+	"replay.badge":        "ODTWARZANIE",
+	"replay.finished":     "zakończone",
+	"replay.paused":       "wstrzymane",
+
+	// Key bindings
+	"keys.title":          "Skróty klawiszowe",
+	"keys.close":          "Naciśnij (Esc) lub (%s), aby zamknąć",
+	"keys.page.main":      "Sesje",
+	"keys.page.stats":     "Statystyki",
+	"keys.page.charts":    "Wykresy",
+	"keys.page.calendar":  "Kalendarz",
+	"keys.page.day":       "Dzień w kalendarzu",
+	"keys.page.records":   "Rekordy",
+	"keys.page.quarter":   "Przegląd kwartału",
+	"keys.page.continued": "Powiązane zadania",
+	"keys.page.tags":      "Tagi",
+	"keys.page.settings":  "Ustawienia",
+	"keys.start":          "rozpocznij sesję",
+	"keys.end":            "zakończ sesję",
+	"keys.interrupt":      "przerwa",
+	"keys.back_to_work":   "powrót do pracy",
+	"keys.defer":          "odłóż przerwę",
+	"keys.focus":          "tryb skupienia",
+	"keys.details":        "szczegóły sesji",
+	"keys.rename":         "zmień nazwę sesji",
+	"keys.resume":         "cofnij koniec",
+	"keys.delete":         "usuń sesję",
+	"keys.move":           "przenieś sesję na inny dzień",
+	"keys.add":            "dodaj minioną sesję",
+	"keys.meetings":       "zaplanuj dzisiejsze spotkania",
+	"keys.mark":           "zaznacz sesję",
+	"keys.bulk":           "działania na zaznaczonych sesjach",
+	"keys.sort":           "sortuj według kolumny",
+	"keys.tags":           "tagi",
+	"keys.settings":       "ustawienia",
+	"keys.stats":          "statystyki",
+	"keys.help":           "skróty klawiszowe",
+	"keys.quit":           "wyjście",
+	"keys.day":            "dzień",
+	"keys.week":           "tydzień",
+	"keys.month":          "miesiąc",
+	"keys.quarter":        "kwartał",
+	"keys.year":           "rok",
+	"keys.all":            "cały okres",
+	"keys.range":          "własny zakres",
+	"keys.productivity":   "wykres produktywności",
+	"keys.interruptions":  "wykres przerw",
+	"keys.trends":         "wykres trendów",
+	"keys.calendar":       "kalendarz",
+	"keys.records":        "rekordy",
+	"keys.okr":            "przegląd kwartału OKR",
+	"keys.linked":         "powiązane zadania",
+	"keys.back":           "powrót",
+	"keys.previous_chart": "poprzedni wykres",
+	"keys.next_chart":     "następny wykres",
+	"keys.back_to_stats":  "powrót do statystyk",
+	"keys.open_day":       "otwórz dzień",
+	"keys.back_calendar":  "powrót do kalendarza",
+	"keys.prev_quarter":   "poprzedni kwartał",
+	"keys.next_quarter":   "następny kwartał",
+	"keys.archive_tag":    "archiwizuj/przywróć wybrany tag",
+	"keys.prune_tags":     "archiwizuj martwe tagi",
+	"keys.back_unsaved":   "powrót bez zapisu",
+
+	// Alerts
+	"alert.interruption": "Przerwa trwa ponad %s (%s), czas wracać do pracy",
+	"alert.session":      "Sesja %q trwa dłużej niż planowane %s",
+
+	// Session state and linear timelines
+	"state.idle":            "Brak sesji",
+	"state.working":         "Praca",
+	"state.interrupted":     "Przerwa",
+	"state.recovery":        "Powrót do skupienia",
+	"timeline.working":      "Praca",
+	"timeline.interrupted":  "Przerwa",
+	"timeline.recovery":     "Powrót do skupienia",
+	"timeline.continues":    "Trwa po północy",
+	"timeline.back_to_work": "Powrót do pracy",
+	"timeline.no_activity":  "Brak aktywności",
+	"timeline.scheduled":    "Godziny pracy: %s-%s",
+	"timeline.day_off":      "Dzień wolny według harmonogramu",
+	"timeline.now":          "teraz",
+	"timeline.title":        "Oś czasu dnia (widok 24-godzinny)",
+	"legend.back_to_work":   "Powrót do pracy",
+	"legend.continues":      "Trwa po północy",
+	"legend.interrupted":    "Przerwa",
+	"legend.no_activity":    "Brak aktywności",
+	"legend.off_hours":      "Poza godzinami pracy",
+	"legend.paused":         "Wstrzymana",
+	"legend.recovery":       "Powrót do skupienia",
+	"legend.working":        "Praca",
+	"timer.interrupted":     "Przerwa od %s",
+	"timer.interrupted_tag": "Przerwa od %s (%s)",
+	"timer.recovery":        "Powrót do skupienia, zostało %s",
+
+	// Status bar
+	"status.activity_watcher_stopped":    "Zatrzymano obserwowanie aktywności: %v",
+	"status.already_interrupted":         "Już przerwano. Naciśnij 'b', aby wrócić",
+	"status.auto_end_failed":             "Błąd automatycznego kończenia sesji: %v",
+	"status.auto_ended":                  "Sesja zakończona automatycznie o %s",
+	"status.continue_failed":             "Błąd kontynuowania sesji: %v",
+	"status.continued_failed":            "Błąd wczytywania kontynuowanych zadań: %v",
+	"status.deferral_failed":             "Błąd zapisu odłożonej przerwy: %v",
+	"status.deferred":                    "Przerwa odłożona (dziś odparto %.0f%%)",
+	"status.delete_failed":               "Błąd usuwania sesji: %v",
+	"status.description_updated":         "Opis zmieniony",
+	"status.end_failed":                  "Błąd kończenia sesji: %v",
+	"status.end_while_interrupted":       "Nie można zakończyć przerwanej sesji. Najpierw wróć z przerwy",
+	"status.error":                       "Błąd: %v",
+	"status.focus_mode_failed":           "Błąd zapisu trybu skupienia: %v",
+	"status.focus_mode_off":              "Tryb skupienia wyłączony",
+	"status.focus_mode_on":               "Tryb skupienia włączony, przerwy są oznaczane jako mimo DND",
+	"status.focus_watcher_stopped":       "Zatrzymano obserwowanie trybu skupienia: %v",
+	"status.interrupted_despite_focus":   "Sesja przerwana mimo trybu skupienia",
+	"status.interruption_failed":         "Błąd zapisu przerwy: %v",
+	"status.interruptions_retagged":      "Zmieniono tag %d przerw(y) na %s",
+	"status.key_bindings_ignored":        "Pominięto skróty klawiszowe: %v",
+	"status.invalid_date":                "Nieprawidłowa data, użyj RRRR-MM-DD",
+	"status.load_day_failed":             "Błąd wczytywania %s: %v",
+	"status.logged":                      "Zapisano %s",
+	"status.marks_cleared":               "Zaznaczenia usunięte",
+	"status.meeting_over":                "Koniec spotkania %s, czas wrócić do pracy",
+	"status.meeting_started":             "Spotkanie %s do %s, zapisane jako przerwa",
+	"status.meetings_declared":           "Zaplanowane spotkania na dziś: %d",
+	"status.merge_needs_two":             "Zaznacz co najmniej dwie sesje do scalenia",
+	"status.move_failed":                 "Błąd przenoszenia sesji: %v",
+	"status.move_needs_end":              "Zakończ sesję, zanim przeniesiesz ją na inny dzień",
+	"status.no_active_session":           "Brak aktywnej sesji",
+	"status.no_active_sub_session":       "Brak aktywnej podsesji",
+	"status.no_column":                   "Brak kolumny %d, tabela ma %d",
+	"status.no_config":                   "Nie wczytano konfiguracji",
+	"status.no_session_for_focus":        "Brak aktywnej sesji, dla której można włączyć tryb skupienia",
+	"status.no_session_selected":         "Nie wybrano sesji",
+	"status.no_session_to_defer":         "Brak aktywnej sesji, w której można odłożyć przerwę",
+	"status.no_session_to_edit":          "Brak aktywnej sesji do edycji",
+	"status.no_session_to_end":           "Brak aktywnej sesji do zakończenia",
+	"status.no_session_to_interrupt":     "Brak aktywnej sesji do przerwania",
+	"status.no_sub_session_to_interrupt": "Brak aktywnej podsesji do przerwania",
+	"status.none_marked":                 "Nie zaznaczono sesji. Naciśnij spację, aby zaznaczać sesje",
+	"status.not_interrupted":             "Brak trwającej przerwy",
+	"status.notification_failed":         "Nie udało się wysłać powiadomienia: %v",
+	"status.quit_failed":                 "Błąd zamykania sesji, program nie zostanie zamknięty: %v",
+	"status.records_failed":              "Błąd aktualizacji rekordów: %v",
+	"status.recovery_end_failed":         "Błąd zapisu końca powrotu do skupienia: %v",
+	"status.reminder_failed":             "Nie udało się wysłać przypomnienia: %v",
+	"status.reminders_disabled":          "Przypomnienia wyłączone: %v",
+	"status.scheduled_failed":            "Zaplanowane zadanie %s nie powiodło się: %v",
+	"status.scheduled_done":              "Wykonano zaplanowane zadanie %s",
+	"status.rename_failed":               "Błąd zmiany opisu: %v",
+	"status.resume_failed":               "Błąd wznawiania sesji: %v",
+	"status.resume_not_ended":            "Sesja nie jest zakończona, nie trzeba jej wznawiać",
+	"status.resume_while_active":         "Nie można wznowić sesji, gdy inna jest aktywna",
+	"status.return_failed":               "Błąd zapisu powrotu: %v",
+	"status.returned":                    "Powrót z przerwy",
+	"status.save_failed":                 "Błąd zapisu sesji: %v",
+	"status.session_deleted":             "Sesja usunięta",
+	"status.session_ended":               "Sesja zakończona",
+	"status.session_interrupted":         "Sesja przerwana",
+	"status.session_resumed":             "Sesja wznowiona w nowym przedziale czasu",
+	"status.session_started":             "Sesja rozpoczęta",
+	"status.sessions_deleted":            "Usunięto sesje: %d",
+	"status.sessions_marked":             "Zaznaczono sesje: %d. Naciśnij (m), aby wybrać akcję, spację, aby zaznaczyć więcej",
+	"status.sessions_merged":             "Scalono sesje: %d",
+	"status.sessions_moved":              "Przeniesiono sesje: %d na %s",
+	"status.settings_saved":              "Ustawienia zapisane",
+	"status.slack_disabled":              "Status skupienia w Slacku wyłączony: %v",
+	"status.slack_failed":                "Aktualizacja Slacka nie powiodła się: %v",
+	"status.sorted_by":                   "Sortowanie: %s, %s",
+	"status.start_while_active":          "Nie można rozpocząć nowej sesji, gdy inna jest aktywna",
+	"status.tag_usage_failed":            "Błąd wczytywania użycia tagów: %v",
+	"status.toggl_disabled":              "Synchronizacja z Toggl wyłączona: %v",
+	"status.toggl_failed":                "Synchronizacja z Toggl nie powiodła się: %v",
+	"status.webhook_failed":              "Webhook nie powiódł się: %v",
+
+	// Dialogs
+	"dialog.description":              "Opis:",
+	"dialog.edit_description":         "Zmień opis aktywności",
+	"dialog.estimate":                 "Szacunek:   ",
+	"dialog.estimate_placeholder":     "opcjonalnie, np. 45m lub 1h30m",
+	"dialog.interruption_description": "Opisz przerwę",
+	"dialog.invalid_estimate":         "Nieprawidłowy szacunek, użyj np. 45m lub 1h30m",
+	"dialog.select_deferred_tag":      "Wybierz rodzaj odłożonej przerwy",
+	"dialog.select_tag":               "Wybierz rodzaj przerwy",
+	"dialog.start_session":            "Rozpocznij sesję",
+	"dialog.suggested_tag":            "%s (sugerowany: %s):",
+	"dialog.tag":                      "Tag:",
+	"dialog.task_placeholder":         "wpisz, aby wyszukać zadanie, lub podaj nowe",
+	"dialog.tasks_title":              "Zadania: 1-9 lub strzałka w dół, aby wybrać",
+	"tag.call":                        "Telefon",
+	"tag.meeting":                     "Spotkanie",
+	"tag.other":                       "Inny (własny)",
+	"tag.spouse":                      "Partner",
+
+	// Session details
+	"details.active":             "Aktywna",
+	"details.attachment":         "Załącznik: %s",
+	"details.back_to_work":       "Powrót do pracy: %s",
+	"details.description":        "Opis: %s",
+	"details.duration":           "Czas: %s",
+	"details.end":                "Koniec: %s",
+	"details.focus_mode":         "Tryb skupienia: %s, przerwy mimo DND: %d",
+	"details.interruption":       "Przerwa nr %d:",
+	"details.interruptions_of":   "Przerwy w podsesji nr %d:",
+	"details.issue":              "Zgłoszenie: %s",
+	"details.no_description":     "(Brak opisu)",
+	"details.no_interruptions":   "Brak przerw w tej podsesji.",
+	"details.ongoing":            "(trwa)",
+	"details.project":            "Projekt: %s",
+	"details.resolving":          "(sprawdzanie...)",
+	"details.select_sub_session": "Wybierz podsesję, aby zobaczyć szczegóły przerw",
+	"details.session":            "Sesja: %s",
+	"details.start":              "Początek: %s",
+	"details.title":              "Szczegóły sesji",
+	"details.total_duration":     "Łączny czas: %s",
+	"details.type":               "Rodzaj: %s",
+	"details.unavailable":        "(niedostępne)",
+	"details.unknown":            "Nieznany",
+
+	// Statistics page
+	"stats.completed_tasks":        "Ukończone zadania",
+	"stats.interruption_breakdown": "Podział przerw",
+	"stats.recurring_tasks":        "Powtarzające się zadania",
+	"stats.title":                  "Statystyki",
+	"stats.for":                    "Statystyki dla zakresu %s:",
+
+	// Daily recap
+	"recap.above_average":    "O %.0f%% więcej skupienia niż średnia z 7 dni (%s).",
+	"recap.below_average":    "O %.0f%% mniej skupienia niż średnia z 7 dni (%s).",
+	"recap.button":           "Do dzieła",
+	"recap.focus":            "Skupienie: %s w sesjach: %d",
+	"recap.interruptions":    "Przerwy: %d (%s)",
+	"recap.no_comparison":    "Brak wcześniejszych dni w tym tygodniu do porównania.",
+	"recap.no_interruptions": "Przerwy: brak",
+	"recap.on_average":       "Dokładnie średnia z 7 dni (%s).",
+	"recap.score":            "Wynik produktywności: %.0f/100",
+	"recap.weekday":          "Podsumowanie: %s (%s)",
+	"recap.yesterday":        "Podsumowanie wczoraj (%s)",
+
+	// Auto-end
+	"autoend.end_at":       "Zakończ o %s",
+	"autoend.keep_running": "Nie przerywaj",
+	"autoend.notice":       "%q wciąż trwała i została zakończona: %s.",
+	"autoend.prompt":       "%q trwa od %s. Zakończyć ją: %s?",
+
+	// Bulk actions
+	"bulk.clear_marks":    "Usuń zaznaczenia",
+	"bulk.confirm_delete": "Usunąć te sesje?",
+	"bulk.confirm_merge":  "Scalić te sesje w jedną?",
+	"bulk.confirm_move":   "Przenieść te sesje na %s?",
+	"bulk.confirm_retag":  "Zmienić tag przerw tych sesji na %q?",
+	"bulk.delete":         "Usuń",
+	"bulk.marked":         "Zaznaczone sesje: %d",
+	"bulk.merge":          "Scal",
+	"bulk.move":           "Przenieś na dzień",
+	"bulk.move_title":     "Przenieś sesje",
+	"bulk.move_to_day":    "Przenieś na dzień:",
+	"bulk.no_description": "(brak opisu)",
+	"bulk.now":            "teraz",
+	"bulk.retag":          "Zmień tag",
+	"bulk.retag_prompt":   "Zmień tag wszystkich przerw zaznaczonych sesji na:",
+	"bulk.session_line":   "%s-%s  %s  %s, przerwy: %d",
+	"bulk.total":          "Sesje: %d, %s pracy",
+
+	// Sync conflicts
+	"conflict.and":         "i",
+	"conflict.copy":        "Narzędzie synchronizacji zostawiło sprzeczną kopię %s (%s).\n\nTen komputer: %s\nKopia: %s",
+	"conflict.delete_copy": "Usuń kopię",
+	"conflict.duplicate":   "Sesja %s jest zapisana pod %s, najpewniej po synchronizacji dwóch komputerów.\nZostaw ją pod jednym dniem, aby nie liczyć jej dwa razy.",
+	"conflict.keep_in":     "Zostaw pod %s",
+	"conflict.keep_ours":   "Zostaw z tego komputera",
+	"conflict.later":       "Później",
+	"conflict.latest":      "(najnowsza)",
+	"conflict.merge_both":  "Scal obie",
+	"conflict.no_sessions": "brak sesji",
+	"conflict.summary":     "sesje: %d, %s pracy, ostatnia zmiana %s",
+	"conflict.title":       "Konflikt synchronizacji %d z %d",
+	"conflict.unreadable":  "Narzędzie synchronizacji zostawiło %s, którego nie można odczytać: %v",
+	"conflict.use_copy":    "Użyj kopii",
+
+	// Settings
+	"settings.color_theme":       "Motyw kolorów",
+	"settings.accessible":        "Tryb dostępności (tekst zamiast kolorów)",
+	"settings.columns":           "Kolumny sesji (oddzielone przecinkami)",
+	"settings.currency":          "Waluta",
+	"settings.custom_tags":       "Własne tagi (oddzielone przecinkami)",
+	"settings.help":              "Tab przechodzi między polami, %s",
+	"settings.hourly_rate":       "Stawka godzinowa do kosztu przerw",
+	"settings.invalid_rate":      "stawka godzinowa musi być liczbą dodatnią",
+	"settings.invalid_recovery":  "czas powrotu do skupienia musi wynosić co najmniej 1 minutę",
+	"settings.language":          "Język",
+	"settings.twelve_hour_clock": "Zegar 12-godzinny",
+	"settings.read_only":         "W trybie bezpiecznym konfiguracja jest tylko do odczytu",
+	"settings.read_only_back":    "W trybie bezpiecznym konfiguracja jest tylko do odczytu, %s",
+	"settings.recovery_notify":   "Powiadom o końcu powrotu do skupienia",
+	"settings.recovery_time":     "Czas powrotu do skupienia (minuty)",
+	"settings.reminder":          "Przypominaj o mierzeniu czasu",
+	"settings.reminder_desktop":  "Powiadomienia systemowe dla przypomnień",
+	"settings.save_failed":       "Błąd zapisu konfiguracji: %v",
+	"settings.sort":              "Sortuj sesje według",
+	"settings.sort_ascending":    "Sortuj rosnąco",
+	"settings.title":             "Ustawienia",
+	"settings.unknown_locale":    "nieznany język %q",
+	"settings.unknown_sort":      "nieznana kolumna sortowania %q",
+
+	// Reports
+	"report.active":       "aktywna",
+	"report.amount":       "Koszt",
+	"report.attachments":  "Załączniki",
+	"report.cost":         "Koszt przerw",
+	"report.focused_work": "Praca w skupieniu",
+	"report.generated":    "Wygenerowano",
+	"report.lost_time":    "Stracony czas",
+	"report.no_sessions":  "Brak sesji w tym zakresie.",
+	"report.project":      "Projekt",
+	"report.title":        "Raport pracy",
+	"report.work":         "Praca",
+
+	// Date ranges
+	"range.all":              "Cały okres",
+	"range.custom":           "%s do %s",
+	"range.day":              "Dziś",
+	"range.end":              "Koniec:",
+	"range.end_before_start": "Koniec nie może być przed początkiem",
+	"range.last_week":        "Poprzedni tydzień",
+	"range.month":            "Ten miesiąc",
+	"range.quarter":          "Ten kwartał",
+	"range.start":            "Początek:",
+	"range.title":            "Zakres dat",
+	"range.week":             "Ten tydzień",
+	"range.year":             "Ten rok",
+
+	// Calendar
+	"calendar.day_focus": "%s: %s skupienia",
+	"calendar.day_title": "%s - sesje: %d, %s skupienia",
+	"calendar.less":      "Mniej",
+	"calendar.more":      "Więcej",
+	"calendar.title":     "Kalendarz skupienia (ostatnie tygodnie: %d)",
+
+	// Continued tasks
+	"continued.active":        "(aktywne)",
+	"continued.combined":      "Łącznie: %s pracy, dni: %d, przerwy: %d (%s)",
+	"continued.interruptions": "przerwy: %d",
+	"continued.none":          "W tym zakresie żadne zadanie nie było kontynuowane przez kilka dni.\n\nSesje są kontynuowane w powiązanej sesji, gdy rollover_mode ma wartość \"split\".",
+	"continued.title":         "Zadania kontynuowane przez kilka dni - %s",
+
+	// Records
+	"records.achievements":    "Osiągnięcia",
+	"records.current_streak":  "Obecna seria",
+	"records.daily_goal":      "(dzienny cel: %s skupienia)",
+	"records.days":            "dni: %d",
+	"records.fewest":          "Najmniej przerw",
+	"records.fewest_unset":    "osiągnij cel, aby ustanowić ten rekord",
+	"records.fewest_value":    "%d w dniu z osiągniętym celem",
+	"records.longest_block":   "Najdłuższy blok skupienia",
+	"records.longest_streak":  "Najdłuższa seria",
+	"records.most_focus":      "Dzień największego skupienia",
+	"records.new":             "NOWE!",
+	"records.no_achievements": "Jeszcze brak - skupiaj się dalej!",
+	"records.personal_bests":  "Rekordy osobiste",
+	"records.streaks":         "Serie",
+	"records.title":           "Rekordy i osiągnięcia",
+
+	// Quarter review
+	"quarter.goal_attainment": "Osiągnięcie celu",
+	"quarter.goal_days":       "dni z celem: %d/%d",
+	"quarter.goal_days_of":    "%d z %d dni z pomiarem (%.0f%%)",
+	"quarter.load_failed":     "Błąd wczytywania kwartału: %v",
+	"quarter.no_projects":     "Brak pracy w skupieniu w tym kwartale",
+	"quarter.no_tracked_days": "brak pomiarów",
+	"quarter.summary":         "Podsumowanie",
+	"quarter.title":           "Przegląd kwartału - %s",
+	"quarter.top_projects":    "Najważniejsze projekty",
+	"quarter.total_focus":     "Łączne skupienie",
+	"quarter.weekly_focus":    "Skupienie w tygodniach",
+
+	// Tag management
+	"tags.active":           "aktywny",
+	"tags.archived":         "zarchiwizowany",
+	"tags.builtin":          "wbudowany",
+	"tags.builtin_archive":  "Wbudowanych tagów nie można archiwizować",
+	"tags.column.kind":      "Rodzaj",
+	"tags.column.last_used": "Ostatnio użyty",
+	"tags.column.status":    "Stan",
+	"tags.column.tag":       "Tag",
+	"tags.column.uses":      "Użycia",
+	"tags.custom":           "własny",
+	"tags.dead":             "martwy",
+	"tags.never":            "nigdy",
+	"tags.pruned":           "Zarchiwizowane martwe tagi: %d",
+	"tags.tag_archived":     "Tag %s zarchiwizowany",
+	"tags.tag_restored":     "Tag %s przywrócony",
+	"tags.title":            "Tagi przerw",
+
+	// Quick entry
+	"quick.label":       "Wpis:",
+	"quick.placeholder": "np. worked on report 9-11 with 2 interruptions",
+	"quick.title":       "Szybki wpis",
+
+	// Setup wizard
+	"wizard.backup_interval": "Częstotliwość kopii (dni)",
+	"wizard.backups":         "Codzienne kopie zapasowe plików",
+	"wizard.data_directory":  "Katalog danych",
+	"wizard.encryption":      "Szyfruj pliki danych",
+	"wizard.intro":           "Skonfigurujmy program. Każde ustawienie można później zmienić w pliku konfiguracyjnym.\nTab przechodzi między polami, Esc pomija konfigurację.",
+	"wizard.passphrase":      "Hasło szyfrowania",
+	"wizard.skip":            "Pomiń (ustawienia domyślne)",
+	"wizard.title":           "Witaj w Interruption Tracker - konfiguracja",
+
+	// Charts
+	"charts.advice_high":         "• Utrzymaj obecny sposób pracy\n• Rozważ optymalizację godzin pracy\n• Podziel się metodami z zespołem",
+	"charts.advice_low":          "• Ogranicz przerwy\n• Rozważ blokowanie czasu\n• Wprowadź zasady „nie przeszkadzać”",
+	"charts.advice_medium":       "• Grupuj podobne zadania\n• Planuj okresy pracy w skupieniu\n• Ogranicz źródła przerw",
+	"charts.analysis":            "Analiza produktywności",
+	"charts.declining":           "↓ Spadek",
+	"charts.improving":           "↑ Poprawa",
+	"charts.interruptions_title": "Analiza przerw (%s)",
+	"charts.no_trends":           "Za mało danych historycznych, aby pokazać trendy.\nMierz czas przez więcej dni, aby zobaczyć wzorce produktywności.",
+	"charts.productivity_title":  "Wizualizacje produktywności (%s)",
+	"charts.recommendations":     "Zalecenia:",
+	"charts.score":               "Wynik produktywności (0-100):",
+	"charts.score_basis":         "Wynik uwzględnia:\n• Czas pracy w skupieniu\n• Częstotliwość przerw\n• Wpływ czasu powrotu do skupienia",
+	"charts.stable":              "→ Bez zmian",
+	"charts.trends_title":        "Trendy produktywności (%s)",
+
+	// Console statistics
+	"console.breakdown":       "Podział przerw:",
+	"console.continued":       "Zadania kontynuowane przez kilka dni:",
+	"console.cost":            "Koszt przerw (%s za godzinę, z powrotem do skupienia):",
+	"console.despite_dnd":     "Przerwy mimo trybu DND: %d (%.1f na godzinę)",
+	"console.estimates":       "Trafność szacunków: %.2fx szacunku, sesje: %d, w tym %d w granicach %.0f%%",
+	"console.focus_mode":      "Tryb skupienia (nie przeszkadzać): %s",
+	"console.issues":          "Sesje według zgłoszeń:",
+	"console.longest_block":   "Najdłuższy blok skupienia: %s (%s)",
+	"console.outside_dnd":     "Przerwy poza trybem DND: %d (%.1f na godzinę)",
+	"console.productive_hour": "Najbardziej produktywna godzina: %d:00 (%s pracy w skupieniu)",
+	"console.recurring":       "Powtarzające się zadania:",
+	"console.refocus":         "Czas powrotu do skupienia (do następnego bloku %d minut bez przerw):",
+	"console.score":           "Wynik produktywności: %.1f / 100",
+	"console.title":           "Statystyki dla zakresu %s (%s do %s)",
+	"console.total":           "razem",
+
+	// Meetings
+	"meetings.add":         "Dodaj:",
+	"meetings.none":        "Na dziś nie zaplanowano spotkań",
+	"meetings.placeholder": "np. 9:30-10 Standup; 14:00-15:00 Planowanie",
+	"meetings.title":       "Spotkania",
+}
@@ -0,0 +1,131 @@
+package integrations
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+)
+
+// DefaultActivityAway is how long the screen is locked or without input before coming back
+// counts as returning to the workstation
+const DefaultActivityAway = 30 * time.Minute
+
+// activityPollInterval is how often the screen lock and input idle time are checked
+const activityPollInterval = 10 * time.Second
+
+// Ways of coming back to the workstation
+const (
+	ActivityUnlock = "unlock"   // The screen was unlocked
+	ActivityInput  = "activity" // Keyboard or mouse input after a while without any
+)
+
+// ActivityEvent reports coming back to the workstation after being away
+type ActivityEvent struct {
+	Reason string        // unlock or activity
+	Away   time.Duration // How long the workstation was left alone
+	At     time.Time     // When the user came back
+}
+
+// ActivityWatcher polls the screen lock and the input idle time and reports coming back
+// after being away. Time the system spent suspended between polls counts as away too.
+type ActivityWatcher struct {
+	away         time.Duration
+	idleTime     func() (time.Duration, error)
+	screenLocked func() (bool, error)
+
+	awaySince time.Time // Since when the user is away, zero while present
+	locked    bool      // The screen was locked at the last poll
+	lastPoll  time.Time
+}
+
+// NewActivityWatcher creates an activity watcher from the configuration
+func NewActivityWatcher(cfg *config.Config) *ActivityWatcher {
+	away := DefaultActivityAway
+	if cfg.ActivityAwayMinutes > 0 {
+		away = time.Duration(cfg.ActivityAwayMinutes) * time.Minute
+	}
+	return &ActivityWatcher{
+		away:         away,
+		idleTime:     idleTime,
+		screenLocked: screenLocked,
+	}
+}
+
+// Poll checks the screen lock and idle time once and returns an event when the user came
+// back after being away. Fails only when neither can be read.
+func (w *ActivityWatcher) Poll(now time.Time) (*ActivityEvent, error) {
+	// A gap between polls means the system was suspended
+	if !w.lastPoll.IsZero() && now.Sub(w.lastPoll) >= w.away && w.awaySince.IsZero() {
+		w.awaySince = w.lastPoll
+	}
+	w.lastPoll = now
+
+	locked, lockErr := w.screenLocked()
+	idle, idleErr := w.idleTime()
+	if lockErr != nil && idleErr != nil {
+		return nil, errors.Join(lockErr, idleErr)
+	}
+
+	if lockErr == nil && locked {
+		if w.awaySince.IsZero() {
+			w.awaySince = now
+			if idleErr == nil {
+				w.awaySince = now.Add(-idle)
+			}
+		}
+		w.locked = true
+		return nil, nil
+	}
+	unlocked := w.locked
+	w.locked = false
+
+	if idleErr == nil && idle >= w.away {
+		if w.awaySince.IsZero() {
+			w.awaySince = now.Add(-idle)
+		}
+		return nil, nil
+	}
+	if w.awaySince.IsZero() {
+		return nil, nil
+	}
+
+	back := now
+	if idleErr == nil {
+		back = now.Add(-idle)
+	}
+	away := back.Sub(w.awaySince)
+	w.awaySince = time.Time{}
+	if away < w.away {
+		return nil, nil // Locked for a moment only
+	}
+
+	reason := ActivityInput
+	if unlocked {
+		reason = ActivityUnlock
+	}
+	return &ActivityEvent{Reason: reason, Away: away, At: back}, nil
+}
+
+// Run polls until stop is closed and calls handle for every event. Polling errors (e.g.
+// no way to read the idle time) are passed to onError once and stop the watcher.
+func (w *ActivityWatcher) Run(stop <-chan struct{}, handle func(ActivityEvent), onError func(error)) {
+	ticker := time.NewTicker(activityPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			event, err := w.Poll(now)
+			if err != nil {
+				onError(err)
+				return
+			}
+			if event != nil {
+				handle(*event)
+			}
+		}
+	}
+}
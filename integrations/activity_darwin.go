@@ -0,0 +1,36 @@
+//go:build darwin
+
+package integrations
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// hidIdlePattern matches the idle time in nanoseconds in the ioreg output
+var hidIdlePattern = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+// idleTime returns how long the system has been without input, from IOHIDSystem
+func idleTime() (time.Duration, error) {
+	output, err := exec.Command("ioreg", "-c", "IOHIDSystem", "-d", "4").Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to query idle time: %w", err)
+	}
+	match := hidIdlePattern.FindSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("idle time not found in ioreg output")
+	}
+	ns, err := strconv.ParseInt(string(match[1]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected idle time %q: %w", match[1], err)
+	}
+	return time.Duration(ns), nil
+}
+
+// screenLocked is not available on macOS, coming back is detected from input alone
+func screenLocked() (bool, error) {
+	return false, fmt.Errorf("screen lock detection is not supported on macOS")
+}
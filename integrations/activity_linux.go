@@ -0,0 +1,38 @@
+//go:build linux
+
+package integrations
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// idleTime returns how long the X11 session has been without input, using xprintidle
+func idleTime() (time.Duration, error) {
+	output, err := exec.Command("xprintidle").Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to query idle time (install xprintidle): %w", err)
+	}
+	ms, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected xprintidle output: %q", output)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// screenLocked reports whether the login session is locked, using loginctl
+func screenLocked() (bool, error) {
+	session := os.Getenv("XDG_SESSION_ID")
+	if session == "" {
+		session = "self"
+	}
+	output, err := exec.Command("loginctl", "show-session", session, "-p", "LockedHint", "--value").Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to query screen lock (is systemd-logind running?): %w", err)
+	}
+	return strings.TrimSpace(string(output)) == "yes", nil
+}
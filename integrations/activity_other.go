@@ -0,0 +1,19 @@
+//go:build !darwin && !linux
+
+package integrations
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// idleTime is not supported on this platform
+func idleTime() (time.Duration, error) {
+	return 0, fmt.Errorf("idle time is not supported on %s", runtime.GOOS)
+}
+
+// screenLocked is not supported on this platform
+func screenLocked() (bool, error) {
+	return false, fmt.Errorf("screen lock detection is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,100 @@
+package integrations
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeActivity returns a watcher reading the lock and idle time from the given variables
+func fakeActivity(locked *bool, idle *time.Duration, lockErr, idleErr error) *ActivityWatcher {
+	watcher := NewActivityWatcher(&config.Config{ActivityAwayMinutes: 20})
+	watcher.screenLocked = func() (bool, error) { return *locked, lockErr }
+	watcher.idleTime = func() (time.Duration, error) { return *idle, idleErr }
+	return watcher
+}
+
+// TestActivityWatcherInput tests coming back after a while without input
+func TestActivityWatcherInput(t *testing.T) {
+	var locked bool
+	var idle time.Duration
+	watcher := fakeActivity(&locked, &idle, errors.New("no logind"), nil)
+	start := time.Date(2025, 3, 10, 7, 0, 0, 0, time.Local)
+
+	idle = 5 * time.Minute
+	event, err := watcher.Poll(start)
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+
+	idle = 2 * time.Hour
+	event, _ = watcher.Poll(start.Add(2 * time.Hour))
+	assert.Nil(t, event)
+
+	// Input 10 seconds ago, after 2h 5m away
+	idle = 10 * time.Second
+	now := start.Add(2*time.Hour + 5*time.Minute + 10*time.Second)
+	event, _ = watcher.Poll(now)
+	if assert.NotNil(t, event) {
+		assert.Equal(t, ActivityInput, event.Reason)
+		assert.Equal(t, 2*time.Hour+5*time.Minute, event.Away)
+		assert.Equal(t, now.Add(-10*time.Second), event.At)
+	}
+
+	// Reported once
+	event, _ = watcher.Poll(now.Add(10 * time.Second))
+	assert.Nil(t, event)
+}
+
+// TestActivityWatcherUnlock tests unlocking after a long and a short lock
+func TestActivityWatcherUnlock(t *testing.T) {
+	locked := true
+	idle := time.Minute
+	watcher := fakeActivity(&locked, &idle, nil, nil)
+	start := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+
+	event, _ := watcher.Poll(start)
+	assert.Nil(t, event)
+
+	locked, idle = false, 0
+	event, _ = watcher.Poll(start.Add(45 * time.Minute))
+	if assert.NotNil(t, event) {
+		assert.Equal(t, ActivityUnlock, event.Reason)
+		assert.Equal(t, 46*time.Minute, event.Away)
+	}
+
+	// Locked for five minutes only
+	locked, idle = true, 0
+	watcher.Poll(start.Add(50 * time.Minute))
+	locked = false
+	event, _ = watcher.Poll(start.Add(55 * time.Minute))
+	assert.Nil(t, event)
+}
+
+// TestActivityWatcherSuspend tests that time suspended between polls counts as away
+func TestActivityWatcherSuspend(t *testing.T) {
+	var locked bool
+	var idle time.Duration
+	watcher := fakeActivity(&locked, &idle, nil, nil)
+	evening := time.Date(2025, 3, 10, 18, 0, 0, 0, time.Local)
+
+	watcher.Poll(evening)
+	event, _ := watcher.Poll(evening.Add(14 * time.Hour))
+	if assert.NotNil(t, event) {
+		assert.Equal(t, ActivityInput, event.Reason)
+		assert.Equal(t, 14*time.Hour, event.Away)
+	}
+}
+
+// TestActivityWatcherErrors tests that the watcher fails only without lock and idle time
+func TestActivityWatcherErrors(t *testing.T) {
+	var locked bool
+	var idle time.Duration
+	watcher := fakeActivity(&locked, &idle, errors.New("no logind"), errors.New("no xprintidle"))
+	_, err := watcher.Poll(time.Now())
+	assert.ErrorContains(t, err, "no xprintidle")
+
+	assert.Equal(t, DefaultActivityAway, NewActivityWatcher(&config.Config{}).away)
+}
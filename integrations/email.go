@@ -0,0 +1,155 @@
+package integrations
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+)
+
+// defaultSMTPPort is the submission port, used with STARTTLS
+const defaultSMTPPort = 587
+
+// implicitTLSPort is the port on which the connection uses TLS from the start
+const implicitTLSPort = 465
+
+// Mailer sends email through the configured SMTP server
+type Mailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+
+	// send delivers a message, replaced in tests
+	send func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewMailer creates a mailer from the SMTP settings of the configuration
+func NewMailer(cfg *config.Config) (*Mailer, error) {
+	if cfg.SMTPHost == "" {
+		return nil, fmt.Errorf("smtp_host is not configured")
+	}
+
+	from := cfg.EmailFrom
+	if from == "" {
+		from = cfg.SMTPUsername
+	}
+	if from == "" {
+		return nil, fmt.Errorf("email_from is not configured")
+	}
+	port := cfg.SMTPPort
+	if port <= 0 {
+		port = defaultSMTPPort
+	}
+
+	mailer := &Mailer{
+		host:     cfg.SMTPHost,
+		port:     port,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		from:     from,
+		send:     smtp.SendMail,
+	}
+	if port == implicitTLSPort {
+		mailer.send = mailer.sendTLS
+	}
+	return mailer, nil
+}
+
+// Send sends a message with a body of the given content type, e.g. "text/html", to the
+// recipients
+func (m *Mailer) Send(to []string, subject, contentType string, body []byte, now time.Time) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients configured")
+	}
+	for _, header := range append([]string{m.from, subject, contentType}, to...) {
+		if strings.ContainsAny(header, "\r\n") {
+			return fmt.Errorf("invalid line break in email header %q", header)
+		}
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	msg, err := buildMessage(m.from, to, subject, contentType, body, now)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+	if err := m.send(addr, auth, m.from, to, msg); err != nil {
+		return fmt.Errorf("failed to send email via %s: %w", addr, err)
+	}
+	return nil
+}
+
+// buildMessage formats a message with a UTF-8 body in quoted-printable, so long report
+// lines stay within the line length limit of SMTP
+func buildMessage(from string, to []string, subject, contentType string, body []byte, now time.Time) ([]byte, error) {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	writer := quotedprintable.NewWriter(&msg)
+	if _, err := writer.Write(body); err != nil {
+		return nil, fmt.Errorf("failed to encode email: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode email: %w", err)
+	}
+	return msg.Bytes(), nil
+}
+
+// sendTLS delivers a message over a connection that uses TLS from the start
+func (m *Mailer) sendTLS(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: m.host})
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	data, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(msg); err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
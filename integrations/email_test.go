@@ -0,0 +1,56 @@
+package integrations
+
+import (
+	"io"
+	"mime/quotedprintable"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMailer tests the SMTP settings and the message handed to the server
+func TestMailer(t *testing.T) {
+	_, err := NewMailer(&config.Config{})
+	assert.Error(t, err)
+	_, err = NewMailer(&config.Config{SMTPHost: "smtp.example.com"})
+	assert.Error(t, err)
+
+	mailer, err := NewMailer(&config.Config{SMTPHost: "smtp.example.com", SMTPUsername: "me@example.com", SMTPPassword: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "me@example.com", mailer.from)
+
+	var sentAddr, sentFrom string
+	var sentTo []string
+	var sent []byte
+	mailer.send = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		assert.NotNil(t, auth)
+		sentAddr, sentFrom, sentTo, sent = addr, from, to, msg
+		return nil
+	}
+
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	body := "<p>" + strings.Repeat("Focus ", 200) + "</p>"
+	assert.NoError(t, mailer.Send([]string{"me@example.com", "lead@example.com"}, "Raport 3–9 marca", "text/html", []byte(body), now))
+	assert.Equal(t, "smtp.example.com:587", sentAddr)
+	assert.Equal(t, "me@example.com", sentFrom)
+	assert.Equal(t, []string{"me@example.com", "lead@example.com"}, sentTo)
+
+	headers, encoded, _ := strings.Cut(string(sent), "\r\n\r\n")
+	assert.Contains(t, headers, "To: me@example.com, lead@example.com\r\n")
+	assert.Contains(t, headers, "Subject: =?utf-8?q?Raport_3=E2=80=939_marca?=\r\n")
+	assert.Contains(t, headers, "Content-Type: text/html; charset=utf-8\r\n")
+	for _, line := range strings.Split(encoded, "\r\n") {
+		assert.LessOrEqual(t, len(line), 76)
+	}
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(encoded)))
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+
+	// Headers cannot be injected and a message needs recipients
+	assert.Error(t, mailer.Send([]string{"me@example.com\r\nBcc: x@example.com"}, "Report", "text/html", nil, now))
+	assert.Error(t, mailer.Send(nil, "Report", "text/html", nil, now))
+}
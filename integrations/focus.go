@@ -0,0 +1,154 @@
+package integrations
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// Focus watcher modes
+const (
+	FocusModeSuggest = "suggest" // Ask whether to record the interruption after returning
+	FocusModeRecord  = "record"  // Record the interruption and the return automatically
+)
+
+// focusPollInterval is how often the active window is checked
+const focusPollInterval = 2 * time.Second
+
+// defaultFocusApps maps well-known interruption apps to tags when none are configured
+var defaultFocusApps = map[string]string{
+	"slack":   string(models.TagOther),
+	"zoom":    string(models.TagMeeting),
+	"teams":   string(models.TagMeeting),
+	"mail":    string(models.TagOther),
+	"outlook": string(models.TagOther),
+}
+
+// FocusEvent reports that an interruption app gained or lost focus
+type FocusEvent struct {
+	App          string                 // Name of the interruption app
+	Tag          models.InterruptionTag // Tag configured for the app
+	Interrupting bool                   // True when the app gained focus, false when it lost it
+	Since        time.Time              // When the app gained focus
+}
+
+// FocusWatcher polls the active window and reports switches to and from interruption apps
+type FocusWatcher struct {
+	apps      map[string]models.InterruptionTag
+	patterns  []string // Lower-cased app patterns in match order
+	mode      string
+	activeApp func() (string, error)
+
+	current *FocusEvent // Interruption app currently focused, if any
+}
+
+// NewFocusWatcher creates a focus watcher from the configuration
+func NewFocusWatcher(cfg *config.Config) *FocusWatcher {
+	configured := cfg.FocusWatcherApps
+	if len(configured) == 0 {
+		configured = defaultFocusApps
+	}
+
+	apps := make(map[string]models.InterruptionTag, len(configured))
+	for pattern, tag := range configured {
+		apps[strings.ToLower(pattern)] = models.InterruptionTag(tag)
+	}
+
+	// Longer patterns first so "microsoft teams" wins over "teams"
+	patterns := make([]string, 0, len(apps))
+	for pattern := range apps {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+
+	mode := cfg.FocusWatcherMode
+	if mode != FocusModeRecord {
+		mode = FocusModeSuggest
+	}
+
+	return &FocusWatcher{
+		apps:      apps,
+		patterns:  patterns,
+		mode:      mode,
+		activeApp: activeApp,
+	}
+}
+
+// Mode returns whether interruptions are suggested or recorded automatically
+func (w *FocusWatcher) Mode() string {
+	return w.mode
+}
+
+// Match returns the tag for an app name if it is a known interruption app
+func (w *FocusWatcher) Match(app string) (models.InterruptionTag, bool) {
+	name := strings.ToLower(app)
+	for _, pattern := range w.patterns {
+		if strings.Contains(name, pattern) {
+			return w.apps[pattern], true
+		}
+	}
+	return "", false
+}
+
+// Poll checks the active window once and returns an event when focus moved to or
+// away from an interruption app
+func (w *FocusWatcher) Poll(now time.Time) (*FocusEvent, error) {
+	app, err := w.activeApp()
+	if err != nil {
+		return nil, err
+	}
+
+	tag, matched := w.Match(app)
+
+	// Still in the same interruption app, or still outside any of them
+	if w.current != nil && matched && strings.EqualFold(w.current.App, app) {
+		return nil, nil
+	}
+	if w.current == nil && !matched {
+		return nil, nil
+	}
+
+	// Leaving an interruption app is reported first; switching directly between two
+	// interruption apps is reported as the new one on the next poll
+	if w.current != nil {
+		left := *w.current
+		left.Interrupting = false
+		w.current = nil
+		return &left, nil
+	}
+
+	w.current = &FocusEvent{App: app, Tag: tag, Interrupting: true, Since: now}
+	event := *w.current
+	return &event, nil
+}
+
+// Run polls until stop is closed and calls handle for every event. Polling errors
+// (e.g. no window manager) are passed to onError once and stop the watcher.
+func (w *FocusWatcher) Run(stop <-chan struct{}, handle func(FocusEvent), onError func(error)) {
+	ticker := time.NewTicker(focusPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			event, err := w.Poll(now)
+			if err != nil {
+				onError(err)
+				return
+			}
+			if event != nil {
+				handle(*event)
+			}
+		}
+	}
+}
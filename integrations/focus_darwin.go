@@ -0,0 +1,29 @@
+//go:build darwin
+
+package integrations
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// activeApp returns the name of the frontmost application using System Events
+func activeApp() (string, error) {
+	output, err := exec.Command("osascript", "-e",
+		`tell application "System Events" to get name of first application process whose frontmost is true`).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query frontmost application (is accessibility access granted?): %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// activeWindowTitle returns the title of the front window of the frontmost application
+func activeWindowTitle() (string, error) {
+	output, err := exec.Command("osascript", "-e",
+		`tell application "System Events" to get name of front window of (first application process whose frontmost is true)`).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query front window title (is accessibility access granted?): %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
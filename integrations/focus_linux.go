@@ -0,0 +1,47 @@
+//go:build linux
+
+package integrations
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// activeApp returns the window class of the active X11 window, using xdotool or xprop
+func activeApp() (string, error) {
+	if output, err := exec.Command("xdotool", "getactivewindow", "getwindowclassname").Output(); err == nil {
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	// Fall back to xprop: _NET_ACTIVE_WINDOW(WINDOW): window id # 0x3a00007
+	output, err := exec.Command("xprop", "-root", "_NET_ACTIVE_WINDOW").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query active window (install xdotool or xprop): %w", err)
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("unexpected xprop output: %q", output)
+	}
+
+	// WM_CLASS(STRING) = "slack", "Slack"
+	output, err = exec.Command("xprop", "-id", fields[len(fields)-1], "WM_CLASS").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query window class: %w", err)
+	}
+	_, classes, found := strings.Cut(string(output), "=")
+	if !found {
+		return "", nil // Root window or no class set
+	}
+	parts := strings.Split(classes, ",")
+	return strings.Trim(strings.TrimSpace(parts[len(parts)-1]), `"`), nil
+}
+
+// activeWindowTitle returns the title of the active X11 window using xdotool
+func activeWindowTitle() (string, error) {
+	output, err := exec.Command("xdotool", "getactivewindow", "getwindowname").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query active window title (install xdotool): %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,18 @@
+//go:build !darwin && !linux
+
+package integrations
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// activeApp is not supported on this platform
+func activeApp() (string, error) {
+	return "", fmt.Errorf("focus watcher is not supported on %s", runtime.GOOS)
+}
+
+// activeWindowTitle is not supported on this platform
+func activeWindowTitle() (string, error) {
+	return "", fmt.Errorf("window titles are not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,65 @@
+package integrations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFocusWatcherPoll tests that only switches to and from interruption apps are reported
+func TestFocusWatcherPoll(t *testing.T) {
+	watcher := NewFocusWatcher(&config.Config{
+		FocusWatcherMode: FocusModeRecord,
+		FocusWatcherApps: map[string]string{"slack": "chat", "zoom": "meeting"},
+	})
+	assert.Equal(t, FocusModeRecord, watcher.Mode())
+
+	focused := "Alacritty"
+	watcher.activeApp = func() (string, error) { return focused, nil }
+	base := time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	// Terminal focused: nothing to report
+	event, err := watcher.Poll(base)
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+
+	// Switching to Slack starts an interruption
+	focused = "Slack"
+	event, err = watcher.Poll(base.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, &FocusEvent{App: "Slack", Tag: "chat", Interrupting: true, Since: base.Add(time.Minute)}, event)
+
+	event, _ = watcher.Poll(base.Add(2 * time.Minute))
+	assert.Nil(t, event)
+
+	// Switching straight to Zoom ends the Slack interruption, then starts the Zoom one
+	focused = "zoom.us"
+	event, _ = watcher.Poll(base.Add(3 * time.Minute))
+	assert.False(t, event.Interrupting)
+	assert.Equal(t, "Slack", event.App)
+	event, _ = watcher.Poll(base.Add(4 * time.Minute))
+	assert.True(t, event.Interrupting)
+	assert.Equal(t, models.TagMeeting, event.Tag)
+
+	// Back to the terminal
+	focused = "Alacritty"
+	event, _ = watcher.Poll(base.Add(5 * time.Minute))
+	assert.False(t, event.Interrupting)
+	assert.Equal(t, base.Add(4*time.Minute), event.Since)
+}
+
+// TestFocusWatcherDefaults tests the built-in app list and mode
+func TestFocusWatcherDefaults(t *testing.T) {
+	watcher := NewFocusWatcher(&config.Config{})
+	assert.Equal(t, FocusModeSuggest, watcher.Mode())
+
+	tag, ok := watcher.Match("Microsoft Teams")
+	assert.True(t, ok)
+	assert.Equal(t, models.TagMeeting, tag)
+
+	_, ok = watcher.Match("Visual Studio Code")
+	assert.False(t, ok)
+}
@@ -0,0 +1,113 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HooksDirName is the directory in the data directory that holds hook scripts
+const HooksDirName = "hooks"
+
+// hookTimeout is how long a hook script may run before it is killed
+const hookTimeout = 30 * time.Second
+
+// HookRunner executes user-defined scripts from the hooks directory on tracker events.
+// A script named after the event, e.g. on-start or on-start.sh for session_start,
+// receives the event as TRACKER_* environment variables and as JSON on stdin.
+type HookRunner struct {
+	dir     string
+	timeout time.Duration
+}
+
+// NewHookRunner creates a runner for the scripts in the hooks directory of a data directory
+func NewHookRunner(dataDir string) *HookRunner {
+	return &HookRunner{
+		dir:     filepath.Join(dataDir, HooksDirName),
+		timeout: hookTimeout,
+	}
+}
+
+// HookName returns the script name for an event, e.g. "on-start" for session_start
+func HookName(event string) string {
+	return "on-" + strings.ReplaceAll(strings.TrimPrefix(event, "session_"), "_", "-")
+}
+
+// Find returns the path of the executable script for an event, if there is one. Scripts
+// owned by another user or writable by others are skipped, as anyone able to change them
+// could run commands as the user.
+func (r *HookRunner) Find(event string) (string, bool) {
+	name := HookName(event)
+	matches, err := filepath.Glob(filepath.Join(r.dir, name+"*"))
+	if err != nil {
+		return "", false
+	}
+	sort.Strings(matches)
+
+	for _, path := range matches {
+		base := filepath.Base(path)
+		if base != name && !strings.HasPrefix(base, name+".") {
+			continue // e.g. on-start-notes for on-start
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		if info.Mode().Perm()&0022 != 0 || !ownedByUser(info) {
+			continue
+		}
+		return path, true
+	}
+
+	return "", false
+}
+
+// Run executes the script for the payload's event, doing nothing when there is none
+func (r *HookRunner) Run(payload WebhookPayload) error {
+	path, ok := r.Find(payload.Event)
+	if !ok {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Dir = r.dir
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(), hookEnv(payload)...)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("hook %s failed: %w: %s", filepath.Base(path), err, message)
+		}
+		return fmt.Errorf("hook %s failed: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
+
+// hookEnv returns the event data as environment variables for hook scripts
+func hookEnv(payload WebhookPayload) []string {
+	return []string{
+		"TRACKER_EVENT=" + payload.Event,
+		"TRACKER_TIMESTAMP=" + payload.Timestamp.Format(time.RFC3339),
+		"TRACKER_SESSION_ID=" + payload.SessionID,
+		"TRACKER_DESCRIPTION=" + payload.Description,
+		"TRACKER_PROJECT=" + payload.Project,
+		"TRACKER_TAG=" + string(payload.Tag),
+		"TRACKER_NOTE=" + payload.Note,
+	}
+}
@@ -0,0 +1,62 @@
+package integrations
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHookRunner tests that hook scripts receive the event as env vars and JSON on stdin
+func TestHookRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts need a POSIX shell")
+	}
+
+	dataDir := t.TempDir()
+	hooksDir := filepath.Join(dataDir, HooksDirName)
+	assert.NoError(t, os.MkdirAll(hooksDir, 0755))
+
+	script := "#!/bin/sh\necho \"$TRACKER_EVENT $TRACKER_TAG $TRACKER_DESCRIPTION\" > env.out\ncat > stdin.out\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(hooksDir, "on-interrupt.sh"), []byte(script), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(hooksDir, "on-end"), []byte("#!/bin/sh\necho broken >&2\nexit 3\n"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(hooksDir, "on-start"), []byte("#!/bin/sh\n"), 0644))
+
+	runner := NewHookRunner(dataDir)
+	session := models.NewSession(models.NewTimeEntry(models.EntryTypeStart, "Deep work"))
+
+	assert.NoError(t, runner.Run(NewWebhookPayload(EventInterrupt, session, models.NewInterruptionEntry("Phone", models.TagCall))))
+	env, err := os.ReadFile(filepath.Join(hooksDir, "env.out"))
+	assert.NoError(t, err)
+	assert.Equal(t, "interrupt call Deep work", strings.TrimSpace(string(env)))
+
+	stdin, err := os.ReadFile(filepath.Join(hooksDir, "stdin.out"))
+	assert.NoError(t, err)
+	var payload WebhookPayload
+	assert.NoError(t, json.Unmarshal(stdin, &payload))
+	assert.Equal(t, session.ID, payload.SessionID)
+	assert.Equal(t, "Phone", payload.Note)
+
+	err = runner.Run(NewWebhookPayload(EventSessionEnd, session, nil))
+	assert.ErrorContains(t, err, "broken")
+
+	// Scripts that are not executable or writable by others, and events without a script,
+	// are skipped
+	_, found := runner.Find(EventSessionStart)
+	assert.False(t, found)
+	assert.NoError(t, os.WriteFile(filepath.Join(hooksDir, "on-resume"), []byte("#!/bin/sh\n"), 0755))
+	_, found = runner.Find(EventSessionResume)
+	assert.True(t, found)
+	assert.NoError(t, os.Chmod(filepath.Join(hooksDir, "on-resume"), 0777))
+	_, found = runner.Find(EventSessionResume)
+	assert.False(t, found)
+	assert.NoError(t, runner.Run(NewWebhookPayload(EventDayRollover, session, nil)))
+
+	assert.Equal(t, "on-day-rollover", HookName(EventDayRollover))
+	assert.Equal(t, "on-return", HookName(EventReturn))
+}
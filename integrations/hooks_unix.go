@@ -0,0 +1,14 @@
+//go:build unix
+
+package integrations
+
+import (
+	"os"
+	"syscall"
+)
+
+// ownedByUser reports whether the file belongs to the user running the tracker
+func ownedByUser(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	return ok && int(stat.Uid) == os.Getuid()
+}
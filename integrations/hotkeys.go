@@ -0,0 +1,237 @@
+package integrations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+)
+
+// Hotkey actions
+const (
+	HotkeyToggle    = "toggle"    // Interrupt while working, return while interrupted
+	HotkeyInterrupt = "interrupt" // Interrupt the active session, with a tag as interrupt:TAG
+	HotkeyReturn    = "return"    // Return from the ongoing interruption
+)
+
+// Formats of hotkey bindings, one for each hotkey tool
+const (
+	HotkeyFormatSxhkd      = "sxhkd"      // X11 hotkey daemon on Linux and BSD
+	HotkeyFormatGnome      = "gnome"      // gsettings commands adding GNOME custom shortcuts
+	HotkeyFormatSkhd       = "skhd"       // macOS hotkey daemon
+	HotkeyFormatAutoHotkey = "autohotkey" // AutoHotkey v2 script on Windows
+)
+
+// defaultHotkeys are bound when none are configured
+var defaultHotkeys = map[string]string{HotkeyToggle: "ctrl+alt+i"}
+
+// hotkeyModifiers maps modifier names to their canonical name
+var hotkeyModifiers = map[string]string{
+	"ctrl": "ctrl", "control": "ctrl",
+	"alt": "alt", "option": "alt", "opt": "alt",
+	"shift": "shift",
+	"super": "super", "win": "super", "cmd": "super", "command": "super", "meta": "super",
+}
+
+// modifierOrder is the order modifiers are written in
+var modifierOrder = []string{"ctrl", "alt", "shift", "super"}
+
+// Hotkey is a key pressed with modifiers
+type Hotkey struct {
+	Modifiers []string // ctrl, alt, shift or super, in that order
+	Key       string   // Lower-cased letter, digit, f1-f24 or space
+}
+
+// ParseHotkey parses a key combination such as "ctrl+alt+i". Modifiers are ctrl, alt,
+// shift and super (or control, option, cmd, win); a global hotkey needs at least one, so
+// it does not take over typing.
+func ParseHotkey(text string) (Hotkey, error) {
+	var hotkey Hotkey
+	mods := map[string]bool{}
+	for _, part := range strings.Split(strings.ToLower(strings.ReplaceAll(text, " ", "")), "+") {
+		if modifier, ok := hotkeyModifiers[part]; ok {
+			mods[modifier] = true
+			continue
+		}
+		if hotkey.Key != "" {
+			return Hotkey{}, fmt.Errorf("hotkey %q has more than one key", text)
+		}
+		if !validHotkeyKey(part) {
+			return Hotkey{}, fmt.Errorf("hotkey %q: unsupported key %q, use a letter, digit, f1-f24 or space", text, part)
+		}
+		hotkey.Key = part
+	}
+
+	if hotkey.Key == "" {
+		return Hotkey{}, fmt.Errorf("hotkey %q has no key", text)
+	}
+	for _, modifier := range modifierOrder {
+		if mods[modifier] {
+			hotkey.Modifiers = append(hotkey.Modifiers, modifier)
+		}
+	}
+	if len(hotkey.Modifiers) == 0 {
+		return Hotkey{}, fmt.Errorf("hotkey %q needs a modifier such as ctrl or alt", text)
+	}
+	return hotkey, nil
+}
+
+// validHotkeyKey reports whether a key is a letter, digit, function key or space
+func validHotkeyKey(key string) bool {
+	if len(key) == 1 {
+		return (key[0] >= 'a' && key[0] <= 'z') || (key[0] >= '0' && key[0] <= '9')
+	}
+	if key == "space" {
+		return true
+	}
+	var n int
+	if _, err := fmt.Sscanf(key, "f%d", &n); err == nil && fmt.Sprintf("f%d", n) == key {
+		return n >= 1 && n <= 24
+	}
+	return false
+}
+
+// ParseHotkeyAction parses an action: toggle, interrupt, interrupt:TAG or return
+func ParseHotkeyAction(text string) (action, tag string, err error) {
+	action, tag, _ = strings.Cut(strings.ToLower(strings.TrimSpace(text)), ":")
+	switch action {
+	case HotkeyInterrupt:
+		return action, tag, nil
+	case HotkeyToggle, HotkeyReturn:
+		if tag == "" {
+			return action, "", nil
+		}
+	}
+	return "", "", fmt.Errorf("unknown hotkey action %q, use toggle, interrupt, interrupt:TAG or return", text)
+}
+
+// HotkeyBinding runs an action when its hotkey is pressed
+type HotkeyBinding struct {
+	Action string // As configured, e.g. interrupt:meeting
+	Hotkey Hotkey
+}
+
+// HotkeyBindings returns the configured hotkeys sorted by action, toggle on ctrl+alt+i
+// when none are configured
+func HotkeyBindings(cfg *config.Config) ([]HotkeyBinding, error) {
+	configured := cfg.Hotkeys
+	if len(configured) == 0 {
+		configured = defaultHotkeys
+	}
+
+	bindings := make([]HotkeyBinding, 0, len(configured))
+	taken := map[string]string{}
+	for action, text := range configured {
+		if _, _, err := ParseHotkeyAction(action); err != nil {
+			return nil, err
+		}
+		hotkey, err := ParseHotkey(text)
+		if err != nil {
+			return nil, err
+		}
+		combo := strings.Join(append(append([]string{}, hotkey.Modifiers...), hotkey.Key), "+")
+		if other, ok := taken[combo]; ok {
+			return nil, fmt.Errorf("hotkey %s is bound to both %s and %s", combo, other, action)
+		}
+		taken[combo] = action
+		bindings = append(bindings, HotkeyBinding{Action: strings.ToLower(strings.TrimSpace(action)), Hotkey: hotkey})
+	}
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].Action < bindings[j].Action })
+	return bindings, nil
+}
+
+// HotkeyConfig writes the bindings for a hotkey tool, each running command with its action
+// appended
+func HotkeyConfig(format string, bindings []HotkeyBinding, command []string) (string, error) {
+	var sb strings.Builder
+	switch format {
+	case HotkeyFormatSxhkd:
+		sb.WriteString("# Add to ~/.config/sxhkd/sxhkdrc\n")
+		for _, binding := range bindings {
+			keys := append(append([]string{}, binding.Hotkey.Modifiers...), xKey(binding.Hotkey.Key))
+			fmt.Fprintf(&sb, "%s\n\t%s\n", strings.Join(keys, " + "), shellCommand(command, binding.Action))
+		}
+	case HotkeyFormatSkhd:
+		sb.WriteString("# Add to ~/.skhdrc\n")
+		for _, binding := range bindings {
+			mods := make([]string, len(binding.Hotkey.Modifiers))
+			for i, modifier := range binding.Hotkey.Modifiers {
+				mods[i] = strings.Replace(modifier, "super", "cmd", 1)
+			}
+			fmt.Fprintf(&sb, "%s - %s : %s\n", strings.Join(mods, " + "), binding.Hotkey.Key, shellCommand(command, binding.Action))
+		}
+	case HotkeyFormatAutoHotkey:
+		sb.WriteString("; Save as interruption-tracker.ahk and run it with AutoHotkey v2, e.g. from the Startup folder\n")
+		symbols := map[string]string{"ctrl": "^", "alt": "!", "shift": "+", "super": "#"}
+		for _, binding := range bindings {
+			var mods string
+			for _, modifier := range binding.Hotkey.Modifiers {
+				mods += symbols[modifier]
+			}
+			args := make([]string, 0, len(command)+1)
+			for _, arg := range append(append([]string{}, command...), binding.Action) {
+				if strings.ContainsAny(arg, " \t") {
+					arg = `"` + arg + `"`
+				}
+				args = append(args, arg)
+			}
+			line := strings.ReplaceAll(strings.Join(args, " "), "'", "`'")
+			fmt.Fprintf(&sb, "%s%s::Run('%s', , \"Hide\")\n", mods, xKey(binding.Hotkey.Key), line)
+		}
+	case HotkeyFormatGnome:
+		const schema = "org.gnome.settings-daemon.plugins.media-keys"
+		const base = "/org/gnome/settings-daemon/plugins/media-keys/custom-keybindings/interruption-tracker-"
+		sb.WriteString("# Run these commands; the first replaces the list of custom shortcuts, add the paths of existing ones to keep them\n")
+		paths := make([]string, len(bindings))
+		for i := range bindings {
+			paths[i] = fmt.Sprintf(`"%s%d/"`, base, i)
+		}
+		fmt.Fprintf(&sb, "gsettings set %s custom-keybindings %s\n", schema, shellQuote("["+strings.Join(paths, ", ")+"]"))
+		for i, binding := range bindings {
+			var accelerator string
+			for _, modifier := range binding.Hotkey.Modifiers {
+				accelerator += map[string]string{"ctrl": "<Control>", "alt": "<Alt>", "shift": "<Shift>", "super": "<Super>"}[modifier]
+			}
+			accelerator += xKey(binding.Hotkey.Key)
+
+			key := fmt.Sprintf("%s.custom-keybinding:%s%d/", schema, base, i)
+			fmt.Fprintf(&sb, "gsettings set %s name %s\n", key, shellQuote(gvariantString("Interruption tracker: "+binding.Action)))
+			fmt.Fprintf(&sb, "gsettings set %s command %s\n", key, shellQuote(gvariantString(shellCommand(command, binding.Action))))
+			fmt.Fprintf(&sb, "gsettings set %s binding %s\n", key, shellQuote(gvariantString(accelerator)))
+		}
+	default:
+		return "", fmt.Errorf("unknown hotkey format %q, use sxhkd, gnome, skhd or autohotkey", format)
+	}
+	return sb.String(), nil
+}
+
+// xKey returns the X11 and AutoHotkey name of a key: F1 for f1, space as is
+func xKey(key string) string {
+	if len(key) > 1 && key[0] == 'f' {
+		return strings.ToUpper(key)
+	}
+	return key
+}
+
+// shellCommand returns the command with the action appended, quoted for a POSIX shell
+func shellCommand(command []string, action string) string {
+	quoted := make([]string, 0, len(command)+1)
+	for _, arg := range append(append([]string{}, command...), action) {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes text for a POSIX shell unless it only has safe characters
+func shellQuote(text string) string {
+	if text != "" && strings.Trim(text, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@%+,") == "" {
+		return text
+	}
+	return "'" + strings.ReplaceAll(text, "'", `'\''`) + "'"
+}
+
+// gvariantString writes text as a GVariant string literal
+func gvariantString(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
@@ -0,0 +1,8 @@
+//go:build darwin
+
+package integrations
+
+// DefaultHotkeyFormat returns the hotkey tool of macOS, skhd
+func DefaultHotkeyFormat() string {
+	return HotkeyFormatSkhd
+}
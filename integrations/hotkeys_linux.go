@@ -0,0 +1,17 @@
+//go:build linux
+
+package integrations
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultHotkeyFormat returns the hotkey tool of the desktop: GNOME custom shortcuts under
+// GNOME, sxhkd otherwise
+func DefaultHotkeyFormat() string {
+	if strings.Contains(strings.ToUpper(os.Getenv("XDG_CURRENT_DESKTOP")), "GNOME") {
+		return HotkeyFormatGnome
+	}
+	return HotkeyFormatSxhkd
+}
@@ -0,0 +1,14 @@
+//go:build !darwin && !linux
+
+package integrations
+
+import "runtime"
+
+// DefaultHotkeyFormat returns the hotkey tool of the platform: AutoHotkey on Windows,
+// sxhkd on the BSDs and other X11 systems
+func DefaultHotkeyFormat() string {
+	if runtime.GOOS == "windows" {
+		return HotkeyFormatAutoHotkey
+	}
+	return HotkeyFormatSxhkd
+}
@@ -0,0 +1,100 @@
+package integrations
+
+import (
+	"testing"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseHotkey tests key combinations and the ones rejected
+func TestParseHotkey(t *testing.T) {
+	hotkey, err := ParseHotkey("Alt + Ctrl + I")
+	assert.NoError(t, err)
+	assert.Equal(t, Hotkey{Modifiers: []string{"ctrl", "alt"}, Key: "i"}, hotkey)
+
+	hotkey, err = ParseHotkey("cmd+shift+f12")
+	assert.NoError(t, err)
+	assert.Equal(t, Hotkey{Modifiers: []string{"shift", "super"}, Key: "f12"}, hotkey)
+
+	hotkey, err = ParseHotkey("win+space")
+	assert.NoError(t, err)
+	assert.Equal(t, Hotkey{Modifiers: []string{"super"}, Key: "space"}, hotkey)
+
+	for _, text := range []string{"i", "ctrl+alt", "ctrl+i+j", "ctrl+f25", "ctrl+f01", "ctrl+enter", ""} {
+		_, err := ParseHotkey(text)
+		assert.Error(t, err, text)
+	}
+}
+
+// TestParseHotkeyAction tests actions with and without tags
+func TestParseHotkeyAction(t *testing.T) {
+	action, tag, err := ParseHotkeyAction("Interrupt:Meeting")
+	assert.NoError(t, err)
+	assert.Equal(t, HotkeyInterrupt, action)
+	assert.Equal(t, "meeting", tag)
+
+	action, tag, err = ParseHotkeyAction("toggle")
+	assert.NoError(t, err)
+	assert.Equal(t, HotkeyToggle, action)
+	assert.Empty(t, tag)
+
+	_, _, err = ParseHotkeyAction("return:call")
+	assert.Error(t, err)
+	_, _, err = ParseHotkeyAction("pause")
+	assert.Error(t, err)
+}
+
+// TestHotkeyBindings tests the default and configured bindings
+func TestHotkeyBindings(t *testing.T) {
+	bindings, err := HotkeyBindings(&config.Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, []HotkeyBinding{{Action: "toggle", Hotkey: Hotkey{Modifiers: []string{"ctrl", "alt"}, Key: "i"}}}, bindings)
+
+	bindings, err = HotkeyBindings(&config.Config{Hotkeys: map[string]string{"return": "ctrl+alt+r", "interrupt:meeting": "ctrl+alt+m"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "interrupt:meeting", bindings[0].Action)
+	assert.Equal(t, "return", bindings[1].Action)
+
+	_, err = HotkeyBindings(&config.Config{Hotkeys: map[string]string{"return": "ctrl+alt+r", "toggle": "alt+control+r"}})
+	assert.ErrorContains(t, err, "bound to both")
+	_, err = HotkeyBindings(&config.Config{Hotkeys: map[string]string{"pause": "ctrl+alt+p"}})
+	assert.Error(t, err)
+}
+
+// TestHotkeyConfig tests the bindings written for each hotkey tool
+func TestHotkeyConfig(t *testing.T) {
+	bindings := []HotkeyBinding{
+		{Action: "interrupt:meeting", Hotkey: Hotkey{Modifiers: []string{"ctrl", "super"}, Key: "f8"}},
+		{Action: "toggle", Hotkey: Hotkey{Modifiers: []string{"ctrl", "alt"}, Key: "i"}},
+	}
+	command := []string{"/opt/my tools/interruption-tracker", "hotkey"}
+
+	text, err := HotkeyConfig(HotkeyFormatSxhkd, bindings, command)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Add to ~/.config/sxhkd/sxhkdrc\n"+
+		"ctrl + super + F8\n\t'/opt/my tools/interruption-tracker' hotkey interrupt:meeting\n"+
+		"ctrl + alt + i\n\t'/opt/my tools/interruption-tracker' hotkey toggle\n", text)
+
+	text, err = HotkeyConfig(HotkeyFormatSkhd, bindings, command)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Add to ~/.skhdrc\n"+
+		"ctrl + cmd - f8 : '/opt/my tools/interruption-tracker' hotkey interrupt:meeting\n"+
+		"ctrl + alt - i : '/opt/my tools/interruption-tracker' hotkey toggle\n", text)
+
+	text, err = HotkeyConfig(HotkeyFormatAutoHotkey, bindings, []string{`C:\Tools\interruption-tracker.exe`, "hotkey"})
+	assert.NoError(t, err)
+	assert.Contains(t, text, "^#F8::Run('C:\\Tools\\interruption-tracker.exe hotkey interrupt:meeting', , \"Hide\")\n")
+	assert.Contains(t, text, "^!i::Run('C:\\Tools\\interruption-tracker.exe hotkey toggle', , \"Hide\")\n")
+
+	text, err = HotkeyConfig(HotkeyFormatGnome, bindings[1:], command)
+	assert.NoError(t, err)
+	key := "org.gnome.settings-daemon.plugins.media-keys.custom-keybinding:/org/gnome/settings-daemon/plugins/media-keys/custom-keybindings/interruption-tracker-0/"
+	assert.Contains(t, text, `gsettings set org.gnome.settings-daemon.plugins.media-keys custom-keybindings '["/org/gnome/settings-daemon/plugins/media-keys/custom-keybindings/interruption-tracker-0/"]'`+"\n")
+	assert.Contains(t, text, "gsettings set "+key+` name '"Interruption tracker: toggle"'`+"\n")
+	assert.Contains(t, text, "gsettings set "+key+` command '"'\''/opt/my tools/interruption-tracker'\'' hotkey toggle"'`+"\n")
+	assert.Contains(t, text, "gsettings set "+key+` binding '"<Control><Alt>i"'`+"\n")
+
+	_, err = HotkeyConfig("xbindkeys", bindings, command)
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// gitHubAPIURL is the default GitHub REST API endpoint
+const gitHubAPIURL = "https://api.github.com"
+
+// IssueResolver looks up issue titles in Jira and GitHub
+type IssueResolver struct {
+	jiraBaseURL  string
+	jiraEmail    string
+	jiraAPIToken string
+	jiraProjects map[string]bool
+	gitHubAPIURL string
+	gitHubToken  string
+	httpClient   *http.Client
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// NewIssueResolver creates an issue resolver from the configuration
+func NewIssueResolver(cfg *config.Config) *IssueResolver {
+	apiURL := cfg.GitHubAPIURL
+	if apiURL == "" {
+		apiURL = gitHubAPIURL
+	}
+
+	projects := make(map[string]bool, len(cfg.JiraProjects))
+	for _, project := range cfg.JiraProjects {
+		projects[strings.ToUpper(strings.TrimSpace(project))] = true
+	}
+
+	return &IssueResolver{
+		jiraBaseURL:  strings.TrimSuffix(cfg.JiraBaseURL, "/"),
+		jiraEmail:    cfg.JiraEmail,
+		jiraAPIToken: cfg.JiraAPIToken,
+		jiraProjects: projects,
+		gitHubAPIURL: strings.TrimSuffix(apiURL, "/"),
+		gitHubToken:  cfg.GitHubToken,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		cache:        make(map[string]string),
+	}
+}
+
+// Resolvable reports whether the title of the issue can be looked up: Jira keys of the
+// configured jira_projects once jira_base_url is set, and GitHub references once
+// github_token or github_api_url is set. Nothing is looked up without configuration, and
+// words like UTF-8 or SHA-256 are never mistaken for Jira keys.
+func (r *IssueResolver) Resolvable(key string) bool {
+	if models.IsGitHubIssueKey(key) {
+		return r.gitHubToken != "" || r.gitHubAPIURL != gitHubAPIURL
+	}
+	return r.jiraBaseURL != "" && r.jiraProjects[models.JiraProject(key)]
+}
+
+// Resolve returns the title of the issue referenced by key.
+// Titles are cached for the lifetime of the resolver.
+func (r *IssueResolver) Resolve(key string) (string, error) {
+	r.mu.Lock()
+	title, cached := r.cache[key]
+	r.mu.Unlock()
+	if cached {
+		return title, nil
+	}
+	if !r.Resolvable(key) {
+		return "", fmt.Errorf("no issue tracker configured for %s", key)
+	}
+
+	var err error
+	if models.IsGitHubIssueKey(key) {
+		title, err = r.resolveGitHub(key)
+	} else {
+		title, err = r.resolveJira(key)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	r.cache[key] = title
+	r.mu.Unlock()
+
+	return title, nil
+}
+
+// resolveGitHub fetches the title of an owner/repo#number reference
+func (r *IssueResolver) resolveGitHub(key string) (string, error) {
+	repo, number, found := strings.Cut(key, "#")
+	if !found {
+		return "", fmt.Errorf("invalid GitHub reference: %s", key)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/repos/%s/issues/%s", r.gitHubAPIURL, repo, number), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if r.gitHubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+r.gitHubToken)
+	}
+
+	var issue struct {
+		Title string `json:"title"`
+	}
+	if err := r.fetchJSON(req, &issue); err != nil {
+		return "", err
+	}
+
+	return issue.Title, nil
+}
+
+// resolveJira fetches the summary of a Jira issue key
+func (r *IssueResolver) resolveJira(key string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary", r.jiraBaseURL, key), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if r.jiraAPIToken != "" {
+		req.SetBasicAuth(r.jiraEmail, r.jiraAPIToken)
+	}
+
+	var issue struct {
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	}
+	if err := r.fetchJSON(req, &issue); err != nil {
+		return "", err
+	}
+
+	return issue.Fields.Summary, nil
+}
+
+// fetchJSON performs the request and decodes a JSON response body into target
+func (r *IssueResolver) fetchJSON(req *http.Request, target interface{}) error {
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("issue lookup returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode issue: %w", err)
+	}
+
+	return nil
+}
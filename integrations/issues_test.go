@@ -0,0 +1,47 @@
+package integrations
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIssueResolver tests looking up titles only for issues of configured trackers
+func TestIssueResolver(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/rest/api/2/issue/PROJ-42", r.URL.Path)
+		fmt.Fprint(w, `{"fields":{"summary":"Fix [red]login"}}`)
+	}))
+	defer server.Close()
+
+	// Nothing is looked up without configuration
+	resolver := NewIssueResolver(config.DefaultConfig())
+	for _, key := range []string{"PROJ-42", "owner/repo#7"} {
+		assert.False(t, resolver.Resolvable(key))
+		_, err := resolver.Resolve(key)
+		assert.Error(t, err)
+	}
+
+	resolver = NewIssueResolver(&config.Config{JiraBaseURL: server.URL + "/", JiraProjects: []string{"proj"}})
+	assert.True(t, resolver.Resolvable("PROJ-42"))
+	assert.False(t, resolver.Resolvable("UTF-8"))
+	assert.False(t, resolver.Resolvable("owner/repo#7"))
+	title, err := resolver.Resolve("PROJ-42")
+	assert.NoError(t, err)
+	assert.Equal(t, "Fix [red]login", title)
+	_, err = resolver.Resolve("SHA-256")
+	assert.Error(t, err)
+
+	// Titles are cached
+	_, err = resolver.Resolve("PROJ-42")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	assert.True(t, NewIssueResolver(&config.Config{GitHubToken: "token"}).Resolvable("owner/repo#7"))
+}
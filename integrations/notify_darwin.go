@@ -0,0 +1,23 @@
+//go:build darwin
+
+package integrations
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// appleScriptString quotes text as an AppleScript string literal
+func appleScriptString(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
+
+// Notify shows a desktop notification using Notification Center
+func Notify(title, message string) error {
+	script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+	if err := exec.Command("osascript", "-e", script).Run(); err != nil {
+		return fmt.Errorf("failed to show notification: %w", err)
+	}
+	return nil
+}
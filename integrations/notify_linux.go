@@ -0,0 +1,16 @@
+//go:build linux
+
+package integrations
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Notify shows a desktop notification using notify-send
+func Notify(title, message string) error {
+	if err := exec.Command("notify-send", "--app-name=interruption-tracker", title, message).Run(); err != nil {
+		return fmt.Errorf("failed to show notification (install notify-send): %w", err)
+	}
+	return nil
+}
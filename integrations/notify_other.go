@@ -0,0 +1,13 @@
+//go:build !darwin && !linux
+
+package integrations
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Notify is not supported on this platform
+func Notify(title, message string) error {
+	return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,145 @@
+package integrations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+)
+
+// slackAPIURL is the base URL of the Slack Web API
+const slackAPIURL = "https://slack.com/api"
+
+// SlackClient toggles Slack status and Do-Not-Disturb around focus sessions
+type SlackClient struct {
+	token         string
+	statusText    string
+	statusEmoji   string
+	snoozeMinutes int
+	baseURL       string
+	httpClient    *http.Client
+}
+
+// slackResponse is the envelope returned by every Slack Web API method
+type slackResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// NewSlackClient creates a Slack client from the configuration
+func NewSlackClient(cfg *config.Config) (*SlackClient, error) {
+	if cfg.SlackToken == "" {
+		return nil, fmt.Errorf("slack_token is not configured")
+	}
+
+	statusText := cfg.SlackStatusText
+	if statusText == "" {
+		statusText = "Focusing"
+	}
+	statusEmoji := cfg.SlackStatusEmoji
+	if statusEmoji == "" {
+		statusEmoji = ":no_bell:"
+	}
+	snoozeMinutes := cfg.SlackSnoozeMinutes
+	if snoozeMinutes <= 0 {
+		snoozeMinutes = 60
+	}
+
+	return &SlackClient{
+		token:         cfg.SlackToken,
+		statusText:    statusText,
+		statusEmoji:   statusEmoji,
+		snoozeMinutes: snoozeMinutes,
+		baseURL:       slackAPIURL,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// StartFocus sets the focus status and snoozes notifications
+func (c *SlackClient) StartFocus() error {
+	if err := c.setStatus(c.statusText, c.statusEmoji); err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("num_minutes", fmt.Sprintf("%d", c.snoozeMinutes))
+	return c.postForm("dnd.setSnooze", form)
+}
+
+// EndFocus clears the focus status and ends the notification snooze
+func (c *SlackClient) EndFocus() error {
+	if err := c.setStatus("", ""); err != nil {
+		return err
+	}
+
+	err := c.postForm("dnd.endSnooze", url.Values{})
+	if err != nil && strings.Contains(err.Error(), "snooze_not_active") {
+		return nil // Snooze already expired or was ended manually
+	}
+	return err
+}
+
+// setStatus updates the user's Slack status text and emoji
+func (c *SlackClient) setStatus(text, emoji string) error {
+	payload := map[string]interface{}{
+		"profile": map[string]interface{}{
+			"status_text":       text,
+			"status_emoji":      emoji,
+			"status_expiration": 0,
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack status: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/users.profile.set", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return c.do(req, "users.profile.set")
+}
+
+// postForm calls a Slack method with form encoded arguments
+func (c *SlackClient) postForm(method string, form url.Values) error {
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/"+method, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.do(req, method)
+}
+
+// do sends an authenticated request and checks the Slack response envelope
+func (c *SlackClient) do(req *http.Request, method string) error {
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", method, resp.StatusCode)
+	}
+
+	var result slackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("%s failed: %s", method, result.Error)
+	}
+
+	return nil
+}
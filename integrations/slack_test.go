@@ -0,0 +1,48 @@
+package integrations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSlackFocus tests that focus start and end call the expected Slack methods
+func TestSlackFocus(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer xoxp-test", r.Header.Get("Authorization"))
+
+		method := strings.TrimPrefix(r.URL.Path, "/")
+		calls = append(calls, method)
+
+		if method == "dnd.setSnooze" {
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "30", r.Form.Get("num_minutes"))
+		}
+
+		// Pretend the snooze already expired to exercise the tolerated error
+		if method == "dnd.endSnooze" {
+			json.NewEncoder(w).Encode(slackResponse{OK: false, Error: "snooze_not_active"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(slackResponse{OK: true})
+	}))
+	defer server.Close()
+
+	_, err := NewSlackClient(&config.Config{})
+	assert.Error(t, err)
+
+	client, err := NewSlackClient(&config.Config{SlackToken: "xoxp-test", SlackSnoozeMinutes: 30})
+	assert.NoError(t, err)
+	client.baseURL = server.URL
+
+	assert.NoError(t, client.StartFocus())
+	assert.NoError(t, client.EndFocus())
+	assert.Equal(t, []string{"users.profile.set", "dnd.setSnooze", "users.profile.set", "dnd.endSnooze"}, calls)
+}
@@ -0,0 +1,237 @@
+package integrations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// togglAPIURL is the base URL of the Toggl Track v9 API
+const togglAPIURL = "https://api.track.toggl.com/api/v9"
+
+// togglSyncFile is the name of the file keeping track of already synced sessions
+const togglSyncFile = "toggl_sync.json"
+
+// togglSyncMu serializes syncs, which read and rewrite the sync state, so concurrent
+// syncs neither push a session twice nor drop each other's progress
+var togglSyncMu sync.Mutex
+
+// TogglClient pushes completed sessions to Toggl Track
+type TogglClient struct {
+	apiToken    string
+	workspaceID int
+	projectID   int
+	projectMap  map[string]int
+	baseURL     string
+	statePath   string
+	httpClient  *http.Client
+}
+
+// togglTimeEntry is the payload accepted by the Toggl time entries endpoint
+type togglTimeEntry struct {
+	Description string `json:"description"`
+	Start       string `json:"start"`
+	Duration    int64  `json:"duration"`
+	WorkspaceID int    `json:"workspace_id"`
+	ProjectID   int    `json:"project_id,omitempty"`
+	CreatedWith string `json:"created_with"`
+}
+
+// togglTimeEntryResponse is the subset of the Toggl response we care about
+type togglTimeEntryResponse struct {
+	ID int64 `json:"id"`
+}
+
+// NewTogglClient creates a Toggl client from the configuration.
+// dataDir is used to persist the sync state used for deduplication.
+func NewTogglClient(cfg *config.Config, dataDir string) (*TogglClient, error) {
+	if cfg.TogglAPIToken == "" {
+		return nil, fmt.Errorf("toggl_api_token is not configured")
+	}
+	if cfg.TogglWorkspaceID == 0 {
+		return nil, fmt.Errorf("toggl_workspace_id is not configured")
+	}
+
+	return &TogglClient{
+		apiToken:    cfg.TogglAPIToken,
+		workspaceID: cfg.TogglWorkspaceID,
+		projectID:   cfg.TogglProjectID,
+		projectMap:  cfg.TogglProjectMap,
+		baseURL:     togglAPIURL,
+		statePath:   filepath.Join(dataDir, togglSyncFile),
+		httpClient:  &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+// loadSyncState reads the map of session ID to Toggl time entry ID
+func (c *TogglClient) loadSyncState() (map[string]int64, error) {
+	state := make(map[string]int64)
+
+	data, err := os.ReadFile(c.statePath)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read toggl sync state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse toggl sync state: %w", err)
+	}
+
+	return state, nil
+}
+
+// saveSyncState writes the map of session ID to Toggl time entry ID
+func (c *TogglClient) saveSyncState(state map[string]int64) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal toggl sync state: %w", err)
+	}
+
+	if err := os.WriteFile(c.statePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write toggl sync state: %w", err)
+	}
+
+	return nil
+}
+
+// projectFor returns the Toggl project for a session description.
+// A keyword from the project map found in the description wins over the default project,
+// the longest keyword when several are found, then the first in alphabetical order.
+func (c *TogglClient) projectFor(description string) int {
+	keywords := make([]string, 0, len(c.projectMap))
+	for keyword := range c.projectMap {
+		keywords = append(keywords, keyword)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if len(keywords[i]) != len(keywords[j]) {
+			return len(keywords[i]) > len(keywords[j])
+		}
+		return keywords[i] < keywords[j]
+	})
+
+	lowerDesc := strings.ToLower(description)
+	for _, keyword := range keywords {
+		if strings.Contains(lowerDesc, strings.ToLower(keyword)) {
+			return c.projectMap[keyword]
+		}
+	}
+	return c.projectID
+}
+
+// pushSession creates a time entry in Toggl for a single session
+func (c *TogglClient) pushSession(session *models.Session) (int64, error) {
+	workDuration, _, _ := session.GetStats()
+
+	entry := togglTimeEntry{
+		Description: session.Start.Description,
+		Start:       session.Start.StartTime.UTC().Format(time.RFC3339),
+		Duration:    int64(workDuration.Seconds()),
+		WorkspaceID: c.workspaceID,
+		ProjectID:   c.projectFor(session.Start.Description),
+		CreatedWith: "interruption-tracker",
+	}
+
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal time entry: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/workspaces/%d/time_entries", c.baseURL, c.workspaceID)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.apiToken, "api_token")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send time entry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return 0, fmt.Errorf("toggl returned status %d", resp.StatusCode)
+	}
+
+	var created togglTimeEntryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return 0, fmt.Errorf("failed to decode toggl response: %w", err)
+	}
+
+	return created.ID, nil
+}
+
+// Pending returns how many completed sessions have not been synced yet
+func (c *TogglClient) Pending(sessions []*models.Session) (int, error) {
+	state, err := c.loadSyncState()
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, session := range sessions {
+		if session.Start == nil || session.End == nil {
+			continue
+		}
+		if _, synced := state[session.ID]; !synced {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// SyncSessions pushes completed sessions that have not been synced yet.
+// Returns the number of sessions pushed.
+func (c *TogglClient) SyncSessions(sessions []*models.Session) (int, error) {
+	togglSyncMu.Lock()
+	defer togglSyncMu.Unlock()
+
+	state, err := c.loadSyncState()
+	if err != nil {
+		return 0, err
+	}
+
+	pushed := 0
+	for _, session := range sessions {
+		// Only completed sessions are synced
+		if session.Start == nil || session.End == nil {
+			continue
+		}
+
+		// Skip sessions that were already pushed
+		if _, synced := state[session.ID]; synced {
+			continue
+		}
+
+		entryID, err := c.pushSession(session)
+		if err != nil {
+			// Persist progress before reporting the failure
+			if saveErr := c.saveSyncState(state); saveErr != nil {
+				return pushed, saveErr
+			}
+			return pushed, fmt.Errorf("failed to sync session %s: %w", session.ID, err)
+		}
+
+		state[session.ID] = entryID
+		pushed++
+	}
+
+	if err := c.saveSyncState(state); err != nil {
+		return pushed, err
+	}
+
+	return pushed, nil
+}
@@ -0,0 +1,192 @@
+package integrations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// TogglTestSuite is the test suite for toggl.go
+type TogglTestSuite struct {
+	suite.Suite
+	tempDir string
+}
+
+// SetupTest is called before each test
+func (suite *TogglTestSuite) SetupTest() {
+	tempDir, err := os.MkdirTemp("", "toggl-test")
+	assert.NoError(suite.T(), err)
+	suite.tempDir = tempDir
+}
+
+// TearDownTest is called after each test
+func (suite *TogglTestSuite) TearDownTest() {
+	if suite.tempDir != "" {
+		os.RemoveAll(suite.tempDir)
+	}
+}
+
+// newCompletedSession creates a completed session for testing
+func newCompletedSession(id, description string) *models.Session {
+	now := time.Now()
+	start := &models.TimeEntry{ID: id + "_start", Type: models.EntryTypeStart, StartTime: now.Add(-1 * time.Hour), Description: description}
+	end := &models.TimeEntry{ID: id + "_end", Type: models.EntryTypeEnd, StartTime: now}
+	session := models.NewSession(start)
+	session.ID = id
+	session.End = end
+	session.SubSessions[0].End = end
+	return session
+}
+
+// TestNewTogglClientRequiresConfig tests that missing credentials are rejected
+func (suite *TogglTestSuite) TestNewTogglClientRequiresConfig() {
+	_, err := NewTogglClient(&config.Config{}, suite.tempDir)
+	assert.Error(suite.T(), err)
+
+	_, err = NewTogglClient(&config.Config{TogglAPIToken: "token"}, suite.tempDir)
+	assert.Error(suite.T(), err)
+
+	client, err := NewTogglClient(&config.Config{TogglAPIToken: "token", TogglWorkspaceID: 1}, suite.tempDir)
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), client)
+}
+
+// TestSyncSessionsDeduplicates tests that sessions are only pushed once
+func (suite *TogglTestSuite) TestSyncSessionsDeduplicates() {
+	var received []togglTimeEntry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, ok := r.BasicAuth()
+		assert.True(suite.T(), ok)
+		assert.Equal(suite.T(), "token", user)
+
+		var entry togglTimeEntry
+		assert.NoError(suite.T(), json.NewDecoder(r.Body).Decode(&entry))
+		received = append(received, entry)
+
+		json.NewEncoder(w).Encode(togglTimeEntryResponse{ID: int64(len(received))})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		TogglAPIToken:    "token",
+		TogglWorkspaceID: 42,
+		TogglProjectID:   7,
+		TogglProjectMap:  map[string]int{"review": 9},
+	}
+	client, err := NewTogglClient(cfg, suite.tempDir)
+	assert.NoError(suite.T(), err)
+	client.baseURL = server.URL
+
+	active := newCompletedSession("sess_active", "Still running")
+	active.End = nil
+
+	sessions := []*models.Session{
+		newCompletedSession("sess_1", "Code review"),
+		newCompletedSession("sess_2", "Writing docs"),
+		active,
+	}
+
+	pending, err := client.Pending(sessions)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, pending)
+
+	pushed, err := client.SyncSessions(sessions)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, pushed)
+	assert.Len(suite.T(), received, 2)
+	assert.Equal(suite.T(), 9, received[0].ProjectID)
+	assert.Equal(suite.T(), 7, received[1].ProjectID)
+	assert.Equal(suite.T(), int64(3600), received[0].Duration)
+
+	// A second sync must not push the same sessions again
+	pushed, err = client.SyncSessions(sessions)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, pushed)
+	assert.Len(suite.T(), received, 2)
+
+	pending, err = client.Pending(sessions)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, pending)
+}
+
+// TestSyncSessionsConcurrent tests that concurrent syncs push each session once and keep all of them in the state
+func (suite *TogglTestSuite) TestSyncSessionsConcurrent() {
+	var mu sync.Mutex
+	received := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var entry togglTimeEntry
+		assert.NoError(suite.T(), json.NewDecoder(r.Body).Decode(&entry))
+		mu.Lock()
+		received[entry.Description]++
+		id := int64(len(received))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(togglTimeEntryResponse{ID: id})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{TogglAPIToken: "token", TogglWorkspaceID: 42}
+	sessions := []*models.Session{
+		newCompletedSession("sess_1", "First"),
+		newCompletedSession("sess_2", "Second"),
+		newCompletedSession("sess_3", "Third"),
+		newCompletedSession("sess_4", "Fourth"),
+	}
+
+	// Each ended session is synced on its own, as the TUI does, and some syncs repeat
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		for _, session := range sessions {
+			wg.Add(1)
+			go func(session *models.Session) {
+				defer wg.Done()
+				client, err := NewTogglClient(cfg, suite.tempDir)
+				assert.NoError(suite.T(), err)
+				client.baseURL = server.URL
+				_, err = client.SyncSessions([]*models.Session{session})
+				assert.NoError(suite.T(), err)
+			}(session)
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(suite.T(), map[string]int{"First": 1, "Second": 1, "Third": 1, "Fourth": 1}, received)
+
+	client, err := NewTogglClient(cfg, suite.tempDir)
+	assert.NoError(suite.T(), err)
+	pending, err := client.Pending(sessions)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, pending)
+}
+
+// TestTogglSuite runs the test suite
+// TestProjectForOverlappingKeywords tests the longest keyword found wins, whatever the map order
+func (suite *TogglTestSuite) TestProjectForOverlappingKeywords() {
+	client, err := NewTogglClient(&config.Config{
+		TogglAPIToken:    "token",
+		TogglWorkspaceID: 42,
+		TogglProjectID:   7,
+		TogglProjectMap:  map[string]int{"review": 9, "code review": 11, "docs": 12, "code": 13},
+	}, suite.tempDir)
+	assert.NoError(suite.T(), err)
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(suite.T(), 11, client.projectFor("Code review of the parser"))
+		assert.Equal(suite.T(), 9, client.projectFor("Review docs"))
+		assert.Equal(suite.T(), 13, client.projectFor("Docs for the code"), "equally long keywords go alphabetically")
+	}
+	assert.Equal(suite.T(), 13, client.projectFor("Code cleanup"))
+	assert.Equal(suite.T(), 7, client.projectFor("Planning"))
+}
+
+func TestTogglSuite(t *testing.T) {
+	suite.Run(t, new(TogglTestSuite))
+}
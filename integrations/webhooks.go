@@ -0,0 +1,135 @@
+package integrations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// Tracker events that can trigger webhooks
+const (
+	EventSessionStart  = "session_start"
+	EventSessionEnd    = "session_end"
+	EventSessionResume = "session_resume"
+	EventInterrupt     = "interrupt"
+	EventReturn        = "return"
+	EventRefocus       = "refocus"      // Recovery after a return ended
+	EventDayRollover   = "day_rollover" // Active session carried over into a new day
+)
+
+// WebhookPayload is the JSON body posted to webhooks
+type WebhookPayload struct {
+	Event       string                 `json:"event"`
+	Timestamp   time.Time              `json:"timestamp"`
+	SessionID   string                 `json:"session_id,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Project     string                 `json:"project,omitempty"`
+	Tag         models.InterruptionTag `json:"tag,omitempty"`
+	Note        string                 `json:"note,omitempty"` // Interruption description
+}
+
+// WebhookNotifier posts tracker events to the configured webhooks
+type WebhookNotifier struct {
+	webhooks   []config.WebhookConfig
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier for the webhooks in the configuration
+func NewWebhookNotifier(cfg *config.Config) *WebhookNotifier {
+	return &WebhookNotifier{
+		webhooks:   cfg.Webhooks,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewWebhookPayload builds the payload for an event on a session.
+// entry is the time entry that triggered the event and may be nil.
+func NewWebhookPayload(event string, session *models.Session, entry *models.TimeEntry) WebhookPayload {
+	payload := WebhookPayload{
+		Event:     event,
+		Timestamp: time.Now(),
+	}
+
+	if session != nil {
+		payload.SessionID = session.ID
+		payload.Project = session.Project
+		if session.Start != nil {
+			payload.Description = session.Start.Description
+		}
+	}
+
+	if entry != nil {
+		payload.Timestamp = entry.StartTime
+		payload.Tag = entry.Tag
+		if entry.Type == models.EntryTypeInterruption {
+			payload.Note = entry.Description
+		}
+	}
+
+	return payload
+}
+
+// HasSubscribers reports whether any webhook listens for the event
+func (n *WebhookNotifier) HasSubscribers(event string) bool {
+	for _, webhook := range n.webhooks {
+		if subscribes(webhook, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// Notify posts the payload to every webhook subscribed to its event.
+// All webhooks are attempted; the first error encountered is returned.
+func (n *WebhookNotifier) Notify(payload WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	var firstErr error
+	for _, webhook := range n.webhooks {
+		if !subscribes(webhook, payload.Event) {
+			continue
+		}
+
+		if err := n.post(webhook.URL, body); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// post sends a JSON body to a single webhook URL
+func (n *WebhookNotifier) post(url string, body []byte) error {
+	resp, err := n.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to call webhook %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned status %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// subscribes reports whether the webhook wants the event; an empty filter means all events
+func subscribes(webhook config.WebhookConfig, event string) bool {
+	if len(webhook.Events) == 0 {
+		return true
+	}
+	for _, e := range webhook.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package integrations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWebhookNotify tests that events are delivered only to subscribed webhooks
+func TestWebhookNotify(t *testing.T) {
+	received := make(map[string][]WebhookPayload)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload WebhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		received[r.URL.Path] = append(received[r.URL.Path], payload)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(&config.Config{
+		Webhooks: []config.WebhookConfig{
+			{URL: server.URL + "/all"},
+			{URL: server.URL + "/interrupts", Events: []string{EventInterrupt, EventReturn}},
+		},
+	})
+
+	session := models.NewSession(models.NewTimeEntry(models.EntryTypeStart, "Deep work"))
+	interruption := models.NewInterruptionEntry("Phone", models.TagCall)
+
+	assert.True(t, notifier.HasSubscribers(EventSessionStart))
+	assert.NoError(t, notifier.Notify(NewWebhookPayload(EventSessionStart, session, session.Start)))
+	assert.NoError(t, notifier.Notify(NewWebhookPayload(EventInterrupt, session, interruption)))
+
+	assert.Len(t, received["/all"], 2)
+	assert.Len(t, received["/interrupts"], 1)
+
+	payload := received["/interrupts"][0]
+	assert.Equal(t, EventInterrupt, payload.Event)
+	assert.Equal(t, session.ID, payload.SessionID)
+	assert.Equal(t, "Deep work", payload.Description)
+	assert.Equal(t, models.TagCall, payload.Tag)
+	assert.Equal(t, "Phone", payload.Note)
+	assert.WithinDuration(t, interruption.StartTime, payload.Timestamp, time.Second)
+}
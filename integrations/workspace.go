@@ -0,0 +1,88 @@
+package integrations
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+)
+
+// Workspace describes where a session is started, used to pick its project
+type Workspace struct {
+	Dir       string // Working directory
+	GitRemote string // URL of the origin remote of the repository in Dir, if any
+	Window    string // Title of the active window, if known
+}
+
+// DetectWorkspace describes the workspace in dir, looking up the active window title only
+// when withWindow is set. Details that can't be detected are left empty.
+func DetectWorkspace(dir string, withWindow bool) Workspace {
+	workspace := Workspace{Dir: dir}
+
+	if output, err := exec.Command("git", "-C", dir, "config", "--get", "remote.origin.url").Output(); err == nil {
+		workspace.GitRemote = strings.TrimSpace(string(output))
+	}
+
+	if withWindow {
+		if title, err := activeWindowTitle(); err == nil {
+			workspace.Window = title
+		}
+	}
+
+	return workspace
+}
+
+// MatchProject returns the project of the first rule matching the workspace
+func MatchProject(rules []config.ProjectRule, workspace Workspace) (string, bool) {
+	for _, rule := range rules {
+		if rule.Project == "" || (rule.Cwd == "" && rule.GitRemote == "" && rule.Window == "") {
+			continue
+		}
+		if rule.Cwd != "" && !underDir(workspace.Dir, config.ExpandHome(rule.Cwd)) {
+			continue
+		}
+		if rule.GitRemote != "" && !containsFold(workspace.GitRemote, rule.GitRemote) {
+			continue
+		}
+		if rule.Window != "" && !containsFold(workspace.Window, rule.Window) {
+			continue
+		}
+		return rule.Project, true
+	}
+	return "", false
+}
+
+// NeedsWindow reports whether any rule looks at the active window title
+func NeedsWindow(rules []config.ProjectRule) bool {
+	for _, rule := range rules {
+		if rule.Window != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// underDir reports whether path is dir or inside it
+func underDir(path, dir string) bool {
+	if path == "" {
+		return false
+	}
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// containsFold reports whether s contains substr, ignoring case
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// ProjectForDir detects the workspace in dir and returns the project the rules assign to
+// it, or an empty string. The active window is only looked up if a rule needs it.
+func ProjectForDir(rules []config.ProjectRule, dir string) string {
+	if len(rules) == 0 {
+		return ""
+	}
+	project, _ := MatchProject(rules, DetectWorkspace(dir, NeedsWindow(rules)))
+	return project
+}
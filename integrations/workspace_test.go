@@ -0,0 +1,68 @@
+package integrations
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMatchProject tests picking the project of the first matching rule
+func TestMatchProject(t *testing.T) {
+	home, err := os.UserHomeDir()
+	assert.NoError(t, err)
+
+	rules := []config.ProjectRule{
+		{Project: "empty"}, // No patterns, never matches
+		{Project: "acme-docs", Cwd: "/src/acme", Window: "confluence"},
+		{Project: "acme", Cwd: "/src/acme"},
+		{Project: "oss", GitRemote: "lukaszraczylo/"},
+		{Project: "home", Cwd: "~/notes"},
+	}
+
+	testCases := []struct {
+		name      string
+		workspace Workspace
+		expected  string
+	}{
+		{"Directory and window", Workspace{Dir: "/src/acme/api", Window: "Confluence - Design"}, "acme-docs"},
+		{"Directory below the rule", Workspace{Dir: "/src/acme/api"}, "acme"},
+		{"Directory sharing a prefix", Workspace{Dir: "/src/acme-old"}, ""},
+		{"SSH git remote", Workspace{Dir: "/tmp", GitRemote: "git@github.com:LukaszRaczylo/interruption-tracker.git"}, "oss"},
+		{"HTTPS git remote", Workspace{Dir: "/tmp", GitRemote: "https://github.com/lukaszraczylo/interruption-tracker"}, "oss"},
+		{"Home directory", Workspace{Dir: filepath.Join(home, "notes", "2025")}, "home"},
+		{"No match", Workspace{Dir: "/var"}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			project, ok := MatchProject(rules, tc.workspace)
+			assert.Equal(t, tc.expected, project)
+			assert.Equal(t, tc.expected != "", ok)
+		})
+	}
+
+	assert.True(t, NeedsWindow(rules))
+	assert.False(t, NeedsWindow(rules[2:]))
+}
+
+// TestDetectWorkspace tests reading the origin remote of the repository in a directory
+func TestDetectWorkspace(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	assert.NoError(t, exec.Command("git", "init", "-q", dir).Run())
+	assert.NoError(t, exec.Command("git", "-C", dir, "remote", "add", "origin", "https://example.com/acme/api.git").Run())
+
+	workspace := DetectWorkspace(dir, false)
+	assert.Equal(t, "https://example.com/acme/api.git", workspace.GitRemote)
+	assert.Empty(t, workspace.Window)
+
+	assert.Equal(t, "acme", ProjectForDir([]config.ProjectRule{{Project: "acme", GitRemote: "acme/api"}}, dir))
+	assert.Empty(t, ProjectForDir(nil, dir))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// runInterruptions prints every interruption of a range with its description, grouped by
+// tag, for reviewing what pulled you away from work
+func runInterruptions(store *storage.Storage, args []string) {
+	interruptionFlags := flag.NewFlagSet("interruptions", flag.ExitOnError)
+	rangeType := interruptionFlags.String("range", "month", "Range of days (day, week, month, quarter, year, all or YYYY-MM-DD..YYYY-MM-DD)")
+	format := interruptionFlags.String("format", "text", "Output format (text, csv, json)")
+	interruptionFlags.Parse(args)
+
+	if err := writeInterruptionLog(os.Stdout, store, *rangeType, *format, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing interruptions: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeInterruptionLog writes the interruptions of the range grouped by tag in the format
+func writeInterruptionLog(w io.Writer, store *storage.Storage, rangeType, format string, now time.Time) error {
+	groups, err := store.GetInterruptionLog(rangeType, now)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "", "text":
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(groups); err != nil {
+			return fmt.Errorf("failed to encode interruptions: %w", err)
+		}
+		return nil
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"tag", "day", "start", "duration_minutes", "description", "task", "despite_dnd"})
+		for _, group := range groups {
+			for _, record := range group.Interruptions {
+				writer.Write([]string{
+					string(group.Tag),
+					record.Day.Format("2006-01-02"),
+					record.Start.Format(time.RFC3339),
+					fmt.Sprintf("%.1f", record.Duration.Minutes()),
+					record.Description,
+					record.Task,
+					strconv.FormatBool(record.DespiteDND),
+				})
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	startDate, endDate, err := store.GetDateRange(rangeType)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Interruptions from %s to %s\n", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if len(groups) == 0 {
+		fmt.Fprintln(w, "\nNo interruptions recorded.")
+		return nil
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(w, "\n%s: %d interruption(s), %s\n", group.Tag, len(group.Interruptions), formatDuration(group.TotalDuration))
+		fmt.Fprintln(w, strings.Repeat("-", 70))
+		for _, record := range group.Interruptions {
+			description := record.Description
+			if description == "" {
+				description = "(no description)"
+			}
+			duration := formatDuration(record.Duration)
+			if record.Ongoing {
+				duration += " (ongoing)"
+			}
+			if record.DespiteDND {
+				description += " (despite DND)"
+			}
+			fmt.Fprintf(w, "%s  %-18s %s\n", record.Start.Format("2006-01-02 15:04"), duration, description)
+			if record.Task != "" {
+				fmt.Fprintf(w, "%17s  %-18s while: %s\n", "", "", record.Task)
+			}
+		}
+	}
+	return nil
+}
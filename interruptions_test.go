@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWriteInterruptionLog tests the interruptions command lists descriptions grouped by tag
+func TestWriteInterruptionLog(t *testing.T) {
+	store := fixtureStorage(t)
+	rangeType := "2025-03-03..2025-03-04"
+	now := time.Now()
+
+	var text bytes.Buffer
+	assert.NoError(t, writeInterruptionLog(&text, store, rangeType, "text", now))
+	assert.Contains(t, text.String(), "Interruptions from 2025-03-03 to 2025-03-04")
+	assert.Contains(t, text.String(), "meeting: 1 interruption(s), 30m 0s")
+	assert.Contains(t, text.String(), "Support call")
+	assert.Contains(t, text.String(), "while: PROJ-42 storage refactor")
+
+	var csvBuf bytes.Buffer
+	assert.NoError(t, writeInterruptionLog(&csvBuf, store, rangeType, "csv", now))
+	rows, err := csv.NewReader(&csvBuf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tag", "day", "start", "duration_minutes", "description", "task", "despite_dnd"}, rows[0])
+	assert.Contains(t, rows, []string{"call", "2025-03-03", "2025-03-03T09:30:00Z", "15.0", "Support call", "PROJ-42 storage refactor", "false"})
+
+	var jsonBuf bytes.Buffer
+	assert.NoError(t, writeInterruptionLog(&jsonBuf, store, rangeType, "json", now))
+	var groups []models.InterruptionGroup
+	assert.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &groups))
+	assert.Equal(t, models.TagMeeting, groups[0].Tag)
+
+	assert.Error(t, writeInterruptionLog(&text, store, rangeType, "xml", now))
+}
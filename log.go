@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// runLog logs work or an interruption after the fact from a sentence such as
+// "interrupted by call 10:14-10:32" or "worked on report 9-11 with 2 interruptions"
+func runLog(store *storage.Storage, args []string) {
+	logFlags := flag.NewFlagSet("log", flag.ExitOnError)
+	dateText := logFlags.String("date", "", "Day to log to (YYYY-MM-DD), defaults to today")
+	logFlags.Parse(args)
+
+	if logFlags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, `Usage: interruption-tracker log [--date=YYYY-MM-DD] "worked on report 9-11 with 2 interruptions"`)
+		os.Exit(2)
+	}
+
+	now := time.Now()
+	day := store.DayOf(now)
+	if *dateText != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *dateText, time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid date %q, use YYYY-MM-DD\n", *dateText)
+			os.Exit(2)
+		}
+		day = parsed
+	}
+
+	entry, err := store.ParseQuickEntry(strings.Join(logFlags.Args(), " "), day)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error logging: %v\n", err)
+		os.Exit(2)
+	}
+	if _, err := store.AddQuickEntry(day, entry, now); err != nil {
+		fmt.Fprintf(os.Stderr, "Error logging %s: %v\n", entry.Summary(), err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Logged %s on %s\n", entry.Summary(), day.Format("2006-01-02"))
+}
@@ -1,15 +1,27 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/lukaszraczylo/interruption-tracker/charts"
 	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/i18n"
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/server"
+	"github.com/lukaszraczylo/interruption-tracker/stats"
 	"github.com/lukaszraczylo/interruption-tracker/storage"
 	"github.com/lukaszraczylo/interruption-tracker/ui"
+	"gopkg.in/yaml.v3"
 )
 
 // Command line flags
@@ -17,10 +29,33 @@ var (
 	configFlag    = flag.String("config", "", "Path to configuration file")
 	dataFlag      = flag.String("data", "", "Path to data directory")
 	exportFlag    = flag.String("export", "", "Export data to file")
+	metricsFlag   = flag.String("export-metrics", "", "Export chart metrics to stdout (csv); uses -stats range, default all")
+	chartsFlag    = flag.String("charts", "", "Render charts with a backend (text, braille, kitty, svg); uses -stats range, default all")
+	chartsDirFlag = flag.String("charts-dir", ".", "Directory for the files written by -charts=svg")
+	reportFlag    = flag.String("report", "", "Write a report of sessions and their attachments to a file, Markdown for .md files and HTML otherwise; uses -stats range, default week")
+	templateFlag  = flag.String("report-template", "", "Template file in the templates directory of the data directory used by -report and -email-report, e.g. team.html.tmpl")
+	emailFlag     = flag.Bool("email-report", false, "Email the report to email_to through the configured SMTP server, e.g. from a weekly cron job; uses -stats range, default week")
+	anonymizeFlag = flag.String("anonymize", "", "With -export, replace descriptions with salted hashes (hash) or generic labels (label) and drop projects and attachments, for sharing")
 	importFlag    = flag.String("import", "", "Import data from file")
 	overwriteFlag = flag.Bool("overwrite", false, "Overwrite existing data on import")
+	mergeFlag     = flag.Bool("merge", false, "On import, union sessions with days that already have data by session ID, keeping the version with the most recent activity; new sessions overlapping stored ones are left out")
+	dryRunFlag    = flag.Bool("dry-run", false, "With -import, only report what would be created, skipped, overwritten or merged and the problems found")
+	recoverFlag   = flag.Bool("recover-journal", false, "Rebuild daily files by replaying the event journal")
+	daemonFlag    = flag.Bool("daemon", false, "Run in the background, owning the data directory for the TUI and commands connecting over its unix socket and running the scheduled jobs (schedule and backup_interval), until interrupted")
+	serveFlag     = flag.String("serve", "", "Serve the HTTP API (GraphQL at /graphql) and a read-only web dashboard on the given address, e.g. :8080 (localhost only) or 0.0.0.0:8080 (all interfaces, no authentication)")
 	backupFlag    = flag.String("backup", "", "Create backup archive")
-	statsFlag     = flag.String("stats", "", "Display stats (day, week, month, quarter, year, all)")
+	remoteFlag    = flag.Bool("backup-remote", false, "Upload a backup archive to the configured S3 or WebDAV target")
+	statsFlag     = flag.String("stats", "", "Display stats (day, week, last-week, month, quarter, year, all or YYYY-MM-DD..YYYY-MM-DD)")
+	outputFlag    = flag.String("output", "text", "Output format for -stats (text, json, yaml)")
+	compareFlag   = flag.String("compare-recovery", "", "With -stats, compare recovery models side by side without changing anything, e.g. \"10m vs 5m,meeting=20m\"; one model is compared with recovery_time")
+	syncFlag      = flag.String("sync", "", "Sync completed sessions to an external service (toggl) or the data directory with git (git)")
+	safeModeFlag  = flag.Bool("safe-mode", false, "Start with the default config and without integrations or auto-refresh, to get at data when startup breaks")
+	takeoutFlag   = flag.String("export-takeout", "", "Write a zip archive of all personal data (sessions, data files, configuration) to a file")
+	rotateKeyFlag = flag.Bool("rotate-key", false, "Re-encrypt all data with a new encryption passphrase read from stdin and save it to the configuration; rerun with the same passphrase to resume an interrupted rotation")
+	purgeFlag     = flag.String("purge-before", "", "Securely delete the daily files, backups and journal events of days before a date (YYYY-MM-DD) after confirmation, logging removals to purge.log")
+	wipeAllFlag   = flag.Bool("wipe-all", false, "Securely delete all data, backups, state files and the configuration after confirmation; with -export-takeout the archive is written first")
+	replayFlag    = flag.String("replay", "", "Replay a recorded day in the TUI for demos and screenshots, given as YYYY-MM-DD or the path of a daily file")
+	speedFlag     = flag.Float64("replay-speed", 60, "With -replay, seconds of the day played per second")
 	versionFlag   = flag.Bool("version", false, "Display version information")
 )
 
@@ -41,12 +76,26 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Safe mode ignores the configuration file and only reads the raw data
+	if *safeModeFlag {
+		runSafeMode()
+		return
+	}
+
+	// First run without any arguments walks through the setup wizard
+	if flag.NArg() == 0 && flag.NFlag() == 0 && !config.ConfigExists() {
+		if err := ui.RunSetupWizard(config.DefaultConfig()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Setup wizard failed: %v\n", err)
+		}
+	}
+
 	// Load configuration
 	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: Error loading configuration: %v\n", err)
 		fmt.Fprintln(os.Stderr, "Proceeding with default settings")
 	}
+	consoleMessages = i18n.New(cfg.Locale).WithDurationFormat(cfg.DurationFormat)
 
 	// Initialize storage
 	dataDir := cfg.DataDirectory
@@ -64,6 +113,46 @@ func main() {
 		os.Exit(0)
 	}
 
+	if store.KeyRotationPending() {
+		fmt.Fprintln(os.Stderr, "Warning: A key rotation was interrupted, some files may not be readable until -rotate-key is run again with the same new passphrase")
+	}
+
+	// Attach to a running daemon, which owns the data while it runs
+	if client := connectDaemon(store); client != nil {
+		if err := runAttached(client); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Pull changes from other devices before loading sessions
+	gitSync := startGitSync(store)
+
+	// Encrypt backups left in plaintext from before encryption was enabled
+	if encrypted, err := store.EncryptBackups(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Encrypting plaintext backups failed: %v\n", err)
+	} else if encrypted > 0 {
+		fmt.Fprintf(os.Stderr, "Encrypted %d plaintext backup(s)\n", encrypted)
+	}
+
+	// Purge days older than the retention policy
+	if result, err := store.ApplyRetention(time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Purging data past retention_days failed: %v\n", err)
+	} else if result != nil && len(result.Files) > 0 {
+		fmt.Fprintf(os.Stderr, "Purged %d file(s) of days before %s (retention_days), see purge.log\n",
+			len(result.Files), result.Cutoff.Format("2006-01-02"))
+	}
+
+	// Reconcile conflicting copies left by Dropbox, Syncthing and the like
+	if conflicts, err := store.FindSyncConflicts(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Checking for sync conflicts failed: %v\n", err)
+	} else if !conflicts.Empty() {
+		if err := ui.RunConflictResolver(store, conflicts); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Resolving sync conflicts failed: %v\n", err)
+		}
+	}
+
 	// Initialize UI
 	timerUI, err := ui.NewTimerUI(store)
 	if err != nil {
@@ -71,11 +160,72 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Run scheduled jobs while the TUI runs
+	if jobScheduler, err := newScheduler(store, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Scheduled jobs are disabled: %v\n", err)
+	} else if jobScheduler != nil {
+		timerUI.SetScheduler(jobScheduler)
+	}
+
 	// Run the application
 	if err := timerUI.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Push this session's changes to other devices
+	if gitSync != nil {
+		if err := gitSync.Push(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: git sync push failed: %v\n", err)
+		}
+	}
+}
+
+// runSafeMode starts the UI with the default configuration, without encryption, git sync,
+// integrations or auto-refresh. Data is read from -data or the default data directory.
+func runSafeMode() {
+	cfg := config.DefaultConfig()
+	if *dataFlag != "" {
+		cfg.DataDirectory = *dataFlag
+	}
+
+	store, err := storage.NewStorageWithConfig(cfg, cfg.DataDirectory)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing storage: %v\n", err)
+		os.Exit(1)
+	}
+
+	timerUI, err := ui.NewTimerUI(store)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing UI: %v\n", err)
+		os.Exit(1)
+	}
+	timerUI.SetSafeMode(true)
+
+	if err := timerUI.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// startGitSync pulls remote changes when git sync is enabled.
+// Returns nil if git sync is disabled or misconfigured.
+func startGitSync(store *storage.Storage) *storage.GitSync {
+	if !store.GetConfig().GitSyncEnabled {
+		return nil
+	}
+
+	gitSync, err := storage.NewGitSync(store)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: git sync disabled: %v\n", err)
+		return nil
+	}
+
+	if err := gitSync.Pull(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: git sync pull failed: %v\n", err)
+	}
+
+	return gitSync
 }
 
 // loadConfig loads the configuration from file or creates default
@@ -91,11 +241,78 @@ func loadConfig() (*config.Config, error) {
 // handleUtilityOperations processes command-line utility operations
 // Returns true if an operation was performed and the app should exit
 func handleUtilityOperations(store *storage.Storage) bool {
+	// Subcommands such as "status"
+	if flag.NArg() > 0 {
+		runCommand(store, flag.Args())
+		return true
+	}
+
+	// Play back a recorded day
+	if *replayFlag != "" {
+		if err := runReplay(store, *replayFlag, *speedFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error replaying day: %v\n", err)
+		}
+		return true
+	}
+
+	// Securely delete all data, after writing the takeout archive when requested
+	if *wipeAllFlag {
+		if err := runWipeAll(store, *takeoutFlag, wipeConfigPath(), os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error wiping data: %v\n", err)
+		}
+		return true
+	}
+
+	// Re-encrypt all data with a new passphrase
+	if *rotateKeyFlag {
+		if err := runRotateKey(store, wipeConfigPath(), os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error rotating key: %v\n", err)
+		}
+		return true
+	}
+
+	// Securely delete the data of old days
+	if *purgeFlag != "" {
+		if err := runPurge(store, *purgeFlag, os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error purging data: %v\n", err)
+		}
+		return true
+	}
+
+	// Export the personal data archive
+	if *takeoutFlag != "" {
+		fmt.Printf("Writing takeout archive to %s...\n", *takeoutFlag)
+		if err := store.ExportTakeout(*takeoutFlag, wipeConfigPath()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting takeout: %v\n", err)
+			return true
+		}
+		fmt.Println("Takeout completed successfully.")
+		return true
+	}
+
+	// Anonymize exported sessions for sharing
+	var anonymizer *models.Anonymizer
+	if *exportFlag != "" && *anonymizeFlag != "" {
+		var err error
+		if anonymizer, err = newExportAnonymizer(*anonymizeFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting data: %v\n", err)
+			return true
+		}
+	}
+
+	// Stream sessions as NDJSON
+	if *exportFlag != "" && isNDJSONPath(*exportFlag) {
+		if err := exportNDJSON(store, *exportFlag, anonymizer); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting data: %v\n", err)
+		}
+		return true
+	}
+
 	// Export data
 	if *exportFlag != "" {
 		exportPath := *exportFlag
 		fmt.Printf("Exporting data to %s...\n", exportPath)
-		if err := store.ExportData(exportPath); err != nil {
+		if err := store.ExportAnonymizedData(exportPath, anonymizer); err != nil {
 			fmt.Fprintf(os.Stderr, "Error exporting data: %v\n", err)
 			return true
 		}
@@ -103,14 +320,108 @@ func handleUtilityOperations(store *storage.Storage) bool {
 		return true
 	}
 
+	// Export chart metrics
+	if *metricsFlag != "" {
+		if *metricsFlag != "csv" {
+			fmt.Fprintf(os.Stderr, "Unsupported metrics format: %s\n", *metricsFlag)
+			return true
+		}
+
+		rangeType := "all"
+		if *statsFlag != "" {
+			rangeType = *statsFlag
+		}
+		if err := store.ExportMetricsCSV(os.Stdout, rangeType); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting metrics: %v\n", err)
+		}
+		return true
+	}
+
+	// Render charts
+	if *chartsFlag != "" {
+		rangeType := "all"
+		if *statsFlag != "" {
+			rangeType = *statsFlag
+		}
+		if err := writeCharts(os.Stdout, store, rangeType, *chartsFlag, *chartsDirFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error rendering charts: %v\n", err)
+		}
+		return true
+	}
+
+	// Write the HTML report
+	if *reportFlag != "" {
+		rangeType := "week"
+		if *statsFlag != "" {
+			rangeType = *statsFlag
+		}
+		if err := writeReportFile(store, rangeType, *reportFlag, *templateFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			return true
+		}
+		fmt.Printf("Wrote report to %s\n", *reportFlag)
+		return true
+	}
+
+	if *emailFlag {
+		rangeType := "week"
+		if *statsFlag != "" {
+			rangeType = *statsFlag
+		}
+		mailer, err := integrations.NewMailer(store.GetConfig())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error configuring email: %v\n", err)
+			return true
+		}
+		if err := emailReport(store, mailer, rangeType, *templateFlag, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error emailing report: %v\n", err)
+			return true
+		}
+		fmt.Printf("Emailed report to %s\n", strings.Join(store.GetConfig().EmailTo, ", "))
+		return true
+	}
+
+	if *importFlag != "" && *overwriteFlag && *mergeFlag {
+		fmt.Fprintln(os.Stderr, "Error importing data: -overwrite and -merge cannot be used together")
+		return true
+	}
+
+	// Import streamed NDJSON sessions
+	if *importFlag != "" && isNDJSONPath(*importFlag) {
+		if *dryRunFlag || *mergeFlag {
+			fmt.Fprintln(os.Stderr, "Error importing data: -dry-run and -merge are not supported for NDJSON imports")
+			return true
+		}
+		if err := importNDJSON(store, *importFlag, *overwriteFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error importing data: %v\n", err)
+		}
+		return true
+	}
+
 	// Import data
 	if *importFlag != "" {
 		importPath := *importFlag
-		fmt.Printf("Importing data from %s...\n", importPath)
-		if err := store.ImportData(importPath, *overwriteFlag); err != nil {
+		options := storage.ImportOptions{Overwrite: *overwriteFlag, Merge: *mergeFlag, DryRun: *dryRunFlag}
+		if options.DryRun {
+			fmt.Printf("Checking import of %s...\n", importPath)
+		} else {
+			fmt.Printf("Importing data from %s...\n", importPath)
+			if point, ok := store.ImportResumePoint(importPath, options.Overwrite, options.Merge); ok {
+				fmt.Printf("Resuming interrupted import %s...\n", point)
+			}
+		}
+		report, err := store.Import(importPath, options)
+		if report != nil {
+			printImportReport(os.Stdout, report)
+		}
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error importing data: %v\n", err)
 			return true
 		}
+		if options.DryRun {
+			fmt.Println("Dry run: nothing was imported.")
+			return true
+		}
 		fmt.Println("Import completed successfully.")
 		return true
 	}
@@ -127,89 +438,532 @@ func handleUtilityOperations(store *storage.Storage) bool {
 		return true
 	}
 
+	// Upload backup archive to the remote target
+	if *remoteFlag {
+		target, err := storage.NewRemoteBackupTarget(store.GetConfig())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error configuring remote backup: %v\n", err)
+			return true
+		}
+		fmt.Printf("Uploading backup archive to %s...\n", store.GetConfig().BackupRemoteURL)
+		name, err := store.UploadBackupArchive(target)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error uploading backup: %v\n", err)
+			return true
+		}
+		fmt.Printf("Backup uploaded as %s.\n", name)
+		return true
+	}
+
+	// Recover daily files from the journal
+	if *recoverFlag {
+		fmt.Println("Replaying journal...")
+		days, err := store.RecoverFromJournal()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error recovering from journal: %v\n", err)
+			return true
+		}
+		fmt.Printf("Recovered %d day(s) from the journal.\n", days)
+		return true
+	}
+
+	// Sync to external service
+	if *syncFlag != "" {
+		syncSessions(store, *syncFlag)
+		return true
+	}
+
+	// Serve the data directory and run scheduled jobs in the background
+	if *daemonFlag {
+		if err := runDaemon(store, os.Stdout, daemonSignals()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running daemon: %v\n", err)
+		}
+		return true
+	}
+
+	// Serve the HTTP API
+	if *serveFlag != "" {
+		addr := server.ListenAddr(*serveFlag)
+		fmt.Printf("Serving dashboard on %s/ and GraphQL API on %s/graphql (events at /events, health at /healthz)\n", addr, addr)
+		if err := newAPIServer(store).ListenAndServe(*serveFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error serving API: %v\n", err)
+		}
+		return true
+	}
+
 	// Display stats
 	if *statsFlag != "" {
 		rangeType := *statsFlag
-		displayConsoleStats(store, rangeType)
+		if *compareFlag != "" {
+			if err := writeRecoveryComparison(os.Stdout, store, rangeType, *compareFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "Error comparing recovery models: %v\n", err)
+			}
+			return true
+		}
+		displayConsoleStats(store, rangeType, *outputFlag)
 		return true
 	}
 
 	return false
 }
 
-// displayConsoleStats shows statistics in the console (non-UI mode)
-func displayConsoleStats(store *storage.Storage, rangeType string) {
-	// Get basic stats
-	workDuration, interruptionDuration, interruptionCount, err := store.GetStats(rangeType)
+// syncSessions syncs the data directory with git, or pushes completed sessions from all available days to the given service
+func syncSessions(store *storage.Storage, service string) {
+	if service == "git" {
+		gitSync, err := storage.NewGitSync(store)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error configuring git sync: %v\n", err)
+			return
+		}
+
+		fmt.Println("Syncing data directory with git remote...")
+		if err := gitSync.Push(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error syncing with git: %v\n", err)
+			return
+		}
+		fmt.Println("Git sync completed successfully.")
+		return
+	}
+
+	if service != "toggl" {
+		fmt.Fprintf(os.Stderr, "Unknown sync target: %s\n", service)
+		return
+	}
+
+	client, err := integrations.NewTogglClient(store.GetConfig(), store.GetDataDir())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting stats: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error configuring Toggl: %v\n", err)
+		return
+	}
+
+	sessions, err := loadAllSessions(store, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing days: %v\n", err)
+		return
+	}
+
+	fmt.Println("Syncing sessions to Toggl Track...")
+	pushed, err := client.SyncSessions(sessions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error syncing sessions: %v\n", err)
+	}
+	fmt.Printf("Synced %d new session(s).\n", pushed)
+}
+
+// loadAllSessions loads the sessions of every stored day. Days that fail to load are
+// reported to warnings, when given, and skipped.
+func loadAllSessions(store *storage.Storage, warnings io.Writer) ([]*models.Session, error) {
+	days, err := store.ListAvailableDays()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*models.Session
+	for _, day := range days {
+		dailySessions, err := store.LoadDailySessions(day)
+		if err != nil {
+			if warnings != nil {
+				fmt.Fprintf(warnings, "Warning: skipping %s: %v\n", day.Format("2006-01-02"), err)
+			}
+			continue
+		}
+		sessions = append(sessions, dailySessions.Sessions...)
+	}
+	return sessions, nil
+}
+
+// newAPIServer creates the HTTP API server, reporting the sessions waiting to be synced
+// to Toggl in /healthz when Toggl is configured
+func newAPIServer(store *storage.Storage) *server.Server {
+	apiServer := server.NewServer(store)
+
+	if client, err := integrations.NewTogglClient(store.GetConfig(), store.GetDataDir()); err == nil {
+		apiServer.AddQueue("toggl", func() (int, error) {
+			sessions, err := loadAllSessions(store, nil)
+			if err != nil {
+				return 0, err
+			}
+			return client.Pending(sessions)
+		})
+	}
+
+	return apiServer
+}
+
+// isNDJSONPath reports whether an export or import path uses the NDJSON stream format:
+// "-" for stdout/stdin, or a .ndjson or .jsonl file
+func isNDJSONPath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return path == "-" || ext == ".ndjson" || ext == ".jsonl"
+}
+
+// newExportAnonymizer creates the anonymizer for -anonymize with a random salt, so hashes
+// match within one export but cannot be compared with other exports
+func newExportAnonymizer(mode string) (*models.Anonymizer, error) {
+	anonymizeMode, err := models.ParseAnonymizeMode(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return models.NewAnonymizer(anonymizeMode, salt), nil
+}
+
+// exportNDJSON streams all sessions to a file, or to stdout for "-" so the output
+// can be piped into tools like jq. Sessions are anonymized unless anonymizer is nil.
+func exportNDJSON(store *storage.Storage, path string, anonymizer *models.Anonymizer) error {
+	if path == "-" {
+		_, err := store.ExportAnonymizedNDJSON(os.Stdout, anonymizer)
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	fmt.Printf("Exporting sessions to %s...\n", path)
+	count, err := store.ExportAnonymizedNDJSON(file, anonymizer)
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	fmt.Printf("Exported %d session(s).\n", count)
+	return nil
+}
+
+// printImportReport writes what an import does with each day, followed by the problems
+// found in the imported data
+func printImportReport(w io.Writer, report *storage.ImportReport) {
+	fmt.Fprintf(w, "Format: %s\n", report.Format)
+	for _, day := range report.Days {
+		line := fmt.Sprintf("  %s  %-9s %d session(s)", day.Date, day.Action, day.Sessions)
+		switch day.Action {
+		case storage.ImportSkip:
+			line += fmt.Sprintf(", %d already stored", day.Existing)
+		case storage.ImportOverwrite:
+			line += fmt.Sprintf(", replacing %d", day.Existing)
+		case storage.ImportMerge:
+			line += fmt.Sprintf(", %d added to %d stored", day.Added, day.Existing)
+			if day.Updated > 0 {
+				line += fmt.Sprintf(", %d updated", day.Updated)
+			}
+			if day.Duplicates > 0 {
+				line += fmt.Sprintf(", %d duplicate(s)", day.Duplicates)
+			}
+			if day.Overlapping > 0 {
+				line += fmt.Sprintf(", %d overlapping", day.Overlapping)
+			}
+		case storage.ImportDone:
+			line += ", imported by the interrupted run"
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "Sessions to import: %d\n", report.Added())
+
+	if len(report.Issues) == 0 {
 		return
 	}
+	fmt.Fprintf(w, "Problems (%d):\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		line := "  " + issue.Date
+		if issue.SessionID != "" {
+			line += " " + issue.SessionID
+		}
+		line += ": " + issue.Problem
+		if issue.Skipped {
+			line += " (skipped)"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// importNDJSON imports sessions streamed from a file, or from stdin for "-"
+func importNDJSON(store *storage.Storage, path string, overwrite bool) error {
+	var count int
+	var err error
+	if path == "-" {
+		count, err = store.ImportNDJSON(os.Stdin, overwrite)
+	} else {
+		if point, ok := store.ImportResumePoint(path, overwrite, false); ok {
+			fmt.Fprintf(os.Stderr, "Resuming interrupted import %s...\n", point)
+		}
+		count, err = store.ImportNDJSONFile(path, overwrite)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d session(s).\n", count)
+	return nil
+}
+
+// writeCharts renders the standard charts for the range with the given backend. SVG
+// charts are written to one file per chart in dir, the others to w.
+func writeCharts(w io.Writer, store *storage.Storage, rangeType, backend, dir string) error {
+	renderer, err := charts.NewRenderer(backend)
+	if err != nil {
+		return err
+	}
+
+	stats, err := store.GetDetailedStats(rangeType)
+	if err != nil {
+		return err
+	}
+	standard := charts.Standard(stats)
+
+	for _, name := range charts.StandardNames {
+		if backend != charts.BackendSVG {
+			if err := renderer.Render(w, standard[name]); err != nil {
+				return err
+			}
+			fmt.Fprintln(w)
+			continue
+		}
+
+		path := filepath.Join(dir, name+".svg")
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		err = renderer.Render(file, standard[name])
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Fprintf(w, "Wrote %s\n", path)
+	}
+
+	return nil
+}
 
-	// Get date range
-	startDate, endDate, _ := store.GetDateRange(rangeType)
+// displayConsoleStats shows statistics in the console (non-UI mode) in the given format
+func displayConsoleStats(store *storage.Storage, rangeType, format string) {
+	var err error
+	switch format {
+	case "", "text":
+		err = writeConsoleStats(os.Stdout, store, rangeType)
+	case "json", "yaml":
+		err = writeStructuredStats(os.Stdout, store, rangeType, format)
+	default:
+		err = fmt.Errorf("unsupported output format: %s", format)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting stats: %v\n", err)
+	}
+}
+
+// writeStructuredStats writes the detailed statistics for the range as JSON or YAML, so
+// scripts can consume them without parsing the text report
+func writeStructuredStats(w io.Writer, store *storage.Storage, rangeType, format string) error {
+	stats, err := store.GetDetailedStats(rangeType)
+	if err != nil {
+		return err
+	}
+	stats.CalculateProductivityScore()
+
+	if format == "yaml" {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(stats); err != nil {
+			return fmt.Errorf("failed to encode stats: %w", err)
+		}
+		return encoder.Close()
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(stats); err != nil {
+		return fmt.Errorf("failed to encode stats: %w", err)
+	}
+	return nil
+}
+
+// writeConsoleStats renders the console statistics report to w
+func writeConsoleStats(w io.Writer, store *storage.Storage, rangeType string) error {
+	// Headline numbers, the same as on the stats page
+	source, err := stats.Load(store, rangeType, nil, time.Time{})
+	if err != nil {
+		return err
+	}
+	summaryConfig, err := stats.NewConfig(store.GetConfig())
+	if err != nil {
+		return err
+	}
+	summary := stats.Summarize(source, store.StatsPolicy(), summaryConfig, time.Now())
 
 	// Display header
-	fmt.Printf("Statistics for %s (%s to %s)\n",
+	t := consoleMessages.T
+	fmt.Fprintln(w, t("console.title",
 		rangeType,
-		startDate.Format("2006-01-02"),
-		endDate.Format("2006-01-02"))
-	fmt.Println(strings.Repeat("-", 50))
+		summary.Start.Format("2006-01-02"),
+		summary.End.Format("2006-01-02")))
+	fmt.Fprintln(w, strings.Repeat("-", 50))
 
 	// Display basic metrics
-	fmt.Printf("Total work time: %s\n", formatDuration(workDuration))
-	fmt.Printf("Total interruptions: %d\n", interruptionCount)
-	fmt.Printf("Total interruption time: %s\n", formatDuration(interruptionDuration))
-
-	// Recovery time (10 min per interruption)
-	recoveryTime := time.Duration(interruptionCount) * 10 * time.Minute
-	fmt.Printf("Estimated recovery time: %s\n", formatDuration(recoveryTime))
-
-	// Total impact
-	totalImpact := interruptionDuration + recoveryTime
-	fmt.Printf("Total productivity impact: %s\n", formatDuration(totalImpact))
+	for _, row := range summary.Rows(formatDuration) {
+		fmt.Fprintln(w, row)
+	}
 
 	// Get detailed stats if available
 	detailedStats, err := store.GetDetailedStats(rangeType)
+
 	if err == nil && detailedStats != nil {
 		// Calculate productivity score
 		score := detailedStats.CalculateProductivityScore()
-		fmt.Printf("Productivity score: %.1f / 100\n", score)
+		fmt.Fprintln(w, t("console.score", score))
 
 		// Most productive hour
 		if hour, duration := detailedStats.GetMostProductiveHour(); duration > 0 {
-			fmt.Printf("Most productive hour: %d:00 (%s of focused work)\n",
-				hour, formatDuration(duration))
+			fmt.Fprintln(w, t("console.productive_hour", hour, formatDuration(duration)))
+		}
+
+		// Longest stretch of work without an interruption
+		if date, block := detailedStats.LongestFocusDay(); block > 0 {
+			fmt.Fprintln(w, t("console.longest_block", formatDuration(block), date))
+		}
+
+		// Estimated vs actual work time
+		if estimates := detailedStats.Estimates; estimates.Sessions > 0 {
+			fmt.Fprintln(w, t("console.estimates",
+				estimates.Ratio(), estimates.Sessions, estimates.Accurate, models.EstimateTolerance*100))
 		}
 
 		// Display interruption breakdown
 		if len(detailedStats.InterruptionsByTag) > 0 {
-			fmt.Println("\nInterruption breakdown:")
-			fmt.Println(strings.Repeat("-", 50))
-			fmt.Printf("%-10s %-10s %-15s\n", "Type", "Count", "Duration")
+			fmt.Fprintln(w, "\n"+t("console.breakdown"))
+			fmt.Fprintln(w, strings.Repeat("-", 50))
+			fmt.Fprintf(w, "%-10s %-10s %-15s\n", t("column.type"), t("column.count"), t("column.duration"))
+
+			// Sort tags so the report is stable between runs
+			tags := make([]models.InterruptionTag, 0, len(detailedStats.InterruptionsByTag))
+			for tag := range detailedStats.InterruptionsByTag {
+				tags = append(tags, tag)
+			}
+			sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
 
-			for tag, count := range detailedStats.InterruptionsByTag {
+			for _, tag := range tags {
+				count := detailedStats.InterruptionsByTag[tag]
 				duration := detailedStats.InterruptionDurationByTag[tag]
-				fmt.Printf("%-10s %-10d %-15s\n",
+				fmt.Fprintf(w, "%-10s %-10d %-15s\n",
 					string(tag), count, formatDuration(duration))
 			}
 		}
+
+		// Display the cost of interruptions at the configured hourly rate
+		if costs, total, ok := store.InterruptionCosts(detailedStats); ok && total.Count > 0 {
+			currency := store.GetConfig().Currency
+			fmt.Fprintln(w, "\n"+t("console.cost", models.FormatCost(store.GetConfig().HourlyRate, currency)))
+			fmt.Fprintln(w, strings.Repeat("-", 50))
+			fmt.Fprintf(w, "%-10s %-10s %-15s %s\n", t("column.type"), t("column.count"), t("report.lost_time"), t("report.amount"))
+			for _, cost := range append(costs, total) {
+				tag := string(cost.Tag)
+				if cost.Tag == "" {
+					tag = t("console.total")
+				}
+				fmt.Fprintf(w, "%-10s %-10d %-15s %s\n",
+					tag, cost.Count, formatDuration(cost.Lost()), models.FormatCost(cost.Cost, currency))
+			}
+		}
+
+		// Display the interruptions that got through focus mode
+		if dnd := detailedStats.DND; dnd.Time > 0 {
+			fmt.Fprintln(w, "\n"+t("console.focus_mode", formatDuration(dnd.Time)))
+			fmt.Fprintln(w, strings.Repeat("-", 50))
+			fmt.Fprintln(w, t("console.despite_dnd", dnd.Interruptions, dnd.RatePerHour()))
+			fmt.Fprintln(w, t("console.outside_dnd", dnd.OtherInterruptions, dnd.OtherRatePerHour()))
+
+			tags := make([]models.InterruptionTag, 0, len(dnd.ByTag))
+			for tag := range dnd.ByTag {
+				tags = append(tags, tag)
+			}
+			sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+			for _, tag := range tags {
+				fmt.Fprintf(w, "  %-10s %d\n", string(tag), dnd.ByTag[tag])
+			}
+		}
+
+		// Display sessions grouped into recurring tasks
+		if recurring := detailedStats.Tasks.Recurring(); len(recurring) > 0 {
+			fmt.Fprintln(w, "\n"+t("console.recurring"))
+			fmt.Fprintln(w, strings.Repeat("-", 50))
+			fmt.Fprintf(w, "%-25s %-10s %-12s %-12s %s\n", t("column.task"), t("column.sessions"), t("column.total"), t("column.average"), t("column.rate"))
+
+			for _, task := range recurring {
+				fmt.Fprintf(w, "%-25s %-10d %-12s %-12s %.1f\n",
+					task.Name, task.Sessions, formatDuration(task.WorkDuration),
+					formatDuration(task.AverageWorkTime()), task.InterruptionRate())
+			}
+		}
 	}
-}
 
-// formatDuration formats a duration in a human-readable format
-func formatDuration(d time.Duration) string {
-	hours := int(d.Hours())
-	minutes := int(d.Minutes()) % 60
+	// Display measured time to refocus against the configured recovery time
+	refocusStats, err := store.GetRefocusStats(rangeType)
+	if err == nil && len(refocusStats) > 0 {
+		policy := store.StatsPolicy()
+		fmt.Fprintln(w, "\n"+t("console.refocus", int(models.SustainedWorkThreshold.Minutes())))
+		fmt.Fprintln(w, strings.Repeat("-", 50))
+		fmt.Fprintf(w, "%-10s %-10s %-10s %-15s %s\n", t("column.type"), t("column.returns"), t("column.refocused"), t("column.measured"), t("column.assumed"))
+
+		for _, stat := range refocusStats {
+			measured := "-"
+			if stat.Refocused > 0 {
+				measured = formatDuration(stat.AverageRefocus())
+			}
+			fmt.Fprintf(w, "%-10s %-10d %-10d %-15s %s\n",
+				string(stat.Tag), stat.Returns, stat.Refocused, measured, formatDuration(policy.RecoveryFor(stat.Tag)))
+		}
+	}
 
-	if hours > 0 {
-		return fmt.Sprintf("%dh %dm", hours, minutes)
+	// Display sessions grouped by linked issue
+	issueStats, err := store.GetIssueStats(rangeType)
+	if err == nil && len(issueStats) > 0 {
+		resolver := integrations.NewIssueResolver(store.GetConfig())
+
+		fmt.Fprintln(w, "\n"+t("console.issues"))
+		fmt.Fprintln(w, strings.Repeat("-", 50))
+		fmt.Fprintf(w, "%-30s %-10s %-15s %s\n", t("column.issue"), t("column.sessions"), t("report.work"), t("column.title"))
+
+		for _, issue := range issueStats {
+			title := "-"
+			if resolver.Resolvable(issue.Key) {
+				if resolved, err := resolver.Resolve(issue.Key); err == nil {
+					title = resolved
+				}
+			}
+			fmt.Fprintf(w, "%-30s %-10d %-15s %s\n",
+				issue.Key, issue.Sessions, formatDuration(issue.WorkDuration), title)
+		}
 	}
 
-	seconds := int(d.Seconds()) % 60
-	if minutes > 0 {
-		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	// Display tasks continued across days with their combined work
+	continuedTasks, err := store.GetContinuedTasks(rangeType)
+	if err == nil && len(continuedTasks) > 0 {
+		fmt.Fprintln(w, "\n"+t("console.continued"))
+		fmt.Fprintln(w, strings.Repeat("-", 50))
+		fmt.Fprintf(w, "%-30s %-10s %-15s %s\n", t("column.task"), t("column.days"), t("report.work"), t("column.interruptions"))
+
+		for _, task := range continuedTasks {
+			fmt.Fprintf(w, "%-30s %-10d %-15s %d\n",
+				task.Description, len(task.Parts), formatDuration(task.WorkDuration), task.Interruptions)
+		}
 	}
 
-	return fmt.Sprintf("%ds", seconds)
+	return nil
+}
+
+// consoleMessages translates the console output into the configured language and formats
+// its durations in the configured format
+var consoleMessages = i18n.New(string(i18n.English))
+
+// formatDuration formats a duration in a human-readable format
+func formatDuration(d time.Duration) string {
+	return consoleMessages.Duration(d)
 }
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/i18n"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/stats"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// updateGolden rewrites the golden files instead of comparing against them
+var updateGolden = flag.Bool("update", false, "update golden files in testdata/golden")
+
+// fixtureStorage creates a storage with the default configuration, not the one in the
+// home directory, seeded with testdata/fixtures/sessions.json
+func fixtureStorage(t *testing.T) *storage.Storage {
+	t.Helper()
+
+	store, err := storage.NewStorageWithConfig(config.DefaultConfig(), t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, store.ImportData(filepath.Join("testdata", "fixtures", "sessions.json"), false))
+
+	return store
+}
+
+// assertGolden compares output with the named golden file, or rewrites it with -update
+func assertGolden(t *testing.T, name string, output []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "golden", name)
+	if *updateGolden {
+		assert.NoError(t, os.WriteFile(path, output, 0644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(output), "output differs from %s; run go test . -update if the change is intended", path)
+}
+
+// TestConsoleStatsGolden tests the --stats console report against a snapshot
+func TestConsoleStatsGolden(t *testing.T) {
+	store := fixtureStorage(t)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeConsoleStats(&buf, store, "all"))
+
+	// The "all" range ends today, so mask the only date that varies between runs
+	_, endDate, err := store.GetDateRange("all")
+	assert.NoError(t, err)
+	output := strings.Replace(buf.String(), endDate.Format("2006-01-02"), "TODAY", 1)
+
+	assertGolden(t, "stats_all.txt", []byte(output))
+}
+
+// TestStatsSummaryGolden tests the headline statistics against a snapshot shared with the
+// stats page, and the console report shows them unchanged
+func TestStatsSummaryGolden(t *testing.T) {
+	store := fixtureStorage(t)
+
+	source, err := stats.Load(store, "all", nil, time.Time{})
+	assert.NoError(t, err)
+	var summary strings.Builder
+	summaryConfig, err := stats.NewConfig(store.GetConfig())
+	assert.NoError(t, err)
+	for _, row := range stats.Summarize(source, store.StatsPolicy(), summaryConfig, time.Now()).Rows(formatDuration) {
+		summary.WriteString(row.String() + "\n")
+	}
+	assertGolden(t, "stats_summary_all.txt", []byte(summary.String()))
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeConsoleStats(&buf, store, "all"))
+	assert.Contains(t, buf.String(), summary.String())
+}
+
+// TestConsoleStatsDurationFormat tests the console report shows durations in the
+// configured format
+func TestConsoleStatsDurationFormat(t *testing.T) {
+	store := fixtureStorage(t)
+	consoleMessages = i18n.New(string(i18n.English)).WithDurationFormat("decimal")
+	defer func() { consoleMessages = i18n.New(string(i18n.English)) }()
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeConsoleStats(&buf, store, "all"))
+	assert.Regexp(t, `Total work time: \d+\.\dh\n`, buf.String())
+}
+
+// TestConsoleStatsLocale tests the console report is shown in the configured language
+func TestConsoleStatsLocale(t *testing.T) {
+	store := fixtureStorage(t)
+	consoleMessages = i18n.New("pl")
+	defer func() { consoleMessages = i18n.New(string(i18n.English)) }()
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeConsoleStats(&buf, store, "all"))
+	assert.Contains(t, buf.String(), "Statystyki dla zakresu all")
+	assert.Contains(t, buf.String(), "Podział przerw:")
+	assert.NotContains(t, buf.String(), "Interruption breakdown")
+}
+
+// TestConsoleStatsRecoveryTime tests measured refocus times are compared against the
+// configured recovery time
+func TestConsoleStatsRecoveryTime(t *testing.T) {
+	store := fixtureStorage(t)
+	store.GetConfig().RecoveryTime = config.Duration(25 * time.Minute)
+	store.GetConfig().StatsPolicy.Tags = map[string]config.TagPolicy{"meeting": {NoRecovery: true}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeConsoleStats(&buf, store, "all"))
+	assert.Contains(t, buf.String(), "call       1          1          0s              25m 0s\n")
+	assert.Contains(t, buf.String(), "meeting    1          1          0s              0s\n")
+}
+
+// TestStructuredStats tests the -output json and yaml stats formats
+func TestStructuredStats(t *testing.T) {
+	store := fixtureStorage(t)
+
+	expected, err := store.GetDetailedStats("all")
+	assert.NoError(t, err)
+	expected.CalculateProductivityScore()
+
+	var jsonBuf bytes.Buffer
+	assert.NoError(t, writeStructuredStats(&jsonBuf, store, "all", "json"))
+	var fromJSON models.DetailedStats
+	assert.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &fromJSON))
+	assert.Contains(t, jsonBuf.String(), `"total_work_duration"`)
+	assert.Equal(t, expected.TotalWorkDuration, fromJSON.TotalWorkDuration)
+	assert.Equal(t, expected.TotalInterruptions, fromJSON.TotalInterruptions)
+	assert.Equal(t, expected.InterruptionsByTag, fromJSON.InterruptionsByTag)
+	assert.Equal(t, expected.HourlyProductivity, fromJSON.HourlyProductivity)
+	assert.InDelta(t, expected.ProductivityScore, fromJSON.ProductivityScore, 0.001)
+
+	var yamlBuf bytes.Buffer
+	assert.NoError(t, writeStructuredStats(&yamlBuf, store, "all", "yaml"))
+	var fromYAML models.DetailedStats
+	assert.NoError(t, yaml.Unmarshal(yamlBuf.Bytes(), &fromYAML))
+	assert.Contains(t, yamlBuf.String(), "total_sessions:")
+	assert.Equal(t, expected.TotalSessions, fromYAML.TotalSessions)
+	assert.Equal(t, expected.DailyWorkDurations, fromYAML.DailyWorkDurations)
+}
+
+// TestExportDataGolden tests the --export JSON output against a snapshot
+func TestExportDataGolden(t *testing.T) {
+	// The snapshot holds UTC dates, pin the time zone so it matches wherever the test runs
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.UTC
+	store := fixtureStorage(t)
+
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	assert.NoError(t, store.ExportData(exportPath))
+
+	output, err := os.ReadFile(exportPath)
+	assert.NoError(t, err)
+
+	assertGolden(t, "export.json", output)
+}
+
+// TestExportNDJSONGolden tests the streaming NDJSON export against a snapshot
+func TestExportNDJSONGolden(t *testing.T) {
+	store := fixtureStorage(t)
+
+	var buf bytes.Buffer
+	count, err := store.ExportNDJSON(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	assertGolden(t, "export.ndjson", buf.Bytes())
+}
+
+// TestAnonymizedExport tests -anonymize removes descriptions but keeps the statistics
+func TestAnonymizedExport(t *testing.T) {
+	store := fixtureStorage(t)
+
+	_, err := newExportAnonymizer("redact")
+	assert.Error(t, err)
+
+	for _, mode := range []string{"hash", "label"} {
+		anonymizer, err := newExportAnonymizer(mode)
+		assert.NoError(t, err)
+
+		exportPath := filepath.Join(t.TempDir(), "export.json")
+		assert.NoError(t, store.ExportAnonymizedData(exportPath, anonymizer))
+		output, err := os.ReadFile(exportPath)
+		assert.NoError(t, err)
+		for _, description := range []string{"PROJ-42", "Support call", "Standup", "Code review"} {
+			assert.NotContains(t, string(output), description, mode)
+		}
+
+		var ndjson bytes.Buffer
+		count, err := store.ExportAnonymizedNDJSON(&ndjson, anonymizer)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.NotContains(t, ndjson.String(), "PROJ-42", mode)
+
+		// Durations, tags and timestamps survive a round trip
+		shared, err := storage.NewStorage(t.TempDir())
+		assert.NoError(t, err)
+		assert.NoError(t, shared.ImportData(exportPath, false))
+		expected, err := store.GetDetailedStats("all")
+		assert.NoError(t, err)
+		actual, err := shared.GetDetailedStats("all")
+		assert.NoError(t, err)
+		assert.Equal(t, expected.TotalWorkDuration, actual.TotalWorkDuration, mode)
+		assert.Equal(t, expected.InterruptionsByTag, actual.InterruptionsByTag, mode)
+		assert.Equal(t, expected.HourlyProductivity, actual.HourlyProductivity, mode)
+	}
+}
+
+// TestExportMetricsGolden tests the --export-metrics CSV output against a snapshot
+func TestExportMetricsGolden(t *testing.T) {
+	store := fixtureStorage(t)
+
+	var buf bytes.Buffer
+	assert.NoError(t, store.ExportMetricsCSV(&buf, "all"))
+
+	assertGolden(t, "metrics_all.csv", buf.Bytes())
+}
+
+// TestPrintImportReport tests the -dry-run preview lists each day's action and the problems
+func TestPrintImportReport(t *testing.T) {
+	report := &storage.ImportReport{
+		Format: "Toggl Track",
+		Days: []storage.ImportDay{
+			{Date: "2025-03-10", Action: storage.ImportMerge, Sessions: 3, Existing: 1, Added: 1, Duplicates: 1, Overlapping: 1},
+			{Date: "2025-03-11", Action: storage.ImportCreate, Sessions: 2, Added: 2},
+		},
+		Issues: []storage.ImportIssue{
+			{Date: "2025-03-10", SessionID: "import_1", Problem: "overlaps stored session 42", Skipped: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	printImportReport(&buf, report)
+	assert.Equal(t, "Format: Toggl Track\n"+
+		"  2025-03-10  merge     3 session(s), 1 added to 1 stored, 1 duplicate(s), 1 overlapping\n"+
+		"  2025-03-11  create    2 session(s)\n"+
+		"Sessions to import: 3\n"+
+		"Problems (1):\n"+
+		"  2025-03-10 import_1: overlaps stored session 42 (skipped)\n", buf.String())
+}
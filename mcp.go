@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/control"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// The mcp command speaks the Model Context Protocol over stdio: newline-delimited
+// JSON-RPC 2.0 messages. It offers the tracker's actions and statistics as tools, so an
+// assistant can start sessions, log interruptions and answer questions about focus time.
+
+// mcpProtocolVersion is the protocol revision the server implements
+const mcpProtocolVersion = "2024-11-05"
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// rpcRequest is a JSON-RPC request, or a notification when it has no ID
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcError is the error member of a failed JSON-RPC response
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcResponse is a JSON-RPC response carrying either a result or an error
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// mcpTool describes a tool in the tools/list result
+type mcpTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+// mcpToolResult is the result of tools/call. Failed actions are reported as results
+// with isError set, so the assistant can read the reason.
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+// mcpContent is a block of tool output
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// mcpToolArgs holds the arguments any tool may receive
+type mcpToolArgs struct {
+	Description string  `json:"description"`
+	Project     string  `json:"project"`
+	Estimate    string  `json:"estimate"`
+	Tag         string  `json:"tag"`
+	Minutes     float64 `json:"minutes"`
+	Range       string  `json:"range"`
+}
+
+// mcpSchema builds an object input schema from property descriptions
+func mcpSchema(properties map[string]map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// mcpTools lists the tools offered to assistants
+func mcpTools() []mcpTool {
+	stringProperty := func(description string) map[string]interface{} {
+		return map[string]interface{}{"type": "string", "description": description}
+	}
+
+	return []mcpTool{
+		{
+			Name:        "get_status",
+			Description: "Current state (working, interrupted or idle), the active session and today's focus time",
+			InputSchema: mcpSchema(map[string]map[string]interface{}{}),
+		},
+		{
+			Name:        "start_session",
+			Description: "Start a work session. Fails while another session is active.",
+			InputSchema: mcpSchema(map[string]map[string]interface{}{
+				"description": stringProperty("What is being worked on"),
+				"project":     stringProperty("Project of the session"),
+				"estimate":    stringProperty("Expected work time, e.g. 45m or 1h30m"),
+			}, "description"),
+		},
+		{
+			Name:        "end_session",
+			Description: "End the active session",
+			InputSchema: mcpSchema(map[string]map[string]interface{}{}),
+		},
+		{
+			Name:        "log_interruption",
+			Description: "Log an interruption of the active session. With minutes the interruption is over: it started that many minutes ago and work has resumed. Without minutes it is ongoing until return_to_work.",
+			InputSchema: mcpSchema(map[string]map[string]interface{}{
+				"tag":         stringProperty("Kind of interruption: call, meeting, spouse, other or a custom tag; suggested from the description when omitted"),
+				"description": stringProperty("What interrupted, e.g. phone call from the bank"),
+				"minutes":     {"type": "number", "description": "How long a finished interruption lasted"},
+			}),
+		},
+		{
+			Name:        "return_to_work",
+			Description: "Return to work from the ongoing interruption",
+			InputSchema: mcpSchema(map[string]map[string]interface{}{}),
+		},
+		{
+			Name:        "get_stats",
+			Description: "Focus time, interruptions and productivity score for a range of days",
+			InputSchema: mcpSchema(map[string]map[string]interface{}{
+				"range": stringProperty("day, week, month, quarter, year, all or YYYY-MM-DD..YYYY-MM-DD; defaults to week"),
+			}),
+		},
+	}
+}
+
+// runMCP serves the Model Context Protocol on stdin and stdout until stdin closes
+func runMCP(store storage.Store, args []string) {
+	if err := serveMCP(store, os.Stdin, os.Stdout, time.Now); err != nil {
+		fmt.Fprintf(os.Stderr, "Error serving MCP: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// serveMCP answers JSON-RPC messages read from r until it ends
+func serveMCP(store storage.Store, r io.Reader, w io.Writer, clock func() time.Time) error {
+	decoder := json.NewDecoder(r)
+	encoder := json.NewEncoder(w)
+
+	for {
+		var request rpcRequest
+		if err := decoder.Decode(&request); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			// The stream cannot be resynchronised after malformed JSON
+			encoder.Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcParseError, Message: err.Error()}})
+			return fmt.Errorf("failed to parse message: %w", err)
+		}
+
+		result, rpcErr := handleMCPRequest(store, request, clock())
+		if len(request.ID) == 0 {
+			continue // Notifications get no response
+		}
+
+		response := rpcResponse{JSONRPC: "2.0", ID: request.ID, Result: result, Error: rpcErr}
+		if err := encoder.Encode(response); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+}
+
+// handleMCPRequest executes a single JSON-RPC method
+func handleMCPRequest(store storage.Store, request rpcRequest, now time.Time) (interface{}, *rpcError) {
+	if request.JSONRPC != "2.0" {
+		return nil, &rpcError{Code: rpcInvalidRequest, Message: "jsonrpc must be \"2.0\""}
+	}
+
+	switch request.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]interface{}{"name": "interruption-tracker", "version": AppVersion},
+		}, nil
+	case "ping", "notifications/initialized":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": mcpTools()}, nil
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+		}
+		var args mcpToolArgs
+		if len(params.Arguments) > 0 {
+			if err := json.Unmarshal(params.Arguments, &args); err != nil {
+				return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("invalid arguments: %v", err)}
+			}
+		}
+
+		text, err := callMCPTool(store, params.Name, args, now)
+		if errors.Is(err, errUnknownTool) {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		if err != nil {
+			return mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		return mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}}, nil
+	}
+
+	return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method %q not found", request.Method)}
+}
+
+// errUnknownTool is returned for tools/call with a name not in mcpTools
+var errUnknownTool = errors.New("unknown tool")
+
+// mcpCommands maps each tool to the control command it runs
+var mcpCommands = map[string]string{
+	"get_status":       control.CommandStatus,
+	"start_session":    control.CommandStart,
+	"end_session":      control.CommandEnd,
+	"log_interruption": control.CommandInterrupt,
+	"return_to_work":   control.CommandReturn,
+	"get_stats":        control.CommandStats,
+}
+
+// callMCPTool runs a tool and describes the outcome for the assistant
+func callMCPTool(store storage.Store, name string, args mcpToolArgs, now time.Time) (string, error) {
+	command, ok := mcpCommands[name]
+	if !ok {
+		return "", fmt.Errorf("%w %q", errUnknownTool, name)
+	}
+
+	resp := handleControl(store, control.Request{
+		Command:     command,
+		Description: args.Description,
+		Project:     args.Project,
+		Estimate:    args.Estimate,
+		Tag:         args.Tag,
+		Minutes:     args.Minutes,
+		Range:       args.Range,
+	}, now)
+	if !resp.OK {
+		return "", errors.New(resp.Error)
+	}
+	return resp.Message, nil
+}
+
+// describeStatus summarises the current state in a sentence or two
+func describeStatus(store storage.Store, now time.Time) (string, error) {
+	status, err := buildStatus(store, now)
+	if err != nil {
+		return "", err
+	}
+
+	today := fmt.Sprintf("Focus time today: %s.", status.Today)
+	switch status.State {
+	case StateWorking:
+		return fmt.Sprintf("Working on %q for %s with %d interruption(s). %s", status.Description, status.Elapsed, status.Interruptions, today), nil
+	case StateInterrupted:
+		return fmt.Sprintf("Interrupted (%s) for %s while working on %q. %s", status.Tag, status.Interrupted, status.Description, today), nil
+	}
+	return "No active session. " + today, nil
+}
+
+// describeStats summarises the statistics of a range
+func describeStats(store storage.Store, rangeType string) (string, error) {
+	stats, err := store.GetDetailedStats(rangeType)
+	if err != nil {
+		return "", err
+	}
+	score := stats.CalculateProductivityScore()
+
+	tags := make([]string, 0, len(stats.InterruptionsByTag))
+	var interrupted time.Duration
+	for tag := range stats.InterruptionsByTag {
+		tags = append(tags, string(tag))
+		interrupted += stats.InterruptionDurationByTag[tag]
+	}
+	sort.Strings(tags)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s to %s:\n", stats.StartDate.Format("2006-01-02"), stats.EndDate.Format("2006-01-02"))
+	fmt.Fprintf(&sb, "Focus time: %s in %d session(s)\n", formatDuration(stats.TotalWorkDuration), stats.TotalSessions)
+	fmt.Fprintf(&sb, "Interruptions: %d, %s in total\n", stats.TotalInterruptions, formatDuration(interrupted))
+	fmt.Fprintf(&sb, "Productivity score: %.1f/100\n", score)
+	for _, tag := range tags {
+		interruptionTag := models.InterruptionTag(tag)
+		fmt.Fprintf(&sb, "- %s: %d, %s\n", tag, stats.InterruptionsByTag[interruptionTag], formatDuration(stats.InterruptionDurationByTag[interruptionTag]))
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestServeMCP tests an assistant session: starting work, logging interruptions and
+// asking for statistics over JSON-RPC
+func TestServeMCP(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	// Each message happens 20 minutes after the previous one, from 9:00 today
+	today := store.DayOf(time.Now())
+	now := today.Add(9 * time.Hour)
+	clock := func() time.Time {
+		now = now.Add(20 * time.Minute)
+		return now
+	}
+
+	requests := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"start_session","arguments":{"description":"Quarterly report","estimate":"2h"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"log_interruption","arguments":{"tag":"call","description":"Phone call","minutes":12}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"log_interruption","arguments":{"tag":"lunch"}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"log_interruption","arguments":{"tag":"meeting","minutes":60}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"log_interruption","arguments":{"tag":"meeting","description":"Standup"}}}`,
+		`{"jsonrpc":"2.0","id":8,"method":"tools/call","params":{"name":"get_status"}}`,
+		`{"jsonrpc":"2.0","id":9,"method":"tools/call","params":{"name":"return_to_work"}}`,
+		`{"jsonrpc":"2.0","id":10,"method":"tools/call","params":{"name":"end_session"}}`,
+		`{"jsonrpc":"2.0","id":11,"method":"tools/call","params":{"name":"get_stats","arguments":{"range":"day"}}}`,
+		`{"jsonrpc":"2.0","id":12,"method":"tools/call","params":{"name":"delete_everything"}}`,
+		`{"jsonrpc":"2.0","id":13,"method":"resources/list"}`,
+	}
+
+	var output strings.Builder
+	assert.NoError(t, serveMCP(store, strings.NewReader(strings.Join(requests, "\n")), &output, clock))
+
+	responses := make(map[float64]map[string]interface{})
+	scanner := bufio.NewScanner(strings.NewReader(output.String()))
+	for scanner.Scan() {
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &response))
+		responses[response["id"].(float64)] = response
+	}
+	assert.Len(t, responses, 13, "the notification gets no response")
+
+	// toolText returns the text of a tool result and whether it is an error
+	toolText := func(id float64) (string, bool) {
+		result := responses[id]["result"].(map[string]interface{})
+		text := result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+		isError, _ := result["isError"].(bool)
+		return text, isError
+	}
+
+	assert.Equal(t, mcpProtocolVersion, responses[1]["result"].(map[string]interface{})["protocolVersion"])
+	assert.Len(t, responses[2]["result"].(map[string]interface{})["tools"], len(mcpTools()))
+
+	text, isError := toolText(3)
+	assert.False(t, isError)
+	assert.Contains(t, text, `Started "Quarterly report"`)
+
+	text, isError = toolText(4)
+	assert.False(t, isError)
+	assert.Contains(t, text, "call interruption of 12m 0s")
+
+	text, isError = toolText(5)
+	assert.True(t, isError)
+	assert.Contains(t, text, `unknown interruption tag "lunch"`)
+
+	text, isError = toolText(6)
+	assert.True(t, isError, "an hour ago is before the session started")
+	assert.Contains(t, text, "overlaps")
+
+	text, isError = toolText(8)
+	assert.False(t, isError)
+	assert.Contains(t, text, "Interrupted (meeting)")
+
+	_, isError = toolText(9)
+	assert.False(t, isError)
+	text, isError = toolText(10)
+	assert.False(t, isError)
+	assert.Contains(t, text, "2 interruption(s)")
+
+	text, isError = toolText(11)
+	assert.False(t, isError)
+	assert.Contains(t, text, "Interruptions: 2")
+	assert.Contains(t, text, "- call: 1, 12m 0s")
+
+	assert.Equal(t, float64(rpcInvalidParams), responses[12]["error"].(map[string]interface{})["code"])
+	assert.Equal(t, float64(rpcMethodNotFound), responses[13]["error"].(map[string]interface{})["code"])
+
+	// The session was saved with both interruptions in order
+	dailySessions, err := store.LoadDailySessions(today)
+	assert.NoError(t, err)
+	if assert.Len(t, dailySessions.Sessions, 1) {
+		session := dailySessions.Sessions[0]
+		assert.NotNil(t, session.End)
+		assert.Len(t, session.Interruptions, 4)
+		assert.Equal(t, session.Interruptions, session.SubSessions[0].Interruptions)
+		assert.Equal(t, "Phone call", session.Interruptions[0].Description)
+		assert.Equal(t, 12*time.Minute, session.Interruptions[1].StartTime.Sub(session.Interruptions[0].StartTime))
+	}
+
+	// Malformed JSON ends the stream with a parse error
+	output.Reset()
+	assert.Error(t, serveMCP(store, strings.NewReader("{not json"), &output, clock))
+	assert.Contains(t, output.String(), `"code":-32700`)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// runMeetings declares the meetings of a day, such as "9:30-10 Standup", which the tracker
+// records as meeting interruptions while they run. Without meetings, lists those declared.
+func runMeetings(store storage.Store, args []string) {
+	meetingFlags := flag.NewFlagSet("meetings", flag.ExitOnError)
+	dateText := meetingFlags.String("date", "", "Day of the meetings (YYYY-MM-DD), defaults to today")
+	clearDay := meetingFlags.Bool("clear", false, "Remove the meetings declared for the day")
+	meetingFlags.Parse(args)
+
+	day := store.DayOf(time.Now())
+	if *dateText != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *dateText, time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid date %q, use YYYY-MM-DD\n", *dateText)
+			os.Exit(2)
+		}
+		day = parsed
+	}
+
+	var dailySessions *models.DailySessions
+	var err error
+	switch {
+	case *clearDay:
+		dailySessions, err = clearMeetings(store, day)
+	case meetingFlags.NArg() > 0:
+		dailySessions, err = declareMeetings(store, day, strings.Join(meetingFlags.Args(), "; "))
+	default:
+		dailySessions, err = store.LoadDailySessions(day)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error declaring meetings: %v\n", err)
+		os.Exit(1)
+	}
+
+	writeMeetings(os.Stdout, day, dailySessions.Meetings)
+}
+
+// declareMeetings parses meetings and adds them to those declared for the day
+func declareMeetings(store storage.Store, day time.Time, text string) (*models.DailySessions, error) {
+	meetings, err := models.ParseMeetings(text, models.DayStartTime(day, store.GetConfig().DayStartHour))
+	if err != nil {
+		return nil, err
+	}
+
+	dailySessions, err := store.LoadDailySessions(day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily sessions: %w", err)
+	}
+	dailySessions.Date = day
+	if err := dailySessions.AddMeetings(meetings); err != nil {
+		return nil, err
+	}
+	if err := store.SaveDailySessions(dailySessions); err != nil {
+		return nil, fmt.Errorf("failed to save meetings: %w", err)
+	}
+	return dailySessions, nil
+}
+
+// clearMeetings removes the meetings declared for the day. Interruptions already recorded
+// for them are kept.
+func clearMeetings(store storage.Store, day time.Time) (*models.DailySessions, error) {
+	dailySessions, err := store.LoadDailySessions(day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily sessions: %w", err)
+	}
+	dailySessions.Date = day
+	dailySessions.Meetings = nil
+	if err := store.SaveDailySessions(dailySessions); err != nil {
+		return nil, fmt.Errorf("failed to save meetings: %w", err)
+	}
+	return dailySessions, nil
+}
+
+// recordMeetings interrupts the active session when a declared meeting starts and returns
+// to work when it ends, as the TUI does while it runs. Describes what was done, empty when
+// nothing was.
+func recordMeetings(store storage.Store, now time.Time) (string, error) {
+	day, active, err := loadActiveSession(store, now)
+	if err != nil || active == nil {
+		return "", err
+	}
+
+	if meeting, over := day.OverMeeting(active, now); over {
+		entry := models.NewTimeEntry(models.EntryTypeReturn, "")
+		entry.StartTime = meeting.End
+		addInterruptionEntry(active, entry)
+		if err := saveSessionEvent(store, day, models.JournalReturn, integrations.EventReturn, active, entry); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Meeting %q is over, back to %q", meeting.Title, active.Start.Description), nil
+	}
+
+	if meeting, due := day.DueMeeting(active, now); due {
+		meeting.Recorded = true
+		entry := meeting.Interruption(active)
+		addInterruptionEntry(active, entry)
+		if err := saveSessionEvent(store, day, models.JournalInterrupt, integrations.EventInterrupt, active, entry); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Meeting %q started, interrupted until %s", meeting.Title, meeting.End.Format("15:04")), nil
+	}
+	return "", nil
+}
+
+// writeMeetings lists the meetings of a day, marking those already recorded
+func writeMeetings(w io.Writer, day time.Time, meetings []*models.Meeting) {
+	if len(meetings) == 0 {
+		fmt.Fprintf(w, "No meetings declared for %s\n", day.Format("2006-01-02"))
+		return
+	}
+	for _, meeting := range meetings {
+		recorded := ""
+		if meeting.Recorded {
+			recorded = " (recorded)"
+		}
+		fmt.Fprintf(w, "%s %s%s\n", meeting.Span(), meeting.Title, recorded)
+	}
+}
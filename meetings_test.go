@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDeclareMeetings tests declaring, listing and clearing the meetings of a day
+func TestDeclareMeetings(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+
+	_, err = declareMeetings(store, day, "14:00-15:00 Planning")
+	assert.NoError(t, err)
+	dailySessions, err := declareMeetings(store, day, "9:30-10 Standup")
+	if !assert.NoError(t, err) {
+		return
+	}
+	dailySessions.Meetings[0].Recorded = true
+
+	var out bytes.Buffer
+	writeMeetings(&out, day, dailySessions.Meetings)
+	assert.Equal(t, "09:30-10:00 Standup (recorded)\n14:00-15:00 Planning\n", out.String())
+
+	_, err = declareMeetings(store, day, "14:30-15:30 Retro")
+	assert.ErrorContains(t, err, "overlaps Planning")
+	_, err = declareMeetings(store, day, "Retro")
+	assert.ErrorContains(t, err, "could not understand")
+
+	stored, err := store.LoadDailySessions(day)
+	assert.NoError(t, err)
+	assert.Len(t, stored.Meetings, 2)
+
+	dailySessions, err = clearMeetings(store, day)
+	assert.NoError(t, err)
+	out.Reset()
+	writeMeetings(&out, day, dailySessions.Meetings)
+	assert.Equal(t, "No meetings declared for 2025-03-03\n", out.String())
+}
+
+// TestRecordMeetings tests the daemon interrupting the active session for a declared
+// meeting and returning to work when it ends
+func TestRecordMeetings(t *testing.T) {
+	store, err := storage.NewStorageWithConfig(config.DefaultConfig(), t.TempDir())
+	assert.NoError(t, err)
+	now := time.Now()
+	meeting := &models.Meeting{Title: "Standup", Start: now.Add(-10 * time.Minute), End: now.Add(5 * time.Minute)}
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date:     store.DayOf(now),
+		Sessions: []*models.Session{models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Write"})},
+		Meetings: []*models.Meeting{meeting},
+	}))
+
+	message, err := recordMeetings(store, now)
+	assert.NoError(t, err)
+	assert.Contains(t, message, `Meeting "Standup" started`)
+	day, active, err := loadActiveSession(store, now)
+	if !assert.NoError(t, err) || !assert.NotNil(t, active) || !assert.Len(t, active.Interruptions, 1) {
+		return
+	}
+	assert.True(t, day.Meetings[0].Recorded)
+	assert.Equal(t, models.TagMeeting, active.Interruptions[0].Tag)
+	assert.True(t, meeting.Start.Equal(active.Interruptions[0].StartTime))
+
+	message, err = recordMeetings(store, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, message)
+
+	message, err = recordMeetings(store, now.Add(10*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, `Meeting "Standup" is over, back to "Write"`, message)
+	_, active, err = loadActiveSession(store, now)
+	if assert.NoError(t, err) && assert.Len(t, active.Interruptions, 2) {
+		assert.True(t, meeting.End.Equal(active.Interruptions[1].StartTime))
+	}
+}
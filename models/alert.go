@@ -0,0 +1,119 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AlertMethod is a way of alerting about an interruption or session running long
+type AlertMethod string
+
+// Alert methods
+const (
+	AlertBell         AlertMethod = "bell"         // Terminal bell
+	AlertFlash        AlertMethod = "flash"        // Status bar highlighted with the alert
+	AlertNotification AlertMethod = "notification" // Desktop notification
+)
+
+// DefaultAlertMethods are used when no alert methods are configured
+var DefaultAlertMethods = []AlertMethod{AlertBell, AlertFlash}
+
+// AlertPolicy decides when interruptions and sessions running long call for an alert.
+// Zero thresholds disable the alerts.
+type AlertPolicy struct {
+	Interruption time.Duration                     // Longest an interruption may run
+	Tags         map[InterruptionTag]time.Duration // Thresholds for interruptions with these tags, lower case
+	Session      time.Duration                     // Planned length of sessions without an estimate
+	Methods      []AlertMethod
+}
+
+// ParseAlertPolicy builds the policy from thresholds, per-tag thresholds and method names,
+// using DefaultAlertMethods when no methods are given
+func ParseAlertPolicy(interruption time.Duration, tags map[string]time.Duration, session time.Duration, methods []string) (AlertPolicy, error) {
+	if interruption < 0 || session < 0 {
+		return AlertPolicy{}, fmt.Errorf("alert thresholds must not be negative")
+	}
+
+	policy := AlertPolicy{Interruption: interruption, Session: session}
+	if len(tags) > 0 {
+		policy.Tags = make(map[InterruptionTag]time.Duration, len(tags))
+		for tag, threshold := range tags {
+			if threshold < 0 {
+				return AlertPolicy{}, fmt.Errorf("alert threshold for %q must not be negative", tag)
+			}
+			policy.Tags[InterruptionTag(strings.ToLower(strings.TrimSpace(tag)))] = threshold
+		}
+	}
+
+	for _, name := range methods {
+		method := AlertMethod(strings.ToLower(strings.TrimSpace(name)))
+		switch method {
+		case AlertBell, AlertFlash, AlertNotification:
+			policy.Methods = append(policy.Methods, method)
+		default:
+			return AlertPolicy{}, fmt.Errorf("unknown alert method %q, use bell, flash or notification", name)
+		}
+	}
+	if len(policy.Methods) == 0 {
+		policy.Methods = DefaultAlertMethods
+	}
+	return policy, nil
+}
+
+// Uses reports whether the policy alerts with the method
+func (p AlertPolicy) Uses(method AlertMethod) bool {
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// InterruptionThreshold returns how long an interruption with the tag may run, 0 for no
+// alert. Tags with their own threshold, even 0, ignore the general one.
+func (p AlertPolicy) InterruptionThreshold(tag InterruptionTag) time.Duration {
+	if threshold, ok := p.Tags[InterruptionTag(strings.ToLower(string(tag)))]; ok {
+		return threshold
+	}
+	return p.Interruption
+}
+
+// InterruptionDue returns the open interruption of the session and its threshold once it
+// has run past it
+func (p AlertPolicy) InterruptionDue(session *Session, now time.Time) (*TimeEntry, time.Duration, bool) {
+	entry, ok := session.OpenInterruption()
+	if !ok {
+		return nil, 0, false
+	}
+	threshold := p.InterruptionThreshold(entry.Tag)
+	if threshold <= 0 || now.Sub(entry.StartTime) < threshold {
+		return nil, 0, false
+	}
+	return entry, threshold, true
+}
+
+// PlannedLength returns the planned length of a session: its estimate, or the policy's
+// session threshold without one. 0 means no plan.
+func (p AlertPolicy) PlannedLength(session *Session) time.Duration {
+	if session.Estimate > 0 {
+		return session.Estimate
+	}
+	return p.Session
+}
+
+// SessionDue returns the planned length of an active session once its work time, up to
+// now, has run past it
+func (p AlertPolicy) SessionDue(session *Session, now time.Time) (time.Duration, bool) {
+	planned := p.PlannedLength(session)
+	if planned <= 0 || session.End != nil {
+		return 0, false
+	}
+
+	var work time.Duration
+	for _, period := range session.workPeriods(now) {
+		work += period.end.Sub(period.start)
+	}
+	return planned, work >= planned
+}
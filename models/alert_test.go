@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAlertPolicy tests parsing the alert settings and the default methods
+func TestParseAlertPolicy(t *testing.T) {
+	policy, err := ParseAlertPolicy(5*time.Minute, map[string]time.Duration{" Call ": 3 * time.Minute, "meeting": 0}, time.Hour, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultAlertMethods, policy.Methods)
+	assert.True(t, policy.Uses(AlertBell))
+	assert.False(t, policy.Uses(AlertNotification))
+	assert.Equal(t, 3*time.Minute, policy.InterruptionThreshold(TagCall))
+	assert.Equal(t, 3*time.Minute, policy.InterruptionThreshold("CALL"))
+	assert.Zero(t, policy.InterruptionThreshold(TagMeeting))
+	assert.Equal(t, 5*time.Minute, policy.InterruptionThreshold(TagOther))
+
+	policy, err = ParseAlertPolicy(0, nil, 0, []string{"Notification"})
+	assert.NoError(t, err)
+	assert.Equal(t, []AlertMethod{AlertNotification}, policy.Methods)
+
+	_, err = ParseAlertPolicy(0, nil, 0, []string{"siren"})
+	assert.Error(t, err)
+	_, err = ParseAlertPolicy(-time.Minute, nil, 0, nil)
+	assert.Error(t, err)
+	_, err = ParseAlertPolicy(0, map[string]time.Duration{"call": -time.Minute}, 0, nil)
+	assert.Error(t, err)
+}
+
+// TestAlertsDue tests alerts are due once an interruption or the work time of a session
+// runs past its threshold
+func TestAlertsDue(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	interruption := &TimeEntry{Type: EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 20*time.Minute), Tag: TagCall}
+	session.Interruptions = append(session.Interruptions, interruption)
+	session.SubSessions[0].Interruptions = append(session.SubSessions[0].Interruptions, interruption)
+
+	policy, _ := ParseAlertPolicy(10*time.Minute, map[string]time.Duration{"call": 5 * time.Minute}, 30*time.Minute, nil)
+
+	_, _, due := policy.InterruptionDue(session, day.Add(9*time.Hour+24*time.Minute))
+	assert.False(t, due)
+	entry, threshold, due := policy.InterruptionDue(session, day.Add(9*time.Hour+25*time.Minute))
+	assert.True(t, due)
+	assert.Same(t, interruption, entry)
+	assert.Equal(t, 5*time.Minute, threshold)
+
+	// Time away does not count towards the planned length
+	_, due = policy.SessionDue(session, day.Add(10*time.Hour))
+	assert.False(t, due)
+
+	back := &TimeEntry{Type: EntryTypeReturn, StartTime: day.Add(10 * time.Hour)}
+	session.Interruptions = append(session.Interruptions, back)
+	session.SubSessions[0].Interruptions = append(session.SubSessions[0].Interruptions, back)
+	_, _, due = policy.InterruptionDue(session, day.Add(11*time.Hour))
+	assert.False(t, due)
+	planned, due := policy.SessionDue(session, day.Add(10*time.Hour+10*time.Minute))
+	assert.True(t, due)
+	assert.Equal(t, 30*time.Minute, planned)
+
+	// The estimate of a session is its plan
+	session.Estimate = time.Hour
+	_, due = policy.SessionDue(session, day.Add(10*time.Hour+10*time.Minute))
+	assert.False(t, due)
+
+	session.EndAt(day.Add(12 * time.Hour))
+	_, due = policy.SessionDue(session, day.Add(12*time.Hour))
+	assert.False(t, due)
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// AnonymizeMode selects how an Anonymizer replaces descriptions
+type AnonymizeMode string
+
+const (
+	// AnonymizeHash replaces descriptions with salted hashes, so repeated tasks can still be
+	// told apart without revealing what they were
+	AnonymizeHash AnonymizeMode = "hash"
+	// AnonymizeLabel replaces descriptions with generic labels: "task" for sessions and the
+	// tag for interruptions
+	AnonymizeLabel AnonymizeMode = "label"
+)
+
+// ParseAnonymizeMode parses the mode given on the command line
+func ParseAnonymizeMode(mode string) (AnonymizeMode, error) {
+	switch AnonymizeMode(mode) {
+	case AnonymizeHash, AnonymizeLabel:
+		return AnonymizeMode(mode), nil
+	}
+	return "", fmt.Errorf("unknown anonymize mode %q, use hash or label", mode)
+}
+
+// Anonymizer copies sessions keeping only durations, tags and timestamps, for sharing
+// data without leaking task contents. Descriptions are replaced, projects and
+// attachments dropped.
+type Anonymizer struct {
+	mode AnonymizeMode
+	salt []byte // Mixed into hashes so short descriptions cannot be guessed from them
+}
+
+// NewAnonymizer creates an anonymizer. Hashes are only comparable between exports made
+// with the same salt.
+func NewAnonymizer(mode AnonymizeMode, salt []byte) *Anonymizer {
+	return &Anonymizer{mode: mode, salt: salt}
+}
+
+// Session returns an anonymized copy of the session. A nil anonymizer returns the session
+// unchanged.
+func (a *Anonymizer) Session(session *Session) *Session {
+	if a == nil {
+		return session
+	}
+
+	// Sub-sessions and the legacy list share entries, so each entry is copied once
+	copies := make(map[*TimeEntry]*TimeEntry)
+	entry := func(original *TimeEntry) *TimeEntry {
+		if original == nil {
+			return nil
+		}
+		if copied, ok := copies[original]; ok {
+			return copied
+		}
+		copied := *original
+		copied.Description = a.description(original)
+		copies[original] = &copied
+		return &copied
+	}
+	entries := func(originals []*TimeEntry) []*TimeEntry {
+		if originals == nil {
+			return nil
+		}
+		copied := make([]*TimeEntry, len(originals))
+		for i, original := range originals {
+			copied[i] = entry(original)
+		}
+		return copied
+	}
+
+	anonymized := &Session{
+		ID:             session.ID,
+		Start:          entry(session.Start),
+		End:            entry(session.End),
+		Interruptions:  entries(session.Interruptions),
+		Estimate:       session.Estimate,
+		Deferred:       entries(session.Deferred),
+		ContinuationOf: session.ContinuationOf,
+		FocusWindows:   session.FocusWindows,
+	}
+	if session.SubSessions != nil {
+		anonymized.SubSessions = make([]*SubSession, len(session.SubSessions))
+		for i, subSession := range session.SubSessions {
+			anonymized.SubSessions[i] = &SubSession{
+				Start:         entry(subSession.Start),
+				End:           entry(subSession.End),
+				Interruptions: entries(subSession.Interruptions),
+			}
+		}
+	}
+
+	return anonymized
+}
+
+// description returns what replaces the entry's description
+func (a *Anonymizer) description(entry *TimeEntry) string {
+	if entry.Description == "" {
+		return ""
+	}
+
+	if a.mode == AnonymizeLabel {
+		if entry.Type == EntryTypeInterruption || entry.Type == EntryTypeDeferred {
+			if entry.Tag == "" {
+				return string(TagOther)
+			}
+			return string(entry.Tag)
+		}
+		return "task"
+	}
+
+	sum := sha256.Sum256(append(append([]byte{}, a.salt...), entry.Description...))
+	return hex.EncodeToString(sum[:])[:12]
+}
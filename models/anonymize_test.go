@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAnonymizer tests descriptions are replaced on a copy while durations and tags stay
+func TestAnonymizer(t *testing.T) {
+	start := time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC)
+	interruption := &TimeEntry{Type: EntryTypeInterruption, StartTime: start.Add(10 * time.Minute), Description: "Call from Alice", Tag: TagCall}
+	back := &TimeEntry{Type: EntryTypeReturn, StartTime: start.Add(20 * time.Minute)}
+	session := &Session{
+		ID:            "sess_1",
+		Start:         &TimeEntry{Type: EntryTypeStart, StartTime: start, Description: "Secret acquisition"},
+		End:           &TimeEntry{Type: EntryTypeEnd, StartTime: start.Add(time.Hour)},
+		Interruptions: []*TimeEntry{interruption, back},
+		Deferred:      []*TimeEntry{{Type: EntryTypeDeferred, StartTime: start.Add(30 * time.Minute), Description: "Ask Bob"}},
+		Project:       "acme",
+		Attachments:   []*Attachment{{Path: "/home/me/contract.pdf"}},
+	}
+	session.SubSessions = []*SubSession{{Start: session.Start, End: session.End, Interruptions: session.Interruptions}}
+
+	_, err := ParseAnonymizeMode("redact")
+	assert.Error(t, err)
+	assert.Same(t, session, (*Anonymizer)(nil).Session(session))
+
+	labelled := NewAnonymizer(AnonymizeLabel, nil).Session(session)
+	assert.Equal(t, "task", labelled.Start.Description)
+	assert.Equal(t, "call", labelled.Interruptions[0].Description)
+	assert.Equal(t, "other", labelled.Deferred[0].Description)
+	assert.Empty(t, labelled.Project)
+	assert.Empty(t, labelled.Attachments)
+	assert.Same(t, labelled.Interruptions[0], labelled.SubSessions[0].Interruptions[0])
+
+	hashed := NewAnonymizer(AnonymizeHash, []byte("salt")).Session(session)
+	assert.Len(t, hashed.Start.Description, 12)
+	assert.NotEqual(t, NewAnonymizer(AnonymizeHash, []byte("pepper")).Session(session).Start.Description, hashed.Start.Description)
+	assert.Equal(t, TagCall, hashed.Interruptions[0].Tag)
+
+	work, interrupted, count := hashed.GetStats()
+	expectedWork, expectedInterrupted, expectedCount := session.GetStats()
+	assert.Equal(t, expectedWork, work)
+	assert.Equal(t, expectedInterrupted, interrupted)
+	assert.Equal(t, expectedCount, count)
+
+	// The original is untouched
+	assert.Equal(t, "Secret acquisition", session.Start.Description)
+	assert.Equal(t, "acme", session.Project)
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// AttachmentStatus describes whether an attached file still matches what was attached
+type AttachmentStatus string
+
+const (
+	// AttachmentOK means the file is unchanged since it was attached
+	AttachmentOK AttachmentStatus = "ok"
+	// AttachmentMissing means the file no longer exists
+	AttachmentMissing AttachmentStatus = "missing"
+	// AttachmentChanged means the file content differs from when it was attached
+	AttachmentChanged AttachmentStatus = "changed"
+)
+
+// Attachment references a file, e.g. a screenshot or document, kept as evidence of the
+// work done in a session. Only the path and metadata are stored, not the file itself.
+type Attachment struct {
+	Path    string    `json:"path"`           // Absolute path of the file
+	Size    int64     `json:"size"`           // Size in bytes when attached
+	SHA256  string    `json:"sha256"`         // Hex digest when attached, to show the file is unchanged
+	AddedAt time.Time `json:"added_at"`       // When the file was attached
+	Note    string    `json:"note,omitempty"` // What the file proves
+}
+
+// Name returns the file name of the attachment
+func (a *Attachment) Name() string {
+	return filepath.Base(a.Path)
+}
+
+// AddAttachment attaches a file to the session, returning false if the same path is
+// already attached
+func (session *Session) AddAttachment(attachment *Attachment) bool {
+	for _, existing := range session.Attachments {
+		if existing.Path == attachment.Path {
+			return false
+		}
+	}
+	session.Attachments = append(session.Attachments, attachment)
+	return true
+}
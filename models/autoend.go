@@ -0,0 +1,112 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// AutoEndPolicy closes sessions left running, instead of carrying them forward and
+// inflating their duration
+type AutoEndPolicy struct {
+	After  time.Duration // Longest a session may run, 0 for no limit
+	At     time.Duration // End-of-day offset from midnight, used when HasAt is set
+	HasAt  bool
+	Prompt bool // Ask on the next launch instead of closing the session
+}
+
+// ParseAutoEndPolicy builds the policy from a maximum session length and an "HH:MM"
+// end-of-day time, either of which may be unset
+func ParseAutoEndPolicy(after time.Duration, at string, prompt bool) (AutoEndPolicy, error) {
+	if after < 0 {
+		return AutoEndPolicy{}, fmt.Errorf("auto end duration must not be negative")
+	}
+
+	policy := AutoEndPolicy{After: after, Prompt: prompt}
+	if at != "" {
+		offset, err := parseClock(at)
+		if err != nil {
+			return AutoEndPolicy{}, err
+		}
+		policy.At, policy.HasAt = offset, true
+	}
+	return policy, nil
+}
+
+// Enabled reports whether the policy ends sessions at all
+func (p AutoEndPolicy) Enabled() bool {
+	return p.After > 0 || p.HasAt
+}
+
+// Deadline returns when an active session is auto-ended: after the maximum length or at
+// the first end-of-day time following its start, whichever comes first. The deadline
+// never falls before the last recorded activity. Returns false for ended sessions or
+// when the policy is disabled.
+func (p AutoEndPolicy) Deadline(session *Session) (time.Time, bool) {
+	if !p.Enabled() || session.End != nil || session.Start == nil {
+		return time.Time{}, false
+	}
+
+	start := session.Start.StartTime
+	var deadline time.Time
+	if p.After > 0 {
+		deadline = start.Add(p.After)
+	}
+	if p.HasAt {
+		year, month, day := start.Date()
+		endOfDay := time.Date(year, month, day, 0, 0, 0, 0, start.Location()).Add(p.At)
+		if !endOfDay.After(start) {
+			endOfDay = endOfDay.AddDate(0, 0, 1)
+		}
+		if deadline.IsZero() || endOfDay.Before(deadline) {
+			deadline = endOfDay
+		}
+	}
+
+	if last := session.LastActivity(); last.After(deadline) {
+		deadline = last
+	}
+	return deadline, true
+}
+
+// Due returns the deadline of an active session once now has passed it
+func (p AutoEndPolicy) Due(session *Session, now time.Time) (time.Time, bool) {
+	deadline, ok := p.Deadline(session)
+	if !ok || now.Before(deadline) {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
+// EndAt ends the session and its current sub-session at t, closing an ongoing
+// interruption with a return and focus mode at the same time. Returns the end entry.
+func (session *Session) EndAt(t time.Time) *TimeEntry {
+	entry := &TimeEntry{
+		ID:        fmt.Sprintf("%d", t.UnixNano()),
+		Type:      EntryTypeEnd,
+		StartTime: t,
+	}
+
+	if len(session.SubSessions) > 0 {
+		current := session.SubSessions[len(session.SubSessions)-1]
+		if len(current.Interruptions)%2 != 0 {
+			ret := &TimeEntry{
+				ID:        fmt.Sprintf("%d", t.UnixNano()-1),
+				Type:      EntryTypeReturn,
+				StartTime: t,
+			}
+			current.Interruptions = append(current.Interruptions, ret)
+
+			// Keep the session's interruptions in step with the sub-session
+			if len(session.Interruptions)%2 != 0 {
+				session.Interruptions = append(session.Interruptions, ret)
+			}
+		}
+		if current.End == nil {
+			current.End = entry
+		}
+	}
+	session.StopFocusMode(t)
+	session.End = entry
+
+	return entry
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAutoEndPolicy tests parsing and validating the auto-end settings
+func TestParseAutoEndPolicy(t *testing.T) {
+	policy, err := ParseAutoEndPolicy(0, "", false)
+	assert.NoError(t, err)
+	assert.False(t, policy.Enabled())
+
+	policy, err = ParseAutoEndPolicy(8*time.Hour, "18:30", true)
+	assert.NoError(t, err)
+	assert.True(t, policy.Enabled())
+	assert.Equal(t, 18*time.Hour+30*time.Minute, policy.At)
+	assert.True(t, policy.Prompt)
+
+	_, err = ParseAutoEndPolicy(0, "6pm", false)
+	assert.Error(t, err)
+	_, err = ParseAutoEndPolicy(-time.Hour, "", false)
+	assert.Error(t, err)
+}
+
+// TestAutoEndDeadline tests the deadline is the earlier of the maximum length and the
+// end-of-day time, and never before the last activity
+func TestAutoEndDeadline(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+
+	policy, _ := ParseAutoEndPolicy(10*time.Hour, "18:00", false)
+	deadline, ok := policy.Deadline(session)
+	assert.True(t, ok)
+	assert.Equal(t, day.Add(18*time.Hour), deadline)
+
+	policy, _ = ParseAutoEndPolicy(4*time.Hour, "18:00", false)
+	deadline, _ = policy.Deadline(session)
+	assert.Equal(t, day.Add(13*time.Hour), deadline)
+
+	// Sessions started after the end-of-day time end at that time the next day
+	late := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(20 * time.Hour)})
+	policy, _ = ParseAutoEndPolicy(0, "18:00", false)
+	deadline, _ = policy.Deadline(late)
+	assert.Equal(t, day.Add(42*time.Hour), deadline)
+
+	// Recorded activity past the deadline moves it
+	session.SubSessions[0].Interruptions = append(session.SubSessions[0].Interruptions,
+		&TimeEntry{Type: EntryTypeInterruption, StartTime: day.Add(19 * time.Hour)})
+	deadline, _ = policy.Deadline(session)
+	assert.Equal(t, day.Add(19*time.Hour), deadline)
+
+	_, due := policy.Due(session, day.Add(18*time.Hour+30*time.Minute))
+	assert.False(t, due)
+	deadline, due = policy.Due(session, day.Add(33*time.Hour))
+	assert.True(t, due)
+	assert.Equal(t, day.Add(19*time.Hour), deadline)
+
+	session.EndAt(deadline)
+	_, ok = policy.Deadline(session)
+	assert.False(t, ok)
+}
+
+// TestSessionEndAt tests ending a session closes an ongoing interruption
+func TestSessionEndAt(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: start})
+	session.SubSessions[0].Interruptions = append(session.SubSessions[0].Interruptions,
+		&TimeEntry{Type: EntryTypeInterruption, StartTime: start.Add(2 * time.Hour)})
+
+	end := session.EndAt(start.Add(3 * time.Hour))
+	assert.Equal(t, EntryTypeEnd, end.Type)
+	assert.Equal(t, end, session.End)
+	assert.Equal(t, end, session.SubSessions[0].End)
+	assert.Len(t, session.SubSessions[0].Interruptions, 2)
+
+	work, interrupted, count := session.GetStats()
+	assert.Equal(t, 2*time.Hour, work)
+	assert.Equal(t, time.Hour, interrupted)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,77 @@
+package models
+
+import "sort"
+
+// MergeSessions combines sessions into one keeping the first session's ID and description.
+// Each session's work periods become sub-sessions of the merged one, so the gaps between
+// them count as time away rather than work. Estimates add up.
+func MergeSessions(sessions []*Session) *Session {
+	sorted := make([]*Session, len(sessions))
+	copy(sorted, sessions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start.StartTime.Before(sorted[j].Start.StartTime)
+	})
+
+	first, last := sorted[0], sorted[len(sorted)-1]
+	merged := &Session{
+		ID:             first.ID,
+		Start:          first.Start,
+		End:            last.End,
+		Project:        first.Project,
+		ContinuationOf: first.ContinuationOf,
+	}
+
+	for _, session := range sorted {
+		if merged.Project == "" {
+			merged.Project = session.Project
+		}
+		merged.Estimate += session.Estimate
+		merged.Deferred = append(merged.Deferred, session.Deferred...)
+		merged.Attachments = append(merged.Attachments, session.Attachments...)
+		merged.FocusWindows = append(merged.FocusWindows, session.FocusWindows...)
+
+		if len(session.SubSessions) > 0 {
+			merged.SubSessions = append(merged.SubSessions, session.SubSessions...)
+			merged.Interruptions = append(merged.Interruptions, session.Interruptions...)
+			continue
+		}
+
+		// Sessions from before sub-sessions become one period from start to end
+		merged.SubSessions = append(merged.SubSessions, &SubSession{
+			Start:         session.Start,
+			End:           session.End,
+			Interruptions: session.Interruptions,
+		})
+		merged.Interruptions = append(merged.Interruptions, session.Interruptions...)
+	}
+
+	return merged
+}
+
+// Retag sets the tag of every interruption and deferred interruption of the session and
+// returns how many entries changed
+func (session *Session) Retag(tag InterruptionTag) int {
+	changed := 0
+	seen := make(map[*TimeEntry]bool)
+	retag := func(entries []*TimeEntry) {
+		for _, entry := range entries {
+			if seen[entry] || (entry.Type != EntryTypeInterruption && entry.Type != EntryTypeDeferred) {
+				continue
+			}
+			seen[entry] = true
+			if entry.Tag != tag {
+				entry.Tag = tag
+				changed++
+			}
+		}
+	}
+
+	// The legacy list may share entries with the sub-sessions
+	for _, subSession := range session.SubSessions {
+		retag(subSession.Interruptions)
+	}
+	retag(session.Interruptions)
+	retag(session.Deferred)
+
+	return changed
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMergeSessions tests merging keeps each session's work periods and the gaps between them
+func TestMergeSessions(t *testing.T) {
+	base := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	session := func(id string, start, end time.Duration, estimate time.Duration) *Session {
+		s := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: base.Add(start), Description: id})
+		s.ID = id
+		s.Estimate = estimate
+		s.EndAt(base.Add(end))
+		return s
+	}
+
+	later := session("later", 2*time.Hour, 3*time.Hour, 30*time.Minute)
+	later.Project = "acme"
+	earlier := session("earlier", 0, time.Hour, time.Hour)
+	interruption := &TimeEntry{Type: EntryTypeInterruption, StartTime: base.Add(15 * time.Minute), Tag: TagCall}
+	back := &TimeEntry{Type: EntryTypeReturn, StartTime: base.Add(25 * time.Minute)}
+	earlier.SubSessions[0].Interruptions = []*TimeEntry{interruption, back}
+	earlier.Interruptions = []*TimeEntry{interruption, back}
+
+	merged := MergeSessions([]*Session{later, earlier})
+
+	assert.Equal(t, "earlier", merged.ID)
+	assert.Equal(t, "earlier", merged.Start.Description)
+	assert.Equal(t, "acme", merged.Project)
+	assert.Equal(t, 90*time.Minute, merged.Estimate)
+	assert.True(t, base.Add(3*time.Hour).Equal(merged.End.StartTime))
+	assert.Len(t, merged.SubSessions, 2)
+
+	// The hour between the sessions is not work
+	work, interrupted, count := merged.GetStats()
+	assert.Equal(t, 110*time.Minute, work)
+	assert.Equal(t, 10*time.Minute, interrupted)
+	assert.Equal(t, 1, count)
+}
+
+// TestSessionRetag tests re-tagging interruptions shared between sub-sessions and the legacy list
+func TestSessionRetag(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: start})
+	interruption := &TimeEntry{Type: EntryTypeInterruption, StartTime: start.Add(time.Minute), Tag: TagCall}
+	back := &TimeEntry{Type: EntryTypeReturn, StartTime: start.Add(2 * time.Minute)}
+	session.SubSessions[0].Interruptions = []*TimeEntry{interruption, back}
+	session.Interruptions = []*TimeEntry{interruption, back}
+	session.Deferred = []*TimeEntry{{Type: EntryTypeDeferred, StartTime: start.Add(3 * time.Minute), Tag: TagSpouse}}
+
+	assert.Equal(t, 2, session.Retag(TagMeeting))
+	assert.Equal(t, TagMeeting, interruption.Tag)
+	assert.Equal(t, TagMeeting, session.Deferred[0].Tag)
+	assert.Equal(t, InterruptionTag(""), back.Tag)
+
+	assert.Equal(t, 0, session.Retag(TagMeeting))
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// ContinuedPart is one day's part of a task continued across days
+type ContinuedPart struct {
+	Day     time.Time // Day the part is stored under
+	Session *Session
+}
+
+// ContinuedTask is a task split at day boundaries into sessions linked through
+// ContinuationOf, with its work summed over all parts
+type ContinuedTask struct {
+	Description          string
+	Project              string
+	Parts                []ContinuedPart // Oldest first
+	WorkDuration         time.Duration
+	InterruptionDuration time.Duration
+	Interruptions        int
+}
+
+// Start returns when the first part of the task started
+func (t ContinuedTask) Start() time.Time {
+	return t.Parts[0].Session.Start.StartTime
+}
+
+// Active reports whether the latest part of the task is still running
+func (t ContinuedTask) Active() bool {
+	return t.Parts[len(t.Parts)-1].Session.End == nil
+}
+
+// Spans reports whether any part of the task is stored under a day from start to end
+func (t ContinuedTask) Spans(start, end time.Time) bool {
+	for _, part := range t.Parts {
+		if !part.Day.Before(start) && !part.Day.After(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// LinkContinuations follows ContinuationOf links between the sessions of the given days and
+// returns the tasks made of more than one part, oldest first. Each day keeps its own
+// session; the combined durations exist only in the result.
+func LinkContinuations(days []*DailySessions) []ContinuedTask {
+	parts := make(map[string]ContinuedPart)
+	continuedBy := make(map[string]string)
+	var order []string
+
+	for _, dailySessions := range days {
+		for _, session := range dailySessions.Sessions {
+			if session.Start == nil {
+				continue
+			}
+			if _, seen := parts[session.ID]; !seen {
+				order = append(order, session.ID)
+			}
+			parts[session.ID] = ContinuedPart{Day: dailySessions.Date, Session: session}
+			if session.ContinuationOf != "" {
+				continuedBy[session.ContinuationOf] = session.ID
+			}
+		}
+	}
+
+	var tasks []ContinuedTask
+	for _, id := range order {
+		head := parts[id].Session
+		if _, continues := parts[head.ContinuationOf]; continues || continuedBy[id] == "" {
+			continue // Not the first part of a continued task
+		}
+
+		task := ContinuedTask{Description: head.Start.Description, Project: head.Project}
+		visited := make(map[string]bool)
+		for next := id; next != "" && !visited[next]; next = continuedBy[next] {
+			part, ok := parts[next]
+			if !ok {
+				break
+			}
+			visited[next] = true
+
+			work, interruption, count := part.Session.GetStats()
+			task.Parts = append(task.Parts, part)
+			task.WorkDuration += work
+			task.InterruptionDuration += interruption
+			task.Interruptions += count
+		}
+		tasks = append(tasks, task)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Start().Before(tasks[j].Start()) })
+	return tasks
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLinkContinuations tests following split sessions across days into one task
+func TestLinkContinuations(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	wednesday := tuesday.AddDate(0, 0, 1)
+
+	first := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: monday.Add(22 * time.Hour), Description: "Migration"})
+	first.ID = "sess_1"
+	first.Project = "acme"
+	second := first.SplitAt(tuesday)
+	second.ID = "sess_2"
+	third := second.SplitAt(wednesday)
+	third.ID = "sess_3"
+	third.EndAt(wednesday.Add(time.Hour))
+
+	standalone := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: tuesday.Add(9 * time.Hour), Description: "Review"})
+	standalone.ID = "sess_4"
+	standalone.EndAt(tuesday.Add(10 * time.Hour))
+
+	// Days are stored in any order and continuations may appear before what they continue
+	tasks := LinkContinuations([]*DailySessions{
+		{Date: wednesday, Sessions: []*Session{third}},
+		{Date: monday, Sessions: []*Session{first}},
+		{Date: tuesday, Sessions: []*Session{standalone, second}},
+	})
+
+	assert.Len(t, tasks, 1)
+	task := tasks[0]
+	assert.Equal(t, "Migration", task.Description)
+	assert.Equal(t, "acme", task.Project)
+	assert.Len(t, task.Parts, 3)
+	assert.Equal(t, "sess_1", task.Parts[0].Session.ID)
+	assert.Equal(t, "sess_3", task.Parts[2].Session.ID)
+	assert.True(t, wednesday.Equal(task.Parts[2].Day))
+	assert.Equal(t, 27*time.Hour, task.WorkDuration)
+	assert.False(t, task.Active())
+	assert.True(t, monday.Add(22*time.Hour).Equal(task.Start()))
+
+	assert.True(t, task.Spans(wednesday, wednesday))
+	assert.False(t, task.Spans(wednesday.AddDate(0, 0, 1), wednesday.AddDate(0, 0, 7)))
+
+	// Each day's own session keeps only that day's work
+	work, _, _ := first.GetStats()
+	assert.Equal(t, 2*time.Hour, work)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// InterruptionCost is the estimated cost of the interruptions of one tag, or of all of
+// them, as the work time lost to them and to recovering from them
+type InterruptionCost struct {
+	Tag          InterruptionTag `json:"tag,omitempty" yaml:"tag,omitempty"`
+	Count        int             `json:"count" yaml:"count"`
+	Interruption time.Duration   `json:"interruption" yaml:"interruption"`
+	Recovery     time.Duration   `json:"recovery" yaml:"recovery"`
+	Cost         float64         `json:"cost" yaml:"cost"`
+}
+
+// Lost returns the interruption and recovery time the cost is for
+func (c InterruptionCost) Lost() time.Duration {
+	return c.Interruption + c.Recovery
+}
+
+// InterruptionCosts returns the cost of the interruptions at the hourly rate, costliest
+// tag first, and in total. Each interruption is followed by its recovery time.
+func (s *DetailedStats) InterruptionCosts(hourlyRate float64) ([]InterruptionCost, InterruptionCost) {
+	var total InterruptionCost
+	costs := make([]InterruptionCost, 0, len(s.InterruptionsByTag))
+	for tag, count := range s.InterruptionsByTag {
+		cost := InterruptionCost{
+			Tag:          tag,
+			Count:        count,
+			Interruption: s.InterruptionDurationByTag[tag],
+			Recovery:     s.RecoveryFor(tag),
+		}
+		cost.Cost = cost.Lost().Hours() * hourlyRate
+		costs = append(costs, cost)
+
+		total.Count += cost.Count
+		total.Interruption += cost.Interruption
+		total.Recovery += cost.Recovery
+		total.Cost += cost.Cost
+	}
+
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].Cost != costs[j].Cost {
+			return costs[i].Cost > costs[j].Cost
+		}
+		return costs[i].Tag < costs[j].Tag
+	})
+	return costs, total
+}
+
+// FormatCost formats an amount of money with two decimals and the currency, if any
+func FormatCost(amount float64, currency string) string {
+	if currency == "" {
+		return fmt.Sprintf("%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInterruptionCosts tests interruption and recovery time are priced per tag and in
+// total, costliest tag first
+func TestInterruptionCosts(t *testing.T) {
+	stats := &DetailedStats{
+		Policy:                    StatsPolicy{Recovery: 10 * time.Minute},
+		InterruptionsByTag:        map[InterruptionTag]int{TagCall: 2, TagMeeting: 1},
+		InterruptionDurationByTag: map[InterruptionTag]time.Duration{TagCall: 10 * time.Minute, TagMeeting: 50 * time.Minute},
+	}
+
+	costs, total := stats.InterruptionCosts(60)
+	assert.Len(t, costs, 2)
+	assert.Equal(t, TagMeeting, costs[0].Tag)
+	assert.InDelta(t, 60, costs[0].Cost, 0.001)
+	assert.Equal(t, TagCall, costs[1].Tag)
+	assert.Equal(t, 20*time.Minute, costs[1].Recovery)
+	assert.Equal(t, 30*time.Minute, costs[1].Lost())
+	assert.InDelta(t, 30, costs[1].Cost, 0.001)
+
+	assert.Equal(t, 3, total.Count)
+	assert.Equal(t, 90*time.Minute, total.Lost())
+	assert.InDelta(t, 90, total.Cost, 0.001)
+}
+
+// TestFormatCost tests amounts are shown with the currency when there is one
+func TestFormatCost(t *testing.T) {
+	assert.Equal(t, "12.50", FormatCost(12.5, ""))
+	assert.Equal(t, "1234.57 EUR", FormatCost(1234.567, "EUR"))
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// DayOf returns local midnight of the tracking day t belongs to, in t's location. Times
+// before startHour (0-23) still count towards the previous day, for those working past
+// midnight. Unlike Truncate, this follows the local calendar, so days stay correct for
+// non-UTC time zones and on daylight saving changes.
+func DayOf(t time.Time, startHour int) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if startHour > 0 && startHour < 24 && t.Hour() < startHour {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// DayStartTime returns when the tracking day starting on day's date begins, startHour
+// (0-23) hours after local midnight
+func DayStartTime(day time.Time, startHour int) time.Time {
+	if startHour < 0 || startHour > 23 {
+		startHour = 0
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), startHour, 0, 0, 0, day.Location())
+}
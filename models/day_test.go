@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDayOf tests local day boundaries across time zones, day start hours and DST changes
+func TestDayOf(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	// 00:30 local is still 22:30 UTC of the previous day, where Truncate would land
+	late := time.Date(2025, 6, 10, 0, 30, 0, 0, warsaw)
+	assert.Equal(t, time.Date(2025, 6, 10, 0, 0, 0, 0, warsaw), DayOf(late, 0))
+	assert.Equal(t, "2025-06-09", late.Truncate(24*time.Hour).In(warsaw).Format("2006-01-02"))
+
+	// Before the day start hour the time belongs to the previous day
+	assert.Equal(t, time.Date(2025, 6, 9, 0, 0, 0, 0, warsaw), DayOf(late, 4))
+	assert.Equal(t, time.Date(2025, 6, 10, 0, 0, 0, 0, warsaw), DayOf(late.Add(4*time.Hour), 4))
+
+	// Days stay on local midnight on the 23-hour day of the spring DST change
+	spring := time.Date(2025, 3, 30, 23, 0, 0, 0, warsaw)
+	day := DayOf(spring, 0)
+	assert.Equal(t, time.Date(2025, 3, 30, 0, 0, 0, 0, warsaw), day)
+	assert.Equal(t, 23*time.Hour, day.AddDate(0, 0, 1).Sub(day))
+	assert.Equal(t, time.Date(2025, 3, 29, 0, 0, 0, 0, warsaw), DayOf(time.Date(2025, 3, 30, 3, 30, 0, 0, warsaw), 4))
+
+	// Out of range start hours are ignored
+	assert.Equal(t, time.Date(2025, 6, 10, 0, 0, 0, 0, warsaw), DayOf(late, 24))
+}
+
+// TestDayStartTime tests when a tracking day begins with and without a day start hour
+func TestDayStartTime(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, day, DayStartTime(day, 0))
+	assert.Equal(t, day.Add(4*time.Hour), DayStartTime(day, 4))
+	assert.Equal(t, day, DayStartTime(day, 24))
+}
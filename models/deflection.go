@@ -0,0 +1,49 @@
+package models
+
+// DeflectionCount counts the interruptions that were accepted and the ones deferred
+// without leaving work
+type DeflectionCount struct {
+	Accepted int `json:"accepted" yaml:"accepted"`
+	Deferred int `json:"deferred" yaml:"deferred"`
+}
+
+// Add adds the counts of another period
+func (c *DeflectionCount) Add(other DeflectionCount) {
+	c.Accepted += other.Accepted
+	c.Deferred += other.Deferred
+}
+
+// Total returns the number of interruption attempts
+func (c DeflectionCount) Total() int {
+	return c.Accepted + c.Deferred
+}
+
+// Rate returns the share of interruption attempts that were deferred, from 0 to 1
+func (c DeflectionCount) Rate() float64 {
+	if c.Total() == 0 {
+		return 0
+	}
+	return float64(c.Deferred) / float64(c.Total())
+}
+
+// NewDeferredEntry creates an entry for an interruption deferred with a tag
+func NewDeferredEntry(description string, tag InterruptionTag) *TimeEntry {
+	entry := NewTimeEntry(EntryTypeDeferred, description)
+	entry.Tag = tag
+	return entry
+}
+
+// GetDeflections counts the session's completed interruptions and deferred interruptions
+func (session *Session) GetDeflections() DeflectionCount {
+	_, _, accepted := session.GetStats()
+	return DeflectionCount{Accepted: accepted, Deferred: len(session.Deferred)}
+}
+
+// GetDeflections counts the accepted and deferred interruptions of the day
+func (ds *DailySessions) GetDeflections() DeflectionCount {
+	var count DeflectionCount
+	for _, session := range ds.Sessions {
+		count.Add(session.GetDeflections())
+	}
+	return count
+}
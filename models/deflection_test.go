@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDeflections tests counting accepted and deferred interruptions
+func TestDeflections(t *testing.T) {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	interrupt := &TimeEntry{Type: EntryTypeInterruption, StartTime: start.Add(10 * time.Minute)}
+	back := &TimeEntry{Type: EntryTypeReturn, StartTime: start.Add(20 * time.Minute)}
+
+	day := &DailySessions{Sessions: []*Session{
+		{
+			Start:         &TimeEntry{Type: EntryTypeStart, StartTime: start},
+			End:           &TimeEntry{Type: EntryTypeEnd, StartTime: start.Add(time.Hour)},
+			Interruptions: []*TimeEntry{interrupt, back},
+			Deferred:      []*TimeEntry{NewDeferredEntry("", TagCall), NewDeferredEntry("review", TagOther)},
+		},
+		{
+			Start:    &TimeEntry{Type: EntryTypeStart, StartTime: start.Add(2 * time.Hour)},
+			End:      &TimeEntry{Type: EntryTypeEnd, StartTime: start.Add(3 * time.Hour)},
+			Deferred: []*TimeEntry{NewDeferredEntry("", TagMeeting)},
+		},
+	}}
+
+	count := day.GetDeflections()
+	assert.Equal(t, DeflectionCount{Accepted: 1, Deferred: 3}, count)
+	assert.Equal(t, 4, count.Total())
+	assert.InDelta(t, 0.75, count.Rate(), 0.001)
+	assert.Equal(t, EntryTypeDeferred, day.Sessions[0].Deferred[0].Type)
+
+	assert.Zero(t, DeflectionCount{}.Rate())
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EstimateTolerance is how far the actual time may differ from the estimate, as a share
+// of the estimate, for the estimate to count as accurate
+const EstimateTolerance = 0.2
+
+// ParseEstimate parses an estimate such as "45m" or "1h30m". A plain number is taken as
+// minutes and empty input means no estimate.
+func ParseEstimate(input string) (time.Duration, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, nil
+	}
+
+	if minutes, err := strconv.Atoi(input); err == nil {
+		if minutes < 0 {
+			return 0, fmt.Errorf("estimate must not be negative")
+		}
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	estimate, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, fmt.Errorf("invalid estimate %q, use e.g. 45m or 1h30m", input)
+	}
+	if estimate < 0 {
+		return 0, fmt.Errorf("estimate must not be negative")
+	}
+	return estimate.Round(time.Minute), nil
+}
+
+// EstimateAccuracy aggregates estimated against actual work time of completed sessions
+type EstimateAccuracy struct {
+	Sessions  int           `json:"sessions" yaml:"sessions"`   // Completed sessions with an estimate
+	Estimated time.Duration `json:"estimated" yaml:"estimated"` // Sum of the estimates
+	Actual    time.Duration `json:"actual" yaml:"actual"`       // Sum of the work time of those sessions
+	Accurate  int           `json:"accurate" yaml:"accurate"`   // Sessions within EstimateTolerance of their estimate
+}
+
+// Add records a completed session's estimate and actual work time
+func (a *EstimateAccuracy) Add(estimate, actual time.Duration) {
+	if estimate <= 0 {
+		return
+	}
+
+	a.Sessions++
+	a.Estimated += estimate
+	a.Actual += actual
+
+	if EstimateWithinTolerance(estimate, actual) {
+		a.Accurate++
+	}
+}
+
+// Ratio returns actual over estimated time: above 1 means work took longer than estimated
+func (a *EstimateAccuracy) Ratio() float64 {
+	if a.Estimated == 0 {
+		return 0
+	}
+	return float64(a.Actual) / float64(a.Estimated)
+}
+
+// AccurateShare returns the percentage of estimated sessions within the tolerance
+func (a *EstimateAccuracy) AccurateShare() float64 {
+	if a.Sessions == 0 {
+		return 0
+	}
+	return float64(a.Accurate) / float64(a.Sessions) * 100
+}
+
+// EstimateWithinTolerance reports whether actual is within EstimateTolerance of estimate
+func EstimateWithinTolerance(estimate, actual time.Duration) bool {
+	diff := actual - estimate
+	if diff < 0 {
+		diff = -diff
+	}
+	return float64(diff) <= float64(estimate)*EstimateTolerance
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseEstimate tests durations, plain minutes and invalid input
+func TestParseEstimate(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":      0,
+		"45":    45 * time.Minute,
+		"45m":   45 * time.Minute,
+		"1h30m": 90 * time.Minute,
+		" 2h ":  2 * time.Hour,
+	}
+	for input, expected := range cases {
+		estimate, err := ParseEstimate(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, estimate, input)
+	}
+
+	for _, input := range []string{"soon", "-5", "-1h"} {
+		_, err := ParseEstimate(input)
+		assert.Error(t, err, input)
+	}
+}
+
+// TestEstimateAccuracy tests aggregating estimates against actual work time
+func TestEstimateAccuracy(t *testing.T) {
+	var accuracy EstimateAccuracy
+	accuracy.Add(time.Hour, 65*time.Minute) // Within tolerance
+	accuracy.Add(time.Hour, 2*time.Hour)    // Took twice as long
+	accuracy.Add(time.Hour, 30*time.Minute) // Done early
+	accuracy.Add(0, 3*time.Hour)            // No estimate, ignored
+
+	assert.Equal(t, 3, accuracy.Sessions)
+	assert.Equal(t, 3*time.Hour, accuracy.Estimated)
+	assert.Equal(t, 215*time.Minute, accuracy.Actual)
+	assert.Equal(t, 1, accuracy.Accurate)
+	assert.InDelta(t, 215.0/180.0, accuracy.Ratio(), 0.001)
+	assert.InDelta(t, 100.0/3, accuracy.AccurateShare(), 0.001)
+
+	assert.Equal(t, 0.0, (&EstimateAccuracy{}).Ratio())
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// LongestFocusDay returns the day with the longest focus block and that block, the
+// earliest day on ties
+func (s *DetailedStats) LongestFocusDay() (date string, block time.Duration) {
+	for dateStr, longest := range s.DailyLongestFocus {
+		if longest > block || (longest == block && longest > 0 && dateStr < date) {
+			date, block = dateStr, longest
+		}
+	}
+	return date, block
+}
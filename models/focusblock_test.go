@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLongestFocusDay tests the day with the longest block is found, the earliest on ties
+func TestLongestFocusDay(t *testing.T) {
+	stats := &DetailedStats{DailyLongestFocus: map[string]time.Duration{
+		"2025-03-05": time.Hour,
+		"2025-03-04": 20 * time.Minute,
+		"2025-03-03": time.Hour,
+	}}
+
+	date, block := stats.LongestFocusDay()
+	assert.Equal(t, "2025-03-03", date)
+	assert.Equal(t, time.Hour, block)
+
+	date, block = (&DetailedStats{}).LongestFocusDay()
+	assert.Equal(t, "", date)
+	assert.Equal(t, time.Duration(0), block)
+}
@@ -0,0 +1,121 @@
+package models
+
+import "time"
+
+// FocusWindow is a stretch of a session declared as do-not-disturb, when interruptions
+// were meant to be blocked
+type FocusWindow struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"` // Omitted while focus mode is on
+}
+
+// FocusModeOn returns whether the session's last focus window is still open
+func (session *Session) FocusModeOn() bool {
+	return len(session.FocusWindows) > 0 && session.FocusWindows[len(session.FocusWindows)-1].End == nil
+}
+
+// StartFocusMode opens a focus window at t, returns false when one is already open
+func (session *Session) StartFocusMode(t time.Time) bool {
+	if session.FocusModeOn() {
+		return false
+	}
+	session.FocusWindows = append(session.FocusWindows, &FocusWindow{Start: t})
+	return true
+}
+
+// StopFocusMode closes the open focus window at t, returns false when none is open
+func (session *Session) StopFocusMode(t time.Time) bool {
+	if !session.FocusModeOn() {
+		return false
+	}
+	session.FocusWindows[len(session.FocusWindows)-1].End = &t
+	return true
+}
+
+// focusWindowEnd returns when the window ended. A window left open lasts until the
+// session ended, or now.
+func (session *Session) focusWindowEnd(window *FocusWindow, now time.Time) time.Time {
+	if window.End != nil {
+		return *window.End
+	}
+	if session.End != nil {
+		return session.End.StartTime
+	}
+	return now
+}
+
+// InFocusMode returns whether t falls within one of the session's focus windows
+func (session *Session) InFocusMode(t time.Time) bool {
+	for _, window := range session.FocusWindows {
+		if t.Before(window.Start) {
+			continue
+		}
+		if window.End == nil {
+			if session.End == nil || t.Before(session.End.StartTime) {
+				return true
+			}
+		} else if t.Before(*window.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// FocusModeTime returns how long the session was in focus mode
+func (session *Session) FocusModeTime(now time.Time) time.Duration {
+	var total time.Duration
+	for _, window := range session.FocusWindows {
+		if end := session.focusWindowEnd(window, now); end.After(window.Start) {
+			total += end.Sub(window.Start)
+		}
+	}
+	return total
+}
+
+// DNDSummary compares the interruptions that got through focus mode with the ones
+// outside it, to tell whether blocking them works
+type DNDSummary struct {
+	Time               time.Duration           `json:"time" yaml:"time"`                               // Time spent in focus mode
+	Interruptions      int                     `json:"interruptions" yaml:"interruptions"`             // Interruptions started despite focus mode
+	ByTag              map[InterruptionTag]int `json:"by_tag,omitempty" yaml:"by_tag,omitempty"`       // Interruptions despite focus mode by tag
+	OtherTime          time.Duration           `json:"other_time" yaml:"other_time"`                   // Session time outside focus mode
+	OtherInterruptions int                     `json:"other_interruptions" yaml:"other_interruptions"` // Interruptions started outside focus mode
+}
+
+// AddSession adds the session's time in and out of focus mode and its counted
+// interruptions, as split by a StatsPolicy breakdown
+func (s *DNDSummary) AddSession(session *Session, breakdown SessionBreakdown, now time.Time) {
+	focus := session.FocusModeTime(now)
+	s.Time += focus
+	if other := breakdown.Work + breakdown.Interruption + breakdown.Break - focus; other > 0 {
+		s.OtherTime += other
+	}
+
+	for _, interruption := range breakdown.Interruptions {
+		if !session.InFocusMode(interruption.Start) {
+			s.OtherInterruptions++
+			continue
+		}
+		if s.ByTag == nil {
+			s.ByTag = make(map[InterruptionTag]int)
+		}
+		s.Interruptions++
+		s.ByTag[interruption.Tag]++
+	}
+}
+
+// RatePerHour returns the interruptions despite focus mode per hour in focus mode
+func (s DNDSummary) RatePerHour() float64 {
+	if s.Time <= 0 {
+		return 0
+	}
+	return float64(s.Interruptions) / s.Time.Hours()
+}
+
+// OtherRatePerHour returns the interruptions per hour of session time outside focus mode
+func (s DNDSummary) OtherRatePerHour() float64 {
+	if s.OtherTime <= 0 {
+		return 0
+	}
+	return float64(s.OtherInterruptions) / s.OtherTime.Hours()
+}
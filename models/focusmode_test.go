@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFocusModeWindows tests focus mode toggles and a window left open lasts until the
+// session ends
+func TestFocusModeWindows(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+
+	assert.True(t, session.StartFocusMode(day.Add(9*time.Hour)))
+	assert.False(t, session.StartFocusMode(day.Add(9*time.Hour+time.Minute)))
+	assert.True(t, session.StopFocusMode(day.Add(10*time.Hour)))
+	assert.False(t, session.StopFocusMode(day.Add(10*time.Hour)))
+	assert.True(t, session.StartFocusMode(day.Add(11*time.Hour)))
+	assert.True(t, session.FocusModeOn())
+
+	assert.True(t, session.InFocusMode(day.Add(9*time.Hour+30*time.Minute)))
+	assert.False(t, session.InFocusMode(day.Add(10*time.Hour+30*time.Minute)))
+	assert.True(t, session.InFocusMode(day.Add(15*time.Hour)))
+	assert.Equal(t, 3*time.Hour, session.FocusModeTime(day.Add(13*time.Hour)))
+
+	session.EndAt(day.Add(12 * time.Hour))
+	assert.False(t, session.FocusModeOn())
+	assert.False(t, session.InFocusMode(day.Add(15*time.Hour)))
+	assert.Equal(t, 2*time.Hour, session.FocusModeTime(day.Add(13*time.Hour)))
+}
+
+// TestDNDSummary tests interruptions started in focus mode are counted apart from the
+// ones outside it
+func TestDNDSummary(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	interruptions := []*TimeEntry{
+		{Type: EntryTypeInterruption, Tag: TagCall, StartTime: day.Add(9*time.Hour + 20*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(9*time.Hour + 30*time.Minute)},
+		{Type: EntryTypeInterruption, StartTime: day.Add(10*time.Hour + 15*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(10*time.Hour + 30*time.Minute)},
+		{Type: EntryTypeInterruption, Tag: TagMeeting, StartTime: day.Add(10*time.Hour + 45*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(11 * time.Hour)},
+	}
+	session.Interruptions = interruptions
+	session.SubSessions[0].Interruptions = interruptions
+	session.StartFocusMode(day.Add(9 * time.Hour))
+	session.StopFocusMode(day.Add(10 * time.Hour))
+	session.EndAt(day.Add(11 * time.Hour))
+
+	now := day.Add(12 * time.Hour)
+	var summary DNDSummary
+	summary.AddSession(session, StatsPolicy{}.Breakdown(session, now), now)
+	assert.Equal(t, time.Hour, summary.Time)
+	assert.Equal(t, time.Hour, summary.OtherTime)
+	assert.Equal(t, 1, summary.Interruptions)
+	assert.Equal(t, 2, summary.OtherInterruptions)
+	assert.Equal(t, map[InterruptionTag]int{TagCall: 1}, summary.ByTag)
+	assert.InDelta(t, 1.0, summary.RatePerHour(), 0.001)
+	assert.InDelta(t, 2.0, summary.OtherRatePerHour(), 0.001)
+
+	// Flagged in the interruption log as well
+	records := session.InterruptionRecords(day, now)
+	assert.True(t, records[0].DespiteDND)
+	assert.False(t, records[1].DespiteDND)
+
+	assert.Equal(t, 0.0, DNDSummary{}.RatePerHour())
+}
+
+// TestSplitAtKeepsFocusMode tests focus mode carries over to the continuation of a split
+func TestSplitAtKeepsFocusMode(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(22 * time.Hour), Description: "Deploy"})
+	session.StartFocusMode(day.Add(23 * time.Hour))
+
+	continuation := session.SplitAt(day.AddDate(0, 0, 1))
+	assert.False(t, session.FocusModeOn())
+	assert.Equal(t, time.Hour, session.FocusModeTime(day.AddDate(0, 0, 1)))
+	assert.True(t, continuation.FocusModeOn())
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FocusGoals is the focused work expected per day, optionally different by weekday
+type FocusGoals struct {
+	Default  time.Duration
+	Weekdays map[time.Weekday]time.Duration // Overrides Default, zero means no goal that day
+}
+
+// NewFocusGoals builds goals from minutes per day and per weekday name ("friday" or "fri").
+// A default of zero uses DefaultFocusGoal.
+func NewFocusGoals(dailyMinutes int, weekdayMinutes map[string]int) (FocusGoals, error) {
+	goals := FocusGoals{Default: time.Duration(dailyMinutes) * time.Minute}
+	if goals.Default <= 0 {
+		goals.Default = DefaultFocusGoal
+	}
+
+	for name, minutes := range weekdayMinutes {
+		weekday, err := ParseWeekday(name)
+		if err != nil {
+			return FocusGoals{}, err
+		}
+		if minutes < 0 {
+			return FocusGoals{}, fmt.Errorf("focus goal for %s must not be negative", name)
+		}
+		if goals.Weekdays == nil {
+			goals.Weekdays = make(map[time.Weekday]time.Duration)
+		}
+		goals.Weekdays[weekday] = time.Duration(minutes) * time.Minute
+	}
+
+	return goals, nil
+}
+
+// ParseWeekday parses a full or three-letter English weekday name, ignoring case
+func ParseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday %q", name)
+}
+
+// Daily returns the goal for weekdays without their own goal
+func (g FocusGoals) Daily() time.Duration {
+	if g.Default <= 0 {
+		return DefaultFocusGoal
+	}
+	return g.Default
+}
+
+// For returns the goal for the weekday of day
+func (g FocusGoals) For(day time.Time) time.Duration {
+	if goal, ok := g.Weekdays[day.Weekday()]; ok {
+		return goal
+	}
+	return g.Daily()
+}
+
+// String describes the goals, e.g. "4h, Fri 2h, Sat none"
+func (g FocusGoals) String() string {
+	parts := []string{formatGoal(g.Daily())}
+
+	weekdays := make([]time.Weekday, 0, len(g.Weekdays))
+	for weekday := range g.Weekdays {
+		weekdays = append(weekdays, weekday)
+	}
+	// Monday first, Sunday last
+	sort.Slice(weekdays, func(i, j int) bool { return (weekdays[i]+6)%7 < (weekdays[j]+6)%7 })
+
+	for _, weekday := range weekdays {
+		parts = append(parts, fmt.Sprintf("%s %s", weekday.String()[:3], formatGoal(g.Weekdays[weekday])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatGoal formats a goal in hours and minutes, or "none" for no goal
+func formatGoal(goal time.Duration) string {
+	if goal <= 0 {
+		return "none"
+	}
+	hours, minutes := int(goal.Hours()), int(goal.Minutes())%60
+	switch {
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFocusGoals tests per-weekday goals overriding the daily goal
+func TestFocusGoals(t *testing.T) {
+	goals, err := NewFocusGoals(300, map[string]int{"Friday": 120, "sat": 0})
+	assert.NoError(t, err)
+
+	monday := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 5*time.Hour, goals.For(monday))
+	assert.Equal(t, 2*time.Hour, goals.For(monday.AddDate(0, 0, 4)))
+	assert.Equal(t, time.Duration(0), goals.For(monday.AddDate(0, 0, 5)))
+	assert.Equal(t, "5h, Fri 2h, Sat none", goals.String())
+
+	defaults, err := NewFocusGoals(0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultFocusGoal, defaults.For(monday))
+
+	_, err = NewFocusGoals(0, map[string]int{"someday": 60})
+	assert.Error(t, err)
+	_, err = NewFocusGoals(0, map[string]int{"monday": -1})
+	assert.Error(t, err)
+}
+
+// TestComputeRecordsWeekdayGoals tests streaks using the goal of each weekday
+func TestComputeRecordsWeekdayGoals(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	day := func(offset int, work time.Duration) DaySummary {
+		return DaySummary{Date: monday.AddDate(0, 0, offset), Sessions: 1, Work: work}
+	}
+
+	goals, err := NewFocusGoals(240, map[string]int{"friday": 120, "saturday": 0})
+	assert.NoError(t, err)
+
+	records := ComputeRecords([]DaySummary{
+		day(3, 4*time.Hour),
+		day(4, 2*time.Hour),    // Meeting day with a lower goal
+		day(5, 30*time.Minute), // No goal on Saturday, doesn't break the streak
+		day(7, 4*time.Hour),
+	}, goals, monday.AddDate(0, 0, 7))
+
+	assert.Equal(t, 3, records.CurrentStreak)
+	assert.Equal(t, 4*time.Hour, records.FocusGoal)
+	assert.Equal(t, goals.String(), records.Goals().String())
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// MinHourlyWorkForRate is the least work in an hour of day for its interruption rate to be
+// reported, so a few minutes of work with one interruption don't look like a bad hour
+const MinHourlyWorkForRate = 15 * time.Minute
+
+// WorkByHour splits the work time of the session into the hours of day it was spent in,
+// without the time away in interruptions. An interruption without return lasts until the
+// end of its work period, or now.
+func WorkByHour(session *Session, now time.Time) map[int]time.Duration {
+	hours := make(map[int]time.Duration)
+	for _, period := range session.workPeriods(now) {
+		addHourlySpan(hours, period.start, period.end)
+	}
+	return hours
+}
+
+// addHourlySpan adds the time between from and to to the hours of day it covers
+func addHourlySpan(hours map[int]time.Duration, from, to time.Time) {
+	for from.Before(to) {
+		next := time.Date(from.Year(), from.Month(), from.Day(), from.Hour()+1, 0, 0, 0, from.Location())
+		if next.After(to) {
+			next = to
+		}
+		hours[from.Hour()] += next.Sub(from)
+		from = next
+	}
+}
+
+// HourlyInterruptionRate returns the interruptions per hour of work for each hour of day
+// with at least MinHourlyWorkForRate of work
+func (s *DetailedStats) HourlyInterruptionRate() map[int]float64 {
+	rates := make(map[int]float64)
+	for hour, work := range s.HourlyWorkTime {
+		if work < MinHourlyWorkForRate {
+			continue
+		}
+		rates[hour] = float64(s.HourlyInterruptions[hour]) / work.Hours()
+	}
+	return rates
+}
+
+// InterruptionProbability returns the probability of at least one interruption during an
+// hour of work at the given rate of interruptions per hour, taking interruptions as
+// arriving independently of each other
+func InterruptionProbability(rate float64) float64 {
+	if rate <= 0 {
+		return 0
+	}
+	return 1 - math.Exp(-rate)
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWorkByHour tests work is split at the hour boundaries without the time away in
+// interruptions
+func TestWorkByHour(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9*time.Hour + 30*time.Minute)})
+	interruptions := []*TimeEntry{
+		{Type: EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 50*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(10*time.Hour + 20*time.Minute)},
+		{Type: EntryTypeInterruption, StartTime: day.Add(11*time.Hour + 10*time.Minute)},
+	}
+	session.Interruptions = interruptions
+	session.SubSessions[0].Interruptions = interruptions
+
+	hours := WorkByHour(session, day.Add(12*time.Hour))
+	assert.Equal(t, map[int]time.Duration{
+		9:  20 * time.Minute,
+		10: 40 * time.Minute,
+		11: 10 * time.Minute,
+	}, hours)
+}
+
+// TestHourlyInterruptionRate tests the rate is per hour of work and hours with too little
+// work are left out
+func TestHourlyInterruptionRate(t *testing.T) {
+	stats := &DetailedStats{
+		HourlyWorkTime:      map[int]time.Duration{9: 2 * time.Hour, 10: 30 * time.Minute, 11: 5 * time.Minute},
+		HourlyInterruptions: map[int]int{9: 1, 10: 2, 11: 1},
+	}
+
+	assert.Equal(t, map[int]float64{9: 0.5, 10: 4}, stats.HourlyInterruptionRate())
+	assert.Equal(t, 0.0, InterruptionProbability(0))
+	assert.InDelta(t, 0.6321, InterruptionProbability(1), 0.0001)
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// InterruptionRecord is a single interruption with the session it broke into
+type InterruptionRecord struct {
+	Day         time.Time       `json:"day"`
+	Task        string          `json:"task"` // Description of the interrupted session
+	Description string          `json:"description"`
+	Tag         InterruptionTag `json:"tag"`
+	Start       time.Time       `json:"start"`
+	Duration    time.Duration   `json:"duration"`
+	Ongoing     bool            `json:"ongoing"`               // No return recorded yet, measured up to now
+	DespiteDND  bool            `json:"despite_dnd,omitempty"` // Started while focus mode was on
+}
+
+// InterruptionGroup holds the interruptions of one tag, oldest first
+type InterruptionGroup struct {
+	Tag           InterruptionTag      `json:"tag"`
+	TotalDuration time.Duration        `json:"total_duration"`
+	Interruptions []InterruptionRecord `json:"interruptions"`
+}
+
+// InterruptionRecords lists the session's interruptions with their durations. Deferred
+// interruptions are left out as they never took time away from work.
+func (session *Session) InterruptionRecords(day, now time.Time) []InterruptionRecord {
+	task := ""
+	if session.Start != nil {
+		task = session.Start.Description
+	}
+
+	var records []InterruptionRecord
+	for i := 0; i < len(session.Interruptions); i += 2 {
+		entry := session.Interruptions[i]
+		tag := entry.Tag
+		if tag == "" {
+			tag = TagOther
+		}
+
+		record := InterruptionRecord{
+			Day:         day,
+			Task:        task,
+			Description: entry.Description,
+			Tag:         tag,
+			Start:       entry.StartTime,
+			DespiteDND:  session.InFocusMode(entry.StartTime),
+		}
+		if i+1 < len(session.Interruptions) {
+			record.Duration = session.Interruptions[i+1].StartTime.Sub(entry.StartTime)
+		} else {
+			record.Duration = now.Sub(entry.StartTime)
+			record.Ongoing = true
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// GroupInterruptionsByTag groups interruptions by tag, the tag that took the most time
+// first. Interruptions within a group are sorted oldest first.
+func GroupInterruptionsByTag(records []InterruptionRecord) []InterruptionGroup {
+	groups := make(map[InterruptionTag]*InterruptionGroup)
+	for _, record := range records {
+		group, exists := groups[record.Tag]
+		if !exists {
+			group = &InterruptionGroup{Tag: record.Tag}
+			groups[record.Tag] = group
+		}
+		group.TotalDuration += record.Duration
+		group.Interruptions = append(group.Interruptions, record)
+	}
+
+	result := make([]InterruptionGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.SliceStable(group.Interruptions, func(i, j int) bool {
+			return group.Interruptions[i].Start.Before(group.Interruptions[j].Start)
+		})
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalDuration != result[j].TotalDuration {
+			return result[i].TotalDuration > result[j].TotalDuration
+		}
+		return result[i].Tag < result[j].Tag
+	})
+
+	return result
+}
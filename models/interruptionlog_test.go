@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInterruptionRecords tests listing a session's interruptions and grouping them by tag
+func TestInterruptionRecords(t *testing.T) {
+	day := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return day.Add(9*time.Hour + time.Duration(minutes)*time.Minute) }
+	interruption := func(minutes int, description string, tag InterruptionTag) *TimeEntry {
+		return &TimeEntry{Type: EntryTypeInterruption, StartTime: at(minutes), Description: description, Tag: tag}
+	}
+	back := func(minutes int) *TimeEntry { return &TimeEntry{Type: EntryTypeReturn, StartTime: at(minutes)} }
+
+	session := &Session{
+		Start: &TimeEntry{Type: EntryTypeStart, StartTime: at(0), Description: "Release notes"},
+		Interruptions: []*TimeEntry{
+			interruption(10, "Support call", TagCall), back(20),
+			interruption(30, "Standup", TagMeeting), back(45),
+			interruption(50, "Courier", ""), back(55),
+			interruption(60, "Vendor call", TagCall),
+		},
+		Deferred: []*TimeEntry{{Type: EntryTypeDeferred, StartTime: at(5), Description: "Later", Tag: TagCall}},
+	}
+
+	records := session.InterruptionRecords(day, at(70))
+	if assert.Len(t, records, 4) {
+		assert.Equal(t, "Release notes", records[0].Task)
+		assert.Equal(t, 10*time.Minute, records[0].Duration)
+		assert.Equal(t, TagOther, records[2].Tag)
+		assert.True(t, records[3].Ongoing)
+		assert.Equal(t, 10*time.Minute, records[3].Duration)
+	}
+
+	groups := GroupInterruptionsByTag(records)
+	if assert.Len(t, groups, 3) {
+		// Calls took 20 minutes, the meeting 15 and the untagged interruption 5
+		assert.Equal(t, TagCall, groups[0].Tag)
+		assert.Equal(t, 20*time.Minute, groups[0].TotalDuration)
+		assert.Equal(t, []string{"Support call", "Vendor call"}, []string{groups[0].Interruptions[0].Description, groups[0].Interruptions[1].Description})
+		assert.Equal(t, TagMeeting, groups[1].Tag)
+		assert.Equal(t, TagOther, groups[2].Tag)
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// githubIssuePattern matches GitHub style references such as owner/repo#123
+	githubIssuePattern = regexp.MustCompile(`\b([A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+#[0-9]+)\b`)
+	// jiraIssuePattern matches Jira style issue keys such as PROJ-123
+	jiraIssuePattern = regexp.MustCompile(`\b([A-Z][A-Z0-9]+-[0-9]+)\b`)
+)
+
+// IssueStats contains aggregated work statistics for a single linked issue
+type IssueStats struct {
+	Key           string
+	Sessions      int
+	WorkDuration  time.Duration
+	Interruptions int
+}
+
+// ExtractIssueKeys returns the issue references found in a description,
+// GitHub references first followed by Jira keys, without duplicates
+func ExtractIssueKeys(description string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+
+	for _, match := range githubIssuePattern.FindAllString(description, -1) {
+		if !seen[match] {
+			seen[match] = true
+			keys = append(keys, match)
+		}
+	}
+
+	for _, match := range jiraIssuePattern.FindAllString(description, -1) {
+		if !seen[match] {
+			seen[match] = true
+			keys = append(keys, match)
+		}
+	}
+
+	return keys
+}
+
+// JiraProject returns the project of a Jira key, PROJ for PROJ-123, and an empty string
+// for GitHub references
+func JiraProject(key string) string {
+	if IsGitHubIssueKey(key) {
+		return ""
+	}
+	project, _, _ := strings.Cut(key, "-")
+	return project
+}
+
+// IsGitHubIssueKey reports whether the key is a GitHub owner/repo#number reference
+func IsGitHubIssueKey(key string) bool {
+	return githubIssuePattern.MatchString(key)
+}
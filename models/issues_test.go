@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExtractIssueKeys tests finding issue references in descriptions
+func TestExtractIssueKeys(t *testing.T) {
+	testCases := []struct {
+		name        string
+		description string
+		expected    []string
+	}{
+		{
+			name:        "No references",
+			description: "Writing documentation",
+			expected:    nil,
+		},
+		{
+			name:        "Jira key",
+			description: "Fixing PROJ-123 login bug",
+			expected:    []string{"PROJ-123"},
+		},
+		{
+			name:        "GitHub reference",
+			description: "Review lukaszraczylo/interruption-tracker#42",
+			expected:    []string{"lukaszraczylo/interruption-tracker#42"},
+		},
+		{
+			name:        "Mixed and duplicated references",
+			description: "ABC-1 and owner/repo#7, then ABC-1 again",
+			expected:    []string{"owner/repo#7", "ABC-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ExtractIssueKeys(tc.description))
+		})
+	}
+
+	assert.True(t, IsGitHubIssueKey("owner/repo#7"))
+	assert.False(t, IsGitHubIssueKey("ABC-1"))
+	assert.Equal(t, "ABC", JiraProject("ABC-1"))
+	assert.Empty(t, JiraProject("owner/repo-x#7"))
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// JournalEventType is the kind of change recorded in the journal
+type JournalEventType string
+
+const (
+	// JournalStart records a new session
+	JournalStart JournalEventType = "start"
+	// JournalEnd records a session being ended
+	JournalEnd JournalEventType = "end"
+	// JournalInterrupt records an interruption of a session
+	JournalInterrupt JournalEventType = "interrupt"
+	// JournalReturn records a return from an interruption
+	JournalReturn JournalEventType = "return"
+	// JournalDefer records an interruption deferred without leaving work
+	JournalDefer JournalEventType = "defer"
+	// JournalRefocus records the end of the recovery window after a return
+	JournalRefocus JournalEventType = "refocus"
+	// JournalResume records a completed session being resumed
+	JournalResume JournalEventType = "resume"
+	// JournalFocusMode records focus mode being turned on or off
+	JournalFocusMode JournalEventType = "focus_mode"
+	// JournalEdit records a manual change to a session
+	JournalEdit JournalEventType = "edit"
+	// JournalDelete records a session being deleted
+	JournalDelete JournalEventType = "delete"
+)
+
+// JournalEvent is a single line in the append-only event journal
+type JournalEvent struct {
+	Time      time.Time        `json:"time"`
+	Type      JournalEventType `json:"type"`
+	Date      string           `json:"date"` // Day file the session is stored in (YYYY-MM-DD)
+	SessionID string           `json:"session_id"`
+	Entry     *TimeEntry       `json:"entry,omitempty"`   // Entry that triggered the event, if any
+	Session   *Session         `json:"session,omitempty"` // Session state after the event, nil for deletes
+}
+
+// NewJournalEvent creates a journal event for a session stored under the given day
+func NewJournalEvent(eventType JournalEventType, date time.Time, session *Session, entry *TimeEntry) *JournalEvent {
+	event := &JournalEvent{
+		Time:  time.Now(),
+		Type:  eventType,
+		Date:  date.Format("2006-01-02"),
+		Entry: entry,
+	}
+
+	if session != nil {
+		event.SessionID = session.ID
+		if eventType != JournalDelete {
+			event.Session = session
+		}
+	}
+
+	return event
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Meeting is a meeting declared ahead of time. While it runs, the active session is
+// interrupted with a meeting interruption named after it, returning when it ends.
+type Meeting struct {
+	Title    string    `json:"title"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Recorded bool      `json:"recorded,omitempty"` // The interruption was recorded, so it is not recorded again
+}
+
+var (
+	meetingRangeFirstPattern = regexp.MustCompile(`(?i)^` + rangePattern + `\s+(.+)$`)
+	meetingRangeLastPattern  = regexp.MustCompile(`(?i)^(.+?)\s+` + rangePattern + `$`)
+)
+
+// ParseMeetings parses meetings such as "9:30-10 Standup; 14:00-15:00 Planning" for the
+// tracking day beginning at dayStart, one per line or separated by semicolons. The time
+// range may also follow the title. Meetings are returned in start order.
+func ParseMeetings(text string, dayStart time.Time) ([]*Meeting, error) {
+	var meetings []*Meeting
+	for _, line := range strings.FieldsFunc(text, func(r rune) bool { return r == ';' || r == '\n' }) {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			continue
+		}
+
+		var title, from, to string
+		if match := meetingRangeFirstPattern.FindStringSubmatch(line); match != nil {
+			from, to, title = match[1], match[2], match[3]
+		} else if match := meetingRangeLastPattern.FindStringSubmatch(line); match != nil {
+			title, from, to = match[1], match[2], match[3]
+		} else {
+			return nil, fmt.Errorf("could not understand %q, try e.g. \"9:30-10:00 Standup\"", line)
+		}
+
+		start, end, err := resolveClockRange(dayStart, from, to)
+		if err != nil {
+			return nil, err
+		}
+		meetings = append(meetings, &Meeting{Title: title, Start: start, End: end})
+	}
+	if len(meetings) == 0 {
+		return nil, fmt.Errorf("no meetings given")
+	}
+
+	sortMeetings(meetings)
+	return meetings, nil
+}
+
+// sortMeetings orders meetings by start time
+func sortMeetings(meetings []*Meeting) {
+	sort.SliceStable(meetings, func(i, j int) bool { return meetings[i].Start.Before(meetings[j].Start) })
+}
+
+// Span formats the time range of the meeting, e.g. "09:30-10:00"
+func (m *Meeting) Span() string {
+	return m.Start.Format("15:04") + "-" + m.End.Format("15:04")
+}
+
+// AddMeetings adds meetings to the day, keeping them in start order. Meetings may not
+// overlap each other, as a session can only be in one interruption at a time.
+func (ds *DailySessions) AddMeetings(meetings []*Meeting) error {
+	all := append(append([]*Meeting{}, ds.Meetings...), meetings...)
+	sortMeetings(all)
+	for i := 1; i < len(all); i++ {
+		if all[i].Start.Before(all[i-1].End) {
+			return fmt.Errorf("%s %s overlaps %s %s", all[i].Title, all[i].Span(), all[i-1].Title, all[i-1].Span())
+		}
+	}
+	ds.Meetings = all
+	return nil
+}
+
+// DueMeeting returns the meeting to interrupt the session with at now: one running, not
+// recorded yet, while the session has been running since before it started and is not
+// interrupted already
+func (ds *DailySessions) DueMeeting(session *Session, now time.Time) (*Meeting, bool) {
+	if session == nil || session.End != nil || session.Start == nil || len(session.Interruptions)%2 != 0 {
+		return nil, false
+	}
+	for _, meeting := range ds.Meetings {
+		if meeting.Recorded || now.Before(meeting.Start) || !now.Before(meeting.End) {
+			continue
+		}
+		if session.Start.StartTime.After(meeting.Start) {
+			return nil, false // Started during the meeting, so presumably not in it
+		}
+		return meeting, true
+	}
+	return nil, false
+}
+
+// Interruption returns the interruption recording the meeting in the session. It starts
+// with the meeting, or at the latest activity of the session when that came later.
+func (m *Meeting) Interruption(session *Session) *TimeEntry {
+	entry := NewInterruptionEntry(m.Title, TagMeeting)
+	entry.StartTime = m.Start
+	if latest := session.LastActivity(); latest.After(entry.StartTime) {
+		entry.StartTime = latest
+	}
+	return entry
+}
+
+// OverMeeting returns the recorded meeting that has ended while the session is still
+// in its interruption, so work resumes at its end
+func (ds *DailySessions) OverMeeting(session *Session, now time.Time) (*Meeting, bool) {
+	if session == nil || session.End != nil || len(session.Interruptions)%2 == 0 {
+		return nil, false
+	}
+	open := session.Interruptions[len(session.Interruptions)-1]
+	for _, meeting := range ds.Meetings {
+		if !meeting.Recorded || now.Before(meeting.End) {
+			continue
+		}
+		if open.Tag == TagMeeting && open.Description == meeting.Title &&
+			!open.StartTime.Before(meeting.Start) && open.StartTime.Before(meeting.End) {
+			return meeting, true
+		}
+	}
+	return nil, false
+}
+
+// mergeMeetings returns the union of the meetings of two versions of the same day,
+// matching meetings by title and start. A meeting recorded in either version stays recorded.
+func mergeMeetings(ours, theirs []*Meeting) []*Meeting {
+	var merged []*Meeting
+	index := make(map[string]int)
+	for _, version := range [][]*Meeting{ours, theirs} {
+		for _, meeting := range version {
+			key := meeting.Title + "@" + meeting.Start.Format(time.RFC3339)
+			if i, exists := index[key]; exists {
+				if meeting.Recorded && !merged[i].Recorded {
+					merged[i] = meeting
+				}
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, meeting)
+		}
+	}
+	sortMeetings(merged)
+	return merged
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseMeetings tests declaring meetings with their time ranges
+func TestParseMeetings(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	meetings, err := ParseMeetings("14:00-15:00 Sprint planning; 9:30-10 Standup\nOne on one with Bob 4-4:30pm", day)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []*Meeting{
+		{Title: "Standup", Start: at(9, 30), End: at(10, 0)},
+		{Title: "Sprint planning", Start: at(14, 0), End: at(15, 0)},
+		{Title: "One on one with Bob", Start: at(16, 0), End: at(16, 30)},
+	}, meetings)
+	assert.Equal(t, "09:30-10:00", meetings[0].Span())
+
+	_, err = ParseMeetings("Standup", day)
+	assert.ErrorContains(t, err, "could not understand")
+	_, err = ParseMeetings(" ; ", day)
+	assert.ErrorContains(t, err, "no meetings")
+	_, err = ParseMeetings("15:00-14:00 Retro", day)
+	assert.ErrorContains(t, err, "ends before it starts")
+}
+
+// TestAddMeetings tests that declared meetings stay in order and do not overlap
+func TestAddMeetings(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	dailySessions := &DailySessions{Date: day}
+
+	meetings, _ := ParseMeetings("14:00-15:00 Planning", day)
+	assert.NoError(t, dailySessions.AddMeetings(meetings))
+	meetings, _ = ParseMeetings("9:30-10:00 Standup", day)
+	assert.NoError(t, dailySessions.AddMeetings(meetings))
+	assert.Equal(t, "Standup", dailySessions.Meetings[0].Title)
+
+	meetings, _ = ParseMeetings("14:30-15:30 Retro", day)
+	assert.ErrorContains(t, dailySessions.AddMeetings(meetings), "Retro 14:30-15:30 overlaps Planning 14:00-15:00")
+	assert.Len(t, dailySessions.Meetings, 2)
+}
+
+// TestMeetingInterruption tests interrupting a session for a declared meeting and
+// returning when it ends
+func TestMeetingInterruption(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	dailySessions := &DailySessions{Date: day}
+	meetings, _ := ParseMeetings("9:30-10:00 Standup; 11-12 Late start", day)
+	dailySessions.AddMeetings(meetings)
+
+	start := NewTimeEntry(EntryTypeStart, "Work")
+	start.StartTime = at(9, 0)
+	session := NewSession(start)
+
+	_, due := dailySessions.DueMeeting(session, at(9, 29))
+	assert.False(t, due)
+	meeting, due := dailySessions.DueMeeting(session, at(9, 31))
+	if !assert.True(t, due) {
+		return
+	}
+	assert.Equal(t, "Standup", meeting.Title)
+
+	entry := meeting.Interruption(session)
+	assert.Equal(t, TagMeeting, entry.Tag)
+	assert.Equal(t, "Standup", entry.Description)
+	assert.Equal(t, at(9, 30), entry.StartTime)
+	session.Interruptions = append(session.Interruptions, entry)
+	meeting.Recorded = true
+
+	_, over := dailySessions.OverMeeting(session, at(9, 59))
+	assert.False(t, over)
+	meeting, over = dailySessions.OverMeeting(session, at(10, 1))
+	assert.True(t, over)
+	assert.Equal(t, at(10, 0), meeting.End)
+
+	// Not recorded again after returning
+	session.Interruptions = append(session.Interruptions, NewTimeEntry(EntryTypeReturn, ""))
+	_, due = dailySessions.DueMeeting(session, at(9, 45))
+	assert.False(t, due)
+
+	// Sessions started during a meeting are left alone
+	late := NewTimeEntry(EntryTypeStart, "Late")
+	late.StartTime = at(11, 15)
+	_, due = dailySessions.DueMeeting(NewSession(late), at(11, 20))
+	assert.False(t, due)
+
+	// Manual interruptions are not returned from
+	manual := NewInterruptionEntry("Call", TagCall)
+	manual.StartTime = at(9, 40)
+	session.Interruptions = append(session.Interruptions, manual)
+	_, over = dailySessions.OverMeeting(session, at(10, 5))
+	assert.False(t, over)
+}
+
+// TestMergeMeetings tests merging the meetings of two versions of a day
+func TestMergeMeetings(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	ours, _ := ParseMeetings("9:30-10:00 Standup", day)
+	theirs, _ := ParseMeetings("9:30-10:00 Standup; 14-15 Planning", day)
+	theirs[0].Recorded = true
+
+	merged := MergeDailySessions(&DailySessions{Date: day, Meetings: ours}, &DailySessions{Date: day, Meetings: theirs})
+	if assert.Len(t, merged.Meetings, 2) {
+		assert.True(t, merged.Meetings[0].Recorded)
+		assert.Equal(t, "Planning", merged.Meetings[1].Title)
+	}
+}
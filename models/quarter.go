@@ -0,0 +1,129 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// quarterTopProjects is how many projects a quarter review lists
+const quarterTopProjects = 5
+
+// NoProject names the focus of sessions without a project
+const NoProject = "(no project)"
+
+// WeekReview holds the focus of one week of a quarter
+type WeekReview struct {
+	Start       time.Time     // Monday, or the first day of the quarter
+	Focus       time.Duration // Focused work in the week
+	TrackedDays int           // Days with sessions and a goal
+	GoalDays    int           // Tracked days reaching the goal
+}
+
+// Attainment returns the share of tracked days reaching the goal, 0-100
+func (w WeekReview) Attainment() float64 {
+	if w.TrackedDays == 0 {
+		return 0
+	}
+	return float64(w.GoalDays) / float64(w.TrackedDays) * 100
+}
+
+// ProjectFocus is the focused work spent on a project
+type ProjectFocus struct {
+	Project string
+	Focus   time.Duration
+}
+
+// QuarterReview aggregates a quarter's focus by week and project for quarterly reviews
+type QuarterReview struct {
+	Start       time.Time // First day of the quarter
+	End         time.Time // Last day of the quarter
+	Weeks       []WeekReview
+	Projects    []ProjectFocus // Projects with the most focus, at most quarterTopProjects
+	TotalFocus  time.Duration
+	TrackedDays int
+	GoalDays    int
+}
+
+// QuarterStart returns the first day of the quarter containing day
+func QuarterStart(day time.Time) time.Time {
+	month := time.Month((int(day.Month())-1)/3*3 + 1)
+	return time.Date(day.Year(), month, 1, 0, 0, 0, 0, day.Location())
+}
+
+// Label names the quarter, e.g. "Q2 2025"
+func (q *QuarterReview) Label() string {
+	return fmt.Sprintf("Q%d %d", (int(q.Start.Month())-1)/3+1, q.Start.Year())
+}
+
+// Attainment returns the share of tracked days in the quarter reaching the goal, 0-100
+func (q *QuarterReview) Attainment() float64 {
+	if q.TrackedDays == 0 {
+		return 0
+	}
+	return float64(q.GoalDays) / float64(q.TrackedDays) * 100
+}
+
+// NewQuarterReview builds the review of the quarter starting at start from the daily
+// sessions keyed by date (YYYY-MM-DD). Weeks start on Monday; the first and last week
+// are cut at the quarter's boundaries.
+func NewQuarterReview(start time.Time, days map[string]*DailySessions, goals FocusGoals) *QuarterReview {
+	review := &QuarterReview{
+		Start: start,
+		End:   start.AddDate(0, 3, -1),
+	}
+
+	projects := make(map[string]time.Duration)
+	var week *WeekReview
+	for day := start; !day.After(review.End); day = day.AddDate(0, 0, 1) {
+		if week == nil || day.Weekday() == time.Monday {
+			review.Weeks = append(review.Weeks, WeekReview{Start: day})
+			week = &review.Weeks[len(review.Weeks)-1]
+		}
+
+		dailySessions, ok := days[day.Format("2006-01-02")]
+		if !ok || len(dailySessions.Sessions) == 0 {
+			continue
+		}
+
+		var focus time.Duration
+		for _, session := range dailySessions.Sessions {
+			work, _, _ := session.GetStats()
+			focus += work
+
+			project := session.Project
+			if project == "" {
+				project = NoProject
+			}
+			projects[project] += work
+		}
+		week.Focus += focus
+		review.TotalFocus += focus
+
+		if goal := goals.For(day); goal > 0 {
+			week.TrackedDays++
+			review.TrackedDays++
+			if focus >= goal {
+				week.GoalDays++
+				review.GoalDays++
+			}
+		}
+	}
+
+	for project, focus := range projects {
+		if focus > 0 {
+			review.Projects = append(review.Projects, ProjectFocus{Project: project, Focus: focus})
+		}
+	}
+	sort.Slice(review.Projects, func(i, j int) bool {
+		if review.Projects[i].Focus != review.Projects[j].Focus {
+			return review.Projects[i].Focus > review.Projects[j].Focus
+		}
+		return review.Projects[i].Project < review.Projects[j].Project
+	})
+	if len(review.Projects) > quarterTopProjects {
+		review.Projects = review.Projects[:quarterTopProjects]
+	}
+
+	return review
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQuarterStart tests finding the first day of a quarter
+func TestQuarterStart(t *testing.T) {
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), QuarterStart(time.Date(2025, 3, 31, 15, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), QuarterStart(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+// TestNewQuarterReview tests splitting a quarter into weeks and attaining weekday goals
+func TestNewQuarterReview(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) // Wednesday
+	day := func(date time.Time, work time.Duration) *DailySessions {
+		start := date.Add(9 * time.Hour)
+		return &DailySessions{Date: date, Sessions: []*Session{{
+			Start:       &TimeEntry{Type: EntryTypeStart, StartTime: start},
+			End:         &TimeEntry{Type: EntryTypeEnd, StartTime: start.Add(work)},
+			SubSessions: []*SubSession{{Start: &TimeEntry{StartTime: start}, End: &TimeEntry{StartTime: start.Add(work)}}},
+		}}}
+	}
+
+	friday := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	days := map[string]*DailySessions{
+		"2025-01-01": day(start, 5*time.Hour),
+		"2025-01-03": day(friday, time.Hour),
+		"2025-01-04": day(friday.AddDate(0, 0, 1), time.Hour), // Saturday without a goal
+	}
+	goals, err := NewFocusGoals(240, map[string]int{"fri": 60, "sat": 0})
+	assert.NoError(t, err)
+
+	review := NewQuarterReview(start, days, goals)
+	assert.Equal(t, "Q1 2025", review.Label())
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), review.End)
+	assert.Len(t, review.Weeks, 14)
+	assert.Equal(t, start, review.Weeks[0].Start)
+	assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), review.Weeks[1].Start)
+
+	first := review.Weeks[0]
+	assert.Equal(t, 7*time.Hour, first.Focus)
+	assert.Equal(t, 2, first.TrackedDays)
+	assert.Equal(t, 2, first.GoalDays)
+	assert.Equal(t, 100.0, first.Attainment())
+	assert.Equal(t, 0.0, review.Weeks[1].Attainment())
+
+	assert.Equal(t, []ProjectFocus{{Project: NoProject, Focus: 7 * time.Hour}}, review.Projects)
+}
@@ -0,0 +1,391 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuickEntryKind is what a quick entry records
+type QuickEntryKind string
+
+const (
+	// QuickEntrySession records a completed session
+	QuickEntrySession QuickEntryKind = "session"
+	// QuickEntryInterruption records an interruption of an existing session
+	QuickEntryInterruption QuickEntryKind = "interruption"
+)
+
+// QuickEntry is a retroactive log entry parsed from a sentence such as
+// "interrupted by call 10:14-10:32" or "worked on report 9-11 with 2 interruptions"
+type QuickEntry struct {
+	Kind               QuickEntryKind
+	Description        string
+	Tag                InterruptionTag // Tag of the interruption, or of the interruptions of a session
+	Start              time.Time
+	End                time.Time
+	Interruptions      int           // Interruptions during a session
+	InterruptionLength time.Duration // Length of each interruption during a session, 0 when unknown
+}
+
+const (
+	clockPattern = `(\d{1,2}(?:[:.]\d{2})?\s*(?:am|pm)?)`
+	rangePattern = `(?:from\s+|between\s+)?` + clockPattern + `\s*(?:-|–|to|until|till|and)\s*` + clockPattern
+)
+
+var (
+	quickInterruptionPattern = regexp.MustCompile(`(?i)^(?:got\s+)?interrupt(?:ed|ion)(?:\s+(?:by|for|with))?\s+(.*?)\s*` + rangePattern + `$`)
+	quickSessionPattern      = regexp.MustCompile(`(?i)^(?:(?:i\s+)?(?:worked|working|work)(?:\s+on)?\s+)?(.+?)\s+` + rangePattern +
+		`(?:\s*,?\s+with\s+(\S+)\s+(?:(\S+)\s+)?interruptions?(?:\s+(?:of|for)\s+(\d+\s*(?:minutes?|mins?|m\b)|[0-9hm]+)(?:\s+each)?)?)?$`)
+	clockPartsPattern = regexp.MustCompile(`(?i)^(\d{1,2})(?:[:.](\d{2}))?\s*(am|pm)?$`)
+)
+
+// quickCounts are the spelled-out interruption counts understood by quick entries
+var quickCounts = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "no": 0,
+}
+
+// ParseQuickEntry parses a quick entry for the tracking day beginning at dayStart. Clock
+// times before dayStart belong to the next calendar day. Interruption tags are the
+// built-in ones and customTags.
+func ParseQuickEntry(text string, dayStart time.Time, customTags []string) (*QuickEntry, error) {
+	text = strings.Join(strings.Fields(text), " ")
+	text = strings.TrimSuffix(text, ".")
+	if text == "" {
+		return nil, fmt.Errorf("nothing to log")
+	}
+
+	if match := quickInterruptionPattern.FindStringSubmatch(text); match != nil {
+		start, end, err := resolveClockRange(dayStart, match[2], match[3])
+		if err != nil {
+			return nil, err
+		}
+		entry := &QuickEntry{Kind: QuickEntryInterruption, Start: start, End: end, Tag: TagOther}
+		entry.Tag, entry.Description = splitQuickTag(match[1], customTags)
+		return entry, nil
+	}
+
+	match := quickSessionPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil, fmt.Errorf("could not understand %q, try e.g. \"worked on report 9-11 with 2 interruptions\" or \"interrupted by call 10:14-10:32\"", text)
+	}
+	start, end, err := resolveClockRange(dayStart, match[2], match[3])
+	if err != nil {
+		return nil, err
+	}
+	entry := &QuickEntry{Kind: QuickEntrySession, Description: match[1], Start: start, End: end, Tag: TagOther}
+
+	if match[4] != "" {
+		count, err := strconv.Atoi(match[4])
+		if err != nil {
+			known, ok := quickCounts[strings.ToLower(match[4])]
+			if !ok {
+				return nil, fmt.Errorf("unknown number of interruptions %q", match[4])
+			}
+			count = known
+		}
+		entry.Interruptions = count
+	}
+	if match[5] != "" {
+		tag, rest := splitQuickTag(match[5], customTags)
+		if rest != "" {
+			return nil, fmt.Errorf("unknown interruption tag %q", match[5])
+		}
+		entry.Tag = tag
+	}
+	if match[6] != "" {
+		length, err := parseQuickLength(match[6])
+		if err != nil {
+			return nil, err
+		}
+		entry.InterruptionLength = length
+	}
+	if span := entry.End.Sub(entry.Start); time.Duration(entry.Interruptions)*entry.InterruptionLength >= span {
+		return nil, fmt.Errorf("%d interruptions of %s do not fit into %s of work", entry.Interruptions, entry.InterruptionLength, span)
+	}
+
+	return entry, nil
+}
+
+// splitQuickTag splits "call from Bob" into a known tag and the description. Without a
+// known tag in front, the whole text is the description of an "other" interruption.
+func splitQuickTag(text string, customTags []string) (InterruptionTag, string) {
+	words := strings.Fields(text)
+	if len(words) > 0 {
+		switch strings.ToLower(words[0]) {
+		case "a", "an", "the":
+			words = words[1:]
+		}
+	}
+	if len(words) == 0 {
+		return TagOther, ""
+	}
+
+	first := strings.ToLower(words[0])
+	tag := InterruptionTag(first)
+	known := IsBuiltinTag(tag)
+	for _, custom := range customTags {
+		if strings.ToLower(custom) == first {
+			tag, known = InterruptionTag(custom), true
+		}
+	}
+	if !known {
+		return TagOther, strings.Join(words, " ")
+	}
+	if len(words) == 1 {
+		return tag, ""
+	}
+	return tag, strings.Join(words, " ")
+}
+
+// parseQuickLength parses an interruption length such as "10m", "10 min" or "1h"
+func parseQuickLength(text string) (time.Duration, error) {
+	text = strings.ToLower(strings.ReplaceAll(text, " ", ""))
+	for _, unit := range []string{"minutes", "minute", "mins", "min"} {
+		if strings.HasSuffix(text, unit) {
+			text = strings.TrimSuffix(text, unit) + "m"
+			break
+		}
+	}
+	length, err := ParseEstimate(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interruption length %q, use e.g. 10m", text)
+	}
+	return length, nil
+}
+
+// clockTime is a time of day as written, with an optional am/pm suffix
+type clockTime struct {
+	hour, minute int
+	meridiem     string
+}
+
+// parseClockTime parses "9", "9:30", "10.15" or "2pm"
+func parseClockTime(text string) (clockTime, error) {
+	match := clockPartsPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return clockTime{}, fmt.Errorf("invalid time %q", text)
+	}
+	clock := clockTime{meridiem: strings.ToLower(match[3])}
+	clock.hour, _ = strconv.Atoi(match[1])
+	if match[2] != "" {
+		clock.minute, _ = strconv.Atoi(match[2])
+	}
+
+	valid := clock.minute < 60 && clock.hour < 24
+	if clock.meridiem != "" {
+		valid = valid && clock.hour >= 1 && clock.hour <= 12
+	}
+	if !valid {
+		return clockTime{}, fmt.Errorf("invalid time %q", text)
+	}
+	return clock, nil
+}
+
+// minutes returns the minutes after midnight, reading a 12-hour clock with its suffix
+func (c clockTime) minutes() int {
+	hour := c.hour
+	switch {
+	case c.meridiem == "am" && hour == 12:
+		hour = 0
+	case c.meridiem == "pm" && hour < 12:
+		hour += 12
+	}
+	return hour*60 + c.minute
+}
+
+// resolveClockRange turns a written range into times of the tracking day. "2-4pm" reads
+// as 14:00-16:00 and "11-1" or "9-5" end in the afternoon.
+func resolveClockRange(dayStart time.Time, fromText, toText string) (time.Time, time.Time, error) {
+	from, err := parseClockTime(fromText)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := parseClockTime(toText)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if from.meridiem == "" && to.meridiem != "" && from.hour >= 1 && from.hour <= 12 {
+		if withSuffix := (clockTime{from.hour, from.minute, to.meridiem}); withSuffix.minutes() <= to.minutes() {
+			from = withSuffix
+		}
+	}
+	fromMinutes, toMinutes := from.minutes(), to.minutes()
+	if toMinutes <= fromMinutes && to.meridiem == "" && to.hour < 12 {
+		toMinutes += 12 * 60
+	}
+
+	at := func(minutes int) time.Time {
+		t := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), 0, minutes, 0, 0, dayStart.Location())
+		if t.Before(dayStart) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t
+	}
+	start, end := at(fromMinutes), at(toMinutes)
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%s ends before it starts", strings.TrimSpace(fromText)+"-"+strings.TrimSpace(toText))
+	}
+	return start, end, nil
+}
+
+// Summary describes the entry for confirmations
+func (q *QuickEntry) Summary() string {
+	span := q.Start.Format("15:04") + "-" + q.End.Format("15:04")
+	if q.Kind == QuickEntryInterruption {
+		if q.Description != "" {
+			return fmt.Sprintf("%s interruption %q %s", q.Tag, q.Description, span)
+		}
+		return fmt.Sprintf("%s interruption %s", q.Tag, span)
+	}
+
+	summary := fmt.Sprintf("%q %s", q.Description, span)
+	if q.Interruptions > 0 {
+		summary += fmt.Sprintf(" with %d %s interruption(s)", q.Interruptions, q.Tag)
+		if q.InterruptionLength > 0 {
+			summary += fmt.Sprintf(" of %s", q.InterruptionLength)
+		}
+	}
+	return summary
+}
+
+// Apply adds the entry to the day, returning the new or changed session and the entry
+// to record in the journal. Entries may not overlap recorded time or end after now.
+func (q *QuickEntry) Apply(day *DailySessions, now time.Time) (*Session, *TimeEntry, error) {
+	if q.End.After(now) {
+		return nil, nil, fmt.Errorf("%s ends in the future", q.Summary())
+	}
+	if q.Kind == QuickEntryInterruption {
+		return q.applyInterruption(day, now)
+	}
+
+	for _, existing := range day.Sessions {
+		if existing.Start == nil {
+			continue
+		}
+		existingEnd := now
+		if existing.End != nil {
+			existingEnd = existing.End.StartTime
+		}
+		if q.Start.Before(existingEnd) && existing.Start.StartTime.Before(q.End) {
+			return nil, nil, fmt.Errorf("overlaps %q from %s to %s", existing.Start.Description,
+				existing.Start.StartTime.Format("15:04"), existingEnd.Format("15:04"))
+		}
+	}
+
+	start := quickTimeEntry(EntryTypeStart, q.Start, 0)
+	start.Description = q.Description
+	session := NewSession(start)
+
+	// Interruptions of unknown time are spread evenly over the session
+	gap := (q.End.Sub(q.Start) - time.Duration(q.Interruptions)*q.InterruptionLength) / time.Duration(q.Interruptions+1)
+	interruptions := []*TimeEntry{}
+	for i := 0; i < q.Interruptions; i++ {
+		begin := q.Start.Add(gap*time.Duration(i+1) + q.InterruptionLength*time.Duration(i)).Truncate(time.Second)
+		interruption := quickTimeEntry(EntryTypeInterruption, begin, 0)
+		interruption.Tag = q.Tag
+		interruptions = append(interruptions, interruption, quickTimeEntry(EntryTypeReturn, begin.Add(q.InterruptionLength), 1))
+	}
+	session.Interruptions = interruptions
+	session.SubSessions[0].Interruptions = append([]*TimeEntry{}, interruptions...)
+	session.EndAt(q.End)
+
+	day.Sessions = append(day.Sessions, session)
+	sort.SliceStable(day.Sessions, func(i, j int) bool {
+		a, b := day.Sessions[i].Start, day.Sessions[j].Start
+		if a == nil || b == nil {
+			return b != nil
+		}
+		return a.StartTime.Before(b.StartTime)
+	})
+	return session, start, nil
+}
+
+// applyInterruption inserts the interruption into the session it happened in
+func (q *QuickEntry) applyInterruption(day *DailySessions, now time.Time) (*Session, *TimeEntry, error) {
+	var session *Session
+	for _, candidate := range day.Sessions {
+		if candidate.Start == nil || q.Start.Before(candidate.Start.StartTime) {
+			continue
+		}
+		if candidate.End == nil || !q.End.After(candidate.End.StartTime) {
+			session = candidate
+			break
+		}
+	}
+	if session == nil {
+		return nil, nil, fmt.Errorf("no session from %s to %s to add the interruption to", q.Start.Format("15:04"), q.End.Format("15:04"))
+	}
+
+	interruption := quickTimeEntry(EntryTypeInterruption, q.Start, 0)
+	interruption.Description = q.Description
+	interruption.Tag = q.Tag
+	entries := []*TimeEntry{interruption, quickTimeEntry(EntryTypeReturn, q.End, 1)}
+
+	if len(session.SubSessions) > 0 {
+		var subSession *SubSession
+		for _, candidate := range session.SubSessions {
+			if candidate.Start == nil || q.Start.Before(candidate.Start.StartTime) {
+				continue
+			}
+			if candidate.End == nil || !q.End.After(candidate.End.StartTime) {
+				subSession = candidate
+			}
+		}
+		if subSession == nil {
+			return nil, nil, fmt.Errorf("%s falls between the parts of a resumed session", q.Summary())
+		}
+		updated, err := insertInterruption(subSession.Interruptions, entries, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		subSession.Interruptions = updated
+	}
+
+	updated, err := insertInterruption(session.Interruptions, entries, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	session.Interruptions = updated
+	return session, interruption, nil
+}
+
+// insertInterruption returns a copy of the interruption/return pairs with the new pair in
+// chronological order, refusing overlaps. An ongoing interruption lasts until now.
+func insertInterruption(existing, pair []*TimeEntry, now time.Time) ([]*TimeEntry, error) {
+	start, end := pair[0].StartTime, pair[1].StartTime
+	position := len(existing)
+	for i := 0; i < len(existing); i += 2 {
+		from, until := existing[i].StartTime, now
+		if i+1 < len(existing) {
+			until = existing[i+1].StartTime
+		}
+		if start.Before(until) && from.Before(end) {
+			return nil, fmt.Errorf("overlaps the %s interruption from %s to %s", existing[i].Tag, from.Format("15:04"), until.Format("15:04"))
+		}
+		if position == len(existing) && !from.Before(end) {
+			position = i
+		}
+	}
+
+	// Copy, as sessions and their sub-sessions may share the backing array
+	updated := make([]*TimeEntry, 0, len(existing)+len(pair))
+	updated = append(updated, existing[:position]...)
+	updated = append(updated, pair...)
+	return append(updated, existing[position:]...), nil
+}
+
+// quickTimeEntry creates an entry at t, with IDs derived from the time like EndAt, offset
+// to keep the entries of zero-length interruptions apart
+func quickTimeEntry(entryType EntryType, t time.Time, offset int64) *TimeEntry {
+	return &TimeEntry{
+		ID:        fmt.Sprintf("%d", t.UnixNano()+offset),
+		Type:      entryType,
+		StartTime: t,
+	}
+}
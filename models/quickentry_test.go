@@ -0,0 +1,130 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseQuickEntry tests the sentences understood by quick entries
+func TestParseQuickEntry(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	entry, err := ParseQuickEntry("interrupted by call 10:14-10:32", day, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &QuickEntry{Kind: QuickEntryInterruption, Tag: TagCall, Start: at(10, 14), End: at(10, 32)}, entry)
+
+	entry, err = ParseQuickEntry("Interrupted by a call from Bob from 10:14 to 10:32.", day, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, TagCall, entry.Tag)
+	assert.Equal(t, "call from Bob", entry.Description)
+
+	entry, err = ParseQuickEntry("interrupted by fire drill 2-2:30pm", day, []string{"Standup"})
+	assert.NoError(t, err)
+	assert.Equal(t, TagOther, entry.Tag)
+	assert.Equal(t, "fire drill", entry.Description)
+	assert.Equal(t, at(14, 0), entry.Start)
+
+	entry, err = ParseQuickEntry("interrupted by standup 9:30-9:45", day, []string{"Standup"})
+	assert.NoError(t, err)
+	assert.Equal(t, InterruptionTag("Standup"), entry.Tag)
+
+	entry, err = ParseQuickEntry("worked on report 9-11 with 2 interruptions", day, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &QuickEntry{Kind: QuickEntrySession, Description: "report", Tag: TagOther, Start: at(9, 0), End: at(11, 0), Interruptions: 2}, entry)
+
+	entry, err = ParseQuickEntry("I worked on Q1 planning 11-1, with three meeting interruptions of 10 min each", day, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Q1 planning", entry.Description)
+	assert.Equal(t, at(13, 0), entry.End)
+	assert.Equal(t, 3, entry.Interruptions)
+	assert.Equal(t, TagMeeting, entry.Tag)
+	assert.Equal(t, 10*time.Minute, entry.InterruptionLength)
+
+	entry, err = ParseQuickEntry("code review 9pm-11pm", day, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, at(21, 0), entry.Start)
+
+	// Past midnight belongs to the day when the day starts later
+	entry, err = ParseQuickEntry("deploy 23:00-1am", day.Add(4*time.Hour), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, at(25, 0), entry.End)
+
+	for _, text := range []string{
+		"",
+		"had a nice day",
+		"report 11-10am",
+		"report 25:00-26:00",
+		"report 9-10 with 2 lunch interruptions",
+		"report 9-10 with 3 interruptions of 20m",
+	} {
+		_, err := ParseQuickEntry(text, day, nil)
+		assert.Error(t, err, text)
+	}
+}
+
+// TestQuickEntryApply tests adding sessions and interruptions to a day
+func TestQuickEntryApply(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	now := day.Add(18 * time.Hour)
+	dailySessions := &DailySessions{Date: day, Sessions: []*Session{}}
+
+	apply := func(text string) (*Session, error) {
+		entry, err := ParseQuickEntry(text, day, nil)
+		if !assert.NoError(t, err, text) {
+			return nil, err
+		}
+		session, _, err := entry.Apply(dailySessions, now)
+		return session, err
+	}
+
+	report, err := apply("worked on report 13-15 with 2 interruptions of 15m")
+	assert.NoError(t, err)
+	work, interrupted, count := report.GetStats()
+	assert.Equal(t, 90*time.Minute, work)
+	assert.Equal(t, 30*time.Minute, interrupted)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, day.Add(13*time.Hour+30*time.Minute), report.Interruptions[0].StartTime)
+	assert.Equal(t, report.Interruptions, report.SubSessions[0].Interruptions)
+
+	email, err := apply("email 9-10")
+	assert.NoError(t, err)
+	assert.Equal(t, []*Session{email, report}, dailySessions.Sessions, "sessions stay in order")
+
+	_, err = apply("planning 14-16")
+	assert.Error(t, err, "overlaps the report")
+	_, err = apply("evening work 17-19")
+	assert.Error(t, err, "ends in the future")
+
+	session, err := apply("interrupted by call 14:05-14:15")
+	assert.NoError(t, err)
+	assert.Same(t, report, session)
+	assert.Len(t, report.Interruptions, 6)
+	assert.Equal(t, TagCall, report.Interruptions[2].Tag)
+	assert.Equal(t, report.Interruptions, report.SubSessions[0].Interruptions)
+	_, _, count = report.GetStats()
+	assert.Equal(t, 3, count)
+
+	_, err = apply("interrupted by call 13:40-13:50")
+	assert.Error(t, err, "overlaps an interruption")
+	_, err = apply("interrupted by call 11:00-11:10")
+	assert.Error(t, err, "no session at that time")
+
+	// An active session runs until now, with an ongoing interruption
+	active := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(16 * time.Hour)})
+	active.Interruptions = []*TimeEntry{{Type: EntryTypeInterruption, StartTime: day.Add(17 * time.Hour)}}
+	active.SubSessions[0].Interruptions = active.Interruptions
+	dailySessions.Sessions = append(dailySessions.Sessions, active)
+
+	session, err = apply("interrupted by spouse 16:30-16:40")
+	assert.NoError(t, err)
+	assert.Same(t, active, session)
+	assert.Equal(t, TagSpouse, active.Interruptions[0].Tag)
+	assert.Equal(t, day.Add(17*time.Hour), active.Interruptions[2].StartTime)
+	_, err = apply("interrupted by call 17:10-17:20")
+	assert.Error(t, err, "overlaps the ongoing interruption")
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// DaySnapshot records how the sessions and meetings of a day looked at one point, to tell
+// later which of them changed since
+type DaySnapshot struct {
+	sessions map[string]string // Encoded sessions by ID
+	meetings string            // Encoded meetings
+}
+
+// Snapshot records the current state of the day
+func (ds *DailySessions) Snapshot() *DaySnapshot {
+	snapshot := &DaySnapshot{sessions: make(map[string]string, len(ds.Sessions)), meetings: encodeSnapshot(ds.Meetings)}
+	for _, session := range ds.Sessions {
+		snapshot.sessions[session.ID] = encodeSnapshot(session)
+	}
+	return snapshot
+}
+
+// encodeSnapshot encodes a value for comparing snapshots
+func encodeSnapshot(value interface{}) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// changed reports whether the session differs from its version in the snapshot, or is not in it
+func (snapshot *DaySnapshot) changed(session *Session) bool {
+	encoded, exists := snapshot.sessions[session.ID]
+	return !exists || encoded != encodeSnapshot(session)
+}
+
+// Rebase applies the changes another writer made to the stored day since the snapshot was
+// taken, keeping the changes made to ds since then. Sessions ds left unchanged take the
+// stored version in place, so pointers to them stay valid; sessions added to the stored
+// day are added and those removed from it are removed. Sessions changed in both keep the
+// version with the most recent activity, as MergeDailySessions does. Returns whether ds
+// changed.
+func (ds *DailySessions) Rebase(base *DaySnapshot, stored *DailySessions) bool {
+	changed := false
+	storedByID := make(map[string]*Session, len(stored.Sessions))
+	for _, session := range stored.Sessions {
+		storedByID[session.ID] = session
+	}
+
+	kept := ds.Sessions[:0]
+	for _, session := range ds.Sessions {
+		theirs, exists := storedByID[session.ID]
+		delete(storedByID, session.ID)
+		switch {
+		case !exists:
+			// Removed by the other writer unless it is new or changed here
+			if _, known := base.sessions[session.ID]; known && !base.changed(session) {
+				changed = true
+				continue
+			}
+		case base.changed(theirs) && encodeSnapshot(theirs) != encodeSnapshot(session):
+			if !base.changed(session) || theirs.LastActivity().After(session.LastActivity()) {
+				*session = *theirs
+				changed = true
+			}
+		}
+		kept = append(kept, session)
+	}
+	ds.Sessions = kept
+
+	// Sessions added by the other writer, unless removed here since the snapshot
+	for _, session := range stored.Sessions {
+		if _, added := storedByID[session.ID]; !added {
+			continue
+		}
+		if _, known := base.sessions[session.ID]; known {
+			continue
+		}
+		ds.Sessions = append(ds.Sessions, session)
+		changed = true
+	}
+
+	if storedMeetings := encodeSnapshot(stored.Meetings); storedMeetings != base.meetings && storedMeetings != encodeSnapshot(ds.Meetings) {
+		if encodeSnapshot(ds.Meetings) == base.meetings {
+			ds.Meetings = stored.Meetings
+		} else {
+			ds.Meetings = mergeMeetings(ds.Meetings, stored.Meetings)
+		}
+		changed = true
+	}
+
+	if changed {
+		// Keep sessions in chronological order
+		sort.SliceStable(ds.Sessions, func(i, j int) bool {
+			a, b := ds.Sessions[i].Start, ds.Sessions[j].Start
+			if a == nil || b == nil {
+				return b != nil
+			}
+			return a.StartTime.Before(b.StartTime)
+		})
+	}
+	return changed
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// copyDay returns a copy of the day as another writer would load it
+func copyDay(t *testing.T, ds *DailySessions) *DailySessions {
+	t.Helper()
+	data, err := json.Marshal(ds)
+	assert.NoError(t, err)
+	var copied DailySessions
+	assert.NoError(t, json.Unmarshal(data, &copied))
+	return &copied
+}
+
+// TestRebase tests taking in the changes another writer saved to a day, keeping those made here
+func TestRebase(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	session := func(id string, hour int) *Session {
+		s := NewSession(&TimeEntry{ID: id, Type: EntryTypeStart, StartTime: day.Add(time.Duration(hour) * time.Hour), Description: id})
+		s.ID = id
+		return s
+	}
+	ours := &DailySessions{Date: day, Sessions: []*Session{session("edited", 8), session("active", 9), session("removed", 10)}}
+	base := ours.Snapshot()
+	active := ours.Sessions[1]
+
+	stored := copyDay(t, ours)
+	interruption := &TimeEntry{ID: "call", Type: EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 30*time.Minute), Tag: TagCall}
+	stored.Sessions[1].Interruptions = append(stored.Sessions[1].Interruptions, interruption)
+	stored.Sessions = append(stored.Sessions[:2], session("added", 7))
+	stored.Meetings = []*Meeting{{Title: "Standup", Start: day.Add(11 * time.Hour), End: day.Add(11*time.Hour + 15*time.Minute)}}
+
+	ours.Sessions[0].Start.Description = "edited here"
+	ours.Sessions = append(ours.Sessions, session("new", 12))
+
+	assert.True(t, ours.Rebase(base, stored))
+	ids := []string{}
+	for _, s := range ours.Sessions {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []string{"added", "edited", "active", "new"}, ids)
+	assert.Equal(t, "edited here", ours.Sessions[1].Start.Description)
+	assert.Same(t, active, ours.Sessions[2], "sessions are updated in place")
+	assert.Len(t, active.Interruptions, 1)
+	assert.Len(t, ours.Meetings, 1)
+
+	// Nothing changes when the stored day is what the snapshot recorded
+	assert.False(t, ours.Rebase(stored.Snapshot(), stored))
+
+	// A session changed on both sides keeps the version with the latest activity
+	base = ours.Snapshot()
+	stored = copyDay(t, ours)
+	stored.Sessions[2].EndAt(day.Add(10 * time.Hour))
+	active.EndAt(day.Add(11 * time.Hour))
+	assert.False(t, ours.Rebase(base, stored))
+	assert.Equal(t, day.Add(11*time.Hour), active.End.StartTime)
+}
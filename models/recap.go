@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// DailyRecap summarizes a single day compared to the days before it
+type DailyRecap struct {
+	Date        time.Time
+	Stats       *DetailedStats // Stats of the recapped day, with the productivity score calculated
+	AverageWork time.Duration  // Average focused work of the working days in the previous week
+	AverageDays int            // Number of working days the average is based on
+}
+
+// WorkChange returns how much more (positive) or less (negative) the day's focused work
+// was than the average, as a fraction. The second value is false without an average.
+func (r *DailyRecap) WorkChange() (float64, bool) {
+	if r.AverageDays == 0 || r.AverageWork == 0 {
+		return 0, false
+	}
+	return float64(r.Stats.TotalWorkDuration)/float64(r.AverageWork) - 1, true
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultFocusGoal is the daily focused work needed to extend a streak when no goal is configured
+const DefaultFocusGoal = 4 * time.Hour
+
+// DaySummary holds the per-day figures records are computed from
+type DaySummary struct {
+	Date          time.Time
+	Sessions      int
+	Work          time.Duration
+	Interruptions int
+	LongestBlock  time.Duration // Longest uninterrupted work period of the day
+}
+
+// Achievement is a milestone earned on a given day
+type Achievement struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	EarnedOn string `json:"earned_on"` // YYYY-MM-DD
+}
+
+// Records holds focus streaks and personal bests over all tracked days
+type Records struct {
+	FocusGoal    time.Duration                  `json:"focus_goal"`              // Default daily goal
+	WeekdayGoals map[time.Weekday]time.Duration `json:"weekday_goals,omitempty"` // Goals that differ by weekday
+
+	CurrentStreak    int    `json:"current_streak"`               // Working days in a row hitting the goal
+	LongestStreak    int    `json:"longest_streak"`               // Best streak ever
+	LongestStreakEnd string `json:"longest_streak_end,omitempty"` // Last day of the best streak
+
+	LongestBlock     time.Duration `json:"longest_block"`
+	LongestBlockDate string        `json:"longest_block_date,omitempty"`
+
+	MostFocus     time.Duration `json:"most_focus"`
+	MostFocusDate string        `json:"most_focus_date,omitempty"`
+
+	// Among days that hit the goal, so short days don't win by default
+	FewestInterruptions     int    `json:"fewest_interruptions"`
+	FewestInterruptionsDate string `json:"fewest_interruptions_date,omitempty"`
+
+	Achievements []Achievement `json:"achievements"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// achievementRule awards an achievement the first day its condition holds
+type achievementRule struct {
+	id, title string
+	earned    func(day DaySummary, streak int, hitGoal bool) bool
+}
+
+// achievementRules lists the available achievements in display order
+var achievementRules = []achievementRule{
+	{"first-goal", "First goal day", func(day DaySummary, streak int, hitGoal bool) bool {
+		return hitGoal
+	}},
+	{"streak-5", "5-day focus streak", func(day DaySummary, streak int, hitGoal bool) bool {
+		return streak >= 5
+	}},
+	{"streak-20", "20-day focus streak", func(day DaySummary, streak int, hitGoal bool) bool {
+		return streak >= 20
+	}},
+	{"block-90", "Deep work: 90 minutes uninterrupted", func(day DaySummary, streak int, hitGoal bool) bool {
+		return day.LongestBlock >= 90*time.Minute
+	}},
+	{"block-180", "Flow: 3 hours uninterrupted", func(day DaySummary, streak int, hitGoal bool) bool {
+		return day.LongestBlock >= 3*time.Hour
+	}},
+	{"zero-interruptions", "Goal day without interruptions", func(day DaySummary, streak int, hitGoal bool) bool {
+		return hitGoal && day.Interruptions == 0
+	}},
+}
+
+// ComputeRecords replays the days in order to find streaks, personal bests and the day
+// each achievement was earned, using the goal of each day's weekday. Days without sessions
+// or without a goal neither extend nor break a streak, and today only counts once its
+// goal is reached.
+func ComputeRecords(days []DaySummary, goals FocusGoals, today time.Time) *Records {
+
+	sorted := make([]DaySummary, len(days))
+	copy(sorted, days)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	records := &Records{FocusGoal: goals.Daily(), WeekdayGoals: goals.Weekdays, FewestInterruptions: -1, UpdatedAt: today}
+	earned := make(map[string]bool)
+	todayStr := today.Format("2006-01-02")
+
+	streak := 0
+	for _, day := range sorted {
+		if day.Sessions == 0 {
+			continue
+		}
+		dateStr := day.Date.Format("2006-01-02")
+		goal := goals.For(day.Date)
+		hitGoal := goal > 0 && day.Work >= goal
+
+		if hitGoal {
+			streak++
+			if streak > records.LongestStreak {
+				records.LongestStreak = streak
+				records.LongestStreakEnd = dateStr
+			}
+			if records.FewestInterruptions < 0 || day.Interruptions < records.FewestInterruptions {
+				records.FewestInterruptions = day.Interruptions
+				records.FewestInterruptionsDate = dateStr
+			}
+		} else if goal > 0 && dateStr != todayStr {
+			streak = 0
+		}
+
+		if day.LongestBlock > records.LongestBlock {
+			records.LongestBlock = day.LongestBlock
+			records.LongestBlockDate = dateStr
+		}
+		if day.Work > records.MostFocus {
+			records.MostFocus = day.Work
+			records.MostFocusDate = dateStr
+		}
+
+		for _, rule := range achievementRules {
+			if !earned[rule.id] && rule.earned(day, streak, hitGoal) {
+				earned[rule.id] = true
+				records.Achievements = append(records.Achievements, Achievement{ID: rule.id, Title: rule.title, EarnedOn: dateStr})
+			}
+		}
+	}
+
+	records.CurrentStreak = streak
+	if records.FewestInterruptions < 0 {
+		records.FewestInterruptions = 0
+	}
+
+	return records
+}
+
+// Goals returns the focus goals the records were computed with
+func (r *Records) Goals() FocusGoals {
+	return FocusGoals{Default: r.FocusGoal, Weekdays: r.WeekdayGoals}
+}
+
+// NewAchievements returns the achievements in records that are not in previous
+func (r *Records) NewAchievements(previous []Achievement) []Achievement {
+	known := make(map[string]bool, len(previous))
+	for _, achievement := range previous {
+		known[achievement.ID] = true
+	}
+
+	var fresh []Achievement
+	for _, achievement := range r.Achievements {
+		if !known[achievement.ID] {
+			fresh = append(fresh, achievement)
+		}
+	}
+	return fresh
+}
+
+// LongestBlock returns the longest uninterrupted work period of the session
+func (session *Session) LongestBlock(now time.Time) time.Duration {
+	var longest time.Duration
+	for _, period := range session.workPeriods(now) {
+		if length := period.end.Sub(period.start); length > longest {
+			longest = length
+		}
+	}
+	return longest
+}
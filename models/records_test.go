@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestComputeRecords tests streaks over working days, personal bests and achievement dates
+func TestComputeRecords(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	day := func(offset int, work time.Duration, interruptions int, block time.Duration) DaySummary {
+		return DaySummary{Date: monday.AddDate(0, 0, offset), Sessions: 1, Work: work, Interruptions: interruptions, LongestBlock: block}
+	}
+
+	days := []DaySummary{
+		day(7, time.Hour, 0, time.Hour), // Today, goal not reached yet
+		day(0, 5*time.Hour, 3, 50*time.Minute),
+		day(1, 4*time.Hour, 1, 95*time.Minute),
+		day(2, 2*time.Hour, 0, time.Hour), // Breaks the streak
+		day(3, 6*time.Hour, 2, time.Hour),
+		{Date: monday.AddDate(0, 0, 5)}, // Weekend without sessions doesn't break it
+		day(4, 4*time.Hour, 0, 40*time.Minute),
+	}
+
+	records := ComputeRecords(days, FocusGoals{}, monday.AddDate(0, 0, 7))
+	assert.Equal(t, DefaultFocusGoal, records.FocusGoal)
+	assert.Equal(t, 2, records.CurrentStreak)
+	assert.Equal(t, 2, records.LongestStreak)
+	assert.Equal(t, "2025-03-04", records.LongestStreakEnd)
+	assert.Equal(t, 95*time.Minute, records.LongestBlock)
+	assert.Equal(t, "2025-03-04", records.LongestBlockDate)
+	assert.Equal(t, 6*time.Hour, records.MostFocus)
+	assert.Equal(t, 0, records.FewestInterruptions)
+	assert.Equal(t, "2025-03-07", records.FewestInterruptionsDate)
+
+	assert.Equal(t, []Achievement{
+		{ID: "first-goal", Title: "First goal day", EarnedOn: "2025-03-03"},
+		{ID: "block-90", Title: "Deep work: 90 minutes uninterrupted", EarnedOn: "2025-03-04"},
+		{ID: "zero-interruptions", Title: "Goal day without interruptions", EarnedOn: "2025-03-07"},
+	}, records.Achievements)
+
+	fresh := records.NewAchievements(records.Achievements[:1])
+	assert.Len(t, fresh, 2)
+	assert.Equal(t, "block-90", fresh[0].ID)
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RecoveryModel decides how much recovery time is assumed after each interruption: a flat
+// default, optionally overridden for individual tags
+type RecoveryModel struct {
+	Default time.Duration
+	PerTag  map[InterruptionTag]time.Duration
+}
+
+// FlatRecoveryModel returns a model assuming the same recovery time for every tag
+func FlatRecoveryModel(recovery time.Duration) RecoveryModel {
+	return RecoveryModel{Default: recovery}
+}
+
+// ParseRecoveryModel parses a model written as a default duration followed by optional
+// per-tag overrides, e.g. "10m" or "5m,meeting=20m,call=15m"
+func ParseRecoveryModel(spec string) (RecoveryModel, error) {
+	model := RecoveryModel{Default: AssumedRecoveryTime}
+	hasDefault := false
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag, value, perTag := strings.Cut(part, "=")
+		if !perTag {
+			value = part
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || duration < 0 {
+			return RecoveryModel{}, fmt.Errorf("invalid recovery time %q, use e.g. 10m", strings.TrimSpace(value))
+		}
+
+		if !perTag {
+			if hasDefault {
+				return RecoveryModel{}, fmt.Errorf("recovery model %q has more than one default", spec)
+			}
+			model.Default = duration
+			hasDefault = true
+			continue
+		}
+
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return RecoveryModel{}, fmt.Errorf("missing tag in %q", part)
+		}
+		if model.PerTag == nil {
+			model.PerTag = make(map[InterruptionTag]time.Duration)
+		}
+		model.PerTag[InterruptionTag(tag)] = duration
+	}
+
+	return model, nil
+}
+
+// RecoveryFor returns the recovery time assumed after an interruption with the given tag
+func (m RecoveryModel) RecoveryFor(tag InterruptionTag) time.Duration {
+	if recovery, ok := m.PerTag[tag]; ok {
+		return recovery
+	}
+	return m.Default
+}
+
+// String formats the model the way ParseRecoveryModel reads it
+func (m RecoveryModel) String() string {
+	parts := []string{formatRecovery(m.Default)}
+
+	tags := make([]string, 0, len(m.PerTag))
+	for tag := range m.PerTag {
+		tags = append(tags, string(tag))
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		parts = append(parts, tag+"="+formatRecovery(m.PerTag[InterruptionTag(tag)]))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// formatRecovery formats a recovery time without the zero units time.Duration prints
+func formatRecovery(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// RecoveryOutcome is the statistics of a range recomputed under a recovery model
+type RecoveryOutcome struct {
+	Model             RecoveryModel
+	RecoveryTime      time.Duration                     // Assumed recovery over all interruptions
+	RecoveryByTag     map[InterruptionTag]time.Duration // Assumed recovery per tag
+	ImpactedTime      time.Duration                     // Interruption time plus recovery
+	ProductivityScore float64
+}
+
+// EvaluateRecoveryModel recomputes recovery time, total impact and the productivity score
+// of the statistics under a recovery model, leaving the statistics themselves unchanged
+func (s *DetailedStats) EvaluateRecoveryModel(model RecoveryModel) RecoveryOutcome {
+	outcome := RecoveryOutcome{
+		Model:         model,
+		RecoveryByTag: make(map[InterruptionTag]time.Duration, len(s.InterruptionsByTag)),
+	}
+
+	var scoreRecovery time.Duration
+	for tag, count := range s.InterruptionsByTag {
+		recovery := time.Duration(count) * model.RecoveryFor(tag)
+		outcome.RecoveryByTag[tag] = recovery
+		outcome.RecoveryTime += recovery
+		if !s.Policy.TagPolicy(tag).NoScorePenalty {
+			scoreRecovery += recovery
+		}
+	}
+	outcome.ImpactedTime = s.totalInterruptionTime() + outcome.RecoveryTime
+	outcome.ProductivityScore = s.productivityScore(scoreRecovery)
+
+	return outcome
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseRecoveryModel tests reading flat and per-tag recovery models
+func TestParseRecoveryModel(t *testing.T) {
+	model, err := ParseRecoveryModel("5m, meeting=20m,call=15m")
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, model.RecoveryFor(TagOther))
+	assert.Equal(t, 20*time.Minute, model.RecoveryFor(TagMeeting))
+	assert.Equal(t, 15*time.Minute, model.RecoveryFor(TagCall))
+	assert.Equal(t, "5m,call=15m,meeting=20m", model.String())
+
+	// Per-tag overrides alone keep the assumed default
+	model, err = ParseRecoveryModel("meeting=1h")
+	assert.NoError(t, err)
+	assert.Equal(t, AssumedRecoveryTime, model.Default)
+	assert.Equal(t, "10m,meeting=1h", model.String())
+
+	for _, invalid := range []string{"ten", "5m,10m", "=5m", "call=-1m"} {
+		_, err := ParseRecoveryModel(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// TestEvaluateRecoveryModel tests recomputing statistics under different recovery models
+func TestEvaluateRecoveryModel(t *testing.T) {
+	stats := &DetailedStats{
+		TotalWorkDuration:  4 * time.Hour,
+		TotalSessions:      4,
+		TotalInterruptions: 3,
+		InterruptionsByTag: map[InterruptionTag]int{TagCall: 1, TagMeeting: 2},
+		InterruptionDurationByTag: map[InterruptionTag]time.Duration{
+			TagCall:    10 * time.Minute,
+			TagMeeting: 50 * time.Minute,
+		},
+	}
+
+	flat := stats.EvaluateRecoveryModel(FlatRecoveryModel(AssumedRecoveryTime))
+	assert.Equal(t, 30*time.Minute, flat.RecoveryTime)
+	assert.Equal(t, 90*time.Minute, flat.ImpactedTime)
+	assert.InDelta(t, stats.CalculateProductivityScore(), flat.ProductivityScore, 0.001)
+
+	perTag := stats.EvaluateRecoveryModel(RecoveryModel{
+		Default: 5 * time.Minute,
+		PerTag:  map[InterruptionTag]time.Duration{TagMeeting: 20 * time.Minute},
+	})
+	assert.Equal(t, 5*time.Minute, perTag.RecoveryByTag[TagCall])
+	assert.Equal(t, 40*time.Minute, perTag.RecoveryByTag[TagMeeting])
+	assert.Equal(t, 45*time.Minute, perTag.RecoveryTime)
+	assert.Less(t, perTag.ProductivityScore, flat.ProductivityScore)
+
+	// Evaluating a model leaves the statistics alone
+	assert.InDelta(t, flat.ProductivityScore, stats.ProductivityScore, 0.001)
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// AssumedRecoveryTime is the recovery period assumed after each interruption when no
+// recovery_time is configured; StatsPolicy carries the configured one
+const AssumedRecoveryTime = 10 * time.Minute
+
+// SustainedWorkThreshold is the uninterrupted work needed after a return to count as refocused
+const SustainedWorkThreshold = 15 * time.Minute
+
+// RefocusSample is the measured time to refocus after a single return from interruption
+type RefocusSample struct {
+	Tag       InterruptionTag
+	Return    time.Time
+	Refocus   time.Duration // From the return to the start of the next sustained work block
+	Refocused bool          // False if no sustained block followed before the session ended
+}
+
+// RefocusStats summarises time to refocus for an interruption tag
+type RefocusStats struct {
+	Tag          InterruptionTag
+	Returns      int           // Returns from interruptions with this tag
+	Refocused    int           // Returns followed by a sustained work block
+	TotalRefocus time.Duration // Sum of refocus times over refocused returns
+}
+
+// AverageRefocus returns the mean measured time to refocus
+func (r RefocusStats) AverageRefocus() time.Duration {
+	if r.Refocused == 0 {
+		return 0
+	}
+	return r.TotalRefocus / time.Duration(r.Refocused)
+}
+
+// lastReturn returns the session's latest entry if it is a return from an interruption
+func (session *Session) lastReturn() (*TimeEntry, bool) {
+	if len(session.Interruptions) == 0 || len(session.Interruptions)%2 != 0 {
+		return nil, false
+	}
+	entry := session.Interruptions[len(session.Interruptions)-1]
+	return entry, entry.Type == EntryTypeReturn
+}
+
+// InRecovery reports whether the session is within the recovery window after its latest
+// return from an interruption
+func (session *Session) InRecovery(now time.Time, window time.Duration) bool {
+	entry, ok := session.lastReturn()
+	return ok && entry.EndTime.IsZero() && now.Before(entry.StartTime.Add(window))
+}
+
+// RecoveryLeft returns how much of the recovery window after the session's latest return
+// is left, false outside the window
+func (session *Session) RecoveryLeft(now time.Time, window time.Duration) (time.Duration, bool) {
+	if !session.InRecovery(now, window) {
+		return 0, false
+	}
+	entry, _ := session.lastReturn()
+	return entry.StartTime.Add(window).Sub(now), true
+}
+
+// PendingRecoveryEnd returns the session's latest return if its recovery window has
+// elapsed but the transition back to work has not been marked yet
+func (session *Session) PendingRecoveryEnd(now time.Time, window time.Duration) (*TimeEntry, bool) {
+	entry, ok := session.lastReturn()
+	if !ok || !entry.EndTime.IsZero() || now.Before(entry.StartTime.Add(window)) {
+		return nil, false
+	}
+	return entry, true
+}
+
+// workPeriod is an uninterrupted stretch of work
+type workPeriod struct {
+	start, end time.Time
+}
+
+// workPeriods returns the uninterrupted work stretches of the session in order.
+// Active sub-sessions are measured up to now.
+func (session *Session) workPeriods(now time.Time) []workPeriod {
+	subSessions := session.SubSessions
+	if len(subSessions) == 0 && session.Start != nil {
+		// Backward compatibility for sessions without sub-sessions
+		subSessions = []*SubSession{{Start: session.Start, End: session.End, Interruptions: session.Interruptions}}
+	}
+
+	var periods []workPeriod
+	for _, subSession := range subSessions {
+		if subSession.Start == nil {
+			continue
+		}
+
+		cursor := subSession.Start.StartTime
+		interrupted := false
+		for i, entry := range subSession.Interruptions {
+			if i%2 == 0 {
+				periods = append(periods, workPeriod{start: cursor, end: entry.StartTime})
+				interrupted = true
+			} else {
+				cursor = entry.StartTime
+				interrupted = false
+			}
+		}
+
+		// Work continues after the last return until the sub-session ends
+		if !interrupted {
+			end := now
+			if subSession.End != nil {
+				end = subSession.End.StartTime
+			}
+			periods = append(periods, workPeriod{start: cursor, end: end})
+		}
+	}
+
+	return periods
+}
+
+// RefocusSamples measures, for every return from interruption, how long it took to
+// reach the next block of at least SustainedWorkThreshold of uninterrupted work.
+func (session *Session) RefocusSamples(now time.Time) []RefocusSample {
+	periods := session.workPeriods(now)
+
+	var samples []RefocusSample
+	for i := 1; i < len(session.Interruptions); i += 2 {
+		tag := session.Interruptions[i-1].Tag
+		if tag == "" {
+			tag = TagOther
+		}
+
+		sample := RefocusSample{Tag: tag, Return: session.Interruptions[i].StartTime}
+		for _, period := range periods {
+			if period.start.Before(sample.Return) {
+				continue
+			}
+			if period.end.Sub(period.start) >= SustainedWorkThreshold {
+				sample.Refocus = period.start.Sub(sample.Return)
+				sample.Refocused = true
+				break
+			}
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples
+}
+
+// SummarizeRefocus groups refocus samples by tag, sorted by tag name
+func SummarizeRefocus(samples []RefocusSample) []RefocusStats {
+	byTag := make(map[InterruptionTag]*RefocusStats)
+	for _, sample := range samples {
+		stats, exists := byTag[sample.Tag]
+		if !exists {
+			stats = &RefocusStats{Tag: sample.Tag}
+			byTag[sample.Tag] = stats
+		}
+
+		stats.Returns++
+		if sample.Refocused {
+			stats.Refocused++
+			stats.TotalRefocus += sample.Refocus
+		}
+	}
+
+	result := make([]RefocusStats, 0, len(byTag))
+	for _, stats := range byTag {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Tag < result[j].Tag
+	})
+
+	return result
+}
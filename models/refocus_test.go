@@ -0,0 +1,115 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRefocusSamples tests measuring time to the next sustained work block across sub-sessions
+func TestRefocusSamples(t *testing.T) {
+	base := time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) *TimeEntry {
+		return &TimeEntry{StartTime: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+	tagged := func(minutes int, tag InterruptionTag) *TimeEntry {
+		entry := at(minutes)
+		entry.Type = EntryTypeInterruption
+		entry.Tag = tag
+		return entry
+	}
+
+	// Call at 9:10-9:20, a meeting 5 minutes later at 9:25-9:40, a pause at 9:45 and
+	// uninterrupted work again from 10:00 to 11:00
+	interruptions := []*TimeEntry{tagged(10, TagCall), at(20), tagged(25, TagMeeting), at(40)}
+	session := &Session{
+		Start:         at(0),
+		End:           at(120),
+		Interruptions: interruptions,
+		SubSessions: []*SubSession{
+			{Start: at(0), End: at(45), Interruptions: interruptions},
+			{Start: at(60), End: at(120)},
+		},
+	}
+
+	samples := session.RefocusSamples(base.Add(3 * time.Hour))
+	assert.Len(t, samples, 2)
+	assert.Equal(t, TagCall, samples[0].Tag)
+	assert.True(t, samples[0].Refocused)
+	assert.Equal(t, 40*time.Minute, samples[0].Refocus)
+	assert.Equal(t, TagMeeting, samples[1].Tag)
+	assert.Equal(t, 20*time.Minute, samples[1].Refocus)
+
+	// A legacy session that ends 5 minutes after the return never refocuses
+	legacy := &Session{
+		Start:         at(0),
+		End:           at(35),
+		Interruptions: []*TimeEntry{tagged(10, TagCall), at(30)},
+	}
+	legacySamples := legacy.RefocusSamples(base.Add(3 * time.Hour))
+	assert.Len(t, legacySamples, 1)
+	assert.False(t, legacySamples[0].Refocused)
+
+	summary := SummarizeRefocus(append(samples, legacySamples...))
+	assert.Len(t, summary, 2)
+	assert.Equal(t, TagCall, summary[0].Tag)
+	assert.Equal(t, 2, summary[0].Returns)
+	assert.Equal(t, 1, summary[0].Refocused)
+	assert.Equal(t, 40*time.Minute, summary[0].AverageRefocus())
+	assert.Equal(t, TagMeeting, summary[1].Tag)
+	assert.Equal(t, 20*time.Minute, summary[1].AverageRefocus())
+}
+
+// TestPendingRecoveryEnd tests detecting the end of the recovery window after a return
+func TestPendingRecoveryEnd(t *testing.T) {
+	base := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	returned := &TimeEntry{Type: EntryTypeReturn, StartTime: base.Add(30 * time.Minute)}
+	session := &Session{
+		Start: &TimeEntry{Type: EntryTypeStart, StartTime: base},
+		Interruptions: []*TimeEntry{
+			{Type: EntryTypeInterruption, StartTime: base.Add(20 * time.Minute)},
+			returned,
+		},
+	}
+
+	during := base.Add(35 * time.Minute)
+	assert.True(t, session.InRecovery(during, AssumedRecoveryTime))
+	_, ok := session.PendingRecoveryEnd(during, AssumedRecoveryTime)
+	assert.False(t, ok)
+
+	after := base.Add(41 * time.Minute)
+	assert.False(t, session.InRecovery(after, AssumedRecoveryTime))
+	entry, ok := session.PendingRecoveryEnd(after, AssumedRecoveryTime)
+	assert.True(t, ok)
+	assert.Same(t, returned, entry)
+
+	// Once marked, the transition is not pending anymore
+	returned.EndTime = returned.StartTime.Add(AssumedRecoveryTime)
+	_, ok = session.PendingRecoveryEnd(after, AssumedRecoveryTime)
+	assert.False(t, ok)
+
+	// Nothing is pending while interrupted
+	session.Interruptions = append(session.Interruptions, &TimeEntry{Type: EntryTypeInterruption, StartTime: after})
+	_, ok = session.PendingRecoveryEnd(base.Add(time.Hour), AssumedRecoveryTime)
+	assert.False(t, ok)
+}
+
+// TestRecoveryLeft tests the recovery left after a return from an interruption
+func TestRecoveryLeft(t *testing.T) {
+	base := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	session := &Session{
+		Start:         &TimeEntry{Type: EntryTypeStart, StartTime: base},
+		Interruptions: []*TimeEntry{{Type: EntryTypeInterruption, StartTime: base.Add(20 * time.Minute)}},
+	}
+	_, ok := session.RecoveryLeft(base.Add(25*time.Minute), AssumedRecoveryTime)
+	assert.False(t, ok)
+
+	session.Interruptions = append(session.Interruptions, &TimeEntry{Type: EntryTypeReturn, StartTime: base.Add(30 * time.Minute)})
+	left, ok := session.RecoveryLeft(base.Add(33*time.Minute), AssumedRecoveryTime)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Minute, left)
+
+	_, ok = session.RecoveryLeft(base.Add(40*time.Minute), AssumedRecoveryTime)
+	assert.False(t, ok)
+}
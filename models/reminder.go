@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Reminder defaults used when the configuration leaves them unset
+const (
+	DefaultWorkStart    = "09:00"
+	DefaultWorkEnd      = "17:00"
+	DefaultReminderIdle = 15 * time.Minute
+)
+
+// WorkingHours is the daily window in which tracking is expected
+type WorkingHours struct {
+	Start    time.Duration  // Offset from midnight
+	End      time.Duration  // Offset from midnight
+	Weekends bool           // Also expect tracking on Saturday and Sunday
+	Days     []time.Weekday // Working days, overriding Weekends when set
+}
+
+// ParseWorkingHours parses "HH:MM" start and end times, using the defaults when empty
+func ParseWorkingHours(start, end string, weekends bool) (WorkingHours, error) {
+	if start == "" {
+		start = DefaultWorkStart
+	}
+	if end == "" {
+		end = DefaultWorkEnd
+	}
+
+	startOffset, err := parseClock(start)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	endOffset, err := parseClock(end)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	if endOffset <= startOffset {
+		return WorkingHours{}, fmt.Errorf("working hours end %s must be after start %s", end, start)
+	}
+
+	return WorkingHours{Start: startOffset, End: endOffset, Weekends: weekends}, nil
+}
+
+// parseClock parses "HH:MM" into an offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, use HH:MM", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// StartOn returns when working hours begin on the day of t
+func (w WorkingHours) StartOn(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location()).Add(w.Start)
+}
+
+// Contains reports whether t falls within working hours
+func (w WorkingHours) Contains(t time.Time) bool {
+	if !w.WorksOn(t) {
+		return false
+	}
+	start := w.StartOn(t)
+	return !t.Before(start) && t.Before(start.Add(w.End-w.Start))
+}
+
+// StartReminder decides when to suggest starting a session during working hours
+type StartReminder struct {
+	Hours WorkingHours
+	Idle  time.Duration // Time without a session before reminding, and between reminders
+
+	lastReminded time.Time
+}
+
+// Due returns how long nothing has been tracked and whether to remind now. Idle time
+// counts from the latest of lastActivity and the start of working hours, and reminders
+// repeat at most once per idle period.
+func (r *StartReminder) Due(now, lastActivity time.Time) (time.Duration, bool) {
+	if !r.Hours.Contains(now) {
+		return 0, false
+	}
+
+	since := r.Hours.StartOn(now)
+	if lastActivity.After(since) {
+		since = lastActivity
+	}
+	idle := now.Sub(since)
+	if idle < r.Idle || now.Sub(r.lastReminded) < r.Idle {
+		return idle, false
+	}
+
+	r.lastReminded = now
+	return idle, true
+}
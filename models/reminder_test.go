@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseWorkingHours tests defaults and invalid working hours
+func TestParseWorkingHours(t *testing.T) {
+	hours, err := ParseWorkingHours("", "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 9*time.Hour, hours.Start)
+	assert.Equal(t, 17*time.Hour, hours.End)
+
+	hours, err = ParseWorkingHours("08:30", "16:15", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 8*time.Hour+30*time.Minute, hours.Start)
+	assert.True(t, hours.Weekends)
+
+	_, err = ParseWorkingHours("9am", "17:00", false)
+	assert.Error(t, err)
+	_, err = ParseWorkingHours("17:00", "09:00", false)
+	assert.Error(t, err)
+}
+
+// TestStartReminder tests when reminders are due within working hours
+func TestStartReminder(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	hours, err := ParseWorkingHours("09:00", "17:00", false)
+	assert.NoError(t, err)
+	reminder := &StartReminder{Hours: hours, Idle: 15 * time.Minute}
+
+	// Outside working hours and on weekends nothing is due
+	_, due := reminder.Due(monday.Add(8*time.Hour), time.Time{})
+	assert.False(t, due)
+	_, due = reminder.Due(monday.AddDate(0, 0, 5).Add(10*time.Hour), time.Time{})
+	assert.False(t, due)
+
+	// Idle time counts from the start of working hours
+	_, due = reminder.Due(monday.Add(9*time.Hour+10*time.Minute), time.Time{})
+	assert.False(t, due)
+	idle, due := reminder.Due(monday.Add(9*time.Hour+20*time.Minute), time.Time{})
+	assert.True(t, due)
+	assert.Equal(t, 20*time.Minute, idle)
+
+	// Reminders repeat at most once per idle period
+	_, due = reminder.Due(monday.Add(9*time.Hour+25*time.Minute), time.Time{})
+	assert.False(t, due)
+
+	// Recent activity resets the idle time
+	_, due = reminder.Due(monday.Add(11*time.Hour), monday.Add(10*time.Hour+50*time.Minute))
+	assert.False(t, due)
+	idle, due = reminder.Due(monday.Add(11*time.Hour+10*time.Minute), monday.Add(10*time.Hour+50*time.Minute))
+	assert.True(t, due)
+	assert.Equal(t, 20*time.Minute, idle)
+}
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// entriesUntil returns the entries recorded at or before t
+func entriesUntil(entries []*TimeEntry, t time.Time) []*TimeEntry {
+	var until []*TimeEntry
+	for _, entry := range entries {
+		if entry != nil && !entry.StartTime.After(t) {
+			until = append(until, entry)
+		}
+	}
+	return until
+}
+
+// AsOf returns a copy of the session as it was at t: entries recorded later are left out,
+// so a session that ended later is still active. Returns nil for a session started later.
+func (session *Session) AsOf(t time.Time) *Session {
+	if session.Start == nil || session.Start.StartTime.After(t) {
+		return nil
+	}
+
+	past := *session
+	if past.End != nil && past.End.StartTime.After(t) {
+		past.End = nil
+	}
+	past.Interruptions = entriesUntil(session.Interruptions, t)
+	past.Deferred = entriesUntil(session.Deferred, t)
+
+	past.SubSessions = nil
+	for _, subSession := range session.SubSessions {
+		if subSession.Start == nil || subSession.Start.StartTime.After(t) {
+			continue
+		}
+		sub := *subSession
+		if sub.End != nil && sub.End.StartTime.After(t) {
+			sub.End = nil
+		}
+		sub.Interruptions = entriesUntil(subSession.Interruptions, t)
+		past.SubSessions = append(past.SubSessions, &sub)
+	}
+
+	past.FocusWindows = nil
+	for _, window := range session.FocusWindows {
+		if window.Start.After(t) {
+			continue
+		}
+		focus := *window
+		if focus.End != nil && focus.End.After(t) {
+			focus.End = nil
+		}
+		past.FocusWindows = append(past.FocusWindows, &focus)
+	}
+	return &past
+}
+
+// AsOf returns a copy of the day as it was at t, with the sessions started by then
+func (ds *DailySessions) AsOf(t time.Time) *DailySessions {
+	past := &DailySessions{Date: ds.Date, Sessions: []*Session{}}
+	for _, session := range ds.Sessions {
+		if snapshot := session.AsOf(t); snapshot != nil {
+			past.Sessions = append(past.Sessions, snapshot)
+		}
+	}
+	return past
+}
+
+// ActivitySpan returns when the first session of the day started and when the last entry
+// was recorded. Returns false for a day without sessions.
+func (ds *DailySessions) ActivitySpan() (start, end time.Time, ok bool) {
+	for _, session := range ds.Sessions {
+		if session.Start == nil {
+			continue
+		}
+		if !ok || session.Start.StartTime.Before(start) {
+			start = session.Start.StartTime
+		}
+		if last := session.LastActivity(); !ok || last.After(end) {
+			end = last
+		}
+		ok = true
+	}
+	return start, end, ok
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSessionAsOf tests a session replayed to a time in the middle of it is active and only
+// has the entries recorded by then
+func TestSessionAsOf(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	session.SubSessions[0].Interruptions = []*TimeEntry{
+		{Type: EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 10*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(9*time.Hour + 20*time.Minute)},
+	}
+	session.EndAt(day.Add(10 * time.Hour))
+
+	assert.Nil(t, session.AsOf(day.Add(8*time.Hour)))
+
+	interrupted := session.AsOf(day.Add(9*time.Hour + 15*time.Minute))
+	assert.Nil(t, interrupted.End)
+	assert.Nil(t, interrupted.SubSessions[0].End)
+	assert.Len(t, interrupted.SubSessions[0].Interruptions, 1)
+
+	ended := session.AsOf(day.Add(11 * time.Hour))
+	assert.NotNil(t, ended.End)
+	assert.Len(t, ended.SubSessions[0].Interruptions, 2)
+
+	// The recorded session is left unchanged
+	assert.NotNil(t, session.End)
+	assert.Len(t, session.SubSessions[0].Interruptions, 2)
+}
+
+// TestDailySessionsAsOf tests a replayed day only has the sessions started by then, and its
+// activity span runs from the first start to the last entry
+func TestDailySessionsAsOf(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	morning := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	morning.EndAt(day.Add(10 * time.Hour))
+	afternoon := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(13 * time.Hour)})
+	afternoon.EndAt(day.Add(15 * time.Hour))
+	ds := &DailySessions{Date: day, Sessions: []*Session{morning, afternoon}}
+
+	assert.Len(t, ds.AsOf(day.Add(12*time.Hour)).Sessions, 1)
+	assert.Len(t, ds.AsOf(day.Add(14*time.Hour)).Sessions, 2)
+
+	start, end, ok := ds.ActivitySpan()
+	assert.True(t, ok)
+	assert.Equal(t, day.Add(9*time.Hour), start)
+	assert.Equal(t, day.Add(15*time.Hour), end)
+
+	_, _, ok = (&DailySessions{Date: day}).ActivitySpan()
+	assert.False(t, ok)
+}
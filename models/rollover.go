@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Day rollover modes for active sessions left over from the previous day
+const (
+	RolloverMove  = "move"  // Move the whole session into the new day
+	RolloverSplit = "split" // End the session at the day boundary and continue in a linked one
+)
+
+// SplitAt ends the session at t and returns a new session continuing it from t, linked
+// through ContinuationOf. An ongoing interruption is closed at t and reopened in the
+// continuation, as is focus mode; the estimate carries over as the work still remaining.
+func (session *Session) SplitAt(t time.Time) *Session {
+	var ongoing *TimeEntry
+	if len(session.SubSessions) > 0 {
+		current := session.SubSessions[len(session.SubSessions)-1]
+		if len(current.Interruptions)%2 != 0 {
+			ongoing = current.Interruptions[len(current.Interruptions)-1]
+		}
+	}
+
+	focusMode := session.FocusModeOn()
+	session.EndAt(t)
+	work, _, _ := session.GetStats()
+
+	start := &TimeEntry{
+		ID:          fmt.Sprintf("%d", t.UnixNano()+1),
+		Type:        EntryTypeStart,
+		StartTime:   t,
+		Description: session.Start.Description,
+	}
+	continuation := NewSession(start)
+	continuation.ID = fmt.Sprintf("sess_%d", t.UnixNano())
+	continuation.Project = session.Project
+	continuation.ContinuationOf = session.ID
+	if session.Estimate > work {
+		continuation.Estimate = session.Estimate - work
+	}
+
+	if focusMode {
+		continuation.StartFocusMode(t)
+	}
+
+	if ongoing != nil {
+		// Kept in both lists, as recording an interruption does
+		reopened := &TimeEntry{
+			ID:          fmt.Sprintf("%d", t.UnixNano()+2),
+			Type:        EntryTypeInterruption,
+			StartTime:   t,
+			Description: ongoing.Description,
+			Tag:         ongoing.Tag,
+		}
+		continuation.Interruptions = append(continuation.Interruptions, reopened)
+		continuation.SubSessions[0].Interruptions = append(continuation.SubSessions[0].Interruptions, reopened)
+	}
+
+	return continuation
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSessionSplitAt tests splitting keeps each day's share of the work and links the
+// continuation to the original session
+func TestSessionSplitAt(t *testing.T) {
+	midnight := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: midnight.Add(-2 * time.Hour), Description: "Release"})
+	session.ID = "sess_1"
+	session.Project = "acme"
+	session.Estimate = 3 * time.Hour
+	session.SubSessions[0].Interruptions = append(session.SubSessions[0].Interruptions,
+		&TimeEntry{Type: EntryTypeInterruption, StartTime: midnight.Add(-30 * time.Minute), Tag: TagCall, Description: "Support"})
+
+	continuation := session.SplitAt(midnight)
+
+	assert.NotNil(t, session.End)
+	assert.Equal(t, midnight, session.End.StartTime)
+	work, interrupted, _ := session.GetStats()
+	assert.Equal(t, 90*time.Minute, work)
+	assert.Equal(t, 30*time.Minute, interrupted)
+
+	assert.NotEqual(t, session.ID, continuation.ID)
+	assert.Equal(t, "sess_1", continuation.ContinuationOf)
+	assert.Equal(t, "Release", continuation.Start.Description)
+	assert.Equal(t, midnight, continuation.Start.StartTime)
+	assert.Equal(t, "acme", continuation.Project)
+	assert.Equal(t, 90*time.Minute, continuation.Estimate)
+	assert.Nil(t, continuation.End)
+
+	// The interruption goes on in the new day
+	interruptions := continuation.SubSessions[0].Interruptions
+	assert.Len(t, interruptions, 1)
+	assert.Equal(t, TagCall, interruptions[0].Tag)
+	assert.Equal(t, midnight, interruptions[0].StartTime)
+	if assert.Len(t, continuation.Interruptions, 1) {
+		assert.Same(t, interruptions[0], continuation.Interruptions[0])
+	}
+}
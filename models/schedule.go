@@ -0,0 +1,119 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weekdayNames maps day names and their three letter abbreviations to weekdays
+var weekdayNames = map[string]time.Weekday{}
+
+func init() {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		weekdayNames[name] = day
+		weekdayNames[name[:3]] = day
+	}
+}
+
+// parseWeekday parses a day name such as "monday" or "mon"
+func parseWeekday(value string) (time.Weekday, error) {
+	day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return 0, fmt.Errorf("invalid day %q, use e.g. mon or monday", value)
+	}
+	return day, nil
+}
+
+// ParseWorkDays parses day names ("mon", "tuesday") and ranges of days ("mon-fri") in
+// week order, nil when there are none
+func ParseWorkDays(values []string) ([]time.Weekday, error) {
+	var working [7]bool
+	for _, value := range values {
+		from, to, isRange := strings.Cut(value, "-")
+		first, err := parseWeekday(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = parseWeekday(to); err != nil {
+				return nil, err
+			}
+		}
+
+		// Ranges may wrap around the week, e.g. "sat-mon"
+		for day := first; ; day = (day + 1) % 7 {
+			working[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+
+	var days []time.Weekday
+	for _, day := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		if working[day] {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// ParseWorkSchedule parses a work schedule: working days, Monday to Friday when empty, and
+// "HH:MM" start and end times, the defaults when empty
+func ParseWorkSchedule(days []string, start, end string) (WorkingHours, error) {
+	hours, err := ParseWorkingHours(start, end, false)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	if hours.Days, err = ParseWorkDays(days); err != nil {
+		return WorkingHours{}, err
+	}
+	return hours, nil
+}
+
+// WorksOn reports whether the day of t is a working day
+func (w WorkingHours) WorksOn(t time.Time) bool {
+	if len(w.Days) > 0 {
+		for _, day := range w.Days {
+			if t.Weekday() == day {
+				return true
+			}
+		}
+		return false
+	}
+	return w.Weekends || (t.Weekday() != time.Saturday && t.Weekday() != time.Sunday)
+}
+
+// Within returns how much of the time from start to end falls within working hours
+func (w WorkingHours) Within(start, end time.Time) time.Duration {
+	var total time.Duration
+	for day := w.StartOn(start); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !w.WorksOn(day) {
+			continue
+		}
+		from, until := day, day.Add(w.End-w.Start)
+		if start.After(from) {
+			from = start
+		}
+		if end.Before(until) {
+			until = end
+		}
+		if until.After(from) {
+			total += until.Sub(from)
+		}
+	}
+	return total
+}
+
+// WorkWithin returns the work time of the session within working hours, ongoing work
+// counted up to now
+func (session *Session) WorkWithin(hours WorkingHours, now time.Time) time.Duration {
+	var total time.Duration
+	for _, period := range session.workPeriods(now) {
+		total += hours.Within(period.start, period.end)
+	}
+	return total
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseWorkDays tests day names, abbreviations and ranges, wrapping ones included
+func TestParseWorkDays(t *testing.T) {
+	days, err := ParseWorkDays([]string{"mon-wed", "Friday"})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Friday}, days)
+
+	days, err = ParseWorkDays([]string{"sat-mon"})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Saturday, time.Sunday}, days)
+
+	days, err = ParseWorkDays(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, days)
+
+	_, err = ParseWorkDays([]string{"mon-someday"})
+	assert.Error(t, err)
+}
+
+// TestWorkSchedule tests the working days of a schedule and the time within it
+func TestWorkSchedule(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	hours, err := ParseWorkSchedule(nil, "", "")
+	assert.NoError(t, err)
+	assert.True(t, hours.WorksOn(monday))
+	assert.False(t, hours.WorksOn(monday.AddDate(0, 0, 5)))
+
+	hours, err = ParseWorkSchedule([]string{"sun-thu"}, "08:00", "12:00")
+	assert.NoError(t, err)
+	assert.False(t, hours.Contains(monday.AddDate(0, 0, 4).Add(9*time.Hour)))
+	assert.True(t, hours.Contains(monday.AddDate(0, 0, 6).Add(9*time.Hour)))
+
+	// Only the working hours of working days count, across days
+	assert.Equal(t, 2*time.Hour, hours.Within(monday.Add(10*time.Hour), monday.Add(20*time.Hour)))
+	assert.Equal(t, 5*time.Hour, hours.Within(monday.Add(11*time.Hour), monday.AddDate(0, 0, 1).Add(20*time.Hour)))
+	assert.Equal(t, 6*time.Hour, hours.Within(monday.AddDate(0, 0, 3), monday.AddDate(0, 0, 6).Add(10*time.Hour)))
+
+	_, err = ParseWorkSchedule([]string{"weekdays"}, "", "")
+	assert.Error(t, err)
+	_, err = ParseWorkSchedule(nil, "12:00", "08:00")
+	assert.Error(t, err)
+
+	// Work off hours and time away are not scheduled work
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: monday.Add(7 * time.Hour)})
+	interruption := &TimeEntry{Type: EntryTypeInterruption, StartTime: monday.Add(9 * time.Hour)}
+	back := &TimeEntry{Type: EntryTypeReturn, StartTime: monday.Add(10 * time.Hour)}
+	session.Interruptions = []*TimeEntry{interruption, back}
+	session.SubSessions[0].Interruptions = []*TimeEntry{interruption, back}
+	assert.Equal(t, 3*time.Hour, session.WorkWithin(hours, monday.Add(12*time.Hour+30*time.Minute)))
+}
@@ -0,0 +1,29 @@
+package models
+
+// OpenInterruption returns the interruption the session is currently in, if any
+func (session *Session) OpenInterruption() (*TimeEntry, bool) {
+	if len(session.SubSessions) == 0 {
+		return nil, false
+	}
+	current := session.SubSessions[len(session.SubSessions)-1]
+	if current.End != nil || len(current.Interruptions)%2 == 0 {
+		return nil, false
+	}
+	return current.Interruptions[len(current.Interruptions)-1], true
+}
+
+// DiscardOpenInterruption removes the interruption the session is currently in, as if it
+// never started, and returns it
+func (session *Session) DiscardOpenInterruption() (*TimeEntry, bool) {
+	interruption, ok := session.OpenInterruption()
+	if !ok {
+		return nil, false
+	}
+
+	current := session.SubSessions[len(session.SubSessions)-1]
+	current.Interruptions = current.Interruptions[:len(current.Interruptions)-1]
+	if n := len(session.Interruptions); n > 0 && session.Interruptions[n-1] == interruption {
+		session.Interruptions = session.Interruptions[:n-1]
+	}
+	return interruption, true
+}
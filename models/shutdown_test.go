@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiscardOpenInterruption tests the open interruption is removed from the sub-session
+// and the session alike, and closed ones are left alone
+func TestDiscardOpenInterruption(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+
+	_, ok := session.OpenInterruption()
+	assert.False(t, ok)
+
+	call := &TimeEntry{Type: EntryTypeInterruption, StartTime: day.Add(10 * time.Hour)}
+	back := &TimeEntry{Type: EntryTypeReturn, StartTime: day.Add(10*time.Hour + 5*time.Minute)}
+	chat := &TimeEntry{Type: EntryTypeInterruption, StartTime: day.Add(11 * time.Hour)}
+	session.Interruptions = []*TimeEntry{call, back, chat}
+	session.SubSessions[0].Interruptions = []*TimeEntry{call, back, chat}
+
+	open, ok := session.OpenInterruption()
+	assert.True(t, ok)
+	assert.Same(t, chat, open)
+
+	discarded, ok := session.DiscardOpenInterruption()
+	assert.True(t, ok)
+	assert.Same(t, chat, discarded)
+	assert.Equal(t, []*TimeEntry{call, back}, session.Interruptions)
+	assert.Equal(t, []*TimeEntry{call, back}, session.SubSessions[0].Interruptions)
+
+	_, ok = session.DiscardOpenInterruption()
+	assert.False(t, ok)
+}
+
+// TestEndAtClosesOpenInterruption tests ending an interrupted session returns from the
+// interruption in the sub-session and the session alike
+func TestEndAtClosesOpenInterruption(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	call := &TimeEntry{Type: EntryTypeInterruption, StartTime: day.Add(10 * time.Hour)}
+	session.Interruptions = []*TimeEntry{call}
+	session.SubSessions[0].Interruptions = []*TimeEntry{call}
+
+	session.EndAt(day.Add(11 * time.Hour))
+
+	assert.Len(t, session.Interruptions, 2)
+	assert.Len(t, session.SubSessions[0].Interruptions, 2)
+	_, interruption, count := session.GetStats()
+	assert.Equal(t, time.Hour, interruption)
+	assert.Equal(t, 1, count)
+}
@@ -7,45 +7,141 @@ import (
 // DetailedStats contains comprehensive statistics for analysis
 type DetailedStats struct {
 	// Date range for the statistics
-	StartDate time.Time
-	EndDate   time.Time
+	StartDate time.Time `json:"start_date" yaml:"start_date"`
+	EndDate   time.Time `json:"end_date" yaml:"end_date"`
 
 	// Overall work stats
-	TotalWorkDuration  time.Duration
-	TotalSessions      int
-	LongestSession     time.Duration
-	AverageSessionTime time.Duration
+	TotalWorkDuration  time.Duration `json:"total_work_duration" yaml:"total_work_duration"`
+	TotalSessions      int           `json:"total_sessions" yaml:"total_sessions"`
+	LongestSession     time.Duration `json:"longest_session" yaml:"longest_session"`
+	AverageSessionTime time.Duration `json:"average_session_time" yaml:"average_session_time"`
+
+	// How interruptions were counted
+	Policy StatsPolicy `json:"policy" yaml:"policy"`
 
 	// Interruption stats
-	TotalInterruptions        int
-	InterruptionsByTag        map[InterruptionTag]int
-	InterruptionDurationByTag map[InterruptionTag]time.Duration
+	TotalInterruptions        int                               `json:"total_interruptions" yaml:"total_interruptions"`
+	TotalInterruptionDuration time.Duration                     `json:"total_interruption_duration" yaml:"total_interruption_duration"` // Including recovery when the policy counts it
+	InterruptionsByTag        map[InterruptionTag]int           `json:"interruptions_by_tag" yaml:"interruptions_by_tag"`
+	InterruptionDurationByTag map[InterruptionTag]time.Duration `json:"interruption_duration_by_tag" yaml:"interruption_duration_by_tag"`
+	RecoveryByTag             map[InterruptionTag]time.Duration `json:"recovery_by_tag" yaml:"recovery_by_tag"` // Recovery after the interruptions as the policy assumes it
+
+	// Accepted vs deferred interruptions, in total and by date string
+	Deflections      DeflectionCount            `json:"deflections" yaml:"deflections"`
+	DailyDeflections map[string]DeflectionCount `json:"daily_deflections" yaml:"daily_deflections"`
+
+	// Interruptions despite focus mode against the ones outside it
+	DND DNDSummary `json:"dnd" yaml:"dnd"`
 
 	// Time analysis
-	DailyWorkDurations map[string]time.Duration // Map of date string to duration
-	HourlyProductivity map[int]time.Duration    // Map of hour (0-23) to duration
+	DailyWorkDurations map[string]time.Duration `json:"daily_work_durations" yaml:"daily_work_durations"` // Map of date string to duration
+	HourlyProductivity map[int]time.Duration    `json:"hourly_productivity" yaml:"hourly_productivity"`   // Map of hour (0-23) to duration
+
+	// Map of date string to the longest stretch of work without an interruption that day,
+	// which total hours hide when the day was fragmented
+	DailyLongestFocus map[string]time.Duration `json:"daily_longest_focus" yaml:"daily_longest_focus"`
+
+	// Work actually done and interruptions started in each hour (0-23), for the chance of
+	// being interrupted by time of day
+	HourlyWorkTime      map[int]time.Duration `json:"hourly_work_time" yaml:"hourly_work_time"`
+	HourlyInterruptions map[int]int           `json:"hourly_interruptions" yaml:"hourly_interruptions"`
+
+	// Map of weekday to focused work started on that weekday
+	WeekdayProductivity map[time.Weekday]time.Duration `json:"weekday_productivity" yaml:"weekday_productivity"`
+
+	// Map of date string to the focused work started in each hour (0-23) of that day
+	DailyHourlyProductivity map[string]map[int]time.Duration `json:"daily_hourly_productivity" yaml:"daily_hourly_productivity"`
+
+	// Estimated vs actual work time of completed sessions
+	Estimates EstimateAccuracy `json:"estimates" yaml:"estimates"`
+
+	// Recurring tasks
+	Tasks TaskGroups `json:"tasks" yaml:"tasks"` // Completed sessions grouped by similar descriptions
 
 	// Generated metrics
-	ProductivityScore float64 // 0-100 score based on focus time vs interruptions
+	ProductivityScore float64 `json:"productivity_score" yaml:"productivity_score"` // 0-100 score based on focus time vs interruptions
 }
 
 // CalculateProductivityScore computes a productivity score based on work and interruption patterns
 func (s *DetailedStats) CalculateProductivityScore() float64 {
-	if s.TotalWorkDuration == 0 {
-		return 0
+	s.ProductivityScore = s.productivityScore(s.scoreRecoveryTime())
+	return s.ProductivityScore
+}
+
+// RecoveryFor returns the recovery after the interruptions with the tag: as counted by
+// the policy, or the assumed 10 minutes per interruption for stats counted without one
+func (s *DetailedStats) RecoveryFor(tag InterruptionTag) time.Duration {
+	if s.RecoveryByTag != nil {
+		return s.RecoveryByTag[tag]
+	}
+	recovery := s.Policy.Recovery
+	if recovery <= 0 {
+		recovery = AssumedRecoveryTime
 	}
+	return time.Duration(s.InterruptionsByTag[tag]) * recovery
+}
+
+// TotalRecovery returns the recovery after all interruptions
+func (s *DetailedStats) TotalRecovery() time.Duration {
+	var total time.Duration
+	for tag := range s.InterruptionsByTag {
+		total += s.RecoveryFor(tag)
+	}
+	return total
+}
 
-	// Calculate total interruption time
-	var totalInterruptionTime time.Duration
+// scoreRecoveryTime returns the recovery after the interruptions that count toward the score
+func (s *DetailedStats) scoreRecoveryTime() time.Duration {
+	var total time.Duration
+	for tag := range s.InterruptionsByTag {
+		if !s.Policy.TagPolicy(tag).NoScorePenalty {
+			total += s.RecoveryFor(tag)
+		}
+	}
+	return total
+}
+
+// totalInterruptionTime returns the pure interruption time over all tags
+func (s *DetailedStats) totalInterruptionTime() time.Duration {
+	var total time.Duration
 	for _, duration := range s.InterruptionDurationByTag {
-		totalInterruptionTime += duration
+		total += duration
 	}
+	return total
+}
+
+// scoreInterruptionTime returns the pure interruption time over the tags that count
+// toward the score
+func (s *DetailedStats) scoreInterruptionTime() time.Duration {
+	var total time.Duration
+	for tag, duration := range s.InterruptionDurationByTag {
+		if !s.Policy.TagPolicy(tag).NoScorePenalty {
+			total += duration
+		}
+	}
+	return total
+}
 
-	// Calculate recovery time (10 minutes per interruption)
-	recoveryTime := time.Duration(s.TotalInterruptions) * 10 * time.Minute
+// scoreInterruptions returns the number of interruptions that count toward the score
+func (s *DetailedStats) scoreInterruptions() int {
+	total := s.TotalInterruptions
+	for tag, count := range s.InterruptionsByTag {
+		if s.Policy.TagPolicy(tag).NoScorePenalty {
+			total -= count
+		}
+	}
+	return total
+}
+
+// productivityScore computes the 0-100 score assuming the given recovery time after the
+// interruptions that count toward the score
+func (s *DetailedStats) productivityScore(recoveryTime time.Duration) float64 {
+	if s.TotalWorkDuration == 0 {
+		return 0
+	}
 
 	// Total impacted time
-	totalImpactedTime := totalInterruptionTime + recoveryTime
+	totalImpactedTime := s.scoreInterruptionTime() + recoveryTime
 
 	// Calculate work ratio (pure work time / total time)
 	totalTime := s.TotalWorkDuration + totalImpactedTime
@@ -55,7 +151,7 @@ func (s *DetailedStats) CalculateProductivityScore() float64 {
 	score := workRatio * 100
 
 	// Apply penalties for too many interruptions
-	interruptionRatio := float64(s.TotalInterruptions) / float64(s.TotalSessions)
+	interruptionRatio := float64(s.scoreInterruptions()) / float64(s.TotalSessions)
 	if interruptionRatio > 0.5 {
 		// Apply penalty for high interruption rate
 		penaltyFactor := (interruptionRatio - 0.5) * 0.2 // Up to 20% penalty
@@ -67,7 +163,6 @@ func (s *DetailedStats) CalculateProductivityScore() float64 {
 		score = 100
 	}
 
-	s.ProductivityScore = score
 	return score
 }
 
@@ -92,7 +187,7 @@ func (s *DetailedStats) GetInterruptionBreakdown() []InterruptionTagStats {
 
 	for tag, count := range s.InterruptionsByTag {
 		duration := s.InterruptionDurationByTag[tag]
-		recoveryTime := time.Duration(count) * 10 * time.Minute
+		recoveryTime := s.RecoveryFor(tag)
 
 		stats := InterruptionTagStats{
 			Tag:               tag,
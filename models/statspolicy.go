@@ -0,0 +1,158 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// StatsPolicy decides how interruptions are counted, so every view and export reports
+// the same interruption time. The zero value counts completed interruptions only, without
+// recovery, as GetStats does.
+type StatsPolicy struct {
+	IncludeRecovery bool                          `json:"include_recovery" yaml:"include_recovery"` // Count the recovery after each interruption as interruption time
+	Recovery        time.Duration                 `json:"recovery" yaml:"recovery"`                 // Recovery after each completed interruption
+	CapBySession    bool                          `json:"cap_by_session" yaml:"cap_by_session"`     // Never count more interruption time than a work period lasted
+	CountActive     bool                          `json:"count_active" yaml:"count_active"`         // Count an interruption without return up to now or the end of its period
+	Tags            map[InterruptionTag]TagPolicy `json:"tags,omitempty" yaml:"tags,omitempty"`     // Exceptions for interruptions with these tags, lower case
+}
+
+// TagPolicy changes how interruptions with a tag are counted. The zero value counts them
+// like any other interruption.
+type TagPolicy struct {
+	NotInterruption bool `json:"not_interruption,omitempty" yaml:"not_interruption,omitempty"` // Time away is a planned break, neither work nor interruption
+	NoRecovery      bool `json:"no_recovery,omitempty" yaml:"no_recovery,omitempty"`           // No recovery after it, e.g. for meetings chosen to attend
+	NoScorePenalty  bool `json:"no_score_penalty,omitempty" yaml:"no_score_penalty,omitempty"` // Left out of the productivity score
+}
+
+// TagPolicy returns how interruptions with the tag are counted, ignoring case
+func (p StatsPolicy) TagPolicy(tag InterruptionTag) TagPolicy {
+	if policy, ok := p.Tags[tag]; ok {
+		return policy
+	}
+	return p.Tags[InterruptionTag(strings.ToLower(string(tag)))]
+}
+
+// RecoveryFor returns the recovery assumed after an interruption with the tag
+func (p StatsPolicy) RecoveryFor(tag InterruptionTag) time.Duration {
+	if p.TagPolicy(tag).NoRecovery {
+		return 0
+	}
+	return p.Recovery
+}
+
+// CountedInterruption is an interruption as counted by a StatsPolicy
+type CountedInterruption struct {
+	Tag      InterruptionTag
+	Start    time.Time
+	Away     time.Duration // Time away from work
+	Recovery time.Duration // Recovery after returning, as the policy assumes it
+	Duration time.Duration // Time counted as interruption, the recovery included when the policy counts it
+	Active   bool          // Still ongoing, counted up to now
+}
+
+// SessionBreakdown is the time of a session as counted by a StatsPolicy
+type SessionBreakdown struct {
+	Work          time.Duration
+	Interruption  time.Duration
+	Break         time.Duration // Time away for tags that are not interruptions
+	Interruptions []CountedInterruption
+}
+
+// Breakdown splits the time of the session into work and interruptions under the policy
+func (p StatsPolicy) Breakdown(session *Session, now time.Time) SessionBreakdown {
+	var breakdown SessionBreakdown
+	if session.Start == nil {
+		return breakdown
+	}
+
+	// Sessions from before work periods were tracked have a single period
+	periods := session.SubSessions
+	if len(periods) == 0 {
+		periods = []*SubSession{{Start: session.Start, End: session.End, Interruptions: session.Interruptions}}
+	}
+
+	for _, period := range periods {
+		if period.Start == nil {
+			continue
+		}
+		end := now
+		if period.End != nil {
+			end = period.End.StartTime
+		}
+		elapsed := end.Sub(period.Start.StartTime)
+
+		var interrupted, away time.Duration
+		for i := 0; i < len(period.Interruptions); i += 2 {
+			interruption := period.Interruptions[i]
+			counted := CountedInterruption{Tag: interruption.Tag, Start: interruption.StartTime}
+			if counted.Tag == "" {
+				counted.Tag = TagOther
+			}
+			tagPolicy := p.TagPolicy(counted.Tag)
+
+			if i+1 < len(period.Interruptions) {
+				back := period.Interruptions[i+1].StartTime
+				counted.Away = back.Sub(interruption.StartTime)
+				counted.Recovery = p.RecoveryFor(counted.Tag)
+				if p.CapBySession && back.Add(counted.Recovery).After(end) {
+					counted.Recovery = end.Sub(back)
+				}
+				if counted.Recovery < 0 {
+					counted.Recovery = 0
+				}
+			} else if p.CountActive {
+				// Left open in an ended period, it lasted until the period ended
+				counted.Away = end.Sub(interruption.StartTime)
+				counted.Active = period.End == nil
+			} else {
+				continue
+			}
+
+			// Planned breaks are not work, but no interruption either
+			if tagPolicy.NotInterruption {
+				away += counted.Away
+				continue
+			}
+
+			counted.Duration = counted.Away
+			if p.IncludeRecovery {
+				counted.Duration += counted.Recovery
+			}
+			interrupted += counted.Duration
+			breakdown.Interruptions = append(breakdown.Interruptions, counted)
+		}
+
+		if p.CapBySession {
+			if away > elapsed {
+				away = elapsed
+			}
+			if interrupted > elapsed-away {
+				interrupted = elapsed - away
+			}
+		}
+		breakdown.Work += elapsed - interrupted - away
+		breakdown.Interruption += interrupted
+		breakdown.Break += away
+	}
+
+	return breakdown
+}
+
+// SessionStats returns the work time, interruption time and number of interruptions of
+// the session under the policy
+func (p StatsPolicy) SessionStats(session *Session, now time.Time) (workDuration, interruptionDuration time.Duration, interruptionCount int) {
+	breakdown := p.Breakdown(session, now)
+	return breakdown.Work, breakdown.Interruption, len(breakdown.Interruptions)
+}
+
+// DayStats returns the work time, interruption time and number of interruptions of all
+// sessions of the day under the policy
+func (p StatsPolicy) DayStats(day *DailySessions, now time.Time) (workDuration, interruptionDuration time.Duration, interruptionCount int) {
+	for _, session := range day.Sessions {
+		work, interruption, count := p.SessionStats(session, now)
+		workDuration += work
+		interruptionDuration += interruption
+		interruptionCount += count
+	}
+	return workDuration, interruptionDuration, interruptionCount
+}
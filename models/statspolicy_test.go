@@ -0,0 +1,159 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// policySession returns a session from 9:00 to 10:00 interrupted by a 20 minute call
+// returning at 9:55, and by a chat started at 9:58 still open at the end
+func policySession(day time.Time, ended bool) *Session {
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	interruptions := []*TimeEntry{
+		{Type: EntryTypeInterruption, Tag: TagCall, StartTime: day.Add(9*time.Hour + 35*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(9*time.Hour + 55*time.Minute)},
+		{Type: EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 58*time.Minute)},
+	}
+	session.Interruptions = interruptions
+	session.SubSessions[0].Interruptions = interruptions
+	if ended {
+		end := &TimeEntry{Type: EntryTypeEnd, StartTime: day.Add(10 * time.Hour)}
+		session.End = end
+		session.SubSessions[0].End = end
+	}
+	return session
+}
+
+// TestStatsPolicyZeroValueMatchesGetStats tests the zero policy counts as GetStats does
+func TestStatsPolicyZeroValueMatchesGetStats(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := policySession(day, true)
+
+	work, interruption, count := StatsPolicy{}.SessionStats(session, day.Add(12*time.Hour))
+	expectedWork, expectedInterruption, expectedCount := session.GetStats()
+	assert.Equal(t, expectedWork, work)
+	assert.Equal(t, expectedInterruption, interruption)
+	assert.Equal(t, expectedCount, count)
+	assert.Equal(t, 20*time.Minute, interruption)
+}
+
+// TestStatsPolicyToggles tests each toggle of the policy on the same session
+func TestStatsPolicyToggles(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	now := day.Add(10 * time.Hour)
+
+	tests := []struct {
+		name         string
+		policy       StatsPolicy
+		ended        bool
+		interruption time.Duration
+		count        int
+	}{
+		{"recovery", StatsPolicy{IncludeRecovery: true, Recovery: 10 * time.Minute}, true, 30 * time.Minute, 1},
+		{"recovery capped by the session", StatsPolicy{IncludeRecovery: true, Recovery: 10 * time.Minute, CapBySession: true}, true, 25 * time.Minute, 1},
+		{"active interruption ignored", StatsPolicy{}, false, 20 * time.Minute, 1},
+		{"active interruption counted", StatsPolicy{CountActive: true}, false, 22 * time.Minute, 2},
+		{"open interruption counted until the end", StatsPolicy{CountActive: true}, true, 22 * time.Minute, 2},
+		{"everything", StatsPolicy{IncludeRecovery: true, Recovery: 10 * time.Minute, CapBySession: true, CountActive: true}, false, 27 * time.Minute, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breakdown := tt.policy.Breakdown(policySession(day, tt.ended), now)
+			assert.Equal(t, tt.interruption, breakdown.Interruption)
+			assert.Equal(t, time.Hour-tt.interruption, breakdown.Work)
+			assert.Len(t, breakdown.Interruptions, tt.count)
+			assert.Equal(t, TagCall, breakdown.Interruptions[0].Tag)
+			if tt.count > 1 {
+				assert.Equal(t, !tt.ended, breakdown.Interruptions[1].Active)
+			}
+		})
+	}
+}
+
+// TestStatsPolicyCapsInterruptionByPeriod tests overlapping recovery never makes the work
+// time negative when capped
+func TestStatsPolicyCapsInterruptionByPeriod(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	var interruptions []*TimeEntry
+	for i := 0; i < 4; i++ {
+		start := day.Add(9*time.Hour + time.Duration(i)*5*time.Minute)
+		interruptions = append(interruptions,
+			&TimeEntry{Type: EntryTypeInterruption, StartTime: start},
+			&TimeEntry{Type: EntryTypeReturn, StartTime: start.Add(time.Minute)})
+	}
+	session.Interruptions = interruptions
+	session.SubSessions[0].Interruptions = interruptions
+	end := &TimeEntry{Type: EntryTypeEnd, StartTime: day.Add(9*time.Hour + 20*time.Minute)}
+	session.End, session.SubSessions[0].End = end, end
+
+	policy := StatsPolicy{IncludeRecovery: true, Recovery: 10 * time.Minute, CapBySession: true}
+	work, interruption, count := policy.SessionStats(session, day.Add(12*time.Hour))
+	assert.Equal(t, time.Duration(0), work)
+	assert.Equal(t, 20*time.Minute, interruption)
+	assert.Equal(t, 4, count)
+}
+
+// TestStatsPolicyTags tests breaks are neither work nor interruption and tags without
+// recovery add none, whatever the case of the tag
+func TestStatsPolicyTags(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	interruptions := []*TimeEntry{
+		{Type: EntryTypeInterruption, Tag: "Lunch", StartTime: day.Add(9*time.Hour + 10*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(9*time.Hour + 40*time.Minute)},
+		{Type: EntryTypeInterruption, Tag: TagMeeting, StartTime: day.Add(10 * time.Hour)},
+		{Type: EntryTypeReturn, StartTime: day.Add(10*time.Hour + 20*time.Minute)},
+		{Type: EntryTypeInterruption, Tag: TagCall, StartTime: day.Add(10*time.Hour + 30*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(10*time.Hour + 35*time.Minute)},
+	}
+	session.Interruptions = interruptions
+	session.SubSessions[0].Interruptions = interruptions
+	end := &TimeEntry{Type: EntryTypeEnd, StartTime: day.Add(11 * time.Hour)}
+	session.End, session.SubSessions[0].End = end, end
+
+	policy := StatsPolicy{
+		IncludeRecovery: true,
+		Recovery:        10 * time.Minute,
+		Tags: map[InterruptionTag]TagPolicy{
+			"lunch":    {NotInterruption: true},
+			TagMeeting: {NoRecovery: true},
+		},
+	}
+	assert.True(t, policy.TagPolicy("LUNCH").NotInterruption)
+	assert.Equal(t, time.Duration(0), policy.RecoveryFor(TagMeeting))
+	assert.Equal(t, 10*time.Minute, policy.RecoveryFor(TagCall))
+
+	breakdown := policy.Breakdown(session, day.Add(12*time.Hour))
+	assert.Equal(t, 30*time.Minute, breakdown.Break)
+	assert.Equal(t, 35*time.Minute, breakdown.Interruption) // 20m meeting, 5m call and 10m recovery
+	assert.Equal(t, 55*time.Minute, breakdown.Work)
+	assert.Len(t, breakdown.Interruptions, 2)
+	assert.Equal(t, time.Duration(0), breakdown.Interruptions[0].Recovery)
+	assert.Equal(t, 5*time.Minute, breakdown.Interruptions[1].Away)
+}
+
+// TestProductivityScoreTagPolicies tests tags without score penalty are left out of the
+// score but not of the recovery counted
+func TestProductivityScoreTagPolicies(t *testing.T) {
+	stats := &DetailedStats{
+		Policy:             StatsPolicy{Tags: map[InterruptionTag]TagPolicy{TagMeeting: {NoScorePenalty: true}}},
+		TotalWorkDuration:  4 * time.Hour,
+		TotalSessions:      4,
+		TotalInterruptions: 3,
+		InterruptionsByTag: map[InterruptionTag]int{TagCall: 1, TagMeeting: 2},
+		InterruptionDurationByTag: map[InterruptionTag]time.Duration{
+			TagCall:    10 * time.Minute,
+			TagMeeting: 50 * time.Minute,
+		},
+		RecoveryByTag: map[InterruptionTag]time.Duration{TagCall: 10 * time.Minute, TagMeeting: 20 * time.Minute},
+	}
+
+	// Only the call: 4h of work against 20 minutes lost
+	assert.InDelta(t, 240.0/260*100, stats.CalculateProductivityScore(), 0.001)
+	assert.Equal(t, 30*time.Minute, stats.TotalRecovery())
+	assert.Equal(t, 20*time.Minute, stats.RecoveryFor(TagMeeting))
+}
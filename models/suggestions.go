@@ -0,0 +1,140 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TaskSuggestion is a task offered when starting a session
+type TaskSuggestion struct {
+	Description string
+	Estimate    time.Duration // Estimate to start with, 0 for none
+	Project     string        // Project to start with, empty for the workspace project
+	Favorite    bool          // Pinned in the configuration
+	LastUsed    time.Time     // Start of the latest session with this description
+	Uses        int           // Sessions with this description among those looked at
+}
+
+// RecentTasks returns the distinct descriptions of the sessions, most recently started
+// first, with the estimate of their latest session
+func RecentTasks(days []*DailySessions) []TaskSuggestion {
+	byDescription := make(map[string]*TaskSuggestion)
+	for _, day := range days {
+		for _, session := range day.Sessions {
+			if session.Start == nil {
+				continue
+			}
+			description := strings.TrimSpace(session.Start.Description)
+			if description == "" {
+				continue
+			}
+
+			key := strings.ToLower(description)
+			task, exists := byDescription[key]
+			if !exists {
+				task = &TaskSuggestion{Description: description}
+				byDescription[key] = task
+			}
+			task.Uses++
+			if session.Start.StartTime.After(task.LastUsed) {
+				task.Description = description
+				task.LastUsed = session.Start.StartTime
+				task.Estimate = session.Estimate
+			}
+		}
+	}
+
+	recent := make([]TaskSuggestion, 0, len(byDescription))
+	for _, task := range byDescription {
+		recent = append(recent, *task)
+	}
+	sort.Slice(recent, func(i, j int) bool {
+		return recent[i].LastUsed.After(recent[j].LastUsed)
+	})
+	return recent
+}
+
+// FuzzyScore reports whether the characters of query appear in text in order, ignoring
+// case and spaces, and how well: consecutive characters, word starts and a matching prefix
+// score higher. An empty query matches everything with a score of 0.
+func FuzzyScore(query, text string) (int, bool) {
+	needle := []rune(strings.ToLower(strings.Join(strings.Fields(query), "")))
+	if len(needle) == 0 {
+		return 0, true
+	}
+	haystack := []rune(strings.ToLower(text))
+
+	score, position, previous := 0, 0, -2
+	for _, char := range needle {
+		found := -1
+		for i := position; i < len(haystack); i++ {
+			if haystack[i] == char {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			return 0, false
+		}
+
+		score++
+		if found == previous+1 {
+			score += 5
+		}
+		if found == 0 || !unicode.IsLetter(haystack[found-1]) && !unicode.IsDigit(haystack[found-1]) {
+			score += 3
+		}
+		previous, position = found, found+1
+	}
+
+	if strings.HasPrefix(strings.ToLower(text), strings.ToLower(strings.TrimSpace(query))) {
+		score += 15
+	}
+	return score, true
+}
+
+// FilterTaskSuggestions returns up to limit suggestions matching the query. Favorites come
+// before recent tasks, which skip descriptions already pinned; a query ranks the best
+// matches first.
+func FilterTaskSuggestions(favorites, recent []TaskSuggestion, query string, limit int) []TaskSuggestion {
+	type candidate struct {
+		task  TaskSuggestion
+		score int
+		order int
+	}
+
+	pinned := make(map[string]bool)
+	var candidates []candidate
+	add := func(task TaskSuggestion) {
+		if score, ok := FuzzyScore(query, task.Description); ok {
+			candidates = append(candidates, candidate{task: task, score: score, order: len(candidates)})
+		}
+	}
+	for _, task := range favorites {
+		pinned[strings.ToLower(task.Description)] = true
+		add(task)
+	}
+	for _, task := range recent {
+		if !pinned[strings.ToLower(task.Description)] {
+			add(task)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].order < candidates[j].order
+	})
+
+	suggestions := []TaskSuggestion{}
+	for _, c := range candidates {
+		if limit > 0 && len(suggestions) == limit {
+			break
+		}
+		suggestions = append(suggestions, c.task)
+	}
+	return suggestions
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRecentTasks tests descriptions are deduplicated and ordered by their latest session
+func TestRecentTasks(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	session := func(description string, hour int, estimate time.Duration) *Session {
+		s := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(time.Duration(hour) * time.Hour), Description: description})
+		s.Estimate = estimate
+		return s
+	}
+
+	recent := RecentTasks([]*DailySessions{
+		{Sessions: []*Session{session("Code review", 9, 30*time.Minute), session("Release notes", 10, 0)}},
+		{Sessions: []*Session{session("code review ", 30, time.Hour), session("", 31, 0)}},
+	})
+
+	assert.Equal(t, []TaskSuggestion{
+		{Description: "code review", Estimate: time.Hour, LastUsed: day.Add(30 * time.Hour), Uses: 2},
+		{Description: "Release notes", LastUsed: day.Add(10 * time.Hour), Uses: 1},
+	}, recent)
+}
+
+// TestFuzzyScore tests subsequence matching and that better matches score higher
+func TestFuzzyScore(t *testing.T) {
+	_, ok := FuzzyScore("", "anything")
+	assert.True(t, ok)
+	_, ok = FuzzyScore("rvw", "Code review")
+	assert.True(t, ok)
+	_, ok = FuzzyScore("wvr", "Code review")
+	assert.False(t, ok)
+
+	prefix, _ := FuzzyScore("rel", "Release notes")
+	scattered, _ := FuzzyScore("rel", "Code review of the login")
+	assert.Greater(t, prefix, scattered)
+
+	wordStart, _ := FuzzyScore("cr", "Code review")
+	inside, _ := FuzzyScore("cr", "Describe")
+	assert.Greater(t, wordStart, inside)
+}
+
+// TestFilterTaskSuggestions tests favorites come first, duplicates are dropped and a query
+// ranks matches
+func TestFilterTaskSuggestions(t *testing.T) {
+	favorites := []TaskSuggestion{{Description: "Standup notes", Favorite: true}, {Description: "Code review", Favorite: true}}
+	recent := []TaskSuggestion{{Description: "Release notes"}, {Description: "code review"}, {Description: "Inbox"}}
+
+	descriptions := func(tasks []TaskSuggestion) []string {
+		result := []string{}
+		for _, task := range tasks {
+			result = append(result, task.Description)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Standup notes", "Code review", "Release notes", "Inbox"},
+		descriptions(FilterTaskSuggestions(favorites, recent, "", 0)))
+	assert.Equal(t, []string{"Standup notes", "Code review"},
+		descriptions(FilterTaskSuggestions(favorites, recent, "", 2)))
+	assert.Equal(t, []string{"Release notes", "Standup notes"},
+		descriptions(FilterTaskSuggestions(favorites, recent, "notes", 0)))
+	assert.Equal(t, []string{"Release notes"},
+		descriptions(FilterTaskSuggestions(favorites, recent, "rel", 0)))
+	assert.Empty(t, FilterTaskSuggestions(favorites, recent, "deploy", 0))
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultTagKeywords are words that point to a tag before anything was learned
+var DefaultTagKeywords = map[string]InterruptionTag{
+	"call":      TagCall,
+	"calling":   TagCall,
+	"phone":     TagCall,
+	"rang":      TagCall,
+	"ring":      TagCall,
+	"voicemail": TagCall,
+	"meeting":   TagMeeting,
+	"meet":      TagMeeting,
+	"zoom":      TagMeeting,
+	"teams":     TagMeeting,
+	"standup":   TagMeeting,
+	"sync":      TagMeeting,
+	"huddle":    TagMeeting,
+	"1:1":       TagMeeting,
+	"wife":      TagSpouse,
+	"husband":   TagSpouse,
+	"partner":   TagSpouse,
+	"spouse":    TagSpouse,
+}
+
+// minTagWordLength leaves out words too short to tell tags apart, like "a" or "on"
+const minTagWordLength = 3
+
+// TagModel maps the words of interruption descriptions to the tags they were recorded
+// with, learned from how interruptions were tagged before
+type TagModel struct {
+	Words map[string]map[InterruptionTag]int `json:"words"` // Times each word was seen with each tag
+}
+
+// NewTagModel creates an empty tag model
+func NewTagModel() *TagModel {
+	return &TagModel{Words: make(map[string]map[InterruptionTag]int)}
+}
+
+// tagWords splits a description into lower case words worth learning from
+func tagWords(description string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ':'
+	})
+
+	var words []string
+	seen := make(map[string]bool)
+	for _, word := range fields {
+		word = strings.Trim(word, ":")
+		if len([]rune(word)) < minTagWordLength && DefaultTagKeywords[word] == "" {
+			continue
+		}
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Learn records that an interruption with the description was tagged with the tag
+func (m *TagModel) Learn(description string, tag InterruptionTag) {
+	if tag == "" || tag == TagOther {
+		return // "other" says nothing about the words
+	}
+	if m.Words == nil {
+		m.Words = make(map[string]map[InterruptionTag]int)
+	}
+	for _, word := range tagWords(description) {
+		if m.Words[word] == nil {
+			m.Words[word] = make(map[InterruptionTag]int)
+		}
+		m.Words[word][tag]++
+	}
+}
+
+// LearnSessions learns from every tagged interruption with a description in the days
+func (m *TagModel) LearnSessions(days []*DailySessions) {
+	for _, day := range days {
+		for _, session := range day.Sessions {
+			for _, entry := range session.Interruptions {
+				if entry.Type == EntryTypeInterruption && entry.Description != "" {
+					m.Learn(entry.Description, entry.Tag)
+				}
+			}
+		}
+	}
+}
+
+// Suggest returns the tag the description most likely belongs to. Words seen before vote
+// for the tags they were used with; default keywords count once, so history outweighs
+// them after a few uses. Returns false when no word points to a tag.
+func (m *TagModel) Suggest(description string) (InterruptionTag, bool) {
+	scores := make(map[InterruptionTag]int)
+	for _, word := range tagWords(description) {
+		if m != nil {
+			for tag, count := range m.Words[word] {
+				scores[tag] += count
+			}
+		}
+		if tag, ok := DefaultTagKeywords[word]; ok {
+			scores[tag]++
+		}
+	}
+	if len(scores) == 0 {
+		return "", false
+	}
+
+	// Highest score first, ties broken by name so the suggestion is stable
+	tags := make([]InterruptionTag, 0, len(scores))
+	for tag := range scores {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if scores[tags[i]] != scores[tags[j]] {
+			return scores[tags[i]] > scores[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	return tags[0], true
+}
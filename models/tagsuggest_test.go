@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTagModelSuggest tests default keywords suggest a tag until history says otherwise
+func TestTagModelSuggest(t *testing.T) {
+	var empty *TagModel
+	tag, ok := empty.Suggest("Zoom with design")
+	assert.True(t, ok)
+	assert.Equal(t, TagMeeting, tag)
+
+	_, ok = empty.Suggest("on it")
+	assert.False(t, ok)
+
+	model := NewTagModel()
+	model.Learn("Zoom call with Anna", TagCall)
+	model.Learn("anna asked about lunch", TagSpouse)
+	model.Learn("Anna again", TagSpouse)
+	model.Learn("whatever", TagOther) // Says nothing about the words
+
+	tag, ok = model.Suggest("Anna")
+	assert.True(t, ok)
+	assert.Equal(t, TagSpouse, tag)
+
+	// "zoom" is a meeting keyword but was used for a call, ties go to the first by name
+	tag, ok = model.Suggest("zoom")
+	assert.True(t, ok)
+	assert.Equal(t, TagCall, tag)
+
+	assert.Empty(t, model.Words["whatever"])
+	assert.Equal(t, []string{"1:1", "with", "bob"}, tagWords("1:1 with Bob, bob!"))
+}
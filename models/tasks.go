@@ -0,0 +1,170 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TaskSimilarity is how similar two normalized descriptions must be, from 0 to 1, to be
+// grouped as the same recurring task
+const TaskSimilarity = 0.8
+
+// RecurringTask aggregates completed sessions with identical or near-identical descriptions
+type RecurringTask struct {
+	Name          string        `json:"name" yaml:"name"` // Description of the first session in the group
+	Sessions      int           `json:"sessions" yaml:"sessions"`
+	WorkDuration  time.Duration `json:"work_duration" yaml:"work_duration"`
+	Interruptions int           `json:"interruptions" yaml:"interruptions"`
+
+	key string
+}
+
+// AverageWorkTime returns the mean work time per session
+func (t *RecurringTask) AverageWorkTime() time.Duration {
+	if t.Sessions == 0 {
+		return 0
+	}
+	return t.WorkDuration / time.Duration(t.Sessions)
+}
+
+// InterruptionRate returns the average number of interruptions per hour of work
+func (t *RecurringTask) InterruptionRate() float64 {
+	if t.WorkDuration <= 0 {
+		return 0
+	}
+	return float64(t.Interruptions) / t.WorkDuration.Hours()
+}
+
+// TaskGroups groups completed sessions into tasks by fuzzy matching their descriptions
+type TaskGroups struct {
+	Tasks []*RecurringTask   `json:"tasks" yaml:"tasks"`
+	Rules NormalizationRules `json:"-" yaml:"-"` // How descriptions are normalized before matching
+}
+
+// NormalizationRules control how session descriptions are normalized before grouping.
+// The zero value folds case and drops punctuation and numbers.
+type NormalizationRules struct {
+	CaseSensitive  bool     // Keep "Login" and "login" apart
+	StripIssueKeys bool     // Drop Jira keys and GitHub references such as PROJ-12 or owner/repo#3
+	StopWords      []string // Words left out of the comparison, matched case-insensitively
+}
+
+// Add records a completed session under the task its description matches best, starting
+// a new task when none is similar enough. Sessions without a description are ignored.
+func (g *TaskGroups) Add(description string, work time.Duration, interruptions int) {
+	key := g.Rules.Normalize(description)
+	if key == "" {
+		return
+	}
+
+	var best *RecurringTask
+	bestScore := TaskSimilarity
+	for _, task := range g.Tasks {
+		if score := taskSimilarity(key, task.key); score >= bestScore {
+			best, bestScore = task, score
+		}
+	}
+
+	if best == nil {
+		best = &RecurringTask{Name: strings.TrimSpace(description), key: key}
+		g.Tasks = append(g.Tasks, best)
+	}
+
+	best.Sessions++
+	best.WorkDuration += work
+	best.Interruptions += interruptions
+}
+
+// Recurring returns the tasks worked on in more than one session, most time first
+func (g *TaskGroups) Recurring() []*RecurringTask {
+	var recurring []*RecurringTask
+	for _, task := range g.Tasks {
+		if task.Sessions > 1 {
+			recurring = append(recurring, task)
+		}
+	}
+
+	sort.SliceStable(recurring, func(i, j int) bool {
+		return recurring[i].WorkDuration > recurring[j].WorkDuration
+	})
+	return recurring
+}
+
+// NormalizeTaskDescription lowercases a description and drops punctuation and numbers, so
+// "Standup #12" and "standup" compare equal
+func NormalizeTaskDescription(description string) string {
+	return NormalizationRules{}.Normalize(description)
+}
+
+// Normalize applies the rules to a description, dropping punctuation and numbers, so
+// "Fix login bug" and "fix Login Bug (PROJ-12)" compare equal with issue keys stripped
+func (r NormalizationRules) Normalize(description string) string {
+	if r.StripIssueKeys {
+		// Issue keys are matched before case folding, Jira keys are upper case
+		description = githubIssuePattern.ReplaceAllString(description, " ")
+		description = jiraIssuePattern.ReplaceAllString(description, " ")
+	}
+	if !r.CaseSensitive {
+		description = strings.ToLower(description)
+	}
+
+	words := strings.FieldsFunc(description, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	kept := words[:0]
+	for _, word := range words {
+		if strings.IndexFunc(word, unicode.IsLetter) >= 0 && !r.isStopWord(word) {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// isStopWord reports whether a word is one of the configured stop words
+func (r NormalizationRules) isStopWord(word string) bool {
+	for _, stopWord := range r.StopWords {
+		if strings.EqualFold(stopWord, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// taskSimilarity returns 1 minus the edit distance relative to the longer description
+func taskSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalizeTaskDescription tests normalizing descriptions for grouping
+func TestNormalizeTaskDescription(t *testing.T) {
+	assert.Equal(t, "standup", NormalizeTaskDescription("Standup #12"))
+	assert.Equal(t, "email triage", NormalizeTaskDescription("  Email   triage!"))
+	assert.Equal(t, "fix proj bug", NormalizeTaskDescription("Fix PROJ-123 bug"))
+	assert.Equal(t, "", NormalizeTaskDescription("2025-03-03"))
+}
+
+// TestTaskGroups tests grouping sessions into recurring tasks
+func TestTaskGroups(t *testing.T) {
+	var groups TaskGroups
+	groups.Add("Standup", 15*time.Minute, 0)
+	groups.Add("standup.", 20*time.Minute, 1)
+	groups.Add("Email triage", 30*time.Minute, 1)
+	groups.Add("Emial triage", 30*time.Minute, 2)
+	groups.Add("email triage 3", time.Hour, 0)
+	groups.Add("Write docs", time.Hour, 0)
+	groups.Add("", time.Hour, 0)
+
+	assert.Len(t, groups.Tasks, 3)
+
+	recurring := groups.Recurring()
+	assert.Len(t, recurring, 2)
+
+	email := recurring[0]
+	assert.Equal(t, "Email triage", email.Name)
+	assert.Equal(t, 3, email.Sessions)
+	assert.Equal(t, 2*time.Hour, email.WorkDuration)
+	assert.Equal(t, 40*time.Minute, email.AverageWorkTime())
+	assert.InDelta(t, 1.5, email.InterruptionRate(), 0.001)
+
+	standup := recurring[1]
+	assert.Equal(t, "Standup", standup.Name)
+	assert.Equal(t, 2, standup.Sessions)
+	assert.Equal(t, 35*time.Minute, standup.WorkDuration)
+}
+
+// TestTaskSimilarity tests that different tasks are kept apart
+func TestTaskSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, taskSimilarity("standup", "standup"))
+	assert.GreaterOrEqual(t, taskSimilarity("email triage", "emial triage"), TaskSimilarity)
+	assert.Less(t, taskSimilarity("write docs", "review docs"), TaskSimilarity)
+	assert.Less(t, taskSimilarity("standup", "stand"), TaskSimilarity)
+}
+
+// TestNormalizationRules tests the configurable normalization rules
+func TestNormalizationRules(t *testing.T) {
+	rules := NormalizationRules{StripIssueKeys: true, StopWords: []string{"the", "A"}}
+	assert.Equal(t, "fix login bug", rules.Normalize("fix Login Bug (PROJ-12)"))
+	assert.Equal(t, "fix login bug", rules.Normalize("Fix the login bug owner/repo#7"))
+	assert.Equal(t, "write docs", rules.Normalize("Write a docs"))
+
+	caseSensitive := NormalizationRules{CaseSensitive: true}
+	assert.Equal(t, "Fix PROJ bug", caseSensitive.Normalize("Fix PROJ-123 bug"))
+
+	groups := TaskGroups{Rules: rules}
+	groups.Add("Fix login bug", time.Hour, 0)
+	groups.Add("fix Login Bug (PROJ-12)", time.Hour, 1)
+	assert.Len(t, groups.Tasks, 1)
+	assert.Equal(t, 2, groups.Tasks[0].Sessions)
+}
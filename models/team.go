@@ -0,0 +1,121 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// MemberDays is one teammate's exported days
+type MemberDays struct {
+	User string
+	Days []*DailySessions
+}
+
+// TagLoad is the interruptions of one tag across the team
+type TagLoad struct {
+	Count    int           `json:"count"`
+	Duration time.Duration `json:"duration"`
+}
+
+// MemberStats summarises one teammate's data within the range of a team report
+type MemberStats struct {
+	User                 string                      `json:"user"`
+	Days                 int                         `json:"days"` // Days with at least one session
+	WorkDuration         time.Duration               `json:"work_duration"`
+	InterruptionDuration time.Duration               `json:"interruption_duration"`
+	Interruptions        int                         `json:"interruptions"`
+	MeetingDuration      time.Duration               `json:"meeting_duration"`
+	ByTag                map[InterruptionTag]TagLoad `json:"by_tag"`
+}
+
+// TeamStats combines several teammates' exports, keeping each member's share
+type TeamStats struct {
+	StartDate            time.Time                   `json:"start_date"`
+	EndDate              time.Time                   `json:"end_date"`
+	Members              []MemberStats               `json:"members"` // Ordered by user
+	WorkDuration         time.Duration               `json:"work_duration"`
+	InterruptionDuration time.Duration               `json:"interruption_duration"`
+	Interruptions        int                         `json:"interruptions"`
+	MeetingDuration      time.Duration               `json:"meeting_duration"`
+	ByTag                map[InterruptionTag]TagLoad `json:"by_tag"`
+	MeetingsByHour       map[int]time.Duration       `json:"meetings_by_hour"`    // Meeting time by the hour meetings started
+	MeetingsByWeekday    map[string]time.Duration    `json:"meetings_by_weekday"` // Meeting time by day of the week
+}
+
+// InterruptionLoad returns the share of tracked time the team spent interrupted, 0-100
+func (t *TeamStats) InterruptionLoad() float64 {
+	total := t.WorkDuration + t.InterruptionDuration
+	if total == 0 {
+		return 0
+	}
+	return float64(t.InterruptionDuration) / float64(total) * 100
+}
+
+// CombineTeam builds team statistics from the days of each member from start to end
+// inclusive. Zero start and end take every day.
+func CombineTeam(members []MemberDays, start, end time.Time) *TeamStats {
+	team := &TeamStats{
+		ByTag:             make(map[InterruptionTag]TagLoad),
+		MeetingsByHour:    make(map[int]time.Duration),
+		MeetingsByWeekday: make(map[string]time.Duration),
+	}
+
+	for _, member := range members {
+		stats := MemberStats{User: member.User, ByTag: make(map[InterruptionTag]TagLoad)}
+
+		for _, day := range member.Days {
+			if (!start.IsZero() && day.Date.Before(start)) || (!end.IsZero() && day.Date.After(end)) {
+				continue
+			}
+			if len(day.Sessions) == 0 {
+				continue
+			}
+			stats.Days++
+			if team.StartDate.IsZero() || day.Date.Before(team.StartDate) {
+				team.StartDate = day.Date
+			}
+			if day.Date.After(team.EndDate) {
+				team.EndDate = day.Date
+			}
+
+			work, interrupted, count := day.GetStats()
+			stats.WorkDuration += work
+			stats.InterruptionDuration += interrupted
+			stats.Interruptions += count
+
+			for _, session := range day.Sessions {
+				// Completed interruptions only, like the daily statistics
+				for _, record := range session.InterruptionRecords(day.Date, time.Time{}) {
+					if record.Ongoing {
+						continue
+					}
+					load := stats.ByTag[record.Tag]
+					load.Count++
+					load.Duration += record.Duration
+					stats.ByTag[record.Tag] = load
+
+					if record.Tag == TagMeeting {
+						stats.MeetingDuration += record.Duration
+						team.MeetingsByHour[record.Start.Hour()] += record.Duration
+						team.MeetingsByWeekday[record.Start.Weekday().String()] += record.Duration
+					}
+				}
+			}
+		}
+
+		team.WorkDuration += stats.WorkDuration
+		team.InterruptionDuration += stats.InterruptionDuration
+		team.Interruptions += stats.Interruptions
+		team.MeetingDuration += stats.MeetingDuration
+		for tag, load := range stats.ByTag {
+			teamLoad := team.ByTag[tag]
+			teamLoad.Count += load.Count
+			teamLoad.Duration += load.Duration
+			team.ByTag[tag] = teamLoad
+		}
+		team.Members = append(team.Members, stats)
+	}
+
+	sort.SliceStable(team.Members, func(i, j int) bool { return team.Members[i].User < team.Members[j].User })
+	return team
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCombineTeam tests members' days are combined within the range with meetings split out
+func TestCombineTeam(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	// day returns a day with an hour of work from 9:00 and one interruption at 9:30
+	day := func(date time.Time, tag InterruptionTag, minutes int) *DailySessions {
+		start := date.Add(9 * time.Hour)
+		return &DailySessions{Date: date, Sessions: []*Session{{
+			Start: &TimeEntry{Type: EntryTypeStart, StartTime: start},
+			End:   &TimeEntry{Type: EntryTypeEnd, StartTime: start.Add(time.Hour)},
+			Interruptions: []*TimeEntry{
+				{Type: EntryTypeInterruption, StartTime: start.Add(30 * time.Minute), Tag: tag},
+				{Type: EntryTypeReturn, StartTime: start.Add(time.Duration(30+minutes) * time.Minute)},
+			},
+		}}}
+	}
+
+	members := []MemberDays{
+		{User: "bob", Days: []*DailySessions{day(monday, TagMeeting, 20), day(monday.AddDate(0, 0, 7), TagMeeting, 30)}},
+		{User: "alice", Days: []*DailySessions{day(monday, TagCall, 10), day(monday.AddDate(0, 0, 1), TagMeeting, 10)}},
+	}
+
+	team := CombineTeam(members, monday, monday.AddDate(0, 0, 6))
+	assert.Equal(t, []string{"alice", "bob"}, []string{team.Members[0].User, team.Members[1].User})
+	assert.Equal(t, 1, team.Members[1].Days)
+	assert.Equal(t, 3, team.Interruptions)
+	assert.Equal(t, 40*time.Minute, team.InterruptionDuration)
+	assert.Equal(t, 30*time.Minute, team.MeetingDuration)
+	assert.Equal(t, TagLoad{Count: 2, Duration: 30 * time.Minute}, team.ByTag[TagMeeting])
+	assert.Equal(t, 20*time.Minute, team.MeetingsByWeekday["Monday"])
+	assert.Equal(t, 30*time.Minute, team.MeetingsByHour[9])
+	assert.True(t, team.StartDate.Equal(monday))
+	assert.True(t, team.EndDate.Equal(monday.AddDate(0, 0, 1)))
+	assert.InDelta(t, 40.0/(140+40)*100, team.InterruptionLoad(), 0.01)
+
+	// Without a range every day counts
+	assert.Equal(t, 2, CombineTeam(members, time.Time{}, time.Time{}).Members[1].Days)
+}
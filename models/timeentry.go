@@ -1,7 +1,9 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -17,6 +19,8 @@ const (
 	EntryTypeInterruption EntryType = "INTERRUPTION"
 	// EntryTypeReturn represents returning from an interruption
 	EntryTypeReturn EntryType = "RETURN"
+	// EntryTypeDeferred represents an interruption that was postponed without leaving work
+	EntryTypeDeferred EntryType = "DEFERRED"
 )
 
 // InterruptionTag represents the reason for interruption
@@ -43,12 +47,69 @@ func GetInterruptionTags() []InterruptionTag {
 	}
 }
 
+// IsBuiltinTag reports whether the tag is one of the built-in interruption tags
+func IsBuiltinTag(tag InterruptionTag) bool {
+	for _, builtin := range GetInterruptionTags() {
+		if builtin == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// TagUsage describes how often an interruption tag has been used
+type TagUsage struct {
+	Tag      InterruptionTag
+	Count    int
+	LastUsed time.Time // Zero if the tag was never used
+}
+
+// MostLikelyTag returns the tag most often used around the given time in the past.
+// Interruptions in the same hour score one point, on the same weekday and hour two points.
+// Returns false when the history is too thin to make a useful suggestion.
+func MostLikelyTag(interruptions []*TimeEntry, at time.Time) (InterruptionTag, bool) {
+	const minimumScore = 2
+
+	scores := make(map[InterruptionTag]int)
+	for _, entry := range interruptions {
+		if entry.Type != EntryTypeInterruption || entry.StartTime.Hour() != at.Hour() {
+			continue
+		}
+
+		tag := entry.Tag
+		if tag == "" {
+			tag = TagOther
+		}
+
+		if entry.StartTime.Weekday() == at.Weekday() {
+			scores[tag] += 2
+		} else {
+			scores[tag]++
+		}
+	}
+
+	var bestTag InterruptionTag
+	bestScore := 0
+	for tag, score := range scores {
+		// Ties are broken by name so the suggestion is stable
+		if score > bestScore || (score == bestScore && tag < bestTag) {
+			bestTag = tag
+			bestScore = score
+		}
+	}
+
+	if bestScore < minimumScore {
+		return "", false
+	}
+	return bestTag, true
+}
+
 // TimeEntry represents a single time entry in the tracker
 type TimeEntry struct {
 	ID          string          `json:"id"`
 	Type        EntryType       `json:"type"`
 	StartTime   time.Time       `json:"start_time"`
-	EndTime     time.Time       `json:"end_time,omitempty"`
+	EndTime     time.Time       `json:"end_time,omitempty"` // For returns, when recovery ended and work resumed
 	Description string          `json:"description,omitempty"`
 	Tag         InterruptionTag `json:"tag,omitempty"`
 }
@@ -95,23 +156,37 @@ type SubSession struct {
 
 // Session represents a complete work session that may contain multiple sub-sessions
 type Session struct {
-	ID            string        `json:"id"`                      // Unique ID for this session
-	Start         *TimeEntry    `json:"start"`                   // First start time of the task
-	End           *TimeEntry    `json:"end,omitempty"`           // Most recent end time, omitted if active
-	SubSessions   []*SubSession `json:"sub_sessions"`            // List of continuous work periods
-	Interruptions []*TimeEntry  `json:"interruptions,omitempty"` // For backward compatibility
+	ID             string         `json:"id"`                        // Unique ID for this session
+	Start          *TimeEntry     `json:"start"`                     // First start time of the task
+	End            *TimeEntry     `json:"end,omitempty"`             // Most recent end time, omitted if active
+	SubSessions    []*SubSession  `json:"sub_sessions"`              // List of continuous work periods
+	Interruptions  []*TimeEntry   `json:"interruptions,omitempty"`   // For backward compatibility
+	Estimate       time.Duration  `json:"estimate,omitempty"`        // Expected work time, set when starting
+	Project        string         `json:"project,omitempty"`         // Assigned from the workspace rules or given when starting
+	Deferred       []*TimeEntry   `json:"deferred,omitempty"`        // Interruptions deflected without leaving work
+	Attachments    []*Attachment  `json:"attachments,omitempty"`     // Files kept as evidence of the work
+	ContinuationOf string         `json:"continuation_of,omitempty"` // ID of the session this one continues after a day rollover split
+	FocusWindows   []*FocusWindow `json:"focus_windows,omitempty"`   // Stretches declared as do-not-disturb
 }
 
 // DailySessions represents all sessions for a single day
 type DailySessions struct {
 	Date     time.Time  `json:"date"`
 	Sessions []*Session `json:"sessions"`
+	Meetings []*Meeting `json:"meetings,omitempty"` // Meetings declared ahead for the day
+}
+
+// SessionRecord is a session together with the day it is stored under, one per line
+// in NDJSON exports
+type SessionRecord struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	*Session
 }
 
 // NewDailySessions creates a new DailySessions for the current day
 func NewDailySessions() *DailySessions {
 	return &DailySessions{
-		Date:     time.Now().Truncate(24 * time.Hour),
+		Date:     DayOf(time.Now(), 0),
 		Sessions: []*Session{},
 	}
 }
@@ -138,60 +213,139 @@ func NewSession(startEntry *TimeEntry) *Session {
 	return session
 }
 
-// GetStats calculates statistics for the daily sessions
-func (ds *DailySessions) GetStats() (totalWorkDuration, totalInterruptionDuration time.Duration, interruptionCount int) {
-	for _, session := range ds.Sessions {
-		// If the session has sub-sessions, use those for accurate duration calculation
-		if len(session.SubSessions) > 0 {
-			for _, subSession := range session.SubSessions {
-				if subSession.Start != nil {
-					var endTime time.Time
-
-					if subSession.End != nil {
-						endTime = subSession.End.StartTime
-					} else {
-						// For active sub-sessions, use current time
-						endTime = time.Now()
-					}
+// GetStats calculates statistics for a single session
+func (session *Session) GetStats() (workDuration, interruptionDuration time.Duration, interruptionCount int) {
+	// If the session has sub-sessions, use those for accurate duration calculation
+	if len(session.SubSessions) > 0 {
+		for _, subSession := range session.SubSessions {
+			if subSession.Start != nil {
+				var endTime time.Time
+
+				if subSession.End != nil {
+					endTime = subSession.End.StartTime
+				} else {
+					// For active sub-sessions, use current time
+					endTime = time.Now()
+				}
 
-					subSessionDuration := endTime.Sub(subSession.Start.StartTime)
-					interruptionDuration := time.Duration(0)
+				subSessionDuration := endTime.Sub(subSession.Start.StartTime)
+				subInterruptionDuration := time.Duration(0)
 
-					// Calculate interruption time within this sub-session
-					for i := 0; i < len(subSession.Interruptions); i += 2 {
-						if i+1 < len(subSession.Interruptions) {
-							interruptionStart := subSession.Interruptions[i].StartTime
-							interruptionEnd := subSession.Interruptions[i+1].StartTime
-							interruptionDuration += interruptionEnd.Sub(interruptionStart)
-						}
+				// Calculate interruption time within this sub-session
+				for i := 0; i < len(subSession.Interruptions); i += 2 {
+					if i+1 < len(subSession.Interruptions) {
+						interruptionStart := subSession.Interruptions[i].StartTime
+						interruptionEnd := subSession.Interruptions[i+1].StartTime
+						subInterruptionDuration += interruptionEnd.Sub(interruptionStart)
 					}
-
-					totalWorkDuration += subSessionDuration - interruptionDuration
-					totalInterruptionDuration += interruptionDuration
-					interruptionCount += len(subSession.Interruptions) / 2
 				}
+
+				workDuration += subSessionDuration - subInterruptionDuration
+				interruptionDuration += subInterruptionDuration
+				interruptionCount += len(subSession.Interruptions) / 2
 			}
-		} else {
-			// Backward compatibility for sessions without sub-sessions
-			if session.Start != nil && session.End != nil {
-				sessionDuration := session.End.StartTime.Sub(session.Start.StartTime)
-				interruptionDuration := time.Duration(0)
-
-				for i := 0; i < len(session.Interruptions); i += 2 {
-					if i+1 < len(session.Interruptions) {
-						interruptionStart := session.Interruptions[i].StartTime
-						interruptionEnd := session.Interruptions[i+1].StartTime
-						interruptionDuration += interruptionEnd.Sub(interruptionStart)
-					}
+		}
+	} else {
+		// Backward compatibility for sessions without sub-sessions
+		if session.Start != nil && session.End != nil {
+			sessionDuration := session.End.StartTime.Sub(session.Start.StartTime)
+
+			for i := 0; i < len(session.Interruptions); i += 2 {
+				if i+1 < len(session.Interruptions) {
+					interruptionStart := session.Interruptions[i].StartTime
+					interruptionEnd := session.Interruptions[i+1].StartTime
+					interruptionDuration += interruptionEnd.Sub(interruptionStart)
 				}
+			}
+
+			workDuration = sessionDuration - interruptionDuration
+			interruptionCount = len(session.Interruptions) / 2
+		}
+	}
+
+	return workDuration, interruptionDuration, interruptionCount
+}
+
+// LastActivity returns the time of the most recent entry recorded in the session
+func (session *Session) LastActivity() time.Time {
+	var latest time.Time
+	consider := func(entry *TimeEntry) {
+		if entry != nil && entry.StartTime.After(latest) {
+			latest = entry.StartTime
+		}
+	}
+
+	consider(session.Start)
+	consider(session.End)
+	for _, entry := range session.Interruptions {
+		consider(entry)
+	}
+	for _, subSession := range session.SubSessions {
+		consider(subSession.Start)
+		consider(subSession.End)
+		for _, entry := range subSession.Interruptions {
+			consider(entry)
+		}
+	}
+	for _, entry := range session.Deferred {
+		consider(entry)
+	}
 
-				totalWorkDuration += sessionDuration - interruptionDuration
-				totalInterruptionDuration += interruptionDuration
-				interruptionCount += len(session.Interruptions) / 2
+	return latest
+}
+
+// Clone returns a deep copy of the session, as it would be saved and loaded again, for use
+// while the original keeps changing
+func (session *Session) Clone() *Session {
+	clone := &Session{}
+	if data, err := json.Marshal(session); err == nil {
+		_ = json.Unmarshal(data, clone)
+	}
+	return clone
+}
+
+// MergeDailySessions returns the union of two versions of the same day, matching sessions by ID.
+// When both versions contain a session, the one with the most recent activity wins. Declared
+// meetings are merged too.
+func MergeDailySessions(ours, theirs *DailySessions) *DailySessions {
+	merged := &DailySessions{Date: ours.Date, Sessions: []*Session{}, Meetings: mergeMeetings(ours.Meetings, theirs.Meetings)}
+	index := make(map[string]int)
+
+	for _, version := range []*DailySessions{ours, theirs} {
+		for _, session := range version.Sessions {
+			i, exists := index[session.ID]
+			if !exists {
+				index[session.ID] = len(merged.Sessions)
+				merged.Sessions = append(merged.Sessions, session)
+				continue
+			}
+			if session.LastActivity().After(merged.Sessions[i].LastActivity()) {
+				merged.Sessions[i] = session
 			}
 		}
 	}
 
+	// Keep sessions in chronological order
+	sort.SliceStable(merged.Sessions, func(i, j int) bool {
+		a, b := merged.Sessions[i].Start, merged.Sessions[j].Start
+		if a == nil || b == nil {
+			return b != nil
+		}
+		return a.StartTime.Before(b.StartTime)
+	})
+
+	return merged
+}
+
+// GetStats calculates statistics for the daily sessions
+func (ds *DailySessions) GetStats() (totalWorkDuration, totalInterruptionDuration time.Duration, interruptionCount int) {
+	for _, session := range ds.Sessions {
+		workDuration, interruptionDuration, count := session.GetStats()
+		totalWorkDuration += workDuration
+		totalInterruptionDuration += interruptionDuration
+		interruptionCount += count
+	}
+
 	return totalWorkDuration, totalInterruptionDuration, interruptionCount
 }
 
@@ -230,7 +384,11 @@ func (ds *DailySessions) GetInterruptionTagStats() []InterruptionTagStats {
 				}
 
 				// Get or create stats for this tag
-				stats := statsMap[tag]
+				stats, exists := statsMap[tag]
+				if !exists {
+					stats = &InterruptionTagStats{Tag: tag}
+					statsMap[tag] = stats
+				}
 
 				// Update the stats
 				stats.Count++
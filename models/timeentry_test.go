@@ -66,8 +66,8 @@ func (suite *TimeEntryTestSuite) TestDailySessionsNewAndGetStats() {
 	assert.NotNil(suite.T(), dailySessions)
 	assert.Empty(suite.T(), dailySessions.Sessions)
 
-	// Ensure date is set to today with time truncated
-	now := time.Now().Truncate(24 * time.Hour)
+	// Ensure date is set to local midnight of today
+	now := time.Now()
 	assert.Equal(suite.T(), now.Year(), dailySessions.Date.Year())
 	assert.Equal(suite.T(), now.Month(), dailySessions.Date.Month())
 	assert.Equal(suite.T(), now.Day(), dailySessions.Date.Day())
@@ -350,7 +350,61 @@ func (suite *TimeEntryTestSuite) TestGetInterruptionTagStats() {
 	assert.Equal(suite.T(), 45*time.Minute, meetingStats.AverageTime)
 }
 
+// TestMostLikelyTag tests suggesting a tag from interruption history
+func (suite *TimeEntryTestSuite) TestMostLikelyTag() {
+	// Monday 10:15
+	at := time.Date(2025, 3, 10, 10, 15, 0, 0, time.Local)
+
+	newInterruption := func(t time.Time, tag InterruptionTag) *TimeEntry {
+		return &TimeEntry{Type: EntryTypeInterruption, StartTime: t, Tag: tag}
+	}
+
+	// No history means no suggestion
+	_, ok := MostLikelyTag(nil, at)
+	assert.False(suite.T(), ok)
+
+	history := []*TimeEntry{
+		newInterruption(time.Date(2025, 3, 3, 10, 5, 0, 0, time.Local), TagMeeting), // Monday, same hour
+		newInterruption(time.Date(2025, 3, 4, 10, 30, 0, 0, time.Local), TagCall),   // Tuesday, same hour
+		newInterruption(time.Date(2025, 3, 5, 10, 45, 0, 0, time.Local), TagCall),   // Wednesday, same hour
+		newInterruption(time.Date(2025, 3, 3, 15, 0, 0, 0, time.Local), TagSpouse),  // Different hour
+		{Type: EntryTypeReturn, StartTime: time.Date(2025, 3, 3, 10, 20, 0, 0, time.Local)},
+	}
+
+	// Meeting and call both score 2, ties are broken by name
+	tag, ok := MostLikelyTag(history, at)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), TagCall, tag)
+
+	// Another Monday meeting tips the balance
+	history = append(history, newInterruption(time.Date(2025, 2, 24, 10, 0, 0, 0, time.Local), TagMeeting))
+	tag, ok = MostLikelyTag(history, at)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), TagMeeting, tag)
+}
+
 // TestTimeEntrySuite runs the test suite
+// TestSessionClone tests a clone keeps the session as it was when the original changes
+func (suite *TimeEntryTestSuite) TestSessionClone() {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	session := NewSession(&TimeEntry{ID: "1", Type: EntryTypeStart, StartTime: start, Description: "Release notes"})
+	session.EndAt(start.Add(time.Hour))
+
+	clone := session.Clone()
+	assert.Equal(suite.T(), session.ID, clone.ID)
+	assert.Equal(suite.T(), "Release notes", clone.Start.Description)
+	assert.True(suite.T(), clone.End.StartTime.Equal(start.Add(time.Hour)))
+
+	session.Start.Description = "Renamed"
+	session.End = nil
+	session.SubSessions[0].End = nil
+	assert.Equal(suite.T(), "Release notes", clone.Start.Description)
+	assert.NotNil(suite.T(), clone.End)
+	assert.NotNil(suite.T(), clone.SubSessions[0].End)
+	work, _, _ := clone.GetStats()
+	assert.Equal(suite.T(), time.Hour, work)
+}
+
 func TestTimeEntrySuite(t *testing.T) {
 	suite.Run(t, new(TimeEntryTestSuite))
 }
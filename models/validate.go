@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Problems lists what makes the session malformed: a missing start, an end before the
+// start, or entries of a work period out of time order. Empty for a valid session.
+func (session *Session) Problems() []string {
+	if session.Start == nil {
+		return []string{"no start entry"}
+	}
+
+	var problems []string
+	if session.End != nil && session.End.StartTime.Before(session.Start.StartTime) {
+		problems = append(problems, "ends before it starts")
+	}
+
+	// Sessions from before work periods were tracked have a single period
+	periods := session.SubSessions
+	if len(periods) == 0 {
+		periods = []*SubSession{{Start: session.Start, End: session.End, Interruptions: session.Interruptions}}
+	}
+
+	for i, period := range periods {
+		if period.Start == nil {
+			problems = append(problems, fmt.Sprintf("work period %d has no start", i+1))
+			continue
+		}
+
+		previous := period.Start.StartTime
+		for _, entry := range period.Interruptions {
+			if entry == nil {
+				problems = append(problems, fmt.Sprintf("work period %d has an empty entry", i+1))
+				break
+			}
+			if entry.StartTime.Before(previous) {
+				problems = append(problems, fmt.Sprintf("work period %d has entries out of order at %s", i+1, entry.StartTime.Format("15:04")))
+				break
+			}
+			previous = entry.StartTime
+		}
+		if period.End != nil && period.End.StartTime.Before(previous) {
+			problems = append(problems, fmt.Sprintf("work period %d ends before its last entry", i+1))
+		}
+	}
+	return problems
+}
+
+// Span returns when the session started and ended, now for an active session
+func (session *Session) Span(now time.Time) (start, end time.Time) {
+	if session.Start == nil {
+		return time.Time{}, time.Time{}
+	}
+	end = now
+	if session.End != nil {
+		end = session.End.StartTime
+	}
+	return session.Start.StartTime, end
+}
+
+// Overlaps reports whether the two sessions ran at the same time for any moment
+func (session *Session) Overlaps(other *Session, now time.Time) bool {
+	start, end := session.Span(now)
+	otherStart, otherEnd := other.Span(now)
+	if start.IsZero() || otherStart.IsZero() {
+		return false
+	}
+	return start.Before(otherEnd) && otherStart.Before(end)
+}
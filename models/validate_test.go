@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSessionProblems tests malformed sessions are reported and valid ones are not
+func TestSessionProblems(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	valid := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	valid.SubSessions[0].Interruptions = []*TimeEntry{
+		{Type: EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 10*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(9*time.Hour + 20*time.Minute)},
+	}
+	valid.EndAt(day.Add(10 * time.Hour))
+	assert.Empty(t, valid.Problems())
+
+	assert.Equal(t, []string{"no start entry"}, (&Session{ID: "empty"}).Problems())
+
+	backwards := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	backwards.EndAt(day.Add(8 * time.Hour))
+	assert.Contains(t, backwards.Problems(), "ends before it starts")
+
+	unordered := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	unordered.SubSessions[0].Interruptions = []*TimeEntry{
+		{Type: EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 30*time.Minute)},
+		{Type: EntryTypeReturn, StartTime: day.Add(9*time.Hour + 10*time.Minute)},
+	}
+	assert.Equal(t, []string{"work period 1 has entries out of order at 09:10"}, unordered.Problems())
+}
+
+// TestSessionOverlaps tests sessions overlap only when they ran at the same time, an active
+// session running until now
+func TestSessionOverlaps(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	now := day.Add(12 * time.Hour)
+
+	morning := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	morning.EndAt(day.Add(10 * time.Hour))
+	adjacent := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(10 * time.Hour)})
+	adjacent.EndAt(day.Add(11 * time.Hour))
+	active := NewSession(&TimeEntry{Type: EntryTypeStart, StartTime: day.Add(9*time.Hour + 30*time.Minute)})
+
+	assert.False(t, morning.Overlaps(adjacent, now))
+	assert.True(t, morning.Overlaps(active, now))
+	assert.True(t, active.Overlaps(adjacent, now))
+	assert.False(t, morning.Overlaps(&Session{}, now))
+}
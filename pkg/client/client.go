@@ -0,0 +1,162 @@
+// Package client lets editor plugins and other integrations show the current session and
+// log interruptions through the control socket of the daemon, without running the TUI.
+// The protocol is one JSON request per line answered by one JSON response per line, see
+// the Control Socket section of the README.
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/control"
+)
+
+// SocketEnv overrides the path of the control socket
+const SocketEnv = "INTERRUPTION_TRACKER_SOCKET"
+
+// socketFile is the control socket in the data directory
+const socketFile = "control.sock"
+
+// Status is the state of the active session and today's work
+type Status = control.Status
+
+// Stats are the statistics of a range of days
+type Stats = control.Stats
+
+// States of the tracker
+const (
+	StateWorking     = control.StateWorking
+	StateInterrupted = control.StateInterrupted
+	StateIdle        = control.StateIdle
+)
+
+// SocketPath returns the path of the control socket: $INTERRUPTION_TRACKER_SOCKET when
+// set, otherwise control.sock in the configured data directory. The configuration is
+// only read, never created.
+func SocketPath() (string, error) {
+	if path := os.Getenv(SocketEnv); path != "" {
+		return path, nil
+	}
+
+	cfg := config.DefaultConfig()
+	if config.ConfigExists() {
+		loaded, err := config.LoadConfig()
+		if err != nil {
+			return "", fmt.Errorf("failed to load configuration: %w", err)
+		}
+		cfg = loaded
+	}
+	return filepath.Join(cfg.DataDirectory, socketFile), nil
+}
+
+// Client sends commands to the daemon. It connects for each command, so it keeps working
+// when the daemon restarts while the editor runs.
+type Client struct {
+	path string
+}
+
+// New creates a client of the control socket at path, the socket of SocketPath when empty
+func New(path string) (*Client, error) {
+	if path == "" {
+		var err error
+		if path, err = SocketPath(); err != nil {
+			return nil, err
+		}
+	}
+	return &Client{path: path}, nil
+}
+
+// Available reports whether the daemon answers, for plugins hiding their status while it
+// is not running
+func (c *Client) Available() bool {
+	_, err := c.Status()
+	return err == nil
+}
+
+// Status returns the state of the tracker
+func (c *Client) Status() (*Status, error) {
+	return c.status(control.Request{Command: control.CommandStatus})
+}
+
+// Start starts a session, with an optional project
+func (c *Client) Start(description, project string) (*Status, error) {
+	return c.status(control.Request{Command: control.CommandStart, Description: description, Project: project})
+}
+
+// End ends the active session
+func (c *Client) End() (*Status, error) {
+	return c.status(control.Request{Command: control.CommandEnd})
+}
+
+// Interrupt interrupts the active session until Return, e.g. Interrupt("meeting", ""). The
+// tag is suggested from the description when empty.
+func (c *Client) Interrupt(tag, description string) (*Status, error) {
+	return c.status(control.Request{Command: control.CommandInterrupt, Tag: tag, Description: description})
+}
+
+// LogInterruption records an interruption that is already over and keeps working
+func (c *Client) LogInterruption(tag, description string, duration time.Duration) (*Status, error) {
+	return c.status(control.Request{Command: control.CommandInterrupt, Tag: tag, Description: description, Minutes: duration.Minutes()})
+}
+
+// Return returns from the ongoing interruption
+func (c *Client) Return() (*Status, error) {
+	return c.status(control.Request{Command: control.CommandReturn})
+}
+
+// Stats returns the statistics of a range: day, week, month, quarter, year, all or
+// YYYY-MM-DD..YYYY-MM-DD
+func (c *Client) Stats(rangeType string) (*Stats, error) {
+	resp, err := c.do(control.Request{Command: control.CommandStats, Range: rangeType})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Stats, nil
+}
+
+// status sends a request answered with the status
+func (c *Client) status(req control.Request) (*Status, error) {
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Status, nil
+}
+
+// do sends a request on a new connection
+func (c *Client) do(req control.Request) (*control.Response, error) {
+	conn, err := control.Dial(c.path)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.Do(req)
+}
+
+// StatusLine returns a short description of the status for editor status bars, such as
+// "Review PR 25m 0s" or "meeting 5m 0s (Review PR)", empty while idle
+func StatusLine(status *Status) string {
+	if status == nil {
+		return ""
+	}
+	switch status.State {
+	case StateWorking:
+		if status.Description == "" {
+			return status.Elapsed
+		}
+		return status.Description + " " + status.Elapsed
+	case StateInterrupted:
+		tag := status.Tag
+		if tag == "" {
+			tag = "interrupted"
+		}
+		if status.Description == "" {
+			return tag + " " + status.Interrupted
+		}
+		return fmt.Sprintf("%s %s (%s)", tag, status.Interrupted, status.Description)
+	}
+	return ""
+}
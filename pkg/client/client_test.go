@@ -0,0 +1,71 @@
+package client
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/control"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSocketPath tests the socket set in the environment
+func TestSocketPath(t *testing.T) {
+	t.Setenv(SocketEnv, "/run/user/1000/tracker.sock")
+	path, err := SocketPath()
+	assert.NoError(t, err)
+	assert.Equal(t, "/run/user/1000/tracker.sock", path)
+
+	client, err := New("")
+	assert.NoError(t, err)
+	assert.Equal(t, "/run/user/1000/tracker.sock", client.path)
+}
+
+// TestClient tests the requests of an editor session against a control socket
+func TestClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "control.sock")
+	client, err := New(path)
+	assert.NoError(t, err)
+	assert.False(t, client.Available())
+
+	listener, err := net.Listen("unix", path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer listener.Close()
+	var requests []control.Request
+	go control.Serve(listener, func(req control.Request) control.Response {
+		requests = append(requests, req)
+		if req.Command == control.CommandStats {
+			return control.Response{OK: true, Stats: &Stats{Sessions: 2}}
+		}
+		return control.Response{OK: true, Status: &Status{State: StateWorking, Description: "Review PR", Elapsed: "25m 0s"}}
+	})
+
+	assert.True(t, client.Available())
+	status, err := client.Interrupt("meeting", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Review PR 25m 0s", StatusLine(status))
+	_, err = client.LogInterruption("call", "Phone call", 12*time.Minute)
+	assert.NoError(t, err)
+	stats, err := client.Stats("")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Sessions)
+
+	assert.Equal(t, []control.Request{
+		{Command: control.CommandStatus},
+		{Command: control.CommandInterrupt, Tag: "meeting"},
+		{Command: control.CommandInterrupt, Tag: "call", Description: "Phone call", Minutes: 12},
+		{Command: control.CommandStats},
+	}, requests)
+}
+
+// TestStatusLine tests the status bar text of each state
+func TestStatusLine(t *testing.T) {
+	assert.Equal(t, "", StatusLine(nil))
+	assert.Equal(t, "", StatusLine(&Status{State: StateIdle}))
+	assert.Equal(t, "10m 0s", StatusLine(&Status{State: StateWorking, Elapsed: "10m 0s"}))
+	assert.Equal(t, "meeting 5m 0s (Review PR)", StatusLine(&Status{State: StateInterrupted, Tag: "meeting", Interrupted: "5m 0s", Description: "Review PR"}))
+	assert.Equal(t, "interrupted 1m 0s", StatusLine(&Status{State: StateInterrupted, Interrupted: "1m 0s"}))
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// parseRecoveryComparison reads the two models compared by -compare-recovery, written as
+// "<model> vs <model>". A single model is compared against the configured recovery time.
+func parseRecoveryComparison(spec string, store *storage.Storage) (models.RecoveryModel, models.RecoveryModel, error) {
+	specs := strings.Split(spec, " vs ")
+	if len(specs) > 2 {
+		return models.RecoveryModel{}, models.RecoveryModel{}, fmt.Errorf("compare at most two recovery models, got %d", len(specs))
+	}
+
+	current := models.FlatRecoveryModel(models.AssumedRecoveryTime)
+	if cfg := store.GetConfig(); cfg != nil && cfg.RecoveryTime > 0 {
+		current = models.FlatRecoveryModel(cfg.RecoveryTime.Std())
+	}
+	if len(specs) == 2 {
+		var err error
+		if current, err = models.ParseRecoveryModel(specs[0]); err != nil {
+			return models.RecoveryModel{}, models.RecoveryModel{}, err
+		}
+	}
+
+	candidate, err := models.ParseRecoveryModel(specs[len(specs)-1])
+	if err != nil {
+		return models.RecoveryModel{}, models.RecoveryModel{}, err
+	}
+	return current, candidate, nil
+}
+
+// writeRecoveryComparison recomputes the range's statistics under two recovery models and
+// writes them side by side, without changing the configuration or any stored data
+func writeRecoveryComparison(w io.Writer, store *storage.Storage, rangeType, spec string) error {
+	current, candidate, err := parseRecoveryComparison(spec, store)
+	if err != nil {
+		return err
+	}
+
+	stats, err := store.GetDetailedStats(rangeType)
+	if err != nil {
+		return err
+	}
+	before := stats.EvaluateRecoveryModel(current)
+	after := stats.EvaluateRecoveryModel(candidate)
+
+	fmt.Fprintf(w, "Recovery model comparison for %s (%s to %s)\n",
+		rangeType,
+		stats.StartDate.Format("2006-01-02"),
+		stats.EndDate.Format("2006-01-02"))
+	fmt.Fprintln(w, strings.Repeat("-", 70))
+	fmt.Fprintf(w, "%-26s %-20s %-20s %s\n", "", "A: "+current.String(), "B: "+candidate.String(), "Change")
+
+	fmt.Fprintf(w, "%-26s %-20s %-20s %s\n", "Estimated recovery time",
+		formatDuration(before.RecoveryTime), formatDuration(after.RecoveryTime),
+		formatDurationChange(after.RecoveryTime-before.RecoveryTime))
+	fmt.Fprintf(w, "%-26s %-20s %-20s %s\n", "Total productivity impact",
+		formatDuration(before.ImpactedTime), formatDuration(after.ImpactedTime),
+		formatDurationChange(after.ImpactedTime-before.ImpactedTime))
+	fmt.Fprintf(w, "%-26s %-20s %-20s %+.1f\n", "Productivity score",
+		fmt.Sprintf("%.1f", before.ProductivityScore), fmt.Sprintf("%.1f", after.ProductivityScore),
+		after.ProductivityScore-before.ProductivityScore)
+
+	if len(stats.InterruptionsByTag) > 0 {
+		fmt.Fprintln(w, "\nRecovery by interruption type:")
+		fmt.Fprintln(w, strings.Repeat("-", 70))
+		fmt.Fprintf(w, "%-10s %-15s %-20s %-20s %s\n", "Type", "Count", "A", "B", "Change")
+
+		// Sort tags so the report is stable between runs
+		tags := make([]models.InterruptionTag, 0, len(stats.InterruptionsByTag))
+		for tag := range stats.InterruptionsByTag {
+			tags = append(tags, tag)
+		}
+		sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+
+		for _, tag := range tags {
+			fmt.Fprintf(w, "%-10s %-15d %-20s %-20s %s\n",
+				string(tag), stats.InterruptionsByTag[tag],
+				formatDuration(before.RecoveryByTag[tag]), formatDuration(after.RecoveryByTag[tag]),
+				formatDurationChange(after.RecoveryByTag[tag]-before.RecoveryByTag[tag]))
+		}
+	}
+
+	fmt.Fprintln(w, "\nDry run: the configuration and stored data were not changed.")
+	return nil
+}
+
+// formatDurationChange formats a signed difference between two durations
+func formatDurationChange(d time.Duration) string {
+	switch {
+	case d > 0:
+		return "+" + formatDuration(d)
+	case d < 0:
+		return "-" + formatDuration(-d)
+	}
+	return "0s"
+}
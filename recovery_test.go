@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRecoveryComparison tests the -compare-recovery what-if report
+func TestRecoveryComparison(t *testing.T) {
+	store := fixtureStorage(t)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeRecoveryComparison(&buf, store, "all", "10m vs 5m,meeting=20m"))
+	output := buf.String()
+	assert.Contains(t, output, "A: 10m")
+	assert.Contains(t, output, "B: 5m,meeting=20m")
+	assert.Contains(t, output, "Productivity score")
+	assert.Contains(t, output, "Recovery by interruption type:")
+
+	// A single model is compared against the configured recovery time
+	buf.Reset()
+	assert.NoError(t, writeRecoveryComparison(&buf, store, "all", "15m"))
+	assert.Contains(t, buf.String(), "A: 10m")
+	assert.Contains(t, buf.String(), "B: 15m")
+
+	assert.Error(t, writeRecoveryComparison(&buf, store, "all", "10m vs 5m vs 1m"))
+	assert.Error(t, writeRecoveryComparison(&buf, store, "all", "soon"))
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/lukaszraczylo/interruption-tracker/ui"
+)
+
+// loadReplayDay loads the day to replay: a stored day given as YYYY-MM-DD, or a daily file
+// at any path such as a backup
+func loadReplayDay(store *storage.Storage, day string) (*models.DailySessions, error) {
+	if date, err := time.ParseInLocation("2006-01-02", day, time.Local); err == nil {
+		return store.LoadDailySessions(date)
+	}
+	return store.LoadDayFile(day)
+}
+
+// runReplay replays a recorded day in the TUI, speed times faster than real time
+func runReplay(store *storage.Storage, day string, speed float64) error {
+	if speed <= 0 {
+		return fmt.Errorf("the replay speed must be positive, got %g", speed)
+	}
+	sessions, err := loadReplayDay(store, day)
+	if err != nil {
+		return err
+	}
+	replayUI, err := ui.NewReplayUI(store, sessions, speed)
+	if err != nil {
+		return err
+	}
+	return replayUI.Run()
+}
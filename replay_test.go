@@ -0,0 +1,31 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadReplayDay tests the day to replay is loaded from storage by date or from a daily
+// file by path
+func TestLoadReplayDay(t *testing.T) {
+	store := fixtureStorage(t)
+	days, err := store.ListAvailableDays()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, days)
+	date := days[0].Format("2006-01-02")
+
+	byDate, err := loadReplayDay(store, date)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, byDate.Sessions)
+
+	byPath, err := loadReplayDay(store, filepath.Join(store.GetDataDir(), "sessions_"+date+".json"))
+	assert.NoError(t, err)
+	assert.Len(t, byPath.Sessions, len(byDate.Sessions))
+
+	_, err = loadReplayDay(store, filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	assert.Error(t, runReplay(store, date, 0))
+}
@@ -0,0 +1,421 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/i18n"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// reportAttachment is an attached file as shown in the HTML report
+type reportAttachment struct {
+	Name   string
+	URL    template.URL // file:// link to the attached file
+	Size   string
+	SHA256 string
+	Note   string
+	Status models.AttachmentStatus
+}
+
+// reportSession is a session row of the HTML report
+type reportSession struct {
+	Start         string
+	End           string
+	Work          string
+	Interruptions int
+	Project       string
+	Description   string
+	Attachments   []reportAttachment
+}
+
+// reportDay groups the sessions of one day in the HTML report
+type reportDay struct {
+	Date     string
+	Work     string
+	Sessions []reportSession
+}
+
+// reportCost is the cost of the interruptions of one tag in the report
+type reportCost struct {
+	Tag   string
+	Count int
+	Lost  string // Interruption and recovery time
+	Cost  string
+}
+
+// reportLabels are the headings and labels of a report in the configured language
+type reportLabels struct {
+	Title         string
+	Generated     string
+	FocusedWork   string
+	Attachments   string
+	Start         string
+	End           string
+	Work          string
+	Interruptions string
+	Project       string
+	Description   string
+	NoSessions    string
+	Cost          string // Heading of the cost of interruptions
+	Type          string
+	Count         string
+	LostTime      string
+	Amount        string // Column of the cost of each type
+}
+
+// newReportLabels returns the report labels in the language of the printer
+func newReportLabels(p *i18n.Printer) reportLabels {
+	return reportLabels{
+		Title:         p.T("report.title"),
+		Generated:     p.T("report.generated"),
+		FocusedWork:   p.T("report.focused_work"),
+		Attachments:   p.T("report.attachments"),
+		Start:         p.T("column.start"),
+		End:           p.T("column.end"),
+		Work:          p.T("report.work"),
+		Interruptions: p.T("column.interruptions"),
+		Project:       p.T("report.project"),
+		Description:   p.T("column.description"),
+		NoSessions:    p.T("report.no_sessions"),
+		Cost:          p.T("report.cost"),
+		Type:          p.T("column.type"),
+		Count:         p.T("column.count"),
+		LostTime:      p.T("report.lost_time"),
+		Amount:        p.T("report.amount"),
+	}
+}
+
+// reportData is the content of a report and the data passed to report templates
+type reportData struct {
+	Lang             string       // Locale of the labels, e.g. "en"
+	Labels           reportLabels // Headings in the configured language
+	From             string
+	To               string
+	Generated        string
+	TotalWork        string
+	Attachments      int
+	Days             []reportDay
+	InterruptionCost string       // Cost of all interruptions, empty without an hourly rate
+	Costs            []reportCost // Cost of the interruptions per tag, costliest first
+}
+
+// reportTemplate renders a self-contained HTML page listing sessions and their evidence
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Labels.Title}} {{.From}} - {{.To}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+ul { margin: 0; padding-left: 1.2em; }
+.digest { font-family: monospace; font-size: 0.8em; color: #666; }
+.missing, .changed { color: #b00; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Labels.Title}} {{.From}} - {{.To}}</h1>
+<p>{{.Labels.Generated}} {{.Generated}}. {{.Labels.FocusedWork}}: {{.TotalWork}}. {{.Labels.Attachments}}: {{.Attachments}}.</p>
+{{$labels := .Labels}}{{range .Days}}
+<h2>{{.Date}} <small>({{.Work}})</small></h2>
+<table>
+<tr><th>{{$labels.Start}}</th><th>{{$labels.End}}</th><th>{{$labels.Work}}</th><th>{{$labels.Interruptions}}</th><th>{{$labels.Project}}</th><th>{{$labels.Description}}</th><th>{{$labels.Attachments}}</th></tr>
+{{range .Sessions}}<tr>
+<td>{{.Start}}</td><td>{{.End}}</td><td>{{.Work}}</td><td>{{.Interruptions}}</td><td>{{.Project}}</td><td>{{.Description}}</td>
+<td>{{if .Attachments}}<ul>{{range .Attachments}}
+<li><a href="{{.URL}}">{{.Name}}</a> ({{.Size}}){{if .Note}} - {{.Note}}{{end}}{{if ne .Status "ok"}} <span class="{{.Status}}">{{.Status}}</span>{{end}}<br><span class="digest">sha256 {{.SHA256}}</span></li>{{end}}
+</ul>{{end}}</td>
+</tr>
+{{end}}</table>
+{{else}}
+<p>{{.Labels.NoSessions}}</p>
+{{end}}
+{{if .InterruptionCost}}
+<h2>{{.Labels.Cost}} <small>({{.InterruptionCost}})</small></h2>
+<table>
+<tr><th>{{.Labels.Type}}</th><th>{{.Labels.Count}}</th><th>{{.Labels.LostTime}}</th><th>{{.Labels.Amount}}</th></tr>
+{{range .Costs}}<tr><td>{{.Tag}}</td><td>{{.Count}}</td><td>{{.Lost}}</td><td>{{.Cost}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// markdownReportTemplate renders the report as Markdown, e.g. for a wiki or pull request
+var markdownReportTemplate = texttemplate.Must(texttemplate.New("report").Parse(`# {{.Labels.Title}} {{.From}} - {{.To}}
+
+{{.Labels.Generated}} {{.Generated}}. {{.Labels.FocusedWork}}: {{.TotalWork}}. {{.Labels.Attachments}}: {{.Attachments}}.
+{{$labels := .Labels}}{{range .Days}}
+## {{.Date}} ({{.Work}})
+
+| {{$labels.Start}} | {{$labels.End}} | {{$labels.Work}} | {{$labels.Interruptions}} | {{$labels.Project}} | {{$labels.Description}} | {{$labels.Attachments}} |
+| --- | --- | --- | --- | --- | --- | --- |
+{{range .Sessions}}| {{.Start}} | {{.End}} | {{.Work}} | {{.Interruptions}} | {{.Project}} | {{.Description}} | {{range $i, $a := .Attachments}}{{if $i}}, {{end}}[{{$a.Name}}]({{$a.URL}}){{if ne $a.Status "ok"}} ({{$a.Status}}){{end}}{{end}} |
+{{end}}{{else}}
+{{.Labels.NoSessions}}
+{{end}}{{if .InterruptionCost}}
+## {{.Labels.Cost}} ({{.InterruptionCost}})
+
+| {{.Labels.Type}} | {{.Labels.Count}} | {{.Labels.LostTime}} | {{.Labels.Amount}} |
+| --- | --- | --- | --- |
+{{range .Costs}}| {{.Tag}} | {{.Count}} | {{.Lost}} | {{.Cost}} |
+{{end}}{{end}}`))
+
+// reportTemplatesDir is the directory in the data directory holding user report templates
+const reportTemplatesDir = "templates"
+
+// reportExecutor renders report data, implemented by HTML and text templates
+type reportExecutor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// isMarkdownPath reports whether a report path or template name is for Markdown
+func isMarkdownPath(path string) bool {
+	name := strings.ToLower(strings.TrimSuffix(path, ".tmpl"))
+	return strings.HasSuffix(name, ".md") || strings.HasSuffix(name, ".markdown")
+}
+
+// loadReportTemplate returns the template for a report written to outputPath. A named
+// template is read from the templates directory in the data directory; without a name
+// templates/report.html.tmpl or templates/report.md.tmpl replace the built-in ones when
+// present. Templates for Markdown use text/template, all others html/template so that
+// descriptions are escaped.
+func loadReportTemplate(store *storage.Storage, outputPath, name string) (reportExecutor, error) {
+	markdown := isMarkdownPath(outputPath)
+	builtin := name == ""
+	if builtin {
+		name = "report.html.tmpl"
+		if markdown {
+			name = "report.md.tmpl"
+		}
+	} else {
+		markdown = isMarkdownPath(name)
+	}
+
+	// Only names are accepted, templates always come from the templates directory
+	content, err := os.ReadFile(filepath.Join(store.GetDataDir(), reportTemplatesDir, filepath.Base(name)))
+	if builtin && errors.Is(err, os.ErrNotExist) {
+		if markdown {
+			return markdownReportTemplate, nil
+		}
+		return reportTemplate, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report template: %w", err)
+	}
+
+	var tmpl reportExecutor
+	if markdown {
+		tmpl, err = texttemplate.New(name).Parse(string(content))
+	} else {
+		tmpl, err = template.New(name).Parse(string(content))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse report template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// writeReport writes a report of the sessions in the range and the files attached to
+// them as evidence with the given template, checking that each file still exists unchanged
+func writeReport(w io.Writer, store *storage.Storage, tmpl reportExecutor, rangeType string, now time.Time) error {
+	data, err := buildReportData(store, rangeType, now)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
+
+// buildReportData collects the sessions of the range for a report
+func buildReportData(store *storage.Storage, rangeType string, now time.Time) (reportData, error) {
+	startDate, endDate, err := store.GetDateRange(rangeType)
+	if err != nil {
+		return reportData{}, err
+	}
+
+	cfg := store.GetConfig()
+	printer := i18n.New(cfg.Locale).WithClock(cfg.ClockFormat).WithDurationFormat(cfg.DurationFormat)
+	data := reportData{
+		Lang:      string(printer.Locale()),
+		Labels:    newReportLabels(printer),
+		From:      startDate.Format("2006-01-02"),
+		To:        endDate.Format("2006-01-02"),
+		Generated: printer.Date(now, "2006-01-02 15:04"),
+	}
+
+	var totalWork time.Duration
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dailySessions, err := store.LoadDailySessions(d)
+		if err != nil {
+			return reportData{}, fmt.Errorf("failed to load sessions for %s: %w", d.Format("2006-01-02"), err)
+		}
+		if len(dailySessions.Sessions) == 0 {
+			continue
+		}
+
+		sessions := make([]*models.Session, len(dailySessions.Sessions))
+		copy(sessions, dailySessions.Sessions)
+		sort.Slice(sessions, func(i, j int) bool {
+			return sessions[i].Start.StartTime.Before(sessions[j].Start.StartTime)
+		})
+
+		day := reportDay{Date: printer.Date(d, "Monday, 02 Jan 2006")}
+		var dayWork time.Duration
+		for _, session := range sessions {
+			work, _, interruptions := session.GetStats()
+			dayWork += work
+
+			row := reportSession{
+				Start:         printer.ShortTime(session.Start.StartTime),
+				End:           printer.T("report.active"),
+				Work:          printer.Duration(work),
+				Interruptions: interruptions,
+				Project:       session.Project,
+				Description:   session.Start.Description,
+			}
+			if session.End != nil {
+				row.End = printer.ShortTime(session.End.StartTime)
+			}
+
+			for _, attachment := range session.Attachments {
+				fileURL := url.URL{Scheme: "file", Path: filepath.ToSlash(attachment.Path)}
+				row.Attachments = append(row.Attachments, reportAttachment{
+					Name:   attachment.Name(),
+					URL:    template.URL(fileURL.String()),
+					Size:   formatFileSize(attachment.Size),
+					SHA256: attachment.SHA256,
+					Note:   attachment.Note,
+					Status: storage.CheckAttachment(attachment),
+				})
+				data.Attachments++
+			}
+
+			day.Sessions = append(day.Sessions, row)
+		}
+
+		day.Work = printer.Duration(dayWork)
+		totalWork += dayWork
+		data.Days = append(data.Days, day)
+	}
+	data.TotalWork = printer.Duration(totalWork)
+
+	// Interruption and recovery time priced at the configured hourly rate
+	stats := store.GetDetailedStatsBetween(startDate, endDate)
+	if costs, total, ok := store.InterruptionCosts(stats); ok && total.Count > 0 {
+		currency := store.GetConfig().Currency
+		data.InterruptionCost = models.FormatCost(total.Cost, currency)
+		for _, cost := range costs {
+			data.Costs = append(data.Costs, reportCost{
+				Tag:   string(cost.Tag),
+				Count: cost.Count,
+				Lost:  printer.Duration(cost.Lost()),
+				Cost:  models.FormatCost(cost.Cost, currency),
+			})
+		}
+	}
+
+	return data, nil
+}
+
+// writeReportFile writes the report for the range to path, as Markdown for .md files and
+// HTML otherwise, or with the named template from the templates directory
+func writeReportFile(store *storage.Storage, rangeType, path, templateName string) error {
+	tmpl, err := loadReportTemplate(store, path, templateName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	err = writeReport(file, store, tmpl, rangeType, time.Now())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// reportMailer sends a rendered report, implemented by integrations.Mailer
+type reportMailer interface {
+	Send(to []string, subject, contentType string, body []byte, now time.Time) error
+}
+
+// emailReport renders the report for the range in the configured email format, or with
+// the named template from the templates directory, and sends it to the configured
+// recipients. Markdown reports are sent as plain text.
+func emailReport(store *storage.Storage, mailer reportMailer, rangeType, templateName string, now time.Time) error {
+	cfg := store.GetConfig()
+	if len(cfg.EmailTo) == 0 {
+		return fmt.Errorf("email_to is not configured")
+	}
+
+	var markdown bool
+	switch strings.ToLower(cfg.EmailFormat) {
+	case "", "html":
+	case "markdown", "md":
+		markdown = true
+	default:
+		return fmt.Errorf("unknown email_format %q, use html or markdown", cfg.EmailFormat)
+	}
+	if templateName != "" {
+		markdown = isMarkdownPath(templateName)
+	}
+	path, contentType := "report.html", "text/html"
+	if markdown {
+		path, contentType = "report.md", "text/plain"
+	}
+
+	tmpl, err := loadReportTemplate(store, path, templateName)
+	if err != nil {
+		return err
+	}
+	data, err := buildReportData(store, rangeType, now)
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s %s - %s", data.Labels.Title, data.From, data.To)
+	return mailer.Send(cfg.EmailTo, subject, contentType, body.Bytes(), now)
+}
+
+// formatFileSize formats a size in bytes, e.g. "12.5 KB"
+func formatFileSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, suffix := float64(size)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
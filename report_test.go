@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/i18n"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWriteReport tests the HTML report listing sessions with their attachments
+func TestWriteReport(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	day := store.DayOf(now)
+	start := day.Add(9 * time.Hour)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: day,
+		Sessions: []*models.Session{
+			{
+				ID:    "sess_1",
+				Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: "Release <v2>"},
+				End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+			},
+			{
+				ID:    "sess_2",
+				Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start.Add(2 * time.Hour), Description: "Docs"},
+				End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(3 * time.Hour)},
+			},
+		},
+	}))
+
+	// Without an ID the latest session is picked
+	session, err := findAttachSession(store, day, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "sess_2", session.ID)
+	_, err = findAttachSession(store, day, "sess_3")
+	assert.Error(t, err)
+
+	evidence := filepath.Join(t.TempDir(), "release notes.pdf")
+	assert.NoError(t, os.WriteFile(evidence, []byte("notes"), 0644))
+	_, err = store.AttachFile(day, "sess_1", evidence, "Signed off", now)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeReport(&buf, store, reportTemplate, "day", now))
+	report := buf.String()
+	assert.Contains(t, report, "Release &lt;v2&gt;")
+	assert.Contains(t, report, "release%20notes.pdf\">release notes.pdf</a> (5 B) - Signed off")
+	assert.Contains(t, report, "Attachments: 1.")
+	assert.NotContains(t, report, "class=\"missing\"")
+
+	// Files removed since attaching are flagged
+	assert.NoError(t, os.Remove(evidence))
+	buf.Reset()
+	assert.NoError(t, writeReport(&buf, store, reportTemplate, "day", now))
+	assert.Contains(t, buf.String(), "<span class=\"missing\">missing</span>")
+}
+
+// TestReportTemplates tests the built-in Markdown report and user templates from the
+// templates directory
+func TestReportTemplates(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	day := store.DayOf(now)
+	start := day.Add(9 * time.Hour)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: day,
+		Sessions: []*models.Session{{
+			ID:    "sess_1",
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: "Release <v2>"},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+		}},
+	}))
+	output := filepath.Join(t.TempDir(), "report.md")
+
+	// Built-in Markdown for .md files
+	assert.NoError(t, writeReportFile(store, "day", output, ""))
+	report, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(report), "# Work report")
+	assert.Contains(t, string(report), "| 09:00 | 10:00 | 1h 0m | 0 |  | Release <v2> |  |")
+
+	// templates/report.md.tmpl replaces the built-in template
+	templates := filepath.Join(store.GetDataDir(), reportTemplatesDir)
+	assert.NoError(t, os.MkdirAll(templates, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(templates, "report.md.tmpl"),
+		[]byte("Acme {{.From}}{{range .Days}}{{range .Sessions}} {{.Description}}{{end}}{{end}}"), 0644))
+	assert.NoError(t, writeReportFile(store, "day", output, ""))
+	report, err = os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme "+day.Format("2006-01-02")+" Release <v2>", string(report))
+
+	// Named HTML templates escape descriptions
+	assert.NoError(t, os.WriteFile(filepath.Join(templates, "team.html.tmpl"),
+		[]byte("<p>{{.TotalWork}}{{range .Days}}{{range .Sessions}} {{.Description}}{{end}}{{end}}</p>"), 0644))
+	assert.NoError(t, writeReportFile(store, "day", output, "team.html.tmpl"))
+	report, err = os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>1h 0m Release &lt;v2&gt;</p>", string(report))
+
+	assert.Error(t, writeReportFile(store, "day", output, "missing.html.tmpl"))
+	assert.NoError(t, os.WriteFile(filepath.Join(templates, "broken.md.tmpl"), []byte("{{.Nope"), 0644))
+	assert.Error(t, writeReportFile(store, "day", output, "broken.md.tmpl"))
+}
+
+// TestFormatFileSize tests human-readable attachment sizes
+func TestFormatFileSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatFileSize(512))
+	assert.Equal(t, "1.5 KB", formatFileSize(1536))
+	assert.Equal(t, "2.0 MB", formatFileSize(2*1024*1024))
+}
+
+// TestReportInterruptionCost tests the report prices interruptions and recovery at the
+// configured hourly rate, and leaves the cost out without one
+func TestReportInterruptionCost(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	day := store.DayOf(now)
+	start := day.Add(9 * time.Hour)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: day,
+		Sessions: []*models.Session{{
+			ID:    "sess_1",
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: "Release"},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+			Interruptions: []*models.TimeEntry{
+				{Type: models.EntryTypeInterruption, Tag: models.TagMeeting, StartTime: start.Add(10 * time.Minute)},
+				{Type: models.EntryTypeReturn, StartTime: start.Add(30 * time.Minute)},
+			},
+		}},
+	}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeReport(&buf, store, markdownReportTemplate, "day", now))
+	assert.NotContains(t, buf.String(), "Cost of interruptions")
+
+	cfg := store.GetConfig()
+	cfg.HourlyRate = 60
+	cfg.Currency = "EUR"
+	cfg.RecoveryTime = 0 // The assumed 10 minutes
+	buf.Reset()
+	assert.NoError(t, writeReport(&buf, store, markdownReportTemplate, "day", now))
+	assert.Contains(t, buf.String(), "## Cost of interruptions (30.00 EUR)")
+	assert.Contains(t, buf.String(), "| meeting | 1 | 30m 0s | 30.00 EUR |")
+}
+
+// TestReportLocale tests the report is written in the configured language
+func TestReportLocale(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	day := store.DayOf(now)
+	start := day.Add(9 * time.Hour)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: day,
+		Sessions: []*models.Session{{
+			ID:    "sess_1",
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: "Release"},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(90 * time.Minute)},
+		}},
+	}))
+	store.GetConfig().Locale = "pl"
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeReport(&buf, store, markdownReportTemplate, "day", now))
+	assert.Contains(t, buf.String(), "Raport pracy")
+	assert.Contains(t, buf.String(), "1 godz. 30 min")
+	assert.Contains(t, buf.String(), i18n.New("pl").Date(day, "Monday, 02 Jan 2006"))
+	assert.Contains(t, buf.String(), "| 09:00 | 10:30 |")
+
+	// Session times follow the configured clock
+	store.GetConfig().ClockFormat = "12h"
+	buf.Reset()
+	assert.NoError(t, writeReport(&buf, store, markdownReportTemplate, "day", now))
+	assert.Contains(t, buf.String(), "| 9:00 AM | 10:30 AM |")
+}
+
+// sentReport is a report handed to fakeMailer
+type sentReport struct {
+	to          []string
+	subject     string
+	contentType string
+	body        string
+}
+
+// fakeMailer records the reports it is asked to send
+type fakeMailer struct {
+	sent []sentReport
+}
+
+func (m *fakeMailer) Send(to []string, subject, contentType string, body []byte, now time.Time) error {
+	m.sent = append(m.sent, sentReport{to: to, subject: subject, contentType: contentType, body: string(body)})
+	return nil
+}
+
+// TestEmailReport tests the report is rendered in the configured email format and sent to
+// the configured recipients
+func TestEmailReport(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	day := store.DayOf(now)
+	start := day.Add(9 * time.Hour)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: day,
+		Sessions: []*models.Session{{
+			ID:    "sess_1",
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: "Release <v2>"},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+		}},
+	}))
+
+	mailer := &fakeMailer{}
+	assert.Error(t, emailReport(store, mailer, "day", "", now))
+
+	cfg := store.GetConfig()
+	cfg.EmailTo = []string{"me@example.com"}
+	assert.NoError(t, emailReport(store, mailer, "day", "", now))
+	date := day.Format("2006-01-02")
+	assert.Equal(t, sentReport{
+		to:          []string{"me@example.com"},
+		subject:     "Work report " + date + " - " + date,
+		contentType: "text/html",
+		body:        mailer.sent[0].body,
+	}, mailer.sent[0])
+	assert.Contains(t, mailer.sent[0].body, "Release &lt;v2&gt;")
+
+	cfg.EmailFormat = "markdown"
+	assert.NoError(t, emailReport(store, mailer, "day", "", now))
+	assert.Equal(t, "text/plain", mailer.sent[1].contentType)
+	assert.Contains(t, mailer.sent[1].body, "| Release <v2> |")
+
+	cfg.EmailFormat = "pdf"
+	assert.Error(t, emailReport(store, mailer, "day", "", now))
+	assert.Len(t, mailer.sent, 2)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// runRotateKey asks for a new encryption passphrase twice, re-encrypts all data with it
+// and saves it to the configuration at configPath
+func runRotateKey(store *storage.Storage, configPath string, input io.Reader, out io.Writer) error {
+	if configPath == "" {
+		return fmt.Errorf("no configuration file to save the new passphrase to")
+	}
+	if store.KeyRotationPending() {
+		fmt.Fprintln(out, "Resuming an interrupted key rotation, enter the same new passphrase as before.")
+	}
+
+	in := bufio.NewReader(input)
+	fmt.Fprint(out, "New encryption passphrase: ")
+	passphrase, _ := in.ReadString('\n')
+	passphrase = strings.TrimRight(passphrase, "\r\n")
+	fmt.Fprint(out, "Repeat the new passphrase: ")
+	repeated, _ := in.ReadString('\n')
+	if strings.TrimRight(repeated, "\r\n") != passphrase {
+		return fmt.Errorf("the passphrases do not match, nothing was changed")
+	}
+
+	rotated, err := store.RotateKey(passphrase)
+	if err != nil {
+		return fmt.Errorf("key rotation stopped after %d file(s), rerun -rotate-key with the same passphrase to resume: %w", rotated, err)
+	}
+
+	if err := config.SaveConfigToPath(store.GetConfig(), configPath); err != nil {
+		return fmt.Errorf("data was re-encrypted but saving the configuration failed, rerun -rotate-key with the same passphrase: %w", err)
+	}
+	if err := store.FinishKeyRotation(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Re-encrypted %d file(s) and saved the new passphrase to %s.\n", rotated, configPath)
+	return nil
+}
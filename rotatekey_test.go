@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunRotateKey tests the new passphrase must be typed twice and is saved to the
+// configuration after the data is re-encrypted
+func TestRunRotateKey(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.EnableEncryption = true
+	cfg.EncryptionKey = "old passphrase"
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, config.SaveConfigToPath(cfg, configPath))
+	store, err := storage.NewStorageWithConfig(cfg, t.TempDir())
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.Error(t, runRotateKey(store, configPath, strings.NewReader("new passphrase\ntypo\n"), &out))
+
+	out.Reset()
+	assert.NoError(t, runRotateKey(store, configPath, strings.NewReader("new passphrase\nnew passphrase\n"), &out))
+	assert.Contains(t, out.String(), "saved the new passphrase")
+	assert.False(t, store.KeyRotationPending())
+
+	saved, err := config.LoadConfigFromPath(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "new passphrase", saved.EncryptionKey)
+}
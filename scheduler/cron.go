@@ -0,0 +1,186 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule decides when a job is due again
+type Schedule interface {
+	// Next returns the first time after last the job is due, zero for never
+	Next(last time.Time) time.Time
+}
+
+// Every is a schedule repeating at a fixed interval
+type Every time.Duration
+
+// Next returns last plus the interval
+func (e Every) Next(last time.Time) time.Time {
+	return last.Add(time.Duration(e))
+}
+
+// cronMacros are the shorthands accepted in place of five fields
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronField is the range and the names of the values of a field
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // Names of the values from min, e.g. "jan" for 1
+}
+
+// cronFields are the fields of an expression in order
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "weekday", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat", "sun"}},
+}
+
+// Cron is a schedule of a five-field cron expression: minute, hour, day of month, month
+// and weekday, each "*", a value, a range "a-b", a list "a,b" or a step "*/n" or "a-b/n".
+// As in cron, a day matches either field when both the day and the weekday are set.
+type Cron struct {
+	minute, hour, day, month, weekday uint64 // Bit sets of the matching values
+	anyDay, anyWeekday                bool   // Day or weekday is "*"
+}
+
+// ParseCron parses a cron expression or one of @hourly, @daily, @weekly and @monthly
+func ParseCron(expr string) (Cron, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return Cron{}, fmt.Errorf("invalid cron expression %q, use minute hour day month weekday", expr)
+	}
+
+	var sets [5]uint64
+	for i, field := range cronFields {
+		set, err := parseCronField(fields[i], field)
+		if err != nil {
+			return Cron{}, fmt.Errorf("invalid %s in cron expression %q: %w", field.name, expr, err)
+		}
+		sets[i] = set
+	}
+
+	// Sunday is 0 or 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return Cron{
+		minute:     sets[0],
+		hour:       sets[1],
+		day:        sets[2],
+		month:      sets[3],
+		weekday:    sets[4],
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps into a bit set
+func parseCronField(value string, field cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(value, ",") {
+		spec, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepText)
+			}
+		}
+
+		from, to := field.min, field.max
+		if spec != "*" {
+			fromText, toText, isRange := strings.Cut(spec, "-")
+			var err error
+			if from, err = parseCronValue(fromText, field); err != nil {
+				return 0, err
+			}
+			to = from
+			if isRange {
+				if to, err = parseCronValue(toText, field); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				to = field.max
+			}
+			if to < from {
+				return 0, fmt.Errorf("range %q ends before it starts", spec)
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// parseCronValue parses a number or a name of a field value
+func parseCronValue(value string, field cronField) (int, error) {
+	for i, name := range field.names {
+		if value == name {
+			return field.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("%q is not between %d and %d", value, field.min, field.max)
+	}
+	return v, nil
+}
+
+// cronSearchLimit is how far ahead Next looks for a matching time
+const cronSearchLimit = 5 // Years
+
+// Next returns the first minute after last matching the expression, zero when none does
+// within five years, e.g. for February 30th
+func (c Cron) Next(last time.Time) time.Time {
+	t := time.Date(last.Year(), last.Month(), last.Day(), last.Hour(), last.Minute()+1, 0, 0, last.Location())
+	limit := t.AddDate(cronSearchLimit, 0, 0)
+
+	for t.Before(limit) {
+		year, month, day := t.Date()
+		switch {
+		case c.month&(1<<uint(month)) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.matchesDay(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay reports whether the day of t matches the day and weekday fields
+func (c Cron) matchesDay(t time.Time) bool {
+	day := c.day&(1<<uint(t.Day())) != 0
+	weekday := c.weekday&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.anyDay && c.anyWeekday:
+		return true
+	case c.anyDay:
+		return weekday
+	case c.anyWeekday:
+		return day
+	}
+	return day || weekday
+}
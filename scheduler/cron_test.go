@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseCron tests fields, names, lists, ranges, steps and macros
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "*/15 9-17 * * mon-fri", "0 8 1,15 jan-jun *", "30 22 * * 7", "@weekly", " @Daily "} {
+		_, err := ParseCron(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * someday", "*/0 * * * *", "5-1 * * * *", "@yearly"} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+// TestCronNext tests the next matching minute after a time
+func TestCronNext(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	next := func(expr string, last time.Time) time.Time {
+		cron, err := ParseCron(expr)
+		assert.NoError(t, err, expr)
+		return cron.Next(last)
+	}
+
+	// Always after last, even on a matching minute
+	assert.Equal(t, monday.Add(8*time.Hour), next("0 8 * * mon", monday))
+	assert.Equal(t, monday.AddDate(0, 0, 7).Add(8*time.Hour), next("0 8 * * mon", monday.Add(8*time.Hour)))
+	assert.Equal(t, monday.Add(9*time.Hour+15*time.Minute), next("*/15 9-17 * * mon-fri", monday.Add(9*time.Hour+5*time.Minute)))
+	assert.Equal(t, monday.AddDate(0, 0, 3).Add(9*time.Hour), next("*/15 9-17 * * mon-fri", monday.AddDate(0, 0, 2).Add(17*time.Hour+45*time.Minute)))
+	assert.Equal(t, monday.AddDate(0, 0, 6).Add(22*time.Hour+30*time.Minute), next("30 22 * * 7", monday))
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), next("@monthly", monday))
+
+	// A day or a weekday matches when both are set
+	assert.Equal(t, monday.AddDate(0, 0, 4), next("0 0 15 * fri", monday))
+	assert.Equal(t, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), next("0 0 15 * fri", monday.AddDate(0, 0, 11)))
+
+	// Dates that never come are never due
+	assert.True(t, next("0 0 30 feb *", monday).IsZero())
+}
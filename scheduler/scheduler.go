@@ -0,0 +1,210 @@
+// Package scheduler runs automated tasks, such as backups and reports, at configured times
+// while the TUI or the daemon runs.
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+)
+
+// Tasks of scheduled jobs
+const (
+	TaskBackup      = "backup"       // Back up the daily files changed since their latest backup, and upload an archive to the remote target when configured
+	TaskCompact     = "compact"      // Keep one backup of each daily file per day
+	TaskReport      = "report"       // Write the report to a file
+	TaskEmailReport = "email_report" // Email the report
+	TaskSync        = "sync"         // Sync with git or Toggl
+)
+
+// backupIntervalJob is the name of the backup run every backup_interval days
+const backupIntervalJob = "backup_interval"
+
+// Job is a task run on a schedule
+type Job struct {
+	Name     string // Unique, when the job last ran is kept under it
+	Schedule Schedule
+	Config   config.ScheduledJob // Task and its settings
+}
+
+// Jobs returns the jobs of a configuration: a backup every backup_interval days when
+// backups are enabled, and the scheduled jobs
+func Jobs(cfg *config.Config) ([]Job, error) {
+	var jobs []Job
+	if cfg.BackupEnabled && cfg.BackupInterval > 0 {
+		jobs = append(jobs, Job{
+			Name:     backupIntervalJob,
+			Schedule: Every(time.Duration(cfg.BackupInterval) * 24 * time.Hour),
+			Config:   config.ScheduledJob{Task: TaskBackup},
+		})
+	}
+
+	names := map[string]bool{backupIntervalJob: true}
+	for i, job := range cfg.Schedule {
+		job.Task = strings.ToLower(strings.TrimSpace(job.Task))
+		switch job.Task {
+		case TaskBackup, TaskCompact, TaskEmailReport:
+		case TaskSync:
+			if job.Target != "" && job.Target != "git" && job.Target != "toggl" {
+				return nil, fmt.Errorf("scheduled job %d: unknown sync target %q, use git or toggl", i+1, job.Target)
+			}
+		case TaskReport:
+			if job.Path == "" {
+				return nil, fmt.Errorf("scheduled job %d: report needs a path", i+1)
+			}
+		default:
+			return nil, fmt.Errorf("scheduled job %d: unknown task %q, use backup, compact, report, email_report or sync", i+1, job.Task)
+		}
+
+		schedule, err := ParseCron(job.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("scheduled job %d: %w", i+1, err)
+		}
+
+		name := job.Name
+		if name == "" {
+			name = job.Task
+		}
+		if names[name] {
+			return nil, fmt.Errorf("scheduled job %d: duplicate name %q, set a name for each %s job", i+1, name, job.Task)
+		}
+		names[name] = true
+		jobs = append(jobs, Job{Name: name, Schedule: schedule, Config: job})
+	}
+	return jobs, nil
+}
+
+// Task runs a job
+type Task func(job config.ScheduledJob, now time.Time) error
+
+// Result is the outcome of a run of a job
+type Result struct {
+	Job string
+	Err error
+}
+
+// Scheduler runs jobs when they are due. When each job last ran is kept in a file, so a
+// run missed while nothing was running is caught up once, and a job seen for the first
+// time is first due a full period later.
+type Scheduler struct {
+	jobs      []Job
+	tasks     map[string]Task
+	statePath string
+	mu        sync.Mutex // Serializes runs
+}
+
+// New creates a scheduler running the jobs with the tasks, keeping when each job last ran
+// in the file at statePath
+func New(jobs []Job, tasks map[string]Task, statePath string) *Scheduler {
+	return &Scheduler{
+		jobs:      jobs,
+		tasks:     tasks,
+		statePath: statePath,
+	}
+}
+
+// Jobs returns the jobs of the scheduler
+func (s *Scheduler) Jobs() []Job {
+	return s.jobs
+}
+
+// RunDue runs the jobs due at now in order and returns their results. The state is read
+// before and saved after every run, so a job another process ran already is not run again.
+func (s *Scheduler) RunDue(now time.Time) ([]Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.loadState()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, job := range s.jobs {
+		last, seen := state[job.Name]
+		if seen {
+			if next := job.Schedule.Next(last); next.IsZero() || now.Before(next) {
+				continue
+			}
+		}
+
+		// Recorded before running, so a failing job is retried at its next time
+		state[job.Name] = now
+		if err := s.saveState(state); err != nil {
+			return results, err
+		}
+		if !seen {
+			continue
+		}
+
+		task, ok := s.tasks[job.Config.Task]
+		if !ok {
+			results = append(results, Result{Job: job.Name, Err: fmt.Errorf("task %q is not available", job.Config.Task)})
+			continue
+		}
+		results = append(results, Result{Job: job.Name, Err: task(job.Config, now)})
+	}
+	return results, nil
+}
+
+// Start runs the due jobs now and then every interval in the background, handing every
+// result to report, until the returned function is called
+func (s *Scheduler) Start(interval time.Duration, report func(Result)) func() {
+	done := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			results, err := s.RunDue(time.Now())
+			for _, result := range results {
+				report(result)
+			}
+			if err != nil {
+				report(Result{Err: err})
+			}
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// loadState reads when each job last ran, empty before the first run
+func (s *Scheduler) loadState() (map[string]time.Time, error) {
+	state := map[string]time.Time{}
+	data, err := os.ReadFile(s.statePath)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scheduler state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse scheduler state: %w", err)
+	}
+	return state, nil
+}
+
+// saveState writes when each job last ran
+func (s *Scheduler) saveState(state map[string]time.Time) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scheduler state: %w", err)
+	}
+	if err := os.WriteFile(s.statePath, data, 0600); err != nil {
+		return fmt.Errorf("failed to save scheduler state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestJobs tests the jobs of a configuration and invalid scheduled jobs
+func TestJobs(t *testing.T) {
+	jobs, err := Jobs(&config.Config{
+		BackupEnabled:  true,
+		BackupInterval: 7,
+		Schedule: []config.ScheduledJob{
+			{Task: "Compact", Cron: "@daily"},
+			{Task: "report", Cron: "0 18 * * fri", Path: "week-{date}.html"},
+			{Name: "monthly_report", Task: "report", Cron: "@monthly", Path: "month.html", Range: "month"},
+		},
+	})
+	assert.NoError(t, err)
+	var names []string
+	for _, job := range jobs {
+		names = append(names, job.Name)
+	}
+	assert.Equal(t, []string{"backup_interval", "compact", "report", "monthly_report"}, names)
+	assert.Equal(t, Every(7*24*time.Hour), jobs[0].Schedule)
+	assert.Equal(t, TaskCompact, jobs[1].Config.Task)
+
+	jobs, err = Jobs(&config.Config{BackupInterval: 7})
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	for _, job := range []config.ScheduledJob{
+		{Task: "defrag", Cron: "@daily"},
+		{Task: "sync", Cron: "daily"},
+		{Task: "report", Cron: "@daily"},
+		{Task: "sync", Cron: "@daily", Target: "dropbox"},
+	} {
+		_, err := Jobs(&config.Config{Schedule: []config.ScheduledJob{job}})
+		assert.Error(t, err, job.Task)
+	}
+	_, err = Jobs(&config.Config{Schedule: []config.ScheduledJob{{Task: "sync", Cron: "@daily"}, {Task: "sync", Cron: "@hourly"}}})
+	assert.Error(t, err)
+}
+
+// TestRunDue tests jobs are first due a period after they are seen, run once however many
+// times were missed, and keep their state across schedulers
+func TestRunDue(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "scheduler.json")
+	monday := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	daily, err := ParseCron("0 8 * * *")
+	assert.NoError(t, err)
+
+	var runs []string
+	tasks := map[string]Task{
+		TaskCompact: func(job config.ScheduledJob, now time.Time) error {
+			runs = append(runs, "compact "+now.Format("Jan 2 15:04"))
+			return nil
+		},
+		TaskSync: func(job config.ScheduledJob, now time.Time) error {
+			return errors.New("offline")
+		},
+	}
+	jobs := []Job{
+		{Name: "compact", Schedule: daily, Config: config.ScheduledJob{Task: TaskCompact}},
+		{Name: "sync", Schedule: Every(time.Hour), Config: config.ScheduledJob{Task: TaskSync}},
+		{Name: "report", Schedule: Every(time.Hour), Config: config.ScheduledJob{Task: TaskReport}},
+	}
+
+	s := New(jobs, tasks, statePath)
+	results, err := s.RunDue(monday)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	results, err = s.RunDue(monday.Add(30 * time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Three days later the daily job runs once
+	s = New(jobs, tasks, statePath)
+	results, err = s.RunDue(monday.AddDate(0, 0, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"compact Mar 6 09:00"}, runs)
+	assert.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	assert.EqualError(t, results[1].Err, "offline")
+	assert.EqualError(t, results[2].Err, `task "report" is not available`)
+
+	// Failed jobs wait for their next time too
+	results, err = s.RunDue(monday.AddDate(0, 0, 3).Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
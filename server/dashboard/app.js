@@ -0,0 +1,211 @@
+// Read-only dashboard: polls /graphql and renders the current state, today's timeline
+// and this week's charts. Nothing here changes tracker data.
+"use strict";
+
+const REFRESH_MS = 10000;
+
+const QUERY = `query Dashboard {
+  today: sessions(range: "day") {
+    description start end active workSeconds interruptionCount
+    interruptions { tag description start end durationSeconds }
+  }
+  week: stats(range: "week") {
+    startDate endDate workSeconds totalSessions totalInterruptions productivityScore
+    dailyWork { date workSeconds }
+    interruptionsByTag { tag count durationSeconds }
+  }
+}`;
+
+let current = null; // Latest query result, used by the one-second ticker
+
+function formatDuration(seconds) {
+  seconds = Math.max(0, Math.floor(seconds));
+  const h = Math.floor(seconds / 3600);
+  const m = Math.floor((seconds % 3600) / 60);
+  const s = seconds % 60;
+  if (h > 0) return `${h}h ${m}m`;
+  if (m > 0) return `${m}m ${s}s`;
+  return `${s}s`;
+}
+
+function row(label, value) {
+  const tr = document.createElement("tr");
+  const labelCell = document.createElement("td");
+  const valueCell = document.createElement("td");
+  labelCell.textContent = label;
+  valueCell.textContent = value;
+  valueCell.className = "value";
+  tr.append(labelCell, valueCell);
+  return tr;
+}
+
+// activeState returns the running session and its ongoing interruption, if any
+function activeState(sessions) {
+  const session = sessions.find((s) => s.active);
+  if (!session) return { session: null, interruption: null };
+  const last = session.interruptions[session.interruptions.length - 1];
+  return { session, interruption: last && !last.end ? last : null };
+}
+
+function renderState(now) {
+  const { session, interruption } = activeState(current.today);
+  const state = document.getElementById("state");
+  const elapsed = document.getElementById("elapsed");
+  const description = document.getElementById("description");
+
+  if (!session) {
+    state.textContent = "idle";
+    state.className = "idle";
+    elapsed.textContent = "";
+    description.textContent = "No active session";
+    return;
+  }
+
+  if (interruption) {
+    state.textContent = "interrupted";
+    state.className = "interrupted";
+    elapsed.textContent = formatDuration((now - new Date(interruption.start)) / 1000);
+    description.textContent = `${interruption.tag}: ${interruption.description || "(no description)"}`;
+    return;
+  }
+
+  state.textContent = "working";
+  state.className = "working";
+  elapsed.textContent = formatDuration((now - new Date(session.start)) / 1000);
+  description.textContent = session.description || "(no description)";
+}
+
+function renderToday(now) {
+  const table = document.getElementById("today");
+  let work = 0;
+  let interrupted = 0;
+  let interruptions = 0;
+  for (const session of current.today) {
+    work += session.workSeconds;
+    interruptions += session.interruptionCount;
+    for (const interruption of session.interruptions) {
+      interrupted += interruption.durationSeconds != null
+        ? interruption.durationSeconds
+        : (now - new Date(interruption.start)) / 1000;
+    }
+  }
+  table.replaceChildren(
+    row("Work", formatDuration(work)),
+    row("Interrupted", formatDuration(interrupted)),
+    row("Interruptions", interruptions),
+    row("Sessions", current.today.length),
+  );
+}
+
+function renderTimeline(now) {
+  const timeline = document.getElementById("timeline");
+  const axis = document.getElementById("axis");
+  timeline.replaceChildren();
+  axis.replaceChildren();
+
+  // Span the working day, widened to cover sessions outside it
+  const dayStart = new Date(now);
+  dayStart.setHours(8, 0, 0, 0);
+  const dayEnd = new Date(now);
+  dayEnd.setHours(18, 0, 0, 0);
+  let from = dayStart.getTime();
+  let to = Math.max(dayEnd.getTime(), now.getTime());
+  for (const session of current.today) {
+    from = Math.min(from, new Date(session.start).getTime());
+    if (session.end) to = Math.max(to, new Date(session.end).getTime());
+  }
+  from = Math.floor(from / 3600000) * 3600000;
+  to = Math.ceil(to / 3600000) * 3600000;
+
+  const span = (cls, start, end) => {
+    const div = document.createElement("div");
+    div.className = cls;
+    div.style.left = `${((start - from) / (to - from)) * 100}%`;
+    div.style.width = `${Math.max(((end - start) / (to - from)) * 100, 0.2)}%`;
+    timeline.append(div);
+    return div;
+  };
+
+  for (const session of current.today) {
+    const start = new Date(session.start).getTime();
+    const end = session.end ? new Date(session.end).getTime() : now.getTime();
+    span("work", start, end).title = session.description || "(no description)";
+    for (const interruption of session.interruptions) {
+      const interruptionStart = new Date(interruption.start).getTime();
+      const interruptionEnd = interruption.end ? new Date(interruption.end).getTime() : now.getTime();
+      span("interruption", interruptionStart, interruptionEnd).title =
+        `${interruption.tag}: ${interruption.description || "(no description)"}`;
+    }
+  }
+
+  const hours = (to - from) / 3600000;
+  const step = hours > 12 ? 3 : 2;
+  for (let t = from; t <= to; t += step * 3600000) {
+    const label = document.createElement("span");
+    label.textContent = `${String(new Date(t).getHours()).padStart(2, "0")}:00`;
+    axis.append(label);
+  }
+}
+
+function renderWeek() {
+  const week = current.week;
+  const bars = document.getElementById("week");
+  bars.replaceChildren();
+
+  const worked = new Map(week.dailyWork.map((d) => [d.date, d.workSeconds]));
+  const days = [];
+  for (let d = new Date(`${week.startDate}T00:00:00`); d <= new Date(`${week.endDate}T00:00:00`); d.setDate(d.getDate() + 1)) {
+    const date = `${d.getFullYear()}-${String(d.getMonth() + 1).padStart(2, "0")}-${String(d.getDate()).padStart(2, "0")}`;
+    days.push({ label: d.toLocaleDateString(undefined, { weekday: "short" }), seconds: worked.get(date) || 0 });
+  }
+  const max = Math.max(...days.map((d) => d.seconds), 1);
+  for (const day of days) {
+    const bar = document.createElement("div");
+    bar.className = "bar";
+    const fill = document.createElement("div");
+    fill.style.height = `${(day.seconds / max) * 100}%`;
+    fill.title = formatDuration(day.seconds);
+    const label = document.createElement("span");
+    label.textContent = day.label;
+    bar.append(fill, label);
+    bars.append(bar);
+  }
+
+  const tags = document.getElementById("tags");
+  tags.replaceChildren(
+    row("Work", formatDuration(week.workSeconds)),
+    row("Productivity score", week.productivityScore.toFixed(1)),
+    ...week.interruptionsByTag.map((t) => row(`${t.tag} (${t.count})`, formatDuration(t.durationSeconds))),
+  );
+}
+
+function render() {
+  if (!current) return;
+  const now = new Date();
+  renderState(now);
+  renderToday(now);
+  renderTimeline(now);
+}
+
+async function refresh() {
+  const error = document.getElementById("error");
+  try {
+    const response = await fetch("graphql", {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ query: QUERY }),
+    });
+    const body = await response.json();
+    if (body.errors && body.errors.length) throw new Error(body.errors[0].message);
+    current = body.data;
+    error.textContent = "";
+    renderWeek();
+    render();
+  } catch (err) {
+    error.textContent = `Could not load data: ${err.message}`;
+  }
+}
+
+refresh();
+setInterval(refresh, REFRESH_MS);
+setInterval(render, 1000);
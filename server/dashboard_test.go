@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDashboard tests the embedded dashboard is served and its query runs on the schema
+func TestDashboard(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: store.DayOf(now),
+		Sessions: []*models.Session{{
+			ID:    "s1",
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Deep work"},
+			Interruptions: []*models.TimeEntry{
+				{Type: models.EntryTypeInterruption, StartTime: now.Add(-10 * time.Minute), Tag: models.TagCall},
+			},
+		}},
+	}))
+	srv := NewServer(store)
+
+	get := func(method, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	index := get(http.MethodGet, "/")
+	assert.Equal(t, http.StatusOK, index.Code)
+	assert.Contains(t, index.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, index.Body.String(), `<script src="app.js">`)
+
+	script := get(http.MethodGet, "/app.js")
+	assert.Equal(t, http.StatusOK, script.Code)
+	assert.Equal(t, http.StatusNotFound, get(http.MethodGet, "/missing.js").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, get(http.MethodPost, "/").Code)
+
+	// The query the dashboard sends must work against the schema
+	match := regexp.MustCompile("(?s)const QUERY = `(.*?)`").FindStringSubmatch(script.Body.String())
+	if assert.Len(t, match, 2) {
+		data, err := executeQuery(match[1], nil, srv.rootResolvers())
+		assert.NoError(t, err)
+		today := data["today"].([]interface{})
+		if assert.Len(t, today, 1) {
+			session := today[0].(map[string]interface{})
+			assert.Equal(t, true, session["active"])
+			assert.Len(t, session["interruptions"], 1)
+		}
+		assert.Contains(t, data["week"], "dailyWork")
+	}
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// Live session states pushed on /events
+const (
+	stateWorking     = "working"
+	stateInterrupted = "interrupted"
+	stateIdle        = "idle"
+)
+
+// Event types pushed on /events
+const (
+	eventState     = "state"     // Snapshot sent when a client connects
+	eventStart     = "start"     // A session started
+	eventEnd       = "end"       // The session ended
+	eventInterrupt = "interrupt" // The session was interrupted
+	eventReturn    = "return"    // Work resumed after an interruption
+	eventTick      = "tick"      // Elapsed time update while a session is active
+)
+
+// defaultEventInterval is how often /events checks for changes and ticks
+const defaultEventInterval = time.Second
+
+// liveState is the current session state reported with every event
+type liveState struct {
+	State              string `json:"state"` // working, interrupted or idle
+	SessionID          string `json:"session_id,omitempty"`
+	Description        string `json:"description,omitempty"`
+	Project            string `json:"project,omitempty"`
+	Tag                string `json:"tag,omitempty"` // Tag of the ongoing interruption
+	ElapsedSeconds     int    `json:"elapsed_seconds"`
+	InterruptedSeconds int    `json:"interrupted_seconds,omitempty"` // Length of the ongoing interruption
+	Interruptions      int    `json:"interruptions"`
+}
+
+// liveEvent is a single message on /events
+type liveEvent struct {
+	Type      string    `json:"type"`
+	Timestamp string    `json:"timestamp"`
+	State     liveState `json:"state"`
+}
+
+// currentState reads the active session, which like in the UI may be left over from the
+// previous day
+func (s *Server) currentState(now time.Time) (liveState, error) {
+	state := liveState{State: stateIdle}
+
+	today := s.storage.DayOf(now)
+	dailySessions, err := s.storage.LoadDailySessions(today)
+	if err != nil {
+		return state, err
+	}
+	active := activeSession(dailySessions)
+	if active == nil {
+		if previousSessions, err := s.storage.LoadDailySessions(today.AddDate(0, 0, -1)); err == nil {
+			active = activeSession(previousSessions)
+		}
+	}
+	if active == nil {
+		return state, nil
+	}
+
+	workDuration, _, interruptionCount := active.GetStats()
+	state.State = stateWorking
+	state.SessionID = active.ID
+	state.Project = active.Project
+	state.Interruptions = interruptionCount
+	if active.Start != nil {
+		state.Description = active.Start.Description
+	}
+
+	// An odd number of entries means the last interruption has no return yet
+	if len(active.Interruptions)%2 != 0 {
+		ongoing := active.Interruptions[len(active.Interruptions)-1]
+		interrupted := now.Sub(ongoing.StartTime)
+		state.State = stateInterrupted
+		state.Tag = string(ongoing.Tag)
+		state.InterruptedSeconds = int(interrupted.Seconds())
+		workDuration -= interrupted // Ongoing interruptions are not subtracted by GetStats
+	}
+	if workDuration > 0 {
+		state.ElapsedSeconds = int(workDuration.Seconds())
+	}
+
+	return state, nil
+}
+
+// activeSession returns the first session without an end entry
+func activeSession(dailySessions *models.DailySessions) *models.Session {
+	for _, session := range dailySessions.Sessions {
+		if session.End == nil {
+			return session
+		}
+	}
+	return nil
+}
+
+// stateEvents returns the events describing the change from previous to next, which is
+// a snapshot when there is no previous state, followed by a tick while a session runs
+func stateEvents(previous *liveState, next liveState, now time.Time) []liveEvent {
+	var types []string
+	switch {
+	case previous == nil:
+		types = append(types, eventState)
+	case previous.SessionID != next.SessionID:
+		if previous.SessionID != "" {
+			types = append(types, eventEnd)
+		}
+		if next.SessionID != "" {
+			types = append(types, eventStart)
+			if next.State == stateInterrupted {
+				types = append(types, eventInterrupt)
+			}
+		}
+	case previous.State == stateWorking && next.State == stateInterrupted:
+		types = append(types, eventInterrupt)
+	case previous.State == stateInterrupted && next.State == stateWorking:
+		types = append(types, eventReturn)
+	case previous.Interruptions < next.Interruptions:
+		// Interrupted and back again between two checks
+		types = append(types, eventInterrupt, eventReturn)
+	}
+	if previous != nil && next.State != stateIdle {
+		types = append(types, eventTick)
+	}
+
+	timestamp := now.Format(time.RFC3339)
+	events := make([]liveEvent, 0, len(types))
+	for _, eventType := range types {
+		events = append(events, liveEvent{Type: eventType, Timestamp: timestamp, State: next})
+	}
+	return events
+}
+
+// handleEvents upgrades to a WebSocket and pushes session state changes and elapsed time
+// ticks until the client disconnects
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ticker := time.NewTicker(s.eventInterval)
+	defer ticker.Stop()
+
+	var previous *liveState
+	for {
+		now := time.Now()
+		if state, err := s.currentState(now); err == nil {
+			for _, event := range stateEvents(previous, state, now) {
+				if err := conn.WriteJSON(event); err != nil {
+					return
+				}
+			}
+			previous = &state
+		}
+
+		select {
+		case <-conn.closed:
+			return
+		case <-ticker.C:
+		}
+	}
+}
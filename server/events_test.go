@@ -0,0 +1,167 @@
+package server
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStateEvents tests which events describe a change of the live state
+func TestStateEvents(t *testing.T) {
+	now := time.Now()
+	idle := liveState{State: stateIdle}
+	working := liveState{State: stateWorking, SessionID: "s1"}
+	interrupted := liveState{State: stateInterrupted, SessionID: "s1", Interruptions: 1}
+	back := liveState{State: stateWorking, SessionID: "s1", Interruptions: 1}
+	next := liveState{State: stateWorking, SessionID: "s2"}
+
+	types := func(previous *liveState, state liveState) []string {
+		var result []string
+		for _, event := range stateEvents(previous, state, now) {
+			result = append(result, event.Type)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{eventState}, types(nil, working))
+	assert.Nil(t, types(&idle, idle))
+	assert.Equal(t, []string{eventStart, eventTick}, types(&idle, working))
+	assert.Equal(t, []string{eventTick}, types(&working, working))
+	assert.Equal(t, []string{eventInterrupt, eventTick}, types(&working, interrupted))
+	assert.Equal(t, []string{eventReturn, eventTick}, types(&interrupted, back))
+	assert.Equal(t, []string{eventInterrupt, eventReturn, eventTick}, types(&working, back))
+	assert.Equal(t, []string{eventEnd, eventStart, eventTick}, types(&back, next))
+	assert.Equal(t, []string{eventEnd}, types(&next, idle))
+}
+
+// TestEventsEndpoint tests the WebSocket handshake, pushed events and control frames
+func TestEventsEndpoint(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	srv := NewServer(store)
+	srv.eventInterval = 10 * time.Millisecond
+	httpServer := httptest.NewServer(srv.Handler())
+	defer httpServer.Close()
+
+	// Plain requests are refused
+	response, err := http.Get(httpServer.URL + "/events")
+	assert.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	// So are upgrades from pages of other sites
+	request, _ := http.NewRequest(http.MethodGet, httpServer.URL+"/events", nil)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	request.Header.Set("Origin", "https://evil.example.com")
+	response, err = http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(httpServer.URL, "http://"))
+	assert.NoError(t, err)
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, err = conn.Write([]byte("GET /events HTTP/1.1\r\nHost: localhost\r\nOrigin: http://localhost\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n"))
+	assert.NoError(t, err)
+	reader := bufio.NewReader(conn)
+	handshake, err := http.ReadResponse(reader, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, handshake.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", handshake.Header.Get("Sec-WebSocket-Accept"))
+
+	// readFrame reads an unmasked server frame, stopping the test when the connection fails
+	readFrame := func() (byte, []byte) {
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(reader, header); !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		length := int(header[1] & 0x7F)
+		if length == 126 {
+			extended := make([]byte, 2)
+			io.ReadFull(reader, extended)
+			length = int(binary.BigEndian.Uint16(extended))
+		}
+		payload := make([]byte, length)
+		_, err = io.ReadFull(reader, payload)
+		assert.NoError(t, err)
+		return header[0] & 0x0F, payload
+	}
+	// writeFrame writes a masked client frame
+	writeFrame := func(opcode byte, payload []byte) {
+		mask := []byte{1, 2, 3, 4}
+		frame := append([]byte{0x80 | opcode, 0x80 | byte(len(payload))}, mask...)
+		for i, b := range payload {
+			frame = append(frame, b^mask[i%4])
+		}
+		_, err := conn.Write(frame)
+		assert.NoError(t, err)
+	}
+	// waitFor reads events until one of the given type arrives
+	waitFor := func(eventType string) liveEvent {
+		for {
+			opcode, payload := readFrame()
+			if opcode != opText {
+				continue
+			}
+			var event liveEvent
+			assert.NoError(t, json.Unmarshal(payload, &event))
+			if event.Type == eventType {
+				return event
+			}
+		}
+	}
+
+	assert.Equal(t, stateIdle, waitFor(eventState).State.State)
+
+	now := time.Now()
+	session := &models.Session{
+		ID:    "s1",
+		Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Deep work"},
+	}
+	session.SubSessions = []*models.SubSession{{Start: session.Start}}
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: store.DayOf(now), Sessions: []*models.Session{session}}))
+	started := waitFor(eventStart)
+	assert.Equal(t, "Deep work", started.State.Description)
+	assert.GreaterOrEqual(t, waitFor(eventTick).State.ElapsedSeconds, 3600)
+
+	session.Interruptions = []*models.TimeEntry{{Type: models.EntryTypeInterruption, StartTime: now, Tag: models.TagCall}}
+	session.SubSessions[0].Interruptions = session.Interruptions
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: store.DayOf(now), Sessions: []*models.Session{session}}))
+	interrupted := waitFor(eventInterrupt)
+	assert.Equal(t, stateInterrupted, interrupted.State.State)
+	assert.Equal(t, "call", interrupted.State.Tag)
+
+	writeFrame(opPing, []byte("hi"))
+	for {
+		opcode, payload := readFrame()
+		if opcode == opPong {
+			assert.Equal(t, "hi", string(payload))
+			break
+		}
+	}
+
+	writeFrame(opClose, []byte{0x03, 0xE8})
+	for {
+		opcode, _ := readFrame()
+		if opcode == opClose {
+			break
+		}
+	}
+}
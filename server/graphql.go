@@ -0,0 +1,431 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// This file implements the subset of GraphQL needed for read-only queries:
+// a single query operation with fields, aliases, arguments and variables.
+// Fragments, directives and mutations are not supported.
+
+// gqlField is a single field selection in a query
+type gqlField struct {
+	Alias     string
+	Name      string
+	Arguments map[string]interface{}
+	Selection []*gqlField
+}
+
+// responseKey returns the key the field is reported under in the result
+func (f *gqlField) responseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// gqlVariable is a reference to a query variable, resolved at execution time
+type gqlVariable string
+
+// gqlParser is a recursive descent parser over a GraphQL query document
+type gqlParser struct {
+	input []rune
+	pos   int
+}
+
+// parseQuery parses a query document and returns the top-level selection set
+func parseQuery(query string) ([]*gqlField, error) {
+	p := &gqlParser{input: []rune(query)}
+	p.skipIgnored()
+
+	// Optional "query Name($var: Type)" header
+	if p.peekName() == "query" {
+		p.readName()
+		p.skipIgnored()
+		if p.peekRune() != '{' && p.peekRune() != '(' {
+			p.readName() // Operation name
+			p.skipIgnored()
+		}
+		if p.peekRune() == '(' {
+			if err := p.skipVariableDefinitions(); err != nil {
+				return nil, err
+			}
+		}
+	} else if name := p.peekName(); name == "mutation" || name == "subscription" {
+		return nil, fmt.Errorf("%s operations are not supported", name)
+	}
+
+	selection, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipIgnored()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q after query", p.input[p.pos])
+	}
+
+	return selection, nil
+}
+
+// parseSelectionSet parses "{ field field ... }"
+func (p *gqlParser) parseSelectionSet() ([]*gqlField, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+
+	var fields []*gqlField
+	for {
+		p.skipIgnored()
+		if p.peekRune() == '}' {
+			p.pos++
+			break
+		}
+		if p.pos >= len(p.input) {
+			return nil, p.errorf("unterminated selection set")
+		}
+		if strings.HasPrefix(string(p.input[p.pos:]), "...") {
+			return nil, p.errorf("fragments are not supported")
+		}
+
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, p.errorf("empty selection set")
+	}
+	return fields, nil
+}
+
+// parseField parses "alias: name(args) { selection }"
+func (p *gqlParser) parseField() (*gqlField, error) {
+	name := p.readName()
+	if name == "" {
+		return nil, p.errorf("expected field name")
+	}
+
+	field := &gqlField{Name: name}
+
+	p.skipIgnored()
+	if p.peekRune() == ':' {
+		p.pos++
+		p.skipIgnored()
+		field.Alias = name
+		field.Name = p.readName()
+		if field.Name == "" {
+			return nil, p.errorf("expected field name after alias %q", name)
+		}
+		p.skipIgnored()
+	}
+
+	if p.peekRune() == '(' {
+		args, err := p.parseArguments()
+		if err != nil {
+			return nil, err
+		}
+		field.Arguments = args
+		p.skipIgnored()
+	}
+
+	if p.peekRune() == '@' {
+		return nil, p.errorf("directives are not supported")
+	}
+
+	if p.peekRune() == '{' {
+		selection, err := p.parseSelectionSet()
+		if err != nil {
+			return nil, err
+		}
+		field.Selection = selection
+	}
+
+	return field, nil
+}
+
+// parseArguments parses "(name: value, ...)"
+func (p *gqlParser) parseArguments() (map[string]interface{}, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]interface{})
+	for {
+		p.skipIgnored()
+		if p.peekRune() == ')' {
+			p.pos++
+			return args, nil
+		}
+
+		name := p.readName()
+		if name == "" {
+			return nil, p.errorf("expected argument name")
+		}
+		p.skipIgnored()
+		if err := p.expect(':'); err != nil {
+			return nil, err
+		}
+		p.skipIgnored()
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+	}
+}
+
+// parseValue parses a literal or variable reference
+func (p *gqlParser) parseValue() (interface{}, error) {
+	r := p.peekRune()
+	switch {
+	case r == '$':
+		p.pos++
+		name := p.readName()
+		if name == "" {
+			return nil, p.errorf("expected variable name")
+		}
+		return gqlVariable(name), nil
+	case r == '"':
+		return p.parseString()
+	case r == '-' || unicode.IsDigit(r):
+		return p.parseNumber()
+	default:
+		name := p.readName()
+		switch name {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		case "":
+			return nil, p.errorf("expected value")
+		default:
+			return name, nil // Enum values are treated as strings
+		}
+	}
+}
+
+// parseString parses a double-quoted string with basic escapes
+func (p *gqlParser) parseString() (string, error) {
+	p.pos++ // Opening quote
+
+	var sb strings.Builder
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		p.pos++
+
+		switch r {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if p.pos >= len(p.input) {
+				return "", p.errorf("unterminated string")
+			}
+			escaped := p.input[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			default:
+				sb.WriteRune(escaped)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+// parseNumber parses an integer or float literal
+func (p *gqlParser) parseNumber() (interface{}, error) {
+	start := p.pos
+	if p.peekRune() == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.input) && (unicode.IsDigit(p.input[p.pos]) || strings.ContainsRune(".eE+-", p.input[p.pos])) {
+		p.pos++
+	}
+
+	literal := string(p.input[start:p.pos])
+	if i, err := strconv.Atoi(literal); err == nil {
+		return i, nil
+	}
+	f, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return nil, p.errorf("invalid number %q", literal)
+	}
+	return f, nil
+}
+
+// skipVariableDefinitions skips "($name: Type = default, ...)"; types are not checked
+func (p *gqlParser) skipVariableDefinitions() error {
+	depth := 0
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				p.pos++
+				p.skipIgnored()
+				return nil
+			}
+		}
+		p.pos++
+	}
+	return p.errorf("unterminated variable definitions")
+}
+
+// skipIgnored skips whitespace, commas and comments
+func (p *gqlParser) skipIgnored() {
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		if r == '#' {
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if !unicode.IsSpace(r) && r != ',' {
+			return
+		}
+		p.pos++
+	}
+}
+
+// peekRune returns the current rune without consuming it
+func (p *gqlParser) peekRune() rune {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// peekName returns the name at the current position without consuming it
+func (p *gqlParser) peekName() string {
+	start := p.pos
+	name := p.readName()
+	p.pos = start
+	return name
+}
+
+// readName consumes a GraphQL name ([_A-Za-z][_0-9A-Za-z]*)
+func (p *gqlParser) readName() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		if r == '_' || (r < unicode.MaxASCII && unicode.IsLetter(r)) || (p.pos > start && unicode.IsDigit(r)) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return string(p.input[start:p.pos])
+}
+
+// expect consumes the given rune or returns an error
+func (p *gqlParser) expect(r rune) error {
+	if p.peekRune() != r {
+		return p.errorf("expected %q", r)
+	}
+	p.pos++
+	return nil
+}
+
+// errorf creates a parse error annotated with the current position
+func (p *gqlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("syntax error at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// gqlObject is a resolved object; values are scalars, gqlObject or []gqlObject
+type gqlObject map[string]interface{}
+
+// gqlResolver resolves a root query field from its arguments
+type gqlResolver func(args map[string]interface{}) (interface{}, error)
+
+// executeQuery parses and executes a query against the root resolvers
+func executeQuery(query string, variables map[string]interface{}, root map[string]gqlResolver) (map[string]interface{}, error) {
+	selection, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	for _, field := range selection {
+		resolver, ok := root[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q on Query", field.Name)
+		}
+
+		args := make(map[string]interface{})
+		for name, value := range field.Arguments {
+			if variable, isVariable := value.(gqlVariable); isVariable {
+				value = variables[string(variable)]
+			}
+			args[name] = value
+		}
+
+		value, err := resolver(args)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+
+		projected, err := projectValue(field, value)
+		if err != nil {
+			return nil, err
+		}
+		data[field.responseKey()] = projected
+	}
+
+	return data, nil
+}
+
+// projectValue keeps only the selected fields of a resolved value
+func projectValue(field *gqlField, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case gqlObject:
+		if len(field.Selection) == 0 {
+			return nil, fmt.Errorf("field %q must have a selection of subfields", field.Name)
+		}
+		result := make(map[string]interface{}, len(field.Selection))
+		for _, sub := range field.Selection {
+			subValue, ok := v[sub.Name]
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q on %s", sub.Name, field.Name)
+			}
+			projected, err := projectValue(sub, subValue)
+			if err != nil {
+				return nil, err
+			}
+			result[sub.responseKey()] = projected
+		}
+		return result, nil
+	case []gqlObject:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			projected, err := projectValue(field, item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, projected)
+		}
+		return result, nil
+	default:
+		if len(field.Selection) > 0 {
+			return nil, fmt.Errorf("field %q is a scalar and cannot have a selection", field.Name)
+		}
+		return v, nil
+	}
+}
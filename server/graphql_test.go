@@ -0,0 +1,126 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseQuery tests parsing aliases, arguments and nested selections
+func TestParseQuery(t *testing.T) {
+	fields, err := parseQuery(`query Recent($to: String) {
+		recent: sessions(from: "2025-03-01", to: $to, limit: 5, open: true) { id interruptions { tag } }
+	}`)
+	assert.NoError(t, err)
+	assert.Len(t, fields, 1)
+
+	field := fields[0]
+	assert.Equal(t, "recent", field.Alias)
+	assert.Equal(t, "sessions", field.Name)
+	assert.Equal(t, "2025-03-01", field.Arguments["from"])
+	assert.Equal(t, gqlVariable("to"), field.Arguments["to"])
+	assert.Equal(t, 5, field.Arguments["limit"])
+	assert.Equal(t, true, field.Arguments["open"])
+	assert.Len(t, field.Selection, 2)
+	assert.Equal(t, "tag", field.Selection[1].Selection[0].Name)
+
+	for _, query := range []string{"{ sessions { id }", "mutation { x }", "{ ...frag }", "{ }"} {
+		_, err := parseQuery(query)
+		assert.Error(t, err, query)
+	}
+}
+
+// TestGraphQLEndpoint tests querying sessions and stats over HTTP
+func TestGraphQLEndpoint(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "interruption-tracker-server-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	store, err := storage.NewStorage(tempDir)
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	session := &models.Session{
+		ID:    "s1",
+		Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour), Description: "Deep work"},
+		End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: day.Add(11 * time.Hour)},
+		Interruptions: []*models.TimeEntry{
+			{Type: models.EntryTypeInterruption, StartTime: day.Add(10 * time.Hour), Tag: models.TagCall, Description: "Phone"},
+			{Type: models.EntryTypeReturn, StartTime: day.Add(10*time.Hour + 30*time.Minute)},
+		},
+	}
+	err = store.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{session}})
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(NewServer(store).Handler())
+	defer server.Close()
+
+	body, err := json.Marshal(graphQLRequest{
+		Query: `query ($from: String) {
+			sessions(from: $from, to: "2025-03-03") { id description workSeconds interruptions { tag durationSeconds } }
+			stats(from: $from, to: "2025-03-03") { totalSessions interruptionsByTag { tag count } }
+		}`,
+		Variables: map[string]interface{}{"from": "2025-03-01"},
+	})
+	assert.NoError(t, err)
+
+	resp, err := http.Post(server.URL+"/graphql", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result graphQLResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Empty(t, result.Errors)
+
+	sessions := result.Data["sessions"].([]interface{})
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, map[string]interface{}{
+		"id":          "s1",
+		"description": "Deep work",
+		"workSeconds": float64(5400),
+		"interruptions": []interface{}{
+			map[string]interface{}{"tag": "call", "durationSeconds": float64(1800)},
+		},
+	}, sessions[0])
+
+	stats := result.Data["stats"].(map[string]interface{})
+	assert.Equal(t, float64(1), stats["totalSessions"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"tag": "call", "count": float64(1)}}, stats["interruptionsByTag"])
+
+	// Unknown fields are reported as errors via GET as well
+	resp, err = http.Get(server.URL + "/graphql?query=" + url.QueryEscape("{ stats { bogus } }"))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	result = graphQLResponse{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "bogus")
+
+	// Ranges running backwards or over too many years are refused
+	for query, problem := range map[string]string{
+		`{ sessions(from: "2025-03-04", to: "2025-03-03") { id } }`:         "after to date",
+		`{ stats(from: "0001-01-01", to: "2025-03-03") { totalSessions } }`: "longer than 5 years",
+	} {
+		resp, err = http.Get(server.URL + "/graphql?query=" + url.QueryEscape(query))
+		if !assert.NoError(t, err) {
+			return
+		}
+		result = graphQLResponse{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		resp.Body.Close()
+		if assert.Len(t, result.Errors, 1) {
+			assert.Contains(t, result.Errors[0].Message, problem)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// Health statuses reported by /healthz
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded" // Still persisting, but something needs attention
+	healthFailing  = "failing"  // Not persisting data
+)
+
+// QueueDepthFunc returns how many items are waiting in a queue
+type QueueDepthFunc func() (int, error)
+
+// healthResponse is the /healthz response body
+type healthResponse struct {
+	Status      string            `json:"status"`
+	Storage     *storage.Health   `json:"storage"`
+	LastSaveAge *float64          `json:"last_save_age_seconds,omitempty"`
+	BackupAge   *float64          `json:"backup_age_seconds,omitempty"`
+	Queues      map[string]int    `json:"queues"`
+	QueueErrors map[string]string `json:"queue_errors,omitempty"`
+	Uptime      float64           `json:"uptime_seconds"`
+}
+
+// AddQueue reports the depth of a named queue in /healthz
+func (s *Server) AddQueue(name string, depth QueueDepthFunc) {
+	s.queues[name] = depth
+}
+
+// handleHealth reports whether the tracker is still persisting data. Responds with 503
+// when the data directory is not writable or the latest save failed, so monitoring can
+// alert on the status code alone.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	now := time.Now()
+	health := s.storage.Health()
+	response := healthResponse{
+		Status:  healthOK,
+		Storage: health,
+		Queues:  make(map[string]int, len(s.queues)),
+		Uptime:  now.Sub(s.started).Seconds(),
+	}
+
+	if health.LastSave != nil {
+		age := now.Sub(*health.LastSave).Seconds()
+		response.LastSaveAge = &age
+	}
+	if health.LastBackup != nil {
+		age := now.Sub(*health.LastBackup).Seconds()
+		response.BackupAge = &age
+	}
+
+	names := make([]string, 0, len(s.queues))
+	for name := range s.queues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		depth, err := s.queues[name]()
+		if err != nil {
+			if response.QueueErrors == nil {
+				response.QueueErrors = make(map[string]string)
+			}
+			response.QueueErrors[name] = err.Error()
+			continue
+		}
+		response.Queues[name] = depth
+	}
+
+	status := http.StatusOK
+	switch {
+	case !health.Healthy():
+		response.Status = healthFailing
+		status = http.StatusServiceUnavailable
+	case health.BackupStale(now) || response.QueueErrors != nil:
+		response.Status = healthDegraded
+	}
+
+	writeJSON(w, status, response)
+}
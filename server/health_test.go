@@ -0,0 +1,64 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHealthEndpoint tests the /healthz report and its status codes
+func TestHealthEndpoint(t *testing.T) {
+	tempDir := t.TempDir()
+	store, err := storage.NewStorage(tempDir)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: now,
+		Sessions: []*models.Session{{
+			ID:    "s1",
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour)},
+		}},
+	}))
+
+	srv := NewServer(store)
+	srv.AddQueue("toggl", func() (int, error) { return 3, nil })
+
+	check := func() (int, map[string]interface{}) {
+		recorder := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		return recorder.Code, body
+	}
+
+	code, body := check()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body["status"])
+	assert.Equal(t, true, body["storage"].(map[string]interface{})["writable"])
+	assert.Less(t, body["last_save_age_seconds"].(float64), 60.0)
+	assert.Equal(t, 3.0, body["queues"].(map[string]interface{})["toggl"])
+
+	// A queue that can't be read degrades the status without failing it
+	srv.AddQueue("broken", func() (int, error) { return 0, errors.New("unavailable") })
+	code, body = check()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "degraded", body["status"])
+	assert.Equal(t, "unavailable", body["queue_errors"].(map[string]interface{})["broken"])
+
+	// Losing the data directory stops persistence
+	assert.NoError(t, os.RemoveAll(tempDir))
+	assert.Error(t, store.SaveDailySessions(&models.DailySessions{Date: now}))
+	code, body = check()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "failing", body["status"])
+	assert.NotEmpty(t, body["storage"].(map[string]interface{})["last_save_error"])
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// The GraphQL schema exposed on /graphql:
+//
+//	type Query {
+//	  sessions(range: String, from: String, to: String): [Session]
+//	  stats(range: String, from: String, to: String): Stats
+//	  tags: [TagUsage]
+//	}
+//
+//	type Session {
+//	  id, date, description, start, end: String
+//	  active: Boolean
+//	  workSeconds, interruptionSeconds: Float
+//	  interruptionCount: Int
+//	  interruptions: [Interruption]
+//	}
+//
+//	type Interruption { tag, description, start, end: String; durationSeconds: Float }
+//
+//	type Stats {
+//	  startDate, endDate: String
+//	  workSeconds, longestSessionSeconds, averageSessionSeconds, productivityScore: Float
+//	  totalSessions, totalInterruptions: Int
+//	  interruptionsByTag: [TagStats]
+//	  hourlyProductivity: [HourStats]
+//	  dailyWork: [DayStats]
+//	}
+//
+//	type TagStats { tag: String; count: Int; durationSeconds: Float }
+//	type HourStats { hour: Int; workSeconds: Float }
+//	type DayStats { date: String; workSeconds: Float }
+//	type TagUsage { tag: String; count: Int; lastUsed: String }
+//
+// Dates are YYYY-MM-DD, timestamps are RFC 3339. Ranges default to "day".
+
+// dateLayout is the format of from/to arguments and date fields
+const dateLayout = "2006-01-02"
+
+// maxRangeYears limits from/to ranges, each day of which is read from disk
+const maxRangeYears = 5
+
+// rootResolvers returns the resolvers for the top-level Query fields
+func (s *Server) rootResolvers() map[string]gqlResolver {
+	return map[string]gqlResolver{
+		"sessions": s.resolveSessions,
+		"stats":    s.resolveStats,
+		"tags":     s.resolveTags,
+	}
+}
+
+// resolveSessions returns the sessions in the requested date range
+func (s *Server) resolveSessions(args map[string]interface{}) (interface{}, error) {
+	startDate, endDate, err := s.dateRange(args)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []gqlObject{}
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dailySessions, err := s.storage.LoadDailySessions(d)
+		if err != nil {
+			continue // Skip days with errors
+		}
+
+		for _, session := range dailySessions.Sessions {
+			sessions = append(sessions, sessionObject(d, session))
+		}
+	}
+
+	return sessions, nil
+}
+
+// resolveStats returns detailed statistics for the requested date range
+func (s *Server) resolveStats(args map[string]interface{}) (interface{}, error) {
+	startDate, endDate, err := s.dateRange(args)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := s.storage.GetDetailedStatsBetween(startDate, endDate)
+
+	byTag := []gqlObject{}
+	for tag, count := range stats.InterruptionsByTag {
+		byTag = append(byTag, gqlObject{
+			"tag":             string(tag),
+			"count":           count,
+			"durationSeconds": stats.InterruptionDurationByTag[tag].Seconds(),
+		})
+	}
+	sort.Slice(byTag, func(i, j int) bool {
+		return byTag[i]["tag"].(string) < byTag[j]["tag"].(string)
+	})
+
+	hourly := []gqlObject{}
+	for hour := 0; hour < 24; hour++ {
+		if duration, ok := stats.HourlyProductivity[hour]; ok {
+			hourly = append(hourly, gqlObject{"hour": hour, "workSeconds": duration.Seconds()})
+		}
+	}
+
+	daily := []gqlObject{}
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		date := d.Format(dateLayout)
+		if duration, ok := stats.DailyWorkDurations[date]; ok {
+			daily = append(daily, gqlObject{"date": date, "workSeconds": duration.Seconds()})
+		}
+	}
+
+	return gqlObject{
+		"startDate":             startDate.Format(dateLayout),
+		"endDate":               endDate.Format(dateLayout),
+		"workSeconds":           stats.TotalWorkDuration.Seconds(),
+		"totalSessions":         stats.TotalSessions,
+		"longestSessionSeconds": stats.LongestSession.Seconds(),
+		"averageSessionSeconds": stats.AverageSessionTime.Seconds(),
+		"totalInterruptions":    stats.TotalInterruptions,
+		"productivityScore":     stats.CalculateProductivityScore(),
+		"interruptionsByTag":    byTag,
+		"hourlyProductivity":    hourly,
+		"dailyWork":             daily,
+	}, nil
+}
+
+// resolveTags returns usage counts for every known interruption tag
+func (s *Server) resolveTags(args map[string]interface{}) (interface{}, error) {
+	usage, err := s.storage.GetTagUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]gqlObject, 0, len(usage))
+	for _, u := range usage {
+		tags = append(tags, gqlObject{
+			"tag":      string(u.Tag),
+			"count":    u.Count,
+			"lastUsed": formatTimestamp(u.LastUsed),
+		})
+	}
+
+	return tags, nil
+}
+
+// dateRange resolves from/to arguments, falling back to a named range. Ranges ending before
+// they start or longer than maxRangeYears are refused.
+func (s *Server) dateRange(args map[string]interface{}) (time.Time, time.Time, error) {
+	from, hasFrom := args["from"].(string)
+	to, hasTo := args["to"].(string)
+
+	if !hasFrom && !hasTo {
+		rangeType, ok := args["range"].(string)
+		if !ok || rangeType == "" {
+			rangeType = "day"
+		}
+		return s.storage.GetDateRange(rangeType)
+	}
+
+	endDate := s.storage.DayOf(time.Now())
+	if hasTo {
+		parsed, err := time.ParseInLocation(dateLayout, to, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date %q: %w", to, err)
+		}
+		endDate = parsed
+	}
+
+	startDate := endDate
+	if hasFrom {
+		parsed, err := time.ParseInLocation(dateLayout, from, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date %q: %w", from, err)
+		}
+		startDate = parsed
+	}
+
+	if startDate.After(endDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from date %s is after to date %s", startDate.Format(dateLayout), endDate.Format(dateLayout))
+	}
+	if startDate.Before(endDate.AddDate(-maxRangeYears, 0, 0)) {
+		return time.Time{}, time.Time{}, fmt.Errorf("date range from %s to %s is longer than %d years", startDate.Format(dateLayout), endDate.Format(dateLayout), maxRangeYears)
+	}
+
+	return startDate, endDate, nil
+}
+
+// sessionObject converts a session into its GraphQL representation
+func sessionObject(date time.Time, session *models.Session) gqlObject {
+	workDuration, interruptionDuration, interruptionCount := session.GetStats()
+
+	obj := gqlObject{
+		"id":                  session.ID,
+		"date":                date.Format(dateLayout),
+		"description":         nil,
+		"start":               nil,
+		"end":                 nil,
+		"active":              session.End == nil,
+		"workSeconds":         workDuration.Seconds(),
+		"interruptionSeconds": interruptionDuration.Seconds(),
+		"interruptionCount":   interruptionCount,
+		"interruptions":       interruptionObjects(session.Interruptions),
+	}
+
+	if session.Start != nil {
+		obj["description"] = session.Start.Description
+		obj["start"] = formatTimestamp(session.Start.StartTime)
+	}
+	if session.End != nil {
+		obj["end"] = formatTimestamp(session.End.StartTime)
+	}
+
+	return obj
+}
+
+// interruptionObjects pairs interruption and return entries into interruptions
+func interruptionObjects(entries []*models.TimeEntry) []gqlObject {
+	interruptions := []gqlObject{}
+	for i := 0; i < len(entries); i += 2 {
+		interrupt := entries[i]
+
+		tag := interrupt.Tag
+		if tag == "" {
+			tag = models.TagOther
+		}
+
+		obj := gqlObject{
+			"tag":             string(tag),
+			"description":     interrupt.Description,
+			"start":           formatTimestamp(interrupt.StartTime),
+			"end":             nil,
+			"durationSeconds": nil,
+		}
+
+		// The last interruption has no return entry while it is ongoing
+		if i+1 < len(entries) {
+			returnTime := entries[i+1].StartTime
+			obj["end"] = formatTimestamp(returnTime)
+			obj["durationSeconds"] = returnTime.Sub(interrupt.StartTime).Seconds()
+		}
+
+		interruptions = append(interruptions, obj)
+	}
+
+	return interruptions
+}
+
+// formatTimestamp formats a time as RFC 3339, returning nil for the zero time
+func formatTimestamp(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
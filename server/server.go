@@ -0,0 +1,139 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// Server exposes tracker data over HTTP
+type Server struct {
+	storage *storage.Storage
+	mux     *http.ServeMux
+	queues  map[string]QueueDepthFunc // Reported by /healthz
+	started time.Time
+
+	eventInterval time.Duration // How often /events checks for changes
+}
+
+// graphQLRequest is the standard GraphQL-over-HTTP request body
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLError is a single entry in the response errors list
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+// graphQLResponse is the standard GraphQL-over-HTTP response body
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []graphQLError         `json:"errors,omitempty"`
+}
+
+// NewServer creates a server backed by the given storage
+func NewServer(store *storage.Storage) *Server {
+	s := &Server{
+		storage: store,
+		mux:     http.NewServeMux(),
+		queues:  make(map[string]QueueDepthFunc),
+		started: time.Now(),
+
+		eventInterval: defaultEventInterval,
+	}
+
+	s.mux.HandleFunc("/graphql", s.handleGraphQL)
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/events", s.handleEvents)
+	s.mux.Handle("/", dashboardHandler())
+
+	return s
+}
+
+// Handler returns the HTTP handler serving all endpoints
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAddr returns the address to listen on: the loopback interface for addresses
+// without a host such as ":8080", as the API has no authentication
+func ListenAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
+
+// ListenAndServe serves the API on the given address until it fails. Addresses without
+// a host listen on the loopback interface only.
+func (s *Server) ListenAndServe(addr string) error {
+	addr = ListenAddr(addr)
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("failed to serve on %s: %w", addr, err)
+	}
+	return nil
+}
+
+// handleGraphQL executes a query sent via GET ?query= or a POST JSON body
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeGraphQLError(w, http.StatusBadRequest, fmt.Errorf("invalid variables: %w", err))
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeGraphQLError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeGraphQLError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	if req.Query == "" {
+		writeGraphQLError(w, http.StatusBadRequest, fmt.Errorf("missing query"))
+		return
+	}
+
+	data, err := executeQuery(req.Query, req.Variables, s.rootResolvers())
+	if err != nil {
+		writeGraphQLError(w, http.StatusOK, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, graphQLResponse{Data: data})
+}
+
+// writeGraphQLError writes a GraphQL response carrying a single error
+func writeGraphQLError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, graphQLResponse{Errors: []graphQLError{{Message: err.Error()}}})
+}
+
+// writeJSON encodes a value as the JSON response body
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
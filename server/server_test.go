@@ -0,0 +1,16 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestListenAddr tests that addresses without a host listen on localhost only
+func TestListenAddr(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:8080", ListenAddr(":8080"))
+	assert.Equal(t, "0.0.0.0:8080", ListenAddr("0.0.0.0:8080"))
+	assert.Equal(t, "192.168.1.5:8080", ListenAddr("192.168.1.5:8080"))
+	assert.Equal(t, "[::1]:8080", ListenAddr("[::1]:8080"))
+	assert.Equal(t, "8080", ListenAddr("8080"))
+}
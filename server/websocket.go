@@ -0,0 +1,217 @@
+package server
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// This file implements the server side of the WebSocket protocol (RFC 6455) needed to
+// push events: the opening handshake, unfragmented text frames and the ping and close
+// control frames. Messages sent by clients are read and discarded.
+
+// webSocketGUID is appended to the client key to compute the handshake accept value
+const webSocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxClientFrame limits the payload of frames read from clients
+const maxClientFrame = 64 * 1024
+
+// WebSocket frame opcodes
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+)
+
+// wsConn is an open WebSocket connection
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex    // Serialises frame writes
+	closed chan struct{} // Closed once the client closed the connection or it broke
+	once   sync.Once
+}
+
+// headerContains reports whether a comma-separated header has the token, ignoring case
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameOrigin reports whether a request comes from a page served by this server, or from a
+// client that is not a browser and sends no Origin. Browsers let any page open WebSockets
+// to any host, so other sites could otherwise read the events.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// upgradeWebSocket completes the opening handshake and takes over the connection.
+// On failure an HTTP error has already been written.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return nil, fmt.Errorf("method %s not allowed", r.Method)
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") || key == "" {
+		http.Error(w, "expected a WebSocket upgrade", http.StatusBadRequest)
+		return nil, errors.New("not a WebSocket upgrade")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported WebSocket version", http.StatusUpgradeRequired)
+		return nil, errors.New("unsupported WebSocket version")
+	}
+	if !sameOrigin(r) {
+		http.Error(w, "cross-origin WebSocket upgrade refused", http.StatusForbidden)
+		return nil, errors.New("cross-origin WebSocket upgrade")
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("connection cannot be hijacked")
+	}
+	conn, buffered, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take over connection: %w", err)
+	}
+
+	sum := sha1.Sum([]byte(key + webSocketGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to complete handshake: %w", err)
+	}
+
+	ws := &wsConn{conn: conn, reader: buffered.Reader, closed: make(chan struct{})}
+	go ws.readLoop()
+	return ws, nil
+}
+
+// writeFrame writes a single unmasked frame
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	switch length := len(payload); {
+	case length < 126:
+		header = append(header, byte(length))
+	case length <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		c.shutdown()
+		return fmt.Errorf("failed to write frame: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON sends the value as a JSON text message
+func (c *wsConn) WriteJSON(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	return c.writeFrame(opText, data)
+}
+
+// Close sends a close frame and closes the connection
+func (c *wsConn) Close() error {
+	c.writeFrame(opClose, []byte{0x03, 0xE8}) // 1000: normal closure
+	c.shutdown()
+	return nil
+}
+
+// shutdown closes the connection once
+func (c *wsConn) shutdown() {
+	c.once.Do(func() {
+		close(c.closed)
+		c.conn.Close()
+	})
+}
+
+// readLoop answers pings and closes, discarding other messages, until the connection ends
+func (c *wsConn) readLoop() {
+	defer c.shutdown()
+
+	header := make([]byte, 2)
+	for {
+		if _, err := io.ReadFull(c.reader, header); err != nil {
+			return
+		}
+		opcode := header[0] & 0x0F
+		masked := header[1]&0x80 != 0
+		length := uint64(header[1] & 0x7F)
+
+		switch length {
+		case 126:
+			extended := make([]byte, 2)
+			if _, err := io.ReadFull(c.reader, extended); err != nil {
+				return
+			}
+			length = uint64(binary.BigEndian.Uint16(extended))
+		case 127:
+			extended := make([]byte, 8)
+			if _, err := io.ReadFull(c.reader, extended); err != nil {
+				return
+			}
+			length = binary.BigEndian.Uint64(extended)
+		}
+		// Clients must mask their frames
+		if !masked || length > maxClientFrame {
+			return
+		}
+
+		mask := make([]byte, 4)
+		if _, err := io.ReadFull(c.reader, mask); err != nil {
+			return
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.reader, payload); err != nil {
+			return
+		}
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+
+		switch opcode {
+		case opClose:
+			c.writeFrame(opClose, payload)
+			return
+		case opPing:
+			c.writeFrame(opPong, payload)
+		}
+	}
+}
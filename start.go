@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// runStart starts a session from the command line. Without --project, the project is
+// assigned by the configured workspace rules.
+func runStart(store storage.Store, args []string) {
+	startFlags := flag.NewFlagSet("start", flag.ExitOnError)
+	project := startFlags.String("project", "", "Project of the session, detected from the workspace when empty")
+	estimateText := startFlags.String("estimate", "", "Expected work time, e.g. 45m or 1h30m")
+	startFlags.Parse(args)
+
+	estimate, err := models.ParseEstimate(*estimateText)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting session: %v\n", err)
+		os.Exit(2)
+	}
+
+	if *project == "" {
+		if dir, err := os.Getwd(); err == nil {
+			*project = integrations.ProjectForDir(store.GetConfig().ProjectRules, dir)
+		}
+	}
+
+	session, err := startSession(store, time.Now(), strings.Join(startFlags.Args(), " "), *project, estimate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting session: %v\n", err)
+		os.Exit(1)
+	}
+
+	if session.Project != "" {
+		fmt.Printf("Started %q in project %s\n", session.Start.Description, session.Project)
+	} else {
+		fmt.Printf("Started %q\n", session.Start.Description)
+	}
+}
+
+// startSession adds a new active session to today's sessions, unless one is already active
+func startSession(store storage.Store, now time.Time, description, project string, estimate time.Duration) (*models.Session, error) {
+	status, err := buildStatus(store, now)
+	if err != nil {
+		return nil, err
+	}
+	if status.State != StateIdle {
+		return nil, fmt.Errorf("a session is already active: %s", status.Description)
+	}
+
+	today := store.DayOf(now)
+	dailySessions, err := store.LoadDailySessions(today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily sessions: %w", err)
+	}
+
+	entry := models.NewTimeEntry(models.EntryTypeStart, description)
+	entry.StartTime = now
+	session := models.NewSession(entry)
+	session.Estimate = estimate
+	session.Project = project
+	dailySessions.Sessions = append(dailySessions.Sessions, session)
+
+	dailySessions.Date = today
+	if err := saveSessionEvent(store, dailySessions, models.JournalStart, integrations.EventSessionStart, session, entry); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
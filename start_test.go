@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStartSession tests starting a session from the command line
+func TestStartSession(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	session, err := startSession(store, now, "Review PR", "acme", 45*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", session.Project)
+
+	status, err := buildStatus(store, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, StateWorking, status.State)
+	assert.Equal(t, "Review PR", status.Description)
+	assert.Equal(t, "acme", status.Project)
+
+	dailySessions, err := store.LoadDailySessions(store.DayOf(now))
+	assert.NoError(t, err)
+	assert.Len(t, dailySessions.Sessions, 1)
+	assert.Equal(t, 45*time.Minute, dailySessions.Sessions[0].Estimate)
+
+	// Only one session can be active
+	_, err = startSession(store, now.Add(time.Minute), "Something else", "", 0)
+	assert.Error(t, err)
+}
+
+// TestSessionEventsRunHooks tests actions from the command line run the hook scripts, as
+// the UI does
+func TestSessionEventsRunHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts need a POSIX shell")
+	}
+
+	dataDir := t.TempDir()
+	store, err := storage.NewStorage(dataDir)
+	assert.NoError(t, err)
+
+	hooksDir := filepath.Join(dataDir, integrations.HooksDirName)
+	assert.NoError(t, os.MkdirAll(hooksDir, 0755))
+	script := "#!/bin/sh\necho \"$TRACKER_EVENT $TRACKER_DESCRIPTION\" >> events.out\n"
+	for _, event := range []string{integrations.EventSessionStart, integrations.EventInterrupt, integrations.EventReturn, integrations.EventSessionEnd} {
+		assert.NoError(t, os.WriteFile(filepath.Join(hooksDir, integrations.HookName(event)), []byte(script), 0755))
+	}
+
+	now := time.Now()
+	_, err = startSession(store, now, "Review PR", "", 0)
+	assert.NoError(t, err)
+	_, err = interruptSession(store, now.Add(time.Minute), models.TagCall, "Phone", 0)
+	assert.NoError(t, err)
+	_, err = returnFromInterruption(store, now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	_, err = endSession(store, now.Add(3*time.Minute))
+	assert.NoError(t, err)
+
+	events, err := os.ReadFile(filepath.Join(hooksDir, "events.out"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"session_start Review PR",
+		"interrupt Review PR",
+		"return Review PR",
+		"session_end Review PR",
+	}, strings.Split(strings.TrimSpace(string(events)), "\n"))
+}
@@ -0,0 +1,351 @@
+// Package stats computes the headline statistics of a range once for every front end, so
+// the console report and the stats page show the same numbers.
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// Store is the storage the statistics are loaded from
+type Store interface {
+	GetDateRange(rangeType string) (time.Time, time.Time, error)
+	LoadDailySessions(date time.Time) (*models.DailySessions, error)
+	StatsPolicy() models.StatsPolicy
+}
+
+// Source is what the statistics count: the stored days of a range, with the active
+// session in its live state in place of its stored copy
+type Source struct {
+	Start, End time.Time
+	Days       []*models.DailySessions
+	Live       *models.Session // Active session counted in the range, nil when there is none
+}
+
+// Load loads the days of a range. The stored copy of the active session, found by ID, is
+// replaced with the live one, which is added to activeDay if it was not saved yet. Without
+// an active session the stored sessions are counted as they are.
+func Load(store Store, rangeType string, active *models.Session, activeDay time.Time) (*Source, error) {
+	startDate, endDate, err := store.GetDateRange(rangeType)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &Source{Start: startDate, End: endDate}
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		day, err := store.LoadDailySessions(d)
+		if err != nil {
+			continue // Skip days with errors
+		}
+
+		if active != nil && day.Date.Equal(activeDay) {
+			live := *day
+			live.Sessions = append([]*models.Session(nil), day.Sessions...)
+			if i := SessionIndex(live.Sessions, active); i >= 0 {
+				live.Sessions[i] = active
+			} else {
+				live.Sessions = append(live.Sessions, active)
+			}
+			day, source.Live = &live, active
+		} else if i := SessionIndex(day.Sessions, active); i >= 0 {
+			// A stored copy under another day, e.g. before a rollover was saved
+			day.Sessions = append(day.Sessions[:i:i], day.Sessions[i+1:]...)
+		}
+		source.Days = append(source.Days, day)
+	}
+	return source, nil
+}
+
+// SessionIndex returns the position of a session in a slice, matching by ID so a stored
+// copy of the session is found too, or -1 when it is missing
+func SessionIndex(sessions []*models.Session, target *models.Session) int {
+	if target == nil {
+		return -1
+	}
+	for i, session := range sessions {
+		if session == target || (target.ID != "" && session.ID == target.ID) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Sessions returns the sessions of every day of the source in order
+func (source *Source) Sessions() []*models.Session {
+	var sessions []*models.Session
+	for _, day := range source.Days {
+		sessions = append(sessions, day.Sessions...)
+	}
+	return sessions
+}
+
+// EfficiencyMode is the formula of the work efficiency
+type EfficiencyMode string
+
+// Efficiency modes
+const (
+	EfficiencyFocus        EfficiencyMode = "focus"         // Work time / session time, from start to end of every session
+	EfficiencyWorkingHours EfficiencyMode = "working_hours" // Work time within working hours / working hours of the range, up to now
+	EfficiencyScore        EfficiencyMode = "score"         // Work time / (work time + interruption time + recovery), as the productivity score counts
+)
+
+// ParseEfficiencyMode parses an efficiency mode, EfficiencyFocus when empty
+func ParseEfficiencyMode(value string) (EfficiencyMode, error) {
+	switch mode := EfficiencyMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return EfficiencyFocus, nil
+	case EfficiencyFocus, EfficiencyWorkingHours, EfficiencyScore:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown efficiency %q, use focus, working_hours or score", value)
+}
+
+// Config is how summaries are computed besides the stats policy
+type Config struct {
+	Efficiency   EfficiencyMode
+	WorkingHours models.WorkingHours // Hours EfficiencyWorkingHours counts work against
+	Scheduled    bool                // WorkingHours are a work schedule, reported in the summary
+}
+
+// NewConfig returns the summary settings of a configuration: the efficiency mode of the
+// stats policy and the working hours of Schedule
+func NewConfig(cfg *config.Config) (Config, error) {
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	mode, err := ParseEfficiencyMode(cfg.StatsPolicy.Efficiency)
+	if err != nil {
+		return Config{}, err
+	}
+	hours, scheduled, err := Schedule(cfg)
+	if err != nil {
+		return Config{}, err
+	}
+	return Config{Efficiency: mode, WorkingHours: hours, Scheduled: scheduled}, nil
+}
+
+// Schedule returns the working hours of a configuration and whether they are a work
+// schedule. Without a schedule the working hours of the start reminders are used.
+func Schedule(cfg *config.Config) (models.WorkingHours, bool, error) {
+	schedule := cfg.WorkSchedule
+	if len(schedule.Days) == 0 && schedule.Start == "" && schedule.End == "" {
+		hours, err := models.ParseWorkingHours(cfg.ReminderWorkStart, cfg.ReminderWorkEnd, cfg.ReminderWeekends)
+		return hours, false, err
+	}
+
+	hours, err := models.ParseWorkSchedule(schedule.Days, schedule.Start, schedule.End)
+	if err != nil {
+		return models.WorkingHours{}, false, fmt.Errorf("invalid work schedule: %w", err)
+	}
+	return hours, true, nil
+}
+
+// workingTime returns the working hours of the days from start to end, up to now
+func workingTime(hours models.WorkingHours, start, end, now time.Time) time.Duration {
+	until := end.AddDate(0, 0, 1)
+	if now.Before(until) {
+		until = now
+	}
+	return hours.Within(start, until)
+}
+
+// Contribution is the time of a session as counted in a summary
+type Contribution struct {
+	Work          time.Duration
+	Interruption  time.Duration
+	Interruptions int
+}
+
+// Summary is the headline statistics of a range
+type Summary struct {
+	Start, End       time.Time
+	Work             time.Duration
+	Interruption     time.Duration // As the policy counts it, recovery included when it counts recovery
+	Interruptions    int
+	Recovery         time.Duration // Recovery the policy assumes after the interruptions
+	IncludesRecovery bool          // Recovery is part of Interruption
+	SessionTime      time.Duration // From start to end of every session, ongoing ones up to now
+	WorkingTime      time.Duration // Working hours of the range up to now
+	ScheduledWork    time.Duration // Work within the working hours
+	Untracked        time.Duration // Working hours up to now without a session
+	Scheduled        bool          // Working hours are a work schedule, reported in the rows
+	Live             *Contribution // What the active session adds, nil without one
+	EfficiencyMode   EfficiencyMode
+}
+
+// Summarize computes the summary of a source under a policy, counting ongoing sessions up
+// to now
+func Summarize(source *Source, policy models.StatsPolicy, cfg Config, now time.Time) Summary {
+	summary := Summary{
+		Start:            source.Start,
+		End:              source.End,
+		IncludesRecovery: policy.IncludeRecovery,
+		WorkingTime:      workingTime(cfg.WorkingHours, source.Start, source.End, now),
+		Scheduled:        cfg.Scheduled,
+		EfficiencyMode:   cfg.Efficiency,
+	}
+	var tracked time.Duration // Session time within working hours
+
+	for _, session := range source.Sessions() {
+		if session.Start == nil {
+			continue
+		}
+
+		breakdown := policy.Breakdown(session, now)
+		summary.Work += breakdown.Work
+		summary.Interruption += breakdown.Interruption
+		summary.Interruptions += len(breakdown.Interruptions)
+		for _, interruption := range breakdown.Interruptions {
+			summary.Recovery += interruption.Recovery
+		}
+
+		// Sessions crossing midnight count in full, regardless of day boundaries
+		end := now
+		if session.End != nil {
+			end = session.End.StartTime
+		}
+		summary.SessionTime += end.Sub(session.Start.StartTime)
+		summary.ScheduledWork += session.WorkWithin(cfg.WorkingHours, now)
+		tracked += cfg.WorkingHours.Within(session.Start.StartTime, end)
+
+		if session == source.Live {
+			summary.Live = &Contribution{
+				Work:          breakdown.Work,
+				Interruption:  breakdown.Interruption,
+				Interruptions: len(breakdown.Interruptions),
+			}
+		}
+	}
+	if tracked < summary.WorkingTime {
+		summary.Untracked = summary.WorkingTime - tracked
+	}
+	return summary
+}
+
+// Impact returns the time lost to interruptions: the interruption time and the recovery
+// after them, counted once
+func (s Summary) Impact() time.Duration {
+	if s.IncludesRecovery {
+		return s.Interruption
+	}
+	return s.Interruption + s.Recovery
+}
+
+// Efficiency returns the work time as a percentage, at most 100, of the time the
+// efficiency mode compares it with:
+//
+//   - focus: the session time, from start to end of every session, interruptions and
+//     breaks included; work plus interruption time for sessions without one
+//   - working_hours: the working hours of the range up to now, counting only the work
+//     within them, so untracked working hours lower it and work off hours does not count
+//   - score: work time plus the time lost to interruptions, their recovery included
+func (s Summary) Efficiency() float64 {
+	work, total := s.Work, time.Duration(0)
+	switch s.EfficiencyMode {
+	case EfficiencyWorkingHours:
+		work, total = s.ScheduledWork, s.WorkingTime
+	case EfficiencyScore:
+		total = s.Work + s.Impact()
+	default:
+		total = s.SessionTime
+		if total <= 0 {
+			total = s.Work + s.Interruption
+		}
+	}
+	return percentage(work, total)
+}
+
+// ScheduledShare returns the work within the working hours as a percentage of them, at
+// most 100
+func (s Summary) ScheduledShare() float64 {
+	return percentage(s.ScheduledWork, s.WorkingTime)
+}
+
+// percentage returns part as a percentage of total, at most 100 and 0 without a total
+func percentage(part, total time.Duration) float64 {
+	if total <= 0 {
+		return 0
+	}
+	if part >= total {
+		return 100
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// efficiencyDefinitions describe what the efficiency of each mode compares work time with
+var efficiencyDefinitions = map[EfficiencyMode]string{
+	EfficiencyFocus:        "work / session time",
+	EfficiencyWorkingHours: "work in working hours / working hours",
+	EfficiencyScore:        "work / (work + interruptions + recovery)",
+}
+
+// Row keys of a summary
+const (
+	RowWork             = "work"
+	RowInterruptions    = "interruptions"
+	RowInterruptionTime = "interruption_time"
+	RowRecovery         = "recovery"
+	RowImpact           = "impact"
+	RowEfficiency       = "efficiency"
+	RowScheduled        = "scheduled"
+	RowUntracked        = "untracked"
+	RowLive             = "live"
+)
+
+// Row is a labeled value of a summary, as every front end shows it
+type Row struct {
+	Key   string // One of the Row constants, for styling
+	Label string
+	Value string
+}
+
+// Rows returns the summary as labeled values in display order, with durations formatted
+// by formatDuration
+func (s Summary) Rows(formatDuration func(time.Duration) string) []Row {
+	rows := []Row{
+		{Key: RowWork, Label: "Total work time", Value: formatDuration(s.Work)},
+		{Key: RowInterruptions, Label: "Total interruptions", Value: fmt.Sprintf("%d", s.Interruptions)},
+	}
+	if s.IncludesRecovery {
+		rows = append(rows, Row{Key: RowInterruptionTime, Label: "Total interruption time (including recovery)", Value: formatDuration(s.Interruption)})
+	} else {
+		rows = append(rows,
+			Row{Key: RowInterruptionTime, Label: "Total interruption time", Value: formatDuration(s.Interruption)},
+			Row{Key: RowRecovery, Label: "Estimated recovery time", Value: formatDuration(s.Recovery)})
+	}
+	rows = append(rows,
+		Row{Key: RowImpact, Label: "Total productivity impact", Value: formatDuration(s.Impact())},
+		Row{Key: RowEfficiency, Label: "Work efficiency", Value: fmt.Sprintf("%.1f%% (%s)", s.Efficiency(), s.efficiencyDefinition())})
+
+	if s.Scheduled {
+		rows = append(rows,
+			Row{Key: RowScheduled, Label: "Work in scheduled hours",
+				Value: fmt.Sprintf("%s of %s (%.1f%%)", formatDuration(s.ScheduledWork), formatDuration(s.WorkingTime), s.ScheduledShare())},
+			Row{Key: RowUntracked, Label: "Untracked scheduled time", Value: formatDuration(s.Untracked)})
+	}
+
+	if s.Live != nil {
+		rows = append(rows, Row{Key: RowLive, Label: "Includes current session (live)",
+			Value: fmt.Sprintf("%s work, %s interrupted, %d interruption(s)",
+				formatDuration(s.Live.Work), formatDuration(s.Live.Interruption), s.Live.Interruptions)})
+	}
+	return rows
+}
+
+// efficiencyDefinition describes what the efficiency compares work time with
+func (s Summary) efficiencyDefinition() string {
+	if definition, ok := efficiencyDefinitions[s.EfficiencyMode]; ok {
+		return definition
+	}
+	return efficiencyDefinitions[EfficiencyFocus]
+}
+
+// String formats a row as "Label: value"
+func (r Row) String() string {
+	return r.Label + ": " + r.Value
+}
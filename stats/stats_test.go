@@ -0,0 +1,261 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// session returns a session started at start, ended after length unless it is 0, with an
+// interruption away for the given time in the middle
+func session(id string, start time.Time, length, away time.Duration) *models.Session {
+	s := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start})
+	s.ID = id
+	if away > 0 {
+		interruption := &models.TimeEntry{Type: models.EntryTypeInterruption, StartTime: start.Add(time.Minute), Tag: models.TagCall}
+		back := &models.TimeEntry{Type: models.EntryTypeReturn, StartTime: start.Add(time.Minute + away)}
+		s.Interruptions = []*models.TimeEntry{interruption, back}
+		s.SubSessions[0].Interruptions = []*models.TimeEntry{interruption, back}
+	}
+	if length > 0 {
+		s.EndAt(start.Add(length))
+	}
+	return s
+}
+
+// sessionIDs returns the IDs of the sessions in order
+func sessionIDs(sessions []*models.Session) []string {
+	ids := []string{}
+	for _, s := range sessions {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+// TestLoad tests the active session is counted once, live, whether or not its day's file
+// already includes it
+func TestLoad(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	today := store.DayOf(now)
+	start := now.Add(-time.Hour)
+	if start.Before(today) {
+		start = today
+	}
+
+	done := session("sess_done", start, time.Minute, 0)
+	stored := session("sess_live", start.Add(time.Minute), 0, 0)
+	day := &models.DailySessions{Date: today, Sessions: []*models.Session{done, stored}}
+	assert.NoError(t, store.SaveDailySessions(day))
+
+	// The live session replaces its stored copy
+	live := session("sess_live", start.Add(time.Minute), 0, 0)
+	source, err := Load(store, "day", live, today)
+	assert.NoError(t, err)
+	assert.Same(t, live, source.Live)
+	assert.Equal(t, []string{"sess_done", "sess_live"}, sessionIDs(source.Sessions()))
+	assert.Same(t, live, source.Sessions()[1])
+
+	// An active session not saved yet is added
+	day.Sessions = []*models.Session{done}
+	assert.NoError(t, store.SaveDailySessions(day))
+	source, err = Load(store, "day", live, today)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sess_done", "sess_live"}, sessionIDs(source.Sessions()))
+
+	// Without an active session the stored sessions are counted
+	source, err = Load(store, "day", nil, time.Time{})
+	assert.NoError(t, err)
+	assert.Nil(t, source.Live)
+	assert.Len(t, source.Sessions(), 1)
+
+	_, err = Load(store, "fortnight", nil, time.Time{})
+	assert.Error(t, err)
+}
+
+// TestSummarize tests the summary counts work, interruptions, recovery and efficiency the
+// way the policy does, and what the live session adds
+func TestSummarize(t *testing.T) {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	live := session("sess_live", day.Add(13*time.Hour), 0, 0)
+	source := &Source{
+		Start: day,
+		End:   day,
+		Days: []*models.DailySessions{{Date: day, Sessions: []*models.Session{
+			session("sess_1", day.Add(9*time.Hour), time.Hour, 20*time.Minute),
+			live,
+		}}},
+		Live: live,
+	}
+	now := day.Add(13*time.Hour + 30*time.Minute)
+	policy := models.StatsPolicy{Recovery: 10 * time.Minute}
+
+	summary := Summarize(source, policy, Config{}, now)
+	assert.Equal(t, 70*time.Minute, summary.Work)
+	assert.Equal(t, 20*time.Minute, summary.Interruption)
+	assert.Equal(t, 1, summary.Interruptions)
+	assert.Equal(t, 10*time.Minute, summary.Recovery)
+	assert.Equal(t, 30*time.Minute, summary.Impact())
+	assert.InDelta(t, 77.8, summary.Efficiency(), 0.05)
+	assert.Equal(t, &Contribution{Work: 30 * time.Minute}, summary.Live)
+
+	rows := summary.Rows(func(d time.Duration) string { return d.String() })
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = row.String()
+	}
+	assert.Equal(t, []string{
+		"Total work time: 1h10m0s",
+		"Total interruptions: 1",
+		"Total interruption time: 20m0s",
+		"Estimated recovery time: 10m0s",
+		"Total productivity impact: 30m0s",
+		"Work efficiency: 77.8% (work / session time)",
+		"Includes current session (live): 30m0s work, 0s interrupted, 0 interruption(s)",
+	}, lines)
+
+	// Recovery counted as interruption time is not counted twice
+	policy.IncludeRecovery = true
+	summary = Summarize(source, policy, Config{}, now)
+	assert.Equal(t, 30*time.Minute, summary.Interruption)
+	assert.Equal(t, 30*time.Minute, summary.Impact())
+	assert.Equal(t, "Total interruption time (including recovery)", summary.Rows(time.Duration.String)[2].Label)
+
+	assert.Zero(t, Summarize(&Source{}, policy, Config{}, now).Efficiency())
+}
+
+// TestParseEfficiencyMode tests parsing the efficiency modes and the focus default
+func TestParseEfficiencyMode(t *testing.T) {
+	for value, want := range map[string]EfficiencyMode{
+		"":               EfficiencyFocus,
+		"focus":          EfficiencyFocus,
+		" Working_Hours": EfficiencyWorkingHours,
+		"SCORE":          EfficiencyScore,
+	} {
+		mode, err := ParseEfficiencyMode(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, mode, value)
+	}
+
+	_, err := ParseEfficiencyMode("total")
+	assert.Error(t, err)
+}
+
+// TestNewConfig tests the summary settings come from the stats policy and the working
+// hours of the start reminders
+func TestNewConfig(t *testing.T) {
+	cfg, err := NewConfig(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, EfficiencyFocus, cfg.Efficiency)
+	assert.Equal(t, 9*time.Hour, cfg.WorkingHours.Start)
+	assert.Equal(t, 17*time.Hour, cfg.WorkingHours.End)
+
+	cfg, err = NewConfig(&config.Config{
+		StatsPolicy:       config.StatsPolicy{Efficiency: "working_hours"},
+		ReminderWorkStart: "08:00",
+		ReminderWorkEnd:   "12:00",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, EfficiencyWorkingHours, cfg.Efficiency)
+	assert.Equal(t, 4*time.Hour, cfg.WorkingHours.End-cfg.WorkingHours.Start)
+
+	_, err = NewConfig(&config.Config{StatsPolicy: config.StatsPolicy{Efficiency: "total"}})
+	assert.Error(t, err)
+	_, err = NewConfig(&config.Config{ReminderWorkStart: "late"})
+	assert.Error(t, err)
+}
+
+// TestEfficiencyModes tests each mode compares the work time with its own total
+func TestEfficiencyModes(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	source := &Source{
+		Start: monday,
+		End:   monday.AddDate(0, 0, 6),
+		Days: []*models.DailySessions{{Date: monday, Sessions: []*models.Session{
+			session("sess_1", monday.Add(9*time.Hour), 2*time.Hour, 30*time.Minute),
+		}}},
+	}
+	now := monday.Add(12 * time.Hour)
+	policy := models.StatsPolicy{Recovery: 10 * time.Minute}
+	hours, err := models.ParseWorkingHours("09:00", "17:00", false)
+	assert.NoError(t, err)
+
+	// 90 minutes of work in a 2 hour session
+	summary := Summarize(source, policy, Config{Efficiency: EfficiencyFocus, WorkingHours: hours}, now)
+	assert.InDelta(t, 75.0, summary.Efficiency(), 0.05)
+
+	// Only the 3 working hours up to now count, not the rest of the week
+	summary = Summarize(source, policy, Config{Efficiency: EfficiencyWorkingHours, WorkingHours: hours}, now)
+	assert.Equal(t, 3*time.Hour, summary.WorkingTime)
+	assert.Equal(t, 90*time.Minute, summary.ScheduledWork)
+	assert.InDelta(t, 50.0, summary.Efficiency(), 0.05)
+	assert.Equal(t, "50.0% (work in working hours / working hours)", summary.Rows(time.Duration.String)[5].Value)
+
+	// 90 minutes of work against 30 minutes away and 10 minutes of recovery
+	summary = Summarize(source, policy, Config{Efficiency: EfficiencyScore, WorkingHours: hours}, now)
+	assert.InDelta(t, 69.2, summary.Efficiency(), 0.05)
+	// Recovery counted as interruption time is no longer work time
+	policy.IncludeRecovery = true
+	summary = Summarize(source, policy, Config{Efficiency: EfficiencyScore, WorkingHours: hours}, now)
+	assert.InDelta(t, 66.7, summary.Efficiency(), 0.05)
+}
+
+// TestSchedule tests the work schedule is reported in the summary, counting only the work
+// and session time within it
+func TestSchedule(t *testing.T) {
+	cfg, err := NewConfig(&config.Config{WorkSchedule: config.WorkSchedule{Days: []string{"mon-thu"}, Start: "10:00"}})
+	assert.NoError(t, err)
+	assert.True(t, cfg.Scheduled)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday}, cfg.WorkingHours.Days)
+
+	_, scheduled, err := Schedule(&config.Config{ReminderWeekends: true})
+	assert.NoError(t, err)
+	assert.False(t, scheduled)
+	_, _, err = Schedule(&config.Config{WorkSchedule: config.WorkSchedule{Days: []string{"someday"}}})
+	assert.Error(t, err)
+
+	// A session from 9 to 11, its last hour scheduled, then an evening one off hours
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	source := &Source{
+		Start: monday,
+		End:   monday,
+		Days: []*models.DailySessions{{Date: monday, Sessions: []*models.Session{
+			session("sess_1", monday.Add(9*time.Hour), 2*time.Hour, 30*time.Minute),
+			session("sess_2", monday.Add(20*time.Hour), time.Hour, 0),
+		}}},
+	}
+	summary := Summarize(source, models.StatsPolicy{}, cfg, monday.Add(14*time.Hour))
+	assert.Equal(t, 4*time.Hour, summary.WorkingTime)
+	assert.Equal(t, time.Hour, summary.ScheduledWork)
+	assert.Equal(t, 3*time.Hour, summary.Untracked)
+	assert.InDelta(t, 25.0, summary.ScheduledShare(), 0.05)
+
+	rows := summary.Rows(time.Duration.String)
+	assert.Equal(t, "Work in scheduled hours: 1h0m0s of 4h0m0s (25.0%)", rows[6].String())
+	assert.Equal(t, "Untracked scheduled time: 3h0m0s", rows[7].String())
+
+	// Without a schedule the rows are left out
+	cfg.Scheduled = false
+	assert.Len(t, Summarize(source, models.StatsPolicy{}, cfg, monday.Add(14*time.Hour)).Rows(time.Duration.String), 6)
+}
+
+// TestWorkingTime tests the working hours skip weekends unless they are working days and
+// stop at now
+func TestWorkingTime(t *testing.T) {
+	friday := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+	hours, err := models.ParseWorkingHours("09:00", "17:00", false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 8*time.Hour, workingTime(hours, friday, friday.AddDate(0, 0, 2), friday.AddDate(0, 0, 7)))
+	assert.Equal(t, 2*time.Hour, workingTime(hours, friday, friday, friday.Add(11*time.Hour)))
+	assert.Zero(t, workingTime(hours, friday, friday, friday.Add(8*time.Hour)))
+
+	hours.Weekends = true
+	assert.Equal(t, 24*time.Hour, workingTime(hours, friday, friday.AddDate(0, 0, 2), friday.AddDate(0, 0, 7)))
+}
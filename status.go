@@ -0,0 +1,164 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/control"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// defaultStatusFormat is used when status is called without --format
+const defaultStatusFormat = "{{.State}} {{.Elapsed}}"
+
+// Status states reported by the status command
+const (
+	StateWorking     = control.StateWorking
+	StateInterrupted = control.StateInterrupted
+	StateIdle        = control.StateIdle
+)
+
+// StatusInfo is the data available to status --format templates, the status reported on
+// the control socket
+type StatusInfo = control.Status
+
+// runCommand dispatches a subcommand given as positional arguments
+func runCommand(store *storage.Storage, args []string) {
+	switch args[0] {
+	case "status":
+		runStatus(commandStore(store), args[1:])
+	case "start":
+		runStart(commandStore(store), args[1:])
+	case "attach":
+		runAttach(store, args[1:])
+	case "interruptions":
+		runInterruptions(store, args[1:])
+	case "team":
+		runTeam(store, args[1:])
+	case "mcp":
+		runMCP(commandStore(store), args[1:])
+	case "log":
+		runLog(store, args[1:])
+	case "meetings":
+		runMeetings(commandStore(store), args[1:])
+	case "hotkey":
+		runHotkey(store, args[1:])
+	case "hotkeys":
+		runHotkeys(store, args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", args[0])
+		os.Exit(2)
+	}
+}
+
+// runStatus prints a one-line, template-driven summary of the current session
+func runStatus(store storage.Store, args []string) {
+	statusFlags := flag.NewFlagSet("status", flag.ExitOnError)
+	format := statusFlags.String("format", defaultStatusFormat, "Go template for the status line (fields: State, Description, Project, Elapsed, Tag, Interrupted, Interruptions, Today)")
+	statusFlags.Parse(args)
+
+	status, err := buildStatus(store, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading status: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeStatus(os.Stdout, status, *format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error rendering status: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeStatus renders the status with the template as a single line
+func writeStatus(w io.Writer, status *StatusInfo, format string) error {
+	tmpl, err := template.New("status").Parse(format)
+	if err != nil {
+		return fmt.Errorf("failed to parse status format: %w", err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, status); err != nil {
+		return fmt.Errorf("failed to render status format: %w", err)
+	}
+
+	// Status bars expect exactly one line
+	_, err = fmt.Fprintln(w, strings.TrimSpace(sb.String()))
+	return err
+}
+
+// buildStatus collects the status of the active session and today's work.
+// Like the UI, an active session left over from the previous day counts as current.
+func buildStatus(store storage.Store, now time.Time) (*StatusInfo, error) {
+	status := &StatusInfo{State: StateIdle}
+
+	today := store.DayOf(now)
+	dailySessions, err := store.LoadDailySessions(today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily sessions: %w", err)
+	}
+
+	todayWork, _, _ := dailySessions.GetStats()
+	defer func() {
+		status.TodaySeconds = int(todayWork.Seconds())
+		status.Today = formatDuration(todayWork)
+	}()
+
+	active := findActiveSession(dailySessions)
+	activeToday := active != nil
+	if active == nil {
+		// Ignore errors as previous day may not exist
+		if previousSessions, err := store.LoadDailySessions(today.AddDate(0, 0, -1)); err == nil {
+			active = findActiveSession(previousSessions)
+		}
+	}
+	if active == nil {
+		return status, nil
+	}
+
+	workDuration, _, interruptionCount := active.GetStats()
+
+	status.State = StateWorking
+	status.Interruptions = interruptionCount
+	status.Project = active.Project
+	if active.Start != nil {
+		status.Description = active.Start.Description
+	}
+
+	// An odd number of entries means the last interruption has no return yet
+	if len(active.Interruptions)%2 != 0 {
+		ongoing := active.Interruptions[len(active.Interruptions)-1]
+		interrupted := now.Sub(ongoing.StartTime)
+
+		status.State = StateInterrupted
+		status.Tag = string(ongoing.Tag)
+		status.Interrupted = formatDuration(interrupted)
+		workDuration -= interrupted // Ongoing interruptions are not subtracted by GetStats
+		if activeToday {
+			todayWork -= interrupted
+		}
+	}
+
+	if workDuration < 0 {
+		workDuration = 0
+	}
+	status.ElapsedSeconds = int(workDuration.Seconds())
+	status.Elapsed = formatDuration(workDuration)
+
+	return status, nil
+}
+
+// findActiveSession returns the first session without an end entry
+func findActiveSession(dailySessions *models.DailySessions) *models.Session {
+	for _, session := range dailySessions.Sessions {
+		if session.End == nil {
+			return session
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuildStatus tests the idle, working and interrupted states
+func TestBuildStatus(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	// No sessions at all
+	status, err := buildStatus(store, now)
+	assert.NoError(t, err)
+	assert.Equal(t, StateIdle, status.State)
+	assert.Equal(t, "", status.Elapsed)
+
+	// An active session started an hour ago
+	start := models.NewTimeEntry(models.EntryTypeStart, "Write docs")
+	start.StartTime = now.Add(-time.Hour)
+	session := models.NewSession(start)
+
+	dailySessions, err := store.LoadDailySessions(store.DayOf(now))
+	assert.NoError(t, err)
+	dailySessions.Sessions = append(dailySessions.Sessions, session)
+	assert.NoError(t, store.SaveDailySessions(dailySessions))
+
+	status, err = buildStatus(store, now)
+	assert.NoError(t, err)
+	assert.Equal(t, StateWorking, status.State)
+	assert.Equal(t, "Write docs", status.Description)
+	assert.InDelta(t, 3600, status.ElapsedSeconds, 5)
+
+	// An interruption that started 15 minutes ago and is still ongoing
+	interruption := models.NewInterruptionEntry("Phone", models.TagCall)
+	interruption.StartTime = now.Add(-15 * time.Minute)
+	session.Interruptions = append(session.Interruptions, interruption)
+	session.SubSessions[0].Interruptions = append(session.SubSessions[0].Interruptions, interruption)
+	assert.NoError(t, store.SaveDailySessions(dailySessions))
+
+	status, err = buildStatus(store, now)
+	assert.NoError(t, err)
+	assert.Equal(t, StateInterrupted, status.State)
+	assert.Equal(t, "call", status.Tag)
+	assert.Equal(t, "15m 0s", status.Interrupted)
+	assert.InDelta(t, 2700, status.ElapsedSeconds, 5)
+	assert.InDelta(t, 2700, status.TodaySeconds, 5)
+}
+
+// TestStatusAfterSplitRollover tests a session split at the day boundary while interrupted
+// is still interrupted in the new day, and can be returned to
+func TestStatusAfterSplitRollover(t *testing.T) {
+	store, err := storage.NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Now()
+	boundary := models.DayStartTime(store.DayOf(now), store.GetConfig().DayStartHour)
+
+	start := models.NewTimeEntry(models.EntryTypeStart, "Release")
+	start.StartTime = boundary.Add(-time.Hour)
+	session := models.NewSession(start)
+	interruption := models.NewInterruptionEntry("Outage", models.TagCall)
+	interruption.StartTime = boundary.Add(-10 * time.Minute)
+	addInterruptionEntry(session, interruption)
+
+	continuation := session.SplitAt(boundary)
+	assert.Len(t, continuation.Interruptions, 1)
+	assert.Len(t, continuation.SubSessions[0].Interruptions, 1)
+
+	dailySessions, err := store.LoadDailySessions(store.DayOf(now))
+	assert.NoError(t, err)
+	dailySessions.Sessions = append(dailySessions.Sessions, continuation)
+	assert.NoError(t, store.SaveDailySessions(dailySessions))
+
+	status, err := buildStatus(store, now)
+	assert.NoError(t, err)
+	assert.Equal(t, StateInterrupted, status.State)
+	assert.Equal(t, "call", status.Tag)
+
+	_, err = endSession(store, now)
+	assert.Error(t, err)
+
+	returned, err := returnFromInterruption(store, now)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, returned.Interruptions, 2)
+	assert.Len(t, returned.SubSessions[0].Interruptions, 2)
+
+	status, err = buildStatus(store, now)
+	assert.NoError(t, err)
+	assert.Equal(t, StateWorking, status.State)
+}
+
+// TestWriteStatus tests rendering templates as a single line
+func TestWriteStatus(t *testing.T) {
+	status := &StatusInfo{State: StateInterrupted, Elapsed: "45m 0s", Tag: "call", Interrupted: "15m 0s"}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeStatus(&buf, status, defaultStatusFormat))
+	assert.Equal(t, "interrupted 45m 0s\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, writeStatus(&buf, status, "{{.State}}{{if .Tag}} ({{.Tag}} {{.Interrupted}}){{end}}\n\n"))
+	assert.Equal(t, "interrupted (call 15m 0s)\n", buf.String())
+
+	assert.Error(t, writeStatus(&buf, status, "{{.State"))
+	assert.Error(t, writeStatus(&buf, status, "{{.Missing}}"))
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// hashFile returns the size and hex SHA-256 digest of a regular file
+func hashFile(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, "", err
+	}
+	if !info.Mode().IsRegular() {
+		return 0, "", fmt.Errorf("%s is not a regular file", path)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// NewAttachment describes the file at path for attaching, recording its size and digest
+func NewAttachment(path, note string, now time.Time) (*models.Attachment, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	size, digest, err := hashFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	return &models.Attachment{
+		Path:    absPath,
+		Size:    size,
+		SHA256:  digest,
+		AddedAt: now,
+		Note:    note,
+	}, nil
+}
+
+// CheckAttachment reports whether an attached file still exists unchanged
+func CheckAttachment(attachment *models.Attachment) models.AttachmentStatus {
+	_, digest, err := hashFile(attachment.Path)
+	if err != nil {
+		return models.AttachmentMissing
+	}
+	if digest != attachment.SHA256 {
+		return models.AttachmentChanged
+	}
+	return models.AttachmentOK
+}
+
+// AttachFile attaches the file at path to the session with the given ID stored under day
+func (s *Storage) AttachFile(day time.Time, sessionID, path, note string, now time.Time) (*models.Attachment, error) {
+	dailySessions, err := s.LoadDailySessions(day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily sessions: %w", err)
+	}
+
+	var session *models.Session
+	for _, candidate := range dailySessions.Sessions {
+		if candidate.ID == sessionID {
+			session = candidate
+			break
+		}
+	}
+	if session == nil {
+		return nil, fmt.Errorf("session %s not found on %s", sessionID, day.Format("2006-01-02"))
+	}
+
+	attachment, err := NewAttachment(path, note, now)
+	if err != nil {
+		return nil, err
+	}
+	if !session.AddAttachment(attachment) {
+		return nil, fmt.Errorf("%s is already attached to this session", attachment.Path)
+	}
+
+	journalErr := s.AppendJournal(models.NewJournalEvent(models.JournalEdit, day, session, nil))
+	if err := s.SaveDailySessions(dailySessions); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	if journalErr != nil {
+		return nil, fmt.Errorf("saved, but failed to write journal: %w", journalErr)
+	}
+
+	return attachment, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAttachFile tests attaching evidence to a stored session and detecting later changes
+func TestAttachFile(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	start := day.Add(9 * time.Hour)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: day,
+		Sessions: []*models.Session{{
+			ID:    "sess_1",
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+		}},
+	}))
+
+	evidence := filepath.Join(t.TempDir(), "screenshot.png")
+	assert.NoError(t, os.WriteFile(evidence, []byte("proof"), 0644))
+
+	attachment, err := store.AttachFile(day, "sess_1", evidence, "Deployed dashboard", start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "screenshot.png", attachment.Name())
+	assert.Equal(t, int64(5), attachment.Size)
+	assert.Len(t, attachment.SHA256, 64)
+
+	saved, err := store.LoadDailySessions(day)
+	assert.NoError(t, err)
+	assert.Len(t, saved.Sessions[0].Attachments, 1)
+	assert.Equal(t, "Deployed dashboard", saved.Sessions[0].Attachments[0].Note)
+	assert.Equal(t, models.AttachmentOK, CheckAttachment(saved.Sessions[0].Attachments[0]))
+
+	// The same file can only be attached once
+	_, err = store.AttachFile(day, "sess_1", evidence, "", start)
+	assert.Error(t, err)
+	_, err = store.AttachFile(day, "sess_2", evidence, "", start)
+	assert.Error(t, err)
+	_, err = store.AttachFile(day, "sess_1", filepath.Join(t.TempDir(), "missing.pdf"), "", start)
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(evidence, []byte("edited"), 0644))
+	assert.Equal(t, models.AttachmentChanged, CheckAttachment(attachment))
+	assert.NoError(t, os.Remove(evidence))
+	assert.Equal(t, models.AttachmentMissing, CheckAttachment(attachment))
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupFile is a backup of a daily file in the backups directory
+type backupFile struct {
+	path    string
+	date    time.Time // Day of the daily file
+	written time.Time // When the backup was made
+}
+
+// listBackups returns the backups of daily files, oldest first
+func (s *Storage) listBackups() ([]backupFile, error) {
+	dir := filepath.Join(s.dataDir, "backups")
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	var backups []backupFile
+	for _, entry := range entries {
+		name := entry.Name()
+		date, ok := purgeFileDate(name)
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name[len("sessions_2006-01-02"):], "_backup_"), ".json")
+		written, err := time.ParseInLocation("2006-01-02_150405", stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(dir, name), date: date, written: written})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].written.Before(backups[j].written)
+	})
+	return backups, nil
+}
+
+// BackupChanged backs up every daily file changed since its latest backup, so a backup of
+// the current data exists even when days are not saved again. Returns the number of daily
+// files backed up.
+func (s *Storage) BackupChanged() (int, error) {
+	if !s.backupEnabled {
+		return 0, nil
+	}
+
+	backups, err := s.listBackups()
+	if err != nil {
+		return 0, err
+	}
+	latest := make(map[time.Time]time.Time, len(backups))
+	for _, backup := range backups {
+		latest[backup.date] = backup.written
+	}
+
+	days, err := s.ListAvailableDays()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, day := range days {
+		path := s.getFilePath(day)
+		info, err := os.Stat(path)
+		if err != nil {
+			return count, fmt.Errorf("failed to check %s: %w", path, err)
+		}
+		// Backup names have a resolution of seconds
+		if written, ok := latest[day]; ok && info.ModTime().Before(written.Add(time.Second)) {
+			continue
+		}
+		if err := s.createBackup(path, day); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// CompactBackups thins out the backups made on every save: of the backups of a daily file
+// made on the same day before today only the newest is kept. Removed backups are shredded
+// like purged files. Returns the number of backups removed.
+func (s *Storage) CompactBackups(now time.Time) (int, error) {
+	backups, err := s.listBackups()
+	if err != nil {
+		return 0, err
+	}
+
+	// Backups are oldest first, so the newest of each group is found last
+	type group struct {
+		date    time.Time
+		written string
+	}
+	newest := make(map[group]string)
+	today := now.Format("2006-01-02")
+	for _, backup := range backups {
+		if written := backup.written.Format("2006-01-02"); written != today {
+			newest[group{backup.date, written}] = backup.path
+		}
+	}
+
+	removed := 0
+	for _, backup := range backups {
+		written := backup.written.Format("2006-01-02")
+		keep, ok := newest[group{backup.date, written}]
+		if !ok || keep == backup.path {
+			continue
+		}
+		if err := shredFile(backup.path); err != nil {
+			return removed, fmt.Errorf("failed to remove backup %s: %w", backup.path, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBackupChanged tests only daily files changed since their latest backup are backed up
+func TestBackupChanged(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStorageWithConfig(&config.Config{BackupEnabled: true}, dir)
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: day}))
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: day.AddDate(0, 0, 1)}))
+
+	count, err := store.BackupChanged()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Backups made after the last change are up to date
+	past := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(store.getFilePath(day), past, past))
+	assert.NoError(t, os.Chtimes(store.getFilePath(day.AddDate(0, 0, 1)), past, past))
+	count, err = store.BackupChanged()
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	disabled, err := NewStorageWithConfig(&config.Config{}, t.TempDir())
+	assert.NoError(t, err)
+	count, err = disabled.BackupChanged()
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+// TestCompactBackups tests only the newest backup of a daily file per day is kept, except
+// for backups made today
+func TestCompactBackups(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStorageWithConfig(&config.Config{BackupEnabled: true}, dir)
+	assert.NoError(t, err)
+
+	backups := filepath.Join(dir, "backups")
+	for _, name := range []string{
+		"sessions_2025-03-03_backup_2025-03-03_090000.json",
+		"sessions_2025-03-03_backup_2025-03-03_120000.json",
+		"sessions_2025-03-03_backup_2025-03-03_170000.json",
+		"sessions_2025-03-03_backup_2025-03-04_080000.json",
+		"sessions_2025-03-04_backup_2025-03-04_090000.json",
+		"sessions_2025-03-04_backup_2025-03-05_090000.json",
+		"sessions_2025-03-04_backup_2025-03-05_100000.json",
+		"notes.txt",
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(backups, name), []byte("{}"), 0600))
+	}
+
+	removed, err := store.CompactBackups(time.Date(2025, 3, 5, 12, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	entries, err := os.ReadDir(backups)
+	assert.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{
+		"notes.txt",
+		"sessions_2025-03-03_backup_2025-03-03_170000.json",
+		"sessions_2025-03-03_backup_2025-03-04_080000.json",
+		"sessions_2025-03-04_backup_2025-03-04_090000.json",
+		"sessions_2025-03-04_backup_2025-03-05_090000.json",
+		"sessions_2025-03-04_backup_2025-03-05_100000.json",
+	}, names)
+}
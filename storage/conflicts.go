@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// conflictedCopyPattern matches copies of daily files left by file sync tools, e.g.
+// "sessions_2025-03-10 (laptop's conflicted copy 2025-03-11).json" from Dropbox or
+// "sessions_2025-03-10.sync-conflict-20250311-101010-ABCDEFG.json" from Syncthing
+var conflictedCopyPattern = regexp.MustCompile(`^sessions_(\d{4}-\d{2}-\d{2})(?: \([^)]*(?i:conflicted copy)[^)]*\)|\.sync-conflict-[^.]+)\.json$`)
+
+// ConflictedCopy is a conflicting version of a daily file left next to it by a sync tool
+type ConflictedCopy struct {
+	Day  time.Time
+	Path string
+}
+
+// DuplicateSession is a session stored under more than one day, e.g. carried over to a
+// new day on one machine while still in the old day on another
+type DuplicateSession struct {
+	ID     string
+	Days   []time.Time // Days the session is stored under, oldest first
+	Latest time.Time   // Day holding the version with the most recent activity
+}
+
+// SyncConflicts are the signs of diverged copies found in a synced data directory
+type SyncConflicts struct {
+	Copies     []ConflictedCopy
+	Duplicates []DuplicateSession
+}
+
+// Empty reports whether no conflicts were found
+func (c *SyncConflicts) Empty() bool {
+	return len(c.Copies) == 0 && len(c.Duplicates) == 0
+}
+
+// FindSyncConflicts looks for conflicted copies of daily files and sessions stored under
+// more than one day, which a data directory synced by Dropbox or Syncthing can end up with
+func (s *Storage) FindSyncConflicts() (*SyncConflicts, error) {
+	files, err := os.ReadDir(s.dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data directory: %w", err)
+	}
+
+	conflicts := &SyncConflicts{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		match := conflictedCopyPattern.FindStringSubmatch(file.Name())
+		if match == nil {
+			continue
+		}
+		day, err := time.ParseInLocation("2006-01-02", match[1], time.Local)
+		if err != nil {
+			continue
+		}
+		conflicts.Copies = append(conflicts.Copies, ConflictedCopy{Day: day, Path: filepath.Join(s.dataDir, file.Name())})
+	}
+
+	days, err := s.ListAvailableDays()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	found := make(map[string]*DuplicateSession)
+	var order []string
+	latestActivity := make(map[string]time.Time)
+	for _, day := range days {
+		dailySessions, err := s.LoadDailySessions(day)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sessions for %s: %w", day.Format("2006-01-02"), err)
+		}
+		for _, session := range dailySessions.Sessions {
+			duplicate, ok := found[session.ID]
+			if !ok {
+				duplicate = &DuplicateSession{ID: session.ID}
+				found[session.ID] = duplicate
+				order = append(order, session.ID)
+			}
+			if n := len(duplicate.Days); n > 0 && duplicate.Days[n-1].Equal(day) {
+				continue // Repeated within one file, not a sync conflict
+			}
+			duplicate.Days = append(duplicate.Days, day)
+			if activity := session.LastActivity(); len(duplicate.Days) == 1 || activity.After(latestActivity[session.ID]) {
+				latestActivity[session.ID] = activity
+				duplicate.Latest = day
+			}
+		}
+	}
+
+	for _, id := range order {
+		if duplicate := found[id]; len(duplicate.Days) > 1 {
+			conflicts.Duplicates = append(conflicts.Duplicates, *duplicate)
+		}
+	}
+
+	return conflicts, nil
+}
+
+// LoadConflictedCopy reads the sessions of a conflicted copy
+func (s *Storage) LoadConflictedCopy(c ConflictedCopy) (*models.DailySessions, error) {
+	data, err := os.ReadFile(c.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conflicted copy: %w", err)
+	}
+	return s.decodeDailySessions(data)
+}
+
+// MergeConflictedCopy merges a conflicted copy into the daily file, keeping the version
+// with the most recent activity of sessions found in both, and removes the copy
+func (s *Storage) MergeConflictedCopy(c ConflictedCopy) error {
+	theirs, err := s.LoadConflictedCopy(c)
+	if err != nil {
+		return err
+	}
+	ours, err := s.LoadDailySessions(c.Day)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+
+	if err := s.SaveDailySessions(models.MergeDailySessions(ours, theirs)); err != nil {
+		return err
+	}
+	return s.DiscardConflictedCopy(c)
+}
+
+// UseConflictedCopy replaces the daily file with a conflicted copy
+func (s *Storage) UseConflictedCopy(c ConflictedCopy) error {
+	theirs, err := s.LoadConflictedCopy(c)
+	if err != nil {
+		return err
+	}
+
+	theirs.Date = c.Day
+	if err := s.SaveDailySessions(theirs); err != nil {
+		return err
+	}
+	return s.DiscardConflictedCopy(c)
+}
+
+// DiscardConflictedCopy removes a conflicted copy, keeping the daily file as it is
+func (s *Storage) DiscardConflictedCopy(c ConflictedCopy) error {
+	if err := os.Remove(c.Path); err != nil {
+		return fmt.Errorf("failed to remove conflicted copy: %w", err)
+	}
+	return nil
+}
+
+// KeepDuplicateIn keeps a duplicated session only under the given day, removing it from
+// every other day it is stored under
+func (s *Storage) KeepDuplicateIn(d DuplicateSession, keep time.Time) error {
+	for _, day := range d.Days {
+		if day.Equal(keep) {
+			continue
+		}
+
+		dailySessions, err := s.LoadDailySessions(day)
+		if err != nil {
+			return fmt.Errorf("failed to load sessions: %w", err)
+		}
+		dailySessions.Sessions = removeSession(dailySessions.Sessions, d.ID)
+		if err := s.SaveDailySessions(dailySessions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
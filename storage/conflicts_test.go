@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// conflictTestSession returns an ended session starting at start
+func conflictTestSession(id string, start time.Time, length time.Duration) *models.Session {
+	return &models.Session{
+		ID:    id,
+		Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+		End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(length)},
+	}
+}
+
+// TestConflictedCopyPattern tests which file names are taken for conflicted copies
+func TestConflictedCopyPattern(t *testing.T) {
+	for name, conflicted := range map[string]bool{
+		"sessions_2025-03-10 (laptop's conflicted copy 2025-03-11).json":     true,
+		"sessions_2025-03-10 (Conflicted copy 2025-03-11 101010).json":       true,
+		"sessions_2025-03-10.sync-conflict-20250311-101010-ABCDEFG.json":     true,
+		"sessions_2025-03-10.json":                                           false,
+		"sessions_2025-03-10 (1).json":                                       false,
+		"backups/sessions_2025-03-10_backup_2025-03-10_100000.json":          false,
+		"sessions_2025-03-10.sync-conflict-20250311-101010-ABCDEFG.json.tmp": false,
+		"records (laptop's conflicted copy 2025-03-11).json":                 false,
+	} {
+		assert.Equal(t, conflicted, conflictedCopyPattern.MatchString(name), name)
+	}
+}
+
+// TestSyncConflicts tests finding and resolving conflicted copies and duplicated sessions
+func TestSyncConflicts(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date:     monday,
+		Sessions: []*models.Session{conflictTestSession("sess_1", monday.Add(9*time.Hour), time.Hour)},
+	}))
+
+	// The other machine added a session and carried sess_2 over to Tuesday
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: tuesday,
+		Sessions: []*models.Session{
+			conflictTestSession("sess_2", monday.Add(23*time.Hour), 2*time.Hour),
+		},
+	}))
+	data, err := store.encodeDailySessions(&models.DailySessions{
+		Date: monday,
+		Sessions: []*models.Session{
+			conflictTestSession("sess_1", monday.Add(9*time.Hour), time.Hour),
+			conflictTestSession("sess_3", monday.Add(13*time.Hour), time.Hour),
+		},
+	})
+	assert.NoError(t, err)
+	copyPath := filepath.Join(store.GetDataDir(), "sessions_2025-03-10 (laptop's conflicted copy 2025-03-11).json")
+	assert.NoError(t, os.WriteFile(copyPath, data, 0644))
+
+	// An older version of sess_2 was left in Monday's file
+	mondaySessions, err := store.LoadDailySessions(monday)
+	assert.NoError(t, err)
+	mondaySessions.Sessions = append(mondaySessions.Sessions, conflictTestSession("sess_2", monday.Add(23*time.Hour), 30*time.Minute))
+	assert.NoError(t, store.SaveDailySessions(mondaySessions))
+
+	conflicts, err := store.FindSyncConflicts()
+	assert.NoError(t, err)
+	assert.False(t, conflicts.Empty())
+	assert.Len(t, conflicts.Copies, 1)
+	assert.Equal(t, copyPath, conflicts.Copies[0].Path)
+	assert.True(t, monday.Equal(conflicts.Copies[0].Day))
+	assert.Len(t, conflicts.Duplicates, 1)
+	assert.Equal(t, "sess_2", conflicts.Duplicates[0].ID)
+	assert.Len(t, conflicts.Duplicates[0].Days, 2)
+	assert.True(t, tuesday.Equal(conflicts.Duplicates[0].Latest))
+
+	assert.NoError(t, store.MergeConflictedCopy(conflicts.Copies[0]))
+	assert.NoError(t, store.KeepDuplicateIn(conflicts.Duplicates[0], conflicts.Duplicates[0].Latest))
+
+	merged, err := store.LoadDailySessions(monday)
+	assert.NoError(t, err)
+	var ids []string
+	for _, session := range merged.Sessions {
+		ids = append(ids, session.ID)
+	}
+	assert.Equal(t, []string{"sess_1", "sess_3"}, ids)
+
+	_, err = os.Stat(copyPath)
+	assert.True(t, os.IsNotExist(err))
+
+	conflicts, err = store.FindSyncConflicts()
+	assert.NoError(t, err)
+	assert.True(t, conflicts.Empty())
+}
+
+// TestUseConflictedCopy tests replacing the daily file with the conflicted copy
+func TestUseConflictedCopy(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date:     day,
+		Sessions: []*models.Session{conflictTestSession("sess_1", day.Add(9*time.Hour), time.Hour)},
+	}))
+	data, err := store.encodeDailySessions(&models.DailySessions{
+		Date:     day,
+		Sessions: []*models.Session{conflictTestSession("sess_2", day.Add(10*time.Hour), time.Hour)},
+	})
+	assert.NoError(t, err)
+	copyPath := filepath.Join(store.GetDataDir(), "sessions_2025-03-10.sync-conflict-20250311-101010-ABCDEFG.json")
+	assert.NoError(t, os.WriteFile(copyPath, data, 0644))
+
+	assert.NoError(t, store.UseConflictedCopy(ConflictedCopy{Day: day, Path: copyPath}))
+
+	saved, err := store.LoadDailySessions(day)
+	assert.NoError(t, err)
+	assert.Len(t, saved.Sessions, 1)
+	assert.Equal(t, "sess_2", saved.Sessions[0].ID)
+	_, err = os.Stat(copyPath)
+	assert.True(t, os.IsNotExist(err))
+}
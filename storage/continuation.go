@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// GetContinuedTasks returns the tasks continued across days that have a part in the range.
+// All days are read, so parts before or after the range still count towards the task.
+func (s *Storage) GetContinuedTasks(rangeType string) ([]models.ContinuedTask, error) {
+	startDate, endDate, err := s.GetDateRange(rangeType)
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := s.ListAvailableDays()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available days: %w", err)
+	}
+	sort.Slice(available, func(i, j int) bool { return available[i].Before(available[j]) })
+
+	days := make([]*models.DailySessions, 0, len(available))
+	for _, day := range available {
+		dailySessions, err := s.LoadDailySessions(day)
+		if err != nil {
+			continue // Skip days with errors
+		}
+		dailySessions.Date = day
+		days = append(days, dailySessions)
+	}
+
+	var tasks []models.ContinuedTask
+	for _, task := range models.LinkContinuations(days) {
+		if task.Spans(startDate, endDate) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
@@ -0,0 +1,19 @@
+package storage
+
+import "path/filepath"
+
+// Unix sockets the daemon listens on in the data directory
+const (
+	daemonSocketFile  = "daemon.sock"  // Serves the storage to the TUI and commands
+	controlSocketFile = "control.sock" // Accepts commands from editors and scripts
+)
+
+// DaemonSocketPath returns the path of the unix socket the daemon serves the storage on
+func (s *Storage) DaemonSocketPath() string {
+	return filepath.Join(s.dataDir, daemonSocketFile)
+}
+
+// ControlSocketPath returns the path of the unix socket the daemon accepts commands on
+func (s *Storage) ControlSocketPath() string {
+	return filepath.Join(s.dataDir, controlSocketFile)
+}
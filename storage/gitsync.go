@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// Files written into the data directory to control how git treats it
+const (
+	gitIgnoreContent     = "backups/\nhooks/\n" + launchStateFile + "\n" + recordsFileName + "\n" + tagModelFileName + "\n" + importProgressFile + "\n" + purgeLogFileName + "\n" + keyRotationFile + "\n" + healthProbeFile + "\n" + schedulerStateFile + "\n" + daemonSocketFile + "\n" + controlSocketFile + "\n" // Device-local state
+	gitAttributesContent = journalFileName + " merge=union\n"
+)
+
+// GitSync keeps the data directory in sync with a remote git repository
+type GitSync struct {
+	storage *Storage
+	remote  string
+	branch  string
+}
+
+// NewGitSync creates a git sync for the storage's data directory using its configuration
+func NewGitSync(store *Storage) (*GitSync, error) {
+	if store.config.GitSyncRemote == "" {
+		return nil, fmt.Errorf("git_sync_remote is not configured")
+	}
+
+	branch := store.config.GitSyncBranch
+	if branch == "" {
+		branch = "main"
+	}
+
+	return &GitSync{
+		storage: store,
+		remote:  store.config.GitSyncRemote,
+		branch:  branch,
+	}, nil
+}
+
+// Init turns the data directory into a git repository pointing at the remote.
+// It is safe to call on an already initialized directory.
+func (g *GitSync) Init() error {
+	if _, err := os.Stat(filepath.Join(g.storage.dataDir, ".git")); os.IsNotExist(err) {
+		if _, err := g.git("init"); err != nil {
+			return err
+		}
+		if _, err := g.git("symbolic-ref", "HEAD", "refs/heads/"+g.branch); err != nil {
+			return err
+		}
+	}
+
+	// Backups and hooks stay local; the journal is merged line by line
+	if err := appendMissingLines(filepath.Join(g.storage.dataDir, ".gitignore"), gitIgnoreContent); err != nil {
+		return err
+	}
+	if err := writeFileIfMissing(filepath.Join(g.storage.dataDir, ".gitattributes"), gitAttributesContent); err != nil {
+		return err
+	}
+
+	// Commits need an identity; fall back to a local one if none is configured
+	if _, err := g.git("config", "user.email"); err != nil {
+		hostname, _ := os.Hostname()
+		if _, err := g.git("config", "user.name", "interruption-tracker"); err != nil {
+			return err
+		}
+		if _, err := g.git("config", "user.email", "interruption-tracker@"+hostname); err != nil {
+			return err
+		}
+	}
+
+	if current, err := g.git("remote", "get-url", "origin"); err != nil {
+		if _, err := g.git("remote", "add", "origin", g.remote); err != nil {
+			return err
+		}
+	} else if strings.TrimSpace(string(current)) != g.remote {
+		if _, err := g.git("remote", "set-url", "origin", g.remote); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Pull commits local changes and merges the remote branch.
+// Conflicting daily files are resolved by a union of their sessions by ID.
+func (g *GitSync) Pull() error {
+	if err := g.Init(); err != nil {
+		return err
+	}
+	if err := g.commitLocal(); err != nil {
+		return err
+	}
+
+	if _, err := g.git("fetch", "origin"); err != nil {
+		return err
+	}
+
+	// Nothing to merge until another device has pushed
+	remoteRef := "refs/remotes/origin/" + g.branch
+	if _, err := g.git("rev-parse", "--verify", "--quiet", remoteRef); err != nil {
+		return nil
+	}
+
+	if _, err := g.git("merge", "--no-edit", "--allow-unrelated-histories", remoteRef); err != nil {
+		if resolveErr := g.resolveConflicts(); resolveErr != nil {
+			g.git("merge", "--abort")
+			return fmt.Errorf("failed to merge remote changes: %w", resolveErr)
+		}
+	}
+
+	return nil
+}
+
+// Push pulls remote changes first and then pushes the merged history
+func (g *GitSync) Push() error {
+	if err := g.Pull(); err != nil {
+		return err
+	}
+
+	_, err := g.git("push", "origin", "HEAD:refs/heads/"+g.branch)
+	return err
+}
+
+// commitLocal commits all changes in the data directory, if there are any
+func (g *GitSync) commitLocal() error {
+	if _, err := g.git("add", "-A"); err != nil {
+		return err
+	}
+
+	// diff --quiet exits non-zero when there are staged changes
+	if _, err := g.git("diff", "--cached", "--quiet"); err == nil {
+		return nil
+	}
+
+	hostname, _ := os.Hostname()
+	message := fmt.Sprintf("Sync from %s at %s", hostname, time.Now().Format(time.RFC3339))
+	_, err := g.git("commit", "-m", message)
+	return err
+}
+
+// resolveConflicts merges conflicting daily files and concludes the merge
+func (g *GitSync) resolveConflicts() error {
+	output, err := g.git("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return err
+	}
+
+	conflicts := strings.Fields(string(output))
+	if len(conflicts) == 0 {
+		return fmt.Errorf("merge failed without conflicts")
+	}
+
+	for _, file := range conflicts {
+		var year, month, day int
+		if _, err := fmt.Sscanf(file, "sessions_%d-%d-%d.json", &year, &month, &day); err != nil {
+			return fmt.Errorf("cannot resolve conflict in %s", file)
+		}
+
+		merged, err := g.mergeDailyFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to merge %s: %w", file, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(g.storage.dataDir, file), merged, 0644); err != nil {
+			return fmt.Errorf("failed to write merged %s: %w", file, err)
+		}
+		if _, err := g.git("add", file); err != nil {
+			return err
+		}
+	}
+
+	_, err = g.git("commit", "--no-edit")
+	return err
+}
+
+// mergeDailyFile returns the union of our and their version of a conflicting daily file
+func (g *GitSync) mergeDailyFile(file string) ([]byte, error) {
+	// Stage 2 is our version and stage 3 theirs; one may be missing if the other side deleted it
+	ours, oursErr := g.git("show", ":2:"+file)
+	theirs, theirsErr := g.git("show", ":3:"+file)
+
+	switch {
+	case oursErr != nil && theirsErr != nil:
+		return nil, fmt.Errorf("no version of the file is available")
+	case oursErr != nil:
+		return theirs, nil
+	case theirsErr != nil:
+		return ours, nil
+	}
+
+	ourSessions, err := g.storage.decodeDailySessions(ours)
+	if err != nil {
+		return nil, err
+	}
+	theirSessions, err := g.storage.decodeDailySessions(theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.storage.encodeDailySessions(models.MergeDailySessions(ourSessions, theirSessions))
+}
+
+// git runs a git command in the data directory and returns its standard output
+func (g *GitSync) git(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.storage.dataDir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.Bytes(), fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// writeFileIfMissing creates a file with the given content unless it already exists
+func writeFileIfMissing(path, content string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// appendMissingLines adds the lines of content missing from the file at path, creating it
+// if needed, so files written by older versions pick up new entries
+func appendMissingLines(path, content string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+
+	present := make(map[string]bool)
+	for _, line := range strings.Split(string(existing), "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+	var missing strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		if !present[line] {
+			missing.WriteString(line + "\n")
+		}
+	}
+	if missing.Len() == 0 {
+		return nil
+	}
+
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		existing = append(existing, '\n')
+	}
+	if err := os.WriteFile(path, append(existing, missing.String()...), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
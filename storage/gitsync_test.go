@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitSync tests syncing two devices through a bare repository with conflicting days
+func TestGitSync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	assert.NoError(t, exec.Command("git", "init", "--bare", remote).Run())
+
+	newDevice := func() (*Storage, *GitSync) {
+		store, err := NewStorage(t.TempDir())
+		assert.NoError(t, err)
+		store.config.GitSyncRemote = remote
+		store.config.GitSyncBranch = "main"
+
+		gitSync, err := NewGitSync(store)
+		assert.NoError(t, err)
+		return store, gitSync
+	}
+
+	day := time.Date(2025, 3, 5, 0, 0, 0, 0, time.Local)
+	newSession := func(id string, start time.Time) *models.Session {
+		session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start})
+		session.ID = id
+		return session
+	}
+
+	laptop, laptopSync := newDevice()
+	desktop, desktopSync := newDevice()
+
+	// Both devices record the same day independently
+	shared := newSession("shared", day.Add(8*time.Hour))
+	assert.NoError(t, laptop.SaveDailySessions(&models.DailySessions{
+		Date:     day,
+		Sessions: []*models.Session{shared, newSession("laptop", day.Add(9*time.Hour))},
+	}))
+	assert.NoError(t, laptopSync.Push())
+
+	// The desktop has a newer version of the shared session
+	sharedEnded := newSession("shared", day.Add(8*time.Hour))
+	sharedEnded.End = &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: day.Add(8*time.Hour + 30*time.Minute)}
+	assert.NoError(t, desktop.SaveDailySessions(&models.DailySessions{
+		Date:     day,
+		Sessions: []*models.Session{newSession("desktop", day.Add(10*time.Hour)), sharedEnded},
+	}))
+	assert.NoError(t, desktopSync.Push())
+
+	assert.NoError(t, laptopSync.Pull())
+
+	for _, store := range []*Storage{laptop, desktop} {
+		sessions, err := store.LoadDailySessions(day)
+		assert.NoError(t, err)
+		if assert.Len(t, sessions.Sessions, 3) {
+			assert.Equal(t, "shared", sessions.Sessions[0].ID)
+			assert.NotNil(t, sessions.Sessions[0].End)
+			assert.Equal(t, "laptop", sessions.Sessions[1].ID)
+			assert.Equal(t, "desktop", sessions.Sessions[2].ID)
+		}
+	}
+
+	_, err := NewGitSync(&Storage{config: config.DefaultConfig()})
+	assert.Error(t, err)
+}
+
+// TestAppendMissingLines tests that ignore files written by older versions pick up new
+// entries, such as the hooks directory, without duplicating existing ones
+func TestAppendMissingLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitignore")
+	assert.NoError(t, os.WriteFile(path, []byte("backups/\nnotes.txt"), 0644))
+
+	assert.NoError(t, appendMissingLines(path, "backups/\nhooks/\n"))
+	assert.NoError(t, appendMissingLines(path, "backups/\nhooks/\n"))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "backups/\nnotes.txt\nhooks/\n", string(data))
+}
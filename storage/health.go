@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// healthProbeFile is written and removed to check the data directory is writable
+const healthProbeFile = ".healthz"
+
+// saveStatus tracks the outcome of saves made by this process
+type saveStatus struct {
+	mu          sync.Mutex
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+}
+
+// Health describes whether the storage is still persisting data
+type Health struct {
+	Writable       bool       `json:"writable"`
+	WriteError     string     `json:"write_error,omitempty"`
+	LastSave       *time.Time `json:"last_save,omitempty"`
+	LastSaveError  string     `json:"last_save_error,omitempty"` // Set when the latest save failed
+	BackupsEnabled bool       `json:"backups_enabled"`
+	LastBackup     *time.Time `json:"last_backup,omitempty"`
+	BackupInterval int        `json:"backup_interval_days"`
+}
+
+// Healthy reports whether the data directory is writable and the latest save succeeded
+func (h *Health) Healthy() bool {
+	return h.Writable && h.LastSaveError == ""
+}
+
+// BackupStale reports whether the latest backup is older than the backup interval.
+// Backups are made when a day file is rewritten, so a new data directory without any
+// backup yet is not stale.
+func (h *Health) BackupStale(now time.Time) bool {
+	if !h.BackupsEnabled || h.BackupInterval <= 0 || h.LastBackup == nil {
+		return false
+	}
+	return now.Sub(*h.LastBackup) > time.Duration(h.BackupInterval)*24*time.Hour
+}
+
+// recordSave remembers the outcome of a save for health reporting
+func (s *Storage) recordSave(err error) {
+	s.saves.mu.Lock()
+	defer s.saves.mu.Unlock()
+
+	if err != nil {
+		s.saves.lastFailure = time.Now()
+		s.saves.lastError = err.Error()
+		return
+	}
+	s.saves.lastSuccess = time.Now()
+}
+
+// Health checks the data directory is writable and reports the last save and backup.
+// Saves made by other processes sharing the directory, like the TUI while serving, are
+// seen through the session file modification times.
+func (s *Storage) Health() *Health {
+	health := &Health{
+		Writable:       true,
+		BackupsEnabled: s.backupEnabled,
+		BackupInterval: s.backupInterval,
+	}
+
+	if err := s.probeWritable(); err != nil {
+		health.Writable = false
+		health.WriteError = err.Error()
+	}
+
+	s.saves.mu.Lock()
+	lastSave := s.saves.lastSuccess
+	if s.saves.lastFailure.After(s.saves.lastSuccess) {
+		health.LastSaveError = s.saves.lastError
+	}
+	s.saves.mu.Unlock()
+
+	if modTime, ok := newestModTime(s.dataDir, "sessions_"); ok && modTime.After(lastSave) {
+		lastSave = modTime
+	}
+	if !lastSave.IsZero() {
+		health.LastSave = &lastSave
+	}
+
+	if s.backupEnabled {
+		if modTime, ok := newestModTime(filepath.Join(s.dataDir, "backups"), "sessions_"); ok {
+			health.LastBackup = &modTime
+		}
+	}
+
+	return health
+}
+
+// probeWritable writes and removes a small file in the data directory
+func (s *Storage) probeWritable() error {
+	probePath := filepath.Join(s.dataDir, healthProbeFile)
+	if err := os.WriteFile(probePath, []byte("ok"), 0600); err != nil {
+		return fmt.Errorf("failed to write to data directory: %w", err)
+	}
+	if err := os.Remove(probePath); err != nil {
+		return fmt.Errorf("failed to remove health probe: %w", err)
+	}
+	return nil
+}
+
+// newestModTime returns the latest modification time of the files in dir with the prefix
+func newestModTime(dir, prefix string) (time.Time, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var newest time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest, !newest.IsZero()
+}
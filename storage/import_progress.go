@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// importProgressFile records how far an interrupted import got
+const importProgressFile = "import_progress.json"
+
+// importProgress identifies an import source and what has already been imported from it
+type importProgress struct {
+	Source    string    `json:"source"` // Absolute path of the import file
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"mod_time"`
+	Overwrite bool      `json:"overwrite"`
+	Merge     bool      `json:"merge,omitempty"`
+
+	Completed string   `json:"completed,omitempty"` // JSON imports: last day imported, days are imported in order
+	Records   int      `json:"records,omitempty"`   // NDJSON imports: records fully imported
+	Days      []string `json:"days,omitempty"`      // NDJSON imports: days saved so far
+}
+
+// newImportProgress returns the saved progress for the source if it matches the file and
+// options exactly, or fresh progress otherwise
+func (s *Storage) newImportProgress(inputPath string, overwrite, merge bool) (*importProgress, error) {
+	source, err := filepath.Abs(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve import path: %w", err)
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	fresh := &importProgress{Source: source, Size: info.Size(), ModTime: info.ModTime(), Overwrite: overwrite, Merge: merge}
+
+	data, err := os.ReadFile(filepath.Join(s.dataDir, importProgressFile))
+	if err != nil {
+		return fresh, nil // No interrupted import
+	}
+	var saved importProgress
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fresh, nil // Unreadable progress, start over
+	}
+
+	// A changed file or different options invalidate the progress
+	if saved.Source != fresh.Source || saved.Size != fresh.Size ||
+		!saved.ModTime.Equal(fresh.ModTime) || saved.Overwrite != overwrite || saved.Merge != merge {
+		return fresh, nil
+	}
+	return &saved, nil
+}
+
+// resuming reports whether the progress continues an interrupted import
+func (p *importProgress) resuming() bool {
+	return p.Completed != "" || p.Records > 0
+}
+
+// saveImportProgress records the progress so a rerun can resume from it
+func (s *Storage) saveImportProgress(progress *importProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to marshal import progress: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dataDir, importProgressFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to save import progress: %w", err)
+	}
+	return nil
+}
+
+// clearImportProgress removes the progress once an import has finished
+func (s *Storage) clearImportProgress() error {
+	if err := os.Remove(filepath.Join(s.dataDir, importProgressFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove import progress: %w", err)
+	}
+	return nil
+}
+
+// ImportResumePoint describes where an interrupted import of the file would resume, if
+// the file is unchanged since then
+func (s *Storage) ImportResumePoint(inputPath string, overwrite, merge bool) (string, bool) {
+	progress, err := s.newImportProgress(inputPath, overwrite, merge)
+	if err != nil || !progress.resuming() {
+		return "", false
+	}
+	if progress.Completed != "" {
+		return "after " + progress.Completed, true
+	}
+	return fmt.Sprintf("after %d session(s)", progress.Records), true
+}
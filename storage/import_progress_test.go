@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestImportNDJSONFileResume tests that an interrupted NDJSON import continues after the
+// records it had already imported
+func TestImportNDJSONFileResume(t *testing.T) {
+	day := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	record := func(date, id string, start time.Time) string {
+		return `{"date":"` + date + `","id":"` + id + `","start":{"type":"start","start_time":"` +
+			start.Format(time.RFC3339) + `"},"end":{"type":"end","start_time":"` +
+			start.Add(time.Hour).Format(time.RFC3339) + `"}}`
+	}
+	lines := []string{
+		record("2025-03-05", "a", day.Add(9*time.Hour)),
+		record("2025-03-06", "c", day.Add(33*time.Hour)),
+		record("2025-03-05", "b", day.Add(13*time.Hour)),
+	}
+	importPath := filepath.Join(t.TempDir(), "sessions.ndjson")
+	assert.NoError(t, os.WriteFile(importPath, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	// Simulate a run interrupted after saving the first day
+	count, err := store.ImportNDJSON(strings.NewReader(lines[0]+"\n"), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	progress, err := store.newImportProgress(importPath, false, false)
+	assert.NoError(t, err)
+	progress.Records = 1
+	progress.Days = []string{"2025-03-05"}
+	assert.NoError(t, store.saveImportProgress(progress))
+
+	point, ok := store.ImportResumePoint(importPath, false, false)
+	assert.True(t, ok)
+	assert.Equal(t, "after 1 session(s)", point)
+
+	// Different options don't resume
+	_, ok = store.ImportResumePoint(importPath, true, false)
+	assert.False(t, ok)
+
+	count, err = store.ImportNDJSONFile(importPath, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	first, err := store.LoadDailySessions(day)
+	assert.NoError(t, err)
+	assert.Len(t, first.Sessions, 2)
+	second, err := store.LoadDailySessions(day.AddDate(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Len(t, second.Sessions, 1)
+
+	// Progress is cleared once the import completes
+	_, err = os.Stat(filepath.Join(store.dataDir, importProgressFile))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestImportDataResume tests that an interrupted JSON import skips the days it had
+// already imported
+func TestImportDataResume(t *testing.T) {
+	source, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 5, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 3; i++ {
+		date := day.AddDate(0, 0, i)
+		start := date.Add(9 * time.Hour)
+		assert.NoError(t, source.SaveDailySessions(&models.DailySessions{
+			Date: date,
+			Sessions: []*models.Session{{
+				ID:    date.Format("20060102"),
+				Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+				End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+			}},
+		}))
+	}
+	importPath := filepath.Join(t.TempDir(), "export.json")
+	assert.NoError(t, source.ExportData(importPath))
+
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	// Simulate a run interrupted after the first two days
+	progress, err := store.newImportProgress(importPath, false, false)
+	assert.NoError(t, err)
+	progress.Completed = "2025-03-06"
+	assert.NoError(t, store.saveImportProgress(progress))
+
+	point, ok := store.ImportResumePoint(importPath, false, false)
+	assert.True(t, ok)
+	assert.Equal(t, "after 2025-03-06", point)
+
+	assert.NoError(t, store.ImportData(importPath, false))
+
+	days, err := store.ListAvailableDays()
+	assert.NoError(t, err)
+	assert.Len(t, days, 1)
+	assert.True(t, days[0].Equal(day.AddDate(0, 0, 2)))
+
+	_, ok = store.ImportResumePoint(importPath, false, false)
+	assert.False(t, ok)
+}
@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// foreignEntry is a time entry read from the export of another time tracker
+type foreignEntry struct {
+	Description string
+	Project     string
+	Start       time.Time
+	End         time.Time
+}
+
+// csvAdapter recognizes the CSV export of another time tracker by its columns. Column
+// names are compared in lower case.
+type csvAdapter struct {
+	name        string
+	required    []string // Columns only this format has all of
+	description string
+	project     string   // Empty when the format has no project
+	start       []string // Columns joined with a space into the start time
+	end         []string // Columns joined with a space into the end time
+}
+
+// csvAdapters are tried in order, the most specific columns first
+var csvAdapters = []csvAdapter{
+	{
+		name:        "Clockify",
+		required:    []string{"description", "start date", "start time", "end date", "end time", "duration (h)"},
+		description: "description",
+		project:     "project",
+		start:       []string{"start date", "start time"},
+		end:         []string{"end date", "end time"},
+	},
+	{
+		name:        "Toggl Track",
+		required:    []string{"description", "start date", "start time", "end date", "end time"},
+		description: "description",
+		project:     "project",
+		start:       []string{"start date", "start time"},
+		end:         []string{"end date", "end time"},
+	},
+	{
+		name:        "ManicTime",
+		required:    []string{"name", "start", "end"},
+		description: "name",
+		start:       []string{"start"},
+		end:         []string{"end"},
+	},
+}
+
+// foreignTimeLayouts are the date and time formats exports use, US month first before
+// day first
+var foreignTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006 3:04 PM",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+}
+
+// parseForeignTime parses a date and time from an export in local time
+func parseForeignTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range foreignTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
+
+// parseForeignCSV reads the entries of a CSV export whose header matches an adapter.
+// Returns false when no adapter recognizes the header.
+func parseForeignCSV(data []byte) (string, []foreignEntry, bool, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil || len(rows) == 0 {
+		return "", nil, false, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, adapter := range csvAdapters {
+		if !hasColumns(columns, adapter.required) {
+			continue
+		}
+
+		field := func(row []string, name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		joined := func(row []string, names []string) string {
+			parts := make([]string, len(names))
+			for i, name := range names {
+				parts[i] = field(row, name)
+			}
+			return strings.Join(parts, " ")
+		}
+
+		entries := make([]foreignEntry, 0, len(rows)-1)
+		for line, row := range rows[1:] {
+			start, err := parseForeignTime(joined(row, adapter.start))
+			if err != nil {
+				return adapter.name, nil, true, fmt.Errorf("line %d: %w", line+2, err)
+			}
+			end, err := parseForeignTime(joined(row, adapter.end))
+			if err != nil {
+				return adapter.name, nil, true, fmt.Errorf("line %d: %w", line+2, err)
+			}
+			entry := foreignEntry{Description: field(row, adapter.description), Start: start, End: end}
+			if adapter.project != "" {
+				entry.Project = field(row, adapter.project)
+			}
+			entries = append(entries, entry)
+		}
+		return adapter.name, entries, true, nil
+	}
+	return "", nil, false, nil
+}
+
+// hasColumns reports whether all the named columns are present
+func hasColumns(columns map[string]int, names []string) bool {
+	for _, name := range names {
+		if _, ok := columns[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// togglJSONEntry is a time entry of the Toggl Track API and JSON exports
+type togglJSONEntry struct {
+	Description string     `json:"description"`
+	Start       time.Time  `json:"start"`
+	Stop        *time.Time `json:"stop"`
+	Project     string     `json:"project_name"`
+}
+
+// clockifyJSONEntry is a time entry of the Clockify API and JSON exports
+type clockifyJSONEntry struct {
+	Description  string `json:"description"`
+	TimeInterval struct {
+		Start time.Time  `json:"start"`
+		End   *time.Time `json:"end"`
+	} `json:"timeInterval"`
+	Project *struct {
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+// parseForeignJSON reads the entries of a Toggl Track or Clockify JSON export, an array
+// of time entries. Returns false when the data is not such an array.
+func parseForeignJSON(data []byte) (string, []foreignEntry, bool, error) {
+	var probe []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil || len(probe) == 0 {
+		return "", nil, false, nil
+	}
+
+	// Entries still running have no end and are left out
+	var entries []foreignEntry
+	switch {
+	case probe[0]["timeInterval"] != nil:
+		var clockify []clockifyJSONEntry
+		if err := json.Unmarshal(data, &clockify); err != nil {
+			return "Clockify", nil, true, fmt.Errorf("failed to parse Clockify entries: %w", err)
+		}
+		for _, item := range clockify {
+			if item.TimeInterval.End == nil {
+				continue
+			}
+			entry := foreignEntry{Description: item.Description, Start: item.TimeInterval.Start.Local(), End: item.TimeInterval.End.Local()}
+			if item.Project != nil {
+				entry.Project = item.Project.Name
+			}
+			entries = append(entries, entry)
+		}
+		return "Clockify", entries, true, nil
+
+	case probe[0]["start"] != nil:
+		var toggl []togglJSONEntry
+		if err := json.Unmarshal(data, &toggl); err != nil {
+			return "Toggl Track", nil, true, fmt.Errorf("failed to parse Toggl Track entries: %w", err)
+		}
+		for _, item := range toggl {
+			if item.Stop == nil {
+				continue
+			}
+			entries = append(entries, foreignEntry{Description: item.Description, Project: item.Project, Start: item.Start.Local(), End: item.Stop.Local()})
+		}
+		return "Toggl Track", entries, true, nil
+	}
+	return "", nil, false, nil
+}
+
+// foreignSessions turns the entries of another tracker into completed sessions without
+// interruptions, grouped by the day each started on. Entries without a positive
+// duration are left out.
+func (s *Storage) foreignSessions(entries []foreignEntry) map[string]*models.DailySessions {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
+
+	days := make(map[string]*models.DailySessions)
+	for _, entry := range entries {
+		if !entry.End.After(entry.Start) {
+			continue
+		}
+
+		start := &models.TimeEntry{
+			ID:          fmt.Sprintf("%d", entry.Start.UnixNano()),
+			Type:        models.EntryTypeStart,
+			StartTime:   entry.Start,
+			Description: entry.Description,
+		}
+		end := &models.TimeEntry{
+			ID:        fmt.Sprintf("%d", entry.End.UnixNano()),
+			Type:      models.EntryTypeEnd,
+			StartTime: entry.End,
+		}
+		session := models.NewSession(start)
+		session.ID = fmt.Sprintf("import_%d", entry.Start.UnixNano())
+		session.Project = entry.Project
+		session.End = end
+		session.SubSessions[0].End = end
+
+		date := s.DayOf(entry.Start)
+		dateStr := date.Format("2006-01-02")
+		if days[dateStr] == nil {
+			days[dateStr] = &models.DailySessions{Date: date}
+		}
+		days[dateStr].Sessions = append(days[dateStr].Sessions, session)
+	}
+	return days
+}
+
+// decodeImport reads an import file: an export of this tracker, or the CSV or JSON export
+// of Toggl Track, Clockify or ManicTime converted into sessions. Returns the days to
+// import by date string and the name of the format.
+func (s *Storage) decodeImport(data []byte) (map[string]*models.DailySessions, string, error) {
+	var allData map[string]*models.DailySessions
+	nativeErr := json.Unmarshal(data, &allData)
+	if nativeErr == nil {
+		return allData, "interruption-tracker", nil
+	}
+
+	for _, parse := range []func([]byte) (string, []foreignEntry, bool, error){parseForeignJSON, parseForeignCSV} {
+		format, entries, ok, err := parse(data)
+		if !ok {
+			continue
+		}
+		if err != nil {
+			return nil, format, fmt.Errorf("failed to read %s export: %w", format, err)
+		}
+		return s.foreignSessions(entries), format, nil
+	}
+
+	return nil, "", fmt.Errorf("failed to unmarshal import data, not an export of this tracker, Toggl Track, Clockify or ManicTime: %w", nativeErr)
+}
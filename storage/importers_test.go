@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestImportForeignExports tests exports of other time trackers are recognized and
+// imported as completed sessions on the day they started
+func TestImportForeignExports(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	utcStart := time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local).UTC().Format(time.RFC3339)
+	utcEnd := time.Date(2025, 3, 10, 10, 30, 0, 0, time.Local).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		format  string
+		project string
+	}{
+		{
+			name: "Toggl Track CSV",
+			file: "toggl.csv",
+			content: "User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags\n" +
+				"Me,me@example.com,,Backend,,Storage refactor,No,2025-03-10,09:00:00,2025-03-10,10:30:00,01:30:00,\n",
+			format:  "Toggl Track",
+			project: "Backend",
+		},
+		{
+			name: "Clockify CSV",
+			file: "clockify.csv",
+			content: "\xef\xbb\xbfProject,Client,Description,Task,User,Tags,Billable,Start Date,Start Time,End Date,End Time,Duration (h),Duration (decimal)\n" +
+				"Backend,,Storage refactor,,Me,,No,03/10/2025,09:00:00 AM,03/10/2025,10:30:00 AM,01:30:00,1.50\n",
+			format:  "Clockify",
+			project: "Backend",
+		},
+		{
+			name: "ManicTime CSV",
+			file: "manictime.csv",
+			content: "Name,Start,End,Duration,Notes\n" +
+				"Storage refactor,2025-03-10 09:00:00,2025-03-10 10:30:00,1:30:00,\n",
+			format: "ManicTime",
+		},
+		{
+			name:    "Toggl Track JSON",
+			file:    "toggl.json",
+			content: `[{"description":"Storage refactor","start":"` + utcStart + `","stop":"` + utcEnd + `","project_name":"Backend"},{"description":"Running","start":"` + utcStart + `","stop":null}]`,
+			format:  "Toggl Track",
+			project: "Backend",
+		},
+		{
+			name:    "Clockify JSON",
+			file:    "clockify.json",
+			content: `[{"description":"Storage refactor","timeInterval":{"start":"` + utcStart + `","end":"` + utcEnd + `"},"project":{"name":"Backend"}}]`,
+			format:  "Clockify",
+			project: "Backend",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewStorage(t.TempDir())
+			assert.NoError(t, err)
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			_, format, err := store.decodeImport([]byte(tt.content))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.format, format)
+
+			assert.NoError(t, store.ImportData(path, false))
+			imported, err := store.LoadDailySessions(day)
+			assert.NoError(t, err)
+			if assert.Len(t, imported.Sessions, 1) {
+				session := imported.Sessions[0]
+				assert.Equal(t, "Storage refactor", session.Start.Description)
+				assert.Equal(t, tt.project, session.Project)
+				work, _, _ := session.GetStats()
+				assert.Equal(t, 90*time.Minute, work)
+			}
+		})
+	}
+}
+
+// TestImportUnknownFormat tests a file no adapter recognizes is rejected
+func TestImportUnknownFormat(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	_, _, err = store.decodeImport([]byte("when,what\nyesterday,stuff\n"))
+	assert.ErrorContains(t, err, "Toggl Track, Clockify or ManicTime")
+
+	_, _, err = store.decodeImport([]byte("Name,Start,End\nBroken,not a time,2025-03-10 10:00\n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	days := store.foreignSessions([]foreignEntry{{Description: "Backwards", Start: time.Now(), End: time.Now().Add(-time.Hour)}})
+	assert.Empty(t, days)
+}
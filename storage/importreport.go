@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// ImportOptions decide what an import does with days that already have sessions
+type ImportOptions struct {
+	Overwrite bool // Replace existing days with the imported ones
+	Merge     bool // Add imported sessions to existing days, leaving out duplicates and overlaps
+	DryRun    bool // Only report what would be done, writing nothing
+}
+
+// ImportAction is what an import does with one day
+type ImportAction string
+
+const (
+	// ImportCreate saves a day that has no sessions yet
+	ImportCreate ImportAction = "create"
+	// ImportSkip leaves an existing day alone
+	ImportSkip ImportAction = "skip"
+	// ImportOverwrite replaces an existing day
+	ImportOverwrite ImportAction = "overwrite"
+	// ImportMerge adds sessions to an existing day
+	ImportMerge ImportAction = "merge"
+	// ImportDone marks a day an interrupted run of the same import already saved
+	ImportDone ImportAction = "done"
+)
+
+// ImportDay is what an import does with one day
+type ImportDay struct {
+	Date        string       `json:"date"`
+	Action      ImportAction `json:"action"`
+	Sessions    int          `json:"sessions"`              // Valid sessions of the day in the import
+	Existing    int          `json:"existing,omitempty"`    // Sessions stored for the day before the import
+	Added       int          `json:"added"`                 // Sessions the import saves for the day
+	Duplicates  int          `json:"duplicates,omitempty"`  // Merging: sessions already stored with the same ID, kept as stored
+	Updated     int          `json:"updated,omitempty"`     // Merging: stored sessions replaced by an imported version with more recent activity
+	Overlapping int          `json:"overlapping,omitempty"` // Merging: sessions overlapping a stored one, left out
+}
+
+// ImportIssue is a problem found in the imported data
+type ImportIssue struct {
+	Date      string `json:"date"`
+	SessionID string `json:"session_id,omitempty"`
+	Problem   string `json:"problem"`
+	Skipped   bool   `json:"skipped"` // The session is left out of the import
+}
+
+// ImportReport lists what an import does with each day and the problems found in it
+type ImportReport struct {
+	Format string        `json:"format"` // Tracker the file was exported from
+	Days   []ImportDay   `json:"days"`
+	Issues []ImportIssue `json:"issues,omitempty"`
+}
+
+// Added returns the number of sessions the import saves
+func (r *ImportReport) Added() int {
+	added := 0
+	for _, day := range r.Days {
+		added += day.Added
+	}
+	return added
+}
+
+// validImportSessions returns the sessions of an imported day that are not malformed,
+// reporting the malformed ones and the valid ones overlapping each other
+func validImportSessions(dateStr string, sessions []*models.Session, now time.Time) ([]*models.Session, []ImportIssue) {
+	var valid []*models.Session
+	var issues []ImportIssue
+	for i, session := range sessions {
+		if session == nil {
+			issues = append(issues, ImportIssue{Date: dateStr, Problem: fmt.Sprintf("session %d is empty", i+1), Skipped: true})
+			continue
+		}
+		if problems := session.Problems(); len(problems) > 0 {
+			for _, problem := range problems {
+				issues = append(issues, ImportIssue{Date: dateStr, SessionID: session.ID, Problem: problem, Skipped: true})
+			}
+			continue
+		}
+		valid = append(valid, session)
+	}
+
+	sorted := append([]*models.Session{}, valid...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.StartTime.Before(sorted[j].Start.StartTime) })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Overlaps(sorted[i-1], now) {
+			issues = append(issues, ImportIssue{Date: dateStr, SessionID: sorted[i].ID,
+				Problem: fmt.Sprintf("overlaps imported session %s", sorted[i-1].ID)})
+		}
+	}
+	return valid, issues
+}
+
+// mergeImportDay unions the imported sessions with the stored ones by session ID like
+// MergeDailySessions: a session stored already is replaced when the imported version has
+// more recent activity, such as a later end, so exporting and importing both ways syncs two
+// machines. New sessions overlapping a stored one are left out. Returns the merged sessions
+// in start order.
+func mergeImportDay(day *ImportDay, existing, imported []*models.Session, now time.Time) ([]*models.Session, []ImportIssue) {
+	stored := make(map[string]int, len(existing))
+	for i, session := range existing {
+		stored[session.ID] = i
+	}
+
+	// Update the stored sessions first, so new sessions are checked against their latest versions
+	merged := append([]*models.Session{}, existing...)
+	var added []*models.Session
+	for _, session := range imported {
+		i, ok := stored[session.ID]
+		switch {
+		case !ok:
+			added = append(added, session)
+		case session.LastActivity().After(merged[i].LastActivity()):
+			merged[i] = session
+			day.Updated++
+		default:
+			day.Duplicates++
+		}
+	}
+
+	var issues []ImportIssue
+	updated := merged[:len(existing)]
+	for _, session := range added {
+		var overlapped *models.Session
+		for _, other := range updated {
+			if session.Overlaps(other, now) {
+				overlapped = other
+				break
+			}
+		}
+		if overlapped != nil {
+			day.Overlapping++
+			issues = append(issues, ImportIssue{Date: day.Date, SessionID: session.ID,
+				Problem: fmt.Sprintf("overlaps stored session %s", overlapped.ID), Skipped: true})
+			continue
+		}
+
+		merged = append(merged, session)
+		day.Added++
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Start.StartTime.Before(merged[j].Start.StartTime) })
+	return merged, issues
+}
+
+// Import imports a file like ImportData and reports what was done with each day and the
+// problems found. Malformed sessions are left out. Days already stored are skipped unless
+// overwritten or merged; with DryRun nothing is written and the report tells what would be.
+func (s *Storage) Import(inputPath string, options ImportOptions) (*ImportReport, error) {
+	progress, err := s.newImportProgress(inputPath, options.Overwrite, options.Merge)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the file
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	// Backup archives are encrypted when encryption is enabled
+	if s.encryptionEnabled && !json.Valid(data) {
+		if decrypted, err := s.decrypt(data); err == nil {
+			data = decrypted
+		}
+	}
+
+	// Parse the data, converting exports of other time trackers
+	allData, format, err := s.decodeImport(data)
+	if err != nil {
+		return nil, err
+	}
+	report := &ImportReport{Format: format}
+
+	dates := make([]string, 0, len(allData))
+	for dateStr := range allData {
+		dates = append(dates, dateStr)
+	}
+	sort.Strings(dates)
+
+	now := time.Now()
+
+	// Import each day's sessions
+	for _, dateStr := range dates {
+		// Dates are kept in UTC like the exports they come from
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return report, fmt.Errorf("invalid date format in import: %s", dateStr)
+		}
+
+		var imported []*models.Session
+		var meetings []*models.Meeting // Meetings declared for the day, the stored ones when merging
+		if allData[dateStr] != nil {
+			imported = allData[dateStr].Sessions
+			meetings = allData[dateStr].Meetings
+		}
+		valid, issues := validImportSessions(dateStr, imported, now)
+		report.Issues = append(report.Issues, issues...)
+		day := ImportDay{Date: dateStr, Sessions: len(valid)}
+
+		if dateStr <= progress.Completed {
+			// Imported before the previous run was interrupted
+			day.Action = ImportDone
+			report.Days = append(report.Days, day)
+			continue
+		}
+
+		sessions := valid
+		day.Action = ImportCreate
+		if _, err := os.Stat(s.getFilePath(date)); err == nil {
+			existing, err := s.LoadDailySessions(date)
+			if err != nil {
+				return report, fmt.Errorf("failed to load sessions for %s: %w", dateStr, err)
+			}
+			day.Existing = len(existing.Sessions)
+
+			switch {
+			case options.Overwrite && len(valid) == 0:
+				// Nothing valid to replace the stored sessions with
+				day.Action = ImportSkip
+				sessions = nil
+			case options.Overwrite:
+				day.Action = ImportOverwrite
+			case options.Merge:
+				day.Action = ImportMerge
+				var mergeIssues []ImportIssue
+				sessions, mergeIssues = mergeImportDay(&day, existing.Sessions, valid, now)
+				meetings = existing.Meetings
+				report.Issues = append(report.Issues, mergeIssues...)
+			default:
+				day.Action = ImportSkip
+				sessions = nil
+			}
+		}
+		if day.Action != ImportMerge && day.Action != ImportSkip {
+			day.Added = len(valid)
+		}
+		report.Days = append(report.Days, day)
+
+		if options.DryRun {
+			continue
+		}
+
+		// Save the sessions
+		if day.Action == ImportOverwrite || day.Added > 0 || day.Updated > 0 {
+			if err := s.SaveDailySessions(&models.DailySessions{Date: date, Sessions: sessions, Meetings: meetings}); err != nil {
+				return report, fmt.Errorf("failed to save imported sessions for %s: %w", dateStr, err)
+			}
+		}
+
+		progress.Completed = dateStr
+		if err := s.saveImportProgress(progress); err != nil {
+			return report, err
+		}
+	}
+
+	if options.DryRun {
+		return report, nil
+	}
+	return report, s.clearImportProgress()
+}
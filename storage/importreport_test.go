@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// importSession creates a completed session for import tests
+func importSession(id string, start, end time.Time) *models.Session {
+	session := models.NewSession(&models.TimeEntry{ID: id + "_start", Type: models.EntryTypeStart, StartTime: start, Description: id})
+	session.ID = id
+	session.EndAt(end)
+	return session
+}
+
+// writeImportFile writes days of sessions in the export format and returns its path
+func writeImportFile(t *testing.T, days map[string]*models.DailySessions) string {
+	t.Helper()
+
+	data, err := json.Marshal(days)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "import.json")
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+	return path
+}
+
+// TestImportReport tests the actions and problems reported for each day, and that a dry
+// run writes nothing
+func TestImportReport(t *testing.T) {
+	storage, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+	stored := importSession("stored", monday.Add(9*time.Hour), monday.Add(10*time.Hour))
+	assert.NoError(t, storage.SaveDailySessions(&models.DailySessions{Date: monday, Sessions: []*models.Session{stored}}))
+
+	malformed := importSession("malformed", tuesday.Add(9*time.Hour), tuesday.Add(8*time.Hour))
+	path := writeImportFile(t, map[string]*models.DailySessions{
+		"2025-03-10": {Date: monday, Sessions: []*models.Session{
+			importSession("stored", monday.Add(9*time.Hour), monday.Add(10*time.Hour)),
+			importSession("overlapping", monday.Add(9*time.Hour+30*time.Minute), monday.Add(11*time.Hour)),
+			importSession("afternoon", monday.Add(14*time.Hour), monday.Add(15*time.Hour)),
+		}},
+		"2025-03-11": {Date: tuesday, Sessions: []*models.Session{
+			importSession("tuesday", tuesday.Add(9*time.Hour), tuesday.Add(10*time.Hour)),
+			malformed,
+		}, Meetings: []*models.Meeting{{Title: "Standup", Start: tuesday.Add(11 * time.Hour), End: tuesday.Add(11*time.Hour + 15*time.Minute)}}},
+	})
+
+	tests := []struct {
+		name    string
+		options ImportOptions
+		monday  ImportDay
+	}{
+		{
+			name:    "skip",
+			options: ImportOptions{DryRun: true},
+			monday:  ImportDay{Date: "2025-03-10", Action: ImportSkip, Sessions: 3, Existing: 1},
+		},
+		{
+			name:    "overwrite",
+			options: ImportOptions{Overwrite: true, DryRun: true},
+			monday:  ImportDay{Date: "2025-03-10", Action: ImportOverwrite, Sessions: 3, Existing: 1, Added: 3},
+		},
+		{
+			name:    "merge",
+			options: ImportOptions{Merge: true, DryRun: true},
+			monday:  ImportDay{Date: "2025-03-10", Action: ImportMerge, Sessions: 3, Existing: 1, Added: 1, Duplicates: 1, Overlapping: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := storage.Import(path, tt.options)
+			assert.NoError(t, err)
+			assert.Equal(t, "interruption-tracker", report.Format)
+			assert.Equal(t, []ImportDay{
+				tt.monday,
+				{Date: "2025-03-11", Action: ImportCreate, Sessions: 1, Added: 1},
+			}, report.Days)
+			assert.Contains(t, report.Issues, ImportIssue{Date: "2025-03-11", SessionID: "malformed", Problem: "ends before it starts", Skipped: true})
+			assert.Contains(t, report.Issues, ImportIssue{Date: "2025-03-10", SessionID: "overlapping", Problem: "overlaps imported session stored"})
+
+			// Nothing was written
+			_, err = os.Stat(storage.getFilePath(tuesday))
+			assert.True(t, os.IsNotExist(err))
+			day, err := storage.LoadDailySessions(monday)
+			assert.NoError(t, err)
+			assert.Len(t, day.Sessions, 1)
+		})
+	}
+
+	// Merging for real adds only the session that neither duplicates nor overlaps a stored one
+	report, err := storage.Import(path, ImportOptions{Merge: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Added())
+	assert.Contains(t, report.Issues, ImportIssue{Date: "2025-03-10", SessionID: "overlapping", Problem: "overlaps stored session stored", Skipped: true})
+
+	day, err := storage.LoadDailySessions(monday)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stored", "afternoon"}, []string{day.Sessions[0].ID, day.Sessions[1].ID})
+	day, err = storage.LoadDailySessions(tuesday)
+	assert.NoError(t, err)
+	assert.Len(t, day.Sessions, 1)
+	assert.Equal(t, "tuesday", day.Sessions[0].ID)
+	assert.Len(t, day.Meetings, 1, "declared meetings are imported with new days")
+}
+
+// TestImportOverwriteInvalid tests overwriting leaves a stored day alone when none of the
+// imported sessions for it are valid
+func TestImportOverwriteInvalid(t *testing.T) {
+	storage, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	assert.NoError(t, storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{
+		importSession("stored", day.Add(9*time.Hour), day.Add(10*time.Hour)),
+	}}))
+	path := writeImportFile(t, map[string]*models.DailySessions{
+		"2025-03-10": {Date: day, Sessions: []*models.Session{importSession("malformed", day.Add(9*time.Hour), day.Add(8*time.Hour))}},
+	})
+
+	report, err := storage.Import(path, ImportOptions{Overwrite: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []ImportDay{{Date: "2025-03-10", Action: ImportSkip, Existing: 1}}, report.Days)
+	stored, err := storage.LoadDailySessions(day)
+	assert.NoError(t, err)
+	if assert.Len(t, stored.Sessions, 1) {
+		assert.Equal(t, "stored", stored.Sessions[0].ID)
+	}
+}
+
+// TestImportMergeUnion tests merging keeps the version of a session with the most recent
+// activity, so two machines sync by exporting and importing each other's data
+func TestImportMergeUnion(t *testing.T) {
+	storage, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	active := models.NewSession(&models.TimeEntry{ID: "active_start", Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour)})
+	active.ID = "active"
+	assert.NoError(t, storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{
+		active,
+		importSession("late", day.Add(13*time.Hour), day.Add(15*time.Hour)),
+	}}))
+
+	path := writeImportFile(t, map[string]*models.DailySessions{
+		"2025-03-10": {Date: day, Sessions: []*models.Session{
+			importSession("active", day.Add(9*time.Hour), day.Add(11*time.Hour)),
+			importSession("late", day.Add(13*time.Hour), day.Add(14*time.Hour)),
+			importSession("other", day.Add(11*time.Hour), day.Add(12*time.Hour)),
+		}},
+	})
+
+	report, err := storage.Import(path, ImportOptions{Merge: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []ImportDay{{Date: "2025-03-10", Action: ImportMerge, Sessions: 3, Existing: 2, Added: 1, Duplicates: 1, Updated: 1}}, report.Days)
+
+	merged, err := storage.LoadDailySessions(day)
+	assert.NoError(t, err)
+	assert.Len(t, merged.Sessions, 3)
+	assert.Equal(t, []string{"active", "other", "late"}, []string{merged.Sessions[0].ID, merged.Sessions[1].ID, merged.Sessions[2].ID})
+	assert.NotNil(t, merged.Sessions[0].End, "ended on the other machine")
+	assert.Equal(t, day.Add(15*time.Hour), merged.Sessions[2].End.StartTime.Local(), "kept as stored")
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// GetInterruptionLog returns every interruption in the given date range with its
+// description, grouped by tag
+func (s *Storage) GetInterruptionLog(rangeType string, now time.Time) ([]models.InterruptionGroup, error) {
+	startDate, endDate, err := s.GetDateRange(rangeType)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []models.InterruptionRecord
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dailySessions, err := s.LoadDailySessions(d)
+		if err != nil {
+			continue // Skip days with errors
+		}
+
+		for _, session := range dailySessions.Sessions {
+			records = append(records, session.InterruptionRecords(d, now)...)
+		}
+	}
+
+	return models.GroupInterruptionsByTag(records), nil
+}
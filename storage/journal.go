@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// journalFileName is the append-only event log kept next to the daily files
+const journalFileName = "journal.jsonl"
+
+// getJournalPath returns the path of the journal file
+func (s *Storage) getJournalPath() string {
+	return filepath.Join(s.dataDir, journalFileName)
+}
+
+// AppendJournal appends an event to the journal when journaling is enabled.
+// Each event is a single line, flushed to disk before returning.
+func (s *Storage) AppendJournal(event *models.JournalEvent) error {
+	if !s.config.JournalEnabled {
+		return nil
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal event: %w", err)
+	}
+
+	// Encrypted lines are base64 encoded to keep one event per line
+	if s.encryptionEnabled {
+		encrypted, err := s.encrypt(line)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt journal event: %w", err)
+		}
+		line = []byte(base64.StdEncoding.EncodeToString(encrypted))
+	}
+
+	file, err := os.OpenFile(s.getJournalPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal event: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+
+	return nil
+}
+
+// ReadJournal returns all journal events in the order they were recorded.
+// A truncated final line, as left by a crash mid-write, is ignored.
+func (s *Storage) ReadJournal() ([]*models.JournalEvent, error) {
+	data, err := os.ReadFile(s.getJournalPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan journal: %w", err)
+	}
+
+	events := make([]*models.JournalEvent, 0, len(lines))
+	for i, line := range lines {
+		event, err := s.decodeJournalLine(line)
+		if err != nil {
+			if i == len(lines)-1 && !bytes.HasSuffix(data, []byte("\n")) {
+				break // Incomplete last write
+			}
+			return nil, fmt.Errorf("failed to parse journal line %d: %w", i+1, err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// decodeJournalLine parses a single, possibly encrypted, journal line
+func (s *Storage) decodeJournalLine(line []byte) (*models.JournalEvent, error) {
+	if s.encryptionEnabled {
+		encrypted, err := base64.StdEncoding.DecodeString(string(line))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode encrypted event: %w", err)
+		}
+		line, err = s.decrypt(encrypted)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var event models.JournalEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// RecoverFromJournal replays the journal over the daily files and saves the result.
+// Sessions found in the journal are replaced by their latest recorded state, deleted
+// sessions are removed, and unreadable daily files are rebuilt from the journal alone.
+// Returns the number of days rewritten.
+func (s *Storage) RecoverFromJournal() (int, error) {
+	events, err := s.ReadJournal()
+	if err != nil {
+		return 0, err
+	}
+
+	days := make(map[string]*models.DailySessions)
+	sessionDays := make(map[string]string) // Session ID to the day it was last recorded in
+
+	loadDay := func(date string) (*models.DailySessions, error) {
+		if daily, ok := days[date]; ok {
+			return daily, nil
+		}
+
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date in journal: %s", date)
+		}
+
+		daily, err := s.LoadDailySessions(parsed)
+		if err != nil {
+			// The snapshot is damaged, rebuild the day from the journal
+			daily = &models.DailySessions{Date: parsed, Sessions: []*models.Session{}}
+		}
+
+		days[date] = daily
+		return daily, nil
+	}
+
+	for _, event := range events {
+		if event.SessionID == "" {
+			continue
+		}
+
+		// Sessions carried over to a new day are removed from the old one
+		if previous, ok := sessionDays[event.SessionID]; ok && previous != event.Date {
+			if daily, ok := days[previous]; ok {
+				daily.Sessions = removeSession(daily.Sessions, event.SessionID)
+			}
+		}
+
+		daily, err := loadDay(event.Date)
+		if err != nil {
+			return 0, err
+		}
+
+		if event.Type == models.JournalDelete || event.Session == nil {
+			daily.Sessions = removeSession(daily.Sessions, event.SessionID)
+			delete(sessionDays, event.SessionID)
+			continue
+		}
+
+		daily.Sessions = upsertSession(daily.Sessions, event.Session)
+		sessionDays[event.SessionID] = event.Date
+	}
+
+	for date, daily := range days {
+		if err := s.SaveDailySessions(daily); err != nil {
+			return 0, fmt.Errorf("failed to save recovered sessions for %s: %w", date, err)
+		}
+	}
+
+	return len(days), nil
+}
+
+// removeSession returns sessions without the session with the given ID
+func removeSession(sessions []*models.Session, id string) []*models.Session {
+	result := sessions[:0]
+	for _, session := range sessions {
+		if session.ID != id {
+			result = append(result, session)
+		}
+	}
+	return result
+}
+
+// upsertSession replaces the session with the same ID or appends it
+func upsertSession(sessions []*models.Session, session *models.Session) []*models.Session {
+	for i, existing := range sessions {
+		if existing.ID == session.ID {
+			sessions[i] = session
+			return sessions
+		}
+	}
+	return append(sessions, session)
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// MoveSessions moves sessions between the files of two days, e.g. a session started after
+// midnight that belongs to the previous day. Both files are replaced together: if the
+// second cannot be written the first is restored, so a session is never lost or doubled.
+func (s *Storage) MoveSessions(from, to time.Time, ids []string) error {
+	from, to = models.DayOf(from, 0), models.DayOf(to, 0)
+	if from.Equal(to) {
+		return fmt.Errorf("sessions are already stored under %s", to.Format("2006-01-02"))
+	}
+
+	source, err := s.LoadDailySessions(from)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions for %s: %w", from.Format("2006-01-02"), err)
+	}
+	target, err := s.LoadDailySessions(to)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions for %s: %w", to.Format("2006-01-02"), err)
+	}
+	source.Date, target.Date = from, to
+
+	for _, id := range ids {
+		var moved *models.Session
+		for _, session := range source.Sessions {
+			if session.ID == id {
+				moved = session
+				break
+			}
+		}
+		if moved == nil {
+			return fmt.Errorf("session %s not found on %s", id, from.Format("2006-01-02"))
+		}
+		source.Sessions = removeSession(source.Sessions, id)
+		target.Sessions = append(removeSession(target.Sessions, id), moved)
+	}
+
+	return s.saveDaysTogether(target, source)
+}
+
+// saveDaysTogether writes several daily files so that either all of them or none are
+// replaced. New contents go to temporary files first, which are then renamed over the
+// daily files; a failed rename restores the files already replaced.
+func (s *Storage) saveDaysTogether(days ...*models.DailySessions) error {
+	type pendingFile struct {
+		path, temp string
+		date       time.Time
+		previous   []byte // Content before the save, nil if the file did not exist
+	}
+
+	var pending []pendingFile
+	cleanup := func() {
+		for _, file := range pending {
+			os.Remove(file.temp)
+		}
+	}
+
+	for _, day := range days {
+		data, err := s.encodeDailySessions(day)
+		if err != nil {
+			cleanup()
+			return err
+		}
+
+		path := s.getFilePath(day.Date)
+		temp := filepath.Join(s.dataDir, "."+filepath.Base(path)+".tmp")
+		if err := os.WriteFile(temp, data, 0644); err != nil {
+			cleanup()
+			s.recordSave(err)
+			return fmt.Errorf("failed to write sessions file: %w", err)
+		}
+
+		previous, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			os.Remove(temp)
+			cleanup()
+			return fmt.Errorf("failed to read sessions file: %w", err)
+		}
+		pending = append(pending, pendingFile{path: path, temp: temp, date: day.Date, previous: previous})
+	}
+
+	for _, file := range pending {
+		if err := s.createBackup(file.path, file.date); err != nil {
+			// Log error but continue with save
+			fmt.Fprintf(os.Stderr, "Warning: failed to create backup: %v\n", err)
+		}
+	}
+
+	for i, file := range pending {
+		if err := os.Rename(file.temp, file.path); err != nil {
+			// Put back the files replaced so far
+			for _, done := range pending[:i] {
+				if done.previous == nil {
+					os.Remove(done.path)
+				} else {
+					os.WriteFile(done.path, done.previous, 0644)
+				}
+			}
+			cleanup()
+			s.recordSave(err)
+			return fmt.Errorf("failed to replace sessions file: %w", err)
+		}
+	}
+
+	s.recordSave(nil)
+	return nil
+}
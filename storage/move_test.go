@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMoveSessions tests sessions move between two daily files and nothing is left behind
+// when a move is refused
+func TestMoveSessions(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	yesterday := time.Date(2025, 3, 9, 0, 0, 0, 0, time.Local)
+	today := yesterday.AddDate(0, 0, 1)
+	session := func(id string, start time.Time) *models.Session {
+		return &models.Session{
+			ID:    id,
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: id},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+		}
+	}
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: today,
+		Sessions: []*models.Session{
+			session("after_midnight", today.Add(30*time.Minute)),
+			session("morning", today.Add(9*time.Hour)),
+		},
+	}))
+
+	// Sessions already on the day, unknown IDs and an unchanged day are refused
+	assert.Error(t, store.MoveSessions(today, today.Add(time.Hour), []string{"after_midnight"}))
+	assert.Error(t, store.MoveSessions(today, yesterday, []string{"missing"}))
+
+	assert.NoError(t, store.MoveSessions(today, yesterday, []string{"after_midnight"}))
+
+	source, err := store.LoadDailySessions(today)
+	assert.NoError(t, err)
+	if assert.Len(t, source.Sessions, 1) {
+		assert.Equal(t, "morning", source.Sessions[0].ID)
+	}
+
+	target, err := store.LoadDailySessions(yesterday)
+	assert.NoError(t, err)
+	if assert.Len(t, target.Sessions, 1) {
+		assert.Equal(t, "after_midnight", target.Sessions[0].ID)
+		assert.True(t, target.Sessions[0].Start.StartTime.Equal(today.Add(30*time.Minute)))
+	}
+
+	// No temporary files are left in the data directory
+	entries, err := os.ReadDir(store.GetDataDir())
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		assert.False(t, strings.HasSuffix(entry.Name(), ".tmp"), entry.Name())
+	}
+	assert.FileExists(t, filepath.Join(store.GetDataDir(), "sessions_2025-03-09.json"))
+}
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// ExportNDJSON streams all sessions to w as newline-delimited JSON, one session per line
+// with the day it belongs to. Only one day is held in memory at a time.
+// Returns the number of sessions written.
+func (s *Storage) ExportNDJSON(w io.Writer) (int, error) {
+	return s.ExportAnonymizedNDJSON(w, nil)
+}
+
+// ExportAnonymizedNDJSON streams all sessions like ExportNDJSON, with every session passed
+// through the anonymizer
+func (s *Storage) ExportAnonymizedNDJSON(w io.Writer, anonymizer *models.Anonymizer) (int, error) {
+	days, err := s.ListAvailableDays()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list available days: %w", err)
+	}
+
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+
+	count := 0
+	for _, day := range days {
+		dailySessions, err := s.LoadDailySessions(day)
+		if err != nil {
+			return count, fmt.Errorf("failed to load sessions for %s: %w", day.Format("2006-01-02"), err)
+		}
+
+		for _, session := range dailySessions.Sessions {
+			record := models.SessionRecord{Date: day.Format("2006-01-02"), Session: anonymizer.Session(session)}
+			if err := encoder.Encode(record); err != nil {
+				return count, fmt.Errorf("failed to write session %s: %w", session.ID, err)
+			}
+			count++
+		}
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return count, fmt.Errorf("failed to write export: %w", err)
+	}
+	return count, nil
+}
+
+// ImportNDJSON reads sessions written by ExportNDJSON. Consecutive lines of the same day
+// are saved together, so only one day is held in memory at a time. Existing days are
+// skipped unless overwrite is set. Returns the number of sessions imported.
+func (s *Storage) ImportNDJSON(r io.Reader, overwrite bool) (int, error) {
+	return s.importNDJSON(r, overwrite, nil)
+}
+
+// ImportNDJSONFile imports an NDJSON file like ImportNDJSON, recording progress after
+// every saved day so rerunning an interrupted import of the same file resumes where it
+// left off
+func (s *Storage) ImportNDJSONFile(inputPath string, overwrite bool) (int, error) {
+	progress, err := s.newImportProgress(inputPath, overwrite, false)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err := os.Open(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	count, err := s.importNDJSON(file, overwrite, progress)
+	if err != nil {
+		return count, err
+	}
+	return count, s.clearImportProgress()
+}
+
+// importNDJSON implements ImportNDJSON, skipping records already imported according to
+// progress and updating it after every saved day when progress is not nil
+func (s *Storage) importNDJSON(r io.Reader, overwrite bool, progress *importProgress) (int, error) {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+
+	var current *models.DailySessions
+	written := make(map[string]bool) // Days saved during this import
+	skipped := make(map[string]bool) // Existing days left alone
+	count := 0
+
+	resumeAfter := 0
+	if progress != nil {
+		resumeAfter = progress.Records
+		for _, day := range progress.Days {
+			written[day] = true
+		}
+	}
+
+	// flush saves the day being collected, appending to it if it was already saved
+	// earlier in this import
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		dateStr := current.Date.Format("2006-01-02")
+		added := len(current.Sessions)
+
+		if written[dateStr] {
+			existing, err := s.LoadDailySessions(current.Date)
+			if err != nil {
+				return fmt.Errorf("failed to load sessions for %s: %w", dateStr, err)
+			}
+			current.Sessions = append(existing.Sessions, current.Sessions...)
+		}
+		if err := s.SaveDailySessions(current); err != nil {
+			return fmt.Errorf("failed to save imported sessions for %s: %w", dateStr, err)
+		}
+		if !written[dateStr] && progress != nil {
+			progress.Days = append(progress.Days, dateStr)
+		}
+		written[dateStr] = true
+		count += added
+		current = nil
+		return nil
+	}
+
+	// checkpoint records that all records before the given line are imported
+	checkpoint := func(line int) error {
+		if progress == nil {
+			return nil
+		}
+		progress.Records = line - 1
+		return s.saveImportProgress(progress)
+	}
+
+	for line := 1; ; line++ {
+		var record models.SessionRecord
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return count, fmt.Errorf("failed to parse line %d: %w", line, err)
+		}
+		if line <= resumeAfter {
+			continue // Imported before the previous run was interrupted
+		}
+		if record.Session == nil {
+			continue
+		}
+
+		date, err := time.Parse("2006-01-02", record.Date)
+		if err != nil {
+			return count, fmt.Errorf("invalid date on line %d: %q", line, record.Date)
+		}
+
+		if current != nil && !current.Date.Equal(date) {
+			if err := flush(); err != nil {
+				return count, err
+			}
+			if err := checkpoint(line); err != nil {
+				return count, err
+			}
+		}
+
+		if current == nil {
+			if !overwrite && !written[record.Date] {
+				if skipped[record.Date] {
+					continue
+				}
+				if _, err := os.Stat(s.getFilePath(date)); err == nil {
+					skipped[record.Date] = true
+					continue // Skip existing files
+				}
+			}
+			current = &models.DailySessions{Date: date}
+		}
+		current.Sessions = append(current.Sessions, record.Session)
+	}
+
+	if err := flush(); err != nil {
+		return count, err
+	}
+	return count, nil
+}
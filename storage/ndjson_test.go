@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNDJSONRoundTrip tests streaming sessions out and back in, including days whose
+// lines are not contiguous and days that already exist
+func TestNDJSONRoundTrip(t *testing.T) {
+	source, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	newSession := func(id string, start time.Time) *models.Session {
+		return &models.Session{
+			ID:    id,
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+		}
+	}
+	assert.NoError(t, source.SaveDailySessions(&models.DailySessions{
+		Date:     day,
+		Sessions: []*models.Session{newSession("a", day.Add(9*time.Hour)), newSession("b", day.Add(13*time.Hour))},
+	}))
+	assert.NoError(t, source.SaveDailySessions(&models.DailySessions{
+		Date:     day.AddDate(0, 0, 1),
+		Sessions: []*models.Session{newSession("c", day.Add(33*time.Hour))},
+	}))
+
+	var buf bytes.Buffer
+	count, err := source.ExportNDJSON(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], `{"date":"2025-03-05","id":"a",`))
+
+	// Move session "b" after the next day so its day is revisited
+	stream := strings.Join([]string{lines[0], lines[2], lines[1]}, "\n") + "\n"
+
+	target, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	count, err = target.ImportNDJSON(strings.NewReader(stream), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	imported, err := target.LoadDailySessions(day)
+	assert.NoError(t, err)
+	assert.Len(t, imported.Sessions, 2)
+	assert.Equal(t, "b", imported.Sessions[1].ID)
+
+	// Existing days are skipped unless overwriting
+	count, err = target.ImportNDJSON(strings.NewReader(stream), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	count, err = target.ImportNDJSON(strings.NewReader(stream), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	imported, _ = target.LoadDailySessions(day)
+	assert.Len(t, imported.Sessions, 2)
+
+	_, err = target.ImportNDJSON(strings.NewReader("{not json}\n"), false)
+	assert.ErrorContains(t, err, "line 1")
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// purgeLogFileName lists what purges removed, one line per file
+const purgeLogFileName = "purge.log"
+
+// PurgeResult is what a purge removed
+type PurgeResult struct {
+	Cutoff        time.Time // Data of days before this day was removed
+	Files         []string  // Removed files, relative to the data directory
+	JournalEvents int       // Journal events of the purged days removed from the journal
+}
+
+// purgeFileDate returns the day a daily file or backup of a daily file belongs to, false
+// for any other file
+func purgeFileDate(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, "sessions_") || !strings.HasSuffix(name, ".json") || len(name) < len("sessions_2006-01-02") {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation("2006-01-02", name[len("sessions_"):len("sessions_2006-01-02")], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// PurgeTargets lists the daily files of days before the cutoff day, then their backups
+func (s *Storage) PurgeTargets(cutoff time.Time) ([]string, error) {
+	cutoff = time.Date(cutoff.Year(), cutoff.Month(), cutoff.Day(), 0, 0, 0, 0, time.Local)
+
+	var targets []string
+	for _, dir := range []string{s.dataDir, filepath.Join(s.dataDir, "backups")} {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			if date, ok := purgeFileDate(entry.Name()); ok && date.Before(cutoff) {
+				targets = append(targets, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	return targets, nil
+}
+
+// Purge securely deletes the data of days before the cutoff day: daily files and their
+// backups are overwritten and removed like WipeAll does, and the journal is rewritten
+// without the events of those days. The learned tag model is removed too, to be learned
+// again from the days kept. Every removal is appended to purge.log.
+func (s *Storage) Purge(cutoff time.Time) (*PurgeResult, error) {
+	cutoff = time.Date(cutoff.Year(), cutoff.Month(), cutoff.Day(), 0, 0, 0, 0, time.Local)
+	result := &PurgeResult{Cutoff: cutoff}
+
+	targets, err := s.PurgeTargets(cutoff)
+	if err != nil {
+		return result, err
+	}
+
+	for _, path := range targets {
+		if err := shredFile(path); err != nil {
+			return result, fmt.Errorf("failed to purge %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(s.dataDir, path)
+		if err != nil {
+			rel = path
+		}
+		result.Files = append(result.Files, filepath.ToSlash(rel))
+	}
+
+	if result.JournalEvents, err = s.purgeJournal(cutoff); err != nil {
+		return result, err
+	}
+
+	if len(result.Files) > 0 {
+		modelPath := filepath.Join(s.dataDir, tagModelFileName)
+		if _, err := os.Lstat(modelPath); err == nil {
+			if err := shredFile(modelPath); err != nil {
+				return result, fmt.Errorf("failed to purge tag model: %w", err)
+			}
+			result.Files = append(result.Files, tagModelFileName)
+		}
+	}
+
+	return result, s.logPurge(result, time.Now())
+}
+
+// purgeJournal rewrites the journal without the events of days before the cutoff. The old
+// journal is shredded. Lines that cannot be read are kept. Returns the events removed.
+func (s *Storage) purgeJournal(cutoff time.Time) (int, error) {
+	path := s.getJournalPath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	cutoffDate := cutoff.Format("2006-01-02")
+	var kept bytes.Buffer
+	removed := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if event, err := s.decodeJournalLine(line); err == nil && event.Date < cutoffDate {
+			removed++
+			continue
+		}
+		kept.Write(line)
+		kept.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to scan journal: %w", err)
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, kept.Bytes(), 0600); err != nil {
+		return 0, fmt.Errorf("failed to write purged journal: %w", err)
+	}
+	if err := shredFile(path); err != nil {
+		return 0, fmt.Errorf("failed to purge journal: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return 0, fmt.Errorf("failed to replace journal: %w", err)
+	}
+	return removed, nil
+}
+
+// logPurge appends what a purge removed to purge.log. Only file names and counts are
+// logged, never the purged data.
+func (s *Storage) logPurge(result *PurgeResult, now time.Time) error {
+	if len(result.Files) == 0 && result.JournalEvents == 0 {
+		return nil
+	}
+
+	var lines strings.Builder
+	stamp := now.Format(time.RFC3339)
+	for _, file := range result.Files {
+		fmt.Fprintf(&lines, "%s purged %s (before %s)\n", stamp, file, result.Cutoff.Format("2006-01-02"))
+	}
+	if result.JournalEvents > 0 {
+		fmt.Fprintf(&lines, "%s purged %d journal event(s) (before %s)\n", stamp, result.JournalEvents, result.Cutoff.Format("2006-01-02"))
+	}
+
+	file, err := os.OpenFile(filepath.Join(s.dataDir, purgeLogFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open purge log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(lines.String()); err != nil {
+		return fmt.Errorf("failed to write purge log: %w", err)
+	}
+	return nil
+}
+
+// ApplyRetention purges the data of days older than the configured retention_days. Returns
+// nil without a retention policy.
+func (s *Storage) ApplyRetention(now time.Time) (*PurgeResult, error) {
+	if s.config == nil || s.config.RetentionDays <= 0 {
+		return nil, nil
+	}
+	return s.Purge(s.DayOf(now).AddDate(0, 0, -s.config.RetentionDays))
+}
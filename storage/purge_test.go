@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPurge tests the daily files, backups and journal events of days before the cutoff
+// are removed and logged, while later days are kept
+func TestPurge(t *testing.T) {
+	storage, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	storage.config.JournalEnabled = true
+
+	old := time.Date(2025, 1, 10, 0, 0, 0, 0, time.Local)
+	kept := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	for _, day := range []time.Time{old, kept} {
+		session := models.NewSession(&models.TimeEntry{ID: "start", Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour), Description: "Secret project"})
+		assert.NoError(t, storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{session}}))
+		assert.NoError(t, storage.AppendJournal(models.NewJournalEvent(models.JournalStart, day, session, session.Start)))
+	}
+	backupDir := filepath.Join(storage.dataDir, "backups")
+	assert.NoError(t, os.MkdirAll(backupDir, 0755))
+	assert.NoError(t, os.WriteFile(storage.getBackupPath(old, old), []byte("{}"), 0644))
+	assert.NoError(t, os.WriteFile(storage.getBackupPath(kept, kept), []byte("{}"), 0644))
+
+	cutoff := time.Date(2025, 2, 1, 15, 0, 0, 0, time.Local)
+	targets, err := storage.PurgeTargets(cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{storage.getFilePath(old), storage.getBackupPath(old, old)}, targets)
+
+	result, err := storage.Purge(cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sessions_2025-01-10.json", "backups/sessions_2025-01-10_backup_2025-01-10_000000.json"}, result.Files)
+	assert.Equal(t, 1, result.JournalEvents)
+
+	for _, path := range targets {
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err), path)
+	}
+	_, err = os.Stat(storage.getFilePath(kept))
+	assert.NoError(t, err)
+	_, err = os.Stat(storage.getBackupPath(kept, kept))
+	assert.NoError(t, err)
+
+	events, err := storage.ReadJournal()
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "2025-03-10", events[0].Date)
+
+	log, err := os.ReadFile(filepath.Join(storage.dataDir, purgeLogFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(log), "\n"))
+	assert.Contains(t, string(log), "purged sessions_2025-01-10.json (before 2025-02-01)")
+	assert.NotContains(t, string(log), "Secret project")
+
+	// Nothing left to purge
+	result, err = storage.Purge(cutoff)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Files)
+}
+
+// TestApplyRetention tests days older than retention_days are purged and nothing is without
+// a retention policy
+func TestApplyRetention(t *testing.T) {
+	storage, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+	for _, day := range []time.Time{now.AddDate(0, 0, -31), now.AddDate(0, 0, -30)} {
+		assert.NoError(t, storage.SaveDailySessions(&models.DailySessions{Date: day}))
+	}
+
+	result, err := storage.ApplyRetention(now)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	storage.config.RetentionDays = 30
+	result, err = storage.ApplyRetention(now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sessions_2025-02-07.json"}, result.Files)
+	days, err := storage.ListAvailableDays()
+	assert.NoError(t, err)
+	assert.Len(t, days, 1)
+}
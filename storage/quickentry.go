@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// ParseQuickEntry parses a quick entry for the tracking day, honouring the configured day
+// start and custom interruption tags
+func (s *Storage) ParseQuickEntry(text string, day time.Time) (*models.QuickEntry, error) {
+	startHour := 0
+	var customTags []string
+	if s.config != nil {
+		startHour = s.config.DayStartHour
+		customTags = s.config.ActiveInterruptionTags()
+	}
+	return models.ParseQuickEntry(text, models.DayStartTime(day, startHour), customTags)
+}
+
+// AddQuickEntry adds a parsed quick entry to the sessions stored under day
+func (s *Storage) AddQuickEntry(day time.Time, entry *models.QuickEntry, now time.Time) (*models.Session, error) {
+	dailySessions, err := s.LoadDailySessions(day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily sessions: %w", err)
+	}
+	dailySessions.Date = day
+
+	session, changed, err := entry.Apply(dailySessions, now)
+	if err != nil {
+		return nil, err
+	}
+
+	journalErr := s.AppendJournal(models.NewJournalEvent(models.JournalEdit, day, session, changed))
+	if err := s.SaveDailySessions(dailySessions); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	if journalErr != nil {
+		return nil, fmt.Errorf("saved, but failed to write journal: %w", journalErr)
+	}
+
+	return session, nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAddQuickEntry tests quick entries use the configured day start and tags, and are
+// saved and journaled
+func TestAddQuickEntry(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	store.config.DayStartHour = 4
+	store.config.CustomInterruptionTags = []string{"slack"}
+	store.config.JournalEnabled = true
+
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	now := day.AddDate(0, 0, 2)
+
+	entry, err := store.ParseQuickEntry("release 23:30-2am", day)
+	assert.NoError(t, err)
+	assert.Equal(t, day.Add(26*time.Hour), entry.End, "past midnight is still the same tracking day")
+	session, err := store.AddQuickEntry(day, entry, now)
+	assert.NoError(t, err)
+
+	entry, err = store.ParseQuickEntry("interrupted by slack 0:10-0:20", day)
+	assert.NoError(t, err)
+	assert.Equal(t, models.InterruptionTag("slack"), entry.Tag)
+	_, err = store.AddQuickEntry(day, entry, now)
+	assert.NoError(t, err)
+
+	dailySessions, err := store.LoadDailySessions(day)
+	assert.NoError(t, err)
+	if assert.Len(t, dailySessions.Sessions, 1) {
+		assert.Equal(t, session.ID, dailySessions.Sessions[0].ID)
+		assert.Len(t, dailySessions.Sessions[0].Interruptions, 2)
+	}
+
+	events, err := store.ReadJournal()
+	assert.NoError(t, err)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, models.JournalEdit, events[1].Type)
+		assert.Equal(t, "2025-03-03", events[1].Date)
+		assert.Equal(t, models.EntryTypeInterruption, events[1].Entry.Type)
+	}
+
+	// Refused entries leave the day unchanged
+	entry, err = store.ParseQuickEntry("review 1-3am", day)
+	assert.NoError(t, err)
+	_, err = store.AddQuickEntry(day, entry, now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// launchStateFile keeps the date the tracker was last launched on
+const launchStateFile = "last_launch"
+
+// recapLookback is how many days back the recap looks for the last day with work,
+// and how many days before it form the average
+const recapLookback = 7
+
+// MarkLaunch records a launch on the given day and reports whether it is the first
+// launch of that day
+func (s *Storage) MarkLaunch(now time.Time) (bool, error) {
+	path := filepath.Join(s.dataDir, launchStateFile)
+	today := s.DayOf(now).Format("2006-01-02")
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read launch state: %w", err)
+	}
+	if strings.TrimSpace(string(data)) == today {
+		return false, nil
+	}
+
+	if err := os.WriteFile(path, []byte(today+"\n"), 0600); err != nil {
+		return false, fmt.Errorf("failed to write launch state: %w", err)
+	}
+	return true, nil
+}
+
+// GetRecap returns the recap of the most recent day with sessions before the given day,
+// or false when there was no work in the previous week
+func (s *Storage) GetRecap(before time.Time) (*models.DailyRecap, bool) {
+	before = s.DayOf(before)
+
+	for back := 1; back <= recapLookback; back++ {
+		day := before.AddDate(0, 0, -back)
+		stats := s.GetDetailedStatsBetween(day, day)
+		if stats.TotalSessions == 0 {
+			continue
+		}
+		stats.CalculateProductivityScore()
+
+		recap := &models.DailyRecap{Date: day, Stats: stats}
+
+		// Average over the working days of the week before, so weekends don't drag it down
+		previous := s.GetDetailedStatsBetween(day.AddDate(0, 0, -recapLookback), day.AddDate(0, 0, -1))
+		var total time.Duration
+		for _, work := range previous.DailyWorkDurations {
+			if work > 0 {
+				total += work
+				recap.AverageDays++
+			}
+		}
+		if recap.AverageDays > 0 {
+			recap.AverageWork = total / time.Duration(recap.AverageDays)
+		}
+
+		return recap, true
+	}
+
+	return nil, false
+}
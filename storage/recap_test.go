@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDailyRecap tests the first-launch marker and recapping the last working day
+func TestDailyRecap(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	monday := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local)
+	first, err := store.MarkLaunch(monday)
+	assert.NoError(t, err)
+	assert.True(t, first)
+	first, err = store.MarkLaunch(monday.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, first)
+	first, _ = store.MarkLaunch(monday.AddDate(0, 0, 1))
+	assert.True(t, first)
+
+	_, ok := store.GetRecap(monday)
+	assert.False(t, ok)
+
+	// Work on Wednesday (2h) and Thursday (4h), then Friday (3h, one call)
+	addDay := func(day time.Time, hours int, interrupted bool) {
+		start := time.Date(day.Year(), day.Month(), day.Day(), 9, 0, 0, 0, time.Local)
+		session := &models.Session{
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Duration(hours) * time.Hour)},
+		}
+		if interrupted {
+			session.Interruptions = []*models.TimeEntry{
+				{Type: models.EntryTypeInterruption, Tag: models.TagCall, StartTime: start.Add(time.Hour)},
+				{Type: models.EntryTypeReturn, StartTime: start.Add(time.Hour)},
+			}
+		}
+		assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: start, Sessions: []*models.Session{session}}))
+	}
+	addDay(monday.AddDate(0, 0, -5), 2, false)
+	addDay(monday.AddDate(0, 0, -4), 4, false)
+	addDay(monday.AddDate(0, 0, -3), 3, true)
+
+	// The weekend is skipped and Friday is compared to Wednesday and Thursday
+	recap, ok := store.GetRecap(monday)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-03-07", recap.Date.Format("2006-01-02"))
+	assert.Equal(t, 3*time.Hour, recap.Stats.TotalWorkDuration)
+	assert.Equal(t, 1, recap.Stats.InterruptionsByTag[models.TagCall])
+	assert.Greater(t, recap.Stats.ProductivityScore, 0.0)
+	assert.Equal(t, 2, recap.AverageDays)
+	assert.Equal(t, 3*time.Hour, recap.AverageWork)
+
+	change, ok := recap.WorkChange()
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, change)
+}
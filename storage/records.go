@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// recordsFileName keeps streaks, personal bests and earned achievements
+const recordsFileName = "records.json"
+
+// LoadRecords returns the records saved by the last UpdateRecords, or nil if none were saved
+func (s *Storage) LoadRecords() (*models.Records, error) {
+	data, err := os.ReadFile(filepath.Join(s.dataDir, recordsFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	var records models.Records
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse records: %w", err)
+	}
+	return &records, nil
+}
+
+// FocusGoals returns the configured daily focus goals, per weekday where set
+func (s *Storage) FocusGoals() (models.FocusGoals, error) {
+	if s.config == nil {
+		return models.NewFocusGoals(0, nil)
+	}
+
+	goals, err := models.NewFocusGoals(s.config.DailyFocusGoal, s.config.WeekdayFocusGoals)
+	if err != nil {
+		return models.FocusGoals{}, fmt.Errorf("invalid weekday_focus_goals: %w", err)
+	}
+	return goals, nil
+}
+
+// UpdateRecords recomputes records from all tracked days, saves them and returns them
+// together with the achievements earned since the previous update
+func (s *Storage) UpdateRecords(now time.Time) (*models.Records, []models.Achievement, error) {
+	days, err := s.ListAvailableDays()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list available days: %w", err)
+	}
+
+	summaries := make([]models.DaySummary, 0, len(days))
+	for _, day := range days {
+		dailySessions, err := s.LoadDailySessions(day)
+		if err != nil {
+			continue // Skip days with errors
+		}
+
+		work, _, interruptions := dailySessions.GetStats()
+		summary := models.DaySummary{
+			Date:          day,
+			Sessions:      len(dailySessions.Sessions),
+			Work:          work,
+			Interruptions: interruptions,
+		}
+		for _, session := range dailySessions.Sessions {
+			if block := session.LongestBlock(now); block > summary.LongestBlock {
+				summary.LongestBlock = block
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	goals, err := s.FocusGoals()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Today is the current tracking day, which may still be yesterday's date before the day start hour
+	records := models.ComputeRecords(summaries, goals, s.DayOf(now))
+	records.UpdatedAt = now
+
+	var fresh []models.Achievement
+	previous, err := s.LoadRecords()
+	if err != nil {
+		return nil, nil, err
+	}
+	if previous != nil {
+		fresh = records.NewAchievements(previous.Achievements)
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal records: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dataDir, recordsFileName), data, 0600); err != nil {
+		return nil, nil, fmt.Errorf("failed to write records: %w", err)
+	}
+
+	return records, fresh, nil
+}
+
+// GetQuarterReview returns the weekly focus, goal attainment and top projects of the
+// quarter containing day
+func (s *Storage) GetQuarterReview(day time.Time) (*models.QuarterReview, error) {
+	start := models.QuarterStart(day)
+	end := start.AddDate(0, 3, 0)
+
+	available, err := s.ListAvailableDays()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available days: %w", err)
+	}
+
+	days := make(map[string]*models.DailySessions)
+	for _, date := range available {
+		if date.Before(start) || !date.Before(end) {
+			continue
+		}
+		dailySessions, err := s.LoadDailySessions(date)
+		if err != nil {
+			continue // Skip days with errors
+		}
+		days[date.Format("2006-01-02")] = dailySessions
+	}
+
+	goals, err := s.FocusGoals()
+	if err != nil {
+		return nil, err
+	}
+	return models.NewQuarterReview(start, days, goals), nil
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUpdateRecords tests computing records from day files and reporting new achievements
+func TestUpdateRecords(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	store.config.DailyFocusGoal = 60
+
+	saveDay := func(start time.Time, length time.Duration) {
+		assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+			Date: start,
+			Sessions: []*models.Session{{
+				ID:    start.Format("20060102"),
+				Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+				End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(length)},
+			}},
+		}))
+	}
+
+	now := time.Date(2025, 3, 10, 18, 0, 0, 0, time.Local)
+	saveDay(time.Date(2025, 3, 9, 9, 0, 0, 0, time.Local), 2*time.Hour)
+
+	records, fresh, err := store.UpdateRecords(now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, records.FocusGoal)
+	assert.Equal(t, 1, records.CurrentStreak)
+	assert.Equal(t, 2*time.Hour, records.LongestBlock)
+	assert.Empty(t, fresh) // Nothing to compare with on the first update
+
+	saveDay(time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local), 3*time.Hour)
+	records, fresh, err = store.UpdateRecords(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, records.CurrentStreak)
+	assert.Len(t, fresh, 1)
+	assert.Equal(t, "block-180", fresh[0].ID)
+
+	saved, err := store.LoadRecords()
+	assert.NoError(t, err)
+	assert.Equal(t, records.Achievements, saved.Achievements)
+}
+
+// TestGetQuarterReview tests aggregating a quarter's day files by week and project
+func TestGetQuarterReview(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	store.config.DailyFocusGoal = 120
+
+	saveDay := func(start time.Time, length time.Duration, project string) {
+		assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+			Date: start,
+			Sessions: []*models.Session{{
+				ID:      start.Format("20060102"),
+				Start:   &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+				End:     &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(length)},
+				Project: project,
+			}},
+		}))
+	}
+
+	saveDay(time.Date(2025, 4, 1, 9, 0, 0, 0, time.Local), 3*time.Hour, "acme")
+	saveDay(time.Date(2025, 4, 2, 9, 0, 0, 0, time.Local), time.Hour, "")
+	saveDay(time.Date(2025, 4, 8, 9, 0, 0, 0, time.Local), 2*time.Hour, "acme")
+	saveDay(time.Date(2025, 3, 31, 9, 0, 0, 0, time.Local), 5*time.Hour, "acme") // Previous quarter
+
+	review, err := store.GetQuarterReview(time.Date(2025, 5, 20, 0, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Equal(t, "Q2 2025", review.Label())
+	assert.Equal(t, 6*time.Hour, review.TotalFocus)
+	assert.Equal(t, 2, review.GoalDays)
+	assert.Equal(t, 3, review.TrackedDays)
+
+	assert.Equal(t, 4*time.Hour, review.Weeks[0].Focus)
+	assert.Equal(t, 1, review.Weeks[0].GoalDays)
+	assert.Equal(t, 2*time.Hour, review.Weeks[1].Focus)
+	assert.Equal(t, []models.ProjectFocus{
+		{Project: "acme", Focus: 5 * time.Hour},
+		{Project: models.NoProject, Focus: time.Hour},
+	}, review.Projects)
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+)
+
+// remoteBackupPrefix starts the name of every archive uploaded to a remote target
+const remoteBackupPrefix = "backup-"
+
+// RemoteBackupTarget stores backup archives outside the local machine
+type RemoteBackupTarget interface {
+	// Upload stores an archive under the given name
+	Upload(name string, data []byte) error
+	// List returns the names of all stored archives
+	List() ([]string, error)
+	// Delete removes the archive with the given name
+	Delete(name string) error
+}
+
+// NewRemoteBackupTarget creates the remote backup target described by the configuration
+func NewRemoteBackupTarget(cfg *config.Config) (RemoteBackupTarget, error) {
+	if cfg.BackupRemoteURL == "" {
+		return nil, fmt.Errorf("backup_remote_url is not configured")
+	}
+
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+	prefix := strings.Trim(cfg.BackupRemotePrefix, "/")
+
+	switch cfg.BackupRemoteType {
+	case "s3":
+		if cfg.BackupRemoteBucket == "" {
+			return nil, fmt.Errorf("backup_remote_bucket is not configured")
+		}
+		region := cfg.BackupRemoteRegion
+		if region == "" {
+			region = "us-east-1"
+		}
+		return &s3Target{
+			endpoint:   strings.TrimSuffix(cfg.BackupRemoteURL, "/"),
+			bucket:     cfg.BackupRemoteBucket,
+			region:     region,
+			prefix:     prefix,
+			accessKey:  cfg.BackupRemoteUser,
+			secretKey:  cfg.BackupRemoteSecret,
+			httpClient: httpClient,
+		}, nil
+	case "webdav":
+		return &webDAVTarget{
+			baseURL:    strings.TrimSuffix(cfg.BackupRemoteURL, "/"),
+			prefix:     prefix,
+			username:   cfg.BackupRemoteUser,
+			password:   cfg.BackupRemoteSecret,
+			httpClient: httpClient,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported backup_remote_type: %q (use s3 or webdav)", cfg.BackupRemoteType)
+	}
+}
+
+// UploadBackupArchive uploads an archive of all data to the remote target, encrypted when
+// encryption is enabled, and applies the retention policy. Returns the name of the
+// uploaded archive.
+func (s *Storage) UploadBackupArchive(target RemoteBackupTarget) (string, error) {
+	data, err := s.backupArchive()
+	if err != nil {
+		return "", err
+	}
+
+	name := remoteBackupPrefix + time.Now().Format("20060102-150405") + ".json"
+	if err := target.Upload(name, data); err != nil {
+		return "", fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+
+	if err := applyRemoteRetention(target, s.config.BackupRemoteRetention); err != nil {
+		return name, fmt.Errorf("uploaded %s, but failed to apply retention: %w", name, err)
+	}
+
+	return name, nil
+}
+
+// applyRemoteRetention deletes all but the newest keep archives; keep <= 0 keeps everything
+func applyRemoteRetention(target RemoteBackupTarget, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	names, err := target.List()
+	if err != nil {
+		return err
+	}
+
+	var archives []string
+	for _, name := range names {
+		if strings.HasPrefix(name, remoteBackupPrefix) {
+			archives = append(archives, name)
+		}
+	}
+	if len(archives) <= keep {
+		return nil
+	}
+
+	// Names embed the timestamp, so lexical order is chronological
+	sort.Strings(archives)
+	for _, name := range archives[:len(archives)-keep] {
+		if err := target.Delete(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// remoteKey joins the optional prefix and an archive name with a slash
+func remoteKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "/" + name
+}
+
+// checkRemoteResponse turns unexpected HTTP statuses into errors
+func checkRemoteResponse(resp *http.Response, operation string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return fmt.Errorf("%s returned status %d", operation, resp.StatusCode)
+}
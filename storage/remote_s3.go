@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// s3Target stores archives in an S3-compatible bucket using path-style requests
+type s3Target struct {
+	endpoint   string
+	bucket     string
+	region     string
+	prefix     string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+}
+
+// s3ListResult is the subset of the ListObjectsV2 response we need
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// Upload stores an archive as an object
+func (t *s3Target) Upload(name string, data []byte) error {
+	resp, err := t.do(http.MethodPut, remoteKey(t.prefix, name), nil, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkRemoteResponse(resp, "S3 PUT")
+}
+
+// List returns the names of the objects under the prefix
+func (t *s3Target) List() ([]string, error) {
+	keyPrefix := remoteKey(t.prefix, "")
+
+	var names []string
+	continuation := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", keyPrefix)
+		if continuation != "" {
+			query.Set("continuation-token", continuation)
+		}
+
+		resp, err := t.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result s3ListResult
+		err = checkRemoteResponse(resp, "S3 list")
+		if err == nil {
+			err = xml.NewDecoder(resp.Body).Decode(&result)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list S3 objects: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			names = append(names, strings.TrimPrefix(object.Key, keyPrefix))
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+		continuation = result.NextContinuationToken
+	}
+}
+
+// Delete removes an archive object
+func (t *s3Target) Delete(name string) error {
+	resp, err := t.do(http.MethodDelete, remoteKey(t.prefix, name), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkRemoteResponse(resp, "S3 DELETE")
+}
+
+// do sends a request signed with AWS Signature Version 4
+func (t *s3Target) do(method, key string, query url.Values, body []byte) (*http.Response, error) {
+	path := "/" + t.bucket
+	if key != "" {
+		path += "/" + key
+	}
+
+	canonicalURI := s3URIEncode(path, false)
+	canonicalQuery := s3CanonicalQuery(query)
+
+	requestURL := t.endpoint + canonicalURI
+	if canonicalQuery != "" {
+		requestURL += "?" + canonicalQuery
+	}
+
+	req, err := http.NewRequest(method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	t.sign(req, canonicalURI, canonicalQuery, body, time.Now().UTC())
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call S3: %w", err)
+	}
+	return resp, nil
+}
+
+// sign adds the Signature Version 4 authorization headers to the request
+func (t *s3Target) sign(req *http.Request, canonicalURI, canonicalQuery string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := shortDate + "/" + t.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+t.secretKey), shortDate)
+	signingKey = hmacSHA256(signingKey, t.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		t.accessKey, scope, signedHeaders, signature))
+}
+
+// s3CanonicalQuery encodes query parameters sorted by name as required for signing
+func s3CanonicalQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, s3URIEncode(key, true)+"="+s3URIEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3URIEncode percent-encodes everything except unreserved characters (and '/' unless encodeSlash)
+func s3URIEncode(value string, encodeSlash bool) string {
+	var sb strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			sb.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			sb.WriteByte(b)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", b)
+		}
+	}
+	return sb.String()
+}
+
+// sha256Hex returns the hex encoded SHA-256 of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with the given key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
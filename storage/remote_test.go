@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeObjectStore records uploaded objects for the S3 and WebDAV fakes
+type fakeObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeObjectStore) keys(prefix string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TestRemoteBackupS3 tests uploading to an S3-compatible endpoint with retention
+func TestRemoteBackupS3(t *testing.T) {
+	fake := &fakeObjectStore{objects: map[string][]byte{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			fake.objects[key], _ = io.ReadAll(r.Body)
+		case http.MethodDelete:
+			delete(fake.objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			fmt.Fprint(w, "<ListBucketResult>")
+			for name := range fake.objects {
+				if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+					fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", name)
+				}
+			}
+			fmt.Fprint(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.BackupRemoteType = "s3"
+	cfg.BackupRemoteURL = server.URL
+	cfg.BackupRemoteBucket = "bucket"
+	cfg.BackupRemotePrefix = "tracker/"
+	cfg.BackupRemoteUser = "key"
+	cfg.BackupRemoteSecret = "secret"
+
+	target, err := NewRemoteBackupTarget(cfg)
+	assert.NoError(t, err)
+
+	// Older archives beyond the retention limit are removed
+	for _, name := range []string{"backup-20250101-000000.json", "backup-20250102-000000.json", "notes.txt"} {
+		assert.NoError(t, target.Upload(name, []byte("{}")))
+	}
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	store.config.BackupRemoteRetention = 2
+
+	name, err := store.UploadBackupArchive(target)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tracker/backup-20250102-000000.json", "tracker/" + name, "tracker/notes.txt"}, fake.keys("tracker/"))
+}
+
+// TestRemoteBackupWebDAV tests uploading to a WebDAV collection with retention
+func TestRemoteBackupWebDAV(t *testing.T) {
+	fake := &fakeObjectStore{objects: map[string][]byte{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		switch r.Method {
+		case "MKCOL":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case http.MethodPut:
+			fake.objects[r.URL.Path], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			delete(fake.objects, r.URL.Path)
+		case "PROPFIND":
+			w.WriteHeader(http.StatusMultiStatus)
+			fmt.Fprintf(w, `<d:multistatus xmlns:d="DAV:"><d:response><d:href>%s</d:href></d:response>`, r.URL.Path)
+			for name := range fake.objects {
+				fmt.Fprintf(w, "<d:response><d:href>%s</d:href></d:response>", name)
+			}
+			fmt.Fprint(w, "</d:multistatus>")
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.BackupRemoteType = "webdav"
+	cfg.BackupRemoteURL = server.URL + "/dav/"
+	cfg.BackupRemotePrefix = "tracker"
+	cfg.BackupRemoteUser = "user"
+	cfg.BackupRemoteSecret = "pass"
+
+	target, err := NewRemoteBackupTarget(cfg)
+	assert.NoError(t, err)
+	assert.NoError(t, target.Upload("backup-20250101-000000.json", []byte("{}")))
+
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	store.config.BackupRemoteRetention = 1
+
+	name, err := store.UploadBackupArchive(target)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/dav/tracker/" + name}, fake.keys("/dav/"))
+}
+
+// TestRemoteBackupEncrypted tests archives are encrypted when encryption is enabled and
+// can be restored with the same key
+func TestRemoteBackupEncrypted(t *testing.T) {
+	fake := &fakeObjectStore{objects: map[string][]byte{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			fake.objects[r.URL.Path], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case "PROPFIND":
+			w.WriteHeader(http.StatusMultiStatus)
+			fmt.Fprint(w, `<d:multistatus xmlns:d="DAV:"></d:multistatus>`)
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.EnableEncryption = true
+	cfg.EncryptionKey = "remote backup key"
+	cfg.BackupRemoteType = "webdav"
+	cfg.BackupRemoteURL = server.URL + "/dav/"
+	store, err := NewStorageWithConfig(cfg, t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	session := importSession("Encrypted work", date.Add(9*time.Hour), date.Add(10*time.Hour))
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: date, Sessions: []*models.Session{session}}))
+
+	target, err := NewRemoteBackupTarget(cfg)
+	assert.NoError(t, err)
+	name, err := store.UploadBackupArchive(target)
+	if !assert.NoError(t, err) {
+		return
+	}
+	uploaded := fake.objects["/dav/"+name]
+	assert.NotEmpty(t, uploaded)
+	assert.False(t, json.Valid(uploaded))
+	assert.NotContains(t, string(uploaded), "Encrypted work")
+
+	// The archive restores into a storage with the same key
+	archive := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(archive, uploaded, 0600))
+	restored, err := NewStorageWithConfig(cfg, t.TempDir())
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = restored.Import(archive, ImportOptions{})
+	assert.NoError(t, err)
+	dailySessions, err := restored.LoadDailySessions(date)
+	assert.NoError(t, err)
+	if assert.Len(t, dailySessions.Sessions, 1) {
+		assert.Equal(t, "Encrypted work", dailySessions.Sessions[0].Start.Description)
+	}
+}
+
+// TestNewRemoteBackupTargetValidation tests rejecting incomplete configuration
+func TestNewRemoteBackupTargetValidation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	_, err := NewRemoteBackupTarget(cfg)
+	assert.Error(t, err)
+
+	cfg.BackupRemoteURL = "https://example.com"
+	cfg.BackupRemoteType = "ftp"
+	_, err = NewRemoteBackupTarget(cfg)
+	assert.Error(t, err)
+
+	cfg.BackupRemoteType = "s3"
+	_, err = NewRemoteBackupTarget(cfg)
+	assert.Error(t, err)
+}
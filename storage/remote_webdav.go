@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// webDAVTarget stores archives in a WebDAV collection
+type webDAVTarget struct {
+	baseURL    string
+	prefix     string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// webDAVMultistatus is the subset of a PROPFIND response we need
+type webDAVMultistatus struct {
+	Responses []struct {
+		Href string `xml:"href"`
+	} `xml:"response"`
+}
+
+// Upload stores an archive in the collection, creating the prefix folder if needed
+func (t *webDAVTarget) Upload(name string, data []byte) error {
+	if t.prefix != "" {
+		resp, err := t.do("MKCOL", t.prefix+"/", nil, nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		// 405 means the collection already exists
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			if err := checkRemoteResponse(resp, "WebDAV MKCOL"); err != nil {
+				return err
+			}
+		}
+	}
+
+	resp, err := t.do(http.MethodPut, remoteKey(t.prefix, name), data, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkRemoteResponse(resp, "WebDAV PUT")
+}
+
+// List returns the names of the files in the collection
+func (t *webDAVTarget) List() ([]string, error) {
+	collection := remoteKey(t.prefix, "")
+	resp, err := t.do("PROPFIND", collection, nil, map[string]string{"Depth": "1"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil // Nothing uploaded yet
+	}
+	if err := checkRemoteResponse(resp, "WebDAV PROPFIND"); err != nil {
+		return nil, err
+	}
+
+	var result webDAVMultistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse WebDAV listing: %w", err)
+	}
+
+	var names []string
+	for _, response := range result.Responses {
+		href := response.Href
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+
+		// The collection itself is listed with a trailing slash
+		if strings.HasSuffix(href, "/") {
+			continue
+		}
+		names = append(names, path.Base(href))
+	}
+
+	return names, nil
+}
+
+// Delete removes an archive from the collection
+func (t *webDAVTarget) Delete(name string) error {
+	resp, err := t.do(http.MethodDelete, remoteKey(t.prefix, name), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkRemoteResponse(resp, "WebDAV DELETE")
+}
+
+// do sends an authenticated WebDAV request for a path relative to the base URL
+func (t *webDAVTarget) do(method, relativePath string, body []byte, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, t.baseURL+"/"+relativePath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if t.username != "" {
+		req.SetBasicAuth(t.username, t.password)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call WebDAV %s: %w", method, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// keyRotationFile marks a key rotation that has not finished yet
+const keyRotationFile = "key_rotation.json"
+
+// KeyRotationPending reports whether a key rotation was interrupted, leaving files
+// encrypted with the new key while the configuration still holds the old one
+func (s *Storage) KeyRotationPending() bool {
+	_, err := os.Stat(filepath.Join(s.dataDir, keyRotationFile))
+	return err == nil
+}
+
+// rotationTargets lists the encrypted files: daily files and their sync conflict copies,
+// the learned tag model and the backups. The journal is encrypted line by line and
+// rotated separately.
+func (s *Storage) rotationTargets() ([]string, error) {
+	var targets []string
+	for _, dir := range []string{s.dataDir, filepath.Join(s.dataDir, "backups")} {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+				continue
+			}
+			if strings.HasPrefix(name, "sessions_") || name == tagModelFileName {
+				targets = append(targets, filepath.Join(dir, name))
+			}
+		}
+	}
+	return targets, nil
+}
+
+// reencrypt returns data encrypted with the old key re-encrypted with the new one. Data
+// the new key decrypts was rotated by an interrupted run and is returned unchanged, false.
+// Plain JSON, such as backups from before encryption was enabled, is encrypted with the
+// new key.
+func reencrypt(data, oldKey, newKey []byte) ([]byte, bool, error) {
+	if json.Valid(data) {
+		encrypted, err := encryptWithKey(newKey, data)
+		return encrypted, err == nil, err
+	}
+	plaintext, err := decryptWithKey(oldKey, data)
+	if err != nil {
+		if _, newErr := decryptWithKey(newKey, data); newErr == nil {
+			return data, false, nil
+		}
+		return nil, false, err
+	}
+	encrypted, err := encryptWithKey(newKey, plaintext)
+	return encrypted, err == nil, err
+}
+
+// replaceFile writes data to a temporary file next to path and renames it over path
+func replaceFile(path string, data []byte) error {
+	temp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(temp, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(temp, path); err != nil {
+		os.Remove(temp)
+		return err
+	}
+	return nil
+}
+
+// RotateKey re-encrypts all encrypted data with the key of a new passphrase: daily files,
+// sync conflict copies, backups, the tag model and every journal line. Files still in
+// plain JSON are encrypted with the new key. Each file is
+// replaced in one step, and files the new key already decrypts are left alone, so running
+// it again with the same passphrase after an interruption resumes where it stopped.
+// The storage uses the new key afterwards; saving it to the configuration is up to the
+// caller, followed by FinishKeyRotation. Returns the number of files re-encrypted.
+func (s *Storage) RotateKey(passphrase string) (int, error) {
+	if !s.encryptionEnabled || s.config == nil || s.config.EncryptionKey == "" {
+		return 0, fmt.Errorf("key rotation needs enable_encryption and an encryption_key in the configuration")
+	}
+	if passphrase == "" {
+		return 0, fmt.Errorf("the new passphrase is empty")
+	}
+	if passphrase == s.config.EncryptionKey {
+		if s.KeyRotationPending() {
+			return 0, nil // Rotated and saved, only the marker is left
+		}
+		return 0, fmt.Errorf("the new passphrase is the current one")
+	}
+
+	if !s.KeyRotationPending() {
+		marker := []byte(fmt.Sprintf("{\"started\":%q}\n", time.Now().Format(time.RFC3339)))
+		if err := os.WriteFile(filepath.Join(s.dataDir, keyRotationFile), marker, 0600); err != nil {
+			return 0, fmt.Errorf("failed to record key rotation: %w", err)
+		}
+	}
+
+	oldKey, newKey := s.encryptionKey, passphraseKey(passphrase)
+	targets, err := s.rotationTargets()
+	if err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for _, path := range targets {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return rotated, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		data, changed, err := reencrypt(data, oldKey, newKey)
+		if err != nil {
+			return rotated, fmt.Errorf("failed to decrypt %s with the old or the new key: %w", path, err)
+		}
+		if !changed {
+			continue
+		}
+		if err := replaceFile(path, data); err != nil {
+			return rotated, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		rotated++
+	}
+
+	changed, err := s.rotateJournal(oldKey, newKey)
+	if err != nil {
+		return rotated, err
+	}
+	if changed {
+		rotated++
+	}
+
+	s.encryptionKey = newKey
+	s.config.EncryptionKey = passphrase
+	return rotated, nil
+}
+
+// rotateJournal re-encrypts the journal lines encrypted with the old key. Reports whether
+// the journal was rewritten.
+func (s *Storage) rotateJournal(oldKey, newKey []byte) (bool, error) {
+	path := s.getJournalPath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var rewritten bytes.Buffer
+	changed := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		encrypted, err := base64.StdEncoding.DecodeString(string(text))
+		if err == nil {
+			var lineChanged bool
+			encrypted, lineChanged, err = reencrypt(encrypted, oldKey, newKey)
+			if lineChanged {
+				text = []byte(base64.StdEncoding.EncodeToString(encrypted))
+				changed = true
+			}
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to decrypt journal line %d with the old or the new key: %w", line, err)
+		}
+		rewritten.Write(text)
+		rewritten.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to scan journal: %w", err)
+	}
+	if !changed {
+		return false, nil
+	}
+
+	if err := replaceFile(path, rewritten.Bytes()); err != nil {
+		return false, fmt.Errorf("failed to write journal: %w", err)
+	}
+	return true, nil
+}
+
+// FinishKeyRotation removes the marker of a key rotation once the new key is saved in the
+// configuration
+func (s *Storage) FinishKeyRotation() error {
+	if err := os.Remove(filepath.Join(s.dataDir, keyRotationFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove key rotation marker: %w", err)
+	}
+	return nil
+}
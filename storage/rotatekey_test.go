@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// encryptedStorage creates a storage with journal, backups and encryption with the passphrase
+func encryptedStorage(t *testing.T, dataDir, passphrase string) *Storage {
+	t.Helper()
+
+	cfg := config.DefaultConfig()
+	cfg.BackupEnabled = true
+	cfg.JournalEnabled = true
+	cfg.EnableEncryption = true
+	cfg.EncryptionKey = passphrase
+	store, err := NewStorageWithConfig(cfg, dataDir)
+	assert.NoError(t, err)
+	return store
+}
+
+// TestRotateKey tests all encrypted data is readable with the new passphrase after a
+// rotation, and that a rotation interrupted mid-way resumes
+func TestRotateKey(t *testing.T) {
+	dataDir := t.TempDir()
+	store := encryptedStorage(t, dataDir, "old passphrase")
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	session := models.NewSession(&models.TimeEntry{ID: "start", Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour), Description: "Report"})
+	session.ID = "report"
+	for i := 0; i < 2; i++ {
+		// Saving twice leaves a backup of the first save
+		assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{session}}))
+		assert.NoError(t, store.AppendJournal(models.NewJournalEvent(models.JournalStart, day, session, session.Start)))
+	}
+	assert.NoError(t, store.LearnTag("weekly report call", models.TagCall))
+
+	// An interrupted run rotated the daily file only
+	daily := store.getFilePath(day)
+	data, err := os.ReadFile(daily)
+	assert.NoError(t, err)
+	data, changed, err := reencrypt(data, store.encryptionKey, passphraseKey("new passphrase"))
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.NoError(t, os.WriteFile(daily, data, 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, keyRotationFile), []byte("{}"), 0600))
+	assert.True(t, store.KeyRotationPending())
+
+	// A different passphrase cannot read the rotated file
+	_, err = store.RotateKey("other passphrase")
+	assert.Error(t, err)
+
+	rotated, err := store.RotateKey("new passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rotated, "backup, tag model and journal")
+	assert.Equal(t, "new passphrase", store.GetConfig().EncryptionKey)
+	assert.NoError(t, store.FinishKeyRotation())
+	assert.False(t, store.KeyRotationPending())
+
+	reopened := encryptedStorage(t, dataDir, "new passphrase")
+	loaded, err := reopened.LoadDailySessions(day)
+	assert.NoError(t, err)
+	assert.Equal(t, "report", loaded.Sessions[0].ID)
+	events, err := reopened.ReadJournal()
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	suggested, ok := reopened.SuggestTag("call about the report")
+	assert.True(t, ok)
+	assert.Equal(t, models.TagCall, suggested)
+
+	backups, err := filepath.Glob(filepath.Join(dataDir, "backups", "*.json"))
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+	data, err = os.ReadFile(backups[0])
+	assert.NoError(t, err)
+	_, err = reopened.decrypt(data)
+	assert.NoError(t, err)
+
+	_, err = reopened.RotateKey("new passphrase")
+	assert.Error(t, err, "the current passphrase")
+}
+
+// TestRotateKeyPlaintextBackup tests that backups left in plain JSON from before
+// encryption was enabled are encrypted with the new key instead of failing the rotation
+func TestRotateKeyPlaintextBackup(t *testing.T) {
+	dataDir := t.TempDir()
+	store := encryptedStorage(t, dataDir, "old passphrase")
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	session := models.NewSession(&models.TimeEntry{ID: "start", Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour), Description: "Report"})
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{session}}))
+
+	backupDir := filepath.Join(dataDir, "backups")
+	assert.NoError(t, os.MkdirAll(backupDir, 0700))
+	plain := filepath.Join(backupDir, "sessions_2025-03-09_backup.json")
+	assert.NoError(t, os.WriteFile(plain, []byte(`{"date":"2025-03-09T00:00:00Z","sessions":[]}`), 0600))
+
+	rotated, err := store.RotateKey("new passphrase")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 2, rotated, "daily file and backup")
+
+	reopened := encryptedStorage(t, dataDir, "new passphrase")
+	_, err = reopened.LoadDailySessions(day)
+	assert.NoError(t, err)
+	data, err := os.ReadFile(plain)
+	assert.NoError(t, err)
+	decrypted, err := reopened.decrypt(data)
+	assert.NoError(t, err)
+	assert.Contains(t, string(decrypted), "2025-03-09")
+}
@@ -0,0 +1,11 @@
+package storage
+
+import "path/filepath"
+
+// schedulerStateFile keeps when each scheduled job last ran on this device
+const schedulerStateFile = "scheduler.json"
+
+// SchedulerStatePath returns the path of the file keeping when each scheduled job last ran
+func (s *Storage) SchedulerStatePath() string {
+	return filepath.Join(s.dataDir, schedulerStateFile)
+}
@@ -5,11 +5,14 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/lukaszraczylo/interruption-tracker/config"
@@ -24,6 +27,7 @@ type Storage struct {
 	encryptionEnabled bool
 	encryptionKey     []byte
 	config            *config.Config
+	saves             saveStatus // Outcome of saves, for health reporting
 }
 
 // NewStorage creates a new storage instance
@@ -33,6 +37,12 @@ func NewStorage(customDataDir string) (*Storage, error) {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	return NewStorageWithConfig(cfg, customDataDir)
+}
+
+// NewStorageWithConfig creates a storage instance using the given configuration instead of
+// the configuration file, e.g. the defaults in safe mode
+func NewStorageWithConfig(cfg *config.Config, customDataDir string) (*Storage, error) {
 	dataDir := cfg.DataDirectory
 	if customDataDir != "" {
 		dataDir = customDataDir
@@ -48,8 +58,7 @@ func NewStorage(customDataDir string) (*Storage, error) {
 	if cfg.EnableEncryption {
 		if cfg.EncryptionKey != "" {
 			// Use provided key
-			hash := sha256.Sum256([]byte(cfg.EncryptionKey))
-			encryptionKey = hash[:]
+			encryptionKey = passphraseKey(cfg.EncryptionKey)
 		} else {
 			// Generate a random key
 			encryptionKey = make([]byte, 32) // AES-256
@@ -79,6 +88,67 @@ func NewStorage(customDataDir string) (*Storage, error) {
 	return storage, nil
 }
 
+// passphraseKey derives the AES-256 key for an encryption passphrase
+func passphraseKey(passphrase string) []byte {
+	hash := sha256.Sum256([]byte(passphrase))
+	return hash[:]
+}
+
+// GetConfig returns the configuration the storage was created with
+func (s *Storage) GetConfig() *config.Config {
+	return s.config
+}
+
+// DayOf returns local midnight of the tracking day t belongs to, honouring the
+// configured day start hour
+func (s *Storage) DayOf(t time.Time) time.Time {
+	if s.config == nil {
+		return models.DayOf(t, 0)
+	}
+	return models.DayOf(t, s.config.DayStartHour)
+}
+
+// StatsPolicy returns how the statistics count interruptions, with the configured
+// recovery time
+func (s *Storage) StatsPolicy() models.StatsPolicy {
+	policy := models.StatsPolicy{Recovery: models.AssumedRecoveryTime}
+	if s.config == nil {
+		return policy
+	}
+	if s.config.RecoveryTime > 0 {
+		policy.Recovery = s.config.RecoveryTime.Std()
+	}
+	policy.IncludeRecovery = s.config.StatsPolicy.IncludeRecovery
+	policy.CapBySession = s.config.StatsPolicy.CapBySession
+	policy.CountActive = s.config.StatsPolicy.CountActive
+	if len(s.config.StatsPolicy.Tags) > 0 {
+		policy.Tags = make(map[models.InterruptionTag]models.TagPolicy, len(s.config.StatsPolicy.Tags))
+		for tag, tagPolicy := range s.config.StatsPolicy.Tags {
+			policy.Tags[models.InterruptionTag(strings.ToLower(strings.TrimSpace(tag)))] = models.TagPolicy{
+				NotInterruption: tagPolicy.NotInterruption,
+				NoRecovery:      tagPolicy.NoRecovery,
+				NoScorePenalty:  tagPolicy.NoScorePenalty,
+			}
+		}
+	}
+	return policy
+}
+
+// InterruptionCosts returns the cost of the interruptions in the stats per tag and in
+// total at the configured hourly rate, false without a rate
+func (s *Storage) InterruptionCosts(stats *models.DetailedStats) ([]models.InterruptionCost, models.InterruptionCost, bool) {
+	if s.config == nil || s.config.HourlyRate <= 0 {
+		return nil, models.InterruptionCost{}, false
+	}
+	costs, total := stats.InterruptionCosts(s.config.HourlyRate)
+	return costs, total, true
+}
+
+// GetDataDir returns the directory where session files are stored
+func (s *Storage) GetDataDir() string {
+	return s.dataDir
+}
+
 // getFilePath returns the file path for the given date
 func (s *Storage) getFilePath(date time.Time) string {
 	fileName := fmt.Sprintf("sessions_%s.json", date.Format("2006-01-02"))
@@ -98,8 +168,12 @@ func (s *Storage) encrypt(data []byte) ([]byte, error) {
 	if !s.encryptionEnabled {
 		return data, nil
 	}
+	return encryptWithKey(s.encryptionKey, data)
+}
 
-	block, err := aes.NewCipher(s.encryptionKey)
+// encryptWithKey encrypts the given data using AES-GCM with the key
+func encryptWithKey(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cipher: %w", err)
 	}
@@ -131,12 +205,16 @@ func (s *Storage) decrypt(data []byte) ([]byte, error) {
 	if !s.encryptionEnabled {
 		return data, nil
 	}
+	return decryptWithKey(s.encryptionKey, data)
+}
 
+// decryptWithKey decrypts the given data using AES-GCM with the key
+func decryptWithKey(key, data []byte) ([]byte, error) {
 	if len(data) < 13 { // Nonce + at least 1 byte
 		return nil, fmt.Errorf("invalid encrypted data: too short")
 	}
 
-	block, err := aes.NewCipher(s.encryptionKey)
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cipher: %w", err)
 	}
@@ -176,17 +254,107 @@ func (s *Storage) createBackup(filePath string, date time.Time) error {
 		return fmt.Errorf("failed to read file for backup: %w", err)
 	}
 
+	// Files saved before encryption was enabled are still plaintext
+	data, err = s.encryptPlaintext(data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt backup: %w", err)
+	}
+
 	// Create backup file
 	backupPath := s.getBackupPath(date, time.Now())
-	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write backup file: %w", err)
 	}
 
 	return nil
 }
 
+// encryptPlaintext encrypts data that is still plain JSON when encryption is enabled.
+// Data encrypted already is returned unchanged.
+func (s *Storage) encryptPlaintext(data []byte) ([]byte, error) {
+	if !s.encryptionEnabled || !json.Valid(data) {
+		return data, nil
+	}
+	return s.encrypt(data)
+}
+
+// EncryptBackups encrypts the backups written in plaintext, before encryption was enabled
+// or by versions that copied plaintext daily files. Each plaintext backup is replaced by an
+// encrypted copy and then securely deleted. Returns the number of backups encrypted.
+func (s *Storage) EncryptBackups() (int, error) {
+	if !s.encryptionEnabled {
+		return 0, nil
+	}
+
+	backupDir := filepath.Join(s.dataDir, "backups")
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	encrypted := 0
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(backupDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return encrypted, fmt.Errorf("failed to read backup %s: %w", entry.Name(), err)
+		}
+		if !json.Valid(data) {
+			continue // Encrypted already
+		}
+
+		data, err = s.encrypt(data)
+		if err != nil {
+			return encrypted, fmt.Errorf("failed to encrypt backup %s: %w", entry.Name(), err)
+		}
+		tempPath := path + ".tmp"
+		if err := os.WriteFile(tempPath, data, 0600); err != nil {
+			return encrypted, fmt.Errorf("failed to write encrypted backup %s: %w", entry.Name(), err)
+		}
+		if err := shredFile(path); err != nil {
+			return encrypted, fmt.Errorf("failed to delete plaintext backup %s: %w", entry.Name(), err)
+		}
+		if err := os.Rename(tempPath, path); err != nil {
+			return encrypted, fmt.Errorf("failed to replace backup %s: %w", entry.Name(), err)
+		}
+		encrypted++
+	}
+	return encrypted, nil
+}
+
 // SaveDailySessions saves daily sessions to disk
 func (s *Storage) SaveDailySessions(sessions *models.DailySessions) error {
+	data, err := s.encodeDailySessions(sessions)
+	if err != nil {
+		return err
+	}
+
+	// Create a backup before saving (if enabled)
+	filePath := s.getFilePath(sessions.Date)
+	if err := s.createBackup(filePath, sessions.Date); err != nil {
+		// Log error but continue with save
+		fmt.Fprintf(os.Stderr, "Warning: failed to create backup: %v\n", err)
+	}
+
+	// Write to file
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		s.recordSave(err)
+		return fmt.Errorf("failed to write sessions file: %w", err)
+	}
+
+	s.recordSave(nil)
+	return nil
+}
+
+// encodeDailySessions marshals sessions with the schema version and encrypts them if enabled
+func (s *Storage) encodeDailySessions(sessions *models.DailySessions) ([]byte, error) {
 	// Add schema version
 	sessionsWithSchema := struct {
 		SchemaVersion int `json:"schema_version"`
@@ -199,30 +367,18 @@ func (s *Storage) SaveDailySessions(sessions *models.DailySessions) error {
 	// Marshal the data
 	data, err := json.MarshalIndent(sessionsWithSchema, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal sessions: %w", err)
-	}
-
-	// Create a backup before saving (if enabled)
-	filePath := s.getFilePath(sessions.Date)
-	if err := s.createBackup(filePath, sessions.Date); err != nil {
-		// Log error but continue with save
-		fmt.Fprintf(os.Stderr, "Warning: failed to create backup: %v\n", err)
+		return nil, fmt.Errorf("failed to marshal sessions: %w", err)
 	}
 
 	// Encrypt if enabled
 	if s.encryptionEnabled {
 		data, err = s.encrypt(data)
 		if err != nil {
-			return fmt.Errorf("failed to encrypt sessions: %w", err)
+			return nil, fmt.Errorf("failed to encrypt sessions: %w", err)
 		}
 	}
 
-	// Write to file
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write sessions file: %w", err)
-	}
-
-	return nil
+	return data, nil
 }
 
 // LoadDailySessions loads daily sessions from disk
@@ -233,7 +389,7 @@ func (s *Storage) LoadDailySessions(date time.Time) (*models.DailySessions, erro
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		// Return empty sessions for the date
 		return &models.DailySessions{
-			Date:     date.Truncate(24 * time.Hour),
+			Date:     models.DayOf(date, 0),
 			Sessions: []*models.Session{},
 		}, nil
 	}
@@ -244,6 +400,23 @@ func (s *Storage) LoadDailySessions(date time.Time) (*models.DailySessions, erro
 		return nil, fmt.Errorf("failed to read sessions file: %w", err)
 	}
 
+	return s.decodeDailySessions(data)
+}
+
+// LoadDayFile loads the daily sessions of a daily file at any path, such as a backup or a
+// copy of a daily file from another machine
+func (s *Storage) LoadDayFile(path string) (*models.DailySessions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read day file: %w", err)
+	}
+	return s.decodeDailySessions(data)
+}
+
+// decodeDailySessions decrypts (if enabled) and parses a daily sessions file, migrating old schemas
+func (s *Storage) decodeDailySessions(data []byte) (*models.DailySessions, error) {
+	var err error
+
 	// Decrypt if enabled
 	if s.encryptionEnabled {
 		data, err = s.decrypt(data)
@@ -302,22 +475,33 @@ func (s *Storage) migrateSchema(oldVersion int, sessions *models.DailySessions)
 	return sessions, nil
 }
 
-// GetDateRange returns a range of dates for stats calculation
+// customRangeSeparator separates the start and end dates of a custom range type
+const customRangeSeparator = ".."
+
+// GetDateRange returns a range of dates for stats calculation. Besides the named ranges,
+// "YYYY-MM-DD..YYYY-MM-DD" selects the days between two dates.
 func (s *Storage) GetDateRange(rangeType string) (time.Time, time.Time, error) {
-	now := time.Now()
-	today := now.Truncate(24 * time.Hour)
+	today := s.DayOf(time.Now())
 
 	switch rangeType {
 	case "day":
 		return today, today, nil
 	case "week":
 		// Get the start of the week (Monday)
-		weekday := int(now.Weekday())
+		weekday := int(today.Weekday())
 		if weekday == 0 { // Sunday
 			weekday = 7
 		}
 		startDate := today.AddDate(0, 0, -(weekday - 1))
 		return startDate, today, nil
+	case "last-week":
+		// Monday to Sunday of the week before this one
+		weekday := int(today.Weekday())
+		if weekday == 0 { // Sunday
+			weekday = 7
+		}
+		endDate := today.AddDate(0, 0, -weekday)
+		return endDate.AddDate(0, 0, -6), endDate, nil
 	case "month":
 		startDate := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
 		return startDate, today, nil
@@ -342,10 +526,41 @@ func (s *Storage) GetDateRange(rangeType string) (time.Time, time.Time, error) {
 		}
 		return earliest, today, nil
 	default:
+		if startDate, endDate, ok, err := parseCustomRange(rangeType); ok {
+			return startDate, endDate, err
+		}
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid range type: %s", rangeType)
 	}
 }
 
+// CustomRange returns the range type covering the days from start to end inclusive
+func CustomRange(start, end time.Time) string {
+	return start.Format("2006-01-02") + customRangeSeparator + end.Format("2006-01-02")
+}
+
+// parseCustomRange parses a "YYYY-MM-DD..YYYY-MM-DD" range type. The bool reports whether
+// rangeType is a custom range at all.
+func parseCustomRange(rangeType string) (time.Time, time.Time, bool, error) {
+	startText, endText, ok := strings.Cut(rangeType, customRangeSeparator)
+	if !ok {
+		return time.Time{}, time.Time{}, false, nil
+	}
+
+	startDate, err := time.ParseInLocation("2006-01-02", startText, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, true, fmt.Errorf("invalid range start %q, use YYYY-MM-DD", startText)
+	}
+	endDate, err := time.ParseInLocation("2006-01-02", endText, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, true, fmt.Errorf("invalid range end %q, use YYYY-MM-DD", endText)
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, true, fmt.Errorf("range end %s is before start %s", endText, startText)
+	}
+
+	return startDate, endDate, true, nil
+}
+
 // GetStats returns the statistics for the given date range
 func (s *Storage) GetStats(rangeType string) (time.Duration, time.Duration, int, error) {
 	startDate, endDate, err := s.GetDateRange(rangeType)
@@ -355,6 +570,7 @@ func (s *Storage) GetStats(rangeType string) (time.Duration, time.Duration, int,
 
 	var totalWork, totalInterruption time.Duration
 	var totalInterruptionCount int
+	policy, now := s.StatsPolicy(), time.Now()
 
 	// Iterate through each day in the range
 	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
@@ -363,7 +579,7 @@ func (s *Storage) GetStats(rangeType string) (time.Duration, time.Duration, int,
 			continue // Skip days with errors
 		}
 
-		workDuration, interruptionDuration, interruptionCount := sessions.GetStats()
+		workDuration, interruptionDuration, interruptionCount := policy.DayStats(sessions, now)
 		totalWork += workDuration
 		totalInterruption += interruptionDuration
 		totalInterruptionCount += interruptionCount
@@ -379,22 +595,44 @@ func (s *Storage) GetDetailedStats(rangeType string) (*models.DetailedStats, err
 		return nil, err
 	}
 
+	return s.GetDetailedStatsBetween(startDate, endDate), nil
+}
+
+// GetDetailedStatsBetween returns detailed statistics for the given inclusive date range
+func (s *Storage) GetDetailedStatsBetween(startDate, endDate time.Time) *models.DetailedStats {
 	stats := &models.DetailedStats{
 		StartDate:                 startDate,
 		EndDate:                   endDate,
+		Policy:                    s.StatsPolicy(),
 		TotalWorkDuration:         0,
 		TotalInterruptions:        0,
 		InterruptionsByTag:        make(map[models.InterruptionTag]int),
 		InterruptionDurationByTag: make(map[models.InterruptionTag]time.Duration),
+		RecoveryByTag:             make(map[models.InterruptionTag]time.Duration),
 		DailyWorkDurations:        make(map[string]time.Duration),
 		HourlyProductivity:        make(map[int]time.Duration),
+		HourlyWorkTime:            make(map[int]time.Duration),
+		DailyLongestFocus:         make(map[string]time.Duration),
+		HourlyInterruptions:       make(map[int]int),
+		WeekdayProductivity:       make(map[time.Weekday]time.Duration),
+		DailyHourlyProductivity:   make(map[string]map[int]time.Duration),
+		DailyDeflections:          make(map[string]models.DeflectionCount),
 		LongestSession:            0,
 		AverageSessionTime:        0,
 		TotalSessions:             0,
 	}
 
-	var sessionDurations []time.Duration
+	if s.config != nil {
+		normalization := s.config.TaskNormalization
+		stats.Tasks.Rules = models.NormalizationRules{
+			CaseSensitive:  normalization.CaseSensitive,
+			StripIssueKeys: normalization.StripIssueKeys,
+			StopWords:      normalization.StopWords,
+		}
+	}
+
 	var totalDuration time.Duration
+	now := time.Now()
 
 	// Iterate through each day in the range
 	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
@@ -402,54 +640,75 @@ func (s *Storage) GetDetailedStats(rangeType string) (*models.DetailedStats, err
 		if err != nil {
 			continue // Skip days with errors
 		}
+		dateStr := d.Format("2006-01-02")
 
-		workDuration, _, _ := dailySessions.GetStats()
-		stats.DailyWorkDurations[d.Format("2006-01-02")] = workDuration
-		stats.TotalWorkDuration += workDuration
+		if deflections := dailySessions.GetDeflections(); deflections.Total() > 0 {
+			stats.DailyDeflections[dateStr] = deflections
+			stats.Deflections.Add(deflections)
+		}
 
 		// Process each session
+		var dayWork time.Duration
 		for _, session := range dailySessions.Sessions {
-			if session.Start != nil && session.End != nil {
-				sessionDuration := session.End.StartTime.Sub(session.Start.StartTime)
-
-				// Calculate pure work time (excluding interruptions)
-				interruptionTime := time.Duration(0)
-				for i := 0; i < len(session.Interruptions); i += 2 {
-					if i+1 < len(session.Interruptions) {
-						interrupt := session.Interruptions[i]
-						returnEntry := session.Interruptions[i+1]
-
-						interruptDuration := returnEntry.StartTime.Sub(interrupt.StartTime)
-						interruptionTime += interruptDuration
-
-						// Track interruption stats by tag
-						tag := interrupt.Tag
-						if tag == "" {
-							tag = models.TagOther
-						}
-
-						stats.InterruptionsByTag[tag]++
-						stats.InterruptionDurationByTag[tag] += interruptDuration
-						stats.TotalInterruptions++
-					}
-				}
+			if session.Start == nil {
+				continue
+			}
+			breakdown := stats.Policy.Breakdown(session, now)
+			dayWork += breakdown.Work
+			stats.TotalInterruptionDuration += breakdown.Interruption
+			stats.DND.AddSession(session, breakdown, now)
 
-				pureWorkTime := sessionDuration - interruptionTime
+			if block := session.LongestBlock(now); block > stats.DailyLongestFocus[dateStr] {
+				stats.DailyLongestFocus[dateStr] = block
+			}
+			for hour, work := range models.WorkByHour(session, now) {
+				stats.HourlyWorkTime[hour] += work
+			}
 
-				// Update session stats
-				sessionDurations = append(sessionDurations, pureWorkTime)
-				totalDuration += pureWorkTime
-				stats.TotalSessions++
+			// Track interruption stats by tag, without recovery
+			for _, interruption := range breakdown.Interruptions {
+				stats.HourlyInterruptions[interruption.Start.Hour()]++
+				stats.InterruptionsByTag[interruption.Tag]++
+				stats.InterruptionDurationByTag[interruption.Tag] += interruption.Away
+				stats.RecoveryByTag[interruption.Tag] += interruption.Recovery
+				stats.TotalInterruptions++
+			}
 
-				if pureWorkTime > stats.LongestSession {
-					stats.LongestSession = pureWorkTime
-				}
+			// Session metrics only cover completed sessions
+			if session.End == nil {
+				continue
+			}
+			workTime := breakdown.Work
+
+			// Update session stats
+			totalDuration += workTime
+			stats.TotalSessions++
+
+			// Estimates are compared with the work time of all work periods
+			if session.Estimate > 0 {
+				actual, _, _ := session.GetStats()
+				stats.Estimates.Add(session.Estimate, actual)
+			}
+
+			stats.Tasks.Add(session.Start.Description, workTime, len(breakdown.Interruptions))
 
-				// Track productivity by hour
-				hour := session.Start.StartTime.Hour()
-				stats.HourlyProductivity[hour] += pureWorkTime
+			if workTime > stats.LongestSession {
+				stats.LongestSession = workTime
 			}
+
+			// Track productivity by hour
+			hour := session.Start.StartTime.Hour()
+			stats.HourlyProductivity[hour] += workTime
+			stats.WeekdayProductivity[session.Start.StartTime.Weekday()] += workTime
+
+			if stats.DailyHourlyProductivity[dateStr] == nil {
+				stats.DailyHourlyProductivity[dateStr] = make(map[int]time.Duration)
+			}
+			stats.DailyHourlyProductivity[dateStr][hour] += workTime
 		}
+
+		stats.DailyWorkDurations[dateStr] = dayWork
+		stats.TotalWorkDuration += dayWork
 	}
 
 	// Calculate average session time
@@ -457,30 +716,267 @@ func (s *Storage) GetDetailedStats(rangeType string) (*models.DetailedStats, err
 		stats.AverageSessionTime = totalDuration / time.Duration(stats.TotalSessions)
 	}
 
-	return stats, nil
+	return stats
 }
 
-// ExportData exports all data to a single JSON file
-func (s *Storage) ExportData(outputPath string) error {
+// ExportMetricsCSV writes chart metrics for each day in the range as long-format
+// CSV rows (date, metric, value), suitable for plotting in external tools
+func (s *Storage) ExportMetricsCSV(w io.Writer, rangeType string) error {
+	startDate, endDate, err := s.GetDateRange(rangeType)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"date", "metric", "value"}); err != nil {
+		return fmt.Errorf("failed to write metrics header: %w", err)
+	}
+
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dateStr := d.Format("2006-01-02")
+		dayStats := s.GetDetailedStatsBetween(d, d)
+
+		// Skip days without any tracked sessions
+		if dayStats.TotalSessions == 0 && dayStats.TotalWorkDuration == 0 {
+			continue
+		}
+
+		rows := [][]string{
+			{dateStr, "focus_hours", formatMetricHours(dayStats.TotalWorkDuration)},
+			{dateStr, "sessions", fmt.Sprintf("%d", dayStats.TotalSessions)},
+			{dateStr, "interruptions", fmt.Sprintf("%d", dayStats.TotalInterruptions)},
+			{dateStr, "longest_focus_hours", formatMetricHours(dayStats.DailyLongestFocus[dateStr])},
+		}
+
+		// Hourly productivity, ordered by hour
+		hours := make([]int, 0, len(dayStats.HourlyProductivity))
+		for hour := range dayStats.HourlyProductivity {
+			hours = append(hours, hour)
+		}
+		sort.Ints(hours)
+		for _, hour := range hours {
+			rows = append(rows, []string{dateStr, fmt.Sprintf("hourly_focus_hours.%02d", hour),
+				formatMetricHours(dayStats.HourlyProductivity[hour])})
+		}
+
+		// Per-tag interruption counts and durations, ordered by tag
+		tags := make([]string, 0, len(dayStats.InterruptionsByTag))
+		for tag := range dayStats.InterruptionsByTag {
+			tags = append(tags, string(tag))
+		}
+		sort.Strings(tags)
+		for _, tag := range tags {
+			interruptionTag := models.InterruptionTag(tag)
+			rows = append(rows,
+				[]string{dateStr, "interruptions." + tag, fmt.Sprintf("%d", dayStats.InterruptionsByTag[interruptionTag])},
+				[]string{dateStr, "interruption_hours." + tag, formatMetricHours(dayStats.InterruptionDurationByTag[interruptionTag])},
+			)
+		}
+
+		if err := writer.WriteAll(rows); err != nil {
+			return fmt.Errorf("failed to write metrics for %s: %w", dateStr, err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatMetricHours formats a duration as decimal hours for metric exports
+func formatMetricHours(d time.Duration) string {
+	return fmt.Sprintf("%.4f", d.Hours())
+}
+
+// GetIssueStats groups sessions in the given date range by the issues referenced in their
+// descriptions. With jira_projects set, only Jira keys of those projects count.
+func (s *Storage) GetIssueStats(rangeType string) ([]models.IssueStats, error) {
+	startDate, endDate, err := s.GetDateRange(rangeType)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make(map[string]bool)
+	if s.config != nil {
+		for _, project := range s.config.JiraProjects {
+			projects[strings.ToUpper(strings.TrimSpace(project))] = true
+		}
+	}
+
+	statsMap := make(map[string]*models.IssueStats)
+
+	// Iterate through each day in the range
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dailySessions, err := s.LoadDailySessions(d)
+		if err != nil {
+			continue // Skip days with errors
+		}
+
+		for _, session := range dailySessions.Sessions {
+			if session.Start == nil {
+				continue
+			}
+
+			keys := models.ExtractIssueKeys(session.Start.Description)
+			if len(keys) == 0 {
+				continue
+			}
+
+			workDuration, _, interruptionCount := session.GetStats()
+			for _, key := range keys {
+				if project := models.JiraProject(key); project != "" && len(projects) > 0 && !projects[project] {
+					continue
+				}
+				stats, exists := statsMap[key]
+				if !exists {
+					stats = &models.IssueStats{Key: key}
+					statsMap[key] = stats
+				}
+
+				stats.Sessions++
+				stats.WorkDuration += workDuration
+				stats.Interruptions += interruptionCount
+			}
+		}
+	}
+
+	// Issues with the most work first
+	result := make([]models.IssueStats, 0, len(statsMap))
+	for _, stats := range statsMap {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].WorkDuration != result[j].WorkDuration {
+			return result[i].WorkDuration > result[j].WorkDuration
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	return result, nil
+}
+
+// GetRefocusStats measures time to refocus after interruptions in the given date range, by tag
+func (s *Storage) GetRefocusStats(rangeType string) ([]models.RefocusStats, error) {
+	startDate, endDate, err := s.GetDateRange(rangeType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var samples []models.RefocusSample
+
+	// Iterate through each day in the range
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dailySessions, err := s.LoadDailySessions(d)
+		if err != nil {
+			continue // Skip days with errors
+		}
+
+		for _, session := range dailySessions.Sessions {
+			samples = append(samples, session.RefocusSamples(now)...)
+		}
+	}
+
+	return models.SummarizeRefocus(samples), nil
+}
+
+// GetLikelyTag suggests the interruption tag most often used around the given time,
+// based on the interruptions recorded in the preceding 90 days
+func (s *Storage) GetLikelyTag(at time.Time) (models.InterruptionTag, bool) {
+	var interruptions []*models.TimeEntry
+
+	endDate := s.DayOf(at)
+	startDate := endDate.AddDate(0, 0, -90)
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dailySessions, err := s.LoadDailySessions(d)
+		if err != nil {
+			continue // Skip days with errors
+		}
+
+		for _, session := range dailySessions.Sessions {
+			interruptions = append(interruptions, session.Interruptions...)
+		}
+	}
+
+	return models.MostLikelyTag(interruptions, at)
+}
+
+// GetTagUsage returns usage statistics for every known interruption tag,
+// including configured custom tags that have never been used
+func (s *Storage) GetTagUsage() ([]models.TagUsage, error) {
+	usage := make(map[models.InterruptionTag]*models.TagUsage)
+
+	// Seed with built-in and configured custom tags so unused tags are reported too
+	for _, tag := range models.GetInterruptionTags() {
+		usage[tag] = &models.TagUsage{Tag: tag}
+	}
+	if s.config != nil {
+		for _, tag := range s.config.CustomInterruptionTags {
+			usage[models.InterruptionTag(tag)] = &models.TagUsage{Tag: models.InterruptionTag(tag)}
+		}
+	}
+
 	days, err := s.ListAvailableDays()
 	if err != nil {
-		return fmt.Errorf("failed to list available days: %w", err)
+		return nil, fmt.Errorf("failed to list available days: %w", err)
 	}
 
-	allData := make(map[string]*models.DailySessions)
 	for _, day := range days {
-		sessions, err := s.LoadDailySessions(day)
+		dailySessions, err := s.LoadDailySessions(day)
 		if err != nil {
-			return fmt.Errorf("failed to load sessions for %s: %w", day.Format("2006-01-02"), err)
+			continue // Skip days with errors
 		}
 
-		allData[day.Format("2006-01-02")] = sessions
+		for _, session := range dailySessions.Sessions {
+			for _, entry := range session.Interruptions {
+				if entry.Type != models.EntryTypeInterruption {
+					continue
+				}
+
+				tag := entry.Tag
+				if tag == "" {
+					tag = models.TagOther
+				}
+
+				tagUsage, exists := usage[tag]
+				if !exists {
+					tagUsage = &models.TagUsage{Tag: tag}
+					usage[tag] = tagUsage
+				}
+
+				tagUsage.Count++
+				if entry.StartTime.After(tagUsage.LastUsed) {
+					tagUsage.LastUsed = entry.StartTime
+				}
+			}
+		}
 	}
 
-	// Marshal the data
-	data, err := json.MarshalIndent(allData, "", "  ")
+	// Most used tags first, ties broken by name for a stable order
+	result := make([]models.TagUsage, 0, len(usage))
+	for _, tagUsage := range usage {
+		result = append(result, *tagUsage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Tag < result[j].Tag
+	})
+
+	return result, nil
+}
+
+// ExportData exports all data to a single JSON file
+func (s *Storage) ExportData(outputPath string) error {
+	return s.ExportAnonymizedData(outputPath, nil)
+}
+
+// ExportAnonymizedData exports all data like ExportData, with every session passed
+// through the anonymizer
+func (s *Storage) ExportAnonymizedData(outputPath string, anonymizer *models.Anonymizer) error {
+	data, err := s.exportJSON(anonymizer)
 	if err != nil {
-		return fmt.Errorf("failed to marshal export data: %w", err)
+		return err
 	}
 
 	// Write to file
@@ -491,43 +987,43 @@ func (s *Storage) ExportData(outputPath string) error {
 	return nil
 }
 
-// ImportData imports data from a JSON file
-func (s *Storage) ImportData(inputPath string, overwrite bool) error {
-	// Read the file
-	data, err := os.ReadFile(inputPath)
+// exportJSON returns all days as the JSON document written by ExportData, anonymized
+// unless anonymizer is nil
+func (s *Storage) exportJSON(anonymizer *models.Anonymizer) ([]byte, error) {
+	days, err := s.ListAvailableDays()
 	if err != nil {
-		return fmt.Errorf("failed to read import file: %w", err)
-	}
-
-	// Parse the data
-	var allData map[string]*models.DailySessions
-	if err := json.Unmarshal(data, &allData); err != nil {
-		return fmt.Errorf("failed to unmarshal import data: %w", err)
+		return nil, fmt.Errorf("failed to list available days: %w", err)
 	}
 
-	// Import each day's sessions
-	for dateStr, sessions := range allData {
-		date, err := time.Parse("2006-01-02", dateStr)
+	allData := make(map[string]*models.DailySessions)
+	for _, day := range days {
+		sessions, err := s.LoadDailySessions(day)
 		if err != nil {
-			return fmt.Errorf("invalid date format in import: %s", dateStr)
+			return nil, fmt.Errorf("failed to load sessions for %s: %w", day.Format("2006-01-02"), err)
 		}
 
-		// If not overwriting, check if file exists
-		if !overwrite {
-			filePath := s.getFilePath(date)
-			if _, err := os.Stat(filePath); err == nil {
-				continue // Skip existing files
-			}
+		for i, session := range sessions.Sessions {
+			sessions.Sessions[i] = anonymizer.Session(session)
 		}
+		allData[day.Format("2006-01-02")] = sessions
+	}
 
-		// Save the sessions
-		sessions.Date = date // Ensure date is set correctly
-		if err := s.SaveDailySessions(sessions); err != nil {
-			return fmt.Errorf("failed to save imported sessions for %s: %w", dateStr, err)
-		}
+	// Marshal the data
+	data, err := json.MarshalIndent(allData, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal export data: %w", err)
 	}
 
-	return nil
+	return data, nil
+}
+
+// ImportData imports data from a JSON file exported by this tracker, or from the CSV or
+// JSON export of Toggl Track, Clockify or ManicTime. Days are imported in date order and progress
+// is recorded, so rerunning an interrupted import of the same file resumes after the last
+// imported day.
+func (s *Storage) ImportData(inputPath string, overwrite bool) error {
+	_, err := s.Import(inputPath, ImportOptions{Overwrite: overwrite})
+	return err
 }
 
 // ListAvailableDays returns a list of days that have tracking data
@@ -647,9 +1143,33 @@ func (s *Storage) SecureDelete(date time.Time, sessionIndex int) error {
 	return s.SaveDailySessions(sessions)
 }
 
-// CreateBackupArchive creates a complete backup of all data
+// CreateBackupArchive creates a complete backup of all data, encrypted when encryption is
+// enabled
 func (s *Storage) CreateBackupArchive(outputPath string) error {
-	// For simplicity, this is just a direct copy of the export functionality
-	// In a production environment, you might want to use tar/zip compression
-	return s.ExportData(outputPath)
+	data, err := s.backupArchive()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write backup archive: %w", err)
+	}
+	return nil
+}
+
+// backupArchive returns all days as the JSON document written by ExportData, encrypted
+// when encryption is enabled so backups never hold the data in the clear. Import restores
+// encrypted archives with the same key.
+func (s *Storage) backupArchive() ([]byte, error) {
+	data, err := s.exportJSON(nil)
+	if err != nil {
+		return nil, err
+	}
+	if !s.encryptionEnabled {
+		return data, nil
+	}
+	data, err = s.encrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt backup archive: %w", err)
+	}
+	return data, nil
 }
@@ -1,11 +1,17 @@
 package storage
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/lukaszraczylo/interruption-tracker/config"
 	"github.com/lukaszraczylo/interruption-tracker/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -58,6 +64,20 @@ func (suite *StorageTestSuite) TestNewStorage() {
 	assert.Equal(suite.T(), expectedPath, storage2.dataDir)
 }
 
+// TestNewStorageWithConfig tests creating a storage from an explicit configuration
+func (suite *StorageTestSuite) TestNewStorageWithConfig() {
+	cfg := config.DefaultConfig()
+	cfg.BackupEnabled = false
+	dataDir := filepath.Join(suite.testDir, "safe")
+
+	store, err := NewStorageWithConfig(cfg, dataDir)
+	assert.NoError(suite.T(), err)
+	assert.Same(suite.T(), cfg, store.GetConfig())
+	assert.Equal(suite.T(), dataDir, store.GetDataDir())
+	assert.False(suite.T(), store.encryptionEnabled)
+	assert.NoDirExists(suite.T(), filepath.Join(dataDir, "backups"))
+}
+
 // TestGetFilePath tests file path generation
 func (suite *StorageTestSuite) TestGetFilePath() {
 	testDate := time.Date(2025, 3, 8, 0, 0, 0, 0, time.Local)
@@ -141,14 +161,29 @@ func (suite *StorageTestSuite) TestLoadNonExistentDailySessions() {
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), sessions)
 	assert.Empty(suite.T(), sessions.Sessions)
-	assert.Equal(suite.T(), testDate.Truncate(24*time.Hour), sessions.Date)
+	assert.Equal(suite.T(), testDate, sessions.Date)
+}
+
+// TestDayOf tests day boundaries with a configured day start hour
+func (suite *StorageTestSuite) TestDayOf() {
+	late := time.Date(2025, 6, 10, 2, 30, 0, 0, time.Local)
+	suite.storage.config.DayStartHour = 0
+	assert.Equal(suite.T(), time.Date(2025, 6, 10, 0, 0, 0, 0, time.Local), suite.storage.DayOf(late))
+
+	suite.storage.config.DayStartHour = 4
+	assert.Equal(suite.T(), time.Date(2025, 6, 9, 0, 0, 0, 0, time.Local), suite.storage.DayOf(late))
+	assert.Equal(suite.T(), time.Date(2025, 6, 10, 0, 0, 0, 0, time.Local), suite.storage.DayOf(late.Add(2*time.Hour)))
 }
 
 // TestGetDateRange tests date range calculations for different range types
 func (suite *StorageTestSuite) TestGetDateRange() {
 	// Store current time for consistent testing
 	now := time.Now()
-	today := now.Truncate(24 * time.Hour)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	lastSunday := today.AddDate(0, 0, -int(today.Weekday()))
+	if today.Weekday() == time.Sunday {
+		lastSunday = today.AddDate(0, 0, -7)
+	}
 
 	// Test cases
 	testCases := []struct {
@@ -172,6 +207,13 @@ func (suite *StorageTestSuite) TestGetDateRange() {
 			expectedEnd: today,
 			expectError: false,
 		},
+		{
+			name:          "Last week range",
+			rangeType:     "last-week",
+			expectedStart: lastSunday.AddDate(0, 0, -6),
+			expectedEnd:   lastSunday,
+			expectError:   false,
+		},
 		{
 			name:      "Month range",
 			rangeType: "month",
@@ -180,6 +222,23 @@ func (suite *StorageTestSuite) TestGetDateRange() {
 			expectedEnd:   today,
 			expectError:   false,
 		},
+		{
+			name:          "Custom range",
+			rangeType:     "2025-01-15..2025-03-31",
+			expectedStart: time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local),
+			expectedEnd:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.Local),
+			expectError:   false,
+		},
+		{
+			name:        "Custom range ending before start",
+			rangeType:   "2025-03-31..2025-01-15",
+			expectError: true,
+		},
+		{
+			name:        "Custom range with invalid date",
+			rangeType:   "2025-01-15..tomorrow",
+			expectError: true,
+		},
 		{
 			name:        "Invalid range",
 			rangeType:   "invalid",
@@ -217,7 +276,7 @@ func (suite *StorageTestSuite) TestGetDateRange() {
 // TestGetStats tests statistics calculation across date ranges
 func (suite *StorageTestSuite) TestGetStats() {
 	// Create test data for multiple days
-	today := time.Now().Truncate(24 * time.Hour)
+	today := models.DayOf(time.Now(), 0)
 	yesterday := today.AddDate(0, 0, -1)
 
 	// Create sessions for today
@@ -335,6 +394,373 @@ func (suite *StorageTestSuite) TestListAvailableDays() {
 	assert.True(suite.T(), dateMap["2025-03-02"])
 }
 
+// TestGetTagUsage tests aggregating interruption tag usage across days
+func (suite *StorageTestSuite) TestGetTagUsage() {
+	day1 := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+	day2 := time.Date(2025, 3, 2, 0, 0, 0, 0, time.Local)
+
+	// newSessionWithTags creates a session with one completed interruption per tag
+	newSessionWithTags := func(day time.Time, tags ...models.InterruptionTag) *models.Session {
+		session := &models.Session{
+			Start: &models.TimeEntry{ID: "start", Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour)},
+		}
+		for i, tag := range tags {
+			interruptTime := day.Add(10*time.Hour + time.Duration(i)*time.Hour)
+			session.Interruptions = append(session.Interruptions,
+				&models.TimeEntry{Type: models.EntryTypeInterruption, StartTime: interruptTime, Tag: tag},
+				&models.TimeEntry{Type: models.EntryTypeReturn, StartTime: interruptTime.Add(5 * time.Minute)},
+			)
+		}
+		return session
+	}
+
+	err := suite.storage.SaveDailySessions(&models.DailySessions{
+		Date:     day1,
+		Sessions: []*models.Session{newSessionWithTags(day1, models.TagCall, models.TagMeeting)},
+	})
+	assert.NoError(suite.T(), err)
+
+	err = suite.storage.SaveDailySessions(&models.DailySessions{
+		Date:     day2,
+		Sessions: []*models.Session{newSessionWithTags(day2, models.TagCall, "slack")},
+	})
+	assert.NoError(suite.T(), err)
+
+	usage, err := suite.storage.GetTagUsage()
+	assert.NoError(suite.T(), err)
+
+	usageMap := make(map[models.InterruptionTag]models.TagUsage)
+	for _, u := range usage {
+		usageMap[u.Tag] = u
+	}
+
+	// Most used tag comes first
+	assert.Equal(suite.T(), models.TagCall, usage[0].Tag)
+	assert.Equal(suite.T(), 2, usageMap[models.TagCall].Count)
+	assert.True(suite.T(), day2.Add(10*time.Hour).Equal(usageMap[models.TagCall].LastUsed))
+	assert.Equal(suite.T(), 1, usageMap[models.TagMeeting].Count)
+	assert.Equal(suite.T(), 1, usageMap["slack"].Count)
+
+	// Unused built-in tags are still reported
+	assert.Equal(suite.T(), 0, usageMap[models.TagSpouse].Count)
+	assert.True(suite.T(), usageMap[models.TagSpouse].LastUsed.IsZero())
+}
+
+// TestExportMetricsCSV tests exporting long-format chart metrics
+func (suite *StorageTestSuite) TestExportMetricsCSV() {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	session := &models.Session{
+		Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour)},
+		End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: day.Add(11 * time.Hour)},
+		Interruptions: []*models.TimeEntry{
+			{Type: models.EntryTypeInterruption, StartTime: day.Add(10 * time.Hour), Tag: models.TagCall},
+			{Type: models.EntryTypeReturn, StartTime: day.Add(10*time.Hour + 30*time.Minute)},
+		},
+	}
+	err := suite.storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{session}})
+	assert.NoError(suite.T(), err)
+
+	var buf bytes.Buffer
+	err = suite.storage.ExportMetricsCSV(&buf, "all")
+	assert.NoError(suite.T(), err)
+
+	output := buf.String()
+	assert.True(suite.T(), strings.HasPrefix(output, "date,metric,value\n"))
+	assert.Contains(suite.T(), output, "2025-03-03,focus_hours,1.5000\n")
+	assert.Contains(suite.T(), output, "2025-03-03,hourly_focus_hours.09,1.5000\n")
+	assert.Contains(suite.T(), output, "2025-03-03,interruptions.call,1\n")
+	assert.Contains(suite.T(), output, "2025-03-03,interruption_hours.call,0.5000\n")
+
+	// Days without data are not exported
+	assert.NotContains(suite.T(), output, "2025-03-04")
+
+	// Invalid ranges are rejected
+	assert.Error(suite.T(), suite.storage.ExportMetricsCSV(&buf, "fortnight"))
+}
+
+// TestDetailedStatsEstimates tests aggregating estimates of completed sessions
+func (suite *StorageTestSuite) TestDetailedStatsEstimates() {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	newSession := func(start, end time.Duration, estimate time.Duration) *models.Session {
+		return &models.Session{
+			Start:    &models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(start)},
+			End:      &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: day.Add(end)},
+			Estimate: estimate,
+		}
+	}
+	err := suite.storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{
+		newSession(9*time.Hour, 10*time.Hour, time.Hour),
+		newSession(10*time.Hour, 13*time.Hour, 2*time.Hour),
+		newSession(13*time.Hour, 14*time.Hour, 0), // Not estimated
+	}})
+	assert.NoError(suite.T(), err)
+
+	stats := suite.storage.GetDetailedStatsBetween(day, day)
+	assert.Equal(suite.T(), 2, stats.Estimates.Sessions)
+	assert.Equal(suite.T(), 3*time.Hour, stats.Estimates.Estimated)
+	assert.Equal(suite.T(), 4*time.Hour, stats.Estimates.Actual)
+	assert.Equal(suite.T(), 1, stats.Estimates.Accurate)
+
+	// The estimate is kept with the session
+	loaded, err := suite.storage.LoadDailySessions(day)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.Hour, loaded.Sessions[0].Estimate)
+}
+
+// TestStatsPolicy tests the configured stats policy counts interruption time the same
+// way for the basic and the detailed statistics
+func (suite *StorageTestSuite) TestStatsPolicy() {
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	cfg := suite.storage.GetConfig()
+	cfg.RecoveryTime = config.Duration(5 * time.Minute)
+	cfg.StatsPolicy = config.StatsPolicy{IncludeRecovery: true, Tags: map[string]config.TagPolicy{"Meeting": {NoRecovery: true}}}
+
+	err := suite.storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{{
+		Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour)},
+		End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: day.Add(10 * time.Hour)},
+		Interruptions: []*models.TimeEntry{
+			{Type: models.EntryTypeInterruption, Tag: models.TagCall, StartTime: day.Add(9*time.Hour + 10*time.Minute)},
+			{Type: models.EntryTypeReturn, StartTime: day.Add(9*time.Hour + 30*time.Minute)},
+		},
+	}}})
+	assert.NoError(suite.T(), err)
+
+	policy := suite.storage.StatsPolicy()
+	assert.True(suite.T(), policy.IncludeRecovery)
+	assert.Equal(suite.T(), 5*time.Minute, policy.Recovery)
+	assert.True(suite.T(), policy.TagPolicy(models.TagMeeting).NoRecovery)
+
+	work, interruption, count, err := suite.storage.GetStats(CustomRange(day, day))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 35*time.Minute, work)
+	assert.Equal(suite.T(), 25*time.Minute, interruption)
+	assert.Equal(suite.T(), 1, count)
+
+	stats := suite.storage.GetDetailedStatsBetween(day, day)
+	assert.Equal(suite.T(), policy, stats.Policy)
+	assert.Equal(suite.T(), 35*time.Minute, stats.TotalWorkDuration)
+	assert.Equal(suite.T(), 35*time.Minute, stats.LongestSession)
+	assert.Equal(suite.T(), 25*time.Minute, stats.TotalInterruptionDuration)
+	assert.Equal(suite.T(), 20*time.Minute, stats.InterruptionDurationByTag[models.TagCall])
+	assert.Equal(suite.T(), 5*time.Minute, stats.GetInterruptionBreakdown()[0].RecoveryTime)
+	assert.Equal(suite.T(), 5*time.Minute, stats.RecoveryByTag[models.TagCall])
+	assert.Equal(suite.T(), map[int]time.Duration{9: 40 * time.Minute}, stats.HourlyWorkTime)
+	assert.Equal(suite.T(), map[int]int{9: 1}, stats.HourlyInterruptions)
+}
+
+// TestDetailedStatsRecurringTasks tests grouping sessions with similar descriptions across days
+func (suite *StorageTestSuite) TestDetailedStatsRecurringTasks() {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	newSession := func(date time.Time, description string, start, end time.Duration) *models.Session {
+		return &models.Session{
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: date.Add(start), Description: description},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: date.Add(end)},
+		}
+	}
+
+	standup := newSession(day, "Standup", 9*time.Hour, 9*time.Hour+30*time.Minute)
+	standup.Interruptions = []*models.TimeEntry{
+		{Type: models.EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 10*time.Minute), Tag: models.TagCall},
+		{Type: models.EntryTypeReturn, StartTime: day.Add(9*time.Hour + 20*time.Minute)},
+	}
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{
+		standup,
+		newSession(day, "Write report", 10*time.Hour, 12*time.Hour),
+	}}))
+
+	next := day.AddDate(0, 0, 1)
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{Date: next, Sessions: []*models.Session{
+		newSession(next, "standup", 9*time.Hour, 9*time.Hour+20*time.Minute),
+	}}))
+
+	stats := suite.storage.GetDetailedStatsBetween(day, next)
+	recurring := stats.Tasks.Recurring()
+	assert.Len(suite.T(), recurring, 1)
+	assert.Equal(suite.T(), "Standup", recurring[0].Name)
+	assert.Equal(suite.T(), 2, recurring[0].Sessions)
+	assert.Equal(suite.T(), 40*time.Minute, recurring[0].WorkDuration)
+	assert.Equal(suite.T(), 1, recurring[0].Interruptions)
+}
+
+// TestDetailedStatsTaskNormalization tests that the configured normalization rules are used for grouping
+func (suite *StorageTestSuite) TestDetailedStatsTaskNormalization() {
+	day := time.Date(2025, 3, 12, 0, 0, 0, 0, time.Local)
+	suite.storage.GetConfig().TaskNormalization.StripIssueKeys = true
+
+	sessions := []*models.Session{}
+	for i, description := range []string{"Fix login bug", "fix Login Bug (PROJ-12)"} {
+		start := day.Add(time.Duration(9+i) * time.Hour)
+		sessions = append(sessions, &models.Session{
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: description},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(30 * time.Minute)},
+		})
+	}
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: sessions}))
+
+	recurring := suite.storage.GetDetailedStatsBetween(day, day).Tasks.Recurring()
+	assert.Len(suite.T(), recurring, 1)
+	assert.Equal(suite.T(), time.Hour, recurring[0].WorkDuration)
+}
+
+// TestCustomRangeStats tests stats for a custom date range
+func (suite *StorageTestSuite) TestCustomRangeStats() {
+	start := time.Date(2025, 2, 3, 0, 0, 0, 0, time.Local)
+	for offset := 0; offset < 3; offset++ {
+		day := start.AddDate(0, 0, offset)
+		assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{Date: day, Sessions: []*models.Session{{
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour)},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: day.Add(10 * time.Hour)},
+		}}}))
+	}
+
+	rangeType := CustomRange(start.AddDate(0, 0, 1), start.AddDate(0, 0, 2))
+	assert.Equal(suite.T(), "2025-02-04..2025-02-05", rangeType)
+
+	work, _, _, err := suite.storage.GetStats(rangeType)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2*time.Hour, work)
+}
+
+// TestJournalRecovery tests appending events and rebuilding daily files from the journal
+func (suite *StorageTestSuite) TestJournalRecovery() {
+	day := time.Date(2025, 3, 5, 0, 0, 0, 0, time.Local)
+
+	// Journaling is off by default
+	kept := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour), Description: "Kept"})
+	assert.NoError(suite.T(), suite.storage.AppendJournal(models.NewJournalEvent(models.JournalStart, day, kept, kept.Start)))
+	events, err := suite.storage.ReadJournal()
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), events)
+
+	suite.storage.config.JournalEnabled = true
+
+	kept.ID = "kept"
+	deleted := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(10 * time.Hour), Description: "Deleted"})
+	deleted.ID = "deleted"
+
+	assert.NoError(suite.T(), suite.storage.AppendJournal(models.NewJournalEvent(models.JournalStart, day, kept, kept.Start)))
+	assert.NoError(suite.T(), suite.storage.AppendJournal(models.NewJournalEvent(models.JournalStart, day, deleted, deleted.Start)))
+
+	kept.Start.Description = "Kept (edited)"
+	assert.NoError(suite.T(), suite.storage.AppendJournal(models.NewJournalEvent(models.JournalEdit, day, kept, nil)))
+	assert.NoError(suite.T(), suite.storage.AppendJournal(models.NewJournalEvent(models.JournalDelete, day, deleted, nil)))
+
+	// Simulate a crash: a half-written journal line and a damaged daily file
+	journal, err := os.OpenFile(filepath.Join(suite.testDir, journalFileName), os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(suite.T(), err)
+	_, err = journal.WriteString(`{"time":"2025-03-05T11:00:00Z","type":"sta`)
+	assert.NoError(suite.T(), err)
+	journal.Close()
+	assert.NoError(suite.T(), os.WriteFile(suite.storage.getFilePath(day), []byte(`{"sessions": [`), 0644))
+
+	events, err = suite.storage.ReadJournal()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), events, 4)
+	assert.Equal(suite.T(), models.JournalDelete, events[3].Type)
+	assert.Nil(suite.T(), events[3].Session)
+
+	days, err := suite.storage.RecoverFromJournal()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, days)
+
+	recovered, err := suite.storage.LoadDailySessions(day)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), recovered.Sessions, 1)
+	assert.Equal(suite.T(), "kept", recovered.Sessions[0].ID)
+	assert.Equal(suite.T(), "Kept (edited)", recovered.Sessions[0].Start.Description)
+}
+
+// TestEncryptedBackups tests backups are encrypted with the storage key, including backups
+// of daily files saved before encryption was enabled and plaintext backups left from then
+func (suite *StorageTestSuite) TestEncryptedBackups() {
+	cfg := config.DefaultConfig()
+	cfg.BackupEnabled = true
+	cfg.EnableEncryption = true
+	cfg.EncryptionKey = "backup test key"
+	dataDir := filepath.Join(suite.testDir, "encrypted")
+	store, err := NewStorageWithConfig(cfg, dataDir)
+	assert.NoError(suite.T(), err)
+
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	plaintext := []byte(`{"date":"2025-03-10T00:00:00Z","sessions":[]}`)
+	assert.NoError(suite.T(), os.WriteFile(store.getFilePath(date), plaintext, 0644))
+	leftover := filepath.Join(dataDir, "backups", "sessions_2025-03-09_backup_2025-03-09_120000.json")
+	assert.NoError(suite.T(), os.WriteFile(leftover, plaintext, 0644))
+
+	// A new backup of the plaintext daily file is encrypted
+	assert.NoError(suite.T(), store.SaveDailySessions(&models.DailySessions{Date: date}))
+	backups, err := filepath.Glob(filepath.Join(dataDir, "backups", "sessions_2025-03-10_backup_*.json"))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), backups, 1)
+	data, err := os.ReadFile(backups[0])
+	assert.NoError(suite.T(), err)
+	decrypted, err := store.decrypt(data)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), plaintext, decrypted)
+
+	// Only the plaintext backup left from before is migrated
+	encrypted, err := store.EncryptBackups()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, encrypted)
+	data, err = os.ReadFile(leftover)
+	assert.NoError(suite.T(), err)
+	decrypted, err = store.decrypt(data)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), plaintext, decrypted)
+
+	encrypted, err = store.EncryptBackups()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, encrypted)
+}
+
+// TestForEachDay tests days are visited in date order and an error stops the iteration
+func (suite *StorageTestSuite) TestForEachDay() {
+	for _, day := range []int{12, 10, 11} {
+		date := time.Date(2025, 3, day, 0, 0, 0, 0, time.Local)
+		assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{Date: date}))
+	}
+
+	var visited []string
+	err := suite.storage.ForEachDay(func(day *models.DailySessions) error {
+		visited = append(visited, day.Date.Format("2006-01-02"))
+		if len(visited) == 2 {
+			return errors.New("stop")
+		}
+		return nil
+	})
+	assert.EqualError(suite.T(), err, "stop")
+	assert.Equal(suite.T(), []string{"2025-03-10", "2025-03-11"}, visited)
+}
+
+// TestIssueStatsProjects tests only Jira keys of the configured projects count as issues
+func (suite *StorageTestSuite) TestIssueStatsProjects() {
+	now := time.Now()
+	today := suite.storage.DayOf(now)
+	sessions := []*models.Session{}
+	for i, description := range []string{"PROJ-42 login", "Convert to UTF-8", "Review owner/repo#7"} {
+		session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: today.Add(time.Duration(i) * time.Minute), Description: description})
+		session.ID = fmt.Sprintf("issue_%d", i)
+		sessions = append(sessions, session)
+	}
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{Date: today, Sessions: sessions}))
+
+	keys := func() []string {
+		stats, err := suite.storage.GetIssueStats("day")
+		assert.NoError(suite.T(), err)
+		var keys []string
+		for _, issue := range stats {
+			keys = append(keys, issue.Key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	assert.Equal(suite.T(), []string{"PROJ-42", "UTF-8", "owner/repo#7"}, keys())
+
+	suite.storage.config.JiraProjects = []string{"PROJ"}
+	defer func() { suite.storage.config.JiraProjects = nil }()
+	assert.Equal(suite.T(), []string{"PROJ-42", "owner/repo#7"}, keys())
+}
+
 // TestStorageSuite runs the test suite
 func TestStorageSuite(t *testing.T) {
 	suite.Run(t, new(StorageTestSuite))
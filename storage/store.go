@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// SessionStore loads, saves and iterates the sessions of each day
+type SessionStore interface {
+	LoadDailySessions(date time.Time) (*models.DailySessions, error)
+	SaveDailySessions(sessions *models.DailySessions) error
+	MoveSessions(from, to time.Time, ids []string) error
+	ForEachDay(fn func(day *models.DailySessions) error) error
+	AppendJournal(event *models.JournalEvent) error
+	DayOf(t time.Time) time.Time
+}
+
+// StatsStore computes statistics over the stored sessions
+type StatsStore interface {
+	GetStats(rangeType string) (time.Duration, time.Duration, int, error)
+	GetDetailedStats(rangeType string) (*models.DetailedStats, error)
+	GetDetailedStatsBetween(startDate, endDate time.Time) *models.DetailedStats
+	GetDateRange(rangeType string) (time.Time, time.Time, error)
+	StatsPolicy() models.StatsPolicy
+	InterruptionCosts(stats *models.DetailedStats) ([]models.InterruptionCost, models.InterruptionCost, bool)
+	GetRefocusStats(rangeType string) ([]models.RefocusStats, error)
+	GetIssueStats(rangeType string) ([]models.IssueStats, error)
+	GetContinuedTasks(rangeType string) ([]models.ContinuedTask, error)
+	GetQuarterReview(day time.Time) (*models.QuarterReview, error)
+	GetRecap(before time.Time) (*models.DailyRecap, bool)
+	FocusGoals() (models.FocusGoals, error)
+	UpdateRecords(now time.Time) (*models.Records, []models.Achievement, error)
+}
+
+// Store is everything the UI needs from storage. Storage implements it with JSON files in
+// the data directory; other backends and test doubles can implement it too.
+type Store interface {
+	SessionStore
+	StatsStore
+
+	GetConfig() *config.Config
+	GetDataDir() string
+	MarkLaunch(now time.Time) (bool, error)
+	ParseQuickEntry(text string, day time.Time) (*models.QuickEntry, error)
+	GetTaskSuggestions(now time.Time) (favorites, recent []models.TaskSuggestion)
+	GetTagUsage() ([]models.TagUsage, error)
+	GetLikelyTag(at time.Time) (models.InterruptionTag, bool)
+	LoadTagModel() (*models.TagModel, error)
+	LearnTag(description string, tag models.InterruptionTag) error
+}
+
+// Storage is the JSON file backend of Store
+var _ Store = (*Storage)(nil)
+
+// ForEachDay calls fn with the sessions of each tracked day in date order, skipping days
+// that cannot be read. Stops at the first error fn returns.
+func (s *Storage) ForEachDay(fn func(day *models.DailySessions) error) error {
+	days, err := s.ListAvailableDays()
+	if err != nil {
+		return err
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	for _, day := range days {
+		dailySessions, err := s.LoadDailySessions(day)
+		if err != nil {
+			continue // Skip days with errors
+		}
+		if err := fn(dailySessions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
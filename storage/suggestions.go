@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// recentTaskLookback is how many days back recent tasks are collected from
+const recentTaskLookback = 30
+
+// GetTaskSuggestions returns the favorite tasks configured as presets and the tasks of
+// sessions started in the last 30 days, most recent first
+func (s *Storage) GetTaskSuggestions(now time.Time) (favorites, recent []models.TaskSuggestion) {
+	if s.config != nil {
+		for _, preset := range s.config.TaskPresets {
+			if strings.TrimSpace(preset.Description) == "" {
+				continue
+			}
+			favorites = append(favorites, models.TaskSuggestion{
+				Description: strings.TrimSpace(preset.Description),
+				Estimate:    preset.Estimate.Std(),
+				Project:     preset.Project,
+				Favorite:    true,
+			})
+		}
+	}
+
+	var days []*models.DailySessions
+	endDate := s.DayOf(now)
+	for d := endDate.AddDate(0, 0, -recentTaskLookback); !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		dailySessions, err := s.LoadDailySessions(d)
+		if err != nil {
+			continue // Skip days with errors
+		}
+		days = append(days, dailySessions)
+	}
+
+	return favorites, models.RecentTasks(days)
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetTaskSuggestions tests presets become favorites and recent tasks come from the
+// last 30 days
+func TestGetTaskSuggestions(t *testing.T) {
+	store, err := NewStorage(t.TempDir())
+	assert.NoError(t, err)
+	store.config.TaskPresets = []config.TaskPreset{
+		{Description: "Standup", Estimate: config.Duration(15 * time.Minute), Project: "team"},
+		{Description: "  "},
+	}
+
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.Local)
+	for _, day := range []time.Time{now.AddDate(0, 0, -2), now.AddDate(0, 0, -40)} {
+		start := &models.TimeEntry{Type: models.EntryTypeStart, StartTime: day, Description: day.Format("Task 2006-01-02")}
+		assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: store.DayOf(day), Sessions: []*models.Session{models.NewSession(start)}}))
+	}
+
+	favorites, recent := store.GetTaskSuggestions(now)
+	assert.Equal(t, []models.TaskSuggestion{{Description: "Standup", Estimate: 15 * time.Minute, Project: "team", Favorite: true}}, favorites)
+	if assert.Len(t, recent, 1) {
+		assert.Equal(t, "Task 2025-03-29", recent[0].Description)
+	}
+}
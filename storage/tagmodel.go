@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// tagModelFileName keeps the words of interruption descriptions learned for each tag
+const tagModelFileName = "tag_model.json"
+
+// LoadTagModel returns the learned tag model. Without a saved model it is learned from
+// all tracked interruptions first and saved, so suggestions start from your history.
+func (s *Storage) LoadTagModel() (*models.TagModel, error) {
+	data, err := os.ReadFile(filepath.Join(s.dataDir, tagModelFileName))
+	if os.IsNotExist(err) {
+		return s.rebuildTagModel()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tag model: %w", err)
+	}
+	data, err = s.decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt tag model: %w", err)
+	}
+
+	model := models.NewTagModel()
+	if err := json.Unmarshal(data, model); err != nil {
+		return nil, fmt.Errorf("failed to parse tag model: %w", err)
+	}
+	return model, nil
+}
+
+// rebuildTagModel learns the tag model from all tracked days and saves it
+func (s *Storage) rebuildTagModel() (*models.TagModel, error) {
+	model := models.NewTagModel()
+	err := s.ForEachDay(func(day *models.DailySessions) error {
+		model.LearnSessions([]*models.DailySessions{day})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available days: %w", err)
+	}
+
+	if err := s.saveTagModel(model); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// saveTagModel writes the tag model to the data directory, encrypted like the sessions as
+// it holds words of their descriptions
+func (s *Storage) saveTagModel(model *models.TagModel) error {
+	data, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tag model: %w", err)
+	}
+	data, err = s.encrypt(data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt tag model: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.dataDir, tagModelFileName), data, 0600); err != nil {
+		return fmt.Errorf("failed to write tag model: %w", err)
+	}
+	return nil
+}
+
+// LearnTag adds an interruption description and the tag it was recorded with to the tag
+// model
+func (s *Storage) LearnTag(description string, tag models.InterruptionTag) error {
+	model, err := s.LoadTagModel()
+	if err != nil {
+		return err
+	}
+	model.Learn(description, tag)
+	return s.saveTagModel(model)
+}
+
+// SuggestTag suggests a tag for an interruption description from the learned tag model
+// and the default keywords
+func (s *Storage) SuggestTag(description string) (models.InterruptionTag, bool) {
+	model, _ := s.LoadTagModel() // Default keywords still work without a model
+	return model.Suggest(description)
+}
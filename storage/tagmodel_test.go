@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTagModel tests the tag model is learned from history on first use, then from new
+// interruptions, and persisted between loads
+func TestTagModel(t *testing.T) {
+	dataDir := t.TempDir()
+	store, err := NewStorage(dataDir)
+	assert.NoError(t, err)
+
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local)
+	session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start})
+	session.Interruptions = []*models.TimeEntry{
+		{Type: models.EntryTypeInterruption, Tag: models.TagMeeting, Description: "Design review", StartTime: start.Add(time.Hour)},
+		{Type: models.EntryTypeReturn, StartTime: start.Add(2 * time.Hour)},
+	}
+	session.SubSessions[0].Interruptions = session.Interruptions
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{Date: start, Sessions: []*models.Session{session}}))
+
+	tag, ok := store.SuggestTag("quick review of the PR")
+	assert.True(t, ok)
+	assert.Equal(t, models.TagMeeting, tag)
+
+	// Learned choices outweigh the default keywords and survive a restart
+	assert.NoError(t, store.LearnTag("zoom with the plumber", "home"))
+	assert.NoError(t, store.LearnTag("plumber zoom again", "home"))
+	store, err = NewStorage(dataDir)
+	assert.NoError(t, err)
+	tag, ok = store.SuggestTag("Zoom plumber")
+	assert.True(t, ok)
+	assert.Equal(t, models.InterruptionTag("home"), tag)
+
+	_, ok = store.SuggestTag("something unrelated")
+	assert.False(t, ok)
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// ReadExport reads the days of a file written by -export, as JSON or as NDJSON for .ndjson
+// and .jsonl files, without importing them. Days are returned oldest first.
+func ReadExport(path string) ([]*models.DailySessions, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export file: %w", err)
+	}
+	defer file.Close()
+
+	byDate := make(map[string]*models.DailySessions)
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".ndjson" || ext == ".jsonl" {
+		decoder := json.NewDecoder(bufio.NewReader(file))
+		for line := 1; ; line++ {
+			var record models.SessionRecord
+			if err := decoder.Decode(&record); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("failed to parse %s record %d: %w", path, line, err)
+			}
+			if record.Session == nil {
+				continue
+			}
+			day, exists := byDate[record.Date]
+			if !exists {
+				day = &models.DailySessions{}
+				byDate[record.Date] = day
+			}
+			day.Sessions = append(day.Sessions, record.Session)
+		}
+	} else if err := json.NewDecoder(file).Decode(&byDate); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	days := make([]*models.DailySessions, 0, len(byDate))
+	for dateStr, day := range byDate {
+		date, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q in %s", dateStr, path)
+		}
+		if day == nil {
+			continue
+		}
+		day.Date = date
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date.Before(days[j].Date) })
+
+	return days, nil
+}
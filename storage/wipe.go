@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"archive/zip"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// takeoutSessionsName is the archive entry holding all sessions in readable JSON
+const takeoutSessionsName = "sessions.json"
+
+// isWithin reports whether path is dir or lies below it
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// absPath returns path as a cleaned absolute path, or the path as given when that fails
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// ExportTakeout writes a zip archive of all personal data: every session as readable JSON
+// (decrypted when encryption is enabled), every file of the data directory except the git
+// repository, and the configuration file at configPath when it lies outside the data
+// directory. The archive must be written outside the data directory.
+func (s *Storage) ExportTakeout(outputPath, configPath string) error {
+	dataDir := absPath(s.dataDir)
+	if isWithin(absPath(outputPath), dataDir) {
+		return fmt.Errorf("takeout archive must be written outside the data directory %s", dataDir)
+	}
+
+	sessions, err := s.exportJSON(nil)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create takeout archive: %w", err)
+	}
+	archive := zip.NewWriter(file)
+
+	err = s.writeTakeout(archive, sessions, configPath)
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write takeout archive: %w", err)
+	}
+	return nil
+}
+
+// writeTakeout adds the takeout entries to the archive
+func (s *Storage) writeTakeout(archive *zip.Writer, sessions []byte, configPath string) error {
+	entry, err := archive.Create(takeoutSessionsName)
+	if err != nil {
+		return err
+	}
+	if _, err := entry.Write(sessions); err != nil {
+		return err
+	}
+
+	err = filepath.WalkDir(s.dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// The git history duplicates the files and lives on the remote as well
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dataDir, path)
+		if err != nil {
+			return err
+		}
+		return addFileToArchive(archive, path, "data/"+filepath.ToSlash(rel))
+	})
+	if err != nil {
+		return err
+	}
+
+	if configPath == "" || isWithin(absPath(configPath), absPath(s.dataDir)) {
+		return nil
+	}
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil
+	}
+	return addFileToArchive(archive, configPath, "config/"+filepath.Base(configPath))
+}
+
+// addFileToArchive copies the file at path into the archive under name
+func addFileToArchive(archive *zip.Writer, path, name string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+
+	entry, err := archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, source)
+	return err
+}
+
+// WipeTargets lists the files WipeAll deletes: every file of the data directory, including
+// backups, the journal, records, sync state and the git repository, followed by the
+// configuration file at configPath when it exists outside the data directory
+func (s *Storage) WipeTargets(configPath string) ([]string, error) {
+	var targets []string
+	err := filepath.WalkDir(s.dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			targets = append(targets, path)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to list data directory: %w", err)
+	}
+
+	if configPath != "" && !isWithin(absPath(configPath), absPath(s.dataDir)) {
+		if _, err := os.Lstat(configPath); err == nil {
+			targets = append(targets, configPath)
+		}
+	}
+	return targets, nil
+}
+
+// WipeAll securely deletes all data: each file listed by WipeTargets is overwritten with
+// random bytes and synced before it is removed, then the data directory itself is removed.
+// It refuses to wipe the home directory or a filesystem root. Returns the number of files
+// deleted.
+func (s *Storage) WipeAll(configPath string) (int, error) {
+	dataDir := absPath(s.dataDir)
+	if filepath.Dir(dataDir) == dataDir {
+		return 0, fmt.Errorf("refusing to wipe filesystem root %s", dataDir)
+	}
+	if home, err := os.UserHomeDir(); err == nil && absPath(home) == dataDir {
+		return 0, fmt.Errorf("refusing to wipe home directory %s", dataDir)
+	}
+
+	targets, err := s.WipeTargets(configPath)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, path := range targets {
+		if err := shredFile(path); err != nil {
+			return i, fmt.Errorf("failed to wipe %s: %w", path, err)
+		}
+	}
+
+	if err := os.RemoveAll(s.dataDir); err != nil {
+		return len(targets), fmt.Errorf("failed to remove data directory: %w", err)
+	}
+	return len(targets), nil
+}
+
+// shredFile overwrites a regular file with random bytes, syncs it and removes it.
+// Symlinks and other special files are removed without following them.
+func shredFile(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.Mode().IsRegular() && info.Size() > 0 {
+		// Git objects are read-only, make the file writable to overwrite it
+		if err := os.Chmod(path, info.Mode().Perm()|0200); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		_, err = io.CopyN(file, rand.Reader, info.Size())
+		if err == nil {
+			err = file.Sync()
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.Remove(path)
+}
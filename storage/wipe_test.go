@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWipeTestStorage returns a storage with a saved day, a backup, a journal and a
+// separate configuration file
+func newWipeTestStorage(t *testing.T) (*Storage, string) {
+	dataDir := t.TempDir()
+	store, err := NewStorage(dataDir)
+	assert.NoError(t, err)
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	start := day.Add(9 * time.Hour)
+	assert.NoError(t, store.SaveDailySessions(&models.DailySessions{
+		Date: day,
+		Sessions: []*models.Session{{
+			ID:    "sess_1",
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: "Quarterly planning"},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+		}},
+	}))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dataDir, "backups"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, "backups", "sessions_2025-03-10_backup_2025-03-10_100000.json"), []byte("{}"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dataDir, journalFileName), []byte("{}\n"), 0600))
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(configPath, []byte(`{"toggl_token":"secret"}`), 0600))
+
+	return store, configPath
+}
+
+// TestExportTakeout tests the takeout archive contains readable sessions, the data files
+// and the configuration
+func TestExportTakeout(t *testing.T) {
+	store, configPath := newWipeTestStorage(t)
+
+	// The archive may not end up in the data directory that is about to be wiped
+	assert.Error(t, store.ExportTakeout(filepath.Join(store.GetDataDir(), "takeout.zip"), configPath))
+
+	archivePath := filepath.Join(t.TempDir(), "takeout.zip")
+	assert.NoError(t, store.ExportTakeout(archivePath, configPath))
+
+	archive, err := zip.OpenReader(archivePath)
+	assert.NoError(t, err)
+	defer archive.Close()
+
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"sessions.json",
+		"data/sessions_2025-03-10.json",
+		"data/backups/sessions_2025-03-10_backup_2025-03-10_100000.json",
+		"data/journal.jsonl",
+		"config/config.json",
+	}, names)
+
+	sessions, err := archive.Open("sessions.json")
+	assert.NoError(t, err)
+	defer sessions.Close()
+	content, err := io.ReadAll(sessions)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Quarterly planning")
+}
+
+// TestWipeAll tests every data file, the data directory and the configuration are removed
+func TestWipeAll(t *testing.T) {
+	store, configPath := newWipeTestStorage(t)
+
+	targets, err := store.WipeTargets(configPath)
+	assert.NoError(t, err)
+	assert.Len(t, targets, 4)
+	assert.Equal(t, configPath, targets[len(targets)-1])
+
+	wiped, err := store.WipeAll(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, wiped)
+
+	_, err = os.Stat(store.GetDataDir())
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(configPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestWipeAllRefusesHome tests the home directory is never wiped
+func TestWipeAllRefusesHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	store := &Storage{dataDir: home}
+	_, err := store.WipeAll("")
+	assert.Error(t, err)
+	_, err = os.Stat(home)
+	assert.NoError(t, err)
+}
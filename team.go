@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// runTeam combines teammates' export files into a team report
+func runTeam(store *storage.Storage, args []string) {
+	teamFlags := flag.NewFlagSet("team", flag.ExitOnError)
+	rangeType := teamFlags.String("range", "all", "Range of days (day, week, month, quarter, year, all or YYYY-MM-DD..YYYY-MM-DD)")
+	format := teamFlags.String("format", "text", "Output format (text, json)")
+	teamFlags.Parse(args)
+
+	if teamFlags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: interruption-tracker team [--range=RANGE] [--format=text|json] [USER=]EXPORT...")
+		os.Exit(2)
+	}
+
+	members, err := readTeamExports(teamFlags.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading exports: %v\n", err)
+		os.Exit(1)
+	}
+
+	var start, end time.Time
+	if *rangeType != "all" {
+		if start, end, err = store.GetDateRange(*rangeType); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	if err := writeTeamReport(os.Stdout, models.CombineTeam(members, start, end), *format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing team report: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// readTeamExports reads one export per teammate, given as USER=PATH or just PATH, in
+// which case the file name without extension names the user
+func readTeamExports(args []string) ([]models.MemberDays, error) {
+	var members []models.MemberDays
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		user, path, named := strings.Cut(arg, "=")
+		if !named {
+			path = arg
+			user = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if seen[user] {
+			return nil, fmt.Errorf("user %q is given twice, name exports with USER=PATH", user)
+		}
+		seen[user] = true
+
+		days, err := storage.ReadExport(path)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, models.MemberDays{User: user, Days: days})
+	}
+	return members, nil
+}
+
+// writeTeamReport writes the team statistics as text or JSON
+func writeTeamReport(w io.Writer, team *models.TeamStats, format string) error {
+	switch format {
+	case "", "text":
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(team); err != nil {
+			return fmt.Errorf("failed to encode team stats: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	if team.StartDate.IsZero() {
+		fmt.Fprintln(w, "No sessions in the exports for this range.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "Team report for %d member(s) (%s to %s)\n", len(team.Members),
+		team.StartDate.Format("2006-01-02"), team.EndDate.Format("2006-01-02"))
+	fmt.Fprintln(w, strings.Repeat("-", 70))
+	fmt.Fprintf(w, "Total work time:         %s\n", formatDuration(team.WorkDuration))
+	fmt.Fprintf(w, "Total interruption time: %s\n", formatDuration(team.InterruptionDuration))
+	fmt.Fprintf(w, "Total interruptions:     %d\n", team.Interruptions)
+	fmt.Fprintf(w, "Interruption load:       %.1f%%\n", team.InterruptionLoad())
+	fmt.Fprintf(w, "Total meeting time:      %s\n", formatDuration(team.MeetingDuration))
+
+	fmt.Fprintln(w, "\nBy member:")
+	fmt.Fprintln(w, strings.Repeat("-", 70))
+	fmt.Fprintf(w, "%-15s %-6s %-12s %-14s %-10s %s\n", "User", "Days", "Work", "Interrupted", "Count", "Meetings")
+	for _, member := range team.Members {
+		fmt.Fprintf(w, "%-15s %-6d %-12s %-14s %-10d %s\n", member.User, member.Days,
+			formatDuration(member.WorkDuration), formatDuration(member.InterruptionDuration),
+			member.Interruptions, formatDuration(member.MeetingDuration))
+	}
+
+	if len(team.ByTag) > 0 {
+		fmt.Fprintln(w, "\nBy interruption type:")
+		fmt.Fprintln(w, strings.Repeat("-", 70))
+		tags := make([]models.InterruptionTag, 0, len(team.ByTag))
+		for tag := range team.ByTag {
+			tags = append(tags, tag)
+		}
+		sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+		for _, tag := range tags {
+			fmt.Fprintf(w, "%-15s %-6d %s\n", tag, team.ByTag[tag].Count, formatDuration(team.ByTag[tag].Duration))
+		}
+	}
+
+	if team.MeetingDuration > 0 {
+		fmt.Fprintln(w, "\nMeeting time by member:")
+		fmt.Fprintln(w, strings.Repeat("-", 70))
+		for _, member := range team.Members {
+			share := float64(member.MeetingDuration) / float64(team.MeetingDuration) * 100
+			fmt.Fprintf(w, "%-15s %-12s %5.1f%%\n", member.User, formatDuration(member.MeetingDuration), share)
+		}
+
+		fmt.Fprintln(w, "\nMeeting time by weekday:")
+		fmt.Fprintln(w, strings.Repeat("-", 70))
+		for day := time.Monday; ; day = (day + 1) % 7 {
+			if duration := team.MeetingsByWeekday[day.String()]; duration > 0 {
+				fmt.Fprintf(w, "%-15s %s\n", day, formatDuration(duration))
+			}
+			if day == time.Sunday {
+				break
+			}
+		}
+
+		fmt.Fprintln(w, "\nMeeting time by hour:")
+		fmt.Fprintln(w, strings.Repeat("-", 70))
+		for hour := 0; hour < 24; hour++ {
+			if duration := team.MeetingsByHour[hour]; duration > 0 {
+				fmt.Fprintf(w, "%02d:00           %s\n", hour, formatDuration(duration))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTeamReport tests combining a JSON and an NDJSON export into one team report
+func TestTeamReport(t *testing.T) {
+	store := fixtureStorage(t)
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "alice.json")
+	assert.NoError(t, store.ExportData(jsonPath))
+	ndjsonPath := filepath.Join(dir, "export.ndjson")
+	file, err := os.Create(ndjsonPath)
+	assert.NoError(t, err)
+	_, err = store.ExportNDJSON(file)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	_, err = readTeamExports([]string{jsonPath, "alice=" + ndjsonPath})
+	assert.Error(t, err, "the same user twice")
+
+	members, err := readTeamExports([]string{jsonPath, "bob=" + ndjsonPath})
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", members[0].User)
+	assert.Equal(t, "bob", members[1].User)
+
+	team := models.CombineTeam(members, time.Time{}, time.Time{})
+	expected, err := store.GetDetailedStats("all")
+	assert.NoError(t, err)
+	assert.Equal(t, 2*expected.TotalWorkDuration, team.WorkDuration)
+	assert.Equal(t, team.Members[0].WorkDuration, team.Members[1].WorkDuration)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeTeamReport(&buf, team, "text"))
+	assert.Contains(t, buf.String(), "Team report for 2 member(s) (2025-03-03 to 2025-03-04)")
+	assert.Contains(t, buf.String(), "Meeting time by member:")
+	assert.Contains(t, buf.String(), " 50.0%")
+
+	assert.Error(t, writeTeamReport(&buf, team, "xml"))
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/rivo/tview"
+)
+
+// accessibleMode replaces color-only information with text: a state marker in the header,
+// letters in the session pattern and timelines listed as spans. Set with the color theme
+// before any view is created.
+var accessibleMode bool
+
+// monochromeTheme is the color theme drawing without colors
+const monochromeTheme = "monochrome"
+
+// monochromeStyles are the styles of the "monochrome" color theme
+var monochromeStyles = tview.Theme{
+	PrimitiveBackgroundColor:    tcell.ColorDefault,
+	ContrastBackgroundColor:     tcell.ColorDefault,
+	MoreContrastBackgroundColor: tcell.ColorDefault,
+	BorderColor:                 tcell.ColorDefault,
+	TitleColor:                  tcell.ColorDefault,
+	GraphicsColor:               tcell.ColorDefault,
+	PrimaryTextColor:            tcell.ColorDefault,
+	SecondaryTextColor:          tcell.ColorDefault,
+	TertiaryTextColor:           tcell.ColorDefault,
+	InverseTextColor:            tcell.ColorDefault,
+	ContrastSecondaryTextColor:  tcell.ColorDefault,
+}
+
+// isMonochrome reports whether the theme draws without colors, as it does for any theme
+// when the NO_COLOR environment variable is set
+func isMonochrome(theme string) bool {
+	return theme == monochromeTheme || os.Getenv("NO_COLOR") != ""
+}
+
+// applyAccessibility applies the accessibility settings of the configuration
+func applyAccessibility(cfg *config.Config, screen *monochromeScreen) {
+	accessibleMode = cfg.AccessibleMode
+	if screen != nil {
+		screen.enabled = isMonochrome(cfg.ColorTheme)
+	}
+}
+
+// monochromeScreen draws without colors when enabled, cells with a background color are
+// drawn in reverse video so selections and highlights stay visible
+type monochromeScreen struct {
+	tcell.Screen
+	enabled bool
+}
+
+// monochromeStyle drops the colors of a style, keeping its attributes
+func monochromeStyle(style tcell.Style) tcell.Style {
+	_, background, attributes := style.Decompose()
+	plain := tcell.StyleDefault.Attributes(attributes)
+	if background != tcell.ColorDefault && background != tcell.ColorBlack {
+		plain = plain.Reverse(true)
+	}
+	return plain
+}
+
+// SetContent sets the contents of a cell, without colors when enabled
+func (s *monochromeScreen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	if s.enabled {
+		style = monochromeStyle(style)
+	}
+	s.Screen.SetContent(x, y, primary, combining, style)
+}
+
+// SetCell sets the contents of a cell, without colors when enabled
+func (s *monochromeScreen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	if len(ch) == 0 {
+		s.SetContent(x, y, ' ', nil, style)
+		return
+	}
+	s.SetContent(x, y, ch[0], ch[1:], style)
+}
+
+// Fill fills the screen, without colors when enabled
+func (s *monochromeScreen) Fill(r rune, style tcell.Style) {
+	if s.enabled {
+		style = monochromeStyle(style)
+	}
+	s.Screen.Fill(r, style)
+}
+
+// SetStyle sets the default style, without colors when enabled
+func (s *monochromeScreen) SetStyle(style tcell.Style) {
+	if s.enabled {
+		style = monochromeStyle(style)
+	}
+	s.Screen.SetStyle(style)
+}
+
+// sessionState describes what the active session is doing, for the header in accessible mode
+func (ui *TimerUI) sessionState(now time.Time) string {
+	session := ui.activeSession
+	switch {
+	case session == nil:
+		return messages.T("state.idle")
+	case len(session.Interruptions)%2 != 0:
+		return messages.T("state.interrupted")
+	case session.InRecovery(now, ui.recoveryTime()):
+		return messages.T("state.recovery")
+	}
+	return messages.T("state.working")
+}
+
+// slotLabels are the names of timeline slots in linear timelines
+var slotLabels = map[int]string{
+	slotWorking:     "timeline.working",
+	slotInterrupted: "timeline.interrupted",
+	slotRecovery:    "timeline.recovery",
+	slotContinues:   "timeline.continues",
+	slotBackToWork:  "timeline.back_to_work",
+}
+
+// linearTimeline lists the slots of a timeline as spans of the same activity, one per line
+// such as "09:00-09:40 Working", for screen readers. Slots without activity are left out.
+func linearTimeline(activities []int, start time.Time, slot time.Duration) string {
+	var sb strings.Builder
+	for i := 0; i < len(activities); {
+		j := i
+		for j < len(activities) && activities[j] == activities[i] {
+			j++
+		}
+		if label, ok := slotLabels[activities[i]]; ok {
+			from := start.Add(slot * time.Duration(i))
+			until := start.Add(slot * time.Duration(j))
+			sb.WriteString(messages.ShortTime(from) + "-" + messages.ShortTime(until) + " " + messages.T(label) + "\n")
+		}
+		i = j
+	}
+
+	if sb.Len() == 0 {
+		return messages.T("timeline.no_activity") + "\n"
+	}
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+)
+
+// startActivityWatcher watches for unlocking the screen or coming back to the keyboard
+// in the background if enabled. Returns a function that stops the watcher.
+func (ui *TimerUI) startActivityWatcher() func() {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil || !cfg.ActivityWatcherEnabled {
+		return func() {}
+	}
+
+	watcher := integrations.NewActivityWatcher(cfg)
+	stop := make(chan struct{})
+
+	go watcher.Run(stop, func(event integrations.ActivityEvent) {
+		ui.app.QueueUpdateDraw(func() {
+			ui.handleActivityEvent(event)
+		})
+	}, func(err error) {
+		ui.app.QueueUpdateDraw(func() {
+			ui.statusBar.SetText("[red]" + messages.T("status.activity_watcher_stopped", err))
+		})
+	})
+
+	return func() { close(stop) }
+}
+
+// handleActivityEvent prompts to start a session when coming back to the workstation
+// without one, in the reminder banner and a desktop notification if enabled. Attached
+// to the daemon, the daemon sends the notification instead.
+func (ui *TimerUI) handleActivityEvent(event integrations.ActivityEvent) {
+	if ui.activeSession != nil {
+		return
+	}
+
+	message := messages.T("reminder.away", formatMinutes(event.Away))
+	ui.reminderText = message
+	ui.reminderShown = true
+	ui.updateHeader(event.At)
+
+	if ui.storage.GetConfig().ActivityDesktop && !ui.attached {
+		go func() {
+			if err := integrations.Notify("Interruption Tracker", message); err != nil {
+				ui.app.QueueUpdateDraw(func() {
+					ui.statusBar.SetText("[red]" + messages.T("status.reminder_failed", err))
+				})
+			}
+		}()
+	}
+}
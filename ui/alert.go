@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// alertFlashDuration is how long the status bar stays highlighted with an alert
+const alertFlashDuration = 10 * time.Second
+
+// alertPolicy returns the configured alert policy, disabled when unset or invalid
+func (ui *TimerUI) alertPolicy() models.AlertPolicy {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil {
+		return models.AlertPolicy{}
+	}
+
+	tags := make(map[string]time.Duration, len(cfg.Alerts.Tags))
+	for tag, threshold := range cfg.Alerts.Tags {
+		tags[tag] = threshold.Std()
+	}
+	policy, err := models.ParseAlertPolicy(cfg.Alerts.InterruptionAfter.Std(), tags, cfg.Alerts.SessionAfter.Std(), cfg.Alerts.Methods)
+	if err != nil {
+		return models.AlertPolicy{}
+	}
+	return policy
+}
+
+// checkAlerts alerts once when the open interruption of the active session runs past its
+// threshold, and once when the session runs past its planned length
+func (ui *TimerUI) checkAlerts(now time.Time) {
+	if ui.activeSession == nil {
+		return
+	}
+	policy := ui.alertPolicy()
+
+	if entry, threshold, due := policy.InterruptionDue(ui.activeSession, now); due && entry != ui.alertedInterruption {
+		ui.alertedInterruption = entry
+		label := messages.T("tag.other")
+		if entry.Tag != "" {
+			label = tagLabel(entry.Tag)
+		}
+		ui.alert(policy, messages.T("alert.interruption", formatMinutes(threshold), label), now)
+	}
+
+	if planned, due := policy.SessionDue(ui.activeSession, now); due && ui.activeSession.ID != ui.alertedSession {
+		ui.alertedSession = ui.activeSession.ID
+		description := ui.activeSession.Start.Description
+		ui.alert(policy, messages.T("alert.session", description, formatMinutes(planned)), now)
+	}
+}
+
+// alert gives an alert with the methods of the policy
+func (ui *TimerUI) alert(policy models.AlertPolicy, message string, now time.Time) {
+	if policy.Uses(models.AlertBell) && ui.screen != nil {
+		_ = ui.screen.Beep()
+	}
+
+	if policy.Uses(models.AlertFlash) {
+		ui.alertText = message
+		ui.alertFlashUntil = now.Add(alertFlashDuration)
+	}
+
+	if policy.Uses(models.AlertNotification) {
+		go func() {
+			if err := integrations.Notify("Interruption Tracker", message); err != nil {
+				ui.app.QueueUpdateDraw(func() {
+					ui.statusBar.SetText("[red]" + messages.T("status.notification_failed", err))
+				})
+			}
+		}()
+	}
+}
+
+// alertFlashActive reports whether the status bar should still flash the latest alert
+func (ui *TimerUI) alertFlashActive(now time.Time) bool {
+	return ui.alertText != "" && now.Before(ui.alertFlashUntil)
+}
+
+// formatMinutes formats a duration in whole minutes, e.g. "45m" or "1h 30m"
+func formatMinutes(d time.Duration) string {
+	if d >= time.Hour {
+		return messages.T("duration.hours_minutes", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return messages.T("duration.minutes", int(d.Minutes()))
+}
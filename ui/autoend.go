@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// pendingAutoEnd is a session past its auto-end deadline, waiting for the user to decide
+type pendingAutoEnd struct {
+	day      *models.DailySessions // Day the session is stored under
+	session  *models.Session
+	deadline time.Time
+}
+
+// autoEndPolicy returns the configured auto-end policy, disabled when unset or invalid
+func (ui *TimerUI) autoEndPolicy() models.AutoEndPolicy {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil {
+		return models.AutoEndPolicy{}
+	}
+
+	policy, err := models.ParseAutoEndPolicy(cfg.AutoEndAfter.Std(), cfg.AutoEndAt, cfg.AutoEndPrompt)
+	if err != nil {
+		return models.AutoEndPolicy{}
+	}
+	return policy
+}
+
+// restoreActiveSession makes an active session found at startup current: past its auto-end
+// deadline it is ended there or, when the policy prompts, held back until the user
+// answers. Sessions from the previous day are rolled over into today otherwise.
+func (ui *TimerUI) restoreActiveSession(day *models.DailySessions, session *models.Session, now time.Time) error {
+	policy := ui.autoEndPolicy()
+	if deadline, due := policy.Due(session, now); due {
+		if policy.Prompt {
+			ui.autoEndPending = &pendingAutoEnd{day: day, session: session, deadline: deadline}
+			return nil
+		}
+		if err := ui.endSessionAt(day, session, deadline); err != nil {
+			return fmt.Errorf("failed to auto-end session: %w", err)
+		}
+		ui.autoEnded = session
+		return nil
+	}
+
+	if day != ui.currentDay {
+		return ui.rollOver(day, session)
+	}
+	ui.activeSession = session
+	return nil
+}
+
+// endSessionAt ends a session at the given time and saves the day it is stored under
+func (ui *TimerUI) endSessionAt(day *models.DailySessions, session *models.Session, at time.Time) error {
+	entry := session.EndAt(at)
+	return ui.saveDayWithJournal(day, models.JournalEnd, session, entry)
+}
+
+// announceAutoEnd reports a session ended by the auto-end policy to the integrations
+func (ui *TimerUI) announceAutoEnd(session *models.Session) {
+	ui.syncEndedSession(session)
+	ui.setSlackFocus(false)
+	ui.notifyEvent(integrations.EventSessionEnd, session, session.End)
+}
+
+// checkAutoEnd ends the active session once it runs past the auto-end deadline, or asks
+// first when the policy prompts. Sessions the user chose to keep running are left alone.
+func (ui *TimerUI) checkAutoEnd(now time.Time) {
+	if ui.activeSession == nil || ui.activeSession.ID == ui.autoEndKept {
+		return
+	}
+
+	policy := ui.autoEndPolicy()
+	deadline, due := policy.Due(ui.activeSession, now)
+	if !due {
+		return
+	}
+
+	session := ui.activeSession
+	ui.activeSession = nil
+	if policy.Prompt {
+		ui.autoEndPending = &pendingAutoEnd{day: ui.currentDay, session: session, deadline: deadline}
+		ui.showAutoEndPrompt()
+		return
+	}
+
+	if err := ui.endSessionAt(ui.currentDay, session, deadline); err != nil {
+		ui.activeSession = session
+		ui.statusBar.SetText("[red]" + messages.T("status.auto_end_failed", err))
+		return
+	}
+	ui.announceAutoEnd(session)
+	ui.refreshTable()
+	ui.statusBar.SetText("[yellow]" + messages.T("status.auto_ended", messages.ShortTime(deadline)))
+}
+
+// showAutoEndNotice tells the user a session left running was ended at startup
+func (ui *TimerUI) showAutoEndNotice() {
+	session := ui.autoEnded
+	ui.autoEnded = nil
+	ui.announceAutoEnd(session)
+
+	modal := tview.NewModal().
+		SetText(messages.T("autoend.notice", session.Start.Description, messages.Date(session.End.StartTime, "Mon 15:04"))).
+		AddButtons([]string{messages.T("button.ok")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.pages.RemovePage("autoend")
+			ui.app.SetFocus(ui.sessionsTable)
+		})
+
+	ui.pages.AddPage("autoend", modal, true, true)
+	ui.app.SetFocus(modal)
+}
+
+// showAutoEndPrompt asks whether a session past its auto-end deadline should be ended
+// there or kept running
+func (ui *TimerUI) showAutoEndPrompt() {
+	pending := ui.autoEndPending
+	endLabel := messages.T("autoend.end_at", messages.ShortTime(pending.deadline))
+
+	modal := tview.NewModal().
+		SetText(messages.T("autoend.prompt",
+			pending.session.Start.Description,
+			messages.Date(pending.session.Start.StartTime, "Mon 15:04"),
+			messages.Date(pending.deadline, "Mon 15:04"))).
+		AddButtons([]string{endLabel, messages.T("autoend.keep_running")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.autoEndPending = nil
+			ui.pages.RemovePage("autoend")
+			ui.app.SetFocus(ui.sessionsTable)
+
+			if buttonLabel == endLabel {
+				if err := ui.endSessionAt(pending.day, pending.session, pending.deadline); err != nil {
+					ui.statusBar.SetText("[red]" + messages.T("status.end_failed", err))
+					return
+				}
+				ui.announceAutoEnd(pending.session)
+				ui.refreshTable()
+				ui.statusBar.SetText("[green]" + messages.T("status.session_ended"))
+				return
+			}
+
+			if pending.day != ui.currentDay {
+				if err := ui.rollOver(pending.day, pending.session); err != nil {
+					ui.statusBar.SetText("[red]" + messages.T("status.continue_failed", err))
+					return
+				}
+				ui.notifyEvent(integrations.EventDayRollover, ui.rolledOver, nil)
+			} else {
+				ui.activeSession = pending.session
+			}
+			// Rolling over may continue the session under a new ID
+			ui.autoEndKept = ui.activeSession.ID
+			ui.refreshTable()
+		})
+
+	ui.pages.AddPage("autoend", modal, true, true)
+	ui.app.SetFocus(modal)
+}
@@ -0,0 +1,357 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// Bulk actions offered for marked sessions, in the order of their buttons
+const (
+	bulkDelete = iota
+	bulkRetag
+	bulkMove
+	bulkMerge
+	bulkClearMarks
+)
+
+// displayedSessions returns the current day's sessions in the order of the sessions table:
+// active sessions first, then in the chosen sort order
+func (ui *TimerUI) displayedSessions() []*models.Session {
+	sessions := make([]*models.Session, len(ui.currentDay.Sessions))
+	copy(sessions, ui.currentDay.Sessions)
+
+	column, ascending := ui.sessionSort()
+	sortSessions(sessions, column, ascending)
+	return sessions
+}
+
+// toggleMark marks the selected session for a bulk operation, or unmarks it, and moves
+// the selection down so consecutive sessions can be marked quickly
+func (ui *TimerUI) toggleMark() {
+	session := ui.selectedSession()
+	if session == nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_selected"))
+		return
+	}
+
+	if ui.marked == nil {
+		ui.marked = make(map[string]bool)
+	}
+	id := session.ID
+	if ui.marked[id] {
+		delete(ui.marked, id)
+	} else {
+		ui.marked[id] = true
+	}
+
+	ui.refreshTable()
+	if row, ok := ui.rows.row(id); ok && row < ui.sessionsTable.GetRowCount()-1 {
+		ui.sessionsTable.Select(row+1, 0)
+	}
+	ui.statusBar.SetText("[yellow]" + messages.T("status.sessions_marked", len(ui.marked)))
+}
+
+// markedSessions returns the marked sessions of the current day in table order
+func (ui *TimerUI) markedSessions() []*models.Session {
+	var marked []*models.Session
+	for _, session := range ui.displayedSessions() {
+		if ui.marked[session.ID] {
+			marked = append(marked, session)
+		}
+	}
+	return marked
+}
+
+// clearMarks unmarks all sessions
+func (ui *TimerUI) clearMarks() {
+	ui.marked = make(map[string]bool)
+	ui.refreshTable()
+}
+
+// summarizeSessions describes the sessions affected by a bulk action for its confirmation
+func summarizeSessions(question string, sessions []*models.Session) string {
+	var sb strings.Builder
+	sb.WriteString(question + "\n")
+
+	var work time.Duration
+	for _, session := range sessions {
+		sessionWork, _, interruptions := session.GetStats()
+		work += sessionWork
+
+		end := messages.T("bulk.now")
+		if session.End != nil {
+			end = messages.ShortTime(session.End.StartTime)
+		}
+		description := session.Start.Description
+		if description == "" {
+			description = messages.T("bulk.no_description")
+		}
+		sb.WriteString("\n" + messages.T("bulk.session_line", messages.ShortTime(session.Start.StartTime), end, description,
+			formatDurationHumanReadable(sessionWork), interruptions))
+	}
+
+	sb.WriteString("\n\n" + messages.T("bulk.total", len(sessions), formatDurationHumanReadable(work)))
+	return sb.String()
+}
+
+// showBulkActions offers the actions for the marked sessions
+func (ui *TimerUI) showBulkActions() {
+	sessions := ui.markedSessions()
+	if len(sessions) == 0 {
+		ui.statusBar.SetText("[red]" + messages.T("status.none_marked"))
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(messages.T("bulk.marked", len(sessions))).
+		AddButtons([]string{messages.T("bulk.delete"), messages.T("bulk.retag"), messages.T("bulk.move"), messages.T("bulk.merge"),
+			messages.T("bulk.clear_marks"), messages.T("button.cancel")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.pages.RemovePage("bulk")
+			ui.app.SetFocus(ui.sessionsTable)
+
+			switch buttonIndex {
+			case bulkDelete:
+				ui.confirmBulk(summarizeSessions(messages.T("bulk.confirm_delete"), sessions), func() error {
+					return ui.deleteSessions(sessions)
+				})
+			case bulkRetag:
+				ui.showBulkTagSelection(sessions)
+			case bulkMove:
+				ui.showMoveInput(sessions, ui.confirmBulk)
+			case bulkMerge:
+				if len(sessions) < 2 {
+					ui.statusBar.SetText("[red]" + messages.T("status.merge_needs_two"))
+					return
+				}
+				ui.confirmBulk(summarizeSessions(messages.T("bulk.confirm_merge"), sessions), func() error {
+					return ui.mergeSessions(sessions)
+				})
+			case bulkClearMarks:
+				ui.clearMarks()
+				ui.statusBar.SetText("[green]" + messages.T("status.marks_cleared"))
+			}
+		})
+
+	ui.pages.AddPage("bulk", modal, true, true)
+	ui.app.SetFocus(modal)
+}
+
+// confirmBulk asks once for the whole bulk action, then applies it and clears the marks
+func (ui *TimerUI) confirmBulk(summary string, apply func() error) {
+	ui.showConfirmationDialog(summary, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		if err := apply(); err != nil {
+			ui.statusBar.SetText("[red]" + messages.T("status.error", err))
+			ui.refreshTable()
+			return
+		}
+		ui.clearMarks()
+	})
+}
+
+// showBulkTagSelection asks which tag the interruptions of the sessions get
+func (ui *TimerUI) showBulkTagSelection(sessions []*models.Session) {
+	tags := []models.InterruptionTag{models.TagCall, models.TagMeeting, models.TagSpouse, models.TagOther}
+	if cfg := ui.storage.GetConfig(); cfg != nil {
+		for _, customTag := range cfg.ActiveInterruptionTags() {
+			tags = append(tags, models.InterruptionTag(customTag))
+		}
+	}
+	buttons := make([]string, 0, len(tags)+1)
+	for _, tag := range tags {
+		buttons = append(buttons, string(tag))
+	}
+	buttons = append(buttons, messages.T("button.cancel"))
+
+	modal := tview.NewModal().
+		SetText(messages.T("bulk.retag_prompt")).
+		AddButtons(buttons).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.pages.RemovePage("bulk")
+			ui.app.SetFocus(ui.sessionsTable)
+			if buttonIndex < 0 || buttonIndex >= len(tags) {
+				return
+			}
+
+			tag := tags[buttonIndex]
+			ui.confirmBulk(summarizeSessions(messages.T("bulk.confirm_retag", tag), sessions), func() error {
+				return ui.retagSessions(sessions, tag)
+			})
+		})
+
+	ui.pages.AddPage("bulk", modal, true, true)
+	ui.app.SetFocus(modal)
+}
+
+// showMoveInput asks for the day the sessions are moved to, then asks to confirm the move
+func (ui *TimerUI) showMoveInput(sessions []*models.Session, confirm func(summary string, apply func() error)) {
+	dayField := tview.NewInputField().
+		SetLabel(messages.T("bulk.move_to_day") + " ").
+		SetText(ui.currentDay.Date.AddDate(0, 0, -1).Format("2006-01-02")).
+		SetFieldWidth(12)
+
+	closeDialog := func() {
+		ui.pages.RemovePage("input")
+		ui.app.SetFocus(ui.sessionsTable)
+	}
+
+	form := tview.NewForm().
+		AddFormItem(dayField).
+		AddButton(messages.T("button.move"), func() {
+			day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(dayField.GetText()), time.Local)
+			if err != nil {
+				ui.statusBar.SetText("[red]" + messages.T("status.invalid_date"))
+				return
+			}
+			closeDialog()
+			confirm(summarizeSessions(messages.T("bulk.confirm_move", messages.Date(day, "Monday, 02 Jan 2006")), sessions), func() error {
+				return ui.moveSessions(sessions, day)
+			})
+		}).
+		AddButton(messages.T("button.cancel"), closeDialog)
+	form.SetBorder(true).SetTitle(" " + messages.T("bulk.move_title") + " ")
+	form.SetCancelFunc(closeDialog)
+
+	flex := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 7, 1, true).
+			AddItem(nil, 0, 1, false), 40, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	ui.pages.AddPage("input", flex, true, true)
+	ui.app.SetFocus(form)
+}
+
+// withoutSessions returns the sessions not in the removed set
+func withoutSessions(sessions []*models.Session, removed []*models.Session) []*models.Session {
+	drop := make(map[*models.Session]bool, len(removed))
+	for _, session := range removed {
+		drop[session] = true
+	}
+
+	kept := []*models.Session{}
+	for _, session := range sessions {
+		if !drop[session] {
+			kept = append(kept, session)
+		}
+	}
+	return kept
+}
+
+// deleteSessions removes the sessions from the current day
+func (ui *TimerUI) deleteSessions(sessions []*models.Session) error {
+	var events []*models.JournalEvent
+	for _, session := range sessions {
+		if session == ui.activeSession {
+			ui.activeSession = nil
+		}
+		events = append(events, models.NewJournalEvent(models.JournalDelete, ui.currentDay.Date, session, nil))
+	}
+
+	ui.currentDay.Sessions = withoutSessions(ui.currentDay.Sessions, sessions)
+	if err := ui.saveDayWithEvents(ui.currentDay, events); err != nil {
+		return fmt.Errorf("failed to delete sessions: %w", err)
+	}
+
+	ui.statusBar.SetText("[green]" + messages.T("status.sessions_deleted", len(sessions)))
+	return nil
+}
+
+// retagSessions sets the tag of every interruption of the sessions
+func (ui *TimerUI) retagSessions(sessions []*models.Session, tag models.InterruptionTag) error {
+	changed := 0
+	var events []*models.JournalEvent
+	for _, session := range sessions {
+		if n := session.Retag(tag); n > 0 {
+			changed += n
+			events = append(events, models.NewJournalEvent(models.JournalEdit, ui.currentDay.Date, session, nil))
+		}
+	}
+
+	if err := ui.saveDayWithEvents(ui.currentDay, events); err != nil {
+		return fmt.Errorf("failed to re-tag sessions: %w", err)
+	}
+
+	ui.statusBar.SetText("[green]" + messages.T("status.interruptions_retagged", changed, tag))
+	return nil
+}
+
+// moveSessions moves ended sessions from the current day to another day
+func (ui *TimerUI) moveSessions(sessions []*models.Session, day time.Time) error {
+	target := models.DayOf(day, 0)
+	var ids []string
+	var events []*models.JournalEvent
+	for _, session := range sessions {
+		if session.End == nil {
+			return fmt.Errorf("end %q before moving it", session.Start.Description)
+		}
+		ids = append(ids, session.ID)
+		events = append(events,
+			models.NewJournalEvent(models.JournalEdit, target, session, nil),
+			models.NewJournalEvent(models.JournalDelete, ui.currentDay.Date, session, nil))
+	}
+
+	// Journal first, so a crash during the move can be recovered from it
+	var journalErr error
+	for _, event := range events {
+		if err := ui.storage.AppendJournal(event); err != nil && journalErr == nil {
+			journalErr = err
+		}
+	}
+	if err := ui.storage.MoveSessions(ui.currentDay.Date, target, ids); err != nil {
+		return fmt.Errorf("failed to move sessions: %w", err)
+	}
+	ui.currentDay.Sessions = withoutSessions(ui.currentDay.Sessions, sessions)
+	if journalErr != nil {
+		return fmt.Errorf("moved, but failed to write journal: %w", journalErr)
+	}
+
+	ui.statusBar.SetText("[green]" + messages.T("status.sessions_moved", len(sessions), messages.Date(target, "02 Jan 2006")))
+	return nil
+}
+
+// mergeSessions replaces the sessions with a single session combining their work
+func (ui *TimerUI) mergeSessions(sessions []*models.Session) error {
+	latest := sessions[0]
+	for _, session := range sessions {
+		if session.Start.StartTime.After(latest.Start.StartTime) {
+			latest = session
+		}
+	}
+	for _, session := range sessions {
+		if session.End == nil && session != latest {
+			return fmt.Errorf("only the latest of the merged sessions can still be running")
+		}
+	}
+
+	merged := models.MergeSessions(sessions)
+	var events []*models.JournalEvent
+	for _, session := range sessions {
+		if session.ID != merged.ID {
+			events = append(events, models.NewJournalEvent(models.JournalDelete, ui.currentDay.Date, session, nil))
+		}
+	}
+	events = append(events, models.NewJournalEvent(models.JournalEdit, ui.currentDay.Date, merged, nil))
+
+	ui.currentDay.Sessions = append(withoutSessions(ui.currentDay.Sessions, sessions), merged)
+	for _, session := range sessions {
+		if session == ui.activeSession {
+			ui.activeSession = merged
+		}
+	}
+	if err := ui.saveDayWithEvents(ui.currentDay, events); err != nil {
+		return fmt.Errorf("failed to merge sessions: %w", err)
+	}
+
+	ui.statusBar.SetText("[green]" + messages.T("status.sessions_merged", len(sessions)))
+	return nil
+}
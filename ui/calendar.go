@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// calendarWeeks is how many weeks the calendar heatmap covers, ending with the current week
+const calendarWeeks = 26
+
+// calendarLevels are the heatmap colors from no work to the most focused days
+var calendarLevels = []tcell.Color{
+	tcell.ColorDarkSlateGray,
+	tcell.ColorDarkGreen,
+	tcell.ColorGreen,
+	tcell.ColorLimeGreen,
+	tcell.ColorLime,
+}
+
+// calendarLevel maps a day's focused work to a heatmap color level
+func calendarLevel(work time.Duration) int {
+	switch {
+	case work <= 0:
+		return 0
+	case work < time.Hour:
+		return 1
+	case work < 2*time.Hour:
+		return 2
+	case work < 4*time.Hour:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// calendarStart returns the Monday of the first week shown in the heatmap ending on today
+func calendarStart(today time.Time) time.Time {
+	weekday := int(today.Weekday())
+	if weekday == 0 { // Sunday
+		weekday = 7
+	}
+	return today.AddDate(0, 0, -(weekday-1)-7*(calendarWeeks-1))
+}
+
+// showCalendar displays a contributions-style heatmap of focused work per day.
+// Arrow keys move between days and Enter opens the selected day.
+func (ui *TimerUI) showCalendar() {
+	now := time.Now()
+	today := ui.storage.DayOf(now)
+	start := calendarStart(today)
+
+	stats := ui.storage.GetDetailedStatsBetween(start, today)
+
+	calendarTable := tview.NewTable().
+		SetSelectable(true, true).
+		SetSelectedStyle(tcell.Style{}.
+			Background(tcell.ColorWhite).
+			Foreground(tcell.ColorBlack))
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true)
+
+	// Weekday labels in the first column; row 0 holds month labels
+	for i, label := range []string{"Mon", "", "Wed", "", "Fri", "", "Sun"} {
+		calendarTable.SetCell(i+1, 0, tview.NewTableCell(label+" ").
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+	}
+	calendarTable.SetCell(0, 0, tview.NewTableCell("").SetSelectable(false))
+
+	dates := make(map[[2]int]time.Time) // Cell position to the day it shows
+	for week := 0; week < calendarWeeks; week++ {
+		col := week + 1
+		weekStart := start.AddDate(0, 0, 7*week)
+
+		// Label the week in which each month starts
+		monthLabel := ""
+		if week == 0 || weekStart.Month() != weekStart.AddDate(0, 0, -7).Month() {
+			monthLabel = messages.Date(weekStart, "Jan")
+		}
+		calendarTable.SetCell(0, col, tview.NewTableCell(monthLabel).
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+
+		for weekday := 0; weekday < 7; weekday++ {
+			day := weekStart.AddDate(0, 0, weekday)
+			row := weekday + 1
+
+			if day.After(today) {
+				calendarTable.SetCell(row, col, tview.NewTableCell("  ").SetSelectable(false))
+				continue
+			}
+
+			work := stats.DailyWorkDurations[day.Format("2006-01-02")]
+			calendarTable.SetCell(row, col, tview.NewTableCell("■ ").
+				SetTextColor(calendarLevels[calendarLevel(work)]))
+			dates[[2]int{row, col}] = day
+		}
+	}
+
+	// Describe the selected day in the footer
+	calendarTable.SetSelectionChangedFunc(func(row, col int) {
+		day, ok := dates[[2]int{row, col}]
+		if !ok {
+			return
+		}
+		work := stats.DailyWorkDurations[day.Format("2006-01-02")]
+		footer.SetText("[white] " + messages.T("calendar.day_focus", messages.Date(day, "Mon, 02 Jan 2006"), formatDurationHumanReadable(work)) +
+			"  [yellow]" + ui.bindings().page("calendar").hints(false))
+	})
+
+	// Start on today
+	todayWeekday := int(today.Weekday())
+	if todayWeekday == 0 {
+		todayWeekday = 7
+	}
+	calendarTable.Select(todayWeekday, calendarWeeks)
+
+	header := tview.NewTextView().
+		SetText(" " + messages.T("calendar.title", calendarWeeks)).
+		SetTextColor(tcell.ColorGreen)
+
+	legend := tview.NewTextView().SetDynamicColors(true)
+	legendText := " " + messages.T("calendar.less") + " "
+	for _, color := range calendarLevels {
+		legendText += fmt.Sprintf("[#%06x]■ ", color.Hex())
+	}
+	legend.SetText(legendText + "[white]" + messages.T("calendar.more"))
+
+	calendarPage := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(calendarTable, 9, 0, true).
+		AddItem(legend, 1, 0, false).
+		AddItem(tview.NewBox(), 0, 1, false).
+		AddItem(footer, 1, 0, false)
+
+	calendarPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if ui.bindings().action("calendar", event) == "open" {
+			row, col := calendarTable.GetSelection()
+			if day, ok := dates[[2]int{row, col}]; ok {
+				ui.showDayView(day)
+			}
+			return nil
+		}
+		return event
+	})
+
+	ui.pages.RemovePage("calendar")
+	ui.pages.AddPage("calendar", calendarPage, true, true)
+	ui.app.SetFocus(calendarTable)
+}
+
+// closeCalendar returns from the calendar to the stats page
+func (ui *TimerUI) closeCalendar() {
+	ui.pages.RemovePage("calendar")
+	ui.pages.SwitchToPage("stats")
+}
+
+// showDayView displays the session table and timeline of a single day
+func (ui *TimerUI) showDayView(day time.Time) {
+	dailySessions, err := ui.storage.LoadDailySessions(day)
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.load_day_failed", day.Format("2006-01-02"), err))
+		return
+	}
+
+	dayTable := tview.NewTable().
+		SetBorders(true).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSeparator(tview.Borders.Vertical).
+		SetSelectedStyle(tcell.Style{}.
+			Background(tcell.ColorNavy).
+			Foreground(tcell.ColorWhite))
+
+	headers := []string{"Start", "End", "Work", "Interruptions", "Description"}
+	for i, header := range headers {
+		// Add 2 spaces padding on both sides
+		dayTable.SetCell(0, i,
+			tview.NewTableCell("  "+header+"  ").
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignCenter).
+				SetSelectable(false))
+	}
+
+	var totalWork time.Duration
+	for i, session := range dailySessions.Sessions {
+		if session.Start == nil {
+			continue
+		}
+		row := i + 1
+
+		end := "active"
+		if session.End != nil {
+			end = messages.Time(session.End.StartTime)
+		}
+
+		workDuration, _, interruptionCount := ui.sessionStats(session)
+		totalWork += workDuration
+
+		dayTable.SetCell(row, 0, tview.NewTableCell("  "+messages.Time(session.Start.StartTime)+"  "))
+		dayTable.SetCell(row, 1, tview.NewTableCell("  "+end+"  "))
+		dayTable.SetCell(row, 2, tview.NewTableCell("  "+formatDurationHumanReadable(workDuration)+"  "))
+		dayTable.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("  %d  ", interruptionCount)))
+		dayTable.SetCell(row, 4, tview.NewTableCell("  "+session.Start.Description+"  "))
+	}
+	calculateTableColumnWidths(dayTable)
+
+	header := tview.NewTextView().
+		SetText(" " + messages.T("calendar.day_title",
+			messages.Date(day, "Monday, 02 January 2006"), len(dailySessions.Sessions), formatDurationHumanReadable(totalWork))).
+		SetTextColor(tcell.ColorGreen)
+
+	timeline := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(ui.generateDayTimelineChart(day, dailySessions.Sessions))
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[yellow] " + ui.statusHint("day"))
+
+	dayPage := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(dayTable, 0, 1, true).
+		AddItem(timeline, 0, 1, false).
+		AddItem(footer, 1, 0, false)
+
+	ui.pages.AddPage("day", dayPage, true, true)
+	ui.app.SetFocus(dayTable)
+}
@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// Columns of the sessions table, as named in the configuration
+const (
+	columnStart         = "start"
+	columnEnd           = "end"
+	columnDuration      = "duration"
+	columnInterruptions = "interruptions"
+	columnPattern       = "pattern"
+	columnDescription   = "description"
+)
+
+// sessionColumns are all columns of the sessions table in their default order
+var sessionColumns = []string{columnStart, columnEnd, columnDuration, columnInterruptions, columnPattern, columnDescription}
+
+// sessionColumnTitles are the message keys of the sessions table column headers
+var sessionColumnTitles = map[string]string{
+	columnStart:         "column.start",
+	columnEnd:           "column.end",
+	columnDuration:      "column.duration",
+	columnInterruptions: "column.interruptions",
+	columnPattern:       "column.pattern",
+	columnDescription:   "column.description",
+}
+
+// parseSessionColumns parses a comma separated list of column names, refusing unknown or
+// repeated columns. An empty list means all columns.
+func parseSessionColumns(text string) ([]string, error) {
+	columns := []string{}
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(text, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, known := sessionColumnTitles[name]; !known {
+			return nil, fmt.Errorf("unknown column %q, use %s", name, strings.Join(sessionColumns, ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("column %q is listed twice", name)
+		}
+		seen[name] = true
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	return columns, nil
+}
+
+// visibleColumns returns the configured columns of the sessions table, skipping unknown
+// names, or all columns
+func (ui *TimerUI) visibleColumns() []string {
+	if cfg := ui.storage.GetConfig(); cfg != nil {
+		columns := []string{}
+		for _, name := range cfg.SessionColumns {
+			if _, known := sessionColumnTitles[name]; known && !containsString(columns, name) {
+				columns = append(columns, name)
+			}
+		}
+		if len(columns) > 0 {
+			return columns
+		}
+	}
+	return sessionColumns
+}
+
+// containsString reports whether the value is in the list
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionSort returns the column sessions are sorted by and whether in ascending order:
+// as chosen with the number keys, as configured, or newest start first
+func (ui *TimerUI) sessionSort() (string, bool) {
+	if ui.sortColumn != "" {
+		return ui.sortColumn, ui.sortAscending
+	}
+	if cfg := ui.storage.GetConfig(); cfg != nil {
+		if _, known := sessionColumnTitles[cfg.SessionSort]; known {
+			return cfg.SessionSort, cfg.SessionSortAscending
+		}
+	}
+	return columnStart, false
+}
+
+// sortSessions orders the sessions by a column, keeping active sessions on top. Ties keep
+// the newest start first. The pattern column sorts by interrupted time.
+func sortSessions(sessions []*models.Session, column string, ascending bool) {
+	type sortKey struct {
+		start, end    time.Time
+		work, lost    time.Duration
+		interruptions int
+		description   string
+	}
+	keys := make(map[*models.Session]sortKey, len(sessions))
+	for _, session := range sessions {
+		key := sortKey{start: session.Start.StartTime, description: strings.ToLower(session.Start.Description)}
+		if session.End != nil {
+			key.end = session.End.StartTime
+		}
+		key.work, key.lost, key.interruptions = session.GetStats()
+		keys[session] = key
+	}
+
+	// compare returns a negative number when a sorts before b in ascending order
+	compare := func(a, b sortKey) int {
+		switch column {
+		case columnEnd:
+			return a.end.Compare(b.end)
+		case columnDuration:
+			return compareInts(int64(a.work), int64(b.work))
+		case columnInterruptions:
+			return compareInts(int64(a.interruptions), int64(b.interruptions))
+		case columnPattern:
+			return compareInts(int64(a.lost), int64(b.lost))
+		case columnDescription:
+			return strings.Compare(a.description, b.description)
+		default:
+			return a.start.Compare(b.start)
+		}
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		iActive, jActive := sessions[i].End == nil, sessions[j].End == nil
+		if iActive != jActive {
+			return iActive
+		}
+		a, b := keys[sessions[i]], keys[sessions[j]]
+		if order := compare(a, b); order != 0 {
+			return (order < 0) == ascending
+		}
+		return a.start.After(b.start)
+	})
+}
+
+// compareInts returns -1, 0 or 1 as a is less than, equal to or greater than b
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// sortByColumn sorts the sessions table by the visible column with the given index,
+// reversing the order when it is already sorted by it. New columns start with the newest,
+// longest or most interrupted sessions first, descriptions from A to Z.
+func (ui *TimerUI) sortByColumn(index int) {
+	columns := ui.visibleColumns()
+	if index < 0 || index >= len(columns) {
+		ui.statusBar.SetText("[red]" + messages.T("status.no_column", index+1, len(columns)))
+		return
+	}
+
+	column := columns[index]
+	current, ascending := ui.sessionSort()
+	if column == current {
+		ascending = !ascending
+	} else {
+		ascending = column == columnDescription
+	}
+	ui.sortColumn, ui.sortAscending = column, ascending
+	ui.refreshTable() // Keeps the selected session selected
+
+	order := messages.T("sort.descending")
+	if ascending {
+		order = messages.T("sort.ascending")
+	}
+	ui.statusBar.SetText("[green]" + messages.T("status.sorted_by", strings.ToLower(messages.T(sessionColumnTitles[column])), order))
+}
+
+// sessionHeaders returns the header cells of the sessions table, marking the sort column
+func (ui *TimerUI) sessionHeaders(columns []string) []*tview.TableCell {
+	sortColumn, ascending := ui.sessionSort()
+	headers := make([]*tview.TableCell, len(columns))
+	for i, column := range columns {
+		header := messages.T(sessionColumnTitles[column])
+		if column == sortColumn {
+			if ascending {
+				header += " ▲"
+			} else {
+				header += " ▼"
+			}
+		}
+
+		// Add 2 spaces padding on both sides
+		headers[i] = tview.NewTableCell("  " + header + "  ").
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false)
+	}
+	return headers
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/rivo/tview"
+)
+
+// conflictStep is one question of the conflict resolver: the text shown, the answers
+// offered and what each answer does. Later leaves the conflict for the next launch.
+type conflictStep struct {
+	text    string
+	buttons []string
+	resolve func(button string) error
+}
+
+// describeSessions summarizes sessions for comparing two versions of a day
+func describeSessions(dailySessions *models.DailySessions) string {
+	if dailySessions == nil || len(dailySessions.Sessions) == 0 {
+		return messages.T("conflict.no_sessions")
+	}
+
+	work, _, _ := dailySessions.GetStats()
+	var latest time.Time
+	for _, session := range dailySessions.Sessions {
+		if activity := session.LastActivity(); activity.After(latest) {
+			latest = activity
+		}
+	}
+	return messages.T("conflict.summary",
+		len(dailySessions.Sessions), formatDurationHumanReadable(work), messages.Date(latest, "02 Jan 15:04"))
+}
+
+// conflictSteps builds the resolver's questions: for each conflicted copy whether to merge
+// it, keep the local file or use the copy, and for each duplicated session which day keeps it
+func conflictSteps(store *storage.Storage, conflicts *storage.SyncConflicts) []conflictStep {
+	var steps []conflictStep
+	later := messages.T("conflict.later")
+
+	for _, conflicted := range conflicts.Copies {
+		conflicted := conflicted
+		ours, _ := store.LoadDailySessions(conflicted.Day)
+		theirs, err := store.LoadConflictedCopy(conflicted)
+		if err != nil {
+			steps = append(steps, conflictStep{
+				text:    messages.T("conflict.unreadable", filepath.Base(conflicted.Path), err),
+				buttons: []string{messages.T("conflict.delete_copy"), later},
+				resolve: func(button string) error {
+					if button == messages.T("conflict.delete_copy") {
+						return store.DiscardConflictedCopy(conflicted)
+					}
+					return nil
+				},
+			})
+			continue
+		}
+
+		steps = append(steps, conflictStep{
+			text: messages.T("conflict.copy", messages.Date(conflicted.Day, "Monday, 02 Jan 2006"), filepath.Base(conflicted.Path),
+				describeSessions(ours), describeSessions(theirs)),
+			buttons: []string{messages.T("conflict.merge_both"), messages.T("conflict.keep_ours"), messages.T("conflict.use_copy"), later},
+			resolve: func(button string) error {
+				switch button {
+				case messages.T("conflict.merge_both"):
+					return store.MergeConflictedCopy(conflicted)
+				case messages.T("conflict.keep_ours"):
+					return store.DiscardConflictedCopy(conflicted)
+				case messages.T("conflict.use_copy"):
+					return store.UseConflictedCopy(conflicted)
+				}
+				return nil
+			},
+		})
+	}
+
+	for _, duplicate := range conflicts.Duplicates {
+		duplicate := duplicate
+		var days []string
+		buttons := []string{}
+		keep := make(map[string]time.Time)
+		for _, day := range duplicate.Days {
+			label := messages.T("conflict.keep_in", messages.Date(day, "02 Jan"))
+			if day.Equal(duplicate.Latest) {
+				label += " " + messages.T("conflict.latest")
+			}
+			days = append(days, messages.Date(day, "02 Jan 2006"))
+			buttons = append(buttons, label)
+			keep[label] = day
+		}
+		buttons = append(buttons, later)
+
+		steps = append(steps, conflictStep{
+			text:    messages.T("conflict.duplicate", duplicate.ID, strings.Join(days, " "+messages.T("conflict.and")+" ")),
+			buttons: buttons,
+			resolve: func(button string) error {
+				if day, ok := keep[button]; ok {
+					return store.KeepDuplicateIn(duplicate, day)
+				}
+				return nil
+			},
+		})
+	}
+
+	return steps
+}
+
+// RunConflictResolver walks through the conflicts found in a synced data directory one
+// at a time before the tracker starts, instead of silently showing one machine's version
+func RunConflictResolver(store *storage.Storage, conflicts *storage.SyncConflicts) error {
+	// Runs before the tracker's views are set up, which apply the language otherwise
+	if cfg := store.GetConfig(); cfg != nil {
+		messages = configMessages(cfg)
+	}
+	steps := conflictSteps(store, conflicts)
+	if len(steps) == 0 {
+		return nil
+	}
+
+	app := tview.NewApplication()
+	var resolveErr error
+
+	var show func(i int)
+	show = func(i int) {
+		if i >= len(steps) {
+			app.Stop()
+			return
+		}
+
+		step := steps[i]
+		modal := tview.NewModal().
+			SetText(messages.T("conflict.title", i+1, len(steps)) + "\n\n" + step.text).
+			AddButtons(step.buttons).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				if err := step.resolve(buttonLabel); err != nil && resolveErr == nil {
+					resolveErr = err
+				}
+				show(i + 1)
+			})
+		app.SetRoot(modal, true)
+	}
+	show(0)
+
+	if err := app.EnableMouse(true).Run(); err != nil {
+		return err
+	}
+	return resolveErr
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// showContinuedTasks displays the tasks of the selected stats range that were continued
+// across days, with the combined work of all parts
+func (ui *TimerUI) showContinuedTasks() {
+	rangeType := ui.statsRange
+	if rangeType == "" {
+		rangeType = "day"
+	}
+
+	tasks, err := ui.storage.GetContinuedTasks(rangeType)
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.continued_failed", err))
+		return
+	}
+
+	header := tview.NewTextView().
+		SetText(" " + messages.T("continued.title", rangeDisplayName(rangeType))).
+		SetTextColor(tcell.ColorGreen)
+
+	content := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(formatContinuedTasks(tasks))
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[yellow] " + ui.statusHint("continued"))
+
+	continuedPage := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(content, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+
+	ui.pages.RemovePage("continued")
+	ui.pages.AddPage("continued", continuedPage, true, true)
+	ui.app.SetFocus(content)
+}
+
+// formatContinuedTasks renders continued tasks as colored text: the combined totals of
+// each task followed by the work of every day's part
+func formatContinuedTasks(tasks []models.ContinuedTask) string {
+	if len(tasks) == 0 {
+		return messages.T("continued.none")
+	}
+
+	var sb strings.Builder
+	for _, task := range tasks {
+		status := ""
+		if task.Active() {
+			status = " [green]" + messages.T("continued.active") + "[white]"
+		}
+		project := ""
+		if task.Project != "" {
+			project = fmt.Sprintf(" [gray](%s)[white]", tview.Escape(task.Project))
+		}
+
+		fmt.Fprintf(&sb, "[yellow]%s[white]%s%s\n", tview.Escape(task.Description), project, status)
+		sb.WriteString("  " + messages.T("continued.combined",
+			formatDurationHumanReadable(task.WorkDuration), len(task.Parts),
+			task.Interruptions, formatDurationHumanReadable(task.InterruptionDuration)) + "\n")
+
+		for _, part := range task.Parts {
+			work, _, count := part.Session.GetStats()
+			end := messages.T("timeline.now")
+			if part.Session.End != nil {
+				end = messages.ShortTime(part.Session.End.StartTime)
+			}
+			fmt.Fprintf(&sb, "    %s  %s-%-5s  %-8s  %s\n",
+				messages.Date(part.Day, "Mon 02 Jan"), messages.ShortTime(part.Session.Start.StartTime), end,
+				formatDurationHumanReadable(work), messages.T("continued.interruptions", count))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
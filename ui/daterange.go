@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/rivo/tview"
+)
+
+// rangeDisplayName returns the title for a stats range, with the dates of custom ranges
+func rangeDisplayName(rangeType string) string {
+	switch rangeType {
+	case "day":
+		return messages.T("range.day")
+	case "week":
+		return messages.T("range.week")
+	case "last-week":
+		return messages.T("range.last_week")
+	case "month":
+		return messages.T("range.month")
+	case "quarter":
+		return messages.T("range.quarter")
+	case "year":
+		return messages.T("range.year")
+	case "all":
+		return messages.T("range.all")
+	}
+	if from, to, ok := strings.Cut(rangeType, ".."); ok {
+		return messages.T("range.custom", from, to)
+	}
+	return rangeType
+}
+
+// showDateRangeInput asks for start and end dates and shows stats for the days between
+func (ui *TimerUI) showDateRangeInput() {
+	// Start from the range currently shown
+	start, end, err := ui.storage.GetDateRange(ui.statsRange)
+	if err != nil {
+		start, end, _ = ui.storage.GetDateRange("day")
+	}
+
+	startField := tview.NewInputField().
+		SetLabel(messages.T("range.start") + " ").
+		SetText(start.Format("2006-01-02")).
+		SetFieldWidth(12)
+	endField := tview.NewInputField().
+		SetLabel(messages.T("range.end") + " ").
+		SetText(end.Format("2006-01-02")).
+		SetFieldWidth(12)
+
+	var rangeForm *tview.Form
+
+	// closeDialog returns to the stats page
+	closeDialog := func() {
+		ui.pages.RemovePage("input")
+		ui.app.SetFocus(ui.statsView)
+	}
+
+	// submit shows the stats, keeping the dialog open while the dates are invalid
+	submit := func() bool {
+		startDate, startErr := time.ParseInLocation("2006-01-02", strings.TrimSpace(startField.GetText()), time.Local)
+		endDate, endErr := time.ParseInLocation("2006-01-02", strings.TrimSpace(endField.GetText()), time.Local)
+		switch {
+		case startErr != nil || endErr != nil:
+			rangeForm.SetTitle(" " + messages.T("status.invalid_date") + " ")
+		case endDate.Before(startDate):
+			rangeForm.SetTitle(" " + messages.T("range.end_before_start") + " ")
+		default:
+			closeDialog()
+			ui.showStats(storage.CustomRange(startDate, endDate))
+			return true
+		}
+		rangeForm.SetTitleColor(tcell.ColorRed)
+		return false
+	}
+
+	// Enter moves from the start to the end date, and submits from the end date
+	endField.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter && !submit() {
+			rangeForm.SetFocus(0) // The form moves on to the next item after Enter, back to the start
+		}
+	})
+
+	rangeForm = tview.NewForm().
+		AddFormItem(startField).
+		AddFormItem(endField).
+		AddButton(messages.T("button.show"), func() {
+			if !submit() {
+				rangeForm.SetFocus(0)
+				ui.app.SetFocus(rangeForm)
+			}
+		}).
+		AddButton(messages.T("button.cancel"), closeDialog)
+
+	rangeForm.SetBorder(true)
+	rangeForm.SetTitle(" " + messages.T("range.title") + " ")
+	rangeForm.SetTitleAlign(tview.AlignCenter)
+
+	// Create a flex layout for centering the form
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexColumn).
+			AddItem(nil, 0, 1, false).
+			AddItem(rangeForm, 40, 1, true).
+			AddItem(nil, 0, 1, false),
+			9, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeDialog()
+			return nil
+		}
+		return event
+	})
+
+	ui.pages.AddPage("input", flex, true, true)
+	ui.app.SetFocus(rangeForm)
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// startFocusWatcher watches the active window in the background if enabled.
+// Returns a function that stops the watcher.
+func (ui *TimerUI) startFocusWatcher() func() {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil || !cfg.FocusWatcherEnabled {
+		return func() {}
+	}
+
+	watcher := integrations.NewFocusWatcher(cfg)
+	stop := make(chan struct{})
+
+	go watcher.Run(stop, func(event integrations.FocusEvent) {
+		ui.app.QueueUpdateDraw(func() {
+			ui.handleFocusEvent(watcher.Mode(), event)
+		})
+	}, func(err error) {
+		ui.app.QueueUpdateDraw(func() {
+			ui.statusBar.SetText("[red]" + messages.T("status.focus_watcher_stopped", err))
+		})
+	})
+
+	return func() { close(stop) }
+}
+
+// handleFocusEvent records or suggests an interruption when an interruption app gains
+// or loses focus during an active session
+func (ui *TimerUI) handleFocusEvent(mode string, event integrations.FocusEvent) {
+	if ui.activeSession == nil {
+		ui.focusEntry = nil
+		return
+	}
+
+	if mode == integrations.FocusModeRecord {
+		if event.Interrupting {
+			if ui.isInInterruptionMode() {
+				return // Already interrupted manually
+			}
+			entry := models.NewInterruptionEntry(event.App, event.Tag)
+			entry.StartTime = event.Since
+			ui.recordInterruption(entry)
+			ui.focusEntry = entry
+			return
+		}
+
+		// Only close interruptions the watcher opened itself
+		interruptions := ui.activeSession.Interruptions
+		if ui.focusEntry != nil && len(interruptions) > 0 && interruptions[len(interruptions)-1] == ui.focusEntry {
+			ui.backFromInterruption()
+		}
+		ui.focusEntry = nil
+		return
+	}
+
+	// Suggest mode asks once the user is back, as they are looking at the tracker again
+	if event.Interrupting || ui.isInInterruptionMode() {
+		return
+	}
+	if page, _ := ui.pages.GetFrontPage(); page != "main" {
+		return
+	}
+
+	message := fmt.Sprintf("%s was focused for %s. Record it as a %s interruption?",
+		event.App, formatDurationHumanReadable(time.Since(event.Since)), event.Tag)
+	ui.showConfirmationDialog(message, func(confirmed bool) {
+		if !confirmed || ui.activeSession == nil || ui.isInInterruptionMode() {
+			return
+		}
+		entry := models.NewInterruptionEntry(event.App, event.Tag)
+		entry.StartTime = event.Since
+		ui.recordInterruption(entry)
+		ui.backFromInterruption()
+	})
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// goalBarWidth is the number of cells in the header goal progress bar
+const goalBarWidth = 20
+
+// formatGoalProgress renders focused work against a goal as a progress bar, or an empty
+// string when there is no goal
+func formatGoalProgress(work, goal time.Duration) string {
+	if goal <= 0 {
+		return ""
+	}
+
+	filled := int(float64(goalBarWidth) * float64(work) / float64(goal))
+	if filled > goalBarWidth {
+		filled = goalBarWidth
+	}
+	color := "yellow"
+	if work >= goal {
+		color = "green"
+	}
+
+	return messages.T("header.goal_today", formatDurationHumanReadable(work.Round(time.Minute)), formatDurationHumanReadable(goal)) +
+		fmt.Sprintf(" [%s]%s[gray]%s[white] %d%%", color, strings.Repeat("█", filled), strings.Repeat("░", goalBarWidth-filled),
+			int(float64(work)/float64(goal)*100))
+}
+
+// updateHeader shows the title, the interruption or recovery timer, progress towards
+// today's focus goal and the start reminder banner, if any
+func (ui *TimerUI) updateHeader(now time.Time) {
+	text := headerText
+	if ui.safeMode {
+		text += "  [white:red] " + messages.T("header.safe_mode") + " [-:-]"
+	}
+	if ui.replay != nil {
+		text += "  " + ui.replay.badge()
+		ui.header.SetText(text)
+		return
+	}
+	if accessibleMode {
+		text += "  " + messages.T("header.state", ui.sessionState(now))
+	}
+	if timer, color := ui.interruptionTimer(now); timer != "" {
+		text += fmt.Sprintf("  [black:%s] %s [-:-]", color, timer)
+	}
+
+	if goals, err := ui.storage.FocusGoals(); err == nil {
+		work, _, _ := ui.currentDay.GetStats()
+		if progress := formatGoalProgress(work, goals.For(ui.storage.DayOf(now))); progress != "" {
+			text += "  " + progress
+		}
+	}
+
+	if ui.reminderText != "" {
+		text += fmt.Sprintf("  [black:yellow] %s [-:-]", ui.reminderText)
+	}
+
+	ui.header.SetText(text)
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// keyHelpPage is the name of the page listing the key bindings
+const keyHelpPage = "keyhelp"
+
+// bindings returns the key bindings of the UI, the defaults until the UI is set up
+func (ui *TimerUI) bindings() keymap {
+	if ui.keys == nil {
+		ui.keys = defaultKeymap()
+	}
+	return ui.keys
+}
+
+// applyKeyBindings sets up the key bindings with the configured ones, keeping the defaults
+// when they are invalid
+func (ui *TimerUI) applyKeyBindings(overrides map[string]string) error {
+	keys, err := newKeymap(overrides)
+	if err != nil {
+		ui.keys = defaultKeymap()
+		return err
+	}
+	ui.keys = keys
+	return nil
+}
+
+// statusHint returns the status bar hint of a page, listing its main key bindings
+func (ui *TimerUI) statusHint(page string) string {
+	keyPage := ui.bindings().page(page)
+	if keyPage == nil {
+		return ""
+	}
+	return messages.T("help.press", keyPage.hints(false))
+}
+
+// showKeyHelp lists the key bindings of every page, those of the current page first
+func (ui *TimerUI) showKeyHelp() {
+	current, _ := ui.pages.GetFrontPage()
+	focus := ui.app.GetFocus()
+	help := helpBinding()
+	if keyPage := ui.bindings().page(current); keyPage != nil {
+		if binding := ui.bindings().binding(keyPage.name + ".help"); binding != nil {
+			help = *binding
+		}
+	}
+
+	header := tview.NewTextView().
+		SetText(" " + messages.T("keys.title")).
+		SetTextColor(tcell.ColorGreen)
+
+	content := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(formatKeyHelp(ui.bindings(), current))
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[yellow] " + messages.T("keys.close", keysLabel(help.keys)))
+
+	helpPage := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(content, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+
+	helpPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || help.matches(event) {
+			ui.pages.RemovePage(keyHelpPage)
+			ui.pages.SwitchToPage(current)
+			if focus != nil {
+				ui.app.SetFocus(focus)
+			}
+			return nil
+		}
+		return event
+	})
+
+	ui.pages.RemovePage(keyHelpPage)
+	ui.pages.AddPage(keyHelpPage, helpPage, true, true)
+	ui.app.SetFocus(content)
+}
+
+// formatKeyHelp lists the key bindings of every page, those of the current page first
+func formatKeyHelp(keys keymap, current string) string {
+	pages := make([]*keyPage, 0, len(keys))
+	if page := keys.page(current); page != nil {
+		pages = append(pages, page)
+	}
+	for i := range keys {
+		if page := &keys[i]; len(pages) == 0 || page != pages[0] {
+			pages = append(pages, page)
+		}
+	}
+
+	var sb strings.Builder
+	for _, page := range pages {
+		fmt.Fprintf(&sb, "[yellow]%s[white]\n", messages.T(page.title))
+		for _, binding := range page.bindings {
+			fmt.Fprintf(&sb, "  %-12s %s\n", keysLabel(binding.keys), messages.T(binding.help))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
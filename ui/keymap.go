@@ -0,0 +1,424 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// keySpec is a key a binding reacts to, letters match regardless of case
+type keySpec struct {
+	name string    // Name as shown in the help overlay, e.g. "s" or "Enter"
+	key  tcell.Key // tcell.KeyRune for printable keys
+	r    rune      // Lower case rune of printable keys
+}
+
+// namedKeys are the keys with names in key bindings, by lower case name
+var namedKeys = map[string]keySpec{
+	"enter":     {name: "Enter", key: tcell.KeyEnter},
+	"esc":       {name: "Esc", key: tcell.KeyEscape},
+	"tab":       {name: "Tab", key: tcell.KeyTab},
+	"backspace": {name: "Backspace", key: tcell.KeyBackspace2},
+	"delete":    {name: "Delete", key: tcell.KeyDelete},
+	"left":      {name: "Left", key: tcell.KeyLeft},
+	"right":     {name: "Right", key: tcell.KeyRight},
+	"up":        {name: "Up", key: tcell.KeyUp},
+	"down":      {name: "Down", key: tcell.KeyDown},
+	"home":      {name: "Home", key: tcell.KeyHome},
+	"end":       {name: "End", key: tcell.KeyEnd},
+	"pgup":      {name: "PgUp", key: tcell.KeyPgUp},
+	"pgdn":      {name: "PgDn", key: tcell.KeyPgDn},
+	"space":     {name: "Space", key: tcell.KeyRune, r: ' '},
+}
+
+// parseKey parses a key name: a single character such as "s" or "?", or a named key such as
+// "Enter", "Left" or "Space"
+func parseKey(name string) (keySpec, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+		if r == ' ' {
+			return namedKeys["space"], nil
+		}
+		return keySpec{name: name, key: tcell.KeyRune, r: unicode.ToLower(r)}, nil
+	}
+	if spec, ok := namedKeys[strings.ToLower(name)]; ok {
+		return spec, nil
+	}
+	return keySpec{}, fmt.Errorf("unknown key %q", name)
+}
+
+// matches reports whether a key event is for the key
+func (k keySpec) matches(event *tcell.EventKey) bool {
+	if k.key == tcell.KeyRune {
+		return event.Key() == tcell.KeyRune && unicode.ToLower(event.Rune()) == k.r
+	}
+	return event.Key() == k.key
+}
+
+// keyBinding is an action of a page and the keys running it
+type keyBinding struct {
+	action string                            // Name in the key_bindings configuration, e.g. "start"
+	keys   []keySpec                         // Keys running the action
+	help   string                            // Message key of the description
+	hint   bool                              // Listed in the status bar of the page
+	run    func(ui *TimerUI, index int) bool // Runs the action, index is the position of the pressed key in keys; false leaves the key unhandled, nil leaves it to the page
+}
+
+// matches reports whether a key event is for one of the keys of the binding
+func (b keyBinding) matches(event *tcell.EventKey) bool {
+	for _, key := range b.keys {
+		if key.matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyPage is a group of pages sharing key bindings
+type keyPage struct {
+	name     string   // Name in the key_bindings configuration, e.g. "main"
+	pages    []string // Names of the pages using the bindings
+	title    string   // Message key of the title in the help overlay
+	bindings []keyBinding
+}
+
+// keymap dispatches keys to the actions bound to them on each page
+type keymap []keyPage
+
+// keys parses key names of the default bindings, which are known to be valid
+func keys(names ...string) []keySpec {
+	specs := make([]keySpec, len(names))
+	for i, name := range names {
+		specs[i], _ = parseKey(name)
+	}
+	return specs
+}
+
+// do runs a UI method as an action
+func do(action func(*TimerUI)) func(*TimerUI, int) bool {
+	return func(ui *TimerUI, _ int) bool {
+		action(ui)
+		return true
+	}
+}
+
+// switchTo switches to a page as an action
+func switchTo(page string) func(*TimerUI, int) bool {
+	return func(ui *TimerUI, _ int) bool {
+		ui.pages.SwitchToPage(page)
+		return true
+	}
+}
+
+// closePage removes a page and returns to another as an action
+func closePage(page, to string) func(*TimerUI, int) bool {
+	return func(ui *TimerUI, _ int) bool {
+		ui.pages.RemovePage(page)
+		ui.pages.SwitchToPage(to)
+		return true
+	}
+}
+
+// statsRange shows the stats page for a range as an action
+func statsRange(rangeType string) func(*TimerUI, int) bool {
+	return func(ui *TimerUI, _ int) bool {
+		ui.showStats(rangeType)
+		return true
+	}
+}
+
+// chartsRange shows the chart pages for a range as an action
+func chartsRange(rangeType RangeType) func(*TimerUI, int) bool {
+	return func(ui *TimerUI, _ int) bool {
+		ui.updateVisualizationPages(rangeType)
+		return true
+	}
+}
+
+// chartPages are the chart pages in the order Left and Right cycle through them
+var chartPages = []string{"productivity", "interruptions", "trends"}
+
+// cycleChart switches to the next chart page, or the previous one for a negative step
+func cycleChart(step int) func(*TimerUI, int) bool {
+	return func(ui *TimerUI, _ int) bool {
+		current, _ := ui.pages.GetFrontPage()
+		for i, page := range chartPages {
+			if page == current {
+				ui.pages.SwitchToPage(chartPages[(i+step+len(chartPages))%len(chartPages)])
+			}
+		}
+		return true
+	}
+}
+
+// helpBinding opens the help overlay, on every page with key bindings
+func helpBinding() keyBinding {
+	return keyBinding{action: "help", keys: keys("?"), help: "keys.help", hint: true, run: do((*TimerUI).showKeyHelp)}
+}
+
+// quitBinding quits the application, on every page with key bindings
+func quitBinding() keyBinding {
+	return keyBinding{action: "quit", keys: keys("q"), help: "keys.quit", hint: true, run: do((*TimerUI).quit)}
+}
+
+// defaultKeymap returns the default key bindings, in the order of the help overlay
+func defaultKeymap() keymap {
+	return keymap{
+		{name: "main", pages: []string{"main"}, title: "keys.page.main", bindings: []keyBinding{
+			{action: "start", keys: keys("s"), help: "keys.start", hint: true, run: do((*TimerUI).startSession)},
+			{action: "end", keys: keys("e"), help: "keys.end", hint: true, run: do((*TimerUI).endSession)},
+			{action: "interrupt", keys: keys("i"), help: "keys.interrupt", hint: true, run: do((*TimerUI).interruptSession)},
+			{action: "back", keys: keys("b"), help: "keys.back_to_work", hint: true, run: do((*TimerUI).backFromInterruption)},
+			{action: "defer", keys: keys("f"), help: "keys.defer", run: do((*TimerUI).deferInterruption)},
+			{action: "focus", keys: keys("n"), help: "keys.focus", run: do((*TimerUI).toggleFocusMode)},
+			{action: "details", keys: keys("Enter"), help: "keys.details", hint: true, run: do((*TimerUI).showSessionDetailsModal)},
+			{action: "rename", keys: keys("r"), help: "keys.rename", run: do((*TimerUI).editCurrentDescription)},
+			{action: "resume", keys: keys("u"), help: "keys.resume", run: do((*TimerUI).resumeSession)},
+			{action: "delete", keys: keys("d"), help: "keys.delete", run: do((*TimerUI).deleteSelectedSession)},
+			{action: "move", keys: keys("g"), help: "keys.move", run: do((*TimerUI).moveSelectedSession)},
+			{action: "add", keys: keys("a"), help: "keys.add", run: do((*TimerUI).showQuickEntry)},
+			{action: "meetings", keys: keys("p"), help: "keys.meetings", run: do((*TimerUI).showMeetings)},
+			{action: "mark", keys: keys("Space"), help: "keys.mark", run: do((*TimerUI).toggleMark)},
+			{action: "bulk", keys: keys("m"), help: "keys.bulk", run: do((*TimerUI).showBulkActions)},
+			{action: "sort", keys: keys("1", "2", "3", "4", "5", "6", "7", "8", "9"), help: "keys.sort", run: func(ui *TimerUI, index int) bool {
+				ui.sortByColumn(index)
+				return true
+			}},
+			{action: "tags", keys: keys("t"), help: "keys.tags", run: do((*TimerUI).showTagManagement)},
+			{action: "settings", keys: keys("o"), help: "keys.settings", run: do((*TimerUI).showSettings)},
+			{action: "stats", keys: keys("v"), help: "keys.stats", hint: true, run: statsRange("day")},
+			helpBinding(),
+			quitBinding(),
+		}},
+		{name: "stats", pages: []string{"stats"}, title: "keys.page.stats", bindings: []keyBinding{
+			{action: "day", keys: keys("d"), help: "keys.day", hint: true, run: statsRange("day")},
+			{action: "week", keys: keys("w"), help: "keys.week", hint: true, run: statsRange("week")},
+			{action: "month", keys: keys("m"), help: "keys.month", hint: true, run: statsRange("month")},
+			{action: "quarter", keys: keys("u"), help: "keys.quarter", hint: true, run: statsRange("quarter")},
+			{action: "year", keys: keys("y"), help: "keys.year", run: statsRange("year")},
+			{action: "all", keys: keys("a"), help: "keys.all", run: statsRange("all")},
+			{action: "range", keys: keys("g"), help: "keys.range", hint: true, run: do((*TimerUI).showDateRangeInput)},
+			{action: "productivity", keys: keys("p", "h"), help: "keys.productivity", run: switchTo("productivity")},
+			{action: "interruptions", keys: keys("i"), help: "keys.interruptions", run: func(ui *TimerUI, _ int) bool {
+				// Left to the interruption dialog while recording an interruption
+				if ui.isInInterruptionMode() {
+					return false
+				}
+				ui.pages.SwitchToPage("interruptions")
+				return true
+			}},
+			{action: "trends", keys: keys("t"), help: "keys.trends", run: switchTo("trends")},
+			{action: "calendar", keys: keys("c"), help: "keys.calendar", run: do((*TimerUI).showCalendar)},
+			{action: "records", keys: keys("r"), help: "keys.records", run: do((*TimerUI).showRecords)},
+			{action: "okr", keys: keys("o"), help: "keys.okr", run: do((*TimerUI).showQuarterReview)},
+			{action: "linked", keys: keys("l"), help: "keys.linked", run: do((*TimerUI).showContinuedTasks)},
+			{action: "back", keys: keys("b", "v"), help: "keys.back", hint: true, run: switchTo("main")},
+			helpBinding(),
+			quitBinding(),
+		}},
+		{name: "charts", pages: chartPages, title: "keys.page.charts", bindings: []keyBinding{
+			{action: "day", keys: keys("d"), help: "keys.day", run: chartsRange(RangeDay)},
+			{action: "week", keys: keys("w"), help: "keys.week", run: chartsRange(RangeWeek)},
+			{action: "month", keys: keys("m"), help: "keys.month", run: chartsRange(RangeMonth)},
+			{action: "quarter", keys: keys("u"), help: "keys.quarter", run: chartsRange(RangeQuarter)},
+			{action: "previous", keys: keys("Left"), help: "keys.previous_chart", hint: true, run: cycleChart(-1)},
+			{action: "next", keys: keys("Right"), help: "keys.next_chart", hint: true, run: cycleChart(1)},
+			{action: "back", keys: keys("b"), help: "keys.back_to_stats", hint: true, run: switchTo("stats")},
+			helpBinding(),
+			quitBinding(),
+		}},
+		{name: "calendar", pages: []string{"calendar"}, title: "keys.page.calendar", bindings: []keyBinding{
+			{action: "open", keys: keys("Enter"), help: "keys.open_day", hint: true},
+			{action: "back", keys: keys("b", "Esc"), help: "keys.back_to_stats", hint: true, run: do((*TimerUI).closeCalendar)},
+			helpBinding(),
+			quitBinding(),
+		}},
+		{name: "day", pages: []string{"day"}, title: "keys.page.day", bindings: []keyBinding{
+			{action: "back", keys: keys("b", "Esc"), help: "keys.back_calendar", hint: true, run: closePage("day", "calendar")},
+			helpBinding(),
+			quitBinding(),
+		}},
+		{name: "records", pages: []string{"records"}, title: "keys.page.records", bindings: []keyBinding{
+			{action: "back", keys: keys("b", "Esc"), help: "keys.back_to_stats", hint: true, run: closePage("records", "stats")},
+			helpBinding(),
+			quitBinding(),
+		}},
+		{name: "quarter", pages: []string{"quarter"}, title: "keys.page.quarter", bindings: []keyBinding{
+			{action: "previous", keys: keys("Left"), help: "keys.prev_quarter", hint: true},
+			{action: "next", keys: keys("Right"), help: "keys.next_quarter", hint: true},
+			{action: "back", keys: keys("b", "Esc"), help: "keys.back_to_stats", hint: true, run: do((*TimerUI).closeQuarterReview)},
+			helpBinding(),
+			quitBinding(),
+		}},
+		{name: "continued", pages: []string{"continued"}, title: "keys.page.continued", bindings: []keyBinding{
+			{action: "back", keys: keys("b", "Esc"), help: "keys.back_to_stats", hint: true, run: closePage("continued", "stats")},
+			helpBinding(),
+			quitBinding(),
+		}},
+		{name: "tags", pages: []string{"tags"}, title: "keys.page.tags", bindings: []keyBinding{
+			{action: "archive", keys: keys("a"), help: "keys.archive_tag", hint: true},
+			{action: "prune", keys: keys("p"), help: "keys.prune_tags", hint: true},
+			{action: "back", keys: keys("b", "Esc"), help: "keys.back", hint: true, run: do((*TimerUI).closeTagManagement)},
+			helpBinding(),
+			quitBinding(),
+		}},
+		// Typed keys go to the form fields, so only named keys are bound
+		{name: "settings", pages: []string{"settings"}, title: "keys.page.settings", bindings: []keyBinding{
+			{action: "back", keys: keys("Esc"), help: "keys.back_unsaved", hint: true, run: do((*TimerUI).closeSettings)},
+		}},
+	}
+}
+
+// newKeymap returns the default key bindings with the configured ones, which map
+// "page.action" to comma separated key names, e.g. "main.start": "x". Returns an error for
+// unknown actions or keys, and for keys bound to two actions of a page.
+func newKeymap(overrides map[string]string) (keymap, error) {
+	keymap := defaultKeymap()
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		binding := keymap.binding(name)
+		if binding == nil {
+			return nil, fmt.Errorf("unknown key binding %q", name)
+		}
+
+		var specs []keySpec
+		for _, key := range strings.Split(overrides[name], ",") {
+			spec, err := parseKey(key)
+			if err != nil {
+				return nil, fmt.Errorf("key binding %q: %w", name, err)
+			}
+			specs = append(specs, spec)
+		}
+		binding.keys = specs
+	}
+
+	for _, page := range keymap {
+		bound := make(map[keySpec]string)
+		for _, binding := range page.bindings {
+			for _, key := range binding.keys {
+				spec := keySpec{key: key.key, r: key.r}
+				if action, taken := bound[spec]; taken && action != binding.action {
+					return nil, fmt.Errorf("key %q is bound to both %s.%s and %s.%s", key.name, page.name, action, page.name, binding.action)
+				}
+				bound[spec] = binding.action
+			}
+		}
+	}
+
+	return keymap, nil
+}
+
+// binding returns the binding of a "page.action" name, nil for unknown names
+func (k keymap) binding(name string) *keyBinding {
+	pageName, action, _ := strings.Cut(name, ".")
+	for i := range k {
+		if k[i].name != pageName {
+			continue
+		}
+		for j := range k[i].bindings {
+			if k[i].bindings[j].action == action {
+				return &k[i].bindings[j]
+			}
+		}
+	}
+	return nil
+}
+
+// page returns the key bindings used on a page, nil for pages handling keys themselves
+func (k keymap) page(name string) *keyPage {
+	for i := range k {
+		for _, page := range k[i].pages {
+			if page == name {
+				return &k[i]
+			}
+		}
+	}
+	return nil
+}
+
+// handle runs the action bound to the key on a page, returning false for unbound keys and
+// actions the page runs itself
+func (k keymap) handle(ui *TimerUI, page string, event *tcell.EventKey) bool {
+	keyPage := k.page(page)
+	if keyPage == nil {
+		return false
+	}
+	for _, binding := range keyPage.bindings {
+		for i, key := range binding.keys {
+			if key.matches(event) {
+				return binding.run != nil && binding.run(ui, i)
+			}
+		}
+	}
+	return false
+}
+
+// action returns the action bound to the key on a page, "" for unbound keys
+func (k keymap) action(page string, event *tcell.EventKey) string {
+	keyPage := k.page(page)
+	if keyPage == nil {
+		return ""
+	}
+	for _, binding := range keyPage.bindings {
+		if binding.matches(event) {
+			return binding.action
+		}
+	}
+	return ""
+}
+
+// keysLabel lists the keys of a binding, runs of three or more consecutive digits as a
+// range such as "1-9"
+func keysLabel(keys []keySpec) string {
+	consecutive := len(keys) > 2
+	for i, key := range keys {
+		if key.key != tcell.KeyRune || !unicode.IsDigit(key.r) || (i > 0 && key.r != keys[i-1].r+1) {
+			consecutive = false
+		}
+	}
+	if consecutive {
+		return keys[0].name + "-" + keys[len(keys)-1].name
+	}
+
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// hints lists the bindings of a page as "(s) start session, (e) end session", only those
+// marked as hints unless all is set
+func (p *keyPage) hints(all bool) string {
+	var hints []string
+	for _, binding := range p.bindings {
+		if binding.hint || all {
+			hints = append(hints, fmt.Sprintf("(%s) %s", keysLabel(binding.keys), messages.T(binding.help)))
+		}
+	}
+	return strings.Join(hints, ", ")
+}
+
+// actionHints lists the named bindings of a page like hints
+func (p *keyPage) actionHints(actions ...string) string {
+	var hints []string
+	for _, action := range actions {
+		for _, binding := range p.bindings {
+			if binding.action == action {
+				hints = append(hints, fmt.Sprintf("(%s) %s", keysLabel(binding.keys), messages.T(binding.help)))
+			}
+		}
+	}
+	return strings.Join(hints, ", ")
+}
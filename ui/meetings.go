@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// checkMeetings interrupts the active session when a declared meeting starts and returns
+// to work when it ends. Attached to the daemon, the daemon records them instead.
+func (ui *TimerUI) checkMeetings(now time.Time) {
+	if ui.activeSession == nil || ui.currentDay == nil || ui.attached {
+		return
+	}
+
+	if meeting, over := ui.currentDay.OverMeeting(ui.activeSession, now); over {
+		ui.backFromInterruptionAt(meeting.End)
+		ui.statusBar.SetText("[green]" + messages.T("status.meeting_over", tview.Escape(meeting.Title)))
+		return
+	}
+
+	if meeting, due := ui.currentDay.DueMeeting(ui.activeSession, now); due {
+		meeting.Recorded = true
+		ui.recordInterruption(meeting.Interruption(ui.activeSession))
+		ui.statusBar.SetText("[yellow]" + messages.T("status.meeting_started", tview.Escape(meeting.Title), meeting.End.Format("15:04")))
+	}
+}
+
+// addMeetings declares meetings such as "9:30-10 Standup; 14:00-15:00 Planning" for the
+// current day
+func (ui *TimerUI) addMeetings(text string) error {
+	startHour := 0
+	if cfg := ui.storage.GetConfig(); cfg != nil {
+		startHour = cfg.DayStartHour
+	}
+	meetings, err := models.ParseMeetings(text, models.DayStartTime(ui.currentDay.Date, startHour))
+	if err != nil {
+		return err
+	}
+
+	// Check for overlaps with the meetings declared from the command line too
+	if ui.reloadDay() {
+		ui.refreshTable()
+	}
+	previous := ui.currentDay.Meetings
+	if err := ui.currentDay.AddMeetings(meetings); err != nil {
+		return err
+	}
+	if err := ui.saveDay(ui.currentDay); err != nil {
+		ui.currentDay.Meetings = previous
+		return fmt.Errorf("failed to save meetings: %w", err)
+	}
+	return nil
+}
+
+// meetingList lists the meetings of the current day, marking those already recorded
+func (ui *TimerUI) meetingList() string {
+	if len(ui.currentDay.Meetings) == 0 {
+		return messages.T("meetings.none")
+	}
+	lines := make([]string, len(ui.currentDay.Meetings))
+	for i, meeting := range ui.currentDay.Meetings {
+		mark := " "
+		if meeting.Recorded {
+			mark = "✓"
+		}
+		lines[i] = fmt.Sprintf("%s %s %s", mark, meeting.Span(), tview.Escape(meeting.Title))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// showMeetings lists today's meetings and asks for more to declare
+func (ui *TimerUI) showMeetings() {
+	list := tview.NewTextView().SetText(ui.meetingList())
+	meetingsField := tview.NewInputField().
+		SetLabel(messages.T("meetings.add") + " ").
+		SetPlaceholder(messages.T("meetings.placeholder")).
+		SetFieldWidth(56)
+
+	closeDialog := func() {
+		ui.pages.RemovePage("input")
+		ui.app.SetFocus(ui.sessionsTable)
+	}
+
+	// submit declares the meetings, keeping the dialog open to correct them on errors
+	submit := func() {
+		if err := ui.addMeetings(meetingsField.GetText()); err != nil {
+			ui.statusBar.SetText(fmt.Sprintf("[red]%s", tview.Escape(err.Error())))
+			return
+		}
+		closeDialog()
+		ui.statusBar.SetText("[green]" + messages.T("status.meetings_declared", len(ui.currentDay.Meetings)))
+	}
+	meetingsField.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			submit()
+		}
+	})
+
+	form := tview.NewForm().
+		AddFormItem(meetingsField).
+		AddButton(messages.T("button.add"), submit).
+		AddButton(messages.T("button.cancel"), closeDialog)
+	form.SetCancelFunc(closeDialog)
+
+	dialog := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, len(ui.currentDay.Meetings)+1, 0, false).
+		AddItem(form, 5, 1, true)
+	dialog.SetBorder(true).SetTitle(" " + messages.T("meetings.title") + " ")
+
+	flex := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(dialog, len(ui.currentDay.Meetings)+8, 1, true).
+			AddItem(nil, 0, 1, false), 66, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	ui.pages.AddPage("input", flex, true, true)
+	ui.app.SetFocus(form)
+}
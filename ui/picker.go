@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// pickerSize is how many tasks the session start picker shows, each picked with its digit
+const pickerSize = 9
+
+// taskPicker holds the tasks offered when starting a session and the one highlighted
+type taskPicker struct {
+	favorites   []models.TaskSuggestion
+	recent      []models.TaskSuggestion
+	suggestions []models.TaskSuggestion // Tasks matching the typed text
+	selected    int                     // Highlighted suggestion, -1 to use the typed text
+}
+
+// newTaskPicker creates a picker offering all favorites and recent tasks
+func newTaskPicker(favorites, recent []models.TaskSuggestion) *taskPicker {
+	picker := &taskPicker{favorites: favorites, recent: recent}
+	picker.filter("")
+	return picker
+}
+
+// filter shows the tasks matching the typed text, highlighting none so Enter keeps the text
+func (p *taskPicker) filter(query string) {
+	p.suggestions = models.FilterTaskSuggestions(p.favorites, p.recent, query, pickerSize)
+	p.selected = -1
+}
+
+// move moves the highlight up or down, back to the typed text above the first task
+func (p *taskPicker) move(delta int) {
+	p.selected += delta
+	if p.selected < -1 {
+		p.selected = -1
+	}
+	if p.selected >= len(p.suggestions) {
+		p.selected = len(p.suggestions) - 1
+	}
+}
+
+// current returns the highlighted task, if any
+func (p *taskPicker) current() (models.TaskSuggestion, bool) {
+	if p.selected < 0 || p.selected >= len(p.suggestions) {
+		return models.TaskSuggestion{}, false
+	}
+	return p.suggestions[p.selected], true
+}
+
+// pick returns the task shown with the digit 1-9
+func (p *taskPicker) pick(digit rune) (models.TaskSuggestion, bool) {
+	index := int(digit - '1')
+	if digit < '1' || digit > '9' || index >= len(p.suggestions) {
+		return models.TaskSuggestion{}, false
+	}
+	return p.suggestions[index], true
+}
+
+// render fills the table with one row per suggestion, marking the highlighted one
+func (p *taskPicker) render(table *tview.Table, now time.Time) {
+	table.Clear()
+	if len(p.suggestions) == 0 {
+		table.SetCell(0, 0, tview.NewTableCell("[gray]No matching tasks, Enter starts a new one"))
+		return
+	}
+	for i, task := range p.suggestions {
+		table.SetCell(i, 0, tview.NewTableCell(formatTaskSuggestion(i, task, i == p.selected, now)))
+	}
+}
+
+// formatTaskSuggestion formats a picker row: its digit, a star for favorites, the
+// description with its estimate and when it was last worked on
+func formatTaskSuggestion(index int, task models.TaskSuggestion, selected bool, now time.Time) string {
+	marker, color := " ", "white"
+	if selected {
+		marker, color = ">", "yellow"
+	}
+	star := " "
+	if task.Favorite {
+		star = "[yellow]*[" + color + "]"
+	}
+
+	row := fmt.Sprintf("[%s]%s %d %s %s", color, marker, index+1, star, tview.Escape(task.Description))
+	if task.Estimate > 0 {
+		row += fmt.Sprintf(" [gray](%s)[%s]", formatDurationHumanReadable(task.Estimate), color)
+	}
+	if !task.LastUsed.IsZero() {
+		row += "  [gray]" + formatLastUsed(task.LastUsed, now)
+	}
+	return row
+}
+
+// formatLastUsed describes how many days ago a task was last worked on
+func formatLastUsed(lastUsed, now time.Time) string {
+	days := int(math.Round(models.DayOf(now, 0).Sub(models.DayOf(lastUsed, 0)).Hours() / 24))
+	switch {
+	case days <= 0:
+		return "today"
+	case days == 1:
+		return "yesterday"
+	default:
+		return fmt.Sprintf("%dd ago", days)
+	}
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// quarterBarWidth is the width of the weekly focus bars at the busiest week
+const quarterBarWidth = 30
+
+// showQuarterReview displays the quarter dashboard for quarterly reviews: weekly focus,
+// goal attainment per week and the top projects. Arrow keys move between quarters.
+func (ui *TimerUI) showQuarterReview() {
+	quarter := models.QuarterStart(time.Now())
+
+	header := tview.NewTextView().
+		SetTextColor(tcell.ColorGreen)
+
+	content := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[yellow] " + ui.statusHint("quarter"))
+
+	// load shows the review of the selected quarter
+	load := func() {
+		review, err := ui.storage.GetQuarterReview(quarter)
+		if err != nil {
+			content.SetText("[red]" + messages.T("quarter.load_failed", err))
+			return
+		}
+		header.SetText(" " + messages.T("quarter.title", review.Label()))
+		content.SetText(formatQuarterReview(review, time.Now())).ScrollToBeginning()
+	}
+	load()
+
+	quarterPage := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(content, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+
+	quarterPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch ui.bindings().action("quarter", event) {
+		case "previous":
+			quarter = quarter.AddDate(0, -3, 0)
+			load()
+			return nil
+		case "next":
+			if next := quarter.AddDate(0, 3, 0); !next.After(time.Now()) {
+				quarter = next
+				load()
+			}
+			return nil
+		}
+		return event
+	})
+
+	ui.pages.RemovePage("quarter")
+	ui.pages.AddPage("quarter", quarterPage, true, true)
+	ui.app.SetFocus(content)
+}
+
+// closeQuarterReview returns from the quarter dashboard to the stats page
+func (ui *TimerUI) closeQuarterReview() {
+	ui.pages.RemovePage("quarter")
+	ui.pages.SwitchToPage("stats")
+}
+
+// formatQuarterReview renders a quarter review as colored text, leaving out weeks that
+// have not started yet
+func formatQuarterReview(review *models.QuarterReview, now time.Time) string {
+	labels := labelColumn("quarter.total_focus", "quarter.goal_attainment")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[yellow]%s[white] (%s - %s)\n", messages.T("quarter.summary"), messages.Date(review.Start, "02 Jan"), messages.Date(review.End, "02 Jan 2006"))
+	fmt.Fprintf(&sb, "  %s %s\n", labels("quarter.total_focus"), formatDurationHumanReadable(review.TotalFocus))
+	fmt.Fprintf(&sb, "  %s %s\n\n", labels("quarter.goal_attainment"), messages.T("quarter.goal_days_of", review.GoalDays, review.TrackedDays, review.Attainment()))
+
+	var busiest time.Duration
+	for _, week := range review.Weeks {
+		if week.Focus > busiest {
+			busiest = week.Focus
+		}
+	}
+
+	fmt.Fprintf(&sb, "[yellow]%s[white]\n", messages.T("quarter.weekly_focus"))
+	for _, week := range review.Weeks {
+		if week.Start.After(now) {
+			break
+		}
+
+		bar := ""
+		if busiest > 0 {
+			bar = strings.Repeat("█", int(float64(quarterBarWidth)*float64(week.Focus)/float64(busiest)))
+		}
+
+		goals, color := messages.T("quarter.no_tracked_days"), "gray"
+		if week.TrackedDays > 0 {
+			goals = messages.T("quarter.goal_days", week.GoalDays, week.TrackedDays)
+			switch {
+			case week.GoalDays == week.TrackedDays:
+				color = "green"
+			case week.GoalDays > 0:
+				color = "yellow"
+			default:
+				color = "red"
+			}
+		}
+
+		fmt.Fprintf(&sb, "  %s  %-8s  [%s]%-15s[white]  [green]%s[white]\n",
+			messages.Date(week.Start, "02 Jan"), formatDurationHumanReadable(week.Focus), color, goals, bar)
+	}
+
+	fmt.Fprintf(&sb, "\n[yellow]%s[white]\n", messages.T("quarter.top_projects"))
+	if len(review.Projects) == 0 {
+		sb.WriteString("  " + messages.T("quarter.no_projects") + "\n")
+	}
+	for _, project := range review.Projects {
+		share := 0.0
+		if review.TotalFocus > 0 {
+			share = float64(project.Focus) / float64(review.TotalFocus) * 100
+		}
+		fmt.Fprintf(&sb, "  %-20s %-8s %3.0f%%\n", project.Project, formatDurationHumanReadable(project.Focus), share)
+	}
+
+	return sb.String()
+}
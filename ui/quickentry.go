@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// addQuickEntry parses a sentence such as "interrupted by call 10:14-10:32" and adds it
+// to the current day, returning a summary of what was logged
+func (ui *TimerUI) addQuickEntry(text string, now time.Time) (string, error) {
+	entry, err := ui.storage.ParseQuickEntry(text, ui.currentDay.Date)
+	if err != nil {
+		return "", err
+	}
+
+	session, changed, err := entry.Apply(ui.currentDay, now)
+	if err != nil {
+		return "", err
+	}
+	if err := ui.saveWithJournal(models.JournalEdit, session, changed); err != nil {
+		return "", fmt.Errorf("failed to save quick entry: %w", err)
+	}
+	return entry.Summary(), nil
+}
+
+// showQuickEntry asks for a sentence describing past work or an interruption and logs it
+func (ui *TimerUI) showQuickEntry() {
+	entryField := tview.NewInputField().
+		SetLabel(messages.T("quick.label") + " ").
+		SetPlaceholder(messages.T("quick.placeholder")).
+		SetFieldWidth(56)
+
+	closeDialog := func() {
+		ui.pages.RemovePage("input")
+		ui.app.SetFocus(ui.sessionsTable)
+	}
+
+	// submit logs the entry, keeping the dialog open to correct the sentence on errors
+	submit := func() {
+		summary, err := ui.addQuickEntry(entryField.GetText(), time.Now())
+		if err != nil {
+			ui.statusBar.SetText(fmt.Sprintf("[red]%s", tview.Escape(err.Error())))
+			return
+		}
+		closeDialog()
+		ui.statusBar.SetText("[green]" + messages.T("status.logged", tview.Escape(summary)))
+		ui.refreshTable()
+	}
+	entryField.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			submit()
+		}
+	})
+
+	form := tview.NewForm().
+		AddFormItem(entryField).
+		AddButton(messages.T("button.log"), submit).
+		AddButton(messages.T("button.cancel"), closeDialog)
+	form.SetBorder(true).SetTitle(" " + messages.T("quick.title") + " ")
+	form.SetCancelFunc(closeDialog)
+
+	flex := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 7, 1, true).
+			AddItem(nil, 0, 1, false), 66, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	ui.pages.AddPage("input", flex, true, true)
+	ui.app.SetFocus(form)
+}
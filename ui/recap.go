@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// showDailyRecap shows a recap of the last working day on the first launch of the day
+func (ui *TimerUI) showDailyRecap() {
+	if cfg := ui.storage.GetConfig(); cfg != nil && cfg.DisableDailyRecap {
+		return
+	}
+
+	now := time.Now()
+	first, err := ui.storage.MarkLaunch(now)
+	if err != nil || !first {
+		return
+	}
+
+	recap, ok := ui.storage.GetRecap(now)
+	if !ok {
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(formatRecap(recap)).
+		AddButtons([]string{messages.T("recap.button")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.pages.RemovePage("recap")
+			ui.app.SetFocus(ui.sessionsTable)
+		})
+
+	ui.pages.AddPage("recap", modal, true, true)
+	ui.app.SetFocus(modal)
+}
+
+// formatRecap describes a recapped day: focus, interruptions by tag, score and the
+// comparison to the previous week
+func formatRecap(recap *models.DailyRecap) string {
+	stats := recap.Stats
+
+	title := messages.T("recap.yesterday", messages.Date(recap.Date, "02 Jan"))
+	if days := int(time.Since(recap.Date).Hours() / 24); days > 1 {
+		title = messages.T("recap.weekday", messages.Date(recap.Date, "Monday"), messages.Date(recap.Date, "02 Jan"))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(title + "\n\n")
+	sb.WriteString(messages.T("recap.focus", formatDurationHumanReadable(stats.TotalWorkDuration), stats.TotalSessions) + "\n")
+
+	if stats.TotalInterruptions == 0 {
+		sb.WriteString(messages.T("recap.no_interruptions") + "\n")
+	} else {
+		breakdown := stats.GetInterruptionBreakdown()
+		sort.Slice(breakdown, func(i, j int) bool {
+			if breakdown[i].Count != breakdown[j].Count {
+				return breakdown[i].Count > breakdown[j].Count
+			}
+			return breakdown[i].Tag < breakdown[j].Tag
+		})
+
+		var tags []string
+		for _, tagStats := range breakdown {
+			tags = append(tags, fmt.Sprintf("%s %d", tagStats.Tag, tagStats.Count))
+		}
+		sb.WriteString(messages.T("recap.interruptions", stats.TotalInterruptions, strings.Join(tags, ", ")) + "\n")
+	}
+	sb.WriteString(messages.T("recap.score", stats.ProductivityScore) + "\n\n")
+
+	change, ok := recap.WorkChange()
+	switch {
+	case !ok:
+		sb.WriteString(messages.T("recap.no_comparison"))
+	case math.Abs(change) < 0.05:
+		sb.WriteString(messages.T("recap.on_average", formatDurationHumanReadable(recap.AverageWork)))
+	case change > 0:
+		sb.WriteString(messages.T("recap.above_average", change*100, formatDurationHumanReadable(recap.AverageWork)))
+	default:
+		sb.WriteString(messages.T("recap.below_average", -change*100, formatDurationHumanReadable(recap.AverageWork)))
+	}
+
+	return sb.String()
+}
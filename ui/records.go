@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// showRecords displays focus streaks, personal bests and achievements
+func (ui *TimerUI) showRecords() {
+	records, fresh, err := ui.storage.UpdateRecords(time.Now())
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.records_failed", err))
+		return
+	}
+
+	header := tview.NewTextView().
+		SetText(" " + messages.T("records.title")).
+		SetTextColor(tcell.ColorGreen)
+
+	content := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(formatRecords(records, fresh))
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[yellow] " + ui.statusHint("records"))
+
+	recordsPage := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(content, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+
+	ui.pages.RemovePage("records")
+	ui.pages.AddPage("records", recordsPage, true, true)
+	ui.app.SetFocus(content)
+}
+
+// formatRecords renders records as colored text, marking achievements earned since the
+// records were last viewed
+func formatRecords(records *models.Records, fresh []models.Achievement) string {
+	// recordDate appends the day a record was set, if any
+	recordDate := func(date string) string {
+		if date == "" {
+			return ""
+		}
+		return fmt.Sprintf(" [gray](%s)[white]", date)
+	}
+
+	// Labels are aligned on the longest one in the language
+	labels := labelColumn("records.current_streak", "records.longest_streak", "records.longest_block", "records.most_focus", "records.fewest")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[yellow]%s[white] %s\n", messages.T("records.streaks"), messages.T("records.daily_goal", records.Goals()))
+	fmt.Fprintf(&sb, "  %s %s\n", labels("records.current_streak"), messages.T("records.days", records.CurrentStreak))
+	fmt.Fprintf(&sb, "  %s %s%s\n\n", labels("records.longest_streak"), messages.T("records.days", records.LongestStreak), recordDate(records.LongestStreakEnd))
+
+	fmt.Fprintf(&sb, "[yellow]%s[white]\n", messages.T("records.personal_bests"))
+	fmt.Fprintf(&sb, "  %s %s%s\n", labels("records.longest_block"), formatDurationHumanReadable(records.LongestBlock), recordDate(records.LongestBlockDate))
+	fmt.Fprintf(&sb, "  %s %s%s\n", labels("records.most_focus"), formatDurationHumanReadable(records.MostFocus), recordDate(records.MostFocusDate))
+	if records.FewestInterruptionsDate != "" {
+		fmt.Fprintf(&sb, "  %s %s%s\n", labels("records.fewest"), messages.T("records.fewest_value", records.FewestInterruptions), recordDate(records.FewestInterruptionsDate))
+	} else {
+		fmt.Fprintf(&sb, "  %s %s\n", labels("records.fewest"), messages.T("records.fewest_unset"))
+	}
+
+	fmt.Fprintf(&sb, "\n[yellow]%s[white]\n", messages.T("records.achievements"))
+	if len(records.Achievements) == 0 {
+		sb.WriteString("  " + messages.T("records.no_achievements") + "\n")
+	}
+	isFresh := make(map[string]bool, len(fresh))
+	for _, achievement := range fresh {
+		isFresh[achievement.ID] = true
+	}
+	for _, achievement := range records.Achievements {
+		marker := ""
+		if isFresh[achievement.ID] {
+			marker = " [green]" + messages.T("records.new") + "[white]"
+		}
+		fmt.Fprintf(&sb, "  ★ %s%s%s\n", achievement.Title, recordDate(achievement.EarnedOn), marker)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// refocusNudgeDuration is how long the status bar stays highlighted once recovery ends
+const refocusNudgeDuration = time.Minute
+
+// checkRecoveryEnd marks the end of the recovery window after the latest return of the
+// active session as the explicit transition back to work, highlighting the status bar and
+// notifying if enabled. Transitions noticed late, e.g. after a restart, are only marked.
+func (ui *TimerUI) checkRecoveryEnd(now time.Time) {
+	if ui.activeSession == nil {
+		return
+	}
+
+	entry, ok := ui.activeSession.PendingRecoveryEnd(now, ui.recoveryTime())
+	if !ok {
+		return
+	}
+
+	// Mark when recovery ended according to the model, not when it was noticed
+	entry.EndTime = entry.StartTime.Add(ui.recoveryTime())
+	if err := ui.saveWithJournal(models.JournalRefocus, ui.activeSession, entry); err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.recovery_end_failed", err))
+		return
+	}
+
+	if now.Sub(entry.EndTime) > refocusNudgeDuration {
+		return
+	}
+
+	ui.refocusedAt = entry.EndTime
+	ui.notifyEvent(integrations.EventRefocus, ui.activeSession, entry)
+
+	if cfg := ui.storage.GetConfig(); cfg != nil && cfg.RecoveryNotify {
+		go func() {
+			if err := integrations.Notify("Interruption Tracker", "Recovery time is over, back to focused work"); err != nil {
+				ui.app.QueueUpdateDraw(func() {
+					ui.statusBar.SetText("[red]" + messages.T("status.notification_failed", err))
+				})
+			}
+		}()
+	}
+}
+
+// refocusNudgeActive reports whether the status bar should still highlight the end of
+// recovery
+func (ui *TimerUI) refocusNudgeActive(now time.Time) bool {
+	return ui.activeSession != nil && !ui.refocusedAt.IsZero() &&
+		now.Sub(ui.refocusedAt) < refocusNudgeDuration && !ui.isInInterruptionMode()
+}
+
+// formatTimer formats a running timer as "MM:SS", or "H:MM:SS" from an hour on
+func formatTimer(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < 0 {
+		d = 0
+	}
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+// interruptionTimer counts up the open interruption of the active session, or down the
+// recovery left after returning, with the color to show it in. Empty otherwise.
+func (ui *TimerUI) interruptionTimer(now time.Time) (text, color string) {
+	if ui.activeSession == nil {
+		return "", ""
+	}
+
+	if entry, ok := ui.activeSession.OpenInterruption(); ok {
+		elapsed := formatTimer(now.Sub(entry.StartTime))
+		if entry.Tag == "" {
+			return messages.T("timer.interrupted", elapsed), "red"
+		}
+		return messages.T("timer.interrupted_tag", elapsed, tagLabel(entry.Tag)), "red"
+	}
+
+	if left, ok := ui.activeSession.RecoveryLeft(now, ui.recoveryTime()); ok {
+		return messages.T("timer.recovery", formatTimer(left)), "yellow"
+	}
+	return "", ""
+}
+
+// updateMainStatusBar sets the main page status bar, highlighted in red with an alert,
+// in green right after recovery ends and leading with the interruption or recovery timer
+// while one runs
+func (ui *TimerUI) updateMainStatusBar(now time.Time) {
+	if ui.alertFlashActive(now) {
+		ui.statusBar.SetBackgroundColor(tcell.ColorDarkRed)
+		ui.statusBar.SetText("[white]" + ui.alertText + "  " + ui.statusHint("main"))
+		return
+	}
+	if ui.refocusNudgeActive(now) {
+		ui.statusBar.SetBackgroundColor(tcell.ColorDarkGreen)
+		ui.statusBar.SetText("[white]" + messages.T("help.recovery_over", ui.statusHint("main")))
+		return
+	}
+
+	ui.statusBar.SetBackgroundColor(tview.Styles.PrimitiveBackgroundColor)
+	if timer, color := ui.interruptionTimer(now); timer != "" {
+		ui.statusBar.SetText(fmt.Sprintf("[%s]%s  [yellow]%s", color, timer, ui.statusHint("main")))
+		return
+	}
+	ui.statusBar.SetText("[yellow]" + ui.statusHint("main"))
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+)
+
+// headerText is the title shown in the main page header
+const headerText = "[green] Interruption Tracker[white]"
+
+// reminderCheckInterval is how often the start reminder is checked
+const reminderCheckInterval = 30 * time.Second
+
+// startReminder checks in the background whether to remind about starting a session,
+// if enabled. Returns a function that stops the checks.
+func (ui *TimerUI) startReminder() func() {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil || !cfg.ReminderEnabled {
+		return func() {}
+	}
+
+	hours, err := models.ParseWorkingHours(cfg.ReminderWorkStart, cfg.ReminderWorkEnd, cfg.ReminderWeekends)
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.reminders_disabled", err))
+		return func() {}
+	}
+
+	idle := models.DefaultReminderIdle
+	if cfg.ReminderIdleMinutes > 0 {
+		idle = time.Duration(cfg.ReminderIdleMinutes) * time.Minute
+	}
+
+	ui.reminder = &models.StartReminder{Hours: hours, Idle: idle}
+	ui.launched = time.Now()
+
+	ticker := time.NewTicker(reminderCheckInterval)
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				ui.app.QueueUpdateDraw(func() {
+					ui.checkStartReminder(now)
+				})
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(stop)
+	}
+}
+
+// restartReminder applies changed reminder settings while running
+func (ui *TimerUI) restartReminder() {
+	if ui.safeMode || ui.stopReminder == nil {
+		return
+	}
+	ui.stopReminder()
+	ui.reminder = nil
+	ui.clearReminderBanner()
+	ui.stopReminder = ui.startReminder()
+}
+
+// checkStartReminder shows the reminder banner, and a desktop notification if enabled,
+// when no session has been active for the idle period during working hours
+func (ui *TimerUI) checkStartReminder(now time.Time) {
+	if ui.reminder == nil {
+		return
+	}
+	if ui.activeSession != nil || !ui.reminder.Hours.Contains(now) {
+		ui.clearReminderBanner()
+		return
+	}
+
+	// Idle time counts from the last session that ended today, or launch if later
+	lastActivity := ui.launched
+	for _, session := range ui.currentDay.Sessions {
+		if session.End != nil && session.End.StartTime.After(lastActivity) {
+			lastActivity = session.End.StartTime
+		}
+	}
+
+	idle, due := ui.reminder.Due(now, lastActivity)
+	if !due && !ui.reminderShown {
+		return
+	}
+
+	message := messages.T("reminder.no_session", formatMinutes(idle))
+	ui.reminderText = message
+	ui.reminderShown = true
+	ui.updateHeader(now)
+
+	if due && ui.storage.GetConfig().ReminderDesktop {
+		go func() {
+			if err := integrations.Notify("Interruption Tracker", message); err != nil {
+				ui.app.QueueUpdateDraw(func() {
+					ui.statusBar.SetText("[red]" + messages.T("status.reminder_failed", err))
+				})
+			}
+		}()
+	}
+}
+
+// clearReminderBanner restores the header once a session starts or working hours end
+func (ui *TimerUI) clearReminderBanner() {
+	if !ui.reminderShown {
+		return
+	}
+	ui.reminderText = ""
+	ui.reminderShown = false
+	ui.updateHeader(time.Now())
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+	"github.com/rivo/tview"
+)
+
+// replayFrame is how often the replay clock advances and the table is redrawn
+const replayFrame = 100 * time.Millisecond
+
+// Speeds a replay can be slowed down or sped up to with - and +
+const (
+	minReplaySpeed = 1
+	maxReplaySpeed = 3600
+)
+
+// replayState steps through a recorded day on a clock running faster than real time
+type replayState struct {
+	day    *models.DailySessions // The recorded day
+	clock  time.Time             // Time of the day shown
+	end    time.Time             // Last entry of the day, where the replay stops
+	speed  float64               // Seconds of the day per real second
+	paused bool
+}
+
+// badge returns the header label of the replay
+func (r *replayState) badge() string {
+	state := fmt.Sprintf("x%g", r.speed)
+	switch {
+	case !r.clock.Before(r.end):
+		state = messages.T("replay.finished")
+	case r.paused:
+		state = messages.T("replay.paused")
+	}
+	return fmt.Sprintf("[black:aqua] %s %s %s %s [-:-]", messages.T("replay.badge"), r.clock.Format("2006-01-02"), messages.Time(r.clock), state)
+}
+
+// advance moves the clock by the real time elapsed at the replay speed, stopping at the
+// end of the day. Reports whether the clock moved.
+func (r *replayState) advance(elapsed time.Duration) bool {
+	if r.paused || !r.clock.Before(r.end) {
+		return false
+	}
+	r.clock = r.clock.Add(time.Duration(float64(elapsed) * r.speed))
+	if r.clock.After(r.end) {
+		r.clock = r.end
+	}
+	return true
+}
+
+// setSpeed changes the replay speed within the allowed range
+func (r *replayState) setSpeed(speed float64) {
+	r.speed = min(max(speed, minReplaySpeed), maxReplaySpeed)
+}
+
+// NewReplayUI creates a read-only UI that replays the recorded day from its first session
+// to its last entry, speed times faster than real time. Nothing is saved while replaying.
+func NewReplayUI(store storage.Store, day *models.DailySessions, speed float64) (*TimerUI, error) {
+	start, end, ok := day.ActivitySpan()
+	if !ok {
+		return nil, fmt.Errorf("no sessions to replay on %s", day.Date.Format("2006-01-02"))
+	}
+
+	ui := &TimerUI{
+		app:     tview.NewApplication(),
+		pages:   tview.NewPages(),
+		storage: store,
+		replay:  &replayState{day: day, clock: start, end: end},
+	}
+	ui.replay.setSpeed(speed)
+	ui.showReplayFrame()
+	ui.setupUI()
+	return ui, nil
+}
+
+// now returns the time the UI shows: the replay clock when replaying, the current time
+// otherwise
+func (ui *TimerUI) now() time.Time {
+	if ui.replay != nil {
+		return ui.replay.clock
+	}
+	return time.Now()
+}
+
+// showReplayFrame shows the recorded day as it was at the replay clock
+func (ui *TimerUI) showReplayFrame() {
+	ui.currentDay = ui.replay.day.AsOf(ui.replay.clock)
+	ui.activeSession = findActiveSession(ui.currentDay)
+	if ui.sessionsTable != nil {
+		ui.refreshTable()
+	}
+}
+
+// replayKeyHandler handles the keys of a replay: space pauses, + and - change the speed
+// and q or Esc quit. Every other key is ignored, so nothing can be changed.
+func (ui *TimerUI) replayKeyHandler(event *tcell.EventKey) {
+	switch {
+	case event.Key() == tcell.KeyCtrlC, event.Key() == tcell.KeyEscape, event.Rune() == 'q':
+		ui.app.Stop()
+	case event.Rune() == ' ':
+		ui.replay.paused = !ui.replay.paused
+	case event.Rune() == '+', event.Rune() == '=':
+		ui.replay.setSpeed(ui.replay.speed * 2)
+	case event.Rune() == '-':
+		ui.replay.setSpeed(ui.replay.speed / 2)
+	}
+}
+
+// runReplay runs the replay until the user quits
+func (ui *TimerUI) runReplay() error {
+	ticker := time.NewTicker(replayFrame)
+	defer ticker.Stop()
+	go func() {
+		for range ticker.C {
+			ui.app.QueueUpdateDraw(func() {
+				if ui.replay.advance(replayFrame) {
+					ui.showReplayFrame()
+				}
+			})
+		}
+	}()
+
+	ui.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		ui.replayKeyHandler(event)
+		return nil
+	})
+
+	ui.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		ui.fitToScreen(screen)
+		ui.updateHeader(ui.replay.clock)
+		ui.statusBar.SetText("[yellow]" + messages.T("help.replay"))
+		return false
+	})
+
+	ui.app.SetRoot(ui.pages, true)
+	return ui.app.Run()
+}
@@ -2,53 +2,83 @@ package ui
 
 import (
 	"fmt"
-	"sort"
+	"os"
 	"time"
 
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
 	"github.com/lukaszraczylo/interruption-tracker/models"
 	"github.com/rivo/tview"
 )
 
-// startSession starts a new work session
 // startSession starts a new work session
 func (ui *TimerUI) startSession() {
 	// Don't start a new session if there's an active one
 	if ui.activeSession != nil {
-		ui.statusBar.SetText("[red]Cannot start a new session while one is active")
+		ui.statusBar.SetText("[red]" + messages.T("status.start_while_active"))
 		return
 	}
 
 	// Set up the action to perform when description is submitted
 	ui.descriptionAction = func(description string) {
-		// Create new session with description
-		entry := models.NewTimeEntry(models.EntryTypeStart, description)
+		ui.beginSession(models.TaskSuggestion{Description: description})
+	}
 
-		// Create a new session with the entry
-		session := models.NewSession(entry)
+	// Create the task picker
+	ui.showSessionStartInput(ui.beginSession)
+}
 
-		// Add session
-		ui.currentDay.Sessions = append(ui.currentDay.Sessions, session)
-		ui.activeSession = session
+// beginSession creates, saves and activates a new session for the task, with its estimate
+// and project if it has them
+func (ui *TimerUI) beginSession(task models.TaskSuggestion) {
+	// Create new session with description
+	entry := models.NewTimeEntry(models.EntryTypeStart, task.Description)
+
+	// Create a new session with the entry
+	session := models.NewSession(entry)
+	session.Estimate = task.Estimate
+	session.Project = task.Project
+	if session.Project == "" {
+		session.Project = ui.workspaceProject()
+	}
 
-		// Save changes
-		err := ui.storage.SaveDailySessions(ui.currentDay)
-		if err != nil {
-			ui.statusBar.SetText(fmt.Sprintf("[red]Error saving session: %v", err))
-		} else {
-			ui.statusBar.SetText("[green]Session started")
-		}
-		ui.refreshTable()
+	// Add session
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, session)
+	ui.activeSession = session
+
+	// Save changes
+	err := ui.saveWithJournal(models.JournalStart, session, entry)
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.save_failed", err))
+	} else {
+		ui.statusBar.SetText("[green]" + messages.T("status.session_started"))
+		ui.clearReminderBanner()
+		ui.setSlackFocus(true)
+		ui.notifyEvent(integrations.EventSessionStart, session, entry)
+	}
+	ui.refreshTable()
+}
+
+// workspaceProject returns the project the configured rules assign to the directory the
+// tracker was started in and the active window, if any
+func (ui *TimerUI) workspaceProject() string {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil || len(cfg.ProjectRules) == 0 {
+		return ""
 	}
 
-	// Create the input dialog
-	ui.showDescriptionInput("Enter Description", "", ui.descriptionAction)
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return integrations.ProjectForDir(cfg.ProjectRules, dir)
 }
 
 // endSession ends the current work session
 func (ui *TimerUI) endSession() {
 	// Check if there's an active session
 	if ui.activeSession == nil {
-		ui.statusBar.SetText("[red]No active session to end")
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_to_end"))
 		return
 	}
 
@@ -56,7 +86,7 @@ func (ui *TimerUI) endSession() {
 	if len(ui.activeSession.SubSessions) > 0 {
 		currentSubSession := ui.activeSession.SubSessions[len(ui.activeSession.SubSessions)-1]
 		if len(currentSubSession.Interruptions) > 0 && len(currentSubSession.Interruptions)%2 != 0 {
-			ui.statusBar.SetText("[red]Cannot end session while interrupted. Return from interruption first")
+			ui.statusBar.SetText("[red]" + messages.T("status.end_while_interrupted"))
 			return
 		}
 	}
@@ -64,7 +94,8 @@ func (ui *TimerUI) endSession() {
 	// Create the end entry
 	entry := models.NewTimeEntry(models.EntryTypeEnd, "")
 
-	// End the active session and the current sub-session
+	// End the active session, its focus mode and the current sub-session
+	ui.activeSession.StopFocusMode(entry.StartTime)
 	ui.activeSession.End = entry
 
 	// End the current sub-session
@@ -74,29 +105,209 @@ func (ui *TimerUI) endSession() {
 	}
 
 	// Mark session as inactive
+	endedSession := ui.activeSession
 	ui.activeSession = nil
 
 	// Save changes
-	err := ui.storage.SaveDailySessions(ui.currentDay)
+	err := ui.saveWithJournal(models.JournalEnd, endedSession, entry)
 	if err != nil {
-		ui.statusBar.SetText(fmt.Sprintf("[red]Error ending session: %v", err))
+		ui.statusBar.SetText("[red]" + messages.T("status.end_failed", err))
 	} else {
-		ui.statusBar.SetText("[green]Session ended")
+		ui.statusBar.SetText("[green]" + messages.T("status.session_ended"))
+		ui.syncEndedSession(endedSession)
+		ui.setSlackFocus(false)
+		ui.notifyEvent(integrations.EventSessionEnd, endedSession, entry)
 	}
 	ui.refreshTable()
 }
 
+// saveWithJournal records the event in the journal and then saves the current day.
+// The journal is written first so a crash during the save can be recovered from it.
+func (ui *TimerUI) saveWithJournal(eventType models.JournalEventType, session *models.Session, entry *models.TimeEntry) error {
+	return ui.saveDayWithJournal(ui.currentDay, eventType, session, entry)
+}
+
+// saveDayWithJournal records the event in the journal and then saves the given day
+func (ui *TimerUI) saveDayWithJournal(day *models.DailySessions, eventType models.JournalEventType, session *models.Session, entry *models.TimeEntry) error {
+	return ui.saveDayWithEvents(day, []*models.JournalEvent{models.NewJournalEvent(eventType, day.Date, session, entry)})
+}
+
+// saveDayWithEvents records several events in the journal and then saves the day once
+func (ui *TimerUI) saveDayWithEvents(day *models.DailySessions, events []*models.JournalEvent) error {
+	var journalErr error
+	for _, event := range events {
+		if err := ui.storage.AppendJournal(event); err != nil && journalErr == nil {
+			journalErr = err
+		}
+	}
+
+	if err := ui.saveDay(day); err != nil {
+		return err
+	}
+
+	if journalErr != nil {
+		return fmt.Errorf("saved, but failed to write journal: %w", journalErr)
+	}
+	return nil
+}
+
+// saveDay saves a day. The current day first takes in what other writers saved to it
+// since, so their changes are not overwritten.
+func (ui *TimerUI) saveDay(day *models.DailySessions) error {
+	if day != ui.currentDay {
+		return ui.storage.SaveDailySessions(day)
+	}
+
+	ui.reloadDay()
+	if err := ui.storage.SaveDailySessions(day); err != nil {
+		return err
+	}
+	ui.dayBase = day.Snapshot()
+	return nil
+}
+
+// reloadDay takes in the changes other writers, such as commands, hotkeys and the daemon,
+// saved to the current day since the UI last loaded or saved it, and follows the active
+// session they started or ended. Returns whether the day changed.
+func (ui *TimerUI) reloadDay() bool {
+	if ui.currentDay == nil || ui.replay != nil {
+		return false
+	}
+	stored, err := ui.storage.LoadDailySessions(ui.currentDay.Date)
+	if err != nil {
+		return false // Keep what the UI has, the next save reports the error
+	}
+	base := ui.dayBase
+	ui.dayBase = stored.Snapshot()
+	if base == nil || !ui.currentDay.Rebase(base, stored) {
+		return false
+	}
+
+	if ui.activeSession != nil && (ui.activeSession.End != nil || !containsSession(ui.currentDay.Sessions, ui.activeSession)) {
+		ui.activeSession = nil
+	}
+	if ui.activeSession == nil && ui.autoEndPending == nil {
+		ui.activeSession = findActiveSession(ui.currentDay)
+	}
+	return true
+}
+
+// syncEndedSession pushes a just-ended session to Toggl Track in the background if enabled
+func (ui *TimerUI) syncEndedSession(session *models.Session) {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil || !cfg.TogglSyncOnEnd {
+		return
+	}
+
+	client, err := integrations.NewTogglClient(cfg, ui.storage.GetDataDir())
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.toggl_disabled", err))
+		return
+	}
+
+	// Sync a copy so later session changes don't race with the push
+	ended := session.Clone()
+	go func() {
+		_, err := client.SyncSessions([]*models.Session{ended})
+		if err != nil {
+			ui.app.QueueUpdateDraw(func() {
+				ui.statusBar.SetText("[red]" + messages.T("status.toggl_failed", err))
+			})
+		}
+	}()
+}
+
+// setSlackFocus sets or clears the Slack focus status in the background if enabled
+func (ui *TimerUI) setSlackFocus(focused bool) {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil || !cfg.SlackFocusEnabled {
+		return
+	}
+
+	client, err := integrations.NewSlackClient(cfg)
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.slack_disabled", err))
+		return
+	}
+
+	go func() {
+		var err error
+		if focused {
+			err = client.StartFocus()
+		} else {
+			err = client.EndFocus()
+		}
+		if err != nil {
+			ui.app.QueueUpdateDraw(func() {
+				ui.statusBar.SetText("[red]" + messages.T("status.slack_failed", err))
+			})
+		}
+	}()
+}
+
+// notifyEvent runs the hook script and fires the webhooks for a tracker event
+func (ui *TimerUI) notifyEvent(event string, session *models.Session, entry *models.TimeEntry) {
+	ui.runHook(event, session, entry)
+	ui.fireWebhooks(event, session, entry)
+}
+
+// runHook executes the user's hook script for a tracker event in the background.
+// Hooks are plugins, so safe mode skips them.
+func (ui *TimerUI) runHook(event string, session *models.Session, entry *models.TimeEntry) {
+	if ui.safeMode {
+		return
+	}
+
+	runner := integrations.NewHookRunner(ui.storage.GetDataDir())
+	if _, ok := runner.Find(event); !ok {
+		return
+	}
+
+	// Build the payload now so later session changes don't leak into it
+	payload := integrations.NewWebhookPayload(event, session, entry)
+	go func() {
+		if err := runner.Run(payload); err != nil {
+			ui.app.QueueUpdateDraw(func() {
+				ui.statusBar.SetText(fmt.Sprintf("[red]%v", err))
+			})
+		}
+	}()
+}
+
+// fireWebhooks notifies the configured webhooks about a tracker event in the background
+func (ui *TimerUI) fireWebhooks(event string, session *models.Session, entry *models.TimeEntry) {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil || len(cfg.Webhooks) == 0 {
+		return
+	}
+
+	notifier := integrations.NewWebhookNotifier(cfg)
+	if !notifier.HasSubscribers(event) {
+		return
+	}
+
+	// Build the payload now so later session changes don't leak into it
+	payload := integrations.NewWebhookPayload(event, session, entry)
+	go func() {
+		if err := notifier.Notify(payload); err != nil {
+			ui.app.QueueUpdateDraw(func() {
+				ui.statusBar.SetText("[red]" + messages.T("status.webhook_failed", err))
+			})
+		}
+	}()
+}
+
 // interruptSession marks an interruption in the current session
 func (ui *TimerUI) interruptSession() {
 	// Check if there's an active session
 	if ui.activeSession == nil {
-		ui.statusBar.SetText("[red]No active session to interrupt")
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_to_interrupt"))
 		return
 	}
 
 	// Check if there's a current sub-session
 	if len(ui.activeSession.SubSessions) == 0 {
-		ui.statusBar.SetText("[red]No active sub-session to interrupt")
+		ui.statusBar.SetText("[red]" + messages.T("status.no_sub_session_to_interrupt"))
 		return
 	}
 
@@ -105,12 +316,78 @@ func (ui *TimerUI) interruptSession() {
 
 	// Check if there's already an active interruption
 	if len(currentSubSession.Interruptions) > 0 && len(currentSubSession.Interruptions)%2 != 0 {
-		ui.statusBar.SetText("[red]Already interrupted. Press 'b' to return")
+		ui.statusBar.SetText("[red]" + messages.T("status.already_interrupted"))
 		return
 	}
 
 	// Show the tag selection dialog
-	ui.showInterruptionTagSelection()
+	ui.showInterruptionTagSelection(models.EntryTypeInterruption)
+}
+
+// deferInterruption records an interruption that was deflected without leaving work
+func (ui *TimerUI) deferInterruption() {
+	if ui.activeSession == nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_to_defer"))
+		return
+	}
+
+	if ui.isInInterruptionMode() {
+		ui.statusBar.SetText("[red]" + messages.T("status.already_interrupted"))
+		return
+	}
+
+	ui.showInterruptionTagSelection(models.EntryTypeDeferred)
+}
+
+// toggleFocusMode turns focus mode of the active session on or off, so interruptions
+// recorded while it is on are reported as coming despite do-not-disturb
+func (ui *TimerUI) toggleFocusMode() {
+	if ui.activeSession == nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_for_focus"))
+		return
+	}
+
+	now := time.Now()
+	message := "[green]" + messages.T("status.focus_mode_on")
+	if !ui.activeSession.StartFocusMode(now) {
+		ui.activeSession.StopFocusMode(now)
+		message = "[green]" + messages.T("status.focus_mode_off")
+	}
+
+	if err := ui.saveWithJournal(models.JournalFocusMode, ui.activeSession, nil); err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.focus_mode_failed", err))
+	} else {
+		ui.statusBar.SetText(message)
+	}
+	ui.refreshTable()
+}
+
+// recordTaggedEntry records an interruption or deferral chosen in the tag selection dialog
+func (ui *TimerUI) recordTaggedEntry(entryType models.EntryType, description string, tag models.InterruptionTag) {
+	if description != "" {
+		// Ignore errors as the tag model only improves suggestions
+		ui.storage.LearnTag(description, tag)
+	}
+
+	if entryType == models.EntryTypeDeferred {
+		ui.recordDeferral(models.NewDeferredEntry(description, tag))
+		return
+	}
+	ui.recordInterruption(models.NewInterruptionEntry(description, tag))
+}
+
+// recordDeferral adds a deferred interruption to the active session
+func (ui *TimerUI) recordDeferral(entry *models.TimeEntry) {
+	ui.activeSession.Deferred = append(ui.activeSession.Deferred, entry)
+
+	err := ui.saveWithJournal(models.JournalDefer, ui.activeSession, entry)
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.deferral_failed", err))
+	} else {
+		count := ui.currentDay.GetDeflections()
+		ui.statusBar.SetText("[green]" + messages.T("status.deferred", count.Rate()*100))
+	}
+	ui.refreshTable()
 }
 
 // recordInterruption adds an interruption entry to the active session
@@ -127,11 +404,17 @@ func (ui *TimerUI) recordInterruption(entry *models.TimeEntry) {
 		ui.activeSession.Interruptions = append(ui.activeSession.Interruptions, entry)
 
 		// Save changes
-		err := ui.storage.SaveDailySessions(ui.currentDay)
+		err := ui.saveWithJournal(models.JournalInterrupt, ui.activeSession, entry)
 		if err != nil {
-			ui.statusBar.SetText(fmt.Sprintf("[red]Error recording interruption: %v", err))
+			ui.statusBar.SetText("[red]" + messages.T("status.interruption_failed", err))
 		} else {
-			ui.statusBar.SetText("[yellow]Session interrupted")
+			if ui.activeSession.InFocusMode(entry.StartTime) {
+				ui.statusBar.SetText("[yellow]" + messages.T("status.interrupted_despite_focus"))
+			} else {
+				ui.statusBar.SetText("[yellow]" + messages.T("status.session_interrupted"))
+			}
+			ui.setSlackFocus(false)
+			ui.notifyEvent(integrations.EventInterrupt, ui.activeSession, entry)
 		}
 		ui.refreshTable()
 	} else {
@@ -140,11 +423,13 @@ func (ui *TimerUI) recordInterruption(entry *models.TimeEntry) {
 		ui.activeSession.Interruptions = append(ui.activeSession.Interruptions, entry)
 
 		// Save changes
-		err := ui.storage.SaveDailySessions(ui.currentDay)
+		err := ui.saveWithJournal(models.JournalInterrupt, ui.activeSession, entry)
 		if err != nil {
-			ui.statusBar.SetText(fmt.Sprintf("[red]Error recording interruption: %v", err))
+			ui.statusBar.SetText("[red]" + messages.T("status.interruption_failed", err))
 		} else {
-			ui.statusBar.SetText("[yellow]Session interrupted")
+			ui.statusBar.SetText("[yellow]" + messages.T("status.session_interrupted"))
+			ui.setSlackFocus(false)
+			ui.notifyEvent(integrations.EventInterrupt, ui.activeSession, entry)
 		}
 		ui.refreshTable()
 	}
@@ -152,15 +437,20 @@ func (ui *TimerUI) recordInterruption(entry *models.TimeEntry) {
 
 // backFromInterruption marks a return from interruption
 func (ui *TimerUI) backFromInterruption() {
+	ui.backFromInterruptionAt(time.Now())
+}
+
+// backFromInterruptionAt marks a return from interruption at the given time
+func (ui *TimerUI) backFromInterruptionAt(at time.Time) {
 	// Check if there's an active session
 	if ui.activeSession == nil {
-		ui.statusBar.SetText("[red]No active session")
+		ui.statusBar.SetText("[red]" + messages.T("status.no_active_session"))
 		return
 	}
 
 	// Check if there's a current sub-session
 	if len(ui.activeSession.SubSessions) == 0 {
-		ui.statusBar.SetText("[red]No active sub-session")
+		ui.statusBar.SetText("[red]" + messages.T("status.no_active_sub_session"))
 		return
 	}
 
@@ -169,12 +459,13 @@ func (ui *TimerUI) backFromInterruption() {
 
 	// Check if there's an active interruption in the current sub-session
 	if len(currentSubSession.Interruptions) == 0 || len(currentSubSession.Interruptions)%2 == 0 {
-		ui.statusBar.SetText("[red]Not currently interrupted")
+		ui.statusBar.SetText("[red]" + messages.T("status.not_interrupted"))
 		return
 	}
 
 	// Create return entry
 	entry := models.NewTimeEntry(models.EntryTypeReturn, "")
+	entry.StartTime = at
 
 	// Add the return entry to current sub-session
 	currentSubSession.Interruptions = append(currentSubSession.Interruptions, entry)
@@ -183,11 +474,13 @@ func (ui *TimerUI) backFromInterruption() {
 	ui.activeSession.Interruptions = append(ui.activeSession.Interruptions, entry)
 
 	// Save changes
-	err := ui.storage.SaveDailySessions(ui.currentDay)
+	err := ui.saveWithJournal(models.JournalReturn, ui.activeSession, entry)
 	if err != nil {
-		ui.statusBar.SetText(fmt.Sprintf("[red]Error recording return: %v", err))
+		ui.statusBar.SetText("[red]" + messages.T("status.return_failed", err))
 	} else {
-		ui.statusBar.SetText("[green]Returned from interruption")
+		ui.statusBar.SetText("[green]" + messages.T("status.returned"))
+		ui.setSlackFocus(true)
+		ui.notifyEvent(integrations.EventReturn, ui.activeSession, entry)
 	}
 	ui.refreshTable()
 }
@@ -196,7 +489,7 @@ func (ui *TimerUI) backFromInterruption() {
 func (ui *TimerUI) editCurrentDescription() {
 	// Check if there's an active session
 	if ui.activeSession == nil {
-		ui.statusBar.SetText("[red]No active session to edit")
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_to_edit"))
 		return
 	}
 
@@ -209,35 +502,28 @@ func (ui *TimerUI) editCurrentDescription() {
 		ui.activeSession.Start.Description = newDescription
 
 		// Save changes
-		err := ui.storage.SaveDailySessions(ui.currentDay)
+		err := ui.saveWithJournal(models.JournalEdit, ui.activeSession, nil)
 		if err != nil {
-			ui.statusBar.SetText(fmt.Sprintf("[red]Error updating description: %v", err))
+			ui.statusBar.SetText("[red]" + messages.T("status.rename_failed", err))
 		} else {
-			ui.statusBar.SetText("[green]Description updated")
+			ui.statusBar.SetText("[green]" + messages.T("status.description_updated"))
 		}
 		ui.refreshTable()
 	}
 
 	// Show the input dialog with current description
-	ui.showDescriptionInput("Edit Activity Description", currentDesc, updateAction)
+	ui.showDescriptionInput(messages.T("dialog.edit_description"), currentDesc, updateAction)
 }
 
 // deleteSelectedSession deletes the selected session
 func (ui *TimerUI) deleteSelectedSession() {
-	// Get selected row
-	row, _ := ui.sessionsTable.GetSelection()
-
-	// Check if a valid row is selected (row 0 is header)
-	if row <= 0 || row > len(ui.currentDay.Sessions) {
-		ui.statusBar.SetText("[red]No session selected")
+	selectedSession := ui.selectedSession()
+	if selectedSession == nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_selected"))
 		return
 	}
 
-	// Get the session index (row - 1 because row 0 is header)
-	sessionIndex := row - 1
-
 	// Ask for confirmation
-	selectedSession := ui.currentDay.Sessions[sessionIndex]
 	description := selectedSession.Start.Description
 	if description == "" {
 		description = "(no description)"
@@ -252,18 +538,15 @@ func (ui *TimerUI) deleteSelectedSession() {
 				ui.activeSession = nil
 			}
 
-			// Remove session from the slice
-			ui.currentDay.Sessions = append(
-				ui.currentDay.Sessions[:sessionIndex],
-				ui.currentDay.Sessions[sessionIndex+1:]...,
-			)
+			// Remove the session by identity, the day may have changed while confirming
+			ui.currentDay.Sessions = withoutSessions(ui.currentDay.Sessions, []*models.Session{selectedSession})
 
 			// Save changes
-			err := ui.storage.SaveDailySessions(ui.currentDay)
+			err := ui.saveWithJournal(models.JournalDelete, selectedSession, nil)
 			if err != nil {
-				ui.statusBar.SetText(fmt.Sprintf("[red]Error deleting session: %v", err))
+				ui.statusBar.SetText("[red]" + messages.T("status.delete_failed", err))
 			} else {
-				ui.statusBar.SetText("[green]Session deleted")
+				ui.statusBar.SetText("[green]" + messages.T("status.session_deleted"))
 			}
 
 			// Refresh table
@@ -272,62 +555,49 @@ func (ui *TimerUI) deleteSelectedSession() {
 	})
 }
 
-// resumeSession allows resuming a previously ended session
-func (ui *TimerUI) resumeSession() {
-	// Check if there's already an active session
-	if ui.activeSession != nil {
-		ui.statusBar.SetText("[red]Cannot resume while a session is already active")
+// moveSelectedSession moves the selected session to another day, e.g. one started after
+// midnight that belongs to the day before
+func (ui *TimerUI) moveSelectedSession() {
+	session := ui.selectedSession()
+	if session == nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_selected"))
 		return
 	}
-
-	// Get selected row
-	row, _ := ui.sessionsTable.GetSelection()
-
-	// Check if a valid row is selected (row 0 is header)
-	if row <= 0 || row > ui.sessionsTable.GetRowCount()-1 {
-		ui.statusBar.SetText("[red]No session selected")
+	if session.End == nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.move_needs_end"))
 		return
 	}
 
-	// Get actual row index in our sorted display
-	rowIndex := row - 1 // Adjust for header row
-
-	// Create a copy of the sessions to sort (same as in refreshTable)
-	sessionsCopy := make([]*models.Session, len(ui.currentDay.Sessions))
-	copy(sessionsCopy, ui.currentDay.Sessions)
-
-	// Sort sessions with active (no end time) first, then by newest start time (same as in refreshTable)
-	sort.Slice(sessionsCopy, func(i, j int) bool {
-		// Active session check (active first)
-		iActive := sessionsCopy[i].End == nil
-		jActive := sessionsCopy[j].End == nil
-
-		if iActive && !jActive {
-			return true // i is active, j is not, so i comes first
-		}
-		if !iActive && jActive {
-			return false // j is active, i is not, so j comes first
-		}
-
-		// If both active or both inactive, sort by start time (newest first)
-		return sessionsCopy[i].Start.StartTime.After(sessionsCopy[j].Start.StartTime)
+	ui.showMoveInput([]*models.Session{session}, func(summary string, apply func() error) {
+		ui.showConfirmationDialog(summary, func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			if err := apply(); err != nil {
+				ui.statusBar.SetText("[red]" + messages.T("status.move_failed", err))
+			}
+			ui.refreshTable()
+		})
 	})
+}
 
-	// Use the rowIndex to get the selected session from the sorted array
-	var selectedSession *models.Session
-	if rowIndex < len(sessionsCopy) {
-		selectedSession = sessionsCopy[rowIndex]
+// resumeSession allows resuming a previously ended session
+func (ui *TimerUI) resumeSession() {
+	// Check if there's already an active session
+	if ui.activeSession != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.resume_while_active"))
+		return
 	}
 
-	// If no matching session found
+	selectedSession := ui.selectedSession()
 	if selectedSession == nil {
-		ui.statusBar.SetText("[red]Could not identify the selected session")
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_selected"))
 		return
 	}
 
 	// Check if the session has an end marker
 	if selectedSession.End == nil {
-		ui.statusBar.SetText("[red]Session is not ended, no need to resume")
+		ui.statusBar.SetText("[red]" + messages.T("status.resume_not_ended"))
 		return
 	}
 
@@ -360,11 +630,13 @@ func (ui *TimerUI) resumeSession() {
 			ui.activeSession = selectedSession
 
 			// Save changes
-			err := ui.storage.SaveDailySessions(ui.currentDay)
+			err := ui.saveWithJournal(models.JournalResume, selectedSession, newStartEntry)
 			if err != nil {
-				ui.statusBar.SetText(fmt.Sprintf("[red]Error resuming session: %v", err))
+				ui.statusBar.SetText("[red]" + messages.T("status.resume_failed", err))
 			} else {
-				ui.statusBar.SetText("[green]Session resumed with a new time period")
+				ui.statusBar.SetText("[green]" + messages.T("status.session_resumed"))
+				ui.setSlackFocus(true)
+				ui.notifyEvent(integrations.EventSessionResume, selectedSession, newStartEntry)
 			}
 
 			// Refresh table
@@ -373,82 +645,98 @@ func (ui *TimerUI) resumeSession() {
 	})
 }
 
-// refreshDurations updates only the duration cells without redrawing the whole table
-func (ui *TimerUI) refreshDurations() {
-	// Instead of trying to partially update the table, just refresh the whole table
-	// This ensures consistent sorting and indexing between refreshTable and refreshDurations
-	ui.refreshTable()
-}
-
-// refreshTable updates the sessions table with current data
-func (ui *TimerUI) refreshTable() {
-	// Clear existing data (keep header)
-	for i := 1; i < ui.sessionsTable.GetRowCount(); i++ {
-		for j := 0; j < ui.sessionsTable.GetColumnCount(); j++ {
-			ui.sessionsTable.SetCell(i, j, tview.NewTableCell(""))
-		}
+// refreshDurations updates the cells of active sessions, rebuilding the whole table at
+// most every tableRebuildInterval. Unless the session is interrupted or recovering, only
+// the duration changes from second to second, so the other cells are updated once a minute.
+func (ui *TimerUI) refreshDurations(now time.Time) {
+	if ui.rows == nil || now.Sub(ui.rows.rebuilt) >= tableRebuildInterval {
+		ui.refreshTable()
+		return
 	}
 
-	// Create a copy of the sessions to sort
-	sessionsCopy := make([]*models.Session, len(ui.currentDay.Sessions))
-	copy(sessionsCopy, ui.currentDay.Sessions)
+	var columns []string // All columns
+	if session := ui.activeSession; session != nil &&
+		len(session.Interruptions)%2 == 0 &&
+		!session.InRecovery(now, ui.recoveryTime()) &&
+		now.Truncate(time.Minute).Equal(ui.rows.now.Truncate(time.Minute)) {
+		columns = []string{columnDuration}
+	}
+	ui.rows.update(now, columns...)
+}
 
-	// Today's date for comparison (used to identify sessions continued from previous days)
-	today := time.Now().Truncate(24 * time.Hour)
+// updateTicker runs the duration ticker while a session is active and pauses it otherwise
+func (ui *TimerUI) updateTicker() {
+	active := ui.activeSession != nil
+	if ui.ticker == nil || active == ui.ticking {
+		return
+	}
+	ui.ticking = active
+	if active {
+		ui.ticker.Reset(1 * time.Second)
+	} else {
+		ui.ticker.Stop()
+	}
+}
 
-	// Sort sessions with active (no end time) first, then by newest start time
-	sort.Slice(sessionsCopy, func(i, j int) bool {
-		// Active session check (active first)
-		iActive := sessionsCopy[i].End == nil
-		jActive := sessionsCopy[j].End == nil
+// refreshTable rebuilds the sessions table with current data. Rows are only rendered
+// once drawn, so a day with hundreds of sessions rebuilds as fast as a short one.
+func (ui *TimerUI) refreshTable() {
+	row, column := ui.sessionsTable.GetSelection()
+	selectedID, selected := ui.rows.id(row)
 
-		if iActive && !jActive {
-			return true // i is active, j is not, so i comes first
-		}
-		if !iActive && jActive {
-			return false // j is active, i is not, so j comes first
-		}
+	// Sessions in the sorted order, active sessions first, in the columns of the settings
+	rows := newSessionRows(ui, ui.visibleColumns(), ui.displayedSessions(), ui.now())
+	if ui.rows != nil {
+		rows.setMaxWidths(ui.rows.maxWidths)
+	}
+	ui.rows = rows
+	ui.sessionsTable.SetContent(rows)
 
-		// If both active or both inactive, sort by start time (newest first)
-		return sessionsCopy[i].Start.StartTime.After(sessionsCopy[j].Start.StartTime)
-	})
+	// Keep the selected session selected when its row moves
+	if newRow, ok := rows.row(selectedID); selected && ok && newRow != row {
+		ui.sessionsTable.Select(newRow, column)
+	}
 
-	// Add session data in the sorted order
-	for i, session := range sessionsCopy {
-		row := i + 1
+	// Sessions start, end and resume through here
+	ui.updateTicker()
+}
 
-		// Start time (with 2 spaces padding on both sides)
-		startTimeStr := "  " + models.FormatTime(session.Start.StartTime) + "  "
-		ui.sessionsTable.SetCell(row, 0,
-			tview.NewTableCell(startTimeStr))
+// sessionCell returns the cell of a sessions table column for the session, with 2 spaces
+// padding on both sides
+func (ui *TimerUI) sessionCell(column string, session *models.Session, today, now time.Time) *tview.TableCell {
+	switch column {
+	case columnStart:
+		// Sessions marked for a bulk operation
+		if ui.marked[session.ID] {
+			return tview.NewTableCell("* " + messages.Time(session.Start.StartTime) + "  ").
+				SetTextColor(tcell.ColorAqua)
+		}
+		return tview.NewTableCell("  " + messages.Time(session.Start.StartTime) + "  ")
 
-		// End time (with 2 spaces padding on both sides)
+	case columnEnd:
 		endTime := ""
 		if session.End != nil {
-			endTime = models.FormatTime(session.End.StartTime)
+			endTime = messages.Time(session.End.StartTime)
 		}
-		endTimeStr := "  " + endTime + "  "
-		ui.sessionsTable.SetCell(row, 1, tview.NewTableCell(endTimeStr))
+		return tview.NewTableCell("  " + endTime + "  ")
 
-		// Duration - calculate including interruptions (with 2 spaces padding on both sides)
-		duration := computeSessionDuration(session)
-		durationStr := "  " + duration + "  "
-		ui.sessionsTable.SetCell(row, 2, tview.NewTableCell(durationStr))
+	case columnDuration:
+		// Duration - calculate including interruptions
+		duration := computeSessionDuration(session, now)
 
 		// Sub-sessions - show count and current (if active)
-		subSessionsInfo := ""
 		if len(session.SubSessions) > 1 {
-			subSessionsInfo = fmt.Sprintf("%d", len(session.SubSessions))
+			subSessionsInfo := fmt.Sprintf("%d", len(session.SubSessions))
 
 			// If this is the active session, show which sub-session is active
 			if session == ui.activeSession {
 				subSessionsInfo += fmt.Sprintf(" (#%d active)", len(session.SubSessions))
 			}
-
-			ui.sessionsTable.SetCell(row, 2, tview.NewTableCell("  "+duration+" ["+subSessionsInfo+"]  "))
+			duration += " [" + subSessionsInfo + "]"
 		}
+		return tview.NewTableCell("  " + duration + "  ")
 
-		// Interruptions (with 2 spaces padding on both sides)
+	case columnInterruptions:
 		totalInterruptions := 0
 
 		// Count interruptions from all sub-sessions
@@ -465,37 +753,64 @@ func (ui *TimerUI) refreshTable() {
 		// Check if interruption is active
 		if len(session.Interruptions) > 0 && len(session.Interruptions)%2 != 0 {
 			interruptions += " (active)"
-		} else if len(session.Interruptions) > 0 && len(session.Interruptions)%2 == 0 && session.End == nil {
-			// Check if in recovery period (10 minutes after last interruption)
-			lastInterruptionEndTime := session.Interruptions[len(session.Interruptions)-1].StartTime
-			recoveryEndTime := lastInterruptionEndTime.Add(10 * time.Minute)
-
-			if time.Now().Before(recoveryEndTime) {
-				interruptions += " (recovery)"
-			}
+		} else if session.End == nil && session.InRecovery(now, ui.recoveryTime()) {
+			// In the recovery period after the last interruption
+			interruptions += " (recovery)"
 		}
+		return tview.NewTableCell("  " + interruptions + "  ")
 
-		interruptionsStr := "  " + interruptions + "  "
-		ui.sessionsTable.SetCell(row, 3, tview.NewTableCell(interruptionsStr))
+	case columnPattern:
+		// Work/interruption pattern of the session
+		return tview.NewTableCell("  " + sessionSparkline(session, now) + "  ")
 
-		// Description (with 2 spaces padding on both sides)
-		description := session.Start.Description
+	default:
+		descriptionStr := "  " + session.Start.Description
 
-		// Prepare the description string with padding
-		descriptionStr := "  " + description
-
-		// Check if this session started before today (continued from previous day)
-		if session.Start.StartTime.Before(today) {
+		// Check if this session started before today or was split off at the day boundary
+		if session.Start.StartTime.Before(today) || session.ContinuationOf != "" {
 			descriptionStr += " (continued from previous day)"
 		}
+		if session.End == nil && session.FocusModeOn() {
+			descriptionStr += " [DND]"
+		}
+		return tview.NewTableCell(descriptionStr + "  ")
+	}
+}
 
-		// Add trailing padding
-		descriptionStr += "  "
+// formatEstimate shows an estimate with how far the actual work time was from it, green
+// within models.EstimateTolerance, red when work took longer and yellow when shorter
+func formatEstimate(estimate, actual time.Duration) string {
+	if estimate <= 0 {
+		return "-"
+	}
 
-		// Set the cell with the description
-		ui.sessionsTable.SetCell(row, 4, tview.NewTableCell(descriptionStr))
+	diff := (actual - estimate).Round(time.Minute)
+	color := "[green]"
+	sign := "+"
+	switch {
+	case models.EstimateWithinTolerance(estimate, actual):
+	case diff > 0:
+		color = "[red]"
+	default:
+		color = "[yellow]"
+	}
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
 	}
 
-	// Calculate and set column widths based on content
-	calculateTableColumnWidths(ui.sessionsTable)
+	return fmt.Sprintf("%dh %02dm %s(%s%dh %02dm)[white]",
+		int(estimate.Hours()), int(estimate.Minutes())%60,
+		color, sign, int(diff.Hours()), int(diff.Minutes())%60)
+}
+
+// formatEstimateAccuracy describes how estimated sessions compared with their work time
+func formatEstimateAccuracy(accuracy *models.EstimateAccuracy) string {
+	text := "[yellow]Estimation Accuracy:[white]\n"
+	text += fmt.Sprintf("  %s estimated, %s actual over %d session(s) (%.2fx)\n",
+		formatDurationHumanReadable(accuracy.Estimated), formatDurationHumanReadable(accuracy.Actual),
+		accuracy.Sessions, accuracy.Ratio())
+	text += fmt.Sprintf("  %d of %d within ±%.0f%% of the estimate (%.0f%%)\n\n",
+		accuracy.Accurate, accuracy.Sessions, models.EstimateTolerance*100, accuracy.AccurateShare())
+	return text
 }
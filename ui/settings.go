@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/i18n"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// defaultStyles are the tview styles before any theme is applied
+var defaultStyles = tview.Styles
+
+// lightStyles are the styles of the "light" color theme
+var lightStyles = tview.Theme{
+	PrimitiveBackgroundColor:    tcell.ColorWhite,
+	ContrastBackgroundColor:     tcell.ColorLightGray,
+	MoreContrastBackgroundColor: tcell.ColorSilver,
+	BorderColor:                 tcell.ColorBlack,
+	TitleColor:                  tcell.ColorBlack,
+	GraphicsColor:               tcell.ColorBlack,
+	PrimaryTextColor:            tcell.ColorBlack,
+	SecondaryTextColor:          tcell.ColorNavy,
+	TertiaryTextColor:           tcell.ColorDarkGreen,
+	InverseTextColor:            tcell.ColorWhite,
+	ContrastSecondaryTextColor:  tcell.ColorDarkBlue,
+}
+
+// themeStyles returns the styles of a color theme, "dark" and "system" keep the defaults
+func themeStyles(theme string) tview.Theme {
+	switch {
+	case isMonochrome(theme):
+		return monochromeStyles
+	case theme == "light":
+		return lightStyles
+	}
+	return defaultStyles
+}
+
+// messages translates the interface into the configured language, set with the color
+// theme before any view is created
+var messages = i18n.New(string(i18n.English))
+
+// configMessages returns the printer for the language, clock and duration format of the
+// configuration
+func configMessages(cfg *config.Config) *i18n.Printer {
+	return i18n.New(cfg.Locale).WithClock(cfg.ClockFormat).WithDurationFormat(cfg.DurationFormat)
+}
+
+// settingsAnswers holds the settings page fields as entered
+type settingsAnswers struct {
+	RecoveryMinutes string
+	Theme           string
+	Locale          string // Language of the interface, empty for English
+	TwelveHourClock bool
+	Accessible      bool   // Text instead of color-only information
+	Tags            string // Comma separated custom interruption tags
+	RecoveryNotify  bool
+	Reminder        bool
+	ReminderDesktop bool
+	Columns         string // Comma separated sessions table columns, empty for all
+	Sort            string // Column the sessions table is sorted by, empty for the start time
+	SortAscending   bool
+	HourlyRate      string // Cost of an hour of work, empty for none
+	Currency        string
+}
+
+// apply validates the answers and writes them to the configuration
+func (a settingsAnswers) apply(cfg *config.Config) error {
+	recovery, err := strconv.Atoi(strings.TrimSpace(a.RecoveryMinutes))
+	if err != nil || recovery < 1 {
+		return errors.New(messages.T("settings.invalid_recovery"))
+	}
+
+	columns, err := parseSessionColumns(a.Columns)
+	if err != nil {
+		return err
+	}
+	if _, ok := i18n.ParseLocale(a.Locale); a.Locale != "" && !ok {
+		return errors.New(messages.T("settings.unknown_locale", a.Locale))
+	}
+	if _, known := sessionColumnTitles[a.Sort]; a.Sort != "" && !known {
+		return errors.New(messages.T("settings.unknown_sort", a.Sort))
+	}
+
+	var rate float64
+	if text := strings.TrimSpace(a.HourlyRate); text != "" {
+		rate, err = strconv.ParseFloat(text, 64)
+		if err != nil || rate < 0 {
+			return errors.New(messages.T("settings.invalid_rate"))
+		}
+	}
+
+	cfg.RecoveryTime = config.Duration(time.Duration(recovery) * time.Minute)
+	cfg.HourlyRate = rate
+	cfg.Currency = strings.TrimSpace(a.Currency)
+	cfg.ColorTheme = a.Theme
+	cfg.Locale = a.Locale
+	cfg.AccessibleMode = a.Accessible
+	cfg.ClockFormat = ""
+	if a.TwelveHourClock {
+		cfg.ClockFormat = string(i18n.Clock12)
+	}
+	cfg.CustomInterruptionTags = parseCustomTags(a.Tags)
+	cfg.RecoveryNotify = a.RecoveryNotify
+	cfg.ReminderEnabled = a.Reminder
+	cfg.ReminderDesktop = a.ReminderDesktop
+	cfg.SessionColumns = columns
+	cfg.SessionSort = a.Sort
+	cfg.SessionSortAscending = a.SortAscending
+
+	return nil
+}
+
+// recoveryTime returns the configured recovery period after an interruption
+func (ui *TimerUI) recoveryTime() time.Duration {
+	if cfg := ui.storage.GetConfig(); cfg != nil && cfg.RecoveryTime > 0 {
+		return cfg.RecoveryTime.Std()
+	}
+	return models.AssumedRecoveryTime
+}
+
+// applyTheme switches the color theme, views created later pick it up from the styles
+func (ui *TimerUI) applyTheme(theme string) {
+	tview.Styles = themeStyles(theme)
+
+	background := tview.Styles.PrimitiveBackgroundColor
+	ui.mainGrid.SetBackgroundColor(background)
+	ui.header.SetBackgroundColor(background)
+	ui.sessionsTable.SetBackgroundColor(background)
+	ui.statusBar.SetBackgroundColor(background)
+	ui.statsView.SetBackgroundColor(background)
+	ui.statsView.SetTextColor(tview.Styles.PrimaryTextColor)
+}
+
+// showSettings displays the settings page where configuration values can be changed
+// without restarting
+func (ui *TimerUI) showSettings() {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.no_config"))
+		return
+	}
+
+	recoveryField := tview.NewInputField().
+		SetLabel(messages.T("settings.recovery_time")).
+		SetText(fmt.Sprintf("%d", int(ui.recoveryTime().Minutes()))).
+		SetFieldWidth(5).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+
+	themeIndex := 0
+	for i, theme := range themeOptions {
+		if theme == cfg.ColorTheme {
+			themeIndex = i
+		}
+	}
+	themeField := tview.NewDropDown().
+		SetLabel(messages.T("settings.color_theme")).
+		SetOptions(themeOptions, nil).
+		SetCurrentOption(themeIndex)
+
+	locales := i18n.Locales()
+	localeNames := make([]string, len(locales))
+	localeIndex := 0
+	for i, locale := range locales {
+		localeNames[i] = locale.Name()
+		if locale == messages.Locale() {
+			localeIndex = i
+		}
+	}
+	localeField := tview.NewDropDown().
+		SetLabel(messages.T("settings.language")).
+		SetOptions(localeNames, nil).
+		SetCurrentOption(localeIndex)
+	clock, _ := i18n.ParseClock(cfg.ClockFormat)
+	clockField := tview.NewCheckbox().
+		SetLabel(messages.T("settings.twelve_hour_clock")).
+		SetChecked(clock == i18n.Clock12)
+	accessibleField := tview.NewCheckbox().
+		SetLabel(messages.T("settings.accessible")).
+		SetChecked(cfg.AccessibleMode)
+
+	tagsField := tview.NewInputField().
+		SetLabel(messages.T("settings.custom_tags")).
+		SetText(strings.Join(cfg.CustomInterruptionTags, ", ")).
+		SetFieldWidth(40)
+	recoveryNotifyField := tview.NewCheckbox().
+		SetLabel(messages.T("settings.recovery_notify")).
+		SetChecked(cfg.RecoveryNotify)
+	reminderField := tview.NewCheckbox().
+		SetLabel(messages.T("settings.reminder")).
+		SetChecked(cfg.ReminderEnabled)
+	reminderDesktopField := tview.NewCheckbox().
+		SetLabel(messages.T("settings.reminder_desktop")).
+		SetChecked(cfg.ReminderDesktop)
+	columnsField := tview.NewInputField().
+		SetLabel(messages.T("settings.columns")).
+		SetText(strings.Join(ui.visibleColumns(), ", ")).
+		SetFieldWidth(60)
+	sortColumn, sortAscending := ui.sessionSort()
+	sortIndex := 0
+	for i, column := range sessionColumns {
+		if column == sortColumn {
+			sortIndex = i
+		}
+	}
+	sortField := tview.NewDropDown().
+		SetLabel(messages.T("settings.sort")).
+		SetOptions(sessionColumns, nil).
+		SetCurrentOption(sortIndex)
+	sortAscendingField := tview.NewCheckbox().
+		SetLabel(messages.T("settings.sort_ascending")).
+		SetChecked(sortAscending)
+	rateText := ""
+	if cfg.HourlyRate > 0 {
+		rateText = strconv.FormatFloat(cfg.HourlyRate, 'f', -1, 64)
+	}
+	rateField := tview.NewInputField().
+		SetLabel(messages.T("settings.hourly_rate")).
+		SetText(rateText).
+		SetFieldWidth(10).
+		SetAcceptanceFunc(tview.InputFieldFloat)
+	currencyField := tview.NewInputField().
+		SetLabel(messages.T("settings.currency")).
+		SetText(cfg.Currency).
+		SetFieldWidth(6)
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[yellow] " + messages.T("settings.help", ui.bindings().page("settings").hints(false)))
+	if ui.safeMode {
+		footer.SetText("[red] " + messages.T("settings.read_only_back", ui.bindings().page("settings").hints(false)))
+	}
+
+	save := func() {
+		if ui.safeMode {
+			footer.SetText("[red] " + messages.T("settings.read_only"))
+			return
+		}
+
+		_, theme := themeField.GetCurrentOption()
+		_, sortColumn := sortField.GetCurrentOption()
+		localeIndex, _ := localeField.GetCurrentOption()
+		answers := settingsAnswers{
+			RecoveryMinutes: recoveryField.GetText(),
+			Theme:           theme,
+			Locale:          string(locales[localeIndex]),
+			TwelveHourClock: clockField.IsChecked(),
+			Accessible:      accessibleField.IsChecked(),
+			Tags:            tagsField.GetText(),
+			RecoveryNotify:  recoveryNotifyField.IsChecked(),
+			Reminder:        reminderField.IsChecked(),
+			ReminderDesktop: reminderDesktopField.IsChecked(),
+			Columns:         columnsField.GetText(),
+			Sort:            sortColumn,
+			SortAscending:   sortAscendingField.IsChecked(),
+			HourlyRate:      rateField.GetText(),
+			Currency:        currencyField.GetText(),
+		}
+		if err := answers.apply(cfg); err != nil {
+			footer.SetText(fmt.Sprintf("[red] %v", err))
+			return
+		}
+		if err := config.SaveConfig(cfg); err != nil {
+			footer.SetText("[red] " + messages.T("settings.save_failed", err))
+			return
+		}
+
+		// Apply the new values to the running UI, the saved sort replaces the one chosen
+		ui.sortColumn = ""
+		ui.applyTheme(cfg.ColorTheme)
+		messages = configMessages(cfg)
+		applyAccessibility(cfg, ui.screen)
+		ui.restartReminder()
+		ui.closeSettings()
+		ui.refreshTable()
+		ui.statusBar.SetText("[green]" + messages.T("status.settings_saved"))
+	}
+
+	form := tview.NewForm().
+		AddFormItem(recoveryField).
+		AddFormItem(themeField).
+		AddFormItem(localeField).
+		AddFormItem(clockField).
+		AddFormItem(accessibleField).
+		AddFormItem(tagsField).
+		AddFormItem(recoveryNotifyField).
+		AddFormItem(reminderField).
+		AddFormItem(reminderDesktopField).
+		AddFormItem(columnsField).
+		AddFormItem(sortField).
+		AddFormItem(sortAscendingField).
+		AddFormItem(rateField).
+		AddFormItem(currencyField).
+		AddButton(messages.T("button.save"), save).
+		AddButton(messages.T("button.back"), ui.closeSettings)
+
+	header := tview.NewTextView().
+		SetText(" " + messages.T("settings.title")).
+		SetTextColor(tcell.ColorGreen)
+
+	settingsPage := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(form, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+
+	ui.pages.AddPage("settings", settingsPage, true, true)
+	ui.app.SetFocus(form)
+}
+
+// closeSettings returns from the settings page to the main view
+func (ui *TimerUI) closeSettings() {
+	ui.pages.RemovePage("settings")
+	ui.pages.SwitchToPage("main")
+	ui.app.SetFocus(ui.sessionsTable)
+}
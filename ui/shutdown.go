@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// Choices offered when quitting with a session still running
+const (
+	quitEnd     = "End"
+	quitKeep    = "Keep active"
+	quitDiscard = "Discard"
+	quitCancel  = "Cancel"
+)
+
+// quit stops the application, first asking what to do with a running session so no
+// interruption is left open by accident. Quitting again while asked stops right away.
+func (ui *TimerUI) quit() {
+	if ui.activeSession == nil || ui.pages.HasPage("quit") {
+		ui.app.Stop()
+		return
+	}
+
+	session := ui.activeSession
+	description := session.Start.Description
+	if description == "" {
+		description = "(no description)"
+	}
+
+	var text string
+	if interruption, interrupted := session.OpenInterruption(); interrupted {
+		text = fmt.Sprintf("%q is interrupted since %s.\n\n"+
+			"End: return now and end the session\n"+
+			"Keep active: leave it running until next time\n"+
+			"Discard: drop the interruption and end the session when it began",
+			description, messages.ShortTime(interruption.StartTime))
+	} else {
+		text = fmt.Sprintf("%q is still running.\n\n"+
+			"End: end the session now\n"+
+			"Keep active: leave it running until next time\n"+
+			"Discard: delete the session",
+			description)
+	}
+
+	// Return to whatever page quitting was asked from
+	previous := ui.app.GetFocus()
+	if previous == nil {
+		previous = ui.sessionsTable
+	}
+
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{quitEnd, quitKeep, quitDiscard, quitCancel}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.pages.RemovePage("quit")
+			ui.app.SetFocus(previous)
+
+			// Escape cancels too
+			if buttonIndex < 0 || buttonLabel == quitCancel {
+				return
+			}
+			if err := ui.closeOnQuit(buttonLabel, time.Now()); err != nil {
+				ui.statusBar.SetText("[red]" + messages.T("status.quit_failed", err))
+				ui.refreshTable()
+				return
+			}
+			ui.app.Stop()
+		})
+
+	ui.pages.AddPage("quit", modal, true, true)
+	ui.app.SetFocus(modal)
+}
+
+// closeOnQuit applies the choice made when quitting to the active session: ending it now,
+// keeping it running, or discarding what is still open. Ending or discarding leaves no
+// interruption open to skew the next day's statistics.
+func (ui *TimerUI) closeOnQuit(choice string, now time.Time) error {
+	session := ui.activeSession
+	if session == nil {
+		return nil
+	}
+
+	switch choice {
+	case quitKeep:
+		return nil
+
+	case quitEnd:
+		entry := session.EndAt(now)
+		if err := ui.saveWithJournal(models.JournalEnd, session, entry); err != nil {
+			return fmt.Errorf("failed to end session: %w", err)
+		}
+
+	case quitDiscard:
+		interruption, interrupted := session.DiscardOpenInterruption()
+		if !interrupted {
+			ui.currentDay.Sessions = withoutSessions(ui.currentDay.Sessions, []*models.Session{session})
+			ui.activeSession = nil
+			ui.setSlackFocus(false)
+			if err := ui.saveWithJournal(models.JournalDelete, session, nil); err != nil {
+				return fmt.Errorf("failed to delete session: %w", err)
+			}
+			return nil
+		}
+
+		// Work stopped when the interruption began
+		entry := session.EndAt(interruption.StartTime)
+		if err := ui.saveWithJournal(models.JournalEnd, session, entry); err != nil {
+			return fmt.Errorf("failed to end session: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown choice %q", choice)
+	}
+
+	ui.activeSession = nil
+	ui.syncEndedSession(session)
+	ui.setSlackFocus(false)
+	ui.notifyEvent(integrations.EventSessionEnd, session, session.End)
+	return nil
+}
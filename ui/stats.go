@@ -8,158 +8,205 @@ import (
 
 	tcell "github.com/gdamore/tcell/v2"
 	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/stats"
 	"github.com/rivo/tview"
 )
 
-// generateTimelineChart creates a text-based timeline chart for a 24-hour period
+// generateTimelineChart creates a text-based timeline chart for the 24 hours of the current
+// tracking day
 func (ui *TimerUI) generateTimelineChart(sessions []*models.Session) string {
-	// Get the start of the day (midnight)
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return ui.generateDayTimelineChart(ui.storage.DayOf(time.Now()), sessions)
+}
 
-	// Each hour will have 6 slots (10 min each)
-	const intervalsPerHour = 6
-	const totalHours = 24
-	const totalSlots = totalHours * intervalsPerHour
+// Kinds of timeline slots
+const (
+	slotNone        = iota // No activity
+	slotWorking            // Working
+	slotInterrupted        // Interrupted
+	slotRecovery           // Recovering after a return
+	slotContinues          // Session continues past the end of the timeline
+	slotBackToWork         // Transition back to work recorded after recovery
+)
 
-	// Build activity map: 0 = none, 1 = working, 2 = interrupted, 3 = recovery
-	activities := make([]int, totalSlots)
+// timelineSlots classifies count slots of the given length from start by what the sessions
+// were doing. Gaps between the work periods of a resumed session have no activity, and the
+// recovery window after each completed interruption is marked after the slot it ended in.
+func timelineSlots(sessions []*models.Session, start time.Time, slot time.Duration, count int, recovery time.Duration, now time.Time) []int {
+	activities := make([]int, count)
+	end := start.Add(slot * time.Duration(count))
+
+	// slotOf returns the slot of t, clamped to the timeline
+	slotOf := func(t time.Time) int {
+		index := int(t.Sub(start) / slot)
+		if index < 0 {
+			return 0
+		}
+		if index >= count {
+			return count - 1
+		}
+		return index
+	}
 
-	// Process all sessions to fill activity map
 	for _, session := range sessions {
 		if session.Start == nil {
 			continue
 		}
 
-		// Calculate start and end slots
-		startTime := session.Start.StartTime
-
-		// If session started before current day, set startTime to beginning of day
-		if startTime.Before(startOfDay) {
-			startTime = startOfDay
-		}
-
-		var endTime time.Time
-		if session.End != nil {
-			endTime = session.End.StartTime
-		} else {
-			endTime = time.Now()
-		}
-
-		// For timeline display purposes only, cap at end of current day
-		displayEndTime := endTime
-		if endTime.After(startOfDay.Add(24 * time.Hour)) {
-			displayEndTime = startOfDay.Add(24 * time.Hour)
-		}
-
-		startSlot := int(startTime.Sub(startOfDay).Minutes()) / (60 / intervalsPerHour)
-		endSlot := int(displayEndTime.Sub(startOfDay).Minutes()) / (60 / intervalsPerHour)
-
-		if startSlot < 0 {
-			startSlot = 0
+		// Work periods, or the whole session for sessions without them
+		periods := [][2]time.Time{}
+		for _, subSession := range session.SubSessions {
+			if subSession.Start == nil {
+				continue
+			}
+			periodEnd := now
+			if subSession.End != nil {
+				periodEnd = subSession.End.StartTime
+			} else if session.End != nil {
+				periodEnd = session.End.StartTime
+			}
+			periods = append(periods, [2]time.Time{subSession.Start.StartTime, periodEnd})
 		}
-		if endSlot >= totalSlots {
-			endSlot = totalSlots - 1
+		if len(periods) == 0 {
+			sessionEnd := now
+			if session.End != nil {
+				sessionEnd = session.End.StartTime
+			}
+			periods = append(periods, [2]time.Time{session.Start.StartTime, sessionEnd})
 		}
 
-		// Mark working periods
-		for i := startSlot; i <= endSlot && i < totalSlots; i++ {
-			if activities[i] == 0 { // Don't overwrite interruptions/recovery
-				activities[i] = 1 // Working
+		// Mark working periods without overwriting interruptions or recovery
+		for _, period := range periods {
+			if !period[1].After(start) || !period[0].Before(end) {
+				continue
+			}
+			for i := slotOf(period[0]); i <= slotOf(period[1]); i++ {
+				if activities[i] == slotNone {
+					activities[i] = slotWorking
+				}
 			}
 		}
 
-		// If this session continues past midnight, mark the last slot of the day
-		if endTime.After(startOfDay.Add(24*time.Hour)) && endSlot == totalSlots-1 {
-			activities[totalSlots-1] = 4 // Special marker for crossing midnight
+		// If this session continues past the end of the timeline, mark the last slot
+		if lastEnd := periods[len(periods)-1][1]; lastEnd.After(end) {
+			activities[count-1] = slotContinues
 		}
 
 		// Process interruptions and recovery periods
 		for i := 0; i < len(session.Interruptions); i += 2 {
-			// Get interruption start time
 			interruptStart := session.Interruptions[i].StartTime
-
-			// Handle interruptions that start before today or after today
-			// If start is before today but end is today, process the part that falls within today
-			var processInterruption bool = true
-			if interruptStart.Before(startOfDay) {
-				if i+1 < len(session.Interruptions) {
-					interruptEnd := session.Interruptions[i+1].StartTime
-					if interruptEnd.Before(startOfDay) {
-						// Both start and end are before today, skip entirely
-						processInterruption = false
-					} else {
-						// Started yesterday, ended today - adjust start time
-						interruptStart = startOfDay
-					}
-				} else {
-					// Started before today, still ongoing - adjust start time
-					interruptStart = startOfDay
-				}
-			} else if interruptStart.After(startOfDay.Add(24 * time.Hour)) {
-				// Starts after today, skip entirely
-				processInterruption = false
+			interruptEnd := now // Still interrupted
+			if i+1 < len(session.Interruptions) {
+				interruptEnd = session.Interruptions[i+1].StartTime
 			}
 
-			if !processInterruption {
+			// Skip interruptions entirely outside the timeline
+			if interruptEnd.Before(start) || interruptStart.After(end) {
 				continue
 			}
 
-			// Calculate start slot for interruption
-			interruptStartSlot := int(interruptStart.Sub(startOfDay).Minutes()) / (60 / intervalsPerHour)
-			if interruptStartSlot < 0 {
-				interruptStartSlot = 0
+			interruptEndSlot := slotOf(interruptEnd)
+			for j := slotOf(interruptStart); j <= interruptEndSlot; j++ {
+				activities[j] = slotInterrupted
 			}
 
-			// Calculate end slot for interruption
-			var interruptEnd time.Time
+			// Add the recovery period after each completed interruption
 			if i+1 < len(session.Interruptions) {
-				interruptEnd = session.Interruptions[i+1].StartTime
-			} else {
-				interruptEnd = time.Now() // Still interrupted
-			}
+				recoverySlots := int((recovery + slot - 1) / slot)
+				for j := interruptEndSlot + 1; j <= interruptEndSlot+recoverySlots && j < count; j++ {
+					activities[j] = slotRecovery
+				}
 
-			// If interruption ends after today, cap at end of day for display
-			if interruptEnd.After(startOfDay.Add(24 * time.Hour)) {
-				interruptEnd = startOfDay.Add(24 * time.Hour)
+				// Mark the recorded transition back to work after recovery
+				if backToWork := session.Interruptions[i+1].EndTime; !backToWork.IsZero() && !backToWork.Before(start) && backToWork.Before(end) {
+					if backToWorkSlot := slotOf(backToWork); activities[backToWorkSlot] != slotInterrupted {
+						activities[backToWorkSlot] = slotBackToWork
+					}
+				}
 			}
+		}
+	}
 
-			interruptEndSlot := int(interruptEnd.Sub(startOfDay).Minutes()) / (60 / intervalsPerHour)
-			if interruptEndSlot >= totalSlots {
-				interruptEndSlot = totalSlots - 1
-			}
+	return activities
+}
 
-			// Mark interruption on timeline
-			for j := interruptStartSlot; j <= interruptEndSlot && j < totalSlots; j++ {
-				activities[j] = 2 // Interrupted
-			}
+// timelineCell renders a timeline slot
+func timelineCell(kind int) string {
+	switch kind {
+	case slotWorking:
+		return "[green]█[white]"
+	case slotInterrupted:
+		return "[red]█[white]"
+	case slotRecovery:
+		return "[yellow]▒[white]"
+	case slotContinues:
+		return "[blue]→[white]"
+	case slotBackToWork:
+		return "[green]▶[white]"
+	}
+	return "·"
+}
 
-			// Add recovery period after each completed interruption
-			// BUT only for exactly 10 minutes (1 slot)
-			if i+1 < len(session.Interruptions) {
-				// Calculate recovery slots (exactly 1 slot for 10 minutes)
-				recoveryStartSlot := interruptEndSlot + 1
-				recoveryEndSlot := recoveryStartSlot // Only mark one 10-minute slot
+// offHoursCell renders an idle timeline slot outside the work schedule
+const offHoursCell = "[gray]░[white]"
 
-				if recoveryEndSlot < totalSlots {
-					// Mark exactly one 10-minute slot as recovery
-					activities[recoveryEndSlot] = 3 // Recovery
-				}
-			}
-		}
+// workSchedule returns the configured work schedule, false when none is set or it is invalid
+func (ui *TimerUI) workSchedule() (models.WorkingHours, bool) {
+	cfg := ui.storage.GetConfig()
+	if cfg == nil {
+		return models.WorkingHours{}, false
+	}
+	hours, scheduled, err := stats.Schedule(cfg)
+	if err != nil {
+		return models.WorkingHours{}, false
+	}
+	return hours, scheduled
+}
+
+// scheduleLine describes the scheduled hours of a day, for linear timelines
+func scheduleLine(hours models.WorkingHours, day time.Time) string {
+	if !hours.WorksOn(day) {
+		return messages.T("timeline.day_off")
+	}
+	start := hours.StartOn(day)
+	return messages.T("timeline.scheduled", messages.ShortTime(start), messages.ShortTime(start.Add(hours.End-hours.Start)))
+}
+
+// generateDayTimelineChart creates a text-based timeline chart for the 24 hours of the given
+// tracking day, from the configured day start hour
+func (ui *TimerUI) generateDayTimelineChart(day time.Time, sessions []*models.Session) string {
+	// The tracking day starts at day_start_hour, so late work before it belongs to it
+	startHour := 0
+	if cfg := ui.storage.GetConfig(); cfg != nil {
+		startHour = cfg.DayStartHour
 	}
+	startOfDay := models.DayStartTime(day, startHour)
+
+	// Each hour will have 6 slots (10 min each)
+	const intervalsPerHour = 6
+	const totalHours = 24
+	const totalSlots = totalHours * intervalsPerHour
+
+	activities := timelineSlots(sessions, startOfDay, time.Hour/intervalsPerHour, totalSlots, ui.storage.StatsPolicy().Recovery, time.Now())
+	hours, scheduled := ui.workSchedule()
 
 	// Build the timeline chart
 	var chart strings.Builder
 
 	// Title
-	chart.WriteString("[yellow]Daily Activity Timeline (24-Hour View)[white]\n\n")
+	chart.WriteString("[yellow]" + messages.T("timeline.title") + "[white]\n\n")
+	if accessibleMode {
+		if scheduled {
+			chart.WriteString(scheduleLine(hours, startOfDay) + "\n")
+		}
+		chart.WriteString(linearTimeline(activities, startOfDay, time.Hour/intervalsPerHour) + "\n")
+		return chart.String()
+	}
 
 	// Create first timeline row with hour markers embedded
 	for i := 0; i < totalHours; i++ {
 		// Add the hour marker (2 chars) centered in the 6 dots
 		chart.WriteString("[blue]")
-		chart.WriteString(fmt.Sprintf("%02d", i))
+		chart.WriteString(fmt.Sprintf("%02d", startOfDay.Add(time.Duration(i)*time.Hour).Hour()))
 		chart.WriteString("[white]")
 
 		// Add 4 more dots to complete the 6 dots per hour
@@ -167,38 +214,74 @@ func (ui *TimerUI) generateTimelineChart(sessions []*models.Session) string {
 	}
 	chart.WriteString("\n")
 
-	// Second timeline row with activity indicators
-	for i := 0; i < totalHours; i++ {
-		// 6 activity slots per hour
-		for j := 0; j < intervalsPerHour; j++ {
-			slotIndex := (i * intervalsPerHour) + j
-
-			if slotIndex < len(activities) {
-				switch activities[slotIndex] {
-				case 0:
-					chart.WriteString("·") // No activity
-				case 1:
-					chart.WriteString("[green]█[white]") // Working
-				case 2:
-					chart.WriteString("[red]█[white]") // Interrupted
-				case 3:
-					chart.WriteString("[yellow]▒[white]") // Recovery
-				case 4:
-					chart.WriteString("[blue]→[white]") // Continues past midnight
-				}
-			} else {
-				chart.WriteString("·") // Default to no activity
-			}
+	// Second timeline row with activity indicators, idle slots off the work schedule shaded
+	for i, kind := range activities {
+		if scheduled && kind == slotNone && !hours.Contains(startOfDay.Add(time.Duration(i)*time.Hour/intervalsPerHour)) {
+			chart.WriteString(offHoursCell)
+			continue
 		}
+		chart.WriteString(timelineCell(kind))
 	}
 	chart.WriteString("\n\n")
 
 	// Legend
-	chart.WriteString("[green]█[white] Working  [red]█[white] Interrupted [yellow]▒[white] Recovery  [blue]→[white] Continues Past Midnight  · No Activity\n\n")
+	fmt.Fprintf(&chart, "[green]█[white] %s  [red]█[white] %s [yellow]▒[white] %s  [green]▶[white] %s  [blue]→[white] %s  · %s",
+		messages.T("legend.working"), messages.T("legend.interrupted"), messages.T("legend.recovery"),
+		messages.T("legend.back_to_work"), messages.T("legend.continues"), messages.T("legend.no_activity"))
+	if scheduled {
+		chart.WriteString("  " + offHoursCell + " " + messages.T("legend.off_hours"))
+	}
+	chart.WriteString("\n\n")
 
 	return chart.String()
 }
 
+// sessionTimelineWidth is the number of slots in the timeline strip of session details
+const sessionTimelineWidth = 60
+
+// sessionTimelineStrip draws the daily timeline at the scale of one session: its span from
+// start to end (or now) split into sessionTimelineWidth slots, with the start and end times
+// underneath, so the share of work, interruptions and recovery is visible at a glance
+func sessionTimelineStrip(session *models.Session, recovery time.Duration, now time.Time) string {
+	if session.Start == nil {
+		return ""
+	}
+
+	start := session.Start.StartTime
+	end := now
+	if session.End != nil {
+		end = session.End.StartTime
+	}
+	if !end.After(start) {
+		return ""
+	}
+	slot := (end.Sub(start) + sessionTimelineWidth - 1) / sessionTimelineWidth
+
+	activities := timelineSlots([]*models.Session{session}, start, slot, sessionTimelineWidth, recovery, now)
+	if accessibleMode {
+		return linearTimeline(activities, start, slot)
+	}
+
+	var strip strings.Builder
+	strip.WriteString(" ")
+	for _, kind := range activities {
+		strip.WriteString(timelineCell(kind))
+	}
+
+	endLabel := messages.T("timeline.now")
+	if session.End != nil {
+		endLabel = messages.ShortTime(end)
+	}
+	startLabel := messages.ShortTime(start)
+	fmt.Fprintf(&strip, "\n [blue]%s%s%s[white]\n", startLabel,
+		strings.Repeat(" ", sessionTimelineWidth-len(startLabel)-len(endLabel)), endLabel)
+	fmt.Fprintf(&strip, " [green]█[white] %s  [red]█[white] %s  [yellow]▒[white] %s  [green]▶[white] %s  · %s\n",
+		messages.T("legend.working"), messages.T("legend.interrupted"), messages.T("legend.recovery"),
+		messages.T("legend.back_to_work"), messages.T("legend.paused"))
+
+	return strip.String()
+}
+
 // Reference to the tasksTable declared in ui.go
 
 // showStats displays statistics for the selected time range
@@ -219,142 +302,85 @@ func (ui *TimerUI) showStats(rangeType string) {
 	ui.pages.RemovePage("stats")
 	ui.pages.AddPage("stats", ui.createStatsPage(), true, true)
 
-	// Create visualization pages
-	ui.createVisualizationPages()
+	// Create visualization pages for the same range
+	ui.statsRange = rangeType
+	ui.createVisualizationPagesWithRange(RangeType(rangeType))
 
 	// Switch to stats page
 	ui.pages.SwitchToPage("stats")
 
-	// Get saved statistics from storage (does not include active session)
-	workDuration, interruptionDuration, interruptionCount, err := ui.storage.GetStats(rangeType)
+	// Count the stored days of the range with the live active session, once
+	var activeDay time.Time
+	if ui.currentDay != nil {
+		activeDay = ui.currentDay.Date
+	}
+	source, err := stats.Load(ui.storage, rangeType, ui.activeSession, activeDay)
 	if err != nil {
 		ui.statsView.SetText(fmt.Sprintf("[red]Error getting stats: %v", err))
 		return
 	}
-
-	// Add active session stats if it exists - important for showing current interruptions!
-	if ui.activeSession != nil {
-		// Get time range for the active session
-		activeWorkDuration, activeInterruptDuration, activeInterruptCount :=
-			calculateSessionStats(ui.activeSession)
-
-		// Add the active session stats to our totals
-		workDuration += activeWorkDuration
-		interruptionDuration += activeInterruptDuration
-		interruptionCount += activeInterruptCount
+	summaryConfig, err := stats.NewConfig(ui.storage.GetConfig())
+	if err != nil {
+		ui.statsView.SetText(fmt.Sprintf("[red]Error getting stats: %v", err))
+		return
 	}
+	policy, now := ui.storage.StatsPolicy(), time.Now()
 
-	// Format durations
-	totalHours := int(workDuration.Hours())
-	totalMinutes := int(workDuration.Minutes()) % 60
-
-	interruptHours := int(interruptionDuration.Hours())
-	interruptMinutes := int(interruptionDuration.Minutes()) % 60
-
-	// Calculate efficiency percentage with improved algorithm
-	var efficiency float64
-
-	// We'll calculate the total actual session time, including interruptions
-	// This handles resumed sessions correctly by only counting each session's duration
-	// Now properly handles sessions crossing midnight boundaries
-	var totalRawSessionTime time.Duration
+	// Build stats text
+	statsText := formatStatsSummary(rangeDisplayName(rangeType), stats.Summarize(source, policy, summaryConfig, now), policy)
 
-	for _, session := range ui.currentDay.Sessions {
-		if session.Start == nil {
-			continue
-		}
+	// Add timeline chart only for day view
+	if rangeType == "day" {
+		statsText += ui.generateTimelineChart(source.Sessions())
+	}
 
-		// Determine end time for this session - no day boundaries for calculation
-		var sessionEndTime time.Time
-		if session.End != nil {
-			sessionEndTime = session.End.StartTime
-		} else {
-			sessionEndTime = time.Now() // Active session
+	// Add measured time to refocus against the configured recovery time
+	if refocusStats, err := ui.storage.GetRefocusStats(rangeType); err == nil && len(refocusStats) > 0 {
+		statsText += "[yellow]Time to Refocus (measured vs assumed):[white]\n"
+		for _, stat := range refocusStats {
+			measured := "-"
+			if stat.Refocused > 0 {
+				measured = formatDurationHumanReadable(stat.AverageRefocus())
+			}
+			statsText += fmt.Sprintf("  %-10s %s vs %s  (%d of %d returns refocused)\n",
+				stat.Tag, measured, formatDurationHumanReadable(policy.RecoveryFor(stat.Tag)), stat.Refocused, stat.Returns)
 		}
-
-		// Add this session's total duration regardless of day boundaries
-		// This ensures sessions crossing midnight are properly counted
-		totalRawSessionTime += sessionEndTime.Sub(session.Start.StartTime)
+		statsText += "\n"
 	}
 
-	// Calculate total time as the sum of work + interruption
-	totalTime := workDuration + interruptionDuration
-
-	// Make sure we don't divide by zero
-	if totalRawSessionTime > 0 {
-		// Pure work time divided by total session time
-		efficiency = float64(workDuration) / float64(totalRawSessionTime) * 100
+	// Add estimation accuracy for sessions started with an estimate
+	detailedStats, detailedErr := ui.storage.GetDetailedStats(rangeType)
+	if detailedErr == nil && detailedStats.Estimates.Sessions > 0 {
+		statsText += formatEstimateAccuracy(&detailedStats.Estimates)
+	}
 
-		// Cap efficiency at 100%
-		if efficiency > 100 {
-			efficiency = 100.0
+	// Add the cost of interruptions at the configured hourly rate
+	if detailedErr == nil {
+		if costs, total, ok := ui.storage.InterruptionCosts(detailedStats); ok && total.Count > 0 {
+			statsText += formatInterruptionCosts(costs, total, ui.storage.GetConfig().Currency)
 		}
-	} else if totalTime > 0 {
-		// Fallback calculation
-		efficiency = float64(workDuration) / float64(totalTime) * 100
 	}
 
-	// Build stats text
-	rangeText := ""
-	switch rangeType {
-	case "day":
-		rangeText = "Today"
-	case "week":
-		rangeText = "This Week"
-	case "month":
-		rangeText = "This Month"
+	// Add the interruptions that got through focus mode
+	if detailedErr == nil && detailedStats.DND.Time > 0 {
+		statsText += formatDND(detailedStats.DND)
 	}
 
-	statsText := fmt.Sprintf(`[yellow]Statistics for %s:
-
-[green]Total Work Time:[white] %d hours, %d minutes
-[red]Total Interruption Time*:[white] %d hours, %d minutes
-[yellow]Number of Interruptions:[white] %d
-[cyan]Work Efficiency:[white] %.1f%%
-
-[gray]*Includes a 10-minute recovery period after each interruption to account for context switching costs[white]
-
-`,
-		rangeText,
-		totalHours, totalMinutes,
-		interruptHours, interruptMinutes,
-		interruptionCount,
-		efficiency,
-	)
-
-	// Add timeline chart only for day view
-	// Add timeline chart only for day view
-	if rangeType == "day" {
-		// Make a copy of sessions and add active session for chart generation
-		sessions := make([]*models.Session, len(ui.currentDay.Sessions))
-		copy(sessions, ui.currentDay.Sessions)
-
-		// Add active session to the chart
-		if ui.activeSession != nil && !containsSession(sessions, ui.activeSession) {
-			sessions = append(sessions, ui.activeSession)
+	// Add sessions grouped by linked issue
+	if issueStats, err := ui.storage.GetIssueStats(rangeType); err == nil && len(issueStats) > 0 {
+		statsText += "[yellow]Sessions by Issue:[white]\n"
+		for _, issue := range issueStats {
+			statsText += fmt.Sprintf("  %-30s %3d session(s)  %s\n",
+				issue.Key, issue.Sessions, formatDurationHumanReadable(issue.WorkDuration))
 		}
-
-		timelineChart := ui.generateTimelineChart(sessions)
-		statsText += timelineChart
+		statsText += "\n"
 	}
 
 	// Get completed sessions based on the selected range
 	var completedSessions []*models.Session
-	startDate, endDate, _ := ui.storage.GetDateRange(rangeType)
-
-	// Iterate through the date range to collect all completed sessions
-	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
-		// Load sessions for each day in the range
-		dailySessions, err := ui.storage.LoadDailySessions(d)
-		if err != nil {
-			continue // Skip days with errors
-		}
-
-		// Add completed sessions from this day
-		for _, session := range dailySessions.Sessions {
-			if session.End != nil {
-				completedSessions = append(completedSessions, session)
-			}
+	for _, session := range source.Sessions() {
+		if session.End != nil {
+			completedSessions = append(completedSessions, session)
 		}
 	}
 
@@ -362,7 +388,7 @@ func (ui *TimerUI) showStats(rangeType string) {
 	tasksTable.Clear()
 
 	// Set header row for tasks table
-	headers := []string{"Description", "Duration", "Interruptions", "Work Periods", "Total Time"}
+	headers := []string{"Description", "Duration", "Interruptions", "Work Periods", "Total Time", "Estimate"}
 	for i, header := range headers {
 		// Add padding to headers
 		paddedHeader := "  " + header + "  "
@@ -382,67 +408,7 @@ func (ui *TimerUI) showStats(rangeType string) {
 		// Populate the table with session data
 		for i, session := range completedSessions {
 			row := i + 1 // Start at row 1 (after header)
-			// Get total work duration from all sub-sessions
-			workDuration := time.Duration(0)
-			totalInterruptions := 0
-
-			// Calculate sub-session stats if they exist
-			if len(session.SubSessions) > 0 {
-				for _, subSession := range session.SubSessions {
-					var subEndTime time.Time
-
-					if subSession.End != nil {
-						subEndTime = subSession.End.StartTime
-					} else {
-						continue // Skip incomplete sub-sessions
-					}
-
-					// Calculate this sub-session's work time
-					subSessionDuration := subEndTime.Sub(subSession.Start.StartTime)
-					subInterruptDuration := time.Duration(0)
-
-					// Calculate interruption time for this sub-session
-					for i := 0; i < len(subSession.Interruptions); i += 2 {
-						if i+1 < len(subSession.Interruptions) {
-							interruptStart := subSession.Interruptions[i].StartTime
-							interruptEnd := subSession.Interruptions[i+1].StartTime
-							subInterruptDuration += interruptEnd.Sub(interruptStart) + (10 * time.Minute) // include recovery
-						}
-					}
-
-					// Don't let interruption time exceed total time
-					if subInterruptDuration > subSessionDuration {
-						subInterruptDuration = subSessionDuration
-					}
-
-					// Add pure work time for this sub-session
-					workDuration += subSessionDuration - subInterruptDuration
-
-					// Count interruptions in this sub-session
-					totalInterruptions += len(subSession.Interruptions) / 2
-				}
-			} else {
-				// Legacy session handling
-				duration := session.End.StartTime.Sub(session.Start.StartTime)
-				interruptCount := len(session.Interruptions) / 2
-				interruptDuration := time.Duration(0)
-
-				for i := 0; i < len(session.Interruptions); i += 2 {
-					if i+1 < len(session.Interruptions) {
-						interruptStart := session.Interruptions[i].StartTime
-						interruptEnd := session.Interruptions[i+1].StartTime
-						interruptDuration += interruptEnd.Sub(interruptStart) + (10 * time.Minute) // include recovery
-					}
-				}
-
-				// Don't let interruption time exceed total time
-				if interruptDuration > duration {
-					interruptDuration = duration
-				}
-
-				workDuration = duration - interruptDuration
-				totalInterruptions = interruptCount
-			}
+			workDuration, _, totalInterruptions := policy.SessionStats(session, time.Now())
 
 			// Format duration
 			hours := int(workDuration.Hours())
@@ -471,6 +437,10 @@ func (ui *TimerUI) showStats(rangeType string) {
 
 			tasksTable.SetCell(row, 3, tview.NewTableCell("  "+workPeriodsStr+"  "))
 			tasksTable.SetCell(row, 4, tview.NewTableCell("  "+totalTimeStr+"  "))
+
+			// Compare the estimate with the work time, without the assumed recovery
+			actual, _, _ := session.GetStats()
+			tasksTable.SetCell(row, 5, tview.NewTableCell("  "+formatEstimate(session.Estimate, actual)+"  "))
 		}
 
 		// Calculate and set optimal column widths based on content
@@ -485,6 +455,7 @@ func (ui *TimerUI) showStats(rangeType string) {
 		tasksTable.SetCell(1, 2, tview.NewTableCell("    "))
 		tasksTable.SetCell(1, 3, tview.NewTableCell("    "))
 		tasksTable.SetCell(1, 4, tview.NewTableCell("    "))
+		tasksTable.SetCell(1, 5, tview.NewTableCell("    "))
 	}
 
 	// Clear the interruptions table
@@ -502,54 +473,16 @@ func (ui *TimerUI) showStats(rangeType string) {
 				SetSelectable(false))
 	}
 
-	// Get interruption tag stats from all days in the range
+	// Get interruption tag stats over the range as the stats policy counts them
 	var allInterruptionStats []models.InterruptionTagStats
 	totalInterruptCount := 0
-
-	// Iterate through the date range to collect all interruption stats
-	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
-		// Load sessions for each day in the range
-		dailySessions, err := ui.storage.LoadDailySessions(d)
-		if err != nil {
-			continue // Skip days with errors
-		}
-
-		// Get stats for this day
-		tagStats := dailySessions.GetInterruptionTagStats()
-
-		// Merge with the overall stats
-		for _, stat := range tagStats {
-			if stat.Count > 0 {
-				// Find matching tag in our running stats
-				found := false
-				for i, existingStat := range allInterruptionStats {
-					if existingStat.Tag == stat.Tag {
-						// Update existing stat
-						allInterruptionStats[i].Count += stat.Count
-						allInterruptionStats[i].TotalTime += stat.TotalTime
-						allInterruptionStats[i].RecoveryTime += stat.RecoveryTime
-						allInterruptionStats[i].TotalWithRecovery += stat.TotalWithRecovery
-						found = true
-						break
-					}
-				}
-
-				// If not found, add it
-				if !found {
-					allInterruptionStats = append(allInterruptionStats, stat)
-				}
-
-				totalInterruptCount += stat.Count
-			}
-		}
-	}
-
-	// Recalculate averages for the aggregated stats
-	for i := range allInterruptionStats {
-		if allInterruptionStats[i].Count > 0 {
-			allInterruptionStats[i].AverageTime = allInterruptionStats[i].TotalTime / time.Duration(allInterruptionStats[i].Count)
-		}
+	if detailedErr == nil {
+		allInterruptionStats = detailedStats.GetInterruptionBreakdown()
+		totalInterruptCount = detailedStats.TotalInterruptions
 	}
+	sort.Slice(allInterruptionStats, func(i, j int) bool {
+		return allInterruptionStats[i].Tag < allInterruptionStats[j].Tag
+	})
 
 	if len(allInterruptionStats) > 0 && totalInterruptCount > 0 {
 		// Format and display each tag's statistics
@@ -606,7 +539,8 @@ func (ui *TimerUI) showStats(rangeType string) {
 		// Calculate and set optimal column widths based on content
 		calculateTableColumnWidths(interruptionsTable)
 
-		statsText += "[gray]Note: A 10-minute recovery period is included after each interruption to account for context switching costs[white]\n\n"
+		statsText += fmt.Sprintf("[gray]Note: Recovery assumes %s after each interruption to account for context switching costs[white]\n\n",
+			formatDurationHumanReadable(policy.Recovery))
 	} else {
 		// Add a "No interruptions" message if there are none
 		interruptionsTable.SetCell(1, 0, tview.NewTableCell("  No interruptions  ").
@@ -617,67 +551,167 @@ func (ui *TimerUI) showStats(rangeType string) {
 			interruptionsTable.SetCell(1, i, tview.NewTableCell("    "))
 		}
 	}
+
+	var recurring []*models.RecurringTask
+	if detailedErr == nil {
+		recurring = detailedStats.Tasks.Recurring()
+	}
+	fillRecurringTable(recurring)
+
 	ui.statsView.SetText(statsText)
 }
 
-// calculateSessionStats computes duration and interruption stats for a session
-// Now correctly handles sessions that cross midnight
-func calculateSessionStats(session *models.Session) (workDuration, interruptDuration time.Duration, interruptCount int) {
-	if session.Start == nil {
-		return 0, 0, 0
+// fillRecurringTable shows completed sessions grouped into recurring tasks
+func fillRecurringTable(tasks []*models.RecurringTask) {
+	recurringTable.Clear()
+
+	headers := []string{"Task", "Sessions", "Total Time", "Avg Time", "Interruptions/h"}
+	for i, header := range headers {
+		recurringTable.SetCell(0, i,
+			tview.NewTableCell("  "+header+"  ").
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignCenter).
+				SetSelectable(false))
 	}
 
-	// Calculate total session time - no limits on duration for crossing midnight
-	var endTime time.Time
-	if session.End != nil {
-		endTime = session.End.StartTime
-	} else {
-		endTime = time.Now()
+	if len(tasks) == 0 {
+		recurringTable.SetCell(1, 0, tview.NewTableCell("  No recurring tasks  ").
+			SetSelectable(false).
+			SetAlign(tview.AlignCenter).
+			SetExpansion(1))
+		for i := 1; i < len(headers); i++ {
+			recurringTable.SetCell(1, i, tview.NewTableCell("    "))
+		}
+		return
 	}
 
-	// Use full duration regardless of day boundaries
-	totalDuration := endTime.Sub(session.Start.StartTime)
-	interruptionDuration := time.Duration(0)
-	interruptionCount := 0
+	for i, task := range tasks {
+		row := i + 1
+		recurringTable.SetCell(row, 0, tview.NewTableCell("  "+task.Name+"  "))
+		recurringTable.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("  %d  ", task.Sessions)))
+		recurringTable.SetCell(row, 2, tview.NewTableCell("  "+formatDurationHumanReadable(task.WorkDuration)+"  "))
+		recurringTable.SetCell(row, 3, tview.NewTableCell("  "+formatDurationHumanReadable(task.AverageWorkTime())+"  "))
+		recurringTable.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("  %.1f  ", task.InterruptionRate())))
+	}
 
-	// Calculate interruption time and count
-	for i := 0; i < len(session.Interruptions); i += 2 {
-		interruptionCount++
+	calculateTableColumnWidths(recurringTable)
+}
 
-		interruptStart := session.Interruptions[i].StartTime
-		var interruptEnd time.Time
+// formatInterruptionCosts formats the cost of interruptions per tag and in total
+func formatInterruptionCosts(costs []models.InterruptionCost, total models.InterruptionCost, currency string) string {
+	text := fmt.Sprintf("[yellow]Cost of Interruptions:[white] [red]%s[white] for %s lost to %d interruption(s) and recovery\n",
+		models.FormatCost(total.Cost, currency), formatDurationHumanReadable(total.Lost()), total.Count)
+	for _, cost := range costs {
+		text += fmt.Sprintf("  %-10s %3d  %-10s %s\n",
+			cost.Tag, cost.Count, formatDurationHumanReadable(cost.Lost()), models.FormatCost(cost.Cost, currency))
+	}
+	return text + "\n"
+}
 
-		if i+1 < len(session.Interruptions) {
-			interruptEnd = session.Interruptions[i+1].StartTime
+// formatDND formats the interruptions despite focus mode against the rate outside it
+func formatDND(dnd models.DNDSummary) string {
+	text := fmt.Sprintf("[yellow]Focus Mode:[white] %d interruption(s) despite DND in %s, %.1f/h against %.1f/h outside it\n",
+		dnd.Interruptions, formatDurationHumanReadable(dnd.Time), dnd.RatePerHour(), dnd.OtherRatePerHour())
 
-			// Add exact 10-minute recovery period for each completed interruption
-			// instead of marking the whole rest of the session
-			interruptionDuration += interruptEnd.Sub(interruptStart) + (10 * time.Minute)
-		} else {
-			// Interruption still active - no recovery time yet
-			interruptEnd = time.Now()
-			interruptionDuration += interruptEnd.Sub(interruptStart)
-		}
+	tags := make([]models.InterruptionTag, 0, len(dnd.ByTag))
+	for tag := range dnd.ByTag {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+	for _, tag := range tags {
+		text += fmt.Sprintf("  %-10s %3d\n", tag, dnd.ByTag[tag])
 	}
+	return text + "\n"
+}
 
-	// Make sure interruption time doesn't exceed total time
-	if interruptionDuration > totalDuration {
-		interruptionDuration = totalDuration
+// policyNote describes how the stats policy counts interruption time
+func policyNote(policy models.StatsPolicy) string {
+	var parts []string
+	if policy.IncludeRecovery {
+		parts = append(parts, fmt.Sprintf("Includes a %s recovery period after each interruption to account for context switching costs",
+			formatDurationHumanReadable(policy.Recovery)))
+	} else {
+		parts = append(parts, "Excludes recovery after interruptions")
+	}
+	if policy.CapBySession {
+		parts = append(parts, "capped by the work period")
+	}
+	if policy.CountActive {
+		parts = append(parts, "ongoing interruptions count up to now")
+	} else {
+		parts = append(parts, "ongoing interruptions are not counted")
 	}
 
-	// Work duration is total time minus interruption time (including recovery periods)
-	workDuration = totalDuration - interruptionDuration
+	// Tags counted differently, in a stable order
+	var breaks, noRecovery, noPenalty []string
+	for _, tag := range sortedPolicyTags(policy) {
+		tagPolicy := policy.Tags[tag]
+		switch {
+		case tagPolicy.NotInterruption:
+			breaks = append(breaks, string(tag))
+		default:
+			if tagPolicy.NoRecovery {
+				noRecovery = append(noRecovery, string(tag))
+			}
+			if tagPolicy.NoScorePenalty {
+				noPenalty = append(noPenalty, string(tag))
+			}
+		}
+	}
+	if len(breaks) > 0 {
+		parts = append(parts, "breaks, not interruptions: "+strings.Join(breaks, ", "))
+	}
+	if len(noRecovery) > 0 {
+		parts = append(parts, "no recovery after: "+strings.Join(noRecovery, ", "))
+	}
+	if len(noPenalty) > 0 {
+		parts = append(parts, "not in the score: "+strings.Join(noPenalty, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
 
-	return workDuration, interruptionDuration, interruptionCount
+// sortedPolicyTags returns the tags with their own policy in alphabetical order
+func sortedPolicyTags(policy models.StatsPolicy) []models.InterruptionTag {
+	tags := make([]models.InterruptionTag, 0, len(policy.Tags))
+	for tag := range policy.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+	return tags
 }
 
+// sessionStats returns the work time, interruption time and number of interruptions of
+// the session under the configured stats policy
+func (ui *TimerUI) sessionStats(session *models.Session) (workDuration, interruptDuration time.Duration, interruptCount int) {
+	return ui.storage.StatsPolicy().SessionStats(session, time.Now())
+}
 
 // containsSession checks if a session slice contains a specific session
 func containsSession(sessions []*models.Session, target *models.Session) bool {
-	for _, s := range sessions {
-		if s == target {
-			return true
-		}
+	return stats.SessionIndex(sessions, target) >= 0
+}
+
+// summaryColors are the label colors of the summary rows on the stats page
+var summaryColors = map[string]string{
+	stats.RowWork:             "green",
+	stats.RowInterruptions:    "yellow",
+	stats.RowInterruptionTime: "red",
+	stats.RowRecovery:         "red",
+	stats.RowImpact:           "red",
+	stats.RowEfficiency:       "cyan",
+	stats.RowScheduled:        "cyan",
+	stats.RowUntracked:        "gray",
+	stats.RowLive:             "gray",
+}
+
+// formatStatsSummary formats the headline statistics of a range with a note on how the
+// policy counts interruptions
+func formatStatsSummary(rangeText string, summary stats.Summary, policy models.StatsPolicy) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "[yellow]%s\n\n", messages.T("stats.for", rangeText))
+	for _, row := range summary.Rows(formatDurationHumanReadable) {
+		fmt.Fprintf(&sb, "[%s]%s:[white] %s\n", summaryColors[row.Key], row.Label, row.Value)
 	}
-	return false
+	fmt.Fprintf(&sb, "\n[gray]%s[white]\n\n", policyNote(policy))
+	return sb.String()
 }
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// deadTagThreshold is how long a custom tag can go unused before it is considered dead
+const deadTagThreshold = 30 * 24 * time.Hour
+
+// isDeadTag reports whether a custom tag has not been used recently enough to keep it around
+func isDeadTag(usage models.TagUsage, now time.Time) bool {
+	if models.IsBuiltinTag(usage.Tag) {
+		return false // Built-in tags are always available
+	}
+	return usage.LastUsed.IsZero() || now.Sub(usage.LastUsed) > deadTagThreshold
+}
+
+// showTagManagement displays tag usage statistics and allows archiving custom tags
+func (ui *TimerUI) showTagManagement() {
+	usage, err := ui.storage.GetTagUsage()
+	if err != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.tag_usage_failed", err))
+		return
+	}
+
+	cfg := ui.storage.GetConfig()
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	// Create tags table
+	tagsTable := tview.NewTable().
+		SetBorders(true).
+		SetFixed(1, 0).
+		SetSelectable(true, false).
+		SetSeparator(tview.Borders.Vertical).
+		SetSelectedStyle(tcell.Style{}.
+			Background(tcell.ColorNavy).
+			Foreground(tcell.ColorWhite)) // Apply selection style only to cell content
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText("[yellow] " + ui.statusHint("tags"))
+
+	// populate fills the table from the current usage and archive state
+	populate := func() {
+		tagsTable.Clear()
+
+		headers := []string{"tags.column.tag", "tags.column.kind", "tags.column.uses", "tags.column.last_used", "tags.column.status"}
+		for i, header := range headers {
+			// Add 2 spaces padding on both sides
+			paddedHeader := "  " + messages.T(header) + "  "
+			tagsTable.SetCell(0, i,
+				tview.NewTableCell(paddedHeader).
+					SetTextColor(tcell.ColorYellow).
+					SetAlign(tview.AlignCenter).
+					SetSelectable(false))
+		}
+
+		now := time.Now()
+		for i, tagUsage := range usage {
+			row := i + 1
+
+			kind := messages.T("tags.custom")
+			if models.IsBuiltinTag(tagUsage.Tag) {
+				kind = messages.T("tags.builtin")
+			}
+
+			lastUsed := messages.T("tags.never")
+			if !tagUsage.LastUsed.IsZero() {
+				lastUsed = tagUsage.LastUsed.Format("2006-01-02")
+			}
+
+			status := "[green]" + messages.T("tags.active")
+			if cfg.IsTagArchived(string(tagUsage.Tag)) {
+				status = "[gray]" + messages.T("tags.archived")
+			} else if isDeadTag(tagUsage, now) {
+				status = "[red]" + messages.T("tags.dead")
+			}
+
+			tagsTable.SetCell(row, 0, tview.NewTableCell("  "+string(tagUsage.Tag)+"  "))
+			tagsTable.SetCell(row, 1, tview.NewTableCell("  "+kind+"  "))
+			tagsTable.SetCell(row, 2, tview.NewTableCell("  "+fmt.Sprintf("%d", tagUsage.Count)+"  "))
+			tagsTable.SetCell(row, 3, tview.NewTableCell("  "+lastUsed+"  "))
+			tagsTable.SetCell(row, 4, tview.NewTableCell("  "+status+"  "))
+		}
+
+		calculateTableColumnWidths(tagsTable)
+	}
+
+	// saveArchive persists archive changes and refreshes the table
+	saveArchive := func(message string) {
+		// Safe mode runs on the defaults, saving them would replace the configuration file
+		if ui.safeMode {
+			footer.SetText("[red] " + messages.T("settings.read_only"))
+			return
+		}
+		if err := config.SaveConfig(cfg); err != nil {
+			footer.SetText("[red] " + messages.T("settings.save_failed", err))
+			return
+		}
+		footer.SetText("[green] " + message)
+		populate()
+	}
+
+	populate()
+
+	header := tview.NewTextView().
+		SetText(" " + messages.T("tags.title")).
+		SetTextColor(tcell.ColorGreen)
+
+	tagsPage := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(header, 1, 0, false).
+		AddItem(tagsTable, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+
+	tagsPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch ui.bindings().action("tags", event) {
+		case "archive":
+			row, _ := tagsTable.GetSelection()
+			if row <= 0 || row > len(usage) {
+				return nil
+			}
+
+			tag := string(usage[row-1].Tag)
+			if models.IsBuiltinTag(usage[row-1].Tag) {
+				footer.SetText("[red] " + messages.T("tags.builtin_archive"))
+				return nil
+			}
+
+			archived := !cfg.IsTagArchived(tag)
+			cfg.SetTagArchived(tag, archived)
+			if archived {
+				saveArchive(messages.T("tags.tag_archived", tag))
+			} else {
+				saveArchive(messages.T("tags.tag_restored", tag))
+			}
+			return nil
+		case "prune":
+			pruned := 0
+			now := time.Now()
+			for _, tagUsage := range usage {
+				if isDeadTag(tagUsage, now) && !cfg.IsTagArchived(string(tagUsage.Tag)) {
+					cfg.SetTagArchived(string(tagUsage.Tag), true)
+					pruned++
+				}
+			}
+			saveArchive(messages.T("tags.pruned", pruned))
+			return nil
+		}
+
+		return event
+	})
+
+	ui.pages.AddPage("tags", tagsPage, true, true)
+	ui.app.SetFocus(tagsTable)
+}
+
+// closeTagManagement returns from the tag management page to the main view
+func (ui *TimerUI) closeTagManagement() {
+	ui.pages.RemovePage("tags")
+	ui.pages.SwitchToPage("main")
+	ui.app.SetFocus(ui.sessionsTable)
+}
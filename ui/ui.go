@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
 	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/scheduler"
 	"github.com/lukaszraczylo/interruption-tracker/storage"
 	"github.com/rivo/tview"
 )
@@ -16,23 +19,92 @@ type TimerUI struct {
 	app           *tview.Application
 	pages         *tview.Pages
 	mainGrid      *tview.Grid
+	header        *tview.TextView
 	sessionsTable *tview.Table
 	statusBar     *tview.TextView
 	inputField    *tview.InputField
 	statsView     *tview.TextView
 
-	storage       *storage.Storage
+	storage       storage.Store
 	currentDay    *models.DailySessions
 	activeSession *models.Session
 
+	// Current day as last loaded or saved, to take in the changes others saved since
+	dayBase *models.DaySnapshot
+
 	// Action to perform when description is submitted
 	descriptionAction func(string)
+
+	// Resolves issue titles for descriptions referencing Jira/GitHub issues
+	issueResolver *integrations.IssueResolver
+
+	// Interruption recorded by the focus watcher, closed when focus returns
+	focusEntry *models.TimeEntry
+
+	// Range shown on the stats page, a named range or a custom one
+	statsRange string
+
+	// End of the latest recovery window, highlighted in the status bar for a moment
+	refocusedAt time.Time
+
+	// Alerts for interruptions and sessions running long, given once each
+	alertedInterruption *models.TimeEntry // Open interruption already alerted about
+	alertedSession      string            // ID of the session already alerted about running past its plan
+	alertText           string            // Latest alert flashed in the status bar
+	alertFlashUntil     time.Time         // End of the status bar flash
+
+	// Reminder to start tracking, nil when disabled
+	reminder      *models.StartReminder
+	reminderShown bool      // Reminder banner is in the header
+	reminderText  string    // Message of the reminder banner
+	launched      time.Time // Idle time for reminders counts from launch at the earliest
+	stopReminder  func()    // Stops the reminder checks, nil until running
+
+	// Safe mode skips integrations, the auto-refresh ticker and the daily recap
+	safeMode bool
+
+	// Attached to the daemon, which sends desktop notifications itself
+	attached bool
+
+	// Replay of a recorded day, nil unless replaying
+	replay *replayState
+
+	// Screen dropping colors for the monochrome theme, nil until running
+	screen *monochromeScreen
+
+	// Key bindings of the pages, the defaults when nil
+	keys keymap
+
+	// Active session carried over from the previous day at startup, announced once running
+	rolledOver *models.Session
+
+	// Sessions marked with Space for bulk operations, by ID
+	marked map[string]bool
+
+	// Session shown in each row of the sessions table, as of the last refresh
+	rows *sessionRows
+
+	// Ticker refreshing durations every second, nil in safe mode
+	ticker  *time.Ticker
+	ticking bool // Paused while no session is active
+
+	// Sort of the sessions table chosen with the number keys, empty for the configured sort
+	sortColumn    string
+	sortAscending bool
+
+	// Sessions left running past the auto-end deadline
+	autoEnded      *models.Session // Ended at startup, announced once running
+	autoEndPending *pendingAutoEnd // Waiting for the user to end or keep it
+	autoEndKept    string          // ID of the session the user chose to keep running
+
+	// Runs scheduled jobs while the UI runs, nil without jobs
+	scheduler *scheduler.Scheduler
 }
 
 // NewTimerUI creates a new UI instance
-func NewTimerUI(storage *storage.Storage) (*TimerUI, error) {
+func NewTimerUI(storage storage.Store) (*TimerUI, error) {
 	// Load today's sessions
-	today := time.Now().Truncate(24 * time.Hour)
+	today := storage.DayOf(time.Now())
 	dailySessions, err := storage.LoadDailySessions(today)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load daily sessions: %w", err)
@@ -44,70 +116,115 @@ func NewTimerUI(storage *storage.Storage) (*TimerUI, error) {
 		pages:      tview.NewPages(),
 		storage:    storage,
 		currentDay: dailySessions,
+		dayBase:    dailySessions.Snapshot(),
 	}
 
-	// Find active session if any
+	// Find active session if any, otherwise one left running on the previous day
+	now := time.Now()
+	day := dailySessions
+	active := findActiveSession(dailySessions)
+	if active == nil {
+		// Ignore errors as previous day may not exist
+		if previousSessions, err := storage.LoadDailySessions(today.AddDate(0, 0, -1)); err == nil {
+			day = previousSessions
+			active = findActiveSession(previousSessions)
+		}
+	}
+	if active != nil {
+		if err := ui.restoreActiveSession(day, active, now); err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize UI components
+	ui.setupUI()
+
+	return ui, nil
+}
+
+// findActiveSession returns the first session of the day that has not ended
+func findActiveSession(dailySessions *models.DailySessions) *models.Session {
 	for _, session := range dailySessions.Sessions {
 		if session.End == nil {
-			ui.activeSession = session
-			break
+			return session
 		}
 	}
+	return nil
+}
 
-	// If no active session found in current day, check previous day for active sessions
-	if ui.activeSession == nil {
-		// Check if there's an active session from the previous day
-		previousDay := today.AddDate(0, 0, -1)
-		previousSessions, err := storage.LoadDailySessions(previousDay)
-		if err == nil { // Ignore errors as previous day may not exist
-			var activeSessionFromPreviousDay *models.Session
-
-			// Find any active session from previous day
-			for _, session := range previousSessions.Sessions {
-				if session.End == nil {
-					activeSessionFromPreviousDay = session
-					break
-				}
-			}
+// rollOver continues an active session from the previous day in today, split at the
+// day boundary when configured and moved as a whole otherwise
+func (ui *TimerUI) rollOver(previousSessions *models.DailySessions, session *models.Session) error {
+	if cfg := ui.storage.GetConfig(); cfg != nil && cfg.RolloverMode == models.RolloverSplit {
+		boundary := models.DayStartTime(ui.currentDay.Date, cfg.DayStartHour)
+		// Activity recorded after the boundary cannot be split off, keep the session whole
+		if !session.LastActivity().After(boundary) {
+			return ui.splitOver(previousSessions, session, boundary)
+		}
+	}
+	return ui.carryOver(previousSessions, session)
+}
 
-			// If an active session exists in the previous day, move it to today
-			if activeSessionFromPreviousDay != nil {
-				// Add the session to current day's sessions
-				ui.currentDay.Sessions = append(ui.currentDay.Sessions, activeSessionFromPreviousDay)
-				ui.activeSession = activeSessionFromPreviousDay
+// splitOver ends an active session from the previous day at the day boundary and
+// continues it today in a linked session, so each day keeps its own share of the work
+func (ui *TimerUI) splitOver(previousSessions *models.DailySessions, session *models.Session, boundary time.Time) error {
+	continuation := session.SplitAt(boundary)
 
-				// Save the current day with the moved session
-				err := ui.storage.SaveDailySessions(ui.currentDay)
-				if err != nil {
-					return nil, fmt.Errorf("failed to save session moved from previous day: %w", err)
-				}
+	// Close the previous day's portion
+	if err := ui.saveDayWithJournal(previousSessions, models.JournalEnd, session, session.End); err != nil {
+		return fmt.Errorf("failed to end previous day's part of the session: %w", err)
+	}
 
-				// Remove the session from previous day's sessions
-				newPreviousSessions := []*models.Session{}
-				for _, s := range previousSessions.Sessions {
-					if s != activeSessionFromPreviousDay {
-						newPreviousSessions = append(newPreviousSessions, s)
-					}
-				}
-				previousSessions.Sessions = newPreviousSessions
+	// Continue today
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, continuation)
+	ui.activeSession = continuation
+	ui.rolledOver = continuation
+	if err := ui.saveWithJournal(models.JournalStart, continuation, continuation.Start); err != nil {
+		return fmt.Errorf("failed to save session continued from previous day: %w", err)
+	}
+	return nil
+}
 
-				// Save the updated previous day's sessions
-				err = ui.storage.SaveDailySessions(previousSessions)
-				if err != nil {
-					return nil, fmt.Errorf("failed to update previous day after moving session: %w", err)
-				}
-			}
-		}
+// carryOver moves an active session from an earlier day into today, so it keeps running
+func (ui *TimerUI) carryOver(previousSessions *models.DailySessions, session *models.Session) error {
+	// Add the session to current day's sessions
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, session)
+	ui.activeSession = session
+	ui.rolledOver = session
+
+	// Save the current day with the moved session
+	if err := ui.saveWithJournal(models.JournalEdit, session, nil); err != nil {
+		return fmt.Errorf("failed to save session moved from previous day: %w", err)
 	}
 
-	// Initialize UI components
-	ui.setupUI()
+	// Remove the session from previous day's sessions
+	newPreviousSessions := []*models.Session{}
+	for _, s := range previousSessions.Sessions {
+		if s != session {
+			newPreviousSessions = append(newPreviousSessions, s)
+		}
+	}
+	previousSessions.Sessions = newPreviousSessions
 
-	return ui, nil
+	// Save the updated previous day's sessions
+	if err := ui.storage.SaveDailySessions(previousSessions); err != nil {
+		return fmt.Errorf("failed to update previous day after moving session: %w", err)
+	}
+	return nil
 }
 
 // setupUI initializes the UI components
 func (ui *TimerUI) setupUI() {
+	// Apply the color theme, language, accessibility settings and key bindings before
+	// creating any views
+	var keysErr error
+	if cfg := ui.storage.GetConfig(); cfg != nil {
+		tview.Styles = themeStyles(cfg.ColorTheme)
+		messages = configMessages(cfg)
+		applyAccessibility(cfg, nil)
+		keysErr = ui.applyKeyBindings(cfg.KeyBindings)
+	}
+
 	// Create sessions table
 	ui.sessionsTable = tview.NewTable().
 		SetBorders(true).
@@ -118,26 +235,20 @@ func (ui *TimerUI) setupUI() {
 			Background(tcell.ColorNavy).
 			Foreground(tcell.ColorWhite)) // Apply selection style only to cell content
 
-	// Set header row
-	headers := []string{"Start", "End", "Duration", "Interruptions", "Description"}
-	for i, header := range headers {
-		// Add 2 spaces padding on both sides
-		paddedHeader := "  " + header + "  "
-		ui.sessionsTable.SetCell(0, i,
-			tview.NewTableCell(paddedHeader).
-				SetTextColor(tcell.ColorYellow).
-				SetAlign(tview.AlignCenter).
-				SetSelectable(false))
-	}
+	// Set header row and the sessions loaded so far
+	ui.refreshTable()
 
 	// Create status bar
 	ui.statusBar = tview.NewTextView().
 		SetDynamicColors(true).
-		SetText("[yellow]Press (s)tart, (e)nd, (i)nterrupt, (b)ack, (d)elete, (r)ename, (u)ndo end, (v)iew stats, (q)uit")
+		SetText("[yellow]" + ui.statusHint("main"))
+	if keysErr != nil {
+		ui.statusBar.SetText("[red]" + messages.T("status.key_bindings_ignored", keysErr))
+	}
 
 	// Create input field for descriptions
 	ui.inputField = tview.NewInputField().
-		SetLabel("Description: ").
+		SetLabel(messages.T("dialog.description") + " ").
 		SetFieldWidth(0) // 0 means use all available space
 	ui.inputField.SetDoneFunc(func(key tcell.Key) {
 		if key == tcell.KeyEnter {
@@ -167,8 +278,13 @@ func (ui *TimerUI) setupUI() {
 		SetColumns(0).
 		SetBorders(false)
 
+	// Create header, which also carries goal progress and the reminder banner
+	ui.header = tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(headerText)
+
 	// Add elements to grid
-	ui.mainGrid.AddItem(tview.NewTextView().SetText(" Interruption Tracker").SetTextColor(tcell.ColorGreen), 0, 0, 1, 1, 0, 0, false)
+	ui.mainGrid.AddItem(ui.header, 0, 0, 1, 1, 0, 0, false)
 	ui.mainGrid.AddItem(ui.sessionsTable, 1, 0, 1, 1, 0, 0, true)
 	ui.mainGrid.AddItem(ui.statusBar, 2, 0, 1, 1, 0, 0, false)
 
@@ -183,27 +299,34 @@ var tasksTable *tview.Table
 // interruptionsTable is a table component for displaying interruption statistics
 var interruptionsTable *tview.Table
 
+// recurringTable is a table component for displaying recurring task statistics
+var recurringTable *tview.Table
+
 // createStatsPage creates a stats view page that adapts to the terminal size
 func (ui *TimerUI) createStatsPage() tview.Primitive {
 	// Use a flexible layout with rows for header, stats view, section headers, tables, and footer
 	statsGrid := tview.NewGrid().
-		SetRows(1, 0, 1, 10, 1, 8, 1). // Main header, stats view, tasks header, tasks table, interruptions header, interruptions table, footer
+		SetRows(1, 0, 1, 10, 1, 8, 1, 8, 1). // Main header, stats view, tasks header, tasks table, interruptions header, interruptions table, recurring header, recurring table, footer
 		SetColumns(0)
 
 	statsHeader := tview.NewTextView().
-		SetText(" Statistics").
+		SetText(" " + messages.T("stats.title")).
 		SetTextColor(tcell.ColorGreen)
 
 	tasksHeader := tview.NewTextView().
-		SetText(" Completed Tasks").
+		SetText(" " + messages.T("stats.completed_tasks")).
 		SetTextColor(tcell.ColorYellow)
 
 	interruptionsHeader := tview.NewTextView().
-		SetText(" Interruption Breakdown").
+		SetText(" " + messages.T("stats.interruption_breakdown")).
+		SetTextColor(tcell.ColorYellow)
+
+	recurringHeader := tview.NewTextView().
+		SetText(" " + messages.T("stats.recurring_tasks")).
 		SetTextColor(tcell.ColorYellow)
 
 	statsFooter := tview.NewTextView().
-		SetText(" Press (d)ay, (w)eek, (m)onth, (p)roductivity, (t)rends, (i)nterruptions, (b)ack, (q)uit").
+		SetText(" " + messages.T("help.press", ui.bindings().page("stats").hints(true))).
 		SetTextColor(tcell.ColorYellow)
 
 	// Enable scrolling for the stats view
@@ -233,11 +356,20 @@ func (ui *TimerUI) createStatsPage() tview.Primitive {
 				Foreground(tcell.ColorWhite)) // Apply selection style only to cell content
 	}
 
+	// Create the recurring tasks table if it doesn't exist
+	if recurringTable == nil {
+		recurringTable = tview.NewTable().
+			SetBorders(true).
+			SetFixed(1, 0).
+			SetSelectable(false, false). // Disable selection
+			SetSeparator(tview.Borders.Vertical)
+	}
+
 	// Set header row for tasks table
-	taskHeaders := []string{"Description", "Duration", "Interruptions", "Start Time", "End Time"}
+	taskHeaders := []string{"column.description", "column.duration", "column.interruptions", "column.start_time", "column.end_time"}
 	for i, header := range taskHeaders {
 		// Add 2 spaces padding on both sides
-		paddedHeader := "  " + header + "  "
+		paddedHeader := "  " + messages.T(header) + "  "
 		tasksTable.SetCell(0, i,
 			tview.NewTableCell(paddedHeader).
 				SetTextColor(tcell.ColorYellow).
@@ -246,10 +378,10 @@ func (ui *TimerUI) createStatsPage() tview.Primitive {
 	}
 
 	// Set header row for interruptions table
-	interruptHeaders := []string{"Type", "Count", "Interrupt", "Recovery", "Total", "Avg Time"}
+	interruptHeaders := []string{"column.type", "column.count", "column.interrupt", "column.recovery", "column.total", "column.avg_time"}
 	for i, header := range interruptHeaders {
 		// Add 2 spaces padding on both sides
-		paddedHeader := "  " + header + "  "
+		paddedHeader := "  " + messages.T(header) + "  "
 		interruptionsTable.SetCell(0, i,
 			tview.NewTableCell(paddedHeader).
 				SetTextColor(tcell.ColorYellow).
@@ -264,7 +396,9 @@ func (ui *TimerUI) createStatsPage() tview.Primitive {
 	statsGrid.AddItem(tasksTable, 3, 0, 1, 1, 0, 0, false) // No longer focusable
 	statsGrid.AddItem(interruptionsHeader, 4, 0, 1, 1, 0, 0, false)
 	statsGrid.AddItem(interruptionsTable, 5, 0, 1, 1, 0, 0, false)
-	statsGrid.AddItem(statsFooter, 6, 0, 1, 1, 0, 0, false)
+	statsGrid.AddItem(recurringHeader, 6, 0, 1, 1, 0, 0, false)
+	statsGrid.AddItem(recurringTable, 7, 0, 1, 1, 0, 0, false)
+	statsGrid.AddItem(statsFooter, 8, 0, 1, 1, 0, 0, false)
 
 	return statsGrid
 }
@@ -279,103 +413,89 @@ func (ui *TimerUI) KeyHandler(key *tcell.EventKey) bool {
 		return false
 	}
 
-	// First, try to handle with the extended key handler (for visualizations)
-	if ui.extendedKeyHandler(key) {
-		return true
+	// Act on the day as saved by others too, such as an interruption logged by a hotkey
+	if currentPage == "main" && ui.reloadDay() {
+		ui.refreshTable()
 	}
 
-	// Handle main page keys
-	if currentPage == "main" {
-		// Handle special keys first
-		if key.Key() == tcell.KeyEnter {
-			ui.showSessionDetailsModal()
-			return true
-		}
+	return ui.bindings().handle(ui, currentPage, key)
+}
 
-		switch key.Rune() {
-		case 's', 'S':
-			ui.startSession()
-			return true
-		case 'e', 'E':
-			ui.endSession()
-			return true
-		case 'i', 'I':
-			ui.interruptSession()
-			return true
-		case 'b', 'B':
-			ui.backFromInterruption()
-			return true
-		case 'v', 'V':
-			ui.showStats("day")
-			return true
-		case 'd', 'D':
-			ui.deleteSelectedSession()
-			return true
-		case 'q', 'Q':
-			ui.app.Stop()
-			return true
-		case 'r', 'R':
-			ui.editCurrentDescription()
-			return true
-		case 'u', 'U':
-			ui.resumeSession()
-			return true
-		}
-	} else if currentPage == "stats" {
-		// Handle stats page keys
-		switch key.Rune() {
-		case 'd', 'D':
-			ui.showStats("day")
-			return true
-		case 'w', 'W':
-			ui.showStats("week")
-			return true
-		case 'm', 'M':
-			ui.showStats("month")
-			return true
-		case 'q', 'Q':
-			// Handle 'q' to quit from stats page
-			ui.app.Stop()
-			return true
-		case 'y', 'Y':
-			ui.showStats("year")
-			return true
-		case 'a', 'A':
-			ui.showStats("all")
-			return true
-		case 'b', 'B':
-			ui.pages.SwitchToPage("main")
-			return true
-		case 'v', 'V':
-			ui.pages.SwitchToPage("main")
-			return true
-		case 'h', 'H':
-			// Toggle heatmap view
-			ui.pages.SwitchToPage("productivity")
-			return true
+// SetScheduler runs the jobs of the scheduler while the UI runs, except in safe mode
+func (ui *TimerUI) SetScheduler(s *scheduler.Scheduler) {
+	ui.scheduler = s
+}
+
+// reportScheduled shows the outcome of a scheduled job in the status bar
+func (ui *TimerUI) reportScheduled(result scheduler.Result) {
+	ui.app.QueueUpdateDraw(func() {
+		if result.Err != nil {
+			ui.statusBar.SetText("[red]" + messages.T("status.scheduled_failed", result.Job, result.Err))
+			return
 		}
-	}
+		ui.statusBar.SetText("[green]" + messages.T("status.scheduled_done", result.Job))
+	})
+}
 
-	return false
+// SetSafeMode starts the UI without integrations, the auto-refresh ticker and the daily
+// recap, so the data stays reachable when one of them breaks startup
+func (ui *TimerUI) SetSafeMode(enabled bool) {
+	ui.safeMode = enabled
+}
+
+// SetAttached marks the UI as attached to the daemon
+func (ui *TimerUI) SetAttached(attached bool) {
+	ui.attached = attached
 }
 
 // Run starts the UI
 func (ui *TimerUI) Run() error {
-	// Set up a ticker to update durations for active sessions
-	ticker := time.NewTicker(1 * time.Second)
-	go func() {
-		for range ticker.C {
-			// Only update if there's an active session
-			if ui.activeSession != nil {
+	if ui.replay != nil {
+		return ui.runReplay()
+	}
+
+	if !ui.safeMode {
+		// Set up a ticker to update durations for active sessions, refreshTable pauses it
+		// while no session is active
+		ticker := time.NewTicker(1 * time.Second)
+		ui.ticker, ui.ticking = ticker, true
+		go func() {
+			for range ticker.C {
 				ui.app.QueueUpdateDraw(func() {
-					ui.refreshDurations() // Only update durations, not the whole table
+					now := time.Now()
+					if ui.reloadDay() {
+						ui.refreshTable()
+					}
+					ui.checkAutoEnd(now)
+					ui.checkMeetings(now)
+					ui.checkRecoveryEnd(now)
+					ui.checkAlerts(now)
+					ui.refreshDurations(now) // Only update durations, not the whole table
 				})
 			}
-		}
-	}()
+		}()
+
+		// Make sure to stop the ticker when the application exits
+		defer ticker.Stop()
+
+		// Detect interruptions from window focus if enabled
+		stopFocusWatcher := ui.startFocusWatcher()
+		defer stopFocusWatcher()
 
-	// Make sure to stop the ticker when the application exits
-	defer ticker.Stop()
+		// Prompt to start a session when back at the workstation if enabled
+		stopActivityWatcher := ui.startActivityWatcher()
+		defer stopActivityWatcher()
+
+		// Remind to start tracking during working hours if enabled
+		ui.stopReminder = ui.startReminder()
+		defer func() { ui.stopReminder() }()
+
+		// Run scheduled jobs in the background
+		if ui.scheduler != nil {
+			stopScheduler := ui.scheduler.Start(time.Minute, ui.reportScheduled)
+			defer stopScheduler()
+		}
+	}
 
 	// Pre-populate the sessions table
 	ui.refreshTable()
@@ -384,7 +504,7 @@ func (ui *TimerUI) Run() error {
 	ui.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		// Handle Ctrl+C to quit
 		if event.Key() == tcell.KeyCtrlC {
-			ui.app.Stop()
+			ui.quit()
 			return nil
 		}
 
@@ -402,74 +522,105 @@ func (ui *TimerUI) Run() error {
 
 	// Set a function to adjust UI based on screen size before drawing
 	ui.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
-		width, height := screen.Size()
-		if width > 10 {
-			// Let our column width calculation function handle most columns
-			widths := calculateTableColumnWidths(ui.sessionsTable)
-
-			// Ensure minimum widths for time columns
-			if len(widths) >= 5 {
-				// Make sure time columns have at least 16 characters width (HH:MM:SS + padding)
-				if widths[0] < 16 {
-					widths[0] = 16 // Start time
-				}
-				if widths[1] < 16 {
-					widths[1] = 16 // End time
-				}
-
-				// Description column gets remaining space with a minimum
-				descColWidth := width - widths[0] - widths[1] - widths[2] - widths[3] - 10 // 10 for borders/padding
-				if descColWidth < 25 {
-					descColWidth = 25 // Minimum width for description
-				}
-				widths[4] = descColWidth
-
-				// Apply the adjusted widths
-				for i, w := range widths {
-					if i < ui.sessionsTable.GetColumnCount() {
-						for row := 0; row < ui.sessionsTable.GetRowCount(); row++ {
-							cell := ui.sessionsTable.GetCell(row, i)
-							if cell != nil {
-								cell.SetMaxWidth(w)
-							}
-						}
-					}
-				}
-			}
-
-			// Use the terminal height to adjust grid dimensions
-			// The main grid has 3 rows: header, content, footer
-			// We want the content to take most of the space
-			contentHeight := height - 2 // Reserve 2 lines for header and footer
-			if contentHeight < 1 {
-				contentHeight = 1 // Minimum height
-			}
-			ui.mainGrid.SetRows(1, contentHeight, 1)
-
-			// We'll recreate the stats page whenever we switch to it
-		}
+		ui.fitToScreen(screen)
 
 		// Reset status bar to standard instructions based on current page
 		currentPage, _ := ui.pages.GetFrontPage()
 		if currentPage == "main" {
-			ui.statusBar.SetText("[yellow]Press (s)tart, (e)nd, (i)nterrupt, (b)ack, (d)elete, (r)ename, (u)ndo end, (v)iew stats, (Enter) details, (q)uit")
+			ui.updateHeader(time.Now())
+			ui.updateMainStatusBar(time.Now())
 		} else if currentPage == "stats" {
-			ui.statusBar.SetText("[yellow]Press (d)ay, (w)eek, (m)onth, (b)ack, (q)uit")
+			ui.statusBar.SetText("[yellow]" + ui.statusHint("stats"))
 		}
 
 		return false // Continue with the actual drawing
 	})
 
+	// Recap the last working day on the first launch of the day
+	if !ui.safeMode {
+		ui.showDailyRecap()
+	}
+
+	// Announce the session carried over from the previous day
+	if ui.rolledOver != nil {
+		ui.notifyEvent(integrations.EventDayRollover, ui.rolledOver, nil)
+	}
+
+	// Announce or ask about a session left running past the auto-end deadline
+	if ui.autoEnded != nil {
+		ui.showAutoEndNotice()
+	}
+	if ui.autoEndPending != nil {
+		ui.showAutoEndPrompt()
+	}
+
+	// Draw through a screen that drops colors for the monochrome theme
+	if screen, err := tcell.NewScreen(); err == nil {
+		ui.screen = &monochromeScreen{Screen: screen}
+		if cfg := ui.storage.GetConfig(); cfg != nil {
+			applyAccessibility(cfg, ui.screen)
+		}
+		ui.app.SetScreen(ui.screen)
+	}
+
 	// Start the application with mouse support
 	ui.app.SetRoot(ui.pages, true).EnableMouse(true)
 	return ui.app.Run()
 }
 
+// fitToScreen sizes the table columns and the main grid to the terminal before drawing
+func (ui *TimerUI) fitToScreen(screen tcell.Screen) {
+	width, height := screen.Size()
+	if width > 10 {
+		// Widths of the rows built so far, rows never drawn are not measured
+		widths := ui.rows.columnWidths()
+
+		// Ensure minimum widths for time columns, the description gets the remaining space
+		descriptionIndex := -1
+		used := 0
+		for i, column := range ui.rows.columns {
+			switch column {
+			case columnStart, columnEnd:
+				// Make sure time columns have at least 16 characters width (HH:MM:SS + padding)
+				if widths[i] < 16 {
+					widths[i] = 16
+				}
+			case columnDescription:
+				descriptionIndex = i
+				continue
+			}
+			used += widths[i]
+		}
+
+		if descriptionIndex >= 0 {
+			descColWidth := width - used - 10 // 10 for borders/padding
+			if descColWidth < 25 {
+				descColWidth = 25 // Minimum width for description
+			}
+			widths[descriptionIndex] = descColWidth
+		}
+
+		// Apply the adjusted widths
+		ui.rows.setMaxWidths(widths)
+
+		// Use the terminal height to adjust grid dimensions
+		// The main grid has 3 rows: header, content, footer
+		// We want the content to take most of the space
+		contentHeight := height - 2 // Reserve 2 lines for header and footer
+		if contentHeight < 1 {
+			contentHeight = 1 // Minimum height
+		}
+		ui.mainGrid.SetRows(1, contentHeight, 1)
+
+		// We'll recreate the stats page whenever we switch to it
+	}
+}
+
 // showDescriptionInput displays a dialog for entering or editing a description
 func (ui *TimerUI) showDescriptionInput(title, initialValue string, callback func(string)) {
 	// Create an input modal
 	inputField := tview.NewInputField().
-		SetLabel("Description: ").
+		SetLabel(messages.T("dialog.description") + " ").
 		SetFieldWidth(40).
 		SetText(initialValue)
 
@@ -487,9 +638,9 @@ func (ui *TimerUI) showDescriptionInput(title, initialValue string, callback fun
 	})
 
 	// Create a form to hold the input field and button
-	buttonText := "Submit"
+	buttonText := messages.T("button.submit")
 	if initialValue != "" {
-		buttonText = "Update"
+		buttonText = messages.T("button.update")
 	}
 
 	inputForm := tview.NewForm().
@@ -503,7 +654,7 @@ func (ui *TimerUI) showDescriptionInput(title, initialValue string, callback fun
 				callback(description)
 			}
 		}).
-		AddButton("Cancel", func() {
+		AddButton(messages.T("button.cancel"), func() {
 			ui.pages.RemovePage("input")
 			ui.app.SetFocus(ui.sessionsTable)
 		})
@@ -539,61 +690,116 @@ func (ui *TimerUI) showDescriptionInput(title, initialValue string, callback fun
 	ui.app.SetFocus(inputField) // Set focus on the input field directly
 }
 
-// showInterruptionTagSelection shows the dialog for selecting interruption tags
-func (ui *TimerUI) showInterruptionTagSelection() {
-	// Create a tag selection modal
-	modal := tview.NewModal().
-		SetText("Select interruption type:").
-		AddButtons([]string{
-			"1. Call",
-			"2. Meeting",
-			"3. Spouse",
-			"4. Other (custom)",
-		})
-
-	// Create a map of available tags
+// selectableTags returns the tags offered when recording an interruption: the built-in
+// tags in their fixed positions, then the custom tags that have not been archived
+func (ui *TimerUI) selectableTags() []models.InterruptionTag {
 	tags := []models.InterruptionTag{
 		models.TagCall,
 		models.TagMeeting,
 		models.TagSpouse,
 		models.TagOther,
 	}
+	if cfg := ui.storage.GetConfig(); cfg != nil {
+		for _, customTag := range cfg.ActiveInterruptionTags() {
+			tags = append(tags, models.InterruptionTag(customTag))
+		}
+	}
+	return tags
+}
 
-	// Handle tag selection
-	modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+// tagLabel returns the name of a tag shown in the tag selection dialog
+func tagLabel(tag models.InterruptionTag) string {
+	switch tag {
+	case models.TagCall:
+		return messages.T("tag.call")
+	case models.TagMeeting:
+		return messages.T("tag.meeting")
+	case models.TagSpouse:
+		return messages.T("tag.spouse")
+	case models.TagOther:
+		return messages.T("tag.other")
+	}
+	return string(tag)
+}
+
+// suggestedTagIndex returns the position among tags of the tag suggested for the
+// description, -1 when none is suggested or it cannot be picked
+func suggestedTagIndex(model *models.TagModel, tags []models.InterruptionTag, description string) int {
+	suggested, ok := model.Suggest(description)
+	if !ok {
+		return -1
+	}
+	for i, tag := range tags {
+		if tag == suggested {
+			return i
+		}
+	}
+	return -1
+}
+
+// showInterruptionTagSelection shows the dialog for selecting interruption tags
+func (ui *TimerUI) showInterruptionTagSelection(entryType models.EntryType) {
+	// Built-in tags keep their fixed positions, "Other" prompts for a description
+	tags := ui.selectableTags()
+	buttons := make([]string, len(tags))
+	for i, tag := range tags {
+		buttons[i] = fmt.Sprintf("%d. %s", i+1, tagLabel(tag))
+	}
+
+	// Create a tag selection modal
+	prompt := messages.T("dialog.select_tag")
+	if entryType == models.EntryTypeDeferred {
+		prompt = messages.T("dialog.select_deferred_tag")
+	}
+	modal := tview.NewModal().
+		SetText(prompt + ":").
+		AddButtons(buttons)
+
+	// Pre-select the tag most often used at this time of day, if enabled
+	if cfg := ui.storage.GetConfig(); cfg != nil && cfg.SmartDefaultTag {
+		if likelyTag, ok := ui.storage.GetLikelyTag(time.Now()); ok {
+			for i, tag := range tags {
+				if tag == likelyTag {
+					modal.SetFocus(i)
+					modal.SetText(messages.T("dialog.suggested_tag", prompt, likelyTag))
+					break
+				}
+			}
+		}
+	}
+
+	// selectTag records the interruption for the tag at the given index
+	selectTag := func(index int) {
 		ui.pages.RemovePage("tag_select")
 
+		// Custom interruption needs description
+		if tags[index] == models.TagOther {
+			ui.showInterruptionDescriptionInput(models.TagOther, entryType)
+		} else {
+			// Record the selected tag with an empty description
+			ui.recordTaggedEntry(entryType, "", tags[index])
+		}
+	}
+
+	// Handle tag selection
+	modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 		if buttonIndex < 0 {
 			// Cancelled
+			ui.pages.RemovePage("tag_select")
 			ui.app.SetFocus(ui.sessionsTable)
 			return
 		}
 
-		// Custom interruption needs description
-		if buttonIndex == 3 { // Other
-			ui.showInterruptionDescriptionInput(models.TagOther)
-		} else {
-			// Create a new interruption with the selected tag and empty description
-			entry := models.NewInterruptionEntry("", tags[buttonIndex])
-			ui.recordInterruption(entry)
-		}
+		selectTag(buttonIndex)
 	})
 
 	// Set key handlers for quick number selection
 	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		// Convert rune to integer (1-4)
+		// Convert rune to integer (1-9)
 		if event.Key() == tcell.KeyRune {
 			num := int(event.Rune() - '0')
-			if num >= 1 && num <= 4 {
-				ui.pages.RemovePage("tag_select")
-
-				if num == 4 { // Other
-					ui.showInterruptionDescriptionInput(models.TagOther)
-				} else {
-					// Create a new interruption with the selected tag and empty description
-					entry := models.NewInterruptionEntry("", tags[num-1])
-					ui.recordInterruption(entry)
-				}
+			if num >= 1 && num <= 9 && num <= len(tags) {
+				selectTag(num - 1)
 				return nil
 			}
 		}
@@ -606,45 +812,211 @@ func (ui *TimerUI) showInterruptionTagSelection() {
 	ui.app.SetFocus(modal)
 }
 
-// showInterruptionDescriptionInput shows a modal for entering interruption description
-func (ui *TimerUI) showInterruptionDescriptionInput(tag models.InterruptionTag) {
+// showSessionStartInput shows a picker for a new session's task: favorites and recent
+// tasks filtered by fuzzy search as you type, or a new description with an optional
+// estimate. On an empty description, digits 1-9 start the task shown with them.
+func (ui *TimerUI) showSessionStartInput(callback func(task models.TaskSuggestion)) {
+	descriptionField := tview.NewInputField().
+		SetLabel(messages.T("dialog.description") + " ").
+		SetPlaceholder(messages.T("dialog.task_placeholder")).
+		SetFieldWidth(40)
+	estimateField := tview.NewInputField().
+		SetLabel(messages.T("dialog.estimate")).
+		SetPlaceholder(messages.T("dialog.estimate_placeholder")).
+		SetFieldWidth(40)
+
+	now := time.Now()
+	picker := newTaskPicker(ui.storage.GetTaskSuggestions(now))
+	tasksTable := tview.NewTable().SetSelectable(false, false)
+	tasksTable.SetBorder(true).SetTitle(" " + messages.T("dialog.tasks_title") + " ")
+	picker.render(tasksTable, now)
+
+	var inputForm *tview.Form
+
+	closeDialog := func() {
+		ui.pages.RemovePage("input")
+		ui.app.SetFocus(ui.sessionsTable)
+	}
+	start := func(task models.TaskSuggestion) {
+		closeDialog()
+		if callback != nil {
+			callback(task)
+		}
+	}
+
+	// submit starts the highlighted task, or the typed one while the estimate is valid
+	submit := func() bool {
+		if task, ok := picker.current(); ok {
+			start(task)
+			return true
+		}
+
+		estimate, err := models.ParseEstimate(estimateField.GetText())
+		if err != nil {
+			inputForm.SetTitle(" " + messages.T("dialog.invalid_estimate") + " ")
+			inputForm.SetTitleColor(tcell.ColorRed)
+			return false
+		}
+		start(models.TaskSuggestion{Description: descriptionField.GetText(), Estimate: estimate})
+		return true
+	}
+
+	descriptionField.SetChangedFunc(func(text string) {
+		picker.filter(text)
+		picker.render(tasksTable, now)
+	})
+	descriptionField.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyDown:
+			picker.move(1)
+			picker.render(tasksTable, now)
+			return nil
+		case tcell.KeyUp:
+			picker.move(-1)
+			picker.render(tasksTable, now)
+			return nil
+		case tcell.KeyEnter:
+			if _, ok := picker.current(); ok {
+				submit()
+				return nil
+			}
+		case tcell.KeyRune:
+			if descriptionField.GetText() == "" {
+				if task, ok := picker.pick(event.Rune()); ok {
+					start(task)
+					return nil
+				}
+			}
+		}
+		return event
+	})
+
+	// Enter moves from the description to the estimate, and submits from the estimate
+	estimateField.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter && !submit() {
+			inputForm.SetFocus(1) // The form moves on to the next item after Enter, back to the estimate
+		}
+	})
+
+	inputForm = tview.NewForm().
+		AddFormItem(descriptionField).
+		AddFormItem(estimateField).
+		AddButton(messages.T("button.start"), func() {
+			if !submit() {
+				inputForm.SetFocus(1)
+				ui.app.SetFocus(inputForm)
+			}
+		}).
+		AddButton(messages.T("button.cancel"), closeDialog)
+
+	inputForm.SetBorder(true)
+	inputForm.SetTitle(" " + messages.T("dialog.start_session") + " ")
+	inputForm.SetTitleAlign(tview.AlignCenter)
+
+	// Create a flex layout for centering the form above the task list
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexColumn).
+			AddItem(nil, 0, 1, false).
+			AddItem(tview.NewFlex().
+				SetDirection(tview.FlexRow).
+				AddItem(inputForm, 9, 1, true).
+				AddItem(tasksTable, pickerSize+2, 1, false), 70, 1, true).
+			AddItem(nil, 0, 1, false),
+			pickerSize+11, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	// Make sure to capture escape key to close the dialog
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeDialog()
+			return nil
+		}
+		return event
+	})
+
+	// Add the input modal as a page
+	ui.pages.AddPage("input", flex, true, true)
+	ui.app.SetFocus(inputForm)
+}
+
+// showInterruptionDescriptionInput shows a modal for entering interruption description,
+// with the tag suggested from the words typed until a tag is picked by hand
+func (ui *TimerUI) showInterruptionDescriptionInput(tag models.InterruptionTag, entryType models.EntryType) {
+	tags := ui.selectableTags()
+	labels := make([]string, len(tags))
+	current := 0
+	for i, option := range tags {
+		labels[i] = string(option)
+		if option == tag {
+			current = i
+		}
+	}
+
 	// Create an input modal
 	inputField := tview.NewInputField().
-		SetLabel("Description: ").
+		SetLabel(messages.T("dialog.description") + " ").
 		SetFieldWidth(40)
+	tagField := tview.NewDropDown().
+		SetLabel(messages.T("dialog.tag")+" ").
+		SetOptions(labels, nil).
+		SetCurrentOption(current)
+
+	// Suggestions stop once a tag was picked by hand
+	model, _ := ui.storage.LoadTagModel() // Default keywords still work without a model
+	suggesting, picked := false, false
+	tagField.SetSelectedFunc(func(text string, index int) {
+		if !suggesting {
+			picked = true
+		}
+	})
+	inputField.SetChangedFunc(func(text string) {
+		if picked {
+			return
+		}
+		index := suggestedTagIndex(model, tags, text)
+		if index < 0 {
+			index = current
+		}
+		suggesting = true
+		tagField.SetCurrentOption(index)
+		suggesting = false
+	})
+
+	// record records the interruption with the description and the tag shown
+	record := func() {
+		description := inputField.GetText()
+		ui.pages.RemovePage("input")
+		ui.app.SetFocus(ui.sessionsTable)
+
+		selected := tag
+		if index, _ := tagField.GetCurrentOption(); index >= 0 && index < len(tags) {
+			selected = tags[index]
+		}
+		ui.recordTaggedEntry(entryType, description, selected)
+	}
 
 	// Set done function that handles Enter key
 	inputField.SetDoneFunc(func(key tcell.Key) {
 		if key == tcell.KeyEnter {
-			description := inputField.GetText()
-			ui.pages.RemovePage("input")
-			ui.app.SetFocus(ui.sessionsTable)
-
-			// Create and record the interruption
-			entry := models.NewInterruptionEntry(description, tag)
-			ui.recordInterruption(entry)
+			record()
 		}
 	})
 
 	// Create a form to hold the input field and button
 	inputForm := tview.NewForm().
 		AddFormItem(inputField).
-		AddButton("Submit", func() {
-			description := inputField.GetText()
-			ui.pages.RemovePage("input")
-			ui.app.SetFocus(ui.sessionsTable)
-
-			// Create and record the interruption
-			entry := models.NewInterruptionEntry(description, tag)
-			ui.recordInterruption(entry)
-		}).
-		AddButton("Cancel", func() {
+		AddFormItem(tagField).
+		AddButton(messages.T("button.submit"), record).
+		AddButton(messages.T("button.cancel"), func() {
 			ui.pages.RemovePage("input")
 			ui.app.SetFocus(ui.sessionsTable)
 		})
 
 	inputForm.SetBorder(true)
-	inputForm.SetTitle(" Enter Interruption Description ")
+	inputForm.SetTitle(" " + messages.T("dialog.interruption_description") + " ")
 	inputForm.SetTitleAlign(tview.AlignCenter)
 
 	// Create a flex layout for centering the form
@@ -656,7 +1028,7 @@ func (ui *TimerUI) showInterruptionDescriptionInput(tag models.InterruptionTag)
 			AddItem(nil, 0, 1, false).
 			AddItem(inputForm, 60, 1, true).
 			AddItem(nil, 0, 1, false),
-			10, 1, true).
+			12, 1, true).
 		AddItem(nil, 0, 1, false)
 
 	// Make sure to capture escape key to close the dialog
@@ -679,7 +1051,7 @@ func (ui *TimerUI) showConfirmationDialog(message string, callback func(bool)) {
 	// Create confirmation modal
 	modal := tview.NewModal().
 		SetText(message).
-		AddButtons([]string{"Yes", "No"}).
+		AddButtons([]string{messages.T("button.yes"), messages.T("button.no")}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 			confirmed := buttonIndex == 0
 			ui.pages.RemovePage("confirm")
@@ -692,50 +1064,16 @@ func (ui *TimerUI) showConfirmationDialog(message string, callback func(bool)) {
 	ui.app.SetFocus(modal)
 }
 
+// detailsLine returns a "Label: value" line of the session details with the label in yellow
+func detailsLine(key, value string) string {
+	return "[yellow]" + messages.T(key, "[white]"+value)
+}
+
 // showSessionDetailsModal displays a modal with detailed information about the selected session
 func (ui *TimerUI) showSessionDetailsModal() {
-	// Get selected row
-	row, _ := ui.sessionsTable.GetSelection()
-
-	// Check if a valid row is selected (row 0 is header)
-	if row <= 0 || row > len(ui.currentDay.Sessions) {
-		ui.statusBar.SetText("[red]No session selected")
-		return
-	}
-
-	// Get the session index (row - 1 because row 0 is header)
-	rowIndex := row - 1
-
-	// Create a copy of the sessions to sort (same as in refreshTable)
-	sessionsCopy := make([]*models.Session, len(ui.currentDay.Sessions))
-	copy(sessionsCopy, ui.currentDay.Sessions)
-
-	// Sort sessions with active (no end time) first, then by newest start time (same as in refreshTable)
-	sort.Slice(sessionsCopy, func(i, j int) bool {
-		// Active session check (active first)
-		iActive := sessionsCopy[i].End == nil
-		jActive := sessionsCopy[j].End == nil
-
-		if iActive && !jActive {
-			return true // i is active, j is not, so i comes first
-		}
-		if !iActive && jActive {
-			return false // j is active, i is not, so j comes first
-		}
-
-		// If both active or both inactive, sort by start time (newest first)
-		return sessionsCopy[i].Start.StartTime.After(sessionsCopy[j].Start.StartTime)
-	})
-
-	// Use the rowIndex to get the selected session from the sorted array
-	var selectedSession *models.Session
-	if rowIndex < len(sessionsCopy) {
-		selectedSession = sessionsCopy[rowIndex]
-	}
-
-	// If no matching session found
+	selectedSession := ui.selectedSession()
 	if selectedSession == nil {
-		ui.statusBar.SetText("[red]Could not identify the selected session")
+		ui.statusBar.SetText("[red]" + messages.T("status.no_session_selected"))
 		return
 	}
 
@@ -744,23 +1082,89 @@ func (ui *TimerUI) showSessionDetailsModal() {
 		SetDirection(tview.FlexRow)
 
 	// Add session header information
-	headerText := fmt.Sprintf(" Session: %s\n Start: %s\n",
-		selectedSession.Start.Description,
-		models.FormatTime(selectedSession.Start.StartTime))
+	headerText := " " + messages.T("details.session", selectedSession.Start.Description) + "\n" +
+		" " + messages.T("details.start", messages.Time(selectedSession.Start.StartTime)) + "\n"
 
 	if selectedSession.End != nil {
-		headerText += fmt.Sprintf(" End: %s\n", models.FormatTime(selectedSession.End.StartTime))
+		headerText += " " + messages.T("details.end", messages.Time(selectedSession.End.StartTime)) + "\n"
 	} else {
-		headerText += " End: [yellow]Active[white]\n"
+		headerText += " " + messages.T("details.end", "[yellow]"+messages.T("details.active")+"[white]") + "\n"
 	}
 
-	headerText += fmt.Sprintf(" Total Duration: %s\n", computeSessionDuration(selectedSession))
+	headerText += " " + messages.T("details.total_duration", computeSessionDuration(selectedSession, time.Now())) + "\n"
+
+	headerHeight := 5
+	if selectedSession.Project != "" {
+		headerText += " " + messages.T("details.project", selectedSession.Project) + "\n"
+		headerHeight++
+	}
+	if len(selectedSession.FocusWindows) > 0 {
+		now := time.Now()
+		var dnd models.DNDSummary
+		dnd.AddSession(selectedSession, ui.storage.StatsPolicy().Breakdown(selectedSession, now), now)
+		headerText += " " + messages.T("details.focus_mode", formatDurationHumanReadable(dnd.Time), dnd.Interruptions) + "\n"
+		headerHeight++
+	}
+
+	// List files attached as evidence, flagging those changed or removed since
+	for _, attachment := range selectedSession.Attachments {
+		status := ""
+		if state := storage.CheckAttachment(attachment); state != models.AttachmentOK {
+			status = fmt.Sprintf(" [red](%s)[white]", state)
+		}
+		headerText += " " + messages.T("details.attachment", tview.Escape(attachment.Name())) + status + "\n"
+		headerHeight++
+	}
 
 	header := tview.NewTextView().
 		SetText(headerText).
 		SetDynamicColors(true)
 
-	modalFlex.AddItem(header, 5, 0, false)
+	// Show linked issues, resolving the titles of those in configured trackers in the background
+	issueKeys := models.ExtractIssueKeys(selectedSession.Start.Description)
+	if len(issueKeys) > 0 {
+		headerHeight += len(issueKeys)
+		resolver := ui.getIssueResolver()
+		pendingText := ""
+		for _, key := range issueKeys {
+			pendingText += " " + messages.T("details.issue", key)
+			if resolver.Resolvable(key) {
+				pendingText += " [gray]" + messages.T("details.resolving") + "[white]"
+			}
+			pendingText += "\n"
+		}
+		header.SetText(headerText + pendingText)
+
+		go func() {
+			issuesText := ""
+			for _, key := range issueKeys {
+				issuesText += " " + messages.T("details.issue", key)
+				if resolver.Resolvable(key) {
+					title, err := resolver.Resolve(key)
+					if err != nil {
+						title = "[gray]" + messages.T("details.unavailable") + "[white]"
+					} else {
+						title = tview.Escape(title)
+					}
+					issuesText += " " + title
+				}
+				issuesText += "\n"
+			}
+			ui.app.QueueUpdateDraw(func() {
+				header.SetText(headerText + issuesText)
+			})
+		}()
+	}
+
+	modalFlex.AddItem(header, headerHeight, 0, false)
+
+	// Mini-map of work, interruptions and recovery over the session
+	if strip := sessionTimelineStrip(selectedSession, ui.recoveryTime(), time.Now()); strip != "" {
+		timeline := tview.NewTextView().
+			SetDynamicColors(true).
+			SetText(strip)
+		modalFlex.AddItem(timeline, 3, 0, false)
+	}
 
 	// Create a table for sub-sessions
 	subSessionsTable := tview.NewTable().
@@ -772,10 +1176,10 @@ func (ui *TimerUI) showSessionDetailsModal() {
 			Foreground(tcell.ColorWhite)) // Apply selection style only to cell content
 
 	// Set header row for sub-sessions table
-	headers := []string{"Sub-Session", "Start", "End", "Duration", "Interruptions"}
+	headers := []string{"column.sub_session", "column.start", "column.end", "column.duration", "column.interruptions"}
 	for i, header := range headers {
 		subSessionsTable.SetCell(0, i,
-			tview.NewTableCell(header).
+			tview.NewTableCell(messages.T(header)).
 				SetTextColor(tcell.ColorYellow).
 				SetAlign(tview.AlignCenter).
 				SetSelectable(false))
@@ -822,14 +1226,14 @@ func (ui *TimerUI) showSessionDetailsModal() {
 
 		// Start time
 		subSessionsTable.SetCell(row, 1,
-			tview.NewTableCell(models.FormatTime(subSession.Start.StartTime)).
+			tview.NewTableCell(messages.Time(subSession.Start.StartTime)).
 				SetTextColor(tcell.ColorWhite).
 				SetAlign(tview.AlignCenter))
 
 		// End time
-		endTimeText := "[yellow]Active[white]"
+		endTimeText := "[yellow]" + messages.T("details.active") + "[white]"
 		if subSession.End != nil {
-			endTimeText = models.FormatTime(subSession.End.StartTime)
+			endTimeText = messages.Time(subSession.End.StartTime)
 		}
 		subSessionsTable.SetCell(row, 2,
 			tview.NewTableCell(endTimeText).
@@ -865,11 +1269,7 @@ func (ui *TimerUI) showSessionDetailsModal() {
 		}
 
 		effectiveDuration := totalDuration - interruptionDuration
-		hours := int(effectiveDuration.Hours())
-		minutes := int(effectiveDuration.Minutes()) % 60
-		seconds := int(effectiveDuration.Seconds()) % 60
-
-		duration = fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+		duration = messages.ClockDuration(effectiveDuration)
 
 		subSessionsTable.SetCell(row, 3,
 			tview.NewTableCell(duration).
@@ -903,7 +1303,7 @@ func (ui *TimerUI) showSessionDetailsModal() {
 
 	// Create a text view for interruptions details with a clearly defined height
 	interruptionsText := tview.NewTextView().
-		SetText("Select a sub-session to view interruption details").
+		SetText(messages.T("details.select_sub_session")).
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignCenter).
 		SetScrollable(true)
@@ -923,41 +1323,46 @@ func (ui *TimerUI) showSessionDetailsModal() {
 			// Build interruption details text
 			var detailsText string
 			if len(selectedSubSession.Interruptions) == 0 {
-				detailsText = "No interruptions recorded for this sub-session."
+				detailsText = messages.T("details.no_interruptions")
 			} else {
-				detailsText = fmt.Sprintf("[yellow]Interruptions for Sub-Session #%d:[white]\n\n", subSessionIndex+1)
+				detailsText = "[yellow]" + messages.T("details.interruptions_of", subSessionIndex+1) + "[white]\n\n"
 
 				for i := 0; i < len(selectedSubSession.Interruptions); i += 2 {
 					interrupt := selectedSubSession.Interruptions[i]
 
 					// Format interruption start
-					interruptStart := fmt.Sprintf("[yellow]Start:[white] %s", models.FormatTime(interrupt.StartTime))
+					interruptStart := detailsLine("details.start", messages.Time(interrupt.StartTime))
 
 					// Format interruption type
 					interruptType := string(interrupt.Tag)
 					if interruptType == "" {
-						interruptType = "Unknown"
+						interruptType = messages.T("details.unknown")
 					}
-					interruptTypeStr := fmt.Sprintf("[yellow]Type:[white] %s", interruptType)
+					interruptTypeStr := detailsLine("details.type", interruptType)
 
 					// Format interruption description
 					description := interrupt.Description
 					if description == "" {
-						description = "(No description)"
+						description = messages.T("details.no_description")
 					}
-					descriptionStr := fmt.Sprintf("[yellow]Description:[white] %s", description)
+					descriptionStr := detailsLine("details.description", description)
 
 					// Format end time and duration if available
 					durationStr := ""
 					if i+1 < len(selectedSubSession.Interruptions) {
 						returnEntry := selectedSubSession.Interruptions[i+1]
-						interruptEnd := fmt.Sprintf("[yellow]End:[white] %s", models.FormatTime(returnEntry.StartTime))
+						interruptEnd := detailsLine("details.end", messages.Time(returnEntry.StartTime))
 
 						duration := returnEntry.StartTime.Sub(interrupt.StartTime)
 						durationFormatted := formatDurationHumanReadable(duration)
-						durationStr = fmt.Sprintf("[yellow]Duration:[white] %s", durationFormatted)
+						durationStr = detailsLine("details.duration", durationFormatted)
+
+						// Explicit transition back to work once recovery ended
+						if !returnEntry.EndTime.IsZero() {
+							durationStr += "\n" + detailsLine("details.back_to_work", messages.Time(returnEntry.EndTime))
+						}
 
-						detailsText += "Interruption #" + fmt.Sprint((i/2)+1) + ":\n" +
+						detailsText += messages.T("details.interruption", (i/2)+1) + "\n" +
 							interruptTypeStr + "\n" +
 							descriptionStr + "\n" +
 							interruptStart + "\n" +
@@ -965,13 +1370,13 @@ func (ui *TimerUI) showSessionDetailsModal() {
 							durationStr + "\n\n"
 					} else {
 						// Active interruption
-						interruptEnd := fmt.Sprintf("[yellow]End:[white] [red]Active[white]")
+						interruptEnd := detailsLine("details.end", "[red]"+messages.T("details.active")+"[white]")
 
 						duration := time.Since(interrupt.StartTime)
 						durationFormatted := formatDurationHumanReadable(duration)
-						durationStr = fmt.Sprintf("[yellow]Duration:[white] %s (ongoing)", durationFormatted)
+						durationStr = detailsLine("details.duration", durationFormatted+" "+messages.T("details.ongoing"))
 
-						detailsText += "Interruption #" + fmt.Sprint((i/2)+1) + ":\n" +
+						detailsText += messages.T("details.interruption", (i/2)+1) + "\n" +
 							interruptTypeStr + "\n" +
 							descriptionStr + "\n" +
 							interruptStart + "\n" +
@@ -994,12 +1399,12 @@ func (ui *TimerUI) showSessionDetailsModal() {
 			AddItem(nil, 0, 1, false).
 			AddItem(modalFlex, 70, 1, true).
 			AddItem(nil, 0, 1, false),
-			20, 1, true).
+			23, 1, true).
 		AddItem(nil, 0, 1, false)
 
 	// Set border and title
 	modalFlex.SetBorder(true).
-		SetTitle(" Session Details ").
+		SetTitle(" " + messages.T("details.title") + " ").
 		SetTitleAlign(tview.AlignCenter)
 
 	// Add key capture for escape key and q/Q keys
@@ -1022,6 +1427,18 @@ func (ui *TimerUI) showSessionDetailsModal() {
 	}
 }
 
+// getIssueResolver returns the issue resolver, creating it on first use
+func (ui *TimerUI) getIssueResolver() *integrations.IssueResolver {
+	if ui.issueResolver == nil {
+		cfg := ui.storage.GetConfig()
+		if cfg == nil {
+			cfg = config.DefaultConfig()
+		}
+		ui.issueResolver = integrations.NewIssueResolver(cfg)
+	}
+	return ui.issueResolver
+}
+
 // calculateTableColumnWidths automatically calculates appropriate column widths
 // for a table based on header text and content
 func calculateTableColumnWidths(table *tview.Table) []int {
@@ -1049,8 +1466,8 @@ func calculateTableColumnWidths(table *tview.Table) []int {
 				continue
 			}
 
-			text := cell.Text
-			textWidth := len(text)
+			// Color tags take no space on screen
+			textWidth := tview.TaggedStringWidth(cell.Text)
 
 			// Update max width if this cell's content is wider
 			if textWidth > columnWidths[col] {
@@ -1,12 +1,22 @@
 package ui
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/i18n"
+	"github.com/lukaszraczylo/interruption-tracker/integrations"
 	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/lukaszraczylo/interruption-tracker/stats"
 	"github.com/lukaszraczylo/interruption-tracker/storage"
 	"github.com/rivo/tview"
 	"github.com/stretchr/testify/assert"
@@ -210,8 +220,11 @@ func (suite *UITestSuite) TestCalculateSessionDuration() {
 	}
 }
 
-// TestCalculateSessionStats tests session stats calculations
+// TestCalculateSessionStats tests session stats calculations with recovery counted as
+// interruption time
 func (suite *UITestSuite) TestCalculateSessionStats() {
+	policy := models.StatsPolicy{IncludeRecovery: true, Recovery: 10 * time.Minute, CapBySession: true, CountActive: true}
+
 	// Test cases
 	testCases := []struct {
 		name                 string
@@ -295,7 +308,7 @@ func (suite *UITestSuite) TestCalculateSessionStats() {
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			session := tc.setupSession()
-			workDuration, interruptDuration, count := calculateSessionStats(session)
+			workDuration, interruptDuration, count := policy.SessionStats(session, time.Now())
 
 			assert.Equal(suite.T(), tc.expectedWork, workDuration)
 			assert.Equal(suite.T(), tc.expectedInterruption, interruptDuration)
@@ -343,7 +356,7 @@ func (suite *UITestSuite) TestGenerateTimelineChart() {
 			name: "Timeline with one session and interruption",
 			setupSessions: func() []*models.Session {
 				now := time.Now()
-				today := now.Truncate(24 * time.Hour)
+				today := models.DayOf(now, 0)
 
 				// Create a session from 9-11 AM with an interruption at 10 AM for 30 min
 				session := &models.Session{
@@ -402,6 +415,43 @@ func (suite *UITestSuite) TestGenerateTimelineChart() {
 	}
 }
 
+// TestSessionTimelineStrip tests the per-session timeline is proportional to the session,
+// showing pauses between work periods and the configured recovery window
+func (suite *UITestSuite) TestSessionTimelineStrip() {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)
+	at := func(minutes int) *models.TimeEntry {
+		return &models.TimeEntry{StartTime: start.Add(time.Duration(minutes) * time.Minute)}
+	}
+	interruptions := []*models.TimeEntry{at(40), at(45)}
+	interruptions[0].Type = models.EntryTypeInterruption
+	interruptions[1].Type = models.EntryTypeReturn
+	session := &models.Session{
+		Start:         at(0),
+		End:           at(60),
+		Interruptions: interruptions,
+		SubSessions: []*models.SubSession{
+			{Start: at(0), End: at(20)},
+			{Start: at(30), End: at(60), Interruptions: interruptions},
+		},
+	}
+
+	// One-minute slots over the hour-long session
+	strip := sessionTimelineStrip(session, 10*time.Minute, start.Add(2*time.Hour))
+	lines := strings.Split(strip, "\n")
+	assert.Equal(suite.T(), sessionTimelineWidth, strings.Count(lines[0], "█")+strings.Count(lines[0], "▒")+strings.Count(lines[0], "·"))
+	assert.Equal(suite.T(), 6, strings.Count(lines[0], "[red]█"))
+	assert.Equal(suite.T(), 10, strings.Count(lines[0], "[yellow]▒"))
+	assert.Equal(suite.T(), 9, strings.Count(lines[0], "·"))
+	assert.Contains(suite.T(), lines[1], "09:00")
+	assert.Contains(suite.T(), lines[1], "10:00")
+
+	// A running session ends now
+	session.End = nil
+	session.SubSessions[1].End = nil
+	assert.Contains(suite.T(), sessionTimelineStrip(session, 10*time.Minute, start.Add(2*time.Hour)), "now")
+	assert.Empty(suite.T(), sessionTimelineStrip(session, 10*time.Minute, start))
+}
+
 // TestContainsSession tests the containsSession helper function
 func (suite *UITestSuite) TestContainsSession() {
 	// Create test sessions
@@ -440,7 +490,7 @@ func (suite *UITestSuite) TestContainsSession() {
 // TestNewTimerUI tests creation of new UI instance
 func (suite *UITestSuite) TestNewTimerUI() {
 	// Set up a real test sessions file
-	today := time.Now().Truncate(24 * time.Hour)
+	today := models.DayOf(time.Now(), 0)
 	testSessions := &models.DailySessions{
 		Date:     today,
 		Sessions: []*models.Session{},
@@ -558,6 +608,7 @@ func (suite *UITestSuite) TestUIRefreshTable() {
 	ui := &TimerUI{
 		app:           tview.NewApplication(),
 		sessionsTable: tview.NewTable(),
+		storage:       suite.storage,
 		currentDay:    &models.DailySessions{},
 	}
 
@@ -585,7 +636,26 @@ func (suite *UITestSuite) TestUIRefreshTable() {
 
 	// Verify table contents
 	assert.Equal(suite.T(), 2, ui.sessionsTable.GetRowCount()) // Header + 1 session
-	assert.Contains(suite.T(), ui.sessionsTable.GetCell(1, 4).Text, "Test Session")
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(1, 5).Text, "Test Session")
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(1, 4).Text, "[green]▇")
+}
+
+// TestSessionSparkline tests the compact work/interruption pattern of a session
+func (suite *UITestSuite) TestSessionSparkline() {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)
+	session := &models.Session{
+		Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+		End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(2 * time.Hour)},
+		Interruptions: []*models.TimeEntry{
+			{Type: models.EntryTypeInterruption, StartTime: start.Add(30 * time.Minute)},
+			{Type: models.EntryTypeReturn, StartTime: start.Add(40 * time.Minute)},
+		},
+	}
+
+	// Ten minute cells: work, work, work, interrupted, recovery, then work
+	expected := strings.Repeat("[green]▇", 3) + "[red]▁" + "[yellow]▃" + strings.Repeat("[green]▇", 7) + "[white]"
+	assert.Equal(suite.T(), expected, sessionSparkline(session, start.Add(3*time.Hour)))
+	assert.Equal(suite.T(), "", sessionSparkline(&models.Session{}, start))
 }
 
 // TestUIRefreshDurations tests the duration refreshing logic
@@ -594,6 +664,7 @@ func (suite *UITestSuite) TestUIRefreshDurations() {
 	ui := &TimerUI{
 		app:           tview.NewApplication(),
 		sessionsTable: tview.NewTable(),
+		storage:       suite.storage,
 		currentDay:    &models.DailySessions{},
 	}
 
@@ -622,7 +693,7 @@ func (suite *UITestSuite) TestUIRefreshDurations() {
 	}
 
 	// Refresh durations
-	ui.refreshDurations()
+	ui.refreshDurations(time.Now())
 
 	// Duration should be approximately 1 hour
 	durationCell := ui.sessionsTable.GetCell(1, 2)
@@ -640,7 +711,7 @@ func (suite *UITestSuite) TestEditCurrentDescription() {
 		statusBar:     tview.NewTextView(),
 		sessionsTable: tview.NewTable(), // Add this to prevent nil pointer exceptions
 		currentDay: &models.DailySessions{
-			Date:     time.Now().Truncate(24 * time.Hour),
+			Date:     models.DayOf(time.Now(), 0),
 			Sessions: []*models.Session{},
 		},
 	}
@@ -679,7 +750,7 @@ func (suite *UITestSuite) TestInterruptionTagsInUI() {
 		statusBar:     tview.NewTextView(),
 		sessionsTable: tview.NewTable(), // This was missing and causing nil pointer dereference
 		currentDay: &models.DailySessions{
-			Date:     time.Now().Truncate(24 * time.Hour),
+			Date:     models.DayOf(time.Now(), 0),
 			Sessions: []*models.Session{},
 		},
 	}
@@ -747,6 +818,49 @@ func (suite *UITestSuite) TestInterruptionTagsInUI() {
 	assert.Greater(suite.T(), int64(meetingStats.TotalTime), int64(0))
 }
 
+// TestCalendarNavigation tests opening a day from the calendar heatmap and going back
+func (suite *UITestSuite) TestCalendarNavigation() {
+	// The heatmap starts on a Monday and covers whole weeks up to today
+	sunday := time.Date(2025, 3, 9, 0, 0, 0, 0, time.Local)
+	start := calendarStart(sunday)
+	assert.Equal(suite.T(), time.Monday, start.Weekday())
+	assert.Equal(suite.T(), 7*calendarWeeks-1, int(sunday.Sub(start).Hours()/24))
+
+	assert.Equal(suite.T(), 0, calendarLevel(0))
+	assert.Equal(suite.T(), 2, calendarLevel(90*time.Minute))
+	assert.Equal(suite.T(), 4, calendarLevel(5*time.Hour))
+
+	ui := &TimerUI{
+		app:        tview.NewApplication(),
+		pages:      tview.NewPages(),
+		storage:    suite.storage,
+		statusBar:  tview.NewTextView(),
+		currentDay: &models.DailySessions{},
+	}
+	ui.pages.AddPage("stats", tview.NewBox(), true, true)
+
+	// Stats page 'c' opens the calendar
+	assert.True(suite.T(), ui.KeyHandler(tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone)))
+	page, _ := ui.pages.GetFrontPage()
+	assert.Equal(suite.T(), "calendar", page)
+
+	ui.showDayView(time.Now())
+	page, _ = ui.pages.GetFrontPage()
+	assert.Equal(suite.T(), "day", page)
+
+	// The day view and the calendar go back through their key bindings, opening a day is
+	// left to the calendar
+	assert.True(suite.T(), ui.KeyHandler(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone)))
+	page, _ = ui.pages.GetFrontPage()
+	assert.Equal(suite.T(), "calendar", page)
+	assert.False(suite.T(), ui.KeyHandler(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)))
+	assert.Equal(suite.T(), "open", ui.bindings().action("calendar", tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)))
+
+	assert.True(suite.T(), ui.KeyHandler(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)))
+	page, _ = ui.pages.GetFrontPage()
+	assert.Equal(suite.T(), "stats", page)
+}
+
 // TestResumeSession tests the resuming of an ended session
 func (suite *UITestSuite) TestResumeSession() {
 	// Create a minimal UI instance with all required components
@@ -757,7 +871,7 @@ func (suite *UITestSuite) TestResumeSession() {
 		statusBar:     tview.NewTextView(),
 		sessionsTable: tview.NewTable(),
 		currentDay: &models.DailySessions{
-			Date:     time.Now().Truncate(24 * time.Hour),
+			Date:     models.DayOf(time.Now(), 0),
 			Sessions: []*models.Session{},
 		},
 	}
@@ -799,6 +913,1479 @@ func (suite *UITestSuite) TestResumeSession() {
 	assert.Equal(suite.T(), "Test Session", ui.activeSession.Start.Description)
 }
 
+// TestCheckRecoveryEnd tests marking the transition back to work once recovery ends
+func (suite *UITestSuite) TestCheckRecoveryEnd() {
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay: &models.DailySessions{
+			Date:     models.DayOf(time.Now(), 0),
+			Sessions: []*models.Session{},
+		},
+	}
+
+	now := time.Now()
+	session := models.NewSession(&models.TimeEntry{ID: "1", Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour)})
+	returned := &models.TimeEntry{ID: "3", Type: models.EntryTypeReturn, StartTime: now.Add(-9 * time.Minute)}
+	subSession := session.SubSessions[0]
+	subSession.Interruptions = []*models.TimeEntry{
+		{ID: "2", Type: models.EntryTypeInterruption, StartTime: now.Add(-20 * time.Minute)},
+		returned,
+	}
+	session.Interruptions = subSession.Interruptions
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, session)
+	ui.activeSession = session
+
+	// Still recovering
+	ui.checkRecoveryEnd(now)
+	assert.True(suite.T(), returned.EndTime.IsZero())
+	assert.False(suite.T(), ui.refocusNudgeActive(now))
+
+	// Recovery ends at the modeled time and the status bar is highlighted
+	later := now.Add(90 * time.Second)
+	ui.checkRecoveryEnd(later)
+	assert.Equal(suite.T(), returned.StartTime.Add(models.AssumedRecoveryTime), returned.EndTime)
+	assert.True(suite.T(), ui.refocusNudgeActive(later))
+
+	ui.updateMainStatusBar(later)
+	assert.Contains(suite.T(), ui.statusBar.GetText(true), "back to focused work")
+	ui.updateMainStatusBar(later.Add(2 * time.Minute))
+	assert.NotContains(suite.T(), ui.statusBar.GetText(true), "back to focused work")
+
+	// The transition is saved with the session
+	saved, err := suite.storage.LoadDailySessions(ui.currentDay.Date)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), returned.EndTime.Unix(), saved.Sessions[0].Interruptions[1].EndTime.Unix())
+}
+
+// TestStartReminderBanner tests showing and clearing the reminder banner
+func (suite *UITestSuite) TestStartReminderBanner() {
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		header:        tview.NewTextView(),
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay: &models.DailySessions{
+			Date:     models.DayOf(time.Now(), 0),
+			Sessions: []*models.Session{},
+		},
+	}
+
+	// Working hours around the whole day so the test passes at any time
+	now := time.Now()
+	ui.reminder = &models.StartReminder{
+		Hours: models.WorkingHours{Start: 0, End: 24 * time.Hour, Weekends: true},
+		Idle:  15 * time.Minute,
+	}
+	ui.launched = now.Add(-20 * time.Minute)
+	if ui.reminder.Hours.StartOn(now).After(ui.launched) {
+		ui.launched = ui.reminder.Hours.StartOn(now) // Just after midnight
+		now = ui.launched.Add(20 * time.Minute)
+	}
+
+	ui.checkStartReminder(now)
+	assert.True(suite.T(), ui.reminderShown)
+	assert.Contains(suite.T(), ui.header.GetText(true), "No session for 20m")
+
+	// Starting a session clears the banner
+	ui.activeSession = models.NewSession(models.NewTimeEntry(models.EntryTypeStart, "Work"))
+	ui.checkStartReminder(now.Add(time.Minute))
+	assert.False(suite.T(), ui.reminderShown)
+	assert.NotContains(suite.T(), ui.header.GetText(true), "No session")
+}
+
+// TestHeaderGoalProgress tests the header progress bar towards today's focus goal
+func (suite *UITestSuite) TestHeaderGoalProgress() {
+	assert.Contains(suite.T(), formatGoalProgress(time.Hour, 4*time.Hour), "25%")
+	assert.Contains(suite.T(), formatGoalProgress(5*time.Hour, 4*time.Hour), "[green]")
+	assert.Empty(suite.T(), formatGoalProgress(time.Hour, 0))
+
+	ui := &TimerUI{
+		storage: suite.storage,
+		header:  tview.NewTextView(),
+		currentDay: &models.DailySessions{
+			Date:     models.DayOf(time.Now(), 0),
+			Sessions: []*models.Session{},
+		},
+	}
+
+	now := time.Now()
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, &models.Session{
+		Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-2 * time.Hour)},
+		End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: now.Add(-time.Hour)},
+	})
+
+	cfg := suite.storage.GetConfig()
+	cfg.WeekdayFocusGoals = map[string]int{now.Weekday().String(): 120}
+	defer func() { cfg.WeekdayFocusGoals = nil }()
+
+	ui.updateHeader(now)
+	assert.Contains(suite.T(), ui.header.GetText(true), "Today 1h 0m / 2h 0m")
+	assert.Contains(suite.T(), ui.header.GetText(true), "50%")
+
+	// No bar on days without a goal
+	cfg.WeekdayFocusGoals = map[string]int{now.Weekday().String(): 0}
+	ui.updateHeader(now)
+	assert.NotContains(suite.T(), ui.header.GetText(true), "Today")
+}
+
+// TestRangeDisplayName tests the titles of named and custom stats ranges
+func (suite *UITestSuite) TestRangeDisplayName() {
+	assert.Equal(suite.T(), "This Quarter", rangeDisplayName("quarter"))
+	assert.Equal(suite.T(), "All Time", rangeDisplayName("all"))
+	assert.Equal(suite.T(), "2025-01-01 to 2025-03-31", rangeDisplayName("2025-01-01..2025-03-31"))
+}
+
+// TestFocusWatcherRecordMode tests recording and closing interruptions from window focus
+func (suite *UITestSuite) TestFocusWatcherRecordMode() {
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay: &models.DailySessions{
+			Date:     models.DayOf(time.Now(), 0),
+			Sessions: []*models.Session{},
+		},
+	}
+
+	now := time.Now()
+	session := models.NewSession(&models.TimeEntry{ID: "1", Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour)})
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, session)
+	ui.activeSession = session
+
+	ui.handleFocusEvent(integrations.FocusModeRecord, integrations.FocusEvent{
+		App: "Slack", Tag: models.TagOther, Interrupting: true, Since: now.Add(-5 * time.Minute),
+	})
+	assert.True(suite.T(), ui.isInInterruptionMode())
+	assert.Equal(suite.T(), "Slack", session.Interruptions[0].Description)
+	assert.Equal(suite.T(), now.Add(-5*time.Minute), session.Interruptions[0].StartTime)
+
+	ui.handleFocusEvent(integrations.FocusModeRecord, integrations.FocusEvent{
+		App: "Slack", Tag: models.TagOther, Since: now.Add(-5 * time.Minute),
+	})
+	assert.False(suite.T(), ui.isInInterruptionMode())
+	assert.Len(suite.T(), session.Interruptions, 2)
+	assert.Nil(suite.T(), ui.focusEntry)
+}
+
+// TestFormatRecap tests the daily recap text
+func (suite *UITestSuite) TestFormatRecap() {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	recap := &models.DailyRecap{
+		Date: yesterday,
+		Stats: &models.DetailedStats{
+			TotalWorkDuration:  4 * time.Hour,
+			TotalSessions:      2,
+			TotalInterruptions: 3,
+			InterruptionsByTag: map[models.InterruptionTag]int{models.TagMeeting: 1, models.TagCall: 2},
+			ProductivityScore:  81.6,
+		},
+		AverageWork: 3 * time.Hour,
+		AverageDays: 4,
+	}
+
+	text := formatRecap(recap)
+	assert.Contains(suite.T(), text, "Yesterday's recap")
+	assert.Contains(suite.T(), text, "Focus: 4h 0m in 2 session(s)")
+	assert.Contains(suite.T(), text, "Interruptions: 3 (call 2, meeting 1)")
+	assert.Contains(suite.T(), text, "Productivity score: 82/100")
+	assert.Contains(suite.T(), text, "33% more focus than your 7-day average of 3h 0m.")
+
+	recap.AverageDays = 0
+	assert.Contains(suite.T(), formatRecap(recap), "No earlier days this week to compare with.")
+
+	// The recap follows the configured language
+	messages = i18n.New("pl")
+	defer func() { messages = i18n.New("en") }()
+	text = formatRecap(recap)
+	assert.Contains(suite.T(), text, "Podsumowanie wczoraj")
+	assert.Contains(suite.T(), text, "Skupienie: 4 godz. 0 min w sesjach: 2")
+}
+
+// TestSetupAnswers tests validating and applying the setup wizard answers
+func (suite *UITestSuite) TestSetupAnswers() {
+	cfg := config.DefaultConfig()
+	answers := setupAnswers{
+		DataDirectory:   "/tmp/tracker",
+		RecoveryMinutes: "15",
+		Tags:            "Review, meeting, review, ",
+		Theme:           "dark",
+		BackupEnabled:   true,
+		BackupInterval:  "3",
+	}
+
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.Equal(suite.T(), "/tmp/tracker", cfg.DataDirectory)
+	assert.Equal(suite.T(), 15*time.Minute, cfg.RecoveryTime.Std())
+	assert.Equal(suite.T(), []string{"review"}, cfg.CustomInterruptionTags)
+	assert.Equal(suite.T(), "dark", cfg.ColorTheme)
+	assert.Equal(suite.T(), 3, cfg.BackupInterval)
+
+	answers.BackupInterval = "0"
+	assert.Error(suite.T(), answers.apply(config.DefaultConfig()))
+
+	answers.BackupInterval = "3"
+	answers.EncryptionEnabled = true
+	assert.Error(suite.T(), answers.apply(config.DefaultConfig()))
+
+	answers.EncryptionKey = "secret"
+	cfg = config.DefaultConfig()
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.True(suite.T(), cfg.EnableEncryption)
+	assert.Equal(suite.T(), "secret", cfg.EncryptionKey)
+
+	answers.DataDirectory = " "
+	assert.Error(suite.T(), answers.apply(config.DefaultConfig()))
+}
+
+// TestSettingsAnswers tests applying the settings page values
+func (suite *UITestSuite) TestSettingsAnswers() {
+	cfg := config.DefaultConfig()
+	answers := settingsAnswers{
+		RecoveryMinutes: "20",
+		Theme:           "light",
+		Tags:            "deploy, call",
+		Reminder:        true,
+	}
+
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.Equal(suite.T(), 20*time.Minute, cfg.RecoveryTime.Std())
+	assert.Equal(suite.T(), "light", cfg.ColorTheme)
+	assert.Equal(suite.T(), []string{"deploy"}, cfg.CustomInterruptionTags)
+	assert.True(suite.T(), cfg.ReminderEnabled)
+	assert.False(suite.T(), cfg.RecoveryNotify)
+
+	answers.RecoveryMinutes = "0"
+	assert.Error(suite.T(), answers.apply(cfg))
+	assert.Equal(suite.T(), 20*time.Minute, cfg.RecoveryTime.Std())
+
+	answers.RecoveryMinutes = "20"
+	answers.Columns = "Start, duration,description"
+	answers.Sort = "duration"
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.Equal(suite.T(), []string{"start", "duration", "description"}, cfg.SessionColumns)
+	assert.Equal(suite.T(), "duration", cfg.SessionSort)
+	answers.Columns = "start, start"
+	assert.Error(suite.T(), answers.apply(cfg))
+	answers.Columns = "start, project"
+	assert.Error(suite.T(), answers.apply(cfg))
+	answers.Columns = ""
+
+	answers.HourlyRate = "85.5"
+	answers.Currency = " EUR "
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.Equal(suite.T(), 85.5, cfg.HourlyRate)
+	assert.Equal(suite.T(), "EUR", cfg.Currency)
+	answers.HourlyRate = "-1"
+	assert.Error(suite.T(), answers.apply(cfg))
+	answers.HourlyRate = ""
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.Equal(suite.T(), 0.0, cfg.HourlyRate)
+
+	answers.Locale = "pl"
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.Equal(suite.T(), "pl", cfg.Locale)
+	answers.Locale = "xx"
+	assert.Error(suite.T(), answers.apply(cfg))
+	answers.Locale = ""
+
+	answers.TwelveHourClock = true
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.Equal(suite.T(), "12h", cfg.ClockFormat)
+	assert.Equal(suite.T(), "9:05 AM", configMessages(cfg).ShortTime(time.Date(2025, 3, 10, 9, 5, 0, 0, time.UTC)))
+	answers.TwelveHourClock = false
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.Empty(suite.T(), cfg.ClockFormat)
+
+	answers.Accessible = true
+	assert.NoError(suite.T(), answers.apply(cfg))
+	assert.True(suite.T(), cfg.AccessibleMode)
+
+	assert.Equal(suite.T(), lightStyles, themeStyles("light"))
+	assert.Equal(suite.T(), defaultStyles, themeStyles("system"))
+}
+
+// TestFormatQuarterReview tests rendering the quarter dashboard
+func (suite *UITestSuite) TestFormatQuarterReview() {
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	review := &models.QuarterReview{
+		Start: start,
+		End:   start.AddDate(0, 3, -1),
+		Weeks: []models.WeekReview{
+			{Start: start, Focus: 6 * time.Hour, TrackedDays: 2, GoalDays: 1},
+			{Start: start.AddDate(0, 0, 6), Focus: 3 * time.Hour, TrackedDays: 1, GoalDays: 1},
+			{Start: start.AddDate(0, 0, 13)},
+		},
+		Projects:    []models.ProjectFocus{{Project: "acme", Focus: 6 * time.Hour}, {Project: models.NoProject, Focus: 3 * time.Hour}},
+		TotalFocus:  9 * time.Hour,
+		TrackedDays: 3,
+		GoalDays:    2,
+	}
+
+	text := formatQuarterReview(review, start.AddDate(0, 0, 10))
+	assert.Contains(suite.T(), text, "Total focus:      9h 0m")
+	assert.Contains(suite.T(), text, "2 of 3 tracked day(s) (67%)")
+	assert.Contains(suite.T(), text, "[yellow]1/2 goal days")
+	assert.Contains(suite.T(), text, "[green]1/1 goal days")
+	assert.Contains(suite.T(), text, strings.Repeat("█", quarterBarWidth))
+	assert.NotContains(suite.T(), text, "14 Apr") // Week not started yet
+	assert.Contains(suite.T(), text, "acme")
+	assert.Contains(suite.T(), text, " 67%")
+
+	// Labels follow the configured language and stay aligned
+	messages = i18n.New("pl")
+	defer func() { messages = i18n.New("en") }()
+	text = formatQuarterReview(review, start.AddDate(0, 0, 10))
+	assert.Contains(suite.T(), text, "Łączne skupienie:  9 godz. 0 min")
+	assert.Contains(suite.T(), text, "Osiągnięcie celu:  2 z 3 dni z pomiarem (67%)")
+	assert.Contains(suite.T(), text, "dni z celem: 1/2")
+}
+
+// TestAutoEndAtStartup tests a session left running on the previous day is ended at the
+// auto-end deadline instead of being carried over, or held back when the policy prompts
+func (suite *UITestSuite) TestAutoEndAtStartup() {
+	yesterday := suite.storage.DayOf(time.Now()).AddDate(0, 0, -1)
+	start := yesterday.Add(9 * time.Hour)
+	saveLeftRunning := func() {
+		session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: "Forgotten"})
+		assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{
+			Date:     yesterday,
+			Sessions: []*models.Session{session},
+		}))
+	}
+
+	cfg := suite.storage.GetConfig()
+	cfg.AutoEndAfter = config.Duration(8 * time.Hour)
+	defer func() { cfg.AutoEndAfter, cfg.AutoEndPrompt = 0, false }()
+
+	saveLeftRunning()
+	ui, err := NewTimerUI(suite.storage)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), ui.activeSession)
+	assert.Nil(suite.T(), ui.rolledOver)
+	assert.NotNil(suite.T(), ui.autoEnded)
+
+	saved, err := suite.storage.LoadDailySessions(yesterday)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), saved.Sessions, 1)
+	assert.NotNil(suite.T(), saved.Sessions[0].End)
+	assert.True(suite.T(), start.Add(8*time.Hour).Equal(saved.Sessions[0].End.StartTime))
+	assert.Empty(suite.T(), ui.currentDay.Sessions)
+
+	// Prompting leaves the session where it is until the user decides
+	cfg.AutoEndPrompt = true
+	saveLeftRunning()
+	ui, err = NewTimerUI(suite.storage)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), ui.activeSession)
+	assert.Nil(suite.T(), ui.autoEnded)
+	assert.NotNil(suite.T(), ui.autoEndPending)
+	assert.True(suite.T(), start.Add(8*time.Hour).Equal(ui.autoEndPending.deadline))
+
+	saved, err = suite.storage.LoadDailySessions(yesterday)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), saved.Sessions[0].End)
+}
+
+// TestSplitRolloverAtStartup tests a session running into a new day is split at the day
+// boundary instead of being moved as a whole
+func (suite *UITestSuite) TestSplitRolloverAtStartup() {
+	today := suite.storage.DayOf(time.Now())
+	yesterday := today.AddDate(0, 0, -1)
+	session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: yesterday.Add(22 * time.Hour), Description: "Deploy"})
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{
+		Date:     yesterday,
+		Sessions: []*models.Session{session},
+	}))
+
+	cfg := suite.storage.GetConfig()
+	cfg.RolloverMode = models.RolloverSplit
+	defer func() { cfg.RolloverMode = "" }()
+
+	ui, err := NewTimerUI(suite.storage)
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), ui.activeSession)
+	assert.Equal(suite.T(), session.ID, ui.activeSession.ContinuationOf)
+	assert.Equal(suite.T(), "Deploy", ui.activeSession.Start.Description)
+	assert.True(suite.T(), today.Equal(ui.activeSession.Start.StartTime))
+	assert.Equal(suite.T(), ui.activeSession, ui.rolledOver)
+
+	previous, err := suite.storage.LoadDailySessions(yesterday)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), previous.Sessions, 1)
+	assert.NotNil(suite.T(), previous.Sessions[0].End)
+	assert.True(suite.T(), today.Equal(previous.Sessions[0].End.StartTime))
+	work, _, _ := previous.Sessions[0].GetStats()
+	assert.Equal(suite.T(), 2*time.Hour, work)
+
+	current, err := suite.storage.LoadDailySessions(today)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), current.Sessions, 1)
+	assert.Equal(suite.T(), session.ID, current.Sessions[0].ContinuationOf)
+
+	// Both days' parts are reported as one task
+	tasks, err := suite.storage.GetContinuedTasks("week")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), tasks, 1)
+	assert.Len(suite.T(), tasks[0].Parts, 2)
+	assert.True(suite.T(), tasks[0].Active())
+	assert.GreaterOrEqual(suite.T(), tasks[0].WorkDuration, 2*time.Hour)
+	assert.Contains(suite.T(), formatContinuedTasks(tasks), "over 2 day(s)")
+}
+
+// TestConflictSteps tests the questions asked about conflicted copies and duplicated
+// sessions, and that answering them resolves the conflicts
+func (suite *UITestSuite) TestConflictSteps() {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	session := func(id string, start time.Time) *models.Session {
+		return &models.Session{
+			ID:    id,
+			Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+			End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(time.Hour)},
+		}
+	}
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{
+		Date:     monday,
+		Sessions: []*models.Session{session("sess_1", monday.Add(9*time.Hour)), session("sess_2", monday.Add(23*time.Hour))},
+	}))
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{
+		Date:     monday.AddDate(0, 0, 1),
+		Sessions: []*models.Session{session("sess_2", monday.Add(23*time.Hour))},
+	}))
+	data, err := json.Marshal(&models.DailySessions{
+		Date:     monday,
+		Sessions: []*models.Session{session("sess_3", monday.Add(13*time.Hour))},
+	})
+	assert.NoError(suite.T(), err)
+	copyPath := filepath.Join(suite.tempDir, "sessions_2025-03-10.sync-conflict-20250311-101010-ABCDEFG.json")
+	assert.NoError(suite.T(), os.WriteFile(copyPath, data, 0644))
+
+	conflicts, err := suite.storage.FindSyncConflicts()
+	assert.NoError(suite.T(), err)
+	steps := conflictSteps(suite.storage, conflicts)
+	assert.Len(suite.T(), steps, 2)
+
+	assert.Contains(suite.T(), steps[0].text, "Monday, 10 Mar 2025")
+	assert.Contains(suite.T(), steps[0].text, "This machine: 2 session(s)")
+	assert.Contains(suite.T(), steps[0].text, "Copy: 1 session(s)")
+	assert.Equal(suite.T(), []string{"Merge both", "Keep this machine's", "Use copy", "Later"}, steps[0].buttons)
+
+	assert.Contains(suite.T(), steps[1].text, "sess_2")
+	assert.Equal(suite.T(), []string{"Keep in 10 Mar (latest)", "Keep in 11 Mar", "Later"}, steps[1].buttons)
+
+	// Later leaves everything as it is
+	assert.NoError(suite.T(), steps[0].resolve("Later"))
+	_, err = os.Stat(copyPath)
+	assert.NoError(suite.T(), err)
+
+	assert.NoError(suite.T(), steps[0].resolve("Merge both"))
+	assert.NoError(suite.T(), steps[1].resolve("Keep in 11 Mar"))
+
+	merged, err := suite.storage.LoadDailySessions(monday)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), merged.Sessions, 2)
+	conflicts, err = suite.storage.FindSyncConflicts()
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), conflicts.Empty())
+}
+
+// TestBulkOperations tests marking sessions and applying bulk actions to them
+func (suite *UITestSuite) TestBulkOperations() {
+	today := models.DayOf(time.Now(), 0)
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay:    &models.DailySessions{Date: today, Sessions: []*models.Session{}},
+	}
+
+	// session creates an ended session with one call starting at the given hour
+	session := func(id string, hour int) *models.Session {
+		start := today.Add(time.Duration(hour) * time.Hour)
+		s := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: id})
+		s.ID = id
+		interruption := &models.TimeEntry{Type: models.EntryTypeInterruption, StartTime: start.Add(10 * time.Minute), Tag: models.TagCall}
+		back := &models.TimeEntry{Type: models.EntryTypeReturn, StartTime: start.Add(20 * time.Minute)}
+		s.SubSessions[0].Interruptions = []*models.TimeEntry{interruption, back}
+		s.Interruptions = []*models.TimeEntry{interruption, back}
+		s.EndAt(start.Add(time.Hour))
+		return s
+	}
+	for i, id := range []string{"a", "b", "c", "d"} {
+		ui.currentDay.Sessions = append(ui.currentDay.Sessions, session(id, 1+i*2))
+	}
+	ui.refreshTable()
+
+	// Rows are newest first: d, c, b, a; marking moves the selection down
+	ui.sessionsTable.Select(1, 0)
+	ui.toggleMark()
+	ui.toggleMark()
+	assert.Equal(suite.T(), []string{"d", "c"}, sessionIDs(ui.markedSessions()))
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(1, 0).Text, "* ")
+	assert.NotContains(suite.T(), ui.sessionsTable.GetCell(3, 0).Text, "* ")
+
+	summary := summarizeSessions("Delete these sessions?", ui.markedSessions())
+	assert.Contains(suite.T(), summary, "Delete these sessions?")
+	assert.Contains(suite.T(), summary, "2 session(s), 1h 40m of work")
+
+	assert.NoError(suite.T(), ui.retagSessions(ui.markedSessions(), models.TagMeeting))
+	assert.Equal(suite.T(), models.TagMeeting, ui.currentDay.Sessions[3].SubSessions[0].Interruptions[0].Tag)
+	assert.Equal(suite.T(), models.TagCall, ui.currentDay.Sessions[0].SubSessions[0].Interruptions[0].Tag)
+
+	assert.NoError(suite.T(), ui.mergeSessions(ui.markedSessions()))
+	assert.Equal(suite.T(), []string{"a", "b", "c"}, sessionIDs(ui.currentDay.Sessions))
+	work, _, count := ui.currentDay.Sessions[2].GetStats()
+	assert.Equal(suite.T(), 100*time.Minute, work)
+	assert.Equal(suite.T(), 2, count)
+
+	yesterday := today.AddDate(0, 0, -1)
+	assert.NoError(suite.T(), ui.moveSessions(ui.currentDay.Sessions[:1], yesterday))
+	assert.Error(suite.T(), ui.moveSessions(ui.currentDay.Sessions[:1], today))
+	moved, err := suite.storage.LoadDailySessions(yesterday)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"a"}, sessionIDs(moved.Sessions))
+	remaining, err := suite.storage.LoadDailySessions(today)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"b", "c"}, sessionIDs(remaining.Sessions))
+
+	assert.NoError(suite.T(), ui.deleteSessions(ui.currentDay.Sessions))
+	saved, err := suite.storage.LoadDailySessions(today)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), saved.Sessions)
+}
+
+// TestAddQuickEntry tests logging past work and interruptions from a sentence
+func (suite *UITestSuite) TestAddQuickEntry() {
+	today := models.DayOf(time.Now(), 0)
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay:    &models.DailySessions{Date: today, Sessions: []*models.Session{}},
+	}
+	now := today.Add(23 * time.Hour)
+
+	summary, err := ui.addQuickEntry("worked on report 9-12 with 2 interruptions", now)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `"report" 09:00-12:00 with 2 other interruption(s)`, summary)
+	summary, err = ui.addQuickEntry("interrupted by call 10:14-10:32", now)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "call interruption 10:14-10:32", summary)
+	_, err = ui.addQuickEntry("interrupted by call 12:00-12:10", now) // After the session
+	assert.Error(suite.T(), err)
+
+	saved, err := suite.storage.LoadDailySessions(today)
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), saved.Sessions, 1) {
+		_, interrupted, count := saved.Sessions[0].GetStats()
+		assert.Equal(suite.T(), 3, count)
+		assert.Equal(suite.T(), 18*time.Minute, interrupted)
+	}
+}
+
+// TestTaskPicker tests picking tasks by digit, highlighting them and typed text
+func (suite *UITestSuite) TestTaskPicker() {
+	now := time.Date(2025, 3, 5, 12, 0, 0, 0, time.Local)
+	picker := newTaskPicker(
+		[]models.TaskSuggestion{{Description: "Standup", Estimate: 15 * time.Minute, Favorite: true}},
+		[]models.TaskSuggestion{{Description: "Release notes", LastUsed: now.AddDate(0, 0, -1)}, {Description: "Inbox", LastUsed: now.AddDate(0, 0, -3)}},
+	)
+
+	task, ok := picker.pick('2')
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Release notes", task.Description)
+	_, ok = picker.pick('4')
+	assert.False(suite.T(), ok)
+
+	// Typed text is used until a task is highlighted
+	picker.filter("in")
+	_, ok = picker.current()
+	assert.False(suite.T(), ok)
+	picker.move(1)
+	task, ok = picker.current()
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Inbox", task.Description)
+	picker.move(5)
+	picker.move(-5)
+	_, ok = picker.current()
+	assert.False(suite.T(), ok, "moving up past the first task returns to the typed text")
+
+	table := tview.NewTable()
+	picker.filter("")
+	picker.move(1)
+	picker.render(table, now)
+	assert.Equal(suite.T(), 3, table.GetRowCount())
+	assert.Contains(suite.T(), table.GetCell(0, 0).Text, "> 1 ")
+	assert.Contains(suite.T(), table.GetCell(0, 0).Text, "Standup [gray](15m 0s)")
+	assert.Contains(suite.T(), table.GetCell(1, 0).Text, "yesterday")
+	assert.Contains(suite.T(), table.GetCell(2, 0).Text, "3d ago")
+}
+
+// TestSessionColumnsAndSort tests configured columns and sorting with the number keys
+func (suite *UITestSuite) TestSessionColumnsAndSort() {
+	today := models.DayOf(time.Now(), 0)
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay:    &models.DailySessions{Date: today, Sessions: []*models.Session{}},
+	}
+	cfg := suite.storage.GetConfig()
+	defer func() { cfg.SessionColumns, cfg.SessionSort = nil, "" }()
+
+	// session creates an ended session of the given minutes with a number of 5 minute calls
+	session := func(description string, hour, minutes, calls int) *models.Session {
+		start := today.Add(time.Duration(hour) * time.Hour)
+		s := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: description})
+		for i := 0; i < calls; i++ {
+			interruption := start.Add(time.Duration(i*10+1) * time.Minute)
+			s.Interruptions = append(s.Interruptions,
+				&models.TimeEntry{Type: models.EntryTypeInterruption, StartTime: interruption, Tag: models.TagCall},
+				&models.TimeEntry{Type: models.EntryTypeReturn, StartTime: interruption.Add(5 * time.Minute)})
+		}
+		s.SubSessions[0].Interruptions = s.Interruptions
+		s.EndAt(start.Add(time.Duration(minutes) * time.Minute))
+		return s
+	}
+	ui.currentDay.Sessions = []*models.Session{
+		session("Inbox", 8, 30, 0),
+		session("Code review", 9, 90, 2),
+		session("Release notes", 12, 60, 1),
+	}
+	descriptions := func() []string {
+		result := []string{}
+		for _, s := range ui.displayedSessions() {
+			result = append(result, s.Start.Description)
+		}
+		return result
+	}
+
+	// Newest first by default, with the sort marked in the header
+	ui.refreshTable()
+	assert.Equal(suite.T(), []string{"Release notes", "Code review", "Inbox"}, descriptions())
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(0, 0).Text, "Start ▼")
+
+	cfg.SessionColumns = []string{"description", "interruptions", "bogus", "duration"}
+	cfg.SessionSort = "description"
+	cfg.SessionSortAscending = true
+	ui.refreshTable()
+	assert.Equal(suite.T(), 3, ui.sessionsTable.GetColumnCount())
+	assert.Equal(suite.T(), []string{"Code review", "Inbox", "Release notes"}, descriptions())
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(1, 0).Text, "Code review")
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(0, 0).Text, "Description ▲")
+
+	// The selected session stays selected, a second press reverses the order
+	ui.sessionsTable.Select(2, 0)
+	ui.sortByColumn(1)
+	assert.Equal(suite.T(), []string{"Code review", "Release notes", "Inbox"}, descriptions())
+	row, _ := ui.sessionsTable.GetSelection()
+	assert.Equal(suite.T(), 3, row, "Inbox is still selected")
+	ui.sortByColumn(1)
+	assert.Equal(suite.T(), []string{"Inbox", "Release notes", "Code review"}, descriptions())
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(0, 1).Text, "Interruptions ▲")
+
+	ui.sortByColumn(2)
+	assert.Equal(suite.T(), []string{"Code review", "Release notes", "Inbox"}, descriptions(), "longest work first")
+	ui.sortByColumn(5)
+	assert.Contains(suite.T(), ui.statusBar.GetText(false), "No column 6")
+
+	// Active sessions stay on top
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions,
+		models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: today.Add(14 * time.Hour), Description: "Active"}))
+	assert.Equal(suite.T(), "Active", descriptions()[0])
+}
+
+// TestSelectedSessionByID tests actions resolve the selected row to the session it showed
+// at the last refresh, even when the day changed since
+func (suite *UITestSuite) TestSelectedSessionByID() {
+	today := models.DayOf(time.Now(), 0)
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay:    &models.DailySessions{Date: today, Sessions: []*models.Session{}},
+	}
+
+	// session creates an ended hour long session
+	session := func(id, description string, hour int) *models.Session {
+		start := today.Add(time.Duration(hour) * time.Hour)
+		s := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: description})
+		s.ID = id
+		s.EndAt(start.Add(time.Hour))
+		return s
+	}
+	inbox, review := session("sess_inbox", "Inbox", 8), session("sess_review", "Code review", 9)
+	ui.currentDay.Sessions = []*models.Session{inbox, review}
+
+	// Nothing is selected before the first refresh
+	assert.Nil(suite.T(), ui.selectedSession())
+
+	ui.refreshTable()
+	ui.sessionsTable.Select(2, 0)
+	assert.Equal(suite.T(), inbox, ui.selectedSession())
+
+	// A newer session added before the next refresh does not shift the selection
+	release := session("sess_release", "Release notes", 10)
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, release)
+	assert.Equal(suite.T(), []string{"sess_release", "sess_review", "sess_inbox"}, sessionIDs(ui.displayedSessions()))
+	assert.Equal(suite.T(), inbox, ui.selectedSession())
+
+	// The refresh moves the selection along with the session
+	ui.refreshTable()
+	row, _ := ui.sessionsTable.GetSelection()
+	assert.Equal(suite.T(), 3, row)
+	assert.Equal(suite.T(), inbox, ui.selectedSession())
+
+	// Marking acts on the selected session and moves to the next row
+	ui.sessionsTable.Select(2, 0)
+	ui.toggleMark()
+	assert.Equal(suite.T(), map[string]bool{"sess_review": true}, ui.marked)
+	assert.Equal(suite.T(), inbox, ui.selectedSession())
+
+	// A session removed since the refresh is no longer selected
+	ui.currentDay.Sessions = []*models.Session{review, release}
+	assert.Nil(suite.T(), ui.selectedSession())
+	ui.deleteSelectedSession()
+	assert.Contains(suite.T(), ui.statusBar.GetText(false), "No session selected")
+	assert.Len(suite.T(), ui.currentDay.Sessions, 2)
+}
+
+// TestSessionTableRendersLazily tests rows are only built when drawn and the ticker only
+// replaces the changed cells of active sessions between full rebuilds
+func (suite *UITestSuite) TestSessionTableRendersLazily() {
+	now := time.Now()
+	today := models.DayOf(now, 0)
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay:    &models.DailySessions{Date: today, Sessions: []*models.Session{}},
+	}
+
+	// Hundreds of imported sessions and one running since an hour ago
+	for i := 0; i < 500; i++ {
+		start := today.Add(-time.Duration(i+1) * time.Hour)
+		s := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start, Description: "Imported"})
+		s.ID = fmt.Sprintf("sess_imported_%d", i)
+		s.EndAt(start.Add(30 * time.Minute))
+		ui.currentDay.Sessions = append(ui.currentDay.Sessions, s)
+	}
+	active := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Active"})
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, active)
+	ui.activeSession = active
+
+	ui.refreshTable()
+	assert.Equal(suite.T(), 502, ui.sessionsTable.GetRowCount())
+	assert.Empty(suite.T(), ui.rows.cells, "no row is built before it is drawn")
+
+	// Drawing the first rows builds only those
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(1, 5).Text, "Active")
+	ended := ui.sessionsTable.GetCell(2, 0)
+	assert.Len(suite.T(), ui.rows.cells, 2)
+
+	// The ticker replaces the stale cells of the active session and leaves the rest alone
+	ui.rows.cells[1][2].SetText("stale")
+	rows := ui.rows
+	ui.refreshDurations(time.Now())
+	assert.Same(suite.T(), rows, ui.rows, "no full rebuild within the interval")
+	assert.NotEqual(suite.T(), "stale", ui.sessionsTable.GetCell(1, 2).Text)
+	assert.Same(suite.T(), ended, ui.sessionsTable.GetCell(2, 0))
+	assert.Len(suite.T(), ui.rows.cells, 2)
+
+	// Once the interval passed the table is rebuilt
+	ui.rows.rebuilt = now.Add(-tableRebuildInterval)
+	ui.refreshDurations(time.Now())
+	assert.NotSame(suite.T(), rows, ui.rows)
+	assert.Empty(suite.T(), ui.rows.cells)
+}
+
+// TestTickerWorkWhenIdle tests the ticker only updates the duration of an uninterrupted
+// session within a minute and pauses while no session is active
+func (suite *UITestSuite) TestTickerWorkWhenIdle() {
+	now := time.Now()
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay:    &models.DailySessions{Date: models.DayOf(now, 0), Sessions: []*models.Session{}},
+		ticker:        time.NewTicker(time.Hour),
+		ticking:       true,
+	}
+	defer ui.ticker.Stop()
+
+	// No session is active, so the ticker pauses
+	ui.refreshTable()
+	assert.False(suite.T(), ui.ticking)
+
+	active := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Active"})
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, active)
+	ui.activeSession = active
+	ui.refreshTable()
+	assert.True(suite.T(), ui.ticking)
+
+	// Within the minute only the duration is updated
+	ui.sessionsTable.GetCell(1, 0)
+	ui.rows.cells[1][2].SetText("stale")
+	ui.rows.cells[1][4].SetText("stale")
+	ui.refreshDurations(ui.rows.now)
+	assert.NotEqual(suite.T(), "stale", ui.sessionsTable.GetCell(1, 2).Text)
+	assert.Equal(suite.T(), "stale", ui.sessionsTable.GetCell(1, 4).Text)
+
+	// During an interruption the whole row follows
+	active.Interruptions = append(active.Interruptions,
+		&models.TimeEntry{Type: models.EntryTypeInterruption, StartTime: now.Add(-10 * time.Minute)})
+	active.SubSessions[0].Interruptions = active.Interruptions
+	ui.refreshDurations(ui.rows.now)
+	assert.NotEqual(suite.T(), "stale", ui.sessionsTable.GetCell(1, 4).Text)
+	assert.Contains(suite.T(), ui.sessionsTable.GetCell(1, 3).Text, "(active)")
+
+	// Ending the session pauses the ticker again
+	active.EndAt(now)
+	ui.activeSession = nil
+	ui.refreshTable()
+	assert.False(suite.T(), ui.ticking)
+}
+
+// TestCloseOnQuit tests quitting asks about a running session and each choice leaves no
+// interruption open in the saved day
+func (suite *UITestSuite) TestCloseOnQuit() {
+	now := time.Now().Truncate(time.Second)
+	today := suite.storage.DayOf(now)
+
+	// setup returns a UI with a session started an hour ago, interrupted 10 minutes ago
+	setup := func(interrupted bool) (*TimerUI, *models.Session) {
+		session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Deploy"})
+		if interrupted {
+			call := &models.TimeEntry{Type: models.EntryTypeInterruption, StartTime: now.Add(-10 * time.Minute), Tag: models.TagCall}
+			session.Interruptions = []*models.TimeEntry{call}
+			session.SubSessions[0].Interruptions = []*models.TimeEntry{call}
+		}
+		ui := &TimerUI{
+			app:           tview.NewApplication(),
+			pages:         tview.NewPages(),
+			storage:       suite.storage,
+			statusBar:     tview.NewTextView(),
+			sessionsTable: tview.NewTable(),
+			currentDay:    &models.DailySessions{Date: today, Sessions: []*models.Session{session}},
+			activeSession: session,
+		}
+		return ui, session
+	}
+	saved := func() []*models.Session {
+		day, err := suite.storage.LoadDailySessions(today)
+		assert.NoError(suite.T(), err)
+		return day.Sessions
+	}
+
+	// Quitting with a running session asks first
+	ui, session := setup(true)
+	ui.quit()
+	assert.True(suite.T(), ui.pages.HasPage("quit"))
+
+	// Keeping it active changes nothing
+	assert.NoError(suite.T(), ui.closeOnQuit(quitKeep, now))
+	assert.Equal(suite.T(), session, ui.activeSession)
+	assert.Nil(suite.T(), session.End)
+
+	// Ending returns from the interruption and ends the session now
+	assert.NoError(suite.T(), ui.closeOnQuit(quitEnd, now))
+	assert.Nil(suite.T(), ui.activeSession)
+	if sessions := saved(); assert.Len(suite.T(), sessions, 1) {
+		assert.True(suite.T(), now.Equal(sessions[0].End.StartTime))
+		assert.Len(suite.T(), sessions[0].Interruptions, 2)
+		_, lost, count := sessions[0].GetStats()
+		assert.Equal(suite.T(), 10*time.Minute, lost)
+		assert.Equal(suite.T(), 1, count)
+	}
+
+	// Discarding an interruption ends the session when it began
+	ui, _ = setup(true)
+	assert.NoError(suite.T(), ui.closeOnQuit(quitDiscard, now))
+	assert.Nil(suite.T(), ui.activeSession)
+	if sessions := saved(); assert.Len(suite.T(), sessions, 1) {
+		assert.True(suite.T(), now.Add(-10*time.Minute).Equal(sessions[0].End.StartTime))
+		assert.Empty(suite.T(), sessions[0].Interruptions)
+		assert.Empty(suite.T(), sessions[0].SubSessions[0].Interruptions)
+	}
+
+	// Discarding an uninterrupted session deletes it
+	ui, _ = setup(false)
+	assert.NoError(suite.T(), ui.closeOnQuit(quitDiscard, now))
+	assert.Nil(suite.T(), ui.activeSession)
+	assert.Empty(suite.T(), ui.currentDay.Sessions)
+	assert.Empty(suite.T(), saved())
+}
+
+// TestToggleFocusMode tests focus mode is saved with the session and flags the
+// interruptions recorded while it is on
+func (suite *UITestSuite) TestToggleFocusMode() {
+	now := time.Now()
+	today := suite.storage.DayOf(now)
+	session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Deploy"})
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay:    &models.DailySessions{Date: today, Sessions: []*models.Session{session}},
+		activeSession: session,
+	}
+
+	ui.toggleFocusMode()
+	assert.True(suite.T(), session.FocusModeOn())
+	assert.Contains(suite.T(), ui.sessionCell(columnDescription, session, today, now).Text, "[DND]")
+
+	ui.recordInterruption(models.NewInterruptionEntry("Chat", models.TagOther))
+	assert.Contains(suite.T(), ui.statusBar.GetText(true), "despite focus mode")
+
+	ui.toggleFocusMode()
+	assert.False(suite.T(), session.FocusModeOn())
+
+	day, err := suite.storage.LoadDailySessions(today)
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), day.Sessions, 1) && assert.Len(suite.T(), day.Sessions[0].FocusWindows, 1) {
+		assert.NotNil(suite.T(), day.Sessions[0].FocusWindows[0].End)
+	}
+
+	// Nothing to turn on without an active session
+	ui.activeSession = nil
+	ui.toggleFocusMode()
+	assert.Contains(suite.T(), ui.statusBar.GetText(true), "No active session")
+}
+
+// TestSuggestedTagIndex tests the tag suggested for a description is picked among the
+// tags offered, custom tags included
+func (suite *UITestSuite) TestSuggestedTagIndex() {
+	suite.storage.GetConfig().CustomInterruptionTags = []string{"home"}
+	ui := &TimerUI{storage: suite.storage}
+	tags := ui.selectableTags()
+	assert.Equal(suite.T(), models.InterruptionTag("home"), tags[len(tags)-1])
+	assert.Equal(suite.T(), "Other (custom)", tagLabel(models.TagOther))
+
+	model := models.NewTagModel()
+	model.Learn("plumber", "home")
+	assert.Equal(suite.T(), 1, suggestedTagIndex(model, tags, "Zoom standup"))
+	assert.Equal(suite.T(), len(tags)-1, suggestedTagIndex(model, tags, "plumber"))
+	assert.Equal(suite.T(), -1, suggestedTagIndex(model, tags, "nothing known"))
+
+	// Tags that cannot be picked are not suggested
+	model.Learn("gardener", "garden")
+	assert.Equal(suite.T(), -1, suggestedTagIndex(model, tags, "gardener"))
+}
+
+// failingSaveStore is a store whose saves fail, backed by a real store for everything else
+type failingSaveStore struct {
+	storage.Store
+	saves int
+}
+
+// SaveDailySessions counts the attempt and fails
+func (f *failingSaveStore) SaveDailySessions(*models.DailySessions) error {
+	f.saves++
+	return errors.New("disk full")
+}
+
+// TestSaveErrorShown tests a failing store save is reported in the status bar
+func (suite *UITestSuite) TestSaveErrorShown() {
+	now := time.Now()
+	session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Deploy"})
+	store := &failingSaveStore{Store: suite.storage}
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       store,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay:    &models.DailySessions{Date: suite.storage.DayOf(now), Sessions: []*models.Session{session}},
+		activeSession: session,
+	}
+
+	ui.toggleFocusMode()
+	assert.Equal(suite.T(), 1, store.saves)
+	assert.Contains(suite.T(), ui.statusBar.GetText(true), "Error saving focus mode: disk full")
+}
+
+// TestReplay tests a replay starts at the first session of the day, shows interruptions as
+// the clock reaches them and stops at the last entry
+func (suite *UITestSuite) TestReplay() {
+	day := models.DayOf(time.Now(), 0).AddDate(0, 0, -1)
+	session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: day.Add(9 * time.Hour), Description: "Demo"})
+	session.SubSessions[0].Interruptions = []*models.TimeEntry{
+		{Type: models.EntryTypeInterruption, StartTime: day.Add(9*time.Hour + 10*time.Minute)},
+		{Type: models.EntryTypeReturn, StartTime: day.Add(9*time.Hour + 20*time.Minute)},
+	}
+	session.EndAt(day.Add(10 * time.Hour))
+	recorded := &models.DailySessions{Date: day, Sessions: []*models.Session{session}}
+
+	_, err := NewReplayUI(suite.storage, &models.DailySessions{Date: day}, 60)
+	assert.Error(suite.T(), err)
+
+	ui, err := NewReplayUI(suite.storage, recorded, 60)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), day.Add(9*time.Hour), ui.now())
+	assert.NotNil(suite.T(), ui.activeSession)
+
+	// 12 seconds at x60 reach the interruption
+	assert.True(suite.T(), ui.replay.advance(12*time.Second))
+	ui.showReplayFrame()
+	assert.Len(suite.T(), ui.currentDay.Sessions[0].SubSessions[0].Interruptions, 1)
+	assert.Equal(suite.T(), "00:10:00", computeSessionDuration(ui.activeSession, ui.now()))
+
+	// Paused replays stand still
+	ui.replay.paused = true
+	assert.False(suite.T(), ui.replay.advance(time.Minute))
+	ui.replay.paused = false
+
+	// The clock stops at the end of the session
+	assert.True(suite.T(), ui.replay.advance(time.Hour))
+	ui.showReplayFrame()
+	assert.Equal(suite.T(), day.Add(10*time.Hour), ui.now())
+	assert.Nil(suite.T(), ui.activeSession)
+	assert.False(suite.T(), ui.replay.advance(time.Second))
+	assert.Contains(suite.T(), ui.replay.badge(), "finished")
+
+	// Recorded data is never changed or saved
+	assert.Len(suite.T(), session.SubSessions[0].Interruptions, 2)
+	days, err := suite.storage.ListAvailableDays()
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), days)
+
+	ui.replay.setSpeed(1e6)
+	assert.Equal(suite.T(), float64(maxReplaySpeed), ui.replay.speed)
+}
+
+// TestDurationFormat tests session durations follow the configured duration format
+func (suite *UITestSuite) TestDurationFormat() {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: start})
+	now := start.Add(83 * time.Minute)
+
+	assert.Equal(suite.T(), "01:23:00", computeSessionDuration(session, now))
+
+	cfg := config.DefaultConfig()
+	cfg.DurationFormat = "decimal"
+	messages = configMessages(cfg)
+	defer func() { messages = i18n.New("en") }()
+	assert.Equal(suite.T(), "1.4h", computeSessionDuration(session, now))
+	assert.Equal(suite.T(), "1.4h", formatDurationHumanReadable(83*time.Minute))
+}
+
+// TestAccessibleMode tests accessible mode spells out states and timelines instead of
+// relying on colors, and the monochrome screen drops colors
+func (suite *UITestSuite) TestAccessibleMode() {
+	accessibleMode = true
+	defer func() { accessibleMode = false }()
+
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)
+	session := &models.Session{
+		Start: &models.TimeEntry{Type: models.EntryTypeStart, StartTime: start},
+		End:   &models.TimeEntry{Type: models.EntryTypeEnd, StartTime: start.Add(2 * time.Hour)},
+		Interruptions: []*models.TimeEntry{
+			{Type: models.EntryTypeInterruption, StartTime: start.Add(30 * time.Minute)},
+			{Type: models.EntryTypeReturn, StartTime: start.Add(40 * time.Minute)},
+		},
+	}
+	assert.Equal(suite.T(), "WWWIRWWWWWWW", sessionSparkline(session, start.Add(3*time.Hour)))
+
+	activities := []int{slotWorking, slotWorking, slotInterrupted, slotRecovery, slotWorking, slotNone}
+	assert.Equal(suite.T(), "09:00-09:20 Working\n09:20-09:30 Interrupted\n09:30-09:40 Recovery\n09:40-09:50 Working\n",
+		linearTimeline(activities, start, 10*time.Minute))
+	assert.Equal(suite.T(), "No activity\n", linearTimeline(make([]int, 6), start, 10*time.Minute))
+	assert.NotContains(suite.T(), sessionTimelineStrip(session, 10*time.Minute, start.Add(3*time.Hour)), "█")
+
+	// The header names the state of the active session
+	ui := &TimerUI{storage: suite.storage, header: tview.NewTextView(), currentDay: &models.DailySessions{Date: models.DayOf(time.Now(), 0)}}
+	ui.updateHeader(time.Now())
+	assert.Contains(suite.T(), ui.header.GetText(true), "State: No session")
+	ui.activeSession = models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: time.Now()})
+	ui.activeSession.Interruptions = []*models.TimeEntry{{Type: models.EntryTypeInterruption, StartTime: time.Now()}}
+	assert.Equal(suite.T(), "Interrupted", ui.sessionState(time.Now()))
+
+	// Highlighted cells are reversed instead of colored
+	style := monochromeStyle(tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorNavy).Bold(true))
+	foreground, background, attributes := style.Decompose()
+	assert.Equal(suite.T(), tcell.ColorDefault, foreground)
+	assert.Equal(suite.T(), tcell.ColorDefault, background)
+	assert.NotZero(suite.T(), attributes&tcell.AttrReverse)
+	assert.NotZero(suite.T(), attributes&tcell.AttrBold)
+	assert.Equal(suite.T(), monochromeStyles, themeStyles("monochrome"))
+}
+
+// TestKeymap tests configured key bindings replace the defaults and the hints and help
+// overlay follow them
+func (suite *UITestSuite) TestKeymap() {
+	keys, err := newKeymap(map[string]string{"main.start": "x", "main.end": "E, Enter"})
+	assert.Error(suite.T(), err, "Enter is already bound to the session details")
+	assert.Contains(suite.T(), err.Error(), "main.details")
+
+	keys, err = newKeymap(map[string]string{"main.start": "x", "main.details": "D, Tab"})
+	assert.Error(suite.T(), err, "d is already bound to deleting sessions")
+
+	keys, err = newKeymap(map[string]string{"main.start": "x", "main.interrupt": "Space, s", "main.mark": "k"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "x", keysLabel(keys.binding("main.start").keys))
+	assert.Equal(suite.T(), "Space, s", keysLabel(keys.binding("main.interrupt").keys))
+	assert.Equal(suite.T(), "1-9", keysLabel(keys.binding("main.sort").keys))
+	assert.Equal(suite.T(), "q", keysLabel(keys.binding("stats.quit").keys), "other bindings keep their defaults")
+	assert.Equal(suite.T(), keys.page("productivity"), keys.page("trends"))
+	assert.Nil(suite.T(), keys.page("input"))
+
+	_, err = newKeymap(map[string]string{"main.teleport": "x"})
+	assert.ErrorContains(suite.T(), err, "unknown key binding")
+	_, err = newKeymap(map[string]string{"main.start": "F13"})
+	assert.ErrorContains(suite.T(), err, "unknown key")
+
+	// Letters match regardless of case, unbound keys are left to the page
+	ui := &TimerUI{pages: tview.NewPages(), keys: keys}
+	ui.pages.AddPage("stats", tview.NewBox(), true, true)
+	assert.True(suite.T(), keys.handle(ui, "stats", tcell.NewEventKey(tcell.KeyRune, 'B', tcell.ModNone)))
+	front, _ := ui.pages.GetFrontPage()
+	assert.Equal(suite.T(), "main", front)
+	assert.False(suite.T(), keys.handle(ui, "main", tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModNone)))
+	assert.False(suite.T(), keys.handle(ui, "input", tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone)))
+
+	// Hints and the help overlay list the configured keys, the current page first
+	assert.Equal(suite.T(), "Press (x) start session, (e) end session, (Space, s) interrupt, (b) back to work, (Enter) session details, (v) statistics, (?) key bindings, (q) quit",
+		ui.statusHint("main"))
+	help := formatKeyHelp(keys, "trends")
+	assert.Less(suite.T(), strings.Index(help, "Charts"), strings.Index(help, "Sessions"))
+	assert.Contains(suite.T(), help, "  1-9          sort by column\n")
+	assert.Contains(suite.T(), help, "  k            mark session\n")
+
+	// Pages with their own actions look them up in the keymap, so rebinding changes the keys
+	// and the hints
+	keys, err = newKeymap(map[string]string{"tags.archive": "x", "quarter.previous": "PgUp"})
+	assert.NoError(suite.T(), err)
+	ui.keys = keys
+	assert.Equal(suite.T(), "archive", keys.action("tags", tcell.NewEventKey(tcell.KeyRune, 'X', tcell.ModNone)))
+	assert.Empty(suite.T(), keys.action("tags", tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone)))
+	assert.Equal(suite.T(), "previous", keys.action("quarter", tcell.NewEventKey(tcell.KeyPgUp, 0, tcell.ModNone)))
+	assert.Equal(suite.T(), "Press (x) archive/restore selected tag, (p) archive dead tags, (b, Esc) back, (?) key bindings, (q) quit",
+		ui.statusHint("tags"))
+	assert.Equal(suite.T(), "Press (PgUp) previous quarter, (Right) next quarter, (b, Esc) back to statistics, (?) key bindings, (q) quit",
+		ui.statusHint("quarter"))
+	help = formatKeyHelp(keys, "settings")
+	assert.True(suite.T(), strings.HasPrefix(help, "[yellow]Settings[white]\n  Esc          back without saving\n"))
+	for _, title := range []string{"Calendar", "Calendar day", "Records", "Quarter review", "Linked tasks", "Tags"} {
+		assert.Contains(suite.T(), help, "[yellow]"+title+"[white]\n")
+	}
+
+	// Invalid configurations fall back to the defaults
+	assert.Error(suite.T(), ui.applyKeyBindings(map[string]string{"main.start": ""}))
+	assert.Equal(suite.T(), "s", keysLabel(ui.bindings().binding("main.start").keys))
+}
+
+// TestInterruptionTimer tests the header and status bar count up open interruptions and
+// down the recovery left after returning
+func (suite *UITestSuite) TestInterruptionTimer() {
+	now := time.Now()
+	ui := &TimerUI{
+		storage:    suite.storage,
+		header:     tview.NewTextView(),
+		statusBar:  tview.NewTextView(),
+		currentDay: &models.DailySessions{Date: models.DayOf(now, 0)},
+	}
+	timer, _ := ui.interruptionTimer(now)
+	assert.Empty(suite.T(), timer)
+
+	ui.activeSession = models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour)})
+	interruption := &models.TimeEntry{Type: models.EntryTypeInterruption, StartTime: now.Add(-(4*time.Minute + 32*time.Second)), Tag: models.TagCall}
+	ui.activeSession.Interruptions = []*models.TimeEntry{interruption}
+	ui.activeSession.SubSessions[0].Interruptions = []*models.TimeEntry{interruption}
+	ui.updateHeader(now)
+	ui.updateMainStatusBar(now)
+	assert.Contains(suite.T(), ui.header.GetText(true), "Interrupted for 04:32 (Call)")
+	assert.True(suite.T(), strings.HasPrefix(ui.statusBar.GetText(true), "Interrupted for 04:32 (Call)"))
+
+	returned := &models.TimeEntry{Type: models.EntryTypeReturn, StartTime: now.Add(-3 * time.Minute)}
+	ui.activeSession.Interruptions = append(ui.activeSession.Interruptions, returned)
+	ui.activeSession.SubSessions[0].Interruptions = append(ui.activeSession.SubSessions[0].Interruptions, returned)
+	timer, color := ui.interruptionTimer(now)
+	assert.Equal(suite.T(), "Recovery, 07:00 left", timer)
+	assert.Equal(suite.T(), "yellow", color)
+
+	timer, _ = ui.interruptionTimer(now.Add(8 * time.Minute))
+	assert.Empty(suite.T(), timer)
+	assert.Equal(suite.T(), "1:02:03", formatTimer(time.Hour+2*time.Minute+3*time.Second))
+}
+
+// TestAlerts tests long interruptions and sessions running past their plan are alerted
+// about once, flashing the status bar
+func (suite *UITestSuite) TestAlerts() {
+	cfg := suite.storage.GetConfig()
+	cfg.Alerts = config.Alerts{
+		InterruptionAfter: config.Duration(10 * time.Minute),
+		SessionAfter:      config.Duration(time.Hour),
+		Tags:              map[string]config.Duration{"call": config.Duration(5 * time.Minute)},
+	}
+	defer func() { cfg.Alerts = config.Alerts{} }()
+
+	now := time.Now()
+	ui := &TimerUI{storage: suite.storage, statusBar: tview.NewTextView()}
+	ui.activeSession = models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: now.Add(-30 * time.Minute), Description: "Write docs"})
+	interruption := &models.TimeEntry{Type: models.EntryTypeInterruption, StartTime: now.Add(-6 * time.Minute), Tag: models.TagCall}
+	ui.activeSession.Interruptions = []*models.TimeEntry{interruption}
+	ui.activeSession.SubSessions[0].Interruptions = []*models.TimeEntry{interruption}
+
+	ui.checkAlerts(now)
+	assert.Same(suite.T(), interruption, ui.alertedInterruption)
+	ui.updateMainStatusBar(now)
+	assert.True(suite.T(), strings.HasPrefix(ui.statusBar.GetText(true), "Interrupted for over 5m (Call)"))
+
+	// Each alert is given once
+	ui.alertText = ""
+	ui.checkAlerts(now.Add(time.Second))
+	assert.Empty(suite.T(), ui.alertText)
+
+	// The planned length counts work time only
+	ui.checkAlerts(now.Add(time.Hour))
+	assert.Empty(suite.T(), ui.alertedSession)
+	returned := &models.TimeEntry{Type: models.EntryTypeReturn, StartTime: now}
+	ui.activeSession.Interruptions = append(ui.activeSession.Interruptions, returned)
+	ui.activeSession.SubSessions[0].Interruptions = append(ui.activeSession.SubSessions[0].Interruptions, returned)
+	ui.checkAlerts(now.Add(time.Hour))
+	assert.Equal(suite.T(), ui.activeSession.ID, ui.alertedSession)
+	assert.Contains(suite.T(), ui.alertText, `"Write docs" has run past its planned 1h 0m`)
+
+	// The flash ends after a while
+	ui.updateMainStatusBar(now.Add(time.Hour + alertFlashDuration))
+	assert.NotContains(suite.T(), ui.statusBar.GetText(true), "Write docs")
+}
+
+// TestStatsSummaryParity tests the stats page shows the same headline statistics as the
+// console report, against the snapshot both are checked with
+func (suite *UITestSuite) TestStatsSummaryParity() {
+	fixture := filepath.Join("..", "testdata", "fixtures", "sessions.json")
+	assert.NoError(suite.T(), suite.storage.ImportData(fixture, false))
+
+	source, err := stats.Load(suite.storage, "all", nil, time.Time{})
+	assert.NoError(suite.T(), err)
+	policy := suite.storage.StatsPolicy()
+	summaryConfig, err := stats.NewConfig(suite.storage.GetConfig())
+	assert.NoError(suite.T(), err)
+	text := formatStatsSummary("all time", stats.Summarize(source, policy, summaryConfig, time.Now()), policy)
+	text = regexp.MustCompile(`\[[a-z]+\]`).ReplaceAllString(text, "") // Drop the color tags
+
+	expected, err := os.ReadFile(filepath.Join("..", "testdata", "golden", "stats_summary_all.txt"))
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), text, string(expected))
+}
+
+// TestWorkScheduleTimeline tests the timeline shades idle slots outside the work schedule,
+// and lists the scheduled hours in accessible mode
+func (suite *UITestSuite) TestWorkScheduleTimeline() {
+	ui := &TimerUI{storage: suite.storage}
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	assert.NotContains(suite.T(), ui.generateDayTimelineChart(monday, nil), "Off Hours")
+
+	cfg := suite.storage.GetConfig()
+	cfg.WorkSchedule = config.WorkSchedule{Days: []string{"mon-fri"}, Start: "09:00", End: "17:00"}
+	defer func() { cfg.WorkSchedule = config.WorkSchedule{} }()
+
+	lines := strings.Split(ui.generateDayTimelineChart(monday, nil), "\n")
+	assert.Equal(suite.T(), 16*6, strings.Count(lines[3], offHoursCell))
+	assert.Contains(suite.T(), lines[5], "Off Hours")
+	saturday := strings.Split(ui.generateDayTimelineChart(monday.AddDate(0, 0, 5), nil), "\n")
+	assert.Equal(suite.T(), 24*6, strings.Count(saturday[3], offHoursCell))
+
+	accessibleMode = true
+	defer func() { accessibleMode = false }()
+	assert.Contains(suite.T(), ui.generateDayTimelineChart(monday, nil), "Scheduled hours: 09:00-17:00")
+	assert.Contains(suite.T(), ui.generateDayTimelineChart(monday.AddDate(0, 0, 5), nil), "Not a scheduled working day")
+}
+
+// TestTimelineDayStartHour tests the timeline covers the tracking day from the configured
+// day start hour, including late work after midnight
+func (suite *UITestSuite) TestTimelineDayStartHour() {
+	cfg := suite.storage.GetConfig()
+	cfg.DayStartHour = 4
+	defer func() { cfg.DayStartHour = 0 }()
+
+	ui := &TimerUI{storage: suite.storage}
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	late := day.AddDate(0, 0, 1).Add(time.Hour) // 01:00 the next calendar day
+	session := models.NewSession(&models.TimeEntry{Type: models.EntryTypeStart, StartTime: late, Description: "Late fix"})
+	session.EndAt(late.Add(25 * time.Minute))
+
+	lines := strings.Split(ui.generateDayTimelineChart(day, []*models.Session{session}), "\n")
+	assert.True(suite.T(), strings.HasPrefix(lines[2], "[blue]04[white]"), "the timeline starts at the day start hour")
+	assert.Contains(suite.T(), lines[2], "[blue]03[white]")
+	assert.Equal(suite.T(), 3, strings.Count(lines[3], timelineCell(slotWorking)))
+}
+
+// TestActivityPrompt tests the banner prompting to start a session when back at the workstation
+func (suite *UITestSuite) TestActivityPrompt() {
+	ui := &TimerUI{
+		app:       tview.NewApplication(),
+		storage:   suite.storage,
+		header:    tview.NewTextView(),
+		statusBar: tview.NewTextView(),
+		currentDay: &models.DailySessions{
+			Date:     models.DayOf(time.Now(), 0),
+			Sessions: []*models.Session{},
+		},
+	}
+	event := integrations.ActivityEvent{Reason: integrations.ActivityUnlock, Away: 95 * time.Minute, At: time.Now()}
+
+	// Nothing to prompt for while tracking
+	ui.activeSession = models.NewSession(models.NewTimeEntry(models.EntryTypeStart, "Work"))
+	ui.handleActivityEvent(event)
+	assert.False(suite.T(), ui.reminderShown)
+
+	ui.activeSession = nil
+	ui.handleActivityEvent(event)
+	assert.True(suite.T(), ui.reminderShown)
+	assert.Contains(suite.T(), ui.header.GetText(true), "Away for 1h 35m")
+
+	// Starting a session clears the banner
+	ui.clearReminderBanner()
+	assert.NotContains(suite.T(), ui.header.GetText(true), "Away for")
+}
+
+// TestMeetingMode tests recording declared meetings as interruptions while they run
+func (suite *UITestSuite) TestMeetingMode() {
+	ui := &TimerUI{
+		app:           tview.NewApplication(),
+		pages:         tview.NewPages(),
+		storage:       suite.storage,
+		statusBar:     tview.NewTextView(),
+		sessionsTable: tview.NewTable(),
+		currentDay: &models.DailySessions{
+			Date:     models.DayOf(time.Now(), 0),
+			Sessions: []*models.Session{},
+		},
+	}
+
+	// The list follows the configured language
+	messages = i18n.New("pl")
+	assert.Equal(suite.T(), "Na dziś nie zaplanowano spotkań", ui.meetingList())
+	messages = i18n.New("en")
+	assert.Equal(suite.T(), "No meetings declared for today", ui.meetingList())
+
+	assert.NoError(suite.T(), ui.addMeetings("23:00-23:30 Late call"))
+	assert.Error(suite.T(), ui.addMeetings("23:15-23:45 Overlap"))
+	assert.Contains(suite.T(), ui.meetingList(), "23:00-23:30 Late call")
+	stored, err := suite.storage.LoadDailySessions(ui.currentDay.Date)
+	if assert.NoError(suite.T(), err) {
+		assert.Len(suite.T(), stored.Meetings, 1)
+	}
+
+	// Meetings declared from the command line meanwhile are kept
+	declared, err := models.ParseMeetings("22:00-22:30 Retro", ui.currentDay.Date)
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), stored.AddMeetings(declared))
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(stored))
+	assert.ErrorContains(suite.T(), ui.addMeetings("22:15-22:45 Overlap"), "overlaps Retro")
+	assert.NoError(suite.T(), ui.addMeetings("21:00-21:30 Sync"))
+	stored, err = suite.storage.LoadDailySessions(ui.currentDay.Date)
+	if assert.NoError(suite.T(), err) {
+		assert.Len(suite.T(), stored.Meetings, 3)
+	}
+
+	start := time.Now().Add(-2 * time.Hour)
+	meeting := &models.Meeting{Title: "Standup", Start: start.Add(time.Hour), End: start.Add(90 * time.Minute)}
+	ui.currentDay.Meetings = []*models.Meeting{meeting}
+	session := models.NewSession(&models.TimeEntry{ID: "1", Type: models.EntryTypeStart, StartTime: start})
+	ui.currentDay.Sessions = append(ui.currentDay.Sessions, session)
+	ui.activeSession = session
+
+	ui.checkMeetings(start.Add(30 * time.Minute))
+	assert.False(suite.T(), ui.isInInterruptionMode())
+
+	ui.checkMeetings(start.Add(65 * time.Minute))
+	assert.True(suite.T(), ui.isInInterruptionMode())
+	assert.True(suite.T(), meeting.Recorded)
+	assert.Equal(suite.T(), models.TagMeeting, session.Interruptions[0].Tag)
+	assert.Equal(suite.T(), "Standup", session.Interruptions[0].Description)
+	assert.Equal(suite.T(), meeting.Start, session.Interruptions[0].StartTime)
+
+	ui.checkMeetings(start.Add(95 * time.Minute))
+	assert.False(suite.T(), ui.isInInterruptionMode())
+	assert.Equal(suite.T(), meeting.End, session.Interruptions[1].StartTime)
+	assert.Contains(suite.T(), ui.statusBar.GetText(true), "Standup is over")
+}
+
+// TestSaveKeepsExternalChanges tests saving from the UI keeps what commands, hotkeys and
+// the daemon saved to the day meanwhile, and the UI follows the session they end
+func (suite *UITestSuite) TestSaveKeepsExternalChanges() {
+	now := time.Now()
+	today := suite.storage.DayOf(now)
+	done := models.NewSession(&models.TimeEntry{ID: "done", Type: models.EntryTypeStart, StartTime: now.Add(-3 * time.Hour), Description: "Review"})
+	done.ID = "done_session"
+	done.EndAt(now.Add(-2 * time.Hour))
+	active := models.NewSession(&models.TimeEntry{ID: "active", Type: models.EntryTypeStart, StartTime: now.Add(-time.Hour), Description: "Write"})
+	active.ID = "active_session"
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(&models.DailySessions{Date: today, Sessions: []*models.Session{done, active}}))
+
+	ui, err := NewTimerUI(suite.storage)
+	if !assert.NoError(suite.T(), err) || !assert.NotNil(suite.T(), ui.activeSession) {
+		return
+	}
+
+	// An interruption logged by a hotkey while the UI runs
+	external, err := suite.storage.LoadDailySessions(today)
+	assert.NoError(suite.T(), err)
+	session := external.Sessions[1]
+	interruption := models.NewInterruptionEntry("Call", models.TagCall)
+	interruption.StartTime = now.Add(-30 * time.Minute)
+	back := models.NewTimeEntry(models.EntryTypeReturn, "")
+	back.StartTime = now.Add(-20 * time.Minute)
+	for _, entry := range []*models.TimeEntry{interruption, back} {
+		session.SubSessions[0].Interruptions = append(session.SubSessions[0].Interruptions, entry)
+		session.Interruptions = append(session.Interruptions, entry)
+	}
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(external))
+
+	// The UI saves an edit of another session
+	ui.currentDay.Sessions[0].Start.Description = "Review (edited)"
+	assert.NoError(suite.T(), ui.saveWithJournal(models.JournalEdit, ui.currentDay.Sessions[0], nil))
+
+	stored, err := suite.storage.LoadDailySessions(today)
+	if assert.NoError(suite.T(), err) && assert.Len(suite.T(), stored.Sessions, 2) {
+		assert.Equal(suite.T(), "Review (edited)", stored.Sessions[0].Start.Description)
+		assert.Len(suite.T(), stored.Sessions[1].Interruptions, 2)
+	}
+	assert.Len(suite.T(), ui.activeSession.Interruptions, 2)
+
+	// The session ended by a command is no longer active in the UI
+	external, err = suite.storage.LoadDailySessions(today)
+	assert.NoError(suite.T(), err)
+	external.Sessions[1].EndAt(now)
+	assert.NoError(suite.T(), suite.storage.SaveDailySessions(external))
+	assert.True(suite.T(), ui.reloadDay())
+	assert.Nil(suite.T(), ui.activeSession)
+	assert.False(suite.T(), ui.reloadDay())
+}
+
+// sessionIDs returns the IDs of the sessions in order
+func sessionIDs(sessions []*models.Session) []string {
+	ids := []string{}
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
+
 // TestUISuite runs the test suite
 func TestUISuite(t *testing.T) {
 	suite.Run(t, new(UITestSuite))
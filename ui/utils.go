@@ -2,13 +2,15 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/lukaszraczylo/interruption-tracker/models"
 )
 
 // calculateSessionDuration calculates the effective duration of a session considering interruptions
-// and recovery time. Returns a formatted string in "HH:MM:SS" format.
+// and recovery time. Returns a formatted string in the configured format, "HH:MM:SS" by default.
 func calculateSessionDuration(session *models.Session) string {
 	if session.Start == nil {
 		return ""
@@ -60,17 +62,12 @@ func calculateSessionDuration(session *models.Session) string {
 	// Effective duration is total time minus interruption time minus recovery time
 	effectiveDuration := totalDuration - interruptionDuration - recoveryDuration
 
-	// Format the duration
-	hours := int(effectiveDuration.Hours())
-	minutes := int(effectiveDuration.Minutes()) % 60
-	seconds := int(effectiveDuration.Seconds()) % 60
-
-	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+	return messages.ClockDuration(effectiveDuration)
 }
 
-// computeSessionDuration computes the effective duration of a session
+// computeSessionDuration computes the effective duration of a session as of now
 // including time spent in interruptions
-func computeSessionDuration(session *models.Session) string {
+func computeSessionDuration(session *models.Session, now time.Time) string {
 	if session.Start == nil {
 		return ""
 	}
@@ -89,7 +86,7 @@ func computeSessionDuration(session *models.Session) string {
 				subEndTime = subSession.End.StartTime
 			} else {
 				// Use current time for active sub-sessions
-				subEndTime = time.Now()
+				subEndTime = now
 			}
 
 			// Calculate total duration for this sub-session
@@ -106,7 +103,7 @@ func computeSessionDuration(session *models.Session) string {
 					interruptEnd = subSession.Interruptions[i+1].StartTime
 				} else {
 					// For active interruptions, use current time
-					interruptEnd = time.Now()
+					interruptEnd = now
 				}
 
 				subInterruptionDuration += interruptEnd.Sub(interruptStart)
@@ -117,12 +114,7 @@ func computeSessionDuration(session *models.Session) string {
 			totalEffectiveDuration += subEffectiveDuration
 		}
 
-		// Format the total duration
-		hours := int(totalEffectiveDuration.Hours())
-		minutes := int(totalEffectiveDuration.Minutes()) % 60
-		seconds := int(totalEffectiveDuration.Seconds()) % 60
-
-		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+		return messages.ClockDuration(totalEffectiveDuration)
 	} else {
 		// Legacy behavior for sessions without sub-sessions
 		var startTime time.Time = session.Start.StartTime
@@ -133,7 +125,7 @@ func computeSessionDuration(session *models.Session) string {
 			endTime = session.End.StartTime
 		} else {
 			// Use current time for active sessions
-			endTime = time.Now()
+			endTime = now
 		}
 
 		// Calculate total duration (end - start)
@@ -150,7 +142,7 @@ func computeSessionDuration(session *models.Session) string {
 				interruptEnd = session.Interruptions[i+1].StartTime
 			} else {
 				// For active interruptions, use current time
-				interruptEnd = time.Now()
+				interruptEnd = now
 			}
 
 			interruptionDuration += interruptEnd.Sub(interruptStart)
@@ -159,33 +151,31 @@ func computeSessionDuration(session *models.Session) string {
 		// Effective duration is total time minus interruption time
 		effectiveDuration := totalDuration - interruptionDuration
 
-		// Format the duration
-		hours := int(effectiveDuration.Hours())
-		minutes := int(effectiveDuration.Minutes()) % 60
-		seconds := int(effectiveDuration.Seconds()) % 60
-
-		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+		return messages.ClockDuration(effectiveDuration)
 	}
 }
 
-// formatDurationHumanReadable formats a duration in a human-readable format
+// formatDurationHumanReadable formats a duration in a human-readable format in the
+// language of the interface
 func formatDurationHumanReadable(d time.Duration) string {
-	hours := int(d.Hours())
-	minutes := int(d.Minutes()) % 60
+	return messages.Duration(d)
+}
 
-	if hours > 0 {
-		return fmt.Sprintf("%dh %dm", hours, minutes)
+// labelColumn translates the labels of a text block, returning a function giving the label
+// of a key followed by a colon and padded one past the longest label, so values line up in
+// every language
+func labelColumn(keys ...string) func(key string) string {
+	width := 0
+	for _, key := range keys {
+		if n := utf8.RuneCountInString(messages.T(key)) + 2; n > width {
+			width = n
+		}
 	}
-
-	seconds := int(d.Seconds()) % 60
-	if minutes > 0 {
-		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	return func(key string) string {
+		return fmt.Sprintf("%-*s", width, messages.T(key)+":")
 	}
-
-	return fmt.Sprintf("%ds", seconds)
 }
 
-
 // createColorGradient returns a color based on a value's position in a range
 func createColorGradient(value, min, max float64) string {
 	// Normalize to 0-1 range
@@ -219,4 +209,82 @@ func applyColorToText(text string, value, min, max float64) string {
 	return fmt.Sprintf("%s%s[-]", colorCode, text)
 }
 
+// sparklineWidth is the number of cells in the per-session sparkline of the sessions table
+const sparklineWidth = 12
+
+// sessionSparkline draws a compact work/interruption pattern of a session, splitting the
+// time from its start to its end (or now) into sparklineWidth cells. A cell is red when an
+// interruption overlaps it, yellow during recovery, dim while the session was paused and
+// green otherwise, so fragmented sessions stand out.
+func sessionSparkline(session *models.Session, now time.Time) string {
+	if session.Start == nil {
+		return ""
+	}
+
+	start := session.Start.StartTime
+	end := now
+	if session.End != nil {
+		end = session.End.StartTime
+	}
+	if !end.After(start) {
+		return ""
+	}
+	cellLength := end.Sub(start) / sparklineWidth
+	if cellLength <= 0 {
+		cellLength = 1
+	}
+
+	// Classify each cell: 0 = working, 1 = paused, 2 = recovery, 3 = interrupted
+	cells := make([]int, sparklineWidth)
+	mark := func(from, to time.Time, kind int) {
+		for i := range cells {
+			cellStart := start.Add(time.Duration(i) * cellLength)
+			cellEnd := cellStart.Add(cellLength)
+			if from.Before(cellEnd) && to.After(cellStart) && kind > cells[i] {
+				cells[i] = kind
+			}
+		}
+	}
+
+	// Gaps between the work periods of a resumed session
+	for i := 1; i < len(session.SubSessions); i++ {
+		previous, next := session.SubSessions[i-1], session.SubSessions[i]
+		if previous.End != nil && next.Start != nil {
+			mark(previous.End.StartTime, next.Start.StartTime, 1)
+		}
+	}
 
+	for i := 0; i < len(session.Interruptions); i += 2 {
+		interruptStart := session.Interruptions[i].StartTime
+		interruptEnd := end
+		if i+1 < len(session.Interruptions) {
+			interruptEnd = session.Interruptions[i+1].StartTime
+			mark(interruptEnd, interruptEnd.Add(models.AssumedRecoveryTime), 2)
+		}
+		mark(interruptStart, interruptEnd, 3)
+	}
+
+	var sb strings.Builder
+	if accessibleMode {
+		// Letters instead of colors: working, paused, recovery, interrupted
+		for _, cell := range cells {
+			sb.WriteByte("W.RI"[cell])
+		}
+		return sb.String()
+	}
+	for _, cell := range cells {
+		switch cell {
+		case 0:
+			sb.WriteString("[green]▇")
+		case 1:
+			sb.WriteString("[gray]·")
+		case 2:
+			sb.WriteString("[yellow]▃")
+		case 3:
+			sb.WriteString("[red]▁")
+		}
+	}
+	sb.WriteString("[white]")
+
+	return sb.String()
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// tableRebuildInterval is how often the ticker rebuilds the sessions table to pick up
+// changes to the order or labels; in between it only updates the cells of active sessions
+const tableRebuildInterval = 30 * time.Second
+
+// sessionRows is the content of the sessions table. It maps each row to the session it
+// shows, by ID, as of the last refresh, so actions act on the session the user sees even
+// when sessions were added, removed or re-sorted since. The cells of a row are built the
+// first time it is drawn, so rows never scrolled into view cost nothing.
+type sessionRows struct {
+	tview.TableContentReadOnly
+
+	ui        *TimerUI
+	columns   []string
+	headers   []*tview.TableCell
+	sessions  []*models.Session          // Sessions in table order, the first is row 1
+	rows      map[string]int             // Table row of each session ID
+	cells     map[int][]*tview.TableCell // Cells of the rows built so far
+	widths    []int                      // Widest text of each column among the built cells
+	maxWidths []int                      // Widths the cells are limited to, nil for none
+	today     time.Time                  // Start of the day sessions continue from
+	now       time.Time                  // Time the cells show
+	rebuilt   time.Time                  // When the table was last rebuilt
+}
+
+// newSessionRows creates the content of the sessions table for the sessions in table order
+func newSessionRows(ui *TimerUI, columns []string, sessions []*models.Session, now time.Time) *sessionRows {
+	r := &sessionRows{
+		ui:       ui,
+		columns:  columns,
+		headers:  ui.sessionHeaders(columns),
+		sessions: sessions,
+		rows:     make(map[string]int, len(sessions)),
+		cells:    make(map[int][]*tview.TableCell),
+		widths:   make([]int, len(columns)),
+		today:    ui.storage.DayOf(now),
+		now:      now,
+		rebuilt:  now,
+	}
+	for i, session := range sessions {
+		r.rows[session.ID] = i + 1
+	}
+	for i, header := range r.headers {
+		r.widths[i] = 10 // Minimum width (to accommodate padding)
+		if width := tview.TaggedStringWidth(header.Text); width > r.widths[i] {
+			r.widths[i] = width
+		}
+	}
+	return r
+}
+
+// GetCell returns a cell of the table, building the row of a session when first asked
+func (r *sessionRows) GetCell(row, column int) *tview.TableCell {
+	if column < 0 || column >= len(r.columns) || row < 0 || row > len(r.sessions) {
+		return nil
+	}
+	if row == 0 {
+		return r.headers[column]
+	}
+
+	cells, built := r.cells[row]
+	if !built {
+		cells = make([]*tview.TableCell, len(r.columns))
+		r.cells[row] = cells
+		for i, name := range r.columns {
+			r.setCell(row, i, r.ui.sessionCell(name, r.sessions[row-1], r.today, r.now))
+		}
+	}
+	return cells[column]
+}
+
+// GetRowCount returns the number of rows, the header included
+func (r *sessionRows) GetRowCount() int {
+	return len(r.sessions) + 1
+}
+
+// GetColumnCount returns the number of visible columns
+func (r *sessionRows) GetColumnCount() int {
+	return len(r.columns)
+}
+
+// setCell stores a cell of a built row, limiting its width and widening its column
+func (r *sessionRows) setCell(row, column int, cell *tview.TableCell) {
+	if column < len(r.maxWidths) {
+		cell.SetMaxWidth(r.maxWidths[column])
+	}
+	if width := tview.TaggedStringWidth(cell.Text); width > r.widths[column] {
+		r.widths[column] = width
+	}
+	r.cells[row][column] = cell
+}
+
+// update moves the table to a new time, replacing the cells of active sessions whose text
+// changed, in the given columns or all. Rows not built yet show the new time once drawn.
+func (r *sessionRows) update(now time.Time, columns ...string) {
+	r.now = now
+	for row, cells := range r.cells {
+		session := r.sessions[row-1]
+		if session.End != nil {
+			continue
+		}
+		for i, name := range r.columns {
+			if len(columns) > 0 && !containsString(columns, name) {
+				continue
+			}
+			if cell := r.ui.sessionCell(name, session, r.today, now); cell.Text != cells[i].Text {
+				r.setCell(row, i, cell)
+			}
+		}
+	}
+}
+
+// columnWidths returns the widest text of each column among the cells built so far
+func (r *sessionRows) columnWidths() []int {
+	widths := make([]int, len(r.widths))
+	copy(widths, r.widths)
+	return widths
+}
+
+// setMaxWidths limits the width of each column, for the cells built so far and later ones
+func (r *sessionRows) setMaxWidths(widths []int) {
+	r.maxWidths = widths
+	for i, width := range widths {
+		if i >= len(r.columns) {
+			break
+		}
+		r.headers[i].SetMaxWidth(width)
+		for _, cells := range r.cells {
+			cells[i].SetMaxWidth(width)
+		}
+	}
+}
+
+// id returns the ID of the session shown in the table row
+func (r *sessionRows) id(row int) (string, bool) {
+	if r == nil || row <= 0 || row > len(r.sessions) {
+		return "", false
+	}
+	return r.sessions[row-1].ID, true
+}
+
+// row returns the table row showing the session with the ID
+func (r *sessionRows) row(id string) (int, bool) {
+	if r == nil {
+		return 0, false
+	}
+	row, ok := r.rows[id]
+	return row, ok
+}
+
+// sessionByID returns the current day's session with the ID, nil when there is none
+func (ui *TimerUI) sessionByID(id string) *models.Session {
+	for _, session := range ui.currentDay.Sessions {
+		if session.ID == id {
+			return session
+		}
+	}
+	return nil
+}
+
+// selectedSession returns the session shown in the selected row of the sessions table,
+// nil when the header is selected or the session no longer exists
+func (ui *TimerUI) selectedSession() *models.Session {
+	row, _ := ui.sessionsTable.GetSelection()
+	id, ok := ui.rows.id(row)
+	if !ok {
+		return nil
+	}
+	return ui.sessionByID(id)
+}
@@ -2,33 +2,27 @@ package ui
 
 import (
 	"fmt"
-	"sort"
-	"time"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/charts"
 	"github.com/lukaszraczylo/interruption-tracker/models"
 	"github.com/rivo/tview"
 )
 
-// Chart types
-const (
-	ChartTypeBar     = "bar"
-	ChartTypeLine    = "line"
-	ChartTypeHeatmap = "heatmap"
-)
-
 // VisualizationData contains data for rendering different types of charts
-type VisualizationData struct {
-	Title       string
-	Description string
-	ChartType   string
-	Labels      []string
-	Values      []float64
-	ColorFunc   func(value float64) string // Function to determine color based on value
+type VisualizationData = charts.Data
+
+// chartRenderer returns the terminal chart backend selected in the configuration
+func (ui *TimerUI) chartRenderer() charts.Renderer {
+	if cfg := ui.storage.GetConfig(); cfg != nil && cfg.ChartBackend == charts.BackendBraille {
+		return &charts.BrailleRenderer{}
+	}
+	return &charts.TextRenderer{}
 }
 
-// renderBarChart creates a bar chart visualization
-func renderBarChart(app *tview.Application, data *VisualizationData) *tview.Flex {
+// renderChart creates a chart view drawn by the given backend
+func renderChart(renderer charts.Renderer, data *VisualizationData) *tview.Flex {
 	// Create the chart content
 	content := tview.NewTextView().
 		SetDynamicColors(true).
@@ -45,52 +39,13 @@ func renderBarChart(app *tview.Application, data *VisualizationData) *tview.Flex
 		SetText(fmt.Sprintf(" %s ", data.Description)).
 		SetTextAlign(tview.AlignCenter)
 
-	// Prepare data for chart
-	if len(data.Labels) != len(data.Values) {
-		content.SetText("Error: Data labels and values must have the same length")
-		return tview.NewFlex().SetDirection(tview.FlexRow).
-			AddItem(header, 1, 0, false).
-			AddItem(description, 1, 0, false).
-			AddItem(content, 0, 1, false)
-	}
-
-	// Find the maximum value for scaling
-	var maxValue float64
-	for _, value := range data.Values {
-		if value > maxValue {
-			maxValue = value
-		}
-	}
-
-	// Create the chart text
-	chartText := ""
-	for i, label := range data.Labels {
-		value := data.Values[i]
-
-		// Determine bar size (max 40 characters)
-		barWidth := int((value / maxValue) * 40)
-		if barWidth < 1 && value > 0 {
-			barWidth = 1 // Always show at least one character for non-zero values
-		}
-
-		// Create the bar
-		bar := ""
-		for j := 0; j < barWidth; j++ {
-			bar += "█"
-		}
-
-		// Apply color if available
-		barColor := "[blue]"
-		if data.ColorFunc != nil {
-			barColor = data.ColorFunc(value)
-		}
-
-		// Format the line with value and label
-		chartText += fmt.Sprintf("[yellow]%-15s[white] %6.1f %s%s[white]\n", label, value, barColor, bar)
+	var chartText strings.Builder
+	if err := renderer.Render(&chartText, data); err != nil {
+		content.SetText(messages.T("status.error", err))
+	} else {
+		content.SetText(chartText.String())
 	}
 
-	content.SetText(chartText)
-
 	// Create flex layout
 	chart := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(header, 1, 0, false).
@@ -100,92 +55,88 @@ func renderBarChart(app *tview.Application, data *VisualizationData) *tview.Flex
 	return chart
 }
 
-// renderHeatmap creates a productivity heatmap visualization
+// gradientColorFunc colors values from red (lowest) to green (highest) within the data range
+func gradientColorFunc(values []float64) func(value float64) string {
+	return func(value float64) string {
+		if len(values) <= 1 {
+			return "[green]"
+		}
+		// Find min and max
+		var min, max float64 = values[0], values[0]
+		for _, v := range values {
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+		return createColorGradient(value, min, max)
+	}
+}
+
 // createInterruptionsChart creates a bar chart showing interruption counts by type
-func createInterruptionsChart(app *tview.Application, stats *models.DetailedStats) *tview.Flex {
-	// Convert interruptions by tag to sorted chart data
-	var labels []string
-	var values []float64
-
-	for tag, count := range stats.InterruptionsByTag {
-		labels = append(labels, string(tag))
-		values = append(values, float64(count))
+func createInterruptionsChart(renderer charts.Renderer, stats *models.DetailedStats) *tview.Flex {
+	data := charts.InterruptionsByType(stats)
+	values := data.Values
+	data.ColorFunc = func(value float64) string {
+		// Lower values are better for interruption counts
+		return createColorGradient(value, values[0], values[len(values)-1])
 	}
 
-	// Create VisualizationData
-	data := &VisualizationData{
-		Title:       "Interruptions by Type",
-		Description: "Number of interruptions by category",
-		ChartType:   ChartTypeBar,
-		Labels:      labels,
-		Values:      values,
-		ColorFunc: func(value float64) string {
-			// Lower values are better for interruption counts
-			return createColorGradient(value, values[0], values[len(values)-1])
-		},
+	return renderChart(renderer, data)
+}
+
+// createDeflectionChart creates a chart showing the daily share of deferred interruptions
+func createDeflectionChart(renderer charts.Renderer, stats *models.DetailedStats) *tview.Flex {
+	data := charts.DeflectionRate(stats, charts.DailyChartDays)
+	data.Description = fmt.Sprintf("%s (%.0f%% of %d in range)", data.Description,
+		stats.Deflections.Rate()*100, stats.Deflections.Total())
+	data.ColorFunc = func(value float64) string {
+		// Higher deflection rates are better
+		return createColorGradient(value, 0, 100)
 	}
 
-	return renderBarChart(app, data)
+	return renderChart(renderer, data)
 }
 
 // createProductivityChart creates a bar chart showing productivity by hour of day
-func createProductivityChart(app *tview.Application, stats *models.DetailedStats) *tview.Flex {
-	// Convert hourly productivity to sorted chart data
-	type hourData struct {
-		hour  int
-		value float64
-	}
+func createProductivityChart(renderer charts.Renderer, stats *models.DetailedStats) *tview.Flex {
+	data := charts.ProductivityByHour(stats)
+	data.ColorFunc = gradientColorFunc(data.Values) // Higher values are better for productivity
 
-	hourlyValues := []hourData{}
-	for hour, duration := range stats.HourlyProductivity {
-		hourlyValues = append(hourlyValues, hourData{
-			hour:  hour,
-			value: float64(duration) / float64(time.Hour), // Convert to hours
-		})
+	return renderChart(renderer, data)
+}
+
+// createInterruptionProbabilityChart creates a bar chart showing the chance of being
+// interrupted during an hour of work by hour of day
+func createInterruptionProbabilityChart(renderer charts.Renderer, stats *models.DetailedStats) *tview.Flex {
+	data := charts.InterruptionProbabilityByHour(stats)
+	data.ColorFunc = func(value float64) string {
+		// Lower probabilities are better for deep work
+		return createColorGradient(value, 100, 0)
 	}
 
-	// Sort by hour
-	sort.Slice(hourlyValues, func(i, j int) bool {
-		return hourlyValues[i].hour < hourlyValues[j].hour
-	})
+	return renderChart(renderer, data)
+}
 
-	// Create chart data
-	var labels []string
-	var values []float64
+// createLongestFocusChart creates a line chart showing the longest uninterrupted block of
+// work by day, which total hours hide when days are fragmented
+func createLongestFocusChart(renderer charts.Renderer, stats *models.DetailedStats) *tview.Flex {
+	data := charts.LongestFocusTrend(stats, charts.DailyChartDays)
+	data.ColorFunc = gradientColorFunc(data.Values) // Longer blocks are better
 
-	for _, data := range hourlyValues {
-		hourStr := fmt.Sprintf("%d:00", data.hour)
-		labels = append(labels, hourStr)
-		values = append(values, data.value)
-	}
+	return renderChart(renderer, data)
+}
 
-	// Create VisualizationData
-	data := &VisualizationData{
-		Title:       "Productivity by Hour",
-		Description: "Hours of focused work by time of day",
-		ChartType:   ChartTypeBar,
-		Labels:      labels,
-		Values:      values,
-		ColorFunc: func(value float64) string {
-			// Higher values are better for productivity
-			if len(values) <= 1 {
-				return "[green]"
-			}
-			// Find min and max
-			var min, max float64 = values[0], values[0]
-			for _, v := range values {
-				if v < min {
-					min = v
-				}
-				if v > max {
-					max = v
-				}
-			}
-			return createColorGradient(value, min, max)
-		},
-	}
+// renderHeatmap creates an hours × days heatmap view with cells colored by intensity
+func renderHeatmap(data *VisualizationData) *tview.Flex {
+	return renderChart(&charts.HeatmapRenderer{}, data)
+}
 
-	return renderBarChart(app, data)
+// createProductivityHeatmap creates a heatmap of focused work by hour for the most recent days
+func createProductivityHeatmap(stats *models.DetailedStats) *tview.Flex {
+	return renderHeatmap(charts.ProductivityHeatmap(stats, charts.DailyChartDays))
 }
 
 // createProductivityScoreView creates a view showing the calculated productivity score
@@ -210,30 +161,27 @@ func createProductivityScoreView(app *tview.Application, stats *models.DetailedS
 	trend := stats.GetProductivityTrend()
 	trendIndicator := ""
 	if trend > 0.1 {
-		trendIndicator = " [green]↑ Improving"
+		trendIndicator = " [green]" + messages.T("charts.improving")
 	} else if trend < -0.1 {
-		trendIndicator = " [red]↓ Declining"
+		trendIndicator = " [red]" + messages.T("charts.declining")
 	} else {
-		trendIndicator = " [yellow]→ Stable"
+		trendIndicator = " [yellow]" + messages.T("charts.stable")
 	}
 
 	// Create full score text
-	fullScoreText := fmt.Sprintf("\n\n[white]Productivity Score (0-100):\n\n[::b]%s[::] %s\n\n", coloredScore, trendIndicator)
+	fullScoreText := fmt.Sprintf("\n\n[white]%s\n\n[::b]%s[::] %s\n\n", messages.T("charts.score"), coloredScore, trendIndicator)
 
 	// Add explanation of score
-	explanation := "Score based on:\n" +
-		"• Focused work time\n" +
-		"• Interruption frequency\n" +
-		"• Recovery time impact\n\n"
+	explanation := messages.T("charts.score_basis") + "\n\n"
 
 	// Add recommendations based on score
-	recommendations := "[yellow]Recommendations:[white]\n"
+	recommendations := "[yellow]" + messages.T("charts.recommendations") + "[white]\n"
 	if stats.ProductivityScore < 40 {
-		recommendations += "• Reduce interruptions\n• Consider time blocking\n• Create a do-not-disturb system"
+		recommendations += messages.T("charts.advice_low")
 	} else if stats.ProductivityScore < 70 {
-		recommendations += "• Group similar tasks\n• Schedule focused work periods\n• Manage interruption sources"
+		recommendations += messages.T("charts.advice_medium")
 	} else {
-		recommendations += "• Maintain current work patterns\n• Consider optimizing work hours\n• Share techniques with team"
+		recommendations += messages.T("charts.advice_high")
 	}
 
 	scoreView.SetText(fullScoreText + explanation + recommendations)
@@ -241,7 +189,7 @@ func createProductivityScoreView(app *tview.Application, stats *models.DetailedS
 	// Create header
 	header := tview.NewTextView().
 		SetTextColor(tcell.ColorGreen).
-		SetText(" Productivity Analysis ").
+		SetText(" " + messages.T("charts.analysis") + " ").
 		SetTextAlign(tview.AlignCenter)
 
 	// Create flex layout
@@ -252,72 +200,18 @@ func createProductivityScoreView(app *tview.Application, stats *models.DetailedS
 	return scoreContainer
 }
 
-// createDailyProductivityChart creates a chart showing daily productivity
-func createDailyProductivityChart(app *tview.Application, stats *models.DetailedStats) *tview.Flex {
-	// Convert daily work durations to chart data
-	type dayData struct {
-		date  string
-		value float64
-	}
-
-	var dailyValues []dayData
-	for dateStr, duration := range stats.DailyWorkDurations {
-		dailyValues = append(dailyValues, dayData{
-			date:  dateStr,
-			value: float64(duration) / float64(time.Hour), // Convert to hours
-		})
-	}
-
-	// Sort by date
-	sort.Slice(dailyValues, func(i, j int) bool {
-		return dailyValues[i].date < dailyValues[j].date
-	})
-
-	// Take only the last 10 days if we have more
-	if len(dailyValues) > 10 {
-		dailyValues = dailyValues[len(dailyValues)-10:]
-	}
+// createDailyProductivityChart creates a chart showing daily productivity for the most recent days
+func createDailyProductivityChart(renderer charts.Renderer, stats *models.DetailedStats) *tview.Flex {
+	data := charts.DailyProductivity(stats, charts.DailyChartDays)
+	data.ColorFunc = gradientColorFunc(data.Values) // Higher values are better for productivity
 
-	// Create chart data
-	var labels []string
-	var values []float64
-
-	for _, data := range dailyValues {
-		// Format date as day-month only
-		t, err := time.Parse("2006-01-02", data.date)
-		if err == nil {
-			labels = append(labels, t.Format("02-Jan"))
-		} else {
-			labels = append(labels, data.date)
-		}
-		values = append(values, data.value)
-	}
+	return renderChart(renderer, data)
+}
 
-	// Create VisualizationData
-	data := &VisualizationData{
-		Title:       "Daily Productivity",
-		Description: "Hours of focused work by day",
-		ChartType:   ChartTypeBar,
-		Labels:      labels,
-		Values:      values,
-		ColorFunc: func(value float64) string {
-			// Higher values are better for productivity
-			if len(values) <= 1 {
-				return "[green]"
-			}
-			// Find min and max
-			var min, max float64 = values[0], values[0]
-			for _, v := range values {
-				if v < min {
-					min = v
-				}
-				if v > max {
-					max = v
-				}
-			}
-			return createColorGradient(value, min, max)
-		},
-	}
+// createWeekdayProductivityChart creates a chart showing the average productivity of each weekday
+func createWeekdayProductivityChart(renderer charts.Renderer, stats *models.DetailedStats) *tview.Flex {
+	data := charts.ProductivityByWeekday(stats)
+	data.ColorFunc = gradientColorFunc(data.Values) // Higher values are better for productivity
 
-	return renderBarChart(app, data)
+	return renderChart(renderer, data)
 }
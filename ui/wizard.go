@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/models"
+	"github.com/rivo/tview"
+)
+
+// themeOptions are the color themes offered by the setup wizard and the settings page
+var themeOptions = []string{"system", "dark", "light", monochromeTheme}
+
+// setupAnswers holds the setup wizard fields as entered
+type setupAnswers struct {
+	DataDirectory     string
+	RecoveryMinutes   string
+	Tags              string // Comma separated custom interruption tags
+	Theme             string
+	BackupEnabled     bool
+	BackupInterval    string // Days between backups
+	EncryptionEnabled bool
+	EncryptionKey     string
+}
+
+// apply validates the answers and writes them to the configuration
+func (a setupAnswers) apply(cfg *config.Config) error {
+	dataDir := strings.TrimSpace(a.DataDirectory)
+	if dataDir == "" {
+		return fmt.Errorf("data directory is required")
+	}
+
+	recovery, err := strconv.Atoi(strings.TrimSpace(a.RecoveryMinutes))
+	if err != nil || recovery < 0 {
+		return fmt.Errorf("recovery time must be a number of minutes")
+	}
+
+	interval := cfg.BackupInterval
+	if a.BackupEnabled {
+		interval, err = strconv.Atoi(strings.TrimSpace(a.BackupInterval))
+		if err != nil || interval < 1 {
+			return fmt.Errorf("backup interval must be at least 1 day")
+		}
+	}
+
+	if a.EncryptionEnabled && a.EncryptionKey == "" {
+		return fmt.Errorf("encryption needs a passphrase to read the data again")
+	}
+
+	cfg.DataDirectory = config.ExpandHome(dataDir)
+	cfg.RecoveryTime = config.Duration(time.Duration(recovery) * time.Minute)
+	cfg.CustomInterruptionTags = parseCustomTags(a.Tags)
+	cfg.ColorTheme = a.Theme
+	cfg.BackupEnabled = a.BackupEnabled
+	cfg.BackupInterval = interval
+	cfg.EnableEncryption = a.EncryptionEnabled
+	if a.EncryptionEnabled {
+		cfg.EncryptionKey = a.EncryptionKey
+	}
+
+	return nil
+}
+
+// parseCustomTags splits comma separated tags, skipping duplicates and built-in tags
+func parseCustomTags(text string) []string {
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(text, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] || models.IsBuiltinTag(models.InterruptionTag(tag)) {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// RunSetupWizard walks through the main settings on the first run and writes the
+// configuration file. Skipping writes the defaults, so the wizard is only shown once.
+func RunSetupWizard(cfg *config.Config) error {
+	app := tview.NewApplication()
+	var saveErr error
+
+	dataDirField := tview.NewInputField().
+		SetLabel(messages.T("wizard.data_directory")).
+		SetText(cfg.DataDirectory).
+		SetFieldWidth(40)
+	recoveryField := tview.NewInputField().
+		SetLabel(messages.T("settings.recovery_time")).
+		SetText(fmt.Sprintf("%d", int(cfg.RecoveryTime.Std().Minutes()))).
+		SetFieldWidth(5).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	tagsField := tview.NewInputField().
+		SetLabel(messages.T("settings.custom_tags")).
+		SetText(strings.Join(cfg.CustomInterruptionTags, ", ")).
+		SetFieldWidth(40)
+
+	themeIndex := 0
+	for i, theme := range themeOptions {
+		if theme == cfg.ColorTheme {
+			themeIndex = i
+		}
+	}
+	themeField := tview.NewDropDown().
+		SetLabel(messages.T("settings.color_theme")).
+		SetOptions(themeOptions, nil).
+		SetCurrentOption(themeIndex)
+
+	backupField := tview.NewCheckbox().
+		SetLabel(messages.T("wizard.backups")).
+		SetChecked(cfg.BackupEnabled)
+	intervalField := tview.NewInputField().
+		SetLabel(messages.T("wizard.backup_interval")).
+		SetText(fmt.Sprintf("%d", cfg.BackupInterval)).
+		SetFieldWidth(5).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	encryptionField := tview.NewCheckbox().
+		SetLabel(messages.T("wizard.encryption")).
+		SetChecked(cfg.EnableEncryption)
+	keyField := tview.NewInputField().
+		SetLabel(messages.T("wizard.passphrase")).
+		SetFieldWidth(40).
+		SetMaskCharacter('*')
+
+	form := tview.NewForm()
+
+	save := func() {
+		_, theme := themeField.GetCurrentOption()
+		answers := setupAnswers{
+			DataDirectory:     dataDirField.GetText(),
+			RecoveryMinutes:   recoveryField.GetText(),
+			Tags:              tagsField.GetText(),
+			Theme:             theme,
+			BackupEnabled:     backupField.IsChecked(),
+			BackupInterval:    intervalField.GetText(),
+			EncryptionEnabled: encryptionField.IsChecked(),
+			EncryptionKey:     keyField.GetText(),
+		}
+		if err := answers.apply(cfg); err != nil {
+			form.SetTitle(fmt.Sprintf(" %v ", err))
+			form.SetTitleColor(tcell.ColorRed)
+			return
+		}
+		saveErr = config.SaveConfig(cfg)
+		app.Stop()
+	}
+
+	skip := func() {
+		saveErr = config.SaveConfig(cfg)
+		app.Stop()
+	}
+
+	form.
+		AddFormItem(dataDirField).
+		AddFormItem(recoveryField).
+		AddFormItem(tagsField).
+		AddFormItem(themeField).
+		AddFormItem(backupField).
+		AddFormItem(intervalField).
+		AddFormItem(encryptionField).
+		AddFormItem(keyField).
+		AddButton(messages.T("button.save"), save).
+		AddButton(messages.T("wizard.skip"), skip)
+
+	form.SetBorder(true)
+	form.SetTitle(" " + messages.T("wizard.title") + " ")
+	form.SetTitleAlign(tview.AlignCenter)
+
+	intro := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetText(messages.T("wizard.intro"))
+
+	// Center the form on the screen
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(intro, 2, 0, false).
+		AddItem(tview.NewFlex().
+			SetDirection(tview.FlexColumn).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 80, 1, true).
+			AddItem(nil, 0, 1, false),
+			21, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			skip()
+			return nil
+		}
+		return event
+	})
+
+	if err := app.SetRoot(layout, true).EnableMouse(true).Run(); err != nil {
+		return err
+	}
+	return saveErr
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/interruption-tracker/config"
+	"github.com/lukaszraczylo/interruption-tracker/storage"
+)
+
+// wipeConfigPath returns the configuration file the wipe and takeout cover
+func wipeConfigPath() string {
+	if *configFlag != "" {
+		return *configFlag
+	}
+	path, err := config.ConfigPath()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// confirmWipe asks twice before wiping: first for "yes", then for the data directory path
+// typed out in full. Any other answer aborts.
+func confirmWipe(in *bufio.Reader, out io.Writer, dataDir string) bool {
+	fmt.Fprint(out, "This cannot be undone. Type 'yes' to continue: ")
+	if answer, _ := in.ReadString('\n'); strings.TrimSpace(answer) != "yes" {
+		return false
+	}
+
+	fmt.Fprintf(out, "Type the data directory path (%s) to confirm: ", dataDir)
+	answer, _ := in.ReadString('\n')
+	return strings.TrimSpace(answer) == dataDir
+}
+
+// runWipeAll securely deletes all data after confirmation. With a takeout path the
+// takeout archive is written first and the wipe is abandoned when that fails.
+func runWipeAll(store *storage.Storage, takeoutPath, configPath string, input io.Reader, out io.Writer) error {
+	if takeoutPath != "" {
+		fmt.Fprintf(out, "Writing takeout archive to %s...\n", takeoutPath)
+		if err := store.ExportTakeout(takeoutPath, configPath); err != nil {
+			return fmt.Errorf("takeout failed, nothing was wiped: %w", err)
+		}
+	}
+
+	targets, err := store.WipeTargets(configPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "About to securely delete %d file(s): sessions, backups, journal, records, sync and\n", len(targets))
+	fmt.Fprintf(out, "import state in %s", store.GetDataDir())
+	if len(targets) > 0 && targets[len(targets)-1] == configPath {
+		fmt.Fprintf(out, ", and the configuration %s", configPath)
+	}
+	fmt.Fprintln(out, ".")
+	if takeoutPath == "" {
+		fmt.Fprintln(out, "No takeout archive was written; abort and rerun with --export-takeout=FILE to keep a copy.")
+	}
+
+	if !confirmWipe(bufio.NewReader(input), out, store.GetDataDir()) {
+		fmt.Fprintln(out, "Aborted, nothing was wiped.")
+		return nil
+	}
+
+	wiped, err := store.WipeAll(configPath)
+	if err != nil {
+		return fmt.Errorf("wipe stopped after %d file(s): %w", wiped, err)
+	}
+	fmt.Fprintf(out, "Securely deleted %d file(s).\n", wiped)
+	return nil
+}
+
+// runPurge securely deletes the data of days before the date after confirmation, printing
+// what was removed
+func runPurge(store *storage.Storage, dateText string, input io.Reader, out io.Writer) error {
+	cutoff, err := time.ParseInLocation("2006-01-02", dateText, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid date %q, use YYYY-MM-DD", dateText)
+	}
+
+	targets, err := store.PurgeTargets(cutoff)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "About to securely delete %d daily file(s) and backup(s) of days before %s,\n", len(targets), dateText)
+	fmt.Fprintln(out, "and their events in the journal.")
+
+	fmt.Fprint(out, "This cannot be undone. Type 'yes' to continue: ")
+	if answer, _ := bufio.NewReader(input).ReadString('\n'); strings.TrimSpace(answer) != "yes" {
+		fmt.Fprintln(out, "Aborted, nothing was purged.")
+		return nil
+	}
+
+	result, err := store.Purge(cutoff)
+	if err != nil {
+		return fmt.Errorf("purge stopped after %d file(s): %w", len(result.Files), err)
+	}
+	printPurge(out, result)
+	return nil
+}
+
+// printPurge lists what a purge removed
+func printPurge(out io.Writer, result *storage.PurgeResult) {
+	for _, file := range result.Files {
+		fmt.Fprintf(out, "  %s\n", file)
+	}
+	fmt.Fprintf(out, "Purged %d file(s) and %d journal event(s) of days before %s.\n",
+		len(result.Files), result.JournalEvents, result.Cutoff.Format("2006-01-02"))
+}
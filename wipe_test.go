@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunWipeAll tests the wipe only proceeds after both confirmations, writing the
+// takeout archive first
+func TestRunWipeAll(t *testing.T) {
+	store := fixtureStorage(t)
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(configPath, []byte("{}"), 0600))
+
+	// A wrong directory path aborts
+	var out bytes.Buffer
+	assert.NoError(t, runWipeAll(store, "", configPath, strings.NewReader("yes\n/tmp\n"), &out))
+	assert.Contains(t, out.String(), "Aborted")
+	assert.Contains(t, out.String(), "--export-takeout")
+	_, err := os.Stat(store.GetDataDir())
+	assert.NoError(t, err)
+
+	// A takeout inside the data directory stops the wipe before asking
+	out.Reset()
+	assert.Error(t, runWipeAll(store, filepath.Join(store.GetDataDir(), "takeout.zip"), configPath, strings.NewReader(""), &out))
+
+	out.Reset()
+	takeoutPath := filepath.Join(t.TempDir(), "takeout.zip")
+	input := strings.NewReader("yes\n" + store.GetDataDir() + "\n")
+	assert.NoError(t, runWipeAll(store, takeoutPath, configPath, input, &out))
+	assert.Contains(t, out.String(), "Securely deleted")
+
+	_, err = os.Stat(takeoutPath)
+	assert.NoError(t, err)
+	_, err = os.Stat(store.GetDataDir())
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(configPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestRunPurge tests the purge only proceeds after confirmation and keeps later days
+func TestRunPurge(t *testing.T) {
+	store := fixtureStorage(t)
+	days, err := store.ListAvailableDays()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, days)
+	cutoff := days[len(days)-1].Format("2006-01-02")
+
+	var out bytes.Buffer
+	assert.Error(t, runPurge(store, "yesterday", strings.NewReader("yes\n"), &out))
+
+	assert.NoError(t, runPurge(store, cutoff, strings.NewReader("no\n"), &out))
+	assert.Contains(t, out.String(), "Aborted")
+	remaining, err := store.ListAvailableDays()
+	assert.NoError(t, err)
+	assert.Len(t, remaining, len(days))
+
+	out.Reset()
+	assert.NoError(t, runPurge(store, cutoff, strings.NewReader("yes\n"), &out))
+	assert.Contains(t, out.String(), "Purged")
+	remaining, err = store.ListAvailableDays()
+	assert.NoError(t, err)
+	assert.Len(t, remaining, 1)
+}